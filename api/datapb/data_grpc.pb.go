@@ -19,11 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DataService_AddData_FullMethodName    = "/data.DataService/AddData"
-	DataService_GetData_FullMethodName    = "/data.DataService/GetData"
-	DataService_UpdateData_FullMethodName = "/data.DataService/UpdateData"
-	DataService_DeleteData_FullMethodName = "/data.DataService/DeleteData"
-	DataService_ListData_FullMethodName   = "/data.DataService/ListData"
+	DataService_AddData_FullMethodName        = "/data.DataService/AddData"
+	DataService_GetData_FullMethodName        = "/data.DataService/GetData"
+	DataService_UpdateData_FullMethodName     = "/data.DataService/UpdateData"
+	DataService_DeleteData_FullMethodName     = "/data.DataService/DeleteData"
+	DataService_ListData_FullMethodName       = "/data.DataService/ListData"
+	DataService_SearchData_FullMethodName     = "/data.DataService/SearchData"
+	DataService_SyncData_FullMethodName       = "/data.DataService/SyncData"
+	DataService_WatchData_FullMethodName      = "/data.DataService/WatchData"
+	DataService_ListVersions_FullMethodName   = "/data.DataService/ListVersions"
+	DataService_GetVersion_FullMethodName     = "/data.DataService/GetVersion"
+	DataService_RestoreVersion_FullMethodName = "/data.DataService/RestoreVersion"
+	DataService_ListTrash_FullMethodName      = "/data.DataService/ListTrash"
+	DataService_RestoreData_FullMethodName    = "/data.DataService/RestoreData"
+	DataService_PurgeData_FullMethodName      = "/data.DataService/PurgeData"
+	DataService_EmptyTrash_FullMethodName     = "/data.DataService/EmptyTrash"
 )
 
 // DataServiceClient is the client API for DataService service.
@@ -35,6 +45,18 @@ type DataServiceClient interface {
 	UpdateData(ctx context.Context, in *UpdateDataRequest, opts ...grpc.CallOption) (*UpdateDataResponse, error)
 	DeleteData(ctx context.Context, in *DeleteDataRequest, opts ...grpc.CallOption) (*DeleteDataResponse, error)
 	ListData(ctx context.Context, in *ListDataRequest, opts ...grpc.CallOption) (*ListDataResponse, error)
+	SearchData(ctx context.Context, in *SearchDataRequest, opts ...grpc.CallOption) (*SearchDataResponse, error)
+	SyncData(ctx context.Context, in *SyncDataRequest, opts ...grpc.CallOption) (*SyncDataResponse, error)
+	WatchData(ctx context.Context, in *WatchDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataEvent], error)
+	ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error)
+	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
+	RestoreVersion(ctx context.Context, in *RestoreVersionRequest, opts ...grpc.CallOption) (*RestoreVersionResponse, error)
+	// DeleteData переносит запись в корзину. Из корзины её можно вернуть RestoreData или удалить
+	// навсегда PurgeData и EmptyTrash. Сервер сам очищает корзину после срока хранения.
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	RestoreData(ctx context.Context, in *RestoreDataRequest, opts ...grpc.CallOption) (*RestoreDataResponse, error)
+	PurgeData(ctx context.Context, in *PurgeDataRequest, opts ...grpc.CallOption) (*PurgeDataResponse, error)
+	EmptyTrash(ctx context.Context, in *EmptyTrashRequest, opts ...grpc.CallOption) (*EmptyTrashResponse, error)
 }
 
 type dataServiceClient struct {
@@ -95,6 +117,115 @@ func (c *dataServiceClient) ListData(ctx context.Context, in *ListDataRequest, o
 	return out, nil
 }
 
+func (c *dataServiceClient) SearchData(ctx context.Context, in *SearchDataRequest, opts ...grpc.CallOption) (*SearchDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchDataResponse)
+	err := c.cc.Invoke(ctx, DataService_SearchData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceClient) SyncData(ctx context.Context, in *SyncDataRequest, opts ...grpc.CallOption) (*SyncDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SyncDataResponse)
+	err := c.cc.Invoke(ctx, DataService_SyncData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceClient) WatchData(ctx context.Context, in *WatchDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DataEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataService_ServiceDesc.Streams[0], DataService_WatchData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchDataRequest, DataEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataService_WatchDataClient = grpc.ServerStreamingClient[DataEvent]
+
+func (c *dataServiceClient) ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVersionsResponse)
+	err := c.cc.Invoke(ctx, DataService_ListVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVersionResponse)
+	err := c.cc.Invoke(ctx, DataService_GetVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceClient) RestoreVersion(ctx context.Context, in *RestoreVersionRequest, opts ...grpc.CallOption) (*RestoreVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreVersionResponse)
+	err := c.cc.Invoke(ctx, DataService_RestoreVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, DataService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceClient) RestoreData(ctx context.Context, in *RestoreDataRequest, opts ...grpc.CallOption) (*RestoreDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreDataResponse)
+	err := c.cc.Invoke(ctx, DataService_RestoreData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceClient) PurgeData(ctx context.Context, in *PurgeDataRequest, opts ...grpc.CallOption) (*PurgeDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeDataResponse)
+	err := c.cc.Invoke(ctx, DataService_PurgeData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceClient) EmptyTrash(ctx context.Context, in *EmptyTrashRequest, opts ...grpc.CallOption) (*EmptyTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyTrashResponse)
+	err := c.cc.Invoke(ctx, DataService_EmptyTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DataServiceServer is the server API for DataService service.
 // All implementations must embed UnimplementedDataServiceServer
 // for forward compatibility.
@@ -104,6 +235,18 @@ type DataServiceServer interface {
 	UpdateData(context.Context, *UpdateDataRequest) (*UpdateDataResponse, error)
 	DeleteData(context.Context, *DeleteDataRequest) (*DeleteDataResponse, error)
 	ListData(context.Context, *ListDataRequest) (*ListDataResponse, error)
+	SearchData(context.Context, *SearchDataRequest) (*SearchDataResponse, error)
+	SyncData(context.Context, *SyncDataRequest) (*SyncDataResponse, error)
+	WatchData(*WatchDataRequest, grpc.ServerStreamingServer[DataEvent]) error
+	ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error)
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
+	RestoreVersion(context.Context, *RestoreVersionRequest) (*RestoreVersionResponse, error)
+	// DeleteData переносит запись в корзину. Из корзины её можно вернуть RestoreData или удалить
+	// навсегда PurgeData и EmptyTrash. Сервер сам очищает корзину после срока хранения.
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	RestoreData(context.Context, *RestoreDataRequest) (*RestoreDataResponse, error)
+	PurgeData(context.Context, *PurgeDataRequest) (*PurgeDataResponse, error)
+	EmptyTrash(context.Context, *EmptyTrashRequest) (*EmptyTrashResponse, error)
 	mustEmbedUnimplementedDataServiceServer()
 }
 
@@ -129,6 +272,36 @@ func (UnimplementedDataServiceServer) DeleteData(context.Context, *DeleteDataReq
 func (UnimplementedDataServiceServer) ListData(context.Context, *ListDataRequest) (*ListDataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListData not implemented")
 }
+func (UnimplementedDataServiceServer) SearchData(context.Context, *SearchDataRequest) (*SearchDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchData not implemented")
+}
+func (UnimplementedDataServiceServer) SyncData(context.Context, *SyncDataRequest) (*SyncDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncData not implemented")
+}
+func (UnimplementedDataServiceServer) WatchData(*WatchDataRequest, grpc.ServerStreamingServer[DataEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchData not implemented")
+}
+func (UnimplementedDataServiceServer) ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVersions not implemented")
+}
+func (UnimplementedDataServiceServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (UnimplementedDataServiceServer) RestoreVersion(context.Context, *RestoreVersionRequest) (*RestoreVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreVersion not implemented")
+}
+func (UnimplementedDataServiceServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedDataServiceServer) RestoreData(context.Context, *RestoreDataRequest) (*RestoreDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreData not implemented")
+}
+func (UnimplementedDataServiceServer) PurgeData(context.Context, *PurgeDataRequest) (*PurgeDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeData not implemented")
+}
+func (UnimplementedDataServiceServer) EmptyTrash(context.Context, *EmptyTrashRequest) (*EmptyTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EmptyTrash not implemented")
+}
 func (UnimplementedDataServiceServer) mustEmbedUnimplementedDataServiceServer() {}
 func (UnimplementedDataServiceServer) testEmbeddedByValue()                     {}
 
@@ -240,6 +413,179 @@ func _DataService_ListData_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataService_SearchData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).SearchData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_SearchData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).SearchData(ctx, req.(*SearchDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataService_SyncData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).SyncData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_SyncData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).SyncData(ctx, req.(*SyncDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataService_WatchData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DataServiceServer).WatchData(m, &grpc.GenericServerStream[WatchDataRequest, DataEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataService_WatchDataServer = grpc.ServerStreamingServer[DataEvent]
+
+func _DataService_ListVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).ListVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_ListVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).ListVersions(ctx, req.(*ListVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_GetVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).GetVersion(ctx, req.(*GetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataService_RestoreVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).RestoreVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_RestoreVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).RestoreVersion(ctx, req.(*RestoreVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataService_RestoreData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).RestoreData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_RestoreData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).RestoreData(ctx, req.(*RestoreDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataService_PurgeData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).PurgeData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_PurgeData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).PurgeData(ctx, req.(*PurgeDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataService_EmptyTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).EmptyTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_EmptyTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).EmptyTrash(ctx, req.(*EmptyTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DataService_ServiceDesc is the grpc.ServiceDesc for DataService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +613,855 @@ var DataService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListData",
 			Handler:    _DataService_ListData_Handler,
 		},
+		{
+			MethodName: "SearchData",
+			Handler:    _DataService_SearchData_Handler,
+		},
+		{
+			MethodName: "SyncData",
+			Handler:    _DataService_SyncData_Handler,
+		},
+		{
+			MethodName: "ListVersions",
+			Handler:    _DataService_ListVersions_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _DataService_GetVersion_Handler,
+		},
+		{
+			MethodName: "RestoreVersion",
+			Handler:    _DataService_RestoreVersion_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _DataService_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreData",
+			Handler:    _DataService_RestoreData_Handler,
+		},
+		{
+			MethodName: "PurgeData",
+			Handler:    _DataService_PurgeData_Handler,
+		},
+		{
+			MethodName: "EmptyTrash",
+			Handler:    _DataService_EmptyTrash_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchData",
+			Handler:       _DataService_WatchData_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/proto/data.proto",
+}
+
+const (
+	BinaryService_StartUpload_FullMethodName    = "/data.BinaryService/StartUpload"
+	BinaryService_UploadBinary_FullMethodName   = "/data.BinaryService/UploadBinary"
+	BinaryService_DownloadBinary_FullMethodName = "/data.BinaryService/DownloadBinary"
+)
+
+// BinaryServiceClient is the client API for BinaryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BinaryServiceClient interface {
+	StartUpload(ctx context.Context, in *StartUploadRequest, opts ...grpc.CallOption) (*StartUploadResponse, error)
+	UploadBinary(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[BinaryChunk, UploadBinaryResponse], error)
+	DownloadBinary(ctx context.Context, in *DownloadBinaryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BinaryChunk], error)
+}
+
+type binaryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBinaryServiceClient(cc grpc.ClientConnInterface) BinaryServiceClient {
+	return &binaryServiceClient{cc}
+}
+
+func (c *binaryServiceClient) StartUpload(ctx context.Context, in *StartUploadRequest, opts ...grpc.CallOption) (*StartUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartUploadResponse)
+	err := c.cc.Invoke(ctx, BinaryService_StartUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *binaryServiceClient) UploadBinary(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[BinaryChunk, UploadBinaryResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BinaryService_ServiceDesc.Streams[0], BinaryService_UploadBinary_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BinaryChunk, UploadBinaryResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BinaryService_UploadBinaryClient = grpc.ClientStreamingClient[BinaryChunk, UploadBinaryResponse]
+
+func (c *binaryServiceClient) DownloadBinary(ctx context.Context, in *DownloadBinaryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BinaryChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BinaryService_ServiceDesc.Streams[1], BinaryService_DownloadBinary_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadBinaryRequest, BinaryChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BinaryService_DownloadBinaryClient = grpc.ServerStreamingClient[BinaryChunk]
+
+// BinaryServiceServer is the server API for BinaryService service.
+// All implementations must embed UnimplementedBinaryServiceServer
+// for forward compatibility.
+type BinaryServiceServer interface {
+	StartUpload(context.Context, *StartUploadRequest) (*StartUploadResponse, error)
+	UploadBinary(grpc.ClientStreamingServer[BinaryChunk, UploadBinaryResponse]) error
+	DownloadBinary(*DownloadBinaryRequest, grpc.ServerStreamingServer[BinaryChunk]) error
+	mustEmbedUnimplementedBinaryServiceServer()
+}
+
+// UnimplementedBinaryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBinaryServiceServer struct{}
+
+func (UnimplementedBinaryServiceServer) StartUpload(context.Context, *StartUploadRequest) (*StartUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartUpload not implemented")
+}
+func (UnimplementedBinaryServiceServer) UploadBinary(grpc.ClientStreamingServer[BinaryChunk, UploadBinaryResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadBinary not implemented")
+}
+func (UnimplementedBinaryServiceServer) DownloadBinary(*DownloadBinaryRequest, grpc.ServerStreamingServer[BinaryChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadBinary not implemented")
+}
+func (UnimplementedBinaryServiceServer) mustEmbedUnimplementedBinaryServiceServer() {}
+func (UnimplementedBinaryServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeBinaryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BinaryServiceServer will
+// result in compilation errors.
+type UnsafeBinaryServiceServer interface {
+	mustEmbedUnimplementedBinaryServiceServer()
+}
+
+func RegisterBinaryServiceServer(s grpc.ServiceRegistrar, srv BinaryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBinaryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BinaryService_ServiceDesc, srv)
+}
+
+func _BinaryService_StartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinaryServiceServer).StartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BinaryService_StartUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinaryServiceServer).StartUpload(ctx, req.(*StartUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BinaryService_UploadBinary_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BinaryServiceServer).UploadBinary(&grpc.GenericServerStream[BinaryChunk, UploadBinaryResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BinaryService_UploadBinaryServer = grpc.ClientStreamingServer[BinaryChunk, UploadBinaryResponse]
+
+func _BinaryService_DownloadBinary_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadBinaryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BinaryServiceServer).DownloadBinary(m, &grpc.GenericServerStream[DownloadBinaryRequest, BinaryChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BinaryService_DownloadBinaryServer = grpc.ServerStreamingServer[BinaryChunk]
+
+// BinaryService_ServiceDesc is the grpc.ServiceDesc for BinaryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BinaryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "data.BinaryService",
+	HandlerType: (*BinaryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartUpload",
+			Handler:    _BinaryService_StartUpload_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadBinary",
+			Handler:       _BinaryService_UploadBinary_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadBinary",
+			Handler:       _BinaryService_DownloadBinary_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/proto/data.proto",
+}
+
+const (
+	OrganizerService_CreateFolder_FullMethodName = "/data.OrganizerService/CreateFolder"
+	OrganizerService_UpdateFolder_FullMethodName = "/data.OrganizerService/UpdateFolder"
+	OrganizerService_DeleteFolder_FullMethodName = "/data.OrganizerService/DeleteFolder"
+	OrganizerService_ListFolders_FullMethodName  = "/data.OrganizerService/ListFolders"
+	OrganizerService_CreateTag_FullMethodName    = "/data.OrganizerService/CreateTag"
+	OrganizerService_DeleteTag_FullMethodName    = "/data.OrganizerService/DeleteTag"
+	OrganizerService_ListTags_FullMethodName     = "/data.OrganizerService/ListTags"
+	OrganizerService_TagData_FullMethodName      = "/data.OrganizerService/TagData"
+	OrganizerService_UntagData_FullMethodName    = "/data.OrganizerService/UntagData"
+	OrganizerService_MoveData_FullMethodName     = "/data.OrganizerService/MoveData"
+)
+
+// OrganizerServiceClient is the client API for OrganizerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OrganizerService - папки и теги записей. Имена папок и тегов хранятся зашифрованными, как meta.
+type OrganizerServiceClient interface {
+	CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*CreateFolderResponse, error)
+	UpdateFolder(ctx context.Context, in *UpdateFolderRequest, opts ...grpc.CallOption) (*UpdateFolderResponse, error)
+	DeleteFolder(ctx context.Context, in *DeleteFolderRequest, opts ...grpc.CallOption) (*DeleteFolderResponse, error)
+	ListFolders(ctx context.Context, in *ListFoldersRequest, opts ...grpc.CallOption) (*ListFoldersResponse, error)
+	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error)
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	TagData(ctx context.Context, in *TagDataRequest, opts ...grpc.CallOption) (*TagDataResponse, error)
+	UntagData(ctx context.Context, in *UntagDataRequest, opts ...grpc.CallOption) (*UntagDataResponse, error)
+	MoveData(ctx context.Context, in *MoveDataRequest, opts ...grpc.CallOption) (*MoveDataResponse, error)
+}
+
+type organizerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrganizerServiceClient(cc grpc.ClientConnInterface) OrganizerServiceClient {
+	return &organizerServiceClient{cc}
+}
+
+func (c *organizerServiceClient) CreateFolder(ctx context.Context, in *CreateFolderRequest, opts ...grpc.CallOption) (*CreateFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFolderResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_CreateFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) UpdateFolder(ctx context.Context, in *UpdateFolderRequest, opts ...grpc.CallOption) (*UpdateFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateFolderResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_UpdateFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) DeleteFolder(ctx context.Context, in *DeleteFolderRequest, opts ...grpc.CallOption) (*DeleteFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFolderResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_DeleteFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) ListFolders(ctx context.Context, in *ListFoldersRequest, opts ...grpc.CallOption) (*ListFoldersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFoldersResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_ListFolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*CreateTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTagResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_CreateTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*DeleteTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTagResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_DeleteTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) TagData(ctx context.Context, in *TagDataRequest, opts ...grpc.CallOption) (*TagDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagDataResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_TagData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) UntagData(ctx context.Context, in *UntagDataRequest, opts ...grpc.CallOption) (*UntagDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UntagDataResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_UntagData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizerServiceClient) MoveData(ctx context.Context, in *MoveDataRequest, opts ...grpc.CallOption) (*MoveDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveDataResponse)
+	err := c.cc.Invoke(ctx, OrganizerService_MoveData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrganizerServiceServer is the server API for OrganizerService service.
+// All implementations must embed UnimplementedOrganizerServiceServer
+// for forward compatibility.
+//
+// OrganizerService - папки и теги записей. Имена папок и тегов хранятся зашифрованными, как meta.
+type OrganizerServiceServer interface {
+	CreateFolder(context.Context, *CreateFolderRequest) (*CreateFolderResponse, error)
+	UpdateFolder(context.Context, *UpdateFolderRequest) (*UpdateFolderResponse, error)
+	DeleteFolder(context.Context, *DeleteFolderRequest) (*DeleteFolderResponse, error)
+	ListFolders(context.Context, *ListFoldersRequest) (*ListFoldersResponse, error)
+	CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error)
+	DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error)
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	TagData(context.Context, *TagDataRequest) (*TagDataResponse, error)
+	UntagData(context.Context, *UntagDataRequest) (*UntagDataResponse, error)
+	MoveData(context.Context, *MoveDataRequest) (*MoveDataResponse, error)
+	mustEmbedUnimplementedOrganizerServiceServer()
+}
+
+// UnimplementedOrganizerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOrganizerServiceServer struct{}
+
+func (UnimplementedOrganizerServiceServer) CreateFolder(context.Context, *CreateFolderRequest) (*CreateFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFolder not implemented")
+}
+func (UnimplementedOrganizerServiceServer) UpdateFolder(context.Context, *UpdateFolderRequest) (*UpdateFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFolder not implemented")
+}
+func (UnimplementedOrganizerServiceServer) DeleteFolder(context.Context, *DeleteFolderRequest) (*DeleteFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFolder not implemented")
+}
+func (UnimplementedOrganizerServiceServer) ListFolders(context.Context, *ListFoldersRequest) (*ListFoldersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFolders not implemented")
+}
+func (UnimplementedOrganizerServiceServer) CreateTag(context.Context, *CreateTagRequest) (*CreateTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTag not implemented")
+}
+func (UnimplementedOrganizerServiceServer) DeleteTag(context.Context, *DeleteTagRequest) (*DeleteTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
+}
+func (UnimplementedOrganizerServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedOrganizerServiceServer) TagData(context.Context, *TagDataRequest) (*TagDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TagData not implemented")
+}
+func (UnimplementedOrganizerServiceServer) UntagData(context.Context, *UntagDataRequest) (*UntagDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UntagData not implemented")
+}
+func (UnimplementedOrganizerServiceServer) MoveData(context.Context, *MoveDataRequest) (*MoveDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveData not implemented")
+}
+func (UnimplementedOrganizerServiceServer) mustEmbedUnimplementedOrganizerServiceServer() {}
+func (UnimplementedOrganizerServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeOrganizerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrganizerServiceServer will
+// result in compilation errors.
+type UnsafeOrganizerServiceServer interface {
+	mustEmbedUnimplementedOrganizerServiceServer()
+}
+
+func RegisterOrganizerServiceServer(s grpc.ServiceRegistrar, srv OrganizerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedOrganizerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OrganizerService_ServiceDesc, srv)
+}
+
+func _OrganizerService_CreateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).CreateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_CreateFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).CreateFolder(ctx, req.(*CreateFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_UpdateFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).UpdateFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_UpdateFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).UpdateFolder(ctx, req.(*UpdateFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_DeleteFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).DeleteFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_DeleteFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).DeleteFolder(ctx, req.(*DeleteFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_ListFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFoldersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).ListFolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_ListFolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).ListFolders(ctx, req.(*ListFoldersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_CreateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).CreateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_CreateTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).CreateTag(ctx, req.(*CreateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_DeleteTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_TagData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).TagData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_TagData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).TagData(ctx, req.(*TagDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_UntagData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UntagDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).UntagData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_UntagData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).UntagData(ctx, req.(*UntagDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrganizerService_MoveData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizerServiceServer).MoveData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrganizerService_MoveData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizerServiceServer).MoveData(ctx, req.(*MoveDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrganizerService_ServiceDesc is the grpc.ServiceDesc for OrganizerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrganizerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "data.OrganizerService",
+	HandlerType: (*OrganizerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateFolder",
+			Handler:    _OrganizerService_CreateFolder_Handler,
+		},
+		{
+			MethodName: "UpdateFolder",
+			Handler:    _OrganizerService_UpdateFolder_Handler,
+		},
+		{
+			MethodName: "DeleteFolder",
+			Handler:    _OrganizerService_DeleteFolder_Handler,
+		},
+		{
+			MethodName: "ListFolders",
+			Handler:    _OrganizerService_ListFolders_Handler,
+		},
+		{
+			MethodName: "CreateTag",
+			Handler:    _OrganizerService_CreateTag_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _OrganizerService_DeleteTag_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _OrganizerService_ListTags_Handler,
+		},
+		{
+			MethodName: "TagData",
+			Handler:    _OrganizerService_TagData_Handler,
+		},
+		{
+			MethodName: "UntagData",
+			Handler:    _OrganizerService_UntagData_Handler,
+		},
+		{
+			MethodName: "MoveData",
+			Handler:    _OrganizerService_MoveData_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/proto/data.proto",
+}
+
+const (
+	RecordTypeService_CreateRecordType_FullMethodName = "/data.RecordTypeService/CreateRecordType"
+	RecordTypeService_ListRecordTypes_FullMethodName  = "/data.RecordTypeService/ListRecordTypes"
+	RecordTypeService_DeleteRecordType_FullMethodName = "/data.RecordTypeService/DeleteRecordType"
+)
+
+// RecordTypeServiceClient is the client API for RecordTypeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RecordTypeService - пользовательские типы записей. Сервер проверяет по схеме типа содержимое записей,
+// пришедшее без сквозного шифрования. Тип, у которого есть записи, в том числе в корзине, удалить нельзя.
+type RecordTypeServiceClient interface {
+	CreateRecordType(ctx context.Context, in *CreateRecordTypeRequest, opts ...grpc.CallOption) (*CreateRecordTypeResponse, error)
+	ListRecordTypes(ctx context.Context, in *ListRecordTypesRequest, opts ...grpc.CallOption) (*ListRecordTypesResponse, error)
+	DeleteRecordType(ctx context.Context, in *DeleteRecordTypeRequest, opts ...grpc.CallOption) (*DeleteRecordTypeResponse, error)
+}
+
+type recordTypeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRecordTypeServiceClient(cc grpc.ClientConnInterface) RecordTypeServiceClient {
+	return &recordTypeServiceClient{cc}
+}
+
+func (c *recordTypeServiceClient) CreateRecordType(ctx context.Context, in *CreateRecordTypeRequest, opts ...grpc.CallOption) (*CreateRecordTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateRecordTypeResponse)
+	err := c.cc.Invoke(ctx, RecordTypeService_CreateRecordType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordTypeServiceClient) ListRecordTypes(ctx context.Context, in *ListRecordTypesRequest, opts ...grpc.CallOption) (*ListRecordTypesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRecordTypesResponse)
+	err := c.cc.Invoke(ctx, RecordTypeService_ListRecordTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordTypeServiceClient) DeleteRecordType(ctx context.Context, in *DeleteRecordTypeRequest, opts ...grpc.CallOption) (*DeleteRecordTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRecordTypeResponse)
+	err := c.cc.Invoke(ctx, RecordTypeService_DeleteRecordType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecordTypeServiceServer is the server API for RecordTypeService service.
+// All implementations must embed UnimplementedRecordTypeServiceServer
+// for forward compatibility.
+//
+// RecordTypeService - пользовательские типы записей. Сервер проверяет по схеме типа содержимое записей,
+// пришедшее без сквозного шифрования. Тип, у которого есть записи, в том числе в корзине, удалить нельзя.
+type RecordTypeServiceServer interface {
+	CreateRecordType(context.Context, *CreateRecordTypeRequest) (*CreateRecordTypeResponse, error)
+	ListRecordTypes(context.Context, *ListRecordTypesRequest) (*ListRecordTypesResponse, error)
+	DeleteRecordType(context.Context, *DeleteRecordTypeRequest) (*DeleteRecordTypeResponse, error)
+	mustEmbedUnimplementedRecordTypeServiceServer()
+}
+
+// UnimplementedRecordTypeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRecordTypeServiceServer struct{}
+
+func (UnimplementedRecordTypeServiceServer) CreateRecordType(context.Context, *CreateRecordTypeRequest) (*CreateRecordTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRecordType not implemented")
+}
+func (UnimplementedRecordTypeServiceServer) ListRecordTypes(context.Context, *ListRecordTypesRequest) (*ListRecordTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecordTypes not implemented")
+}
+func (UnimplementedRecordTypeServiceServer) DeleteRecordType(context.Context, *DeleteRecordTypeRequest) (*DeleteRecordTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRecordType not implemented")
+}
+func (UnimplementedRecordTypeServiceServer) mustEmbedUnimplementedRecordTypeServiceServer() {}
+func (UnimplementedRecordTypeServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeRecordTypeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RecordTypeServiceServer will
+// result in compilation errors.
+type UnsafeRecordTypeServiceServer interface {
+	mustEmbedUnimplementedRecordTypeServiceServer()
+}
+
+func RegisterRecordTypeServiceServer(s grpc.ServiceRegistrar, srv RecordTypeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRecordTypeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RecordTypeService_ServiceDesc, srv)
+}
+
+func _RecordTypeService_CreateRecordType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRecordTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordTypeServiceServer).CreateRecordType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecordTypeService_CreateRecordType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordTypeServiceServer).CreateRecordType(ctx, req.(*CreateRecordTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordTypeService_ListRecordTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecordTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordTypeServiceServer).ListRecordTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecordTypeService_ListRecordTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordTypeServiceServer).ListRecordTypes(ctx, req.(*ListRecordTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordTypeService_DeleteRecordType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRecordTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordTypeServiceServer).DeleteRecordType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecordTypeService_DeleteRecordType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordTypeServiceServer).DeleteRecordType(ctx, req.(*DeleteRecordTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RecordTypeService_ServiceDesc is the grpc.ServiceDesc for RecordTypeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RecordTypeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "data.RecordTypeService",
+	HandlerType: (*RecordTypeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRecordType",
+			Handler:    _RecordTypeService_CreateRecordType_Handler,
+		},
+		{
+			MethodName: "ListRecordTypes",
+			Handler:    _RecordTypeService_ListRecordTypes_Handler,
+		},
+		{
+			MethodName: "DeleteRecordType",
+			Handler:    _RecordTypeService_DeleteRecordType_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/data.proto",
@@ -1,16 +1,23 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/NikolosHGW/goph-keeper/internal/client/command"
 	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
 	"github.com/NikolosHGW/goph-keeper/internal/client/infrastructure/config"
+	"github.com/NikolosHGW/goph-keeper/internal/client/infrastructure/localstore"
+	"github.com/NikolosHGW/goph-keeper/internal/client/infrastructure/sessionstore"
+	"github.com/NikolosHGW/goph-keeper/internal/client/output"
 	"github.com/NikolosHGW/goph-keeper/internal/client/service"
 	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"google.golang.org/grpc"
 )
 
 const defaultTagValue = "N/A"
@@ -28,31 +35,110 @@ func main() {
 		log.Fatalf("ошибка инициализации логгер: %v", err)
 	}
 
-	grpcClient, err := service.NewGRPCClient(config.GetServerAddress(), myLogger, config.GetRootCertPath())
+	formatter, err := output.New(config.GetOutputFormat())
+	if err != nil {
+		log.Fatalf("ошибка формата вывода: %v", err)
+	}
+
+	tokenHolder := &entity.TokenHolder{}
+
+	sessions, err := sessionstore.Open(config.GetConfigDir())
+	if err != nil {
+		myLogger.LogInfo("Ошибка открытия каталога конфигурации", err)
+		os.Exit(1)
+	}
+	restoreSession(sessions, tokenHolder, myLogger)
+	saveSession := func() {
+		if err := sessions.Save(tokenHolder); err != nil {
+			myLogger.LogInfo("Ошибка сохранения сессии", err)
+		}
+	}
+
+	cache, err := localstore.Open(config.GetCacheDir())
+	if err != nil {
+		myLogger.LogInfo("Ошибка открытия локального кеша", err)
+		os.Exit(1)
+	}
+
+	grpcClient, err := service.NewGRPCClient(
+		config.GetServerAddress(),
+		myLogger,
+		config.GetRootCertPath(),
+		grpc.WithChainUnaryInterceptor(service.NewTokenRefresher(tokenHolder).OnRefresh(saveSession).Unary()),
+	)
 	if err != nil {
 		myLogger.LogInfo("Ошибка инициализации gRPC клиента", err)
 		os.Exit(1)
 	}
-	defer func() {
+	closeClient := func() {
 		err := grpcClient.Close()
 		if err != nil {
 			myLogger.LogInfo("не удалось закрыть соединение клиента gRPC", err)
 		}
-	}()
-
-	tokenHolder := &entity.TokenHolder{}
+	}
+	defer closeClient()
 
 	authService := service.NewAuthService(grpcClient, myLogger)
-	dataService := service.NewDataService(grpcClient, myLogger)
+	vault := service.NewVault()
+	remoteDataService := service.NewDataService(grpcClient, myLogger)
+	dataService := service.NewE2EDataService(
+		service.NewOfflineDataService(remoteDataService, cache, tokenHolder),
+		tokenHolder,
+	)
+	syncService := service.NewSyncService(remoteDataService, cache, tokenHolder)
+	binaryService := service.NewBinaryService(grpcClient, tokenHolder)
+	organizerService := service.NewOrganizerService(grpcClient, tokenHolder)
+	historyService := service.NewHistoryService(grpcClient, tokenHolder)
+	trashService := service.NewTrashService(grpcClient, tokenHolder)
+	recordTypeService := service.NewRecordTypeService(grpcClient)
+
+	// В режиме одной команды stdin запоминается: после повторного входа команда повторяется с тем же вводом.
+	args := config.GetArgs()
+	var stdin io.Reader = os.Stdin
+	replayStdin := command.NewReplayReader(os.Stdin)
+	if len(args) > 0 {
+		stdin = replayStdin
+	}
+
+	loginCommand := command.NewLoginCommand(authService, vault, tokenHolder, stdin, os.Stdout)
 
 	commands := []command.Command{
-		command.NewRegisterCommand(authService, tokenHolder, os.Stdin, os.Stdout),
-		command.NewLoginCommand(authService, tokenHolder, os.Stdin, os.Stdout),
-		command.NewAddCommand(dataService, tokenHolder, os.Stdin, os.Stdout),
-		command.NewGetCommand(dataService, tokenHolder, os.Stdin, os.Stdout),
-		command.NewUpdateCommand(dataService, tokenHolder, os.Stdin, os.Stdout),
-		command.NewDeleteCommand(dataService, tokenHolder, os.Stdin, os.Stdout),
-		command.NewListCommand(dataService, tokenHolder, os.Stdin, os.Stdout),
+		command.NewRegisterCommand(authService, vault, tokenHolder, stdin, os.Stdout),
+		loginCommand,
+		command.NewLogoutCommand(authService, tokenHolder, os.Stdout),
+		command.NewWhoamiCommand(tokenHolder, os.Stdout),
+		command.NewSessionsCommand(authService, tokenHolder, stdin, os.Stdout),
+		command.NewTwoFactorCommand(authService, tokenHolder, stdin, os.Stdout),
+		command.NewAddCommand(dataService, binaryService, recordTypeService, tokenHolder, stdin, os.Stdout),
+		command.NewGetCommand(dataService, binaryService, recordTypeService, formatter, tokenHolder, stdin, os.Stdout),
+		command.NewUpdateCommand(dataService, binaryService, recordTypeService, tokenHolder, stdin, os.Stdout),
+		command.NewDeleteCommand(dataService, tokenHolder, stdin, os.Stdout),
+		command.NewOTPCommand(dataService, tokenHolder, stdin, os.Stdout),
+		command.NewTrashCommand(trashService, tokenHolder, os.Stdout),
+		command.NewTypesCommand(recordTypeService, tokenHolder, os.Stdout),
+		command.NewListCommand(dataService, organizerService, formatter, tokenHolder, stdin, os.Stdout),
+		command.NewSearchCommand(dataService, formatter, tokenHolder, stdin, os.Stdout),
+		command.NewTagCommand(organizerService, tokenHolder, stdin, os.Stdout),
+		command.NewUntagCommand(organizerService, tokenHolder, stdin, os.Stdout),
+		command.NewMoveCommand(organizerService, tokenHolder, stdin, os.Stdout),
+		command.NewTreeCommand(dataService, organizerService, tokenHolder, os.Stdout),
+		command.NewHistoryCommand(historyService, formatter, tokenHolder, stdin, os.Stdout),
+		command.NewRestoreCommand(historyService, tokenHolder, stdin, os.Stdout),
+		command.NewSyncCommand(syncService, tokenHolder, os.Stdout),
+		command.NewWatchCommand(remoteDataService, syncService, tokenHolder, stdin, os.Stdout),
+	}
+
+	if len(args) > 0 {
+		cli := command.NewCLI(commands, os.Stderr)
+		code := cli.RunSignedIn(loginCommand, replayStdin, args, command.Credentials{
+			Login:          config.GetLogin(),
+			Password:       config.GetPassword(),
+			Code:           config.GetLoginCode(),
+			MasterPassword: config.GetMasterPassword(),
+		})
+		saveSession()
+		closeClient()
+		os.Exit(code)
 	}
 
 	commandNames := make([]string, len(commands))
@@ -69,6 +155,14 @@ func main() {
 	)
 
 	fmt.Println("Доступные команды: ", strings.Join(commandNames, ", "))
+	if tokenHolder.Token != "" {
+		fmt.Println("Сессия восстановлена:", tokenHolder.Login)
+	}
+	if tokenHolder.VaultLocked() {
+		if err := loginCommand.UnlockInteractive(); err != nil {
+			myLogger.LogInfo("Хранилище не разблокировано", err)
+		}
+	}
 	for {
 		fmt.Print("Введите команду: ")
 		var input string
@@ -87,5 +181,33 @@ func main() {
 		if err != nil {
 			myLogger.LogInfo("Ошибка вызова команды", err)
 		}
+		if command.ExitCode(err) == command.ExitUnauthenticated && cmd != loginCommand {
+			fmt.Println("Требуется вход в систему.")
+			if err := loginCommand.Execute(); err != nil {
+				myLogger.LogInfo("Ошибка входа", err)
+			} else {
+				fmt.Println("Повторите команду.")
+			}
+		}
+		saveSession()
+	}
+}
+
+// restoreSession восстанавливает сессию прошлого запуска. Сессия без refresh токена
+// с истёкшим access токеном бесполезна и удаляется.
+func restoreSession(sessions *sessionstore.Store, tokenHolder *entity.TokenHolder, myLogger logger.CustomLogger) {
+	err := sessions.Load(tokenHolder)
+	if err != nil {
+		if !errors.Is(err, sessionstore.ErrNoSession) {
+			myLogger.LogInfo("Ошибка восстановления сессии", err)
+		}
+		return
+	}
+
+	if tokenHolder.Expired(time.Now()) && tokenHolder.RefreshToken == "" {
+		*tokenHolder = entity.TokenHolder{}
+		if err := sessions.Clear(); err != nil {
+			myLogger.LogInfo("Ошибка удаления истёкшей сессии", err)
+		}
 	}
 }
@@ -0,0 +1,41 @@
+// Package keyring собирает мастер-ключи шифрования по конфигурации для сервера и утилиты ротации ключей.
+package keyring
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/config"
+	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/keyprovider"
+	"github.com/NikolosHGW/goph-keeper/internal/server/service"
+)
+
+type keyringConfig interface {
+	GetKeyProvider() string
+	GetCryptoKey() string
+	GetCryptoKeyID() string
+	GetCryptoOldKeyIDs() ([]string, error)
+	GetCryptoKeyDir() string
+	GetKMSRootKeyPath() string
+	IsDevMode() bool
+}
+
+// Load загружает из источника, выбранного в конфигурации, активный мастер-ключ и его предыдущие версии.
+func Load(ctx context.Context, cfg keyringConfig) (*service.Keyring, error) {
+	oldKeyIDs, err := cfg.GetCryptoOldKeyIDs()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось разобрать идентификаторы старых ключей шифрования: %w", err)
+	}
+
+	provider, err := keyprovider.FromConfig(cfg, config.DefaultCryptoKey)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось инициализировать источник ключей шифрования: %w", err)
+	}
+
+	keyring, err := service.LoadKeyring(ctx, provider, cfg.GetCryptoKeyID(), oldKeyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось инициализировать мастер-ключи: %w", err)
+	}
+
+	return keyring, nil
+}
@@ -0,0 +1,44 @@
+package keyring
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/config"
+	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/keyprovider"
+	"github.com/stretchr/testify/assert"
+)
+
+type configStub struct {
+	devMode   bool
+	oldKeyIDs []string
+	oldErr    error
+}
+
+func (c configStub) GetKeyProvider() string                { return keyprovider.ProviderFlag }
+func (c configStub) GetCryptoKey() string                  { return config.DefaultCryptoKey }
+func (c configStub) GetCryptoKeyID() string                { return "1" }
+func (c configStub) GetCryptoOldKeyIDs() ([]string, error) { return c.oldKeyIDs, c.oldErr }
+func (c configStub) GetCryptoKeyDir() string               { return "" }
+func (c configStub) GetKMSRootKeyPath() string             { return "" }
+func (c configStub) IsDevMode() bool                       { return c.devMode }
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+
+	keyring, err := Load(ctx, configStub{devMode: true})
+	assert.NoError(t, err)
+	if assert.NotNil(t, keyring) {
+		assert.Equal(t, "1", keyring.ActiveID())
+	}
+
+	_, err = Load(ctx, configStub{})
+	assert.ErrorIs(t, err, keyprovider.ErrDefaultKey)
+
+	_, err = Load(ctx, configStub{devMode: true, oldKeyIDs: []string{"0"}})
+	assert.Error(t, err, "старого ключа нет в источнике")
+
+	_, err = Load(ctx, configStub{devMode: true, oldErr: errors.New("пустой идентификатор")})
+	assert.Error(t, err)
+}
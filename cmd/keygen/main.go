@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/keyprovider"
+)
+
+const (
+	keyLength         = 32
+	rsaKeyBits        = 3072
+	keyFilePerm       = 0o600
+	publicKeyFilePerm = 0o644
+	keyDirPerm        = 0o700
+	formatHex         = "hex"
+	formatBase64      = "base64"
+	typeAES           = "aes"
+	typeEd25519       = "ed25519"
+	typeRSA           = "rsa"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(fmt.Errorf("не удалось сгенерировать ключ: %w", err))
+	}
+}
+
+func run() error {
+	dir := flag.String("dir", "./keys", "directory for key files")
+	keyID := flag.String("id", "1", "key version id")
+	keyType := flag.String("type", typeAES, "key type: aes for master keys, ed25519 or rsa for token signing keys")
+	format := flag.String("format", formatHex, "key file encoding: hex or base64")
+	kmsRootKey := flag.String("kms-root-key", "",
+		"path to local KMS root key; when set, <id>.enc is written instead of <id>.key")
+	initRoot := flag.Bool("init-kms-root", false, "generate the local KMS root key file at -kms-root-key")
+	flag.Parse()
+
+	if *keyID == "" || *keyID != filepath.Base(*keyID) {
+		return fmt.Errorf("недопустимый идентификатор ключа %q", *keyID)
+	}
+
+	if err := os.MkdirAll(*dir, keyDirPerm); err != nil {
+		return fmt.Errorf("не удалось создать каталог ключей: %w", err)
+	}
+
+	if *keyType != typeAES {
+		return writeSigningKey(*dir, *keyID, *keyType)
+	}
+
+	if *initRoot {
+		if *kmsRootKey == "" {
+			return errors.New("для -init-kms-root нужен -kms-root-key")
+		}
+		if err := writeNewKey(*kmsRootKey, *format); err != nil {
+			return err
+		}
+	}
+
+	key, err := newKey()
+	if err != nil {
+		return err
+	}
+
+	if *kmsRootKey == "" {
+		path := filepath.Join(*dir, *keyID+".key")
+		encoded, err := encode(key, *format)
+		if err != nil {
+			return err
+		}
+
+		return writeFile(path, encoded, keyFilePerm)
+	}
+
+	rootKey, err := keyprovider.ReadKeyFile(*kmsRootKey)
+	if err != nil {
+		return err
+	}
+	kms, err := keyprovider.NewLocalKMS(rootKey)
+	if err != nil {
+		return err
+	}
+	ciphertext, err := kms.Encrypt(context.Background(), *keyID, key)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(filepath.Join(*dir, *keyID+".enc"), base64.StdEncoding.EncodeToString(ciphertext), keyFilePerm)
+}
+
+func newKey() ([]byte, error) {
+	key := make([]byte, keyLength)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("ошибка генерации ключа: %w", err)
+	}
+
+	return key, nil
+}
+
+func writeNewKey(path, format string) error {
+	key, err := newKey()
+	if err != nil {
+		return err
+	}
+
+	encoded, err := encode(key, format)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(path, encoded, keyFilePerm)
+}
+
+// writeSigningKey записывает приватный ключ подписи токенов <id>.pem и его открытую часть <id>.pub.pem.
+// После ротации приватный файл удаляют, а открытый оставляют, пока не истекут выданные токены.
+func writeSigningKey(dir, keyID, keyType string) error {
+	var private crypto.Signer
+	var err error
+	switch keyType {
+	case typeEd25519:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	case typeRSA:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	default:
+		return fmt.Errorf("неизвестный тип ключа %q", keyType)
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка генерации ключа: %w", err)
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return fmt.Errorf("ошибка кодирования приватного ключа: %w", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return fmt.Errorf("ошибка кодирования открытого ключа: %w", err)
+	}
+
+	err = writeFile(
+		filepath.Join(dir, keyID+keyprovider.SigningKeyExt),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})),
+		keyFilePerm,
+	)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(
+		filepath.Join(dir, keyID+keyprovider.VerificationKeyExt),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})),
+		publicKeyFilePerm,
+	)
+}
+
+func encode(key []byte, format string) (string, error) {
+	switch format {
+	case formatHex:
+		return hex.EncodeToString(key), nil
+	case formatBase64:
+		return base64.StdEncoding.EncodeToString(key), nil
+	default:
+		return "", fmt.Errorf("неизвестный формат %q", format)
+	}
+}
+
+// writeFile не перезаписывает существующие ключи: потеря ключа означает потерю данных.
+func writeFile(path, content string, perm os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return fmt.Errorf("не удалось создать файл ключа: %w", err)
+	}
+
+	if _, err = file.WriteString(strings.TrimRight(content, "\n") + "\n"); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("не удалось записать файл ключа: %w", err)
+	}
+
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("не удалось закрыть файл ключа: %w", err)
+	}
+
+	fmt.Println("Ключ записан в", path)
+
+	return nil
+}
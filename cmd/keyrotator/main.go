@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/NikolosHGW/goph-keeper/cmd/internal/keyring"
+	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/config"
+	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/db"
+	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/repository"
+	"github.com/NikolosHGW/goph-keeper/internal/server/service"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+)
+
+const (
+	modeRewrap    = "rewrap"
+	modeReencrypt = "reencrypt"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(fmt.Errorf("ротация ключей завершилась с ошибкой: %w", err))
+	}
+}
+
+func run() error {
+	mode := flag.String("mode", modeRewrap,
+		"rewrap - re-wrap user keys with the active master key, reencrypt - re-encrypt user_data rows")
+	batchSize := flag.Int("batch-size", 500, "rows per batch")
+	retireKeys := flag.Bool("retire-keys", false, "retire active user keys before reencrypt")
+
+	config := config.NewConfig()
+
+	if *batchSize <= 0 {
+		return errors.New("batch-size должен быть положительным")
+	}
+
+	myLogger, err := logger.NewLogger("info")
+	if err != nil {
+		return fmt.Errorf("не удалось инициализировать логгер: %w", err)
+	}
+
+	database, err := db.InitDB(config.GetDatabaseURI(), &db.DBConnector{}, &db.Migrator{})
+	if err != nil {
+		return fmt.Errorf("не удалось инициализировать базу данных: %w", err)
+	}
+
+	defer func() {
+		if closeErr := database.Close(); closeErr != nil {
+			myLogger.LogInfo("ошибка при закрытии базы данных: ", closeErr)
+		}
+	}()
+
+	keyring, err := keyring.Load(context.Background(), config)
+	if err != nil {
+		return err
+	}
+
+	userKeyRepo := repository.NewUserKeyRepository(database, myLogger)
+	dataRepo := repository.NewDataRepository(database, myLogger)
+	envelope := service.NewEnvelopeEncryption(keyring, userKeyRepo)
+	rotator := service.NewKeyRotator(keyring, envelope, userKeyRepo, dataRepo)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var stats service.RotationStats
+	switch *mode {
+	case modeRewrap:
+		stats, err = rotator.RewrapKeys(ctx, *batchSize)
+	case modeReencrypt:
+		if *retireKeys {
+			retired, retireErr := rotator.RetireKeys(ctx)
+			if retireErr != nil {
+				return retireErr
+			}
+			myLogger.LogStringInfo("Ключи пользователей выведены из оборота", "count", fmt.Sprint(retired))
+		}
+		stats, err = rotator.ReencryptData(ctx, *batchSize)
+	default:
+		return fmt.Errorf("неизвестный режим %q", *mode)
+	}
+
+	myLogger.LogStringInfo("Ротация ключей "+*mode, "stats",
+		fmt.Sprintf("processed=%d updated=%d skipped=%d", stats.Processed, stats.Updated, stats.Skipped))
+
+	return err
+}
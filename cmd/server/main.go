@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -8,23 +9,64 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/NikolosHGW/goph-keeper/api/authpb"
 	"github.com/NikolosHGW/goph-keeper/api/datapb"
 	"github.com/NikolosHGW/goph-keeper/api/registerpb"
+	"github.com/NikolosHGW/goph-keeper/cmd/internal/keyring"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
 	"github.com/NikolosHGW/goph-keeper/internal/server/handler"
+	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/blobstore"
 	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/config"
 	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/db"
+	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/eventbus"
+	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/keyprovider"
 	"github.com/NikolosHGW/goph-keeper/internal/server/infrastructure/repository"
 	"github.com/NikolosHGW/goph-keeper/internal/server/interceptor"
 	"github.com/NikolosHGW/goph-keeper/internal/server/service"
 	"github.com/NikolosHGW/goph-keeper/internal/server/usecase"
 	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"github.com/jmoiron/sqlx"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
+const (
+	// totpIssuer - название сервиса, под которым аккаунт отображается в приложении-аутентификаторе.
+	totpIssuer = "GophKeeper"
+	// blobGCInterval - как часто удалять из хранилища файлы удалённых записей и брошенных загрузок.
+	blobGCInterval = 10 * time.Minute
+	// historyPruneInterval - как часто удалять прежние версии записей сверх лимита.
+	historyPruneInterval = time.Hour
+	// trashPurgeInterval - как часто удалять навсегда записи, пролежавшие в корзине дольше срока хранения.
+	trashPurgeInterval = time.Hour
+)
+
+type tokenService interface {
+	GenerateJWT(user *entity.User, sessionID string) (string, error)
+	ValidateToken(tokenString string) (*entity.Claims, error)
+	JWKS() []*entity.JWK
+}
+
+type eventBus interface {
+	Publish(ctx context.Context, event *entity.DataEvent) error
+	Subscribe(userID int) (events <-chan *entity.DataEvent, cancel func())
+}
+
+type searchIndexConfig interface {
+	GetSearchKeyID() string
+	IsDevMode() bool
+}
+
+type tokenConfig interface {
+	GetSecretKey() string
+	GetJWTKeyDir() string
+	GetJWTKeyID() string
+	IsDevMode() bool
+}
+
 func main() {
 	if err := run(); err != nil {
 		log.Fatal(fmt.Errorf("не удалось запустить сервер: %w", err))
@@ -52,14 +94,62 @@ func run() error {
 
 	userRepo := repository.NewUser(database, myLogger)
 	dataRepo := repository.NewDataRepository(database, myLogger)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(database, myLogger)
+	userKeyRepo := repository.NewUserKeyRepository(database, myLogger)
+	sessionRepo := repository.NewSessionRepository(database, myLogger)
+	twoFactorRepo := repository.NewTwoFactorRepository(database, myLogger)
+	binaryRepo := repository.NewBinaryRepository(database, myLogger)
+	organizerRepo := repository.NewOrganizerRepository(database, myLogger)
+	recordTypeRepo := repository.NewRecordTypeRepository(database, myLogger)
 
 	registerService := service.NewRegister(myLogger)
-	tokenService := service.NewToken(myLogger, config.GetSecretKey())
-	encryptionService := service.NewEncryptionService([]byte(config.GetCryptoKeyPath()))
-	dataService := service.NewDataService(dataRepo, encryptionService)
+	tokenService, err := newTokenService(config, myLogger)
+	if err != nil {
+		return err
+	}
+	sessionService := service.NewSessions(sessionRepo, tokenService)
+	keyring, err := keyring.Load(context.Background(), config)
+	if err != nil {
+		return err
+	}
+	encryptionService := service.NewEnvelopeEncryption(keyring, userKeyRepo)
+	searchIndex, err := newSearchIndex(config, keyring)
+	if err != nil {
+		return err
+	}
+	bus, closeBus, err := newEventBus(config.GetEventBus(), database, config.GetDatabaseURI(), myLogger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := closeBus(); closeErr != nil {
+			myLogger.LogInfo("ошибка при остановке брокера событий: ", closeErr)
+		}
+	}()
+	dataService := service.NewWatchedDataService(
+		service.NewDataService(dataRepo, encryptionService).WithSearchIndex(searchIndex),
+		bus,
+		myLogger,
+	)
+	organizerService := service.NewOrganizerService(organizerRepo, encryptionService, bus, myLogger)
+	recordTypeService := service.NewRecordTypeService(recordTypeRepo)
+	blobs, err := blobstore.FromConfig(config)
+	if err != nil {
+		return fmt.Errorf("не удалось инициализировать хранилище файлов: %w", err)
+	}
+	binaryService := service.NewBinaryService(binaryRepo, dataService, blobs, encryptionService)
+	blobCollector := service.NewBlobCollector(binaryRepo, blobs, config.GetUploadTTL(), myLogger)
+	historyPruner := service.NewHistoryPruner(dataRepo, config.GetHistoryVersions(), config.GetHistoryTTL(), myLogger)
+	trashPurger := service.NewTrashPurger(dataRepo, bus, config.GetTrashTTL(), myLogger)
+	loginGuard := service.NewLoginGuard(loginAttemptRepo)
+	twoFactorService := service.NewTwoFactor(twoFactorRepo, userRepo, encryptionService, totpIssuer)
+	credentialsService, err := service.NewCredentials(myLogger)
+	if err != nil {
+		return fmt.Errorf("не удалось инициализировать сервис проверки пароля: %w", err)
+	}
 
-	registerUsecase := usecase.NewRegister(registerService, tokenService, userRepo)
-	authUsecase := usecase.NewAuth(tokenService, userRepo)
+	registerUsecase := usecase.NewRegister(registerService, sessionService, userRepo)
+	authUsecase := usecase.NewAuth(sessionService, userRepo, credentialsService, loginGuard, twoFactorService)
 
 	listen, err := net.Listen("tcp", config.GetRunAddress())
 	if err != nil {
@@ -69,6 +159,9 @@ func run() error {
 	noAuthMethods := []string{
 		"/register.Register/RegisterUser",
 		"/auth.Auth/LoginUser",
+		"/auth.Auth/RefreshToken",
+		"/auth.Auth/VerifyLoginCode",
+		"/auth.Auth/GetJWKS",
 	}
 
 	creds, err := credentials.NewServerTLSFromFile(config.GetServerCrtPath(), config.GetServerKeyPath())
@@ -76,18 +169,34 @@ func run() error {
 		return fmt.Errorf("не удалось загрузить TLS сертификаты: %w", err)
 	}
 
+	authInterceptor := interceptor.NewAuthInterceptor(tokenService, sessionService, noAuthMethods)
 	srv := grpc.NewServer(
 		grpc.Creds(creds),
-		grpc.ChainUnaryInterceptor(
-			interceptor.NewAuthInterceptor(tokenService, noAuthMethods).Unary(),
-		),
+		grpc.ChainUnaryInterceptor(authInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(authInterceptor.Stream()),
 	)
 
 	reflection.Register(srv)
 
 	registerpb.RegisterRegisterServer(srv, handler.NewRegisterServer(registerUsecase))
-	authpb.RegisterAuthServer(srv, handler.NewAuthServer(authUsecase))
-	datapb.RegisterDataServiceServer(srv, handler.NewDataServer(dataService, myLogger))
+	authpb.RegisterAuthServer(srv, handler.NewAuthServer(
+		authUsecase,
+		sessionService,
+		tokenService,
+		twoFactorService,
+	))
+	datapb.RegisterDataServiceServer(srv, handler.NewDataServer(dataService, myLogger).WithRecordTypes(recordTypeService))
+	datapb.RegisterBinaryServiceServer(srv, handler.NewBinaryServer(binaryService, myLogger))
+	datapb.RegisterOrganizerServiceServer(srv, handler.NewOrganizerServer(organizerService, myLogger))
+	datapb.RegisterRecordTypeServiceServer(srv, handler.NewRecordTypeServer(recordTypeService, myLogger))
+
+	gcCtx, stopGC := context.WithCancel(context.Background())
+	defer stopGC()
+	go blobCollector.Run(gcCtx, blobGCInterval)
+	go historyPruner.Run(gcCtx, historyPruneInterval)
+	if config.GetTrashTTL() > 0 {
+		go trashPurger.Run(gcCtx, trashPurgeInterval)
+	}
 
 	errChan := make(chan error, 1)
 
@@ -116,3 +225,66 @@ func run() error {
 
 	return nil
 }
+
+// newSearchIndex выводит ключ слепого индекса из мастер-ключа -search-key-id. Версия ключа закреплена,
+// потому что токены поиска в базе не перешифровываются при ротации: с активным ключом, который допустим
+// только в режиме разработки, после смены CRYPTO_KEY_ID записи перестали бы находиться.
+func newSearchIndex(cfg searchIndexConfig, keyring *service.Keyring) (*service.SearchIndex, error) {
+	keyID := cfg.GetSearchKeyID()
+	if keyID == "" && !cfg.IsDevMode() {
+		return nil, errors.New("версию ключа индекса поиска нужно задать через -search-key-id (SEARCH_KEY_ID)")
+	}
+
+	key, err := keyring.DeriveKey(keyID, service.SearchIndexPurpose)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить ключ индекса поиска: %w", err)
+	}
+
+	return service.NewSearchIndex(key), nil
+}
+
+// newTokenService подписывает токены ключами из -jwt-key-dir, а если каталог не задан - HMAC секретом -k.
+func newTokenService(cfg tokenConfig, log logger.CustomLogger) (tokenService, error) {
+	if cfg.GetJWTKeyDir() == "" {
+		if cfg.GetSecretKey() == config.DefaultSecretKey && !cfg.IsDevMode() {
+			return nil, errors.New("секрет подписи токенов по умолчанию допустим только в режиме разработки (-dev)")
+		}
+
+		return service.NewToken(log, cfg.GetSecretKey()), nil
+	}
+
+	keys, err := keyprovider.LoadSigningKeys(cfg.GetJWTKeyDir())
+	if err != nil {
+		return nil, fmt.Errorf("не удалось загрузить ключи подписи токенов: %w", err)
+	}
+
+	keySet, err := service.NewSigningKeySet(cfg.GetJWTKeyID(), keys)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось инициализировать ключи подписи токенов: %w", err)
+	}
+
+	return service.NewSigningToken(log, keySet), nil
+}
+
+// newEventBus выбирает брокер событий WatchData: в памяти процесса для одного узла
+// или через Postgres LISTEN/NOTIFY, когда узлов несколько.
+func newEventBus(
+	kind string,
+	database *sqlx.DB,
+	dsn string,
+	log logger.CustomLogger,
+) (bus eventBus, closeBus func() error, err error) {
+	switch kind {
+	case "local":
+		return eventbus.NewLocal(), func() error { return nil }, nil
+	case "postgres":
+		pgBus, err := eventbus.NewPostgres(database, dsn, log)
+		if err != nil {
+			return nil, nil, fmt.Errorf("не удалось инициализировать брокер событий: %w", err)
+		}
+
+		return pgBus, pgBus.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("неизвестный брокер событий %q, ожидается local или postgres", kind)
+	}
+}
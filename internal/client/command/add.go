@@ -3,22 +3,39 @@ package command
 import (
 	"bufio"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/NikolosHGW/goph-keeper/api/datapb"
 	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+	"google.golang.org/protobuf/proto"
 )
 
 type dataService interface {
 	AddData(ctx context.Context, token string, data *datapb.DataItem) (int32, error)
 }
 
+// binaryUploader загружает файл по частям и возвращает ID записи.
+type binaryUploader interface {
+	Upload(ctx context.Context, token, filePath, meta string, replaceID int32) (int32, error)
+}
+
+// recordTypeLister - схемы пользовательских типов записей для выбора типа и ввода полей.
+type recordTypeLister interface {
+	recordTypeLookup
+	ListRecordTypes(ctx context.Context, token string) ([]*datapb.RecordType, error)
+}
+
 type AddCommand struct {
 	dataService dataService
+	uploader    binaryUploader
+	recordTypes recordTypeLister
 	tokenHolder *entity.TokenHolder
 	reader      io.Reader
 	writer      io.Writer
@@ -26,12 +43,16 @@ type AddCommand struct {
 
 func NewAddCommand(
 	dataService dataService,
+	uploader binaryUploader,
+	recordTypes recordTypeLister,
 	tokenHolder *entity.TokenHolder,
 	reader io.Reader,
 	writer io.Writer,
 ) *AddCommand {
 	return &AddCommand{
 		dataService: dataService,
+		uploader:    uploader,
+		recordTypes: recordTypes,
 		tokenHolder: tokenHolder,
 		reader:      reader,
 		writer:      writer,
@@ -44,7 +65,7 @@ func (c *AddCommand) Name() string {
 
 func (c *AddCommand) Execute() error {
 	if c.tokenHolder.Token == "" {
-		return fmt.Errorf("вы должны войти в систему")
+		return ErrNotLoggedIn
 	}
 
 	scanner := bufio.NewScanner(c.reader)
@@ -54,6 +75,11 @@ func (c *AddCommand) Execute() error {
 	fmt.Fprintln(c.writer, "2. Text")
 	fmt.Fprintln(c.writer, "3. Binary File")
 	fmt.Fprintln(c.writer, "4. Bank Card")
+	fmt.Fprintln(c.writer, "5. TOTP")
+	customTypes := c.customTypes()
+	for i, recordType := range customTypes {
+		fmt.Fprintf(c.writer, "%d. %s\n", i+firstCustomOption, recordType.Name)
+	}
 	fmt.Fprint(c.writer, "Введите номер опции: ")
 
 	var option string
@@ -72,27 +98,243 @@ func (c *AddCommand) Execute() error {
 	case "2":
 		dataItem, err = c.inputTextData(scanner)
 	case "3":
-		dataItem, err = c.inputBinaryData(scanner)
+		return c.uploadBinaryData(scanner)
 	case "4":
 		dataItem, err = c.inputBankCardData(scanner)
+	case "5":
+		dataItem, err = c.inputTOTPData(scanner)
 	default:
-		fmt.Fprintln(c.writer, "Некорректная опция")
-		return nil
+		index, convErr := strconv.Atoi(option)
+		index -= firstCustomOption
+		if convErr != nil || index < 0 || index >= len(customTypes) {
+			fmt.Fprintln(c.writer, "Некорректная опция")
+			return nil
+		}
+		dataItem, err = c.inputCustomData(scanner, customTypes[index])
 	}
 
 	if err != nil {
 		return err
 	}
 
-	id, err := c.dataService.AddData(context.Background(), c.tokenHolder.Token, dataItem)
+	id, err := c.add(dataItem)
 	if err != nil {
-		return fmt.Errorf("ошибка добавления данных: %w", err)
+		return err
 	}
 
 	fmt.Fprintf(c.writer, "Данные успешно добавлены с ID: %d\n", id)
 	return nil
 }
 
+// Run добавляет запись по флагам: `add text --meta заметка --stdin` или `add wifi --set ssid=home --stdin`
+// для пользовательского типа. Печатает только ID новой записи.
+func (c *AddCommand) Run(args []string) error {
+	fs := newFlagSet("add <login_password|text|binary|bank_card|totp|пользовательский тип> [флаги]")
+	meta := fs.String("meta", "", "метаинформация")
+	fromStdin := fs.Bool("stdin", false, "прочитать секрет из stdin: пароль, текст, CVV, секрет TOTP "+
+		"или первое скрытое поле пользовательского типа")
+	var values stringsFlag
+	fs.Var(&values, "set", "значение поля пользовательского типа в виде имя=значение; флаг повторяется")
+	login := fs.String("login", "", "логин (login_password)")
+	password := fs.String("password", "", "пароль (login_password)")
+	url := fs.String("url", "", "URL (login_password)")
+	totpID := fs.Int("totp", 0, "ID записи TOTP с кодами второго фактора (login_password)")
+	text := fs.String("text", "", "текст (text)")
+	file := fs.String("file", "", "путь к файлу (binary)")
+	number := fs.String("number", "", "номер карты (bank_card)")
+	expiry := fs.String("expiry", "", "срок действия MM/YY (bank_card)")
+	cvv := fs.String("cvv", "", "CVV (bank_card)")
+	holder := fs.String("holder", "", "имя держателя карты (bank_card)")
+	secret := fs.String("secret", "", "секрет в base32 или otpauth:// URI (totp)")
+
+	positional, err := parseArgs(fs, args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("укажите тип данных: login_password, text, binary, bank_card, totp или пользовательский тип")
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	infoType := positional[0]
+	if !payload.IsBuiltin(infoType) {
+		return c.runCustom(infoType, values, *fromStdin, *meta)
+	}
+	if len(values) > 0 {
+		return usagef("--set поддерживается только для пользовательских типов")
+	}
+	if *fromStdin {
+		var stdinValue *string
+		switch infoType {
+		case "login_password":
+			stdinValue = password
+		case "text":
+			stdinValue = text
+		case "bank_card":
+			stdinValue = cvv
+		case "totp":
+			stdinValue = secret
+		default:
+			return usagef("--stdin не поддерживается для типа %s", infoType)
+		}
+		if *stdinValue, err = readSecret(c.reader); err != nil {
+			return err
+		}
+	}
+
+	var dataItem *datapb.DataItem
+	switch infoType {
+	case "login_password":
+		dataItem = newDataItem(infoType, &datapb.LoginPassword{
+			Login:    *login,
+			Password: *password,
+			Url:      *url,
+			TotpId:   int32(*totpID),
+		}, *meta)
+	case "text":
+		dataItem = newDataItem(infoType, &datapb.Text{Text: *text}, *meta)
+	case "binary":
+		if *file == "" {
+			return usagef("укажите путь к файлу флагом --file")
+		}
+		id, err := c.uploader.Upload(context.Background(), c.tokenHolder.Token, *file, *meta, 0)
+		if err != nil {
+			return fmt.Errorf("ошибка загрузки файла: %w", err)
+		}
+		fmt.Fprintln(c.writer, id)
+		return nil
+	case "bank_card":
+		dataItem = newDataItem(infoType, &datapb.BankCard{
+			CardNumber: *number,
+			ExpiryDate: *expiry,
+			Cvv:        *cvv,
+			HolderName: *holder,
+		}, *meta)
+	case "totp":
+		data, err := parseTOTP(*secret)
+		if err != nil {
+			return &usageError{err: err}
+		}
+		dataItem = newDataItem(infoType, data, *meta)
+	default:
+		return usagef("неизвестный тип данных: %s", infoType)
+	}
+
+	id, err := c.add(dataItem)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.writer, id)
+	return nil
+}
+
+// runCustom добавляет запись пользовательского типа со значениями полей из флагов --set.
+// Секрет из stdin записывается в первое скрытое поле типа.
+func (c *AddCommand) runCustom(infoType string, values []string, fromStdin bool, meta string) error {
+	recordType, err := c.recordType(infoType)
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[string]string, len(values))
+	for _, value := range values {
+		name, fieldValue, ok := strings.Cut(value, "=")
+		if !ok {
+			return usagef("значение поля задаётся как имя=значение: %s", value)
+		}
+		fields[name] = fieldValue
+	}
+	if fromStdin {
+		field := sensitiveField(recordType)
+		if field == nil {
+			return usagef("у типа %s нет скрытых полей для --stdin", infoType)
+		}
+		if fields[field.Name], err = readSecret(c.reader); err != nil {
+			return err
+		}
+	}
+
+	dataItem, err := customItem(recordType, fields, meta)
+	if err != nil {
+		return &usageError{err: err}
+	}
+
+	id, err := c.add(dataItem)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.writer, id)
+	return nil
+}
+
+// recordType возвращает схему пользовательского типа записей. Тип, которого нет, - ошибка использования.
+func (c *AddCommand) recordType(infoType string) (*datapb.RecordType, error) {
+	if c.recordTypes == nil {
+		return nil, usagef("неизвестный тип данных: %s", infoType)
+	}
+
+	recordType, err := c.recordTypes.RecordType(context.Background(), c.tokenHolder.Token, infoType)
+	if errors.Is(err, entity.ErrRecordTypeNotFound) {
+		return nil, usagef("неизвестный тип данных: %s", infoType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения типа записей: %w", err)
+	}
+
+	return recordType, nil
+}
+
+// customTypes возвращает пользовательские типы записей для меню. Схемы хранит сервер, поэтому без сети
+// меню содержит только встроенные типы.
+func (c *AddCommand) customTypes() []*datapb.RecordType {
+	if c.recordTypes == nil {
+		return nil
+	}
+
+	recordTypes, err := c.recordTypes.ListRecordTypes(context.Background(), c.tokenHolder.Token)
+	if err != nil {
+		return nil
+	}
+
+	return recordTypes
+}
+
+func (c *AddCommand) add(dataItem *datapb.DataItem) (int32, error) {
+	id, err := c.dataService.AddData(context.Background(), c.tokenHolder.Token, dataItem)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка добавления данных: %w", err)
+	}
+
+	return id, nil
+}
+
+// newDataItem возвращает запись с содержимым info в payload.
+func newDataItem(infoType string, info proto.Message, meta string) *datapb.DataItem {
+	dataItem := &datapb.DataItem{
+		InfoType: infoType,
+		Meta:     meta,
+	}
+	// Типы содержимого в командах известны payload, поэтому ошибки здесь быть не может.
+	_ = payload.Set(dataItem, info)
+
+	return dataItem
+}
+
+// readSecret читает секрет из stdin целиком, отбрасывая завершающий перевод строки,
+// чтобы секрет не попадал в аргументы процесса и историю оболочки.
+func readSecret(reader io.Reader) (string, error) {
+	secret, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("ошибка чтения stdin: %w", err)
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(string(secret), "\n"), "\r"), nil
+}
+
 func (c *AddCommand) inputLoginPasswordData(scanner *bufio.Scanner) (*datapb.DataItem, error) {
 	fmt.Fprint(c.writer, "Введите логин: ")
 	var login string
@@ -126,24 +368,13 @@ func (c *AddCommand) inputLoginPasswordData(scanner *bufio.Scanner) (*datapb.Dat
 		return nil, fmt.Errorf("ошибка ввода метаинформации: %w", scanner.Err())
 	}
 
-	loginPasswordData := &entity.LoginPasswordData{
+	loginPasswordData := &datapb.LoginPassword{
 		Login:    login,
 		Password: password,
-		URL:      url,
-	}
-
-	infoBytes, err := json.Marshal(loginPasswordData)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка сериализации данных: %w", err)
-	}
-
-	dataItem := &datapb.DataItem{
-		InfoType: "login_password",
-		Info:     infoBytes,
-		Meta:     meta,
+		Url:      url,
 	}
 
-	return dataItem, nil
+	return newDataItem(payload.LoginPasswordType, loginPasswordData, meta), nil
 }
 
 func (c *AddCommand) inputTextData(scanner *bufio.Scanner) (*datapb.DataItem, error) {
@@ -163,36 +394,25 @@ func (c *AddCommand) inputTextData(scanner *bufio.Scanner) (*datapb.DataItem, er
 		return nil, fmt.Errorf("ошибка ввода метаинформации: %w", scanner.Err())
 	}
 
-	textData := &entity.TextData{
+	textData := &datapb.Text{
 		Text: text,
 	}
 
-	infoBytes, err := json.Marshal(textData)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка сериализации данных: %w", err)
-	}
-
-	dataItem := &datapb.DataItem{
-		InfoType: "text",
-		Info:     infoBytes,
-		Meta:     meta,
-	}
-
-	return dataItem, nil
+	return newDataItem(payload.TextType, textData, meta), nil
 }
 
-func (c *AddCommand) inputBinaryData(scanner *bufio.Scanner) (*datapb.DataItem, error) {
+// uploadBinaryData загружает файл по частям: целиком файл в одно сообщение gRPC может не поместиться.
+func (c *AddCommand) uploadBinaryData(scanner *bufio.Scanner) error {
 	fmt.Fprint(c.writer, "Введите путь к файлу: ")
 	var filePath string
 	if scanner.Scan() {
 		filePath = scanner.Text()
 	} else {
-		return nil, fmt.Errorf("ошибка ввода пути к файлу: %w", scanner.Err())
+		return fmt.Errorf("ошибка ввода пути к файлу: %w", scanner.Err())
 	}
 
-	fileContent, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("не удалось прочитать файл: %w", err)
+	if _, err := os.Stat(filePath); err != nil {
+		return fmt.Errorf("не удалось прочитать файл: %w", err)
 	}
 
 	fmt.Fprint(c.writer, "Введите метаинформацию: ")
@@ -200,28 +420,16 @@ func (c *AddCommand) inputBinaryData(scanner *bufio.Scanner) (*datapb.DataItem,
 	if scanner.Scan() {
 		meta = scanner.Text()
 	} else {
-		return nil, fmt.Errorf("ошибка ввода метаинформации: %w", scanner.Err())
-	}
-
-	fileName := getFileName(filePath)
-
-	binaryData := &entity.BinaryData{
-		FileName:    fileName,
-		FileContent: fileContent,
+		return fmt.Errorf("ошибка ввода метаинформации: %w", scanner.Err())
 	}
 
-	infoBytes, err := json.Marshal(binaryData)
+	id, err := c.uploader.Upload(context.Background(), c.tokenHolder.Token, filePath, meta, 0)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка сериализации данных: %w", err)
-	}
-
-	dataItem := &datapb.DataItem{
-		InfoType: "binary",
-		Info:     infoBytes,
-		Meta:     meta,
+		return fmt.Errorf("ошибка загрузки файла: %w", err)
 	}
 
-	return dataItem, nil
+	fmt.Fprintf(c.writer, "Файл %s загружен с ID: %d\n", getFileName(filePath), id)
+	return nil
 }
 
 func (c *AddCommand) inputBankCardData(scanner *bufio.Scanner) (*datapb.DataItem, error) {
@@ -265,25 +473,62 @@ func (c *AddCommand) inputBankCardData(scanner *bufio.Scanner) (*datapb.DataItem
 		return nil, fmt.Errorf("ошибка ввода метаинформации: %w", scanner.Err())
 	}
 
-	bankCardData := &entity.BankCardData{
+	bankCardData := &datapb.BankCard{
 		CardNumber: cardNumber,
 		ExpiryDate: expiryDate,
-		CVV:        cvv,
+		Cvv:        cvv,
 		HolderName: holderName,
 	}
 
-	infoBytes, err := json.Marshal(bankCardData)
+	return newDataItem(payload.BankCardType, bankCardData, meta), nil
+}
+
+// inputTOTPData запрашивает секрет аутентификатора: строку base32 или otpauth:// URI из QR-кода.
+func (c *AddCommand) inputTOTPData(scanner *bufio.Scanner) (*datapb.DataItem, error) {
+	fmt.Fprint(c.writer, "Введите секрет или otpauth:// URI: ")
+	var secret string
+	if scanner.Scan() {
+		secret = scanner.Text()
+	} else {
+		return nil, fmt.Errorf("ошибка ввода секрета: %w", scanner.Err())
+	}
+
+	totpData, err := parseTOTP(secret)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка сериализации данных: %w", err)
+		return nil, err
 	}
 
-	dataItem := &datapb.DataItem{
-		InfoType: "bank_card",
-		Info:     infoBytes,
-		Meta:     meta,
+	fmt.Fprint(c.writer, "Введите метаинформацию: ")
+	var meta string
+	if scanner.Scan() {
+		meta = scanner.Text()
+	} else {
+		return nil, fmt.Errorf("ошибка ввода метаинформации: %w", scanner.Err())
+	}
+
+	return newDataItem(payload.TOTPType, totpData, meta), nil
+}
+
+// inputCustomData запрашивает значения полей пользовательского типа в порядке схемы.
+func (c *AddCommand) inputCustomData(scanner *bufio.Scanner, recordType *datapb.RecordType) (*datapb.DataItem, error) {
+	fields := make(map[string]string, len(recordType.Fields))
+	for _, field := range recordType.Fields {
+		fmt.Fprintf(c.writer, "Введите %s%s: ", fieldLabel(field), fieldHint(field))
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("ошибка ввода поля %s: %w", field.Name, scanner.Err())
+		}
+		fields[field.Name] = scanner.Text()
+	}
+
+	fmt.Fprint(c.writer, "Введите метаинформацию: ")
+	var meta string
+	if scanner.Scan() {
+		meta = scanner.Text()
+	} else {
+		return nil, fmt.Errorf("ошибка ввода метаинформации: %w", scanner.Err())
 	}
 
-	return dataItem, nil
+	return customItem(recordType, fields, meta)
 }
 
 func getFileName(filePath string) string {
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -27,6 +26,15 @@ func (m *MockDataService) AddData(ctx context.Context, token string, data *datap
 	return args.Get(0).(int32), args.Error(1)
 }
 
+type MockBinaryUploader struct {
+	mock.Mock
+}
+
+func (m *MockBinaryUploader) Upload(ctx context.Context, token, filePath, meta string, replaceID int32) (int32, error) {
+	args := m.Called(ctx, token, filePath, meta, replaceID)
+	return args.Get(0).(int32), args.Error(1)
+}
+
 func TestAddCommand_Execute(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -41,15 +49,14 @@ func TestAddCommand_Execute(t *testing.T) {
 			token: "valid_token",
 			input: "1\nuser123\npass123\nhttp://example.com\nmeta_info\n",
 			mockSetup: func(m *MockDataService) {
-				loginPasswordData := &entity.LoginPasswordData{
+				loginPasswordData := &datapb.LoginPassword{
 					Login:    "user123",
 					Password: "pass123",
-					URL:      "http://example.com",
+					Url:      "http://example.com",
 				}
-				infoBytes, _ := json.Marshal(loginPasswordData)
 				dataItem := &datapb.DataItem{
 					InfoType: "login_password",
-					Info:     infoBytes,
+					Payload:  &datapb.DataItem_LoginPassword{LoginPassword: loginPasswordData},
 					Meta:     "meta_info",
 				}
 				m.On("AddData", mock.Anything, "valid_token", dataItem).Return(int32(1), nil)
@@ -59,6 +66,7 @@ func TestAddCommand_Execute(t *testing.T) {
 				"2. Text\n" +
 				"3. Binary File\n" +
 				"4. Bank Card\n" +
+				"5. TOTP\n" +
 				"Введите номер опции: " +
 				"Введите логин: " +
 				"Введите пароль: " +
@@ -72,13 +80,12 @@ func TestAddCommand_Execute(t *testing.T) {
 			token: "valid_token",
 			input: "2\nSample text data\nmeta_info_text\n",
 			mockSetup: func(m *MockDataService) {
-				textData := &entity.TextData{
+				textData := &datapb.Text{
 					Text: "Sample text data",
 				}
-				infoBytes, _ := json.Marshal(textData)
 				dataItem := &datapb.DataItem{
 					InfoType: "text",
-					Info:     infoBytes,
+					Payload:  &datapb.DataItem_Text{Text: textData},
 					Meta:     "meta_info_text",
 				}
 				m.On("AddData", mock.Anything, "valid_token", dataItem).Return(int32(2), nil)
@@ -88,6 +95,7 @@ func TestAddCommand_Execute(t *testing.T) {
 				"2. Text\n" +
 				"3. Binary File\n" +
 				"4. Bank Card\n" +
+				"5. TOTP\n" +
 				"Введите номер опции: " +
 				"Введите текст: " +
 				"Введите метаинформацию: " +
@@ -99,16 +107,15 @@ func TestAddCommand_Execute(t *testing.T) {
 			token: "valid_token",
 			input: "4\n1234-5678-9012-3456\n12/24\n123\nJohn Doe\nmeta_info_card\n",
 			mockSetup: func(m *MockDataService) {
-				bankCardData := &entity.BankCardData{
+				bankCardData := &datapb.BankCard{
 					CardNumber: "1234-5678-9012-3456",
 					ExpiryDate: "12/24",
-					CVV:        "123",
+					Cvv:        "123",
 					HolderName: "John Doe",
 				}
-				infoBytes, _ := json.Marshal(bankCardData)
 				dataItem := &datapb.DataItem{
 					InfoType: "bank_card",
-					Info:     infoBytes,
+					Payload:  &datapb.DataItem_BankCard{BankCard: bankCardData},
 					Meta:     "meta_info_card",
 				}
 				m.On("AddData", mock.Anything, "valid_token", dataItem).Return(int32(4), nil)
@@ -118,6 +125,7 @@ func TestAddCommand_Execute(t *testing.T) {
 				"2. Text\n" +
 				"3. Binary File\n" +
 				"4. Bank Card\n" +
+				"5. TOTP\n" +
 				"Введите номер опции: " +
 				"Введите номер карты: " +
 				"Введите срок действия (MM/YY): " +
@@ -145,6 +153,7 @@ func TestAddCommand_Execute(t *testing.T) {
 				"2. Text\n" +
 				"3. Binary File\n" +
 				"4. Bank Card\n" +
+				"5. TOTP\n" +
 				"Введите номер опции: ",
 			expectedError: fmt.Errorf("ошибка ввода опции:"),
 		},
@@ -158,6 +167,7 @@ func TestAddCommand_Execute(t *testing.T) {
 				"2. Text\n" +
 				"3. Binary File\n" +
 				"4. Bank Card\n" +
+				"5. TOTP\n" +
 				"Введите номер опции: " +
 				"Введите логин: ",
 			expectedError: fmt.Errorf("ошибка ввода логина:"),
@@ -167,15 +177,14 @@ func TestAddCommand_Execute(t *testing.T) {
 			token: "valid_token",
 			input: "1\nuser123\npass123\nhttp://example.com\nmeta_info\n",
 			mockSetup: func(m *MockDataService) {
-				loginPasswordData := &entity.LoginPasswordData{
+				loginPasswordData := &datapb.LoginPassword{
 					Login:    "user123",
 					Password: "pass123",
-					URL:      "http://example.com",
+					Url:      "http://example.com",
 				}
-				infoBytes, _ := json.Marshal(loginPasswordData)
 				dataItem := &datapb.DataItem{
 					InfoType: "login_password",
-					Info:     infoBytes,
+					Payload:  &datapb.DataItem_LoginPassword{LoginPassword: loginPasswordData},
 					Meta:     "meta_info",
 				}
 				m.On("AddData", mock.Anything, "valid_token", dataItem).Return(int32(0), fmt.Errorf("service error"))
@@ -185,6 +194,7 @@ func TestAddCommand_Execute(t *testing.T) {
 				"2. Text\n" +
 				"3. Binary File\n" +
 				"4. Bank Card\n" +
+				"5. TOTP\n" +
 				"Введите номер опции: " +
 				"Введите логин: " +
 				"Введите пароль: " +
@@ -195,13 +205,14 @@ func TestAddCommand_Execute(t *testing.T) {
 		{
 			name:      "Invalid option",
 			token:     "valid_token",
-			input:     "5\n",
+			input:     "9\n",
 			mockSetup: func(m *MockDataService) {},
 			expectedOutput: "Выберите тип данных для добавления:\n" +
 				"1. Login and Password\n" +
 				"2. Text\n" +
 				"3. Binary File\n" +
 				"4. Bank Card\n" +
+				"5. TOTP\n" +
 				"Введите номер опции: " +
 				"Некорректная опция\n",
 			expectedError: nil,
@@ -216,6 +227,7 @@ func TestAddCommand_Execute(t *testing.T) {
 				"2. Text\n" +
 				"3. Binary File\n" +
 				"4. Bank Card\n" +
+				"5. TOTP\n" +
 				"Введите номер опции: " +
 				"Введите текст: ",
 			expectedError: fmt.Errorf("ошибка ввода текста:"),
@@ -230,6 +242,7 @@ func TestAddCommand_Execute(t *testing.T) {
 				"2. Text\n" +
 				"3. Binary File\n" +
 				"4. Bank Card\n" +
+				"5. TOTP\n" +
 				"Введите номер опции: " +
 				"Введите путь к файлу: ",
 			expectedError: fmt.Errorf("ошибка ввода пути к файлу:"),
@@ -244,6 +257,7 @@ func TestAddCommand_Execute(t *testing.T) {
 				"2. Text\n" +
 				"3. Binary File\n" +
 				"4. Bank Card\n" +
+				"5. TOTP\n" +
 				"Введите номер опции: " +
 				"Введите номер карты: ",
 			expectedError: fmt.Errorf("ошибка ввода номера карты:"),
@@ -263,7 +277,7 @@ func TestAddCommand_Execute(t *testing.T) {
 			reader := strings.NewReader(tt.input)
 			var writer bytes.Buffer
 
-			cmd := NewAddCommand(mockService, tokenHolder, reader, &writer)
+			cmd := NewAddCommand(mockService, nil, nil, tokenHolder, reader, &writer)
 
 			err := cmd.Execute()
 
@@ -281,66 +295,107 @@ func TestAddCommand_Execute(t *testing.T) {
 	}
 }
 
+func TestAddCommand_ExecuteCustom(t *testing.T) {
+	mockService := new(MockDataService)
+	mockService.On("AddData", mock.Anything, "token", mock.MatchedBy(func(item *datapb.DataItem) bool {
+		fields := item.GetCustom().GetFields()
+		return item.InfoType == "wifi" && item.Meta == "дом" &&
+			fields["ssid"] == "home" && fields["password"] == "secret" && fields["expires"] == "2030-01-01"
+	})).Return(int32(12), nil)
+
+	writer := &bytes.Buffer{}
+	cmd := NewAddCommand(mockService, nil, newWifiTypes(), &entity.TokenHolder{Token: "token"},
+		strings.NewReader("6\nhome\nsecret\n2030-01-01\nдом\n"), writer)
+
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, writer.String(), "5. TOTP\n6. wifi\n")
+	assert.Contains(t, writer.String(), "Введите Сеть: Введите Пароль: Введите expires (YYYY-MM-DD): ")
+	assert.Contains(t, writer.String(), "Данные успешно добавлены с ID: 12")
+	mockService.AssertExpectations(t)
+
+	writer.Reset()
+	cmd = NewAddCommand(mockService, nil, newWifiTypes(), &entity.TokenHolder{Token: "token"},
+		strings.NewReader("6\n\nsecret\n\n\n"), writer)
+	assert.ErrorContains(t, cmd.Execute(), "не заполнено обязательное поле: ssid")
+
+	writer.Reset()
+	cmd = NewAddCommand(mockService, nil, newWifiTypes(), &entity.TokenHolder{Token: "token"},
+		strings.NewReader("7\n"), writer)
+	assert.NoError(t, cmd.Execute())
+	assert.Contains(t, writer.String(), "Некорректная опция")
+}
+
 func TestAddCommand_Name(t *testing.T) {
-	cmd := NewAddCommand(nil, nil, nil, nil)
+	cmd := NewAddCommand(nil, nil, nil, nil, nil, nil)
 	expectedName := "add"
 	actualName := cmd.Name()
 	assert.Equal(t, expectedName, actualName, "Название команды должно быть 'add'")
 }
 
-func TestInputBinaryData_ValidInput(t *testing.T) {
+func TestUploadBinaryData_ValidInput(t *testing.T) {
 	tmpFile, err := os.CreateTemp("", "testfile")
 	if err != nil {
 		t.Fatalf("Не удалось создать временный файл: %v", err)
 	}
 	defer os.Remove(tmpFile.Name())
 
-	fileContent := []byte("Тестовое содержимое файла.")
-	if _, err := tmpFile.Write(fileContent); err != nil {
+	if _, err := tmpFile.Write([]byte("Тестовое содержимое файла.")); err != nil {
 		t.Fatalf("Не удалось записать в временный файл: %v", err)
 	}
 	tmpFile.Close()
 
 	input := bytes.NewBufferString(tmpFile.Name() + "\n" + "Тестовая метаинформация\n")
 
-	reader := input
+	uploader := new(MockBinaryUploader)
+	uploader.On("Upload", mock.Anything, "token", tmpFile.Name(), "Тестовая метаинформация", int32(0)).
+		Return(int32(12), nil)
+
 	writer := &bytes.Buffer{}
 	c := &AddCommand{
-		reader: reader,
-		writer: writer,
+		uploader:    uploader,
+		tokenHolder: &entity.TokenHolder{Token: "token"},
+		reader:      input,
+		writer:      writer,
 	}
 
-	scanner := bufio.NewScanner(reader)
-
-	dataItem, err := c.inputBinaryData(scanner)
+	err = c.uploadBinaryData(bufio.NewScanner(input))
 	if err != nil {
 		t.Errorf("Ожидалась успешная обработка, получили ошибку: %v", err)
 	}
 
-	if dataItem.InfoType != "binary" {
-		t.Errorf("Ожидался InfoType 'binary', получили '%s'", dataItem.InfoType)
+	expected := fmt.Sprintf("Файл %s загружен с ID: 12", filepath.Base(tmpFile.Name()))
+	if !strings.Contains(writer.String(), expected) {
+		t.Errorf("Ожидался вывод '%s', получили '%s'", expected, writer.String())
 	}
+	uploader.AssertExpectations(t)
+}
 
-	if dataItem.Meta != "Тестовая метаинформация" {
-		t.Errorf("Ожидалась Meta 'Тестовая метаинформация', получили '%s'", dataItem.Meta)
+func TestUploadBinaryData_UploadError(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "testfile")
+	if err != nil {
+		t.Fatalf("Не удалось создать временный файл: %v", err)
 	}
+	defer os.Remove(tmpFile.Name())
+	tmpFile.Close()
 
-	var binaryData entity.BinaryData
-	if err := json.Unmarshal(dataItem.Info, &binaryData); err != nil {
-		t.Errorf("Не удалось распарсить Info: %v", err)
-	}
+	input := bytes.NewBufferString(tmpFile.Name() + "\nmeta\n")
 
-	expectedFileName := filepath.Base(tmpFile.Name())
-	if binaryData.FileName != expectedFileName {
-		t.Errorf("Ожидалось имя файла '%s', получили '%s'", expectedFileName, binaryData.FileName)
-	}
+	uploader := new(MockBinaryUploader)
+	uploader.On("Upload", mock.Anything, "token", tmpFile.Name(), "meta", int32(0)).
+		Return(int32(0), errors.New("connection lost"))
 
-	if !bytes.Equal(binaryData.FileContent, fileContent) {
-		t.Errorf("Ожидалось содержимое файла '%s', получили '%s'", fileContent, binaryData.FileContent)
+	c := &AddCommand{
+		uploader:    uploader,
+		tokenHolder: &entity.TokenHolder{Token: "token"},
+		reader:      input,
+		writer:      &bytes.Buffer{},
 	}
+
+	err = c.uploadBinaryData(bufio.NewScanner(input))
+	assert.ErrorContains(t, err, "ошибка загрузки файла: connection lost")
 }
 
-func TestInputBinaryData_InvalidFilePath(t *testing.T) {
+func TestUploadBinaryData_InvalidFilePath(t *testing.T) {
 	input := bytes.NewBufferString("/не/существующий/путь\nТестовая метаинформация\n")
 
 	reader := input
@@ -352,7 +407,7 @@ func TestInputBinaryData_InvalidFilePath(t *testing.T) {
 
 	scanner := bufio.NewScanner(reader)
 
-	dataItem, err := c.inputBinaryData(scanner)
+	err := c.uploadBinaryData(scanner)
 	if err == nil {
 		t.Errorf("Ожидалась ошибка из-за некорректного пути к файлу")
 	} else {
@@ -361,13 +416,9 @@ func TestInputBinaryData_InvalidFilePath(t *testing.T) {
 			t.Errorf("Ожидалась ошибка, содержащая '%s', получили '%v'", expectedError, err)
 		}
 	}
-
-	if dataItem != nil {
-		t.Errorf("Ожидалось, что dataItem будет nil при ошибке")
-	}
 }
 
-func TestInputBinaryData_ScannerErrorOnFilePath(t *testing.T) {
+func TestUploadBinaryData_ScannerErrorOnFilePath(t *testing.T) {
 	input := bytes.NewBufferString("")
 
 	reader := input
@@ -379,7 +430,7 @@ func TestInputBinaryData_ScannerErrorOnFilePath(t *testing.T) {
 
 	scanner := bufio.NewScanner(reader)
 
-	dataItem, err := c.inputBinaryData(scanner)
+	err := c.uploadBinaryData(scanner)
 	if err == nil {
 		t.Errorf("Ожидалась ошибка из-за отсутствия ввода пути к файлу")
 	} else {
@@ -388,13 +439,9 @@ func TestInputBinaryData_ScannerErrorOnFilePath(t *testing.T) {
 			t.Errorf("Ожидалась ошибка, содержащая '%s', получили '%v'", expectedError, err)
 		}
 	}
-
-	if dataItem != nil {
-		t.Errorf("Ожидалось, что dataItem будет nil при ошибке")
-	}
 }
 
-func TestInputBinaryData_ScannerErrorOnMeta(t *testing.T) {
+func TestUploadBinaryData_ScannerErrorOnMeta(t *testing.T) {
 	tmpFile, err := os.CreateTemp("", "testfile")
 	if err != nil {
 		t.Fatalf("Не удалось создать временный файл: %v", err)
@@ -418,7 +465,7 @@ func TestInputBinaryData_ScannerErrorOnMeta(t *testing.T) {
 
 	scanner := bufio.NewScanner(reader)
 
-	dataItem, err := c.inputBinaryData(scanner)
+	err = c.uploadBinaryData(scanner)
 	if err == nil {
 		t.Errorf("Ожидалась ошибка из-за отсутствия метаинформации")
 	} else {
@@ -427,8 +474,117 @@ func TestInputBinaryData_ScannerErrorOnMeta(t *testing.T) {
 			t.Errorf("Ожидалась ошибка, содержащая '%s', получили '%v'", expectedError, err)
 		}
 	}
+}
 
-	if dataItem != nil {
-		t.Errorf("Ожидалось, что dataItem будет nil при ошибке")
-	}
+func TestAddCommand_Run(t *testing.T) {
+	t.Run("Текст из stdin", func(t *testing.T) {
+		mockService := new(MockDataService)
+		mockService.On("AddData", mock.Anything, "token", mock.MatchedBy(func(item *datapb.DataItem) bool {
+			return item.InfoType == "text" && item.Meta == "заметка" && item.GetText().GetText() == "секрет\nв две строки"
+		})).Return(int32(42), nil)
+
+		writer := &bytes.Buffer{}
+		cmd := NewAddCommand(mockService, nil, nil, &entity.TokenHolder{Token: "token"},
+			strings.NewReader("секрет\nв две строки\n"), writer)
+
+		assert.NoError(t, cmd.Run([]string{"text", "--meta", "заметка", "--stdin"}))
+		assert.Equal(t, "42\n", writer.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Пароль из stdin", func(t *testing.T) {
+		mockService := new(MockDataService)
+		mockService.On("AddData", mock.Anything, "token", mock.MatchedBy(func(item *datapb.DataItem) bool {
+			data := item.GetLoginPassword()
+			return item.InfoType == "login_password" && data.GetLogin() == "user" && data.GetPassword() == "pass"
+		})).Return(int32(7), nil)
+
+		cmd := NewAddCommand(mockService, nil, nil, &entity.TokenHolder{Token: "token"},
+			strings.NewReader("pass\r\n"), &bytes.Buffer{})
+
+		assert.NoError(t, cmd.Run([]string{"--login", "user", "login_password", "--stdin"}))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Файл", func(t *testing.T) {
+		uploader := new(MockBinaryUploader)
+		uploader.On("Upload", mock.Anything, "token", "report.pdf", "отчёт", int32(0)).Return(int32(9), nil)
+
+		writer := &bytes.Buffer{}
+		cmd := NewAddCommand(nil, uploader, nil, &entity.TokenHolder{Token: "token"}, strings.NewReader(""), writer)
+
+		assert.NoError(t, cmd.Run([]string{"binary", "--file", "report.pdf", "--meta", "отчёт"}))
+		assert.Equal(t, "9\n", writer.String())
+		uploader.AssertExpectations(t)
+	})
+
+	t.Run("Неверные аргументы", func(t *testing.T) {
+		cmd := NewAddCommand(nil, nil, nil, &entity.TokenHolder{Token: "token"}, strings.NewReader(""), &bytes.Buffer{})
+
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run(nil)))
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"note"})))
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"binary"})))
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"binary", "--file", "a", "--stdin"})))
+	})
+
+	t.Run("TOTP из otpauth URI", func(t *testing.T) {
+		mockService := new(MockDataService)
+		mockService.On("AddData", mock.Anything, "token", mock.MatchedBy(func(item *datapb.DataItem) bool {
+			data := item.GetTotp()
+			return item.InfoType == "totp" && data.GetSecret() == "JBSWY3DPEHPK3PXP" &&
+				data.GetIssuer() == "GitHub" && data.GetAccount() == "alice" && data.GetDigits() == 8
+		})).Return(int32(13), nil)
+
+		writer := &bytes.Buffer{}
+		cmd := NewAddCommand(mockService, nil, nil, &entity.TokenHolder{Token: "token"},
+			strings.NewReader("otpauth://totp/GitHub:alice?secret=JBSWY3DPEHPK3PXP&digits=8\n"), writer)
+
+		assert.NoError(t, cmd.Run([]string{"totp", "--stdin"}))
+		assert.Equal(t, "13\n", writer.String())
+		mockService.AssertExpectations(t)
+
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"totp", "--secret", "не base32"})))
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"totp", "--secret", "otpauth://totp/x?secret=A&digits=4"})))
+	})
+
+	t.Run("Логин со ссылкой на TOTP", func(t *testing.T) {
+		mockService := new(MockDataService)
+		mockService.On("AddData", mock.Anything, "token", mock.MatchedBy(func(item *datapb.DataItem) bool {
+			return item.GetLoginPassword().GetLogin() == "user" && item.GetLoginPassword().GetTotpId() == 13
+		})).Return(int32(14), nil)
+
+		cmd := NewAddCommand(mockService, nil, nil, &entity.TokenHolder{Token: "token"},
+			strings.NewReader(""), &bytes.Buffer{})
+
+		assert.NoError(t, cmd.Run([]string{"login_password", "--login", "user", "--totp", "13"}))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Пользовательский тип", func(t *testing.T) {
+		mockService := new(MockDataService)
+		mockService.On("AddData", mock.Anything, "token", mock.MatchedBy(func(item *datapb.DataItem) bool {
+			fields := item.GetCustom().GetFields()
+			return item.InfoType == "wifi" && len(fields) == 2 && fields["ssid"] == "home" && fields["password"] == "secret"
+		})).Return(int32(11), nil)
+
+		writer := &bytes.Buffer{}
+		cmd := NewAddCommand(mockService, nil, newWifiTypes(), &entity.TokenHolder{Token: "token"},
+			strings.NewReader("secret\n"), writer)
+
+		assert.NoError(t, cmd.Run([]string{"wifi", "--set", "ssid=home", "--set", "expires=", "--stdin"}))
+		assert.Equal(t, "11\n", writer.String())
+		mockService.AssertExpectations(t)
+
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"wifi", "--set", "password=secret"})))
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"wifi", "--set", "ssid"})))
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"wifi", "--set", "ssid=home", "--set", "expires=завтра"})))
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"passport", "--set", "number=1"})))
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"text", "--set", "text=x"})))
+	})
+
+	t.Run("Нет входа", func(t *testing.T) {
+		cmd := NewAddCommand(nil, nil, nil, &entity.TokenHolder{}, strings.NewReader(""), &bytes.Buffer{})
+
+		assert.ErrorIs(t, cmd.Run([]string{"text", "--text", "x"}), ErrNotLoggedIn)
+	})
 }
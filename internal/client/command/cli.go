@@ -0,0 +1,255 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/output"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Коды завершения клиента при вызове команды из командной строки.
+const (
+	ExitOK              = 0
+	ExitError           = 1
+	ExitUsage           = 2
+	ExitUnauthenticated = 3
+)
+
+// ErrNotLoggedIn - команда требует входа в систему.
+var ErrNotLoggedIn = errors.New("вы должны войти в систему")
+
+// Runner - команда, которую можно вызвать одной строкой с флагами, без интерактивных вопросов.
+type Runner interface {
+	Command
+	Run(args []string) error
+}
+
+// usageError - команда вызвана с неверными аргументами.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+func usagef(format string, args ...any) error {
+	return &usageError{err: fmt.Errorf(format, args...)}
+}
+
+// CLI выполняет одну команду по аргументам командной строки, например `get 42 --field password`.
+type CLI struct {
+	commands map[string]Runner
+	names    []string
+	writer   io.Writer
+}
+
+// NewCLI конструктор CLI. Ошибки и справка пишутся в writer, результат команды - в её собственный writer.
+func NewCLI(commands []Command, writer io.Writer) *CLI {
+	cli := &CLI{
+		commands: make(map[string]Runner, len(commands)),
+		writer:   writer,
+	}
+	for _, cmd := range commands {
+		runner, ok := cmd.(Runner)
+		if !ok {
+			cli.commands[cmd.Name()] = nil
+			continue
+		}
+		cli.commands[cmd.Name()] = runner
+		cli.names = append(cli.names, cmd.Name())
+	}
+
+	return cli
+}
+
+// Run выполняет команду args[0] с остальными аргументами и возвращает код завершения.
+func (c *CLI) Run(args []string) int {
+	if len(args) == 0 {
+		c.usage()
+		return ExitUsage
+	}
+
+	cmd, ok := c.commands[args[0]]
+	if !ok {
+		fmt.Fprintf(c.writer, "Неизвестная команда: %s\n", args[0])
+		c.usage()
+		return ExitUsage
+	}
+	if cmd == nil {
+		fmt.Fprintf(c.writer, "Команда %s доступна только в интерактивном режиме\n", args[0])
+		return ExitUsage
+	}
+
+	err := cmd.Run(args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+	if err != nil {
+		fmt.Fprintf(c.writer, "Ошибка: %s\n", errorMessage(err))
+	}
+
+	return ExitCode(err)
+}
+
+// RunSignedIn выполняет команду, как Run, но без сохранённой сессии или после отказа сервера
+// входит с creds, если в них задан логин. Мастер-пароль из creds разблокирует хранилище.
+// Повтор после входа читает тот же ввод, что первая попытка: stdin перематывается к началу.
+func (c *CLI) RunSignedIn(login *LoginCommand, stdin *ReplayReader, args []string, creds Credentials) int {
+	if len(args) > 0 && args[0] == login.Name() {
+		return c.Run(args)
+	}
+
+	if creds.MasterPassword != "" {
+		if err := login.Unlock(creds.MasterPassword); err != nil {
+			fmt.Fprintf(c.writer, "Ошибка: %v\n", err)
+			return ExitCode(err)
+		}
+	}
+
+	signedIn := false
+	if login.tokenHolder.Token == "" && creds.Login != "" {
+		if err := login.LoginWith(creds); err != nil {
+			fmt.Fprintf(c.writer, "Ошибка: %v\n", err)
+			return ExitCode(err)
+		}
+		signedIn = true
+	}
+
+	code := c.Run(args)
+	if code != ExitUnauthenticated || signedIn || creds.Login == "" {
+		return code
+	}
+
+	if err := login.LoginWith(creds); err != nil {
+		fmt.Fprintf(c.writer, "Ошибка: %v\n", err)
+		return ExitCode(err)
+	}
+	stdin.Rewind()
+
+	return c.Run(args)
+}
+
+// errorMessage - текст ошибки команды для пользователя: ответ сервера в нём заменён сообщением сервера
+// без кода gRPC.
+func errorMessage(err error) string {
+	var statusErr interface {
+		error
+		GRPCStatus() *status.Status
+	}
+	if !errors.As(err, &statusErr) {
+		return err.Error()
+	}
+
+	return strings.Replace(err.Error(), statusErr.Error(), statusErr.GRPCStatus().Message(), 1)
+}
+
+func (c *CLI) usage() {
+	fmt.Fprintf(c.writer, "Использование: gophkeeper [флаги] <команда> [аргументы]\n")
+	fmt.Fprintf(c.writer, "Команды: %s\n", strings.Join(c.names, ", "))
+	fmt.Fprintln(c.writer, "Без команды клиент запускается в интерактивном режиме.")
+}
+
+// ExitCode возвращает код завершения, соответствующий ошибке команды.
+func ExitCode(err error) int {
+	var usageErr *usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usageErr):
+		return ExitUsage
+	case errors.Is(err, ErrNotLoggedIn), status.Code(err) == codes.Unauthenticated:
+		return ExitUnauthenticated
+	default:
+		return ExitError
+	}
+}
+
+// newFlagSet создаёт набор флагов команды, который не завершает процесс при ошибке разбора.
+// Ошибку разбора печатает CLI, поэтому сам набор флагов ничего не выводит.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parseArgs разбирает флаги вперемешку с позиционными аргументами: `get 42 --field password`
+// и `get --field password 42` равнозначны. Возвращает позиционные аргументы.
+// На -h печатает справку по флагам в writer.
+func parseArgs(fs *flag.FlagSet, args []string, writer io.Writer) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				fs.SetOutput(writer)
+				fmt.Fprintf(writer, "Использование: gophkeeper %s\n", fs.Name())
+				fs.PrintDefaults()
+				return nil, err
+			}
+			return nil, &usageError{err: err}
+		}
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		args = rest
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// stringsFlag - флаг, который можно указать несколько раз: каждое значение добавляется в конец.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// parseID разбирает ID записи из аргумента командной строки.
+func parseID(arg string) (int32, error) {
+	id64, err := strconv.ParseInt(arg, 10, 32)
+	if err != nil {
+		return 0, usagef("некорректный ID: %w", err)
+	}
+
+	return int32(id64), nil
+}
+
+// outputFlag добавляет флаги формата вывода --output и -o. Пустое значение - формат, заданный для клиента.
+func outputFlag(fs *flag.FlagSet) *string {
+	format := fs.String("output", "", "формат вывода: text, table, json или yaml")
+	fs.StringVar(format, "o", "", "сокращение для --output")
+	return format
+}
+
+// formatterFor возвращает форматтер для формата из флага, а если флаг не задан - форматтер клиента.
+func formatterFor(defaultFormatter output.Formatter, format string) (output.Formatter, error) {
+	if format == "" {
+		return defaultFormatter, nil
+	}
+
+	formatter, err := output.New(format)
+	if err != nil {
+		return nil, &usageError{err: err}
+	}
+
+	return formatter, nil
+}
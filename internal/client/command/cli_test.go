@@ -0,0 +1,187 @@
+package command
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeRunner struct {
+	name string
+	err  error
+	args []string
+}
+
+func (r *fakeRunner) Name() string {
+	return r.name
+}
+
+func (r *fakeRunner) Execute() error {
+	return nil
+}
+
+func (r *fakeRunner) Run(args []string) error {
+	r.args = args
+	return r.err
+}
+
+type interactiveOnly struct{}
+
+func (interactiveOnly) Name() string {
+	return "watch"
+}
+
+func (interactiveOnly) Execute() error {
+	return nil
+}
+
+func TestCLI_Run(t *testing.T) {
+	runner := &fakeRunner{name: "get"}
+	output := &bytes.Buffer{}
+	cli := NewCLI([]Command{runner, interactiveOnly{}}, output)
+
+	assert.Equal(t, ExitOK, cli.Run([]string{"get", "42", "--field", "password"}))
+	assert.Equal(t, []string{"42", "--field", "password"}, runner.args)
+	assert.Empty(t, output.String())
+
+	assert.Equal(t, ExitUsage, cli.Run(nil))
+	assert.Contains(t, output.String(), "Команды: get")
+
+	output.Reset()
+	assert.Equal(t, ExitUsage, cli.Run([]string{"unknown"}))
+	assert.Contains(t, output.String(), "Неизвестная команда: unknown")
+
+	output.Reset()
+	assert.Equal(t, ExitUsage, cli.Run([]string{"watch"}))
+	assert.Contains(t, output.String(), "только в интерактивном режиме")
+
+	output.Reset()
+	runner.err = fmt.Errorf("ошибка получения данных: %w", errors.New("boom"))
+	assert.Equal(t, ExitError, cli.Run([]string{"get", "1"}))
+	assert.Contains(t, output.String(), "Ошибка: ошибка получения данных: boom")
+
+	output.Reset()
+	runner.err = fmt.Errorf("ошибка получения версии: %w", status.Error(codes.NotFound, "версия записи не найдена"))
+	assert.Equal(t, ExitError, cli.Run([]string{"get", "1"}))
+	assert.Equal(t, "Ошибка: ошибка получения версии: версия записи не найдена\n", output.String())
+}
+
+func TestCLI_RunSignedIn_RetriesWithSameStdin(t *testing.T) {
+	authService := new(MockService)
+	authService.On("Login", mock.Anything, "user", "pass").Return(&entity.LoginResult{Token: "fresh"}, nil).Once()
+
+	isSecret := mock.MatchedBy(func(item *datapb.DataItem) bool {
+		return item.InfoType == "text" && item.GetText().GetText() == "секрет"
+	})
+	dataService := new(MockDataService)
+	dataService.On("AddData", mock.Anything, "stale", isSecret).
+		Return(int32(0), status.Error(codes.Unauthenticated, "сессия отозвана")).Once()
+	dataService.On("AddData", mock.Anything, "fresh", isSecret).Return(int32(42), nil).Once()
+
+	tokenHolder := &entity.TokenHolder{Token: "stale"}
+	stdin := NewReplayReader(strings.NewReader("секрет\n"))
+	output := &bytes.Buffer{}
+	login := NewLoginCommand(authService, nil, tokenHolder, stdin, output)
+	add := NewAddCommand(dataService, nil, nil, tokenHolder, stdin, output)
+	cli := NewCLI([]Command{login, add}, output)
+
+	code := cli.RunSignedIn(login, stdin, []string{"add", "text", "--stdin"}, Credentials{Login: "user", Password: "pass"})
+
+	assert.Equal(t, ExitOK, code)
+	assert.Contains(t, output.String(), "42\n")
+	authService.AssertExpectations(t)
+	dataService.AssertExpectations(t)
+}
+
+func TestReplayReader(t *testing.T) {
+	reader := NewReplayReader(strings.NewReader("первая\nвторая\n"))
+
+	scanner := bufio.NewScanner(reader)
+	assert.True(t, scanner.Scan())
+	assert.Equal(t, "первая", scanner.Text())
+
+	reader.Rewind()
+	all, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "первая\nвторая\n", string(all))
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "Успех", err: nil, want: ExitOK},
+		{name: "Неверные аргументы", err: usagef("укажите ID записи"), want: ExitUsage},
+		{name: "Нет входа", err: ErrNotLoggedIn, want: ExitUnauthenticated},
+		{
+			name: "Сервер отклонил токен",
+			err:  fmt.Errorf("ошибка получения данных: %w", status.Error(codes.Unauthenticated, "сессия завершена")),
+			want: ExitUnauthenticated,
+		},
+		{name: "Прочая ошибка", err: errors.New("boom"), want: ExitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		positional []string
+		field      string
+		wantCode   int
+	}{
+		{
+			name:       "Флаги после аргумента",
+			args:       []string{"42", "--field", "password"},
+			positional: []string{"42"},
+			field:      "password",
+		},
+		{name: "Флаги до аргумента", args: []string{"-field=url", "42"}, positional: []string{"42"}, field: "url"},
+		{name: "Аргументы после --", args: []string{"--", "-1", "--field"}, positional: []string{"-1", "--field"}},
+		{name: "Неизвестный флаг", args: []string{"--unknown"}, wantCode: ExitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newFlagSet("get")
+			field := fs.String("field", "", "")
+
+			positional, err := parseArgs(fs, tt.args, &bytes.Buffer{})
+
+			assert.Equal(t, tt.wantCode, ExitCode(err))
+			assert.Equal(t, tt.positional, positional)
+			assert.Equal(t, tt.field, *field)
+		})
+	}
+}
+
+func TestParseArgs_Help(t *testing.T) {
+	fs := newFlagSet("list [флаги]")
+	fs.Bool("json", false, "вывести список в формате JSON")
+	output := &bytes.Buffer{}
+
+	_, err := parseArgs(fs, []string{"-h"}, output)
+
+	assert.Error(t, err)
+	assert.Contains(t, output.String(), "Использование: gophkeeper list [флаги]")
+	assert.Contains(t, output.String(), "вывести список в формате JSON")
+}
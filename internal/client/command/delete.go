@@ -41,7 +41,7 @@ func (c *DeleteCommand) Name() string {
 
 func (c *DeleteCommand) Execute() error {
 	if c.tokenHolder.Token == "" {
-		return fmt.Errorf("вы должны войти в систему")
+		return ErrNotLoggedIn
 	}
 
 	_, err := fmt.Fprint(c.writer, "Введите ID данных: ")
@@ -61,15 +61,44 @@ func (c *DeleteCommand) Execute() error {
 	}
 	id := int32(id64)
 
-	err = c.dataService.DeleteData(context.Background(), c.tokenHolder.Token, id)
+	err = c.delete(id)
 	if err != nil {
-		return fmt.Errorf("ошибка удаления данных: %w", err)
+		return err
 	}
 
-	_, err = fmt.Fprintln(c.writer, "Данные успешно удалены.")
+	_, err = fmt.Fprintln(c.writer, "Данные перенесены в корзину.")
 	if err != nil {
 		return fmt.Errorf("ошибка вывода результата: %w", err)
 	}
 
 	return nil
 }
+
+// Run удаляет запись по ID: `delete 42`. При успехе ничего не выводит.
+func (c *DeleteCommand) Run(args []string) error {
+	positional, err := parseArgs(newFlagSet("delete <id>"), args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("укажите ID записи")
+	}
+	id, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	return c.delete(id)
+}
+
+func (c *DeleteCommand) delete(id int32) error {
+	err := c.dataService.DeleteData(context.Background(), c.tokenHolder.Token, id)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления данных: %w", err)
+	}
+
+	return nil
+}
@@ -39,7 +39,7 @@ func TestDeleteCommand_Execute(t *testing.T) {
 			mockSetup: func(m *MockDeleteDataService) {
 				m.On("DeleteData", context.Background(), "valid_token", int32(1)).Return(nil)
 			},
-			expectedOutput: "Введите ID данных: Данные успешно удалены.\n",
+			expectedOutput: "Введите ID данных: Данные перенесены в корзину.\n",
 			expectedError:  nil,
 		},
 		{
@@ -141,3 +141,19 @@ func TestDeleteCommand_Name(t *testing.T) {
 	actualName := cmd.Name()
 	assert.Equal(t, expectedName, actualName, "Название команды должно быть 'delete'")
 }
+
+func TestDeleteCommand_Run(t *testing.T) {
+	mockService := new(MockDeleteDataService)
+	mockService.On("DeleteData", mock.Anything, "token", int32(42)).Return(nil)
+
+	writer := &bytes.Buffer{}
+	cmd := NewDeleteCommand(mockService, &entity.TokenHolder{Token: "token"}, strings.NewReader(""), writer)
+
+	assert.NoError(t, cmd.Run([]string{"42"}))
+	assert.Empty(t, writer.String())
+	mockService.AssertExpectations(t)
+
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run(nil)))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"1", "2"})))
+	assert.ErrorIs(t, NewDeleteCommand(mockService, &entity.TokenHolder{}, nil, nil).Run([]string{"1"}), ErrNotLoggedIn)
+}
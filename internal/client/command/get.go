@@ -8,17 +8,29 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/NikolosHGW/goph-keeper/api/datapb"
 	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/client/output"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
 )
 
 type getDataService interface {
 	GetData(ctx context.Context, token string, id int32) (*datapb.DataItem, error)
 }
 
+// binaryDownloader скачивает файл, загруженный по частям, и сверяет его контрольную сумму.
+type binaryDownloader interface {
+	Download(ctx context.Context, token string, id int32, info *datapb.Binary, filePath string) error
+}
+
 type GetCommand struct {
 	dataService getDataService
+	downloader  binaryDownloader
+	recordTypes recordTypeLookup
+	formatter   output.Formatter
 	tokenHolder *entity.TokenHolder
 	reader      io.Reader
 	writer      io.Writer
@@ -26,12 +38,18 @@ type GetCommand struct {
 
 func NewGetCommand(
 	dataService getDataService,
+	downloader binaryDownloader,
+	recordTypes recordTypeLookup,
+	formatter output.Formatter,
 	tokenHolder *entity.TokenHolder,
 	reader io.Reader,
 	writer io.Writer,
 ) *GetCommand {
 	return &GetCommand{
 		dataService: dataService,
+		downloader:  downloader,
+		recordTypes: recordTypes,
+		formatter:   formatter,
 		tokenHolder: tokenHolder,
 		reader:      reader,
 		writer:      writer,
@@ -44,7 +62,7 @@ func (c *GetCommand) Name() string {
 
 func (c *GetCommand) Execute() error {
 	if c.tokenHolder.Token == "" {
-		return fmt.Errorf("вы должны войти в систему")
+		return ErrNotLoggedIn
 	}
 
 	_, err := fmt.Fprint(c.writer, "Введите ID данных: ")
@@ -64,54 +82,168 @@ func (c *GetCommand) Execute() error {
 	}
 	id := int32(id64)
 
+	dataItem, err := c.fetch(id)
+	if err != nil {
+		return err
+	}
+
+	recordType := c.schema(dataItem.InfoType)
+	reveal := false
+	if recordType != nil && sensitiveField(recordType) != nil {
+		fmt.Fprint(c.writer, "Показать скрытые поля? (y/N): ")
+		reveal = scanner.Scan() && strings.EqualFold(strings.TrimSpace(scanner.Text()), "y")
+	}
+
+	return c.print(c.formatter, dataItem, recordType, reveal, "")
+}
+
+// Run выводит запись по ID: `get 42` печатает её целиком, `get 42 --field password` - только значение поля.
+// Скрытые поля пользовательских типов выводятся только с --reveal или по --field.
+func (c *GetCommand) Run(args []string) error {
+	fs := newFlagSet("get <id> [флаги]")
+	field := fs.String("field", "", "вывести только значение поля, например password, text или meta")
+	reveal := fs.Bool("reveal", false, "показать значения скрытых полей пользовательского типа")
+	out := fs.String("out", "", "путь для сохранения файла (binary), по умолчанию - исходное имя файла")
+	format := outputFlag(fs)
+
+	positional, err := parseArgs(fs, args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("укажите ID записи")
+	}
+	id, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+	formatter, err := formatterFor(c.formatter, *format)
+	if err != nil {
+		return err
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	dataItem, err := c.fetch(id)
+	if err != nil {
+		return err
+	}
+
+	if *field != "" {
+		return c.printField(dataItem, *field)
+	}
+
+	return c.print(formatter, dataItem, c.schema(dataItem.InfoType), *reveal, *out)
+}
+
+func (c *GetCommand) fetch(id int32) (*datapb.DataItem, error) {
 	dataItem, err := c.dataService.GetData(context.Background(), c.tokenHolder.Token, id)
 	if err != nil {
-		return fmt.Errorf("ошибка получения данных: %w", err)
+		return nil, fmt.Errorf("ошибка получения данных: %w", err)
 	}
 
-	fmt.Fprintf(c.writer, "ID: %d\n", dataItem.Id)
-	fmt.Fprintf(c.writer, "Тип: %s\n", dataItem.InfoType)
-	fmt.Fprintf(c.writer, "Данные: %s\n", dataItem.Info)
-	fmt.Fprintf(c.writer, "Мета: %s\n", dataItem.Meta)
-	fmt.Fprintf(c.writer, "Создано: %s\n", dataItem.Created.AsTime())
+	return dataItem, nil
+}
 
-	switch dataItem.InfoType {
-	case "login_password":
-		var loginPasswordData entity.LoginPasswordData
-		if err := json.Unmarshal(dataItem.Info, &loginPasswordData); err != nil {
-			return fmt.Errorf("ошибка десериализации данных: %w", err)
-		}
-		fmt.Fprintf(c.writer, "Логин: %s\n", loginPasswordData.Login)
-		fmt.Fprintf(c.writer, "Пароль: %s\n", loginPasswordData.Password)
-		fmt.Fprintf(c.writer, "URL: %s\n", loginPasswordData.URL)
-	case "text":
-		var textData entity.TextData
-		if err := json.Unmarshal(dataItem.Info, &textData); err != nil {
-			return fmt.Errorf("ошибка десериализации данных: %w", err)
-		}
-		fmt.Fprintf(c.writer, "Текст: %s\n", textData.Text)
-	case "binary":
-		var binaryData entity.BinaryData
-		if err := json.Unmarshal(dataItem.Info, &binaryData); err != nil {
-			return fmt.Errorf("ошибка десериализации данных: %w", err)
-		}
-		err = os.WriteFile(binaryData.FileName, binaryData.FileContent, 0644)
+// printField печатает одно поле записи без подписи, чтобы значение можно было подставить в скрипт.
+// Имя поля сравнивается без учёта регистра: password, Password и PASSWORD равнозначны.
+func (c *GetCommand) printField(dataItem *datapb.DataItem, name string) error {
+	record, err := output.NewRecord(dataItem)
+	if err != nil {
+		return err
+	}
+
+	value, ok := record.Lookup(strings.ToLower(name))
+	if !ok {
+		return usagef("поле %s не найдено, доступны: %s", name, strings.Join(record.Names(), ", "))
+	}
+
+	switch v := value.(type) {
+	case string:
+		fmt.Fprintln(c.writer, v)
+	case time.Time:
+		fmt.Fprintln(c.writer, v.Format(time.RFC3339))
+	default:
+		raw, err := json.Marshal(v)
 		if err != nil {
-			return fmt.Errorf("ошибка сохранения файла: %w", err)
+			return fmt.Errorf("ошибка сериализации поля: %w", err)
 		}
-		fmt.Fprintf(c.writer, "Бинарные данные сохранены в файл: %s\n", binaryData.FileName)
-	case "bank_card":
-		var bankCardData entity.BankCardData
-		if err := json.Unmarshal(dataItem.Info, &bankCardData); err != nil {
-			return fmt.Errorf("ошибка десериализации данных: %w", err)
-		}
-		fmt.Fprintf(c.writer, "Номер карты: %s\n", bankCardData.CardNumber)
-		fmt.Fprintf(c.writer, "Срок действия: %s\n", bankCardData.ExpiryDate)
-		fmt.Fprintf(c.writer, "CVV: %s\n", bankCardData.CVV)
-		fmt.Fprintf(c.writer, "Имя держателя: %s\n", bankCardData.HolderName)
-	default:
-		fmt.Fprintln(c.writer, "Неизвестный тип данных")
+		fmt.Fprintln(c.writer, string(raw))
 	}
 
 	return nil
 }
+
+// schema возвращает схему пользовательского типа записи. Если схема недоступна, например без сети,
+// возвращает nil: тогда поля выводятся как есть.
+func (c *GetCommand) schema(infoType string) *datapb.RecordType {
+	if c.recordTypes == nil || payload.IsBuiltin(infoType) {
+		return nil
+	}
+
+	recordType, err := c.recordTypes.RecordType(context.Background(), c.tokenHolder.Token, infoType)
+	if err != nil {
+		return nil
+	}
+
+	return recordType
+}
+
+// print выводит запись целиком, а запись пользовательского типа - по схеме recordType, если она есть.
+// Файл сохраняется в filePath, а если он пуст - под исходным именем.
+func (c *GetCommand) print(
+	formatter output.Formatter,
+	dataItem *datapb.DataItem,
+	recordType *datapb.RecordType,
+	reveal bool,
+	filePath string,
+) error {
+	record, err := output.NewRecord(dataItem)
+	if err != nil {
+		return err
+	}
+	if recordType != nil {
+		record.ApplySchema(recordType, reveal)
+	}
+
+	if dataItem.InfoType == "binary" {
+		savedTo, err := c.saveFile(dataItem, filePath)
+		if err != nil {
+			return err
+		}
+		record.Fields = append(record.Fields, output.Field{
+			Name:  "saved_to",
+			Label: "Бинарные данные сохранены в файл",
+			Value: savedTo,
+		})
+	}
+
+	return formatter.Record(c.writer, record)
+}
+
+// saveFile сохраняет содержимое файла из записи и возвращает путь к нему.
+func (c *GetCommand) saveFile(dataItem *datapb.DataItem, filePath string) (string, error) {
+	message, err := payload.Decode(dataItem)
+	if err != nil {
+		return "", fmt.Errorf("ошибка десериализации данных: %w", err)
+	}
+	binaryData, ok := message.(*datapb.Binary)
+	if !ok {
+		return "", fmt.Errorf("ошибка десериализации данных: %w", payload.ErrTypeMismatch)
+	}
+	if filePath == "" {
+		filePath = binaryData.FileName
+	}
+
+	if binaryData.Chunked {
+		err = c.downloader.Download(context.Background(), c.tokenHolder.Token, dataItem.Id, binaryData, filePath)
+	} else {
+		err = os.WriteFile(filePath, binaryData.FileContent, 0644)
+	}
+	if err != nil {
+		return "", fmt.Errorf("ошибка сохранения файла: %w", err)
+	}
+
+	return filePath, nil
+}
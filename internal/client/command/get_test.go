@@ -3,7 +3,6 @@ package command
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"io"
 	"os"
@@ -12,10 +11,13 @@ import (
 
 	"github.com/NikolosHGW/goph-keeper/api/datapb"
 	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/client/output"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var textOutput, _ = output.New(output.FormatText)
+
 type mockGetDataService struct {
 	dataItem *datapb.DataItem
 	err      error
@@ -31,7 +33,7 @@ func TestGetCommand_Execute_TokenMissing(t *testing.T) {
 	reader := strings.NewReader("")
 	writer := &bytes.Buffer{}
 
-	getCmd := NewGetCommand(dataService, tokenHolder, reader, writer)
+	getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, reader, writer)
 
 	err := getCmd.Execute()
 	if err == nil || err.Error() != "вы должны войти в систему" {
@@ -45,7 +47,7 @@ func TestGetCommand_Execute_InvalidIDInput(t *testing.T) {
 	reader := strings.NewReader("abc\n")
 	writer := &bytes.Buffer{}
 
-	getCmd := NewGetCommand(dataService, tokenHolder, reader, writer)
+	getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, reader, writer)
 
 	err := getCmd.Execute()
 	if err == nil || !strings.Contains(err.Error(), "некорректный ID") {
@@ -61,7 +63,7 @@ func TestGetCommand_Execute_GetDataError(t *testing.T) {
 	reader := strings.NewReader("1\n")
 	writer := &bytes.Buffer{}
 
-	getCmd := NewGetCommand(dataService, tokenHolder, reader, writer)
+	getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, reader, writer)
 
 	err := getCmd.Execute()
 	if err == nil || !strings.Contains(err.Error(), "ошибка получения данных") {
@@ -70,17 +72,16 @@ func TestGetCommand_Execute_GetDataError(t *testing.T) {
 }
 
 func TestGetCommand_Execute_LoginPasswordData(t *testing.T) {
-	loginPasswordData := entity.LoginPasswordData{
+	loginPasswordData := &datapb.LoginPassword{
 		Login:    "user",
 		Password: "pass",
-		URL:      "http://example.com",
+		Url:      "http://example.com",
 	}
-	infoBytes, _ := json.Marshal(loginPasswordData)
 
 	dataItem := &datapb.DataItem{
 		Id:       1,
 		InfoType: "login_password",
-		Info:     infoBytes,
+		Payload:  &datapb.DataItem_LoginPassword{LoginPassword: loginPasswordData},
 		Meta:     "meta data",
 		Created:  timestamppb.Now(),
 	}
@@ -92,7 +93,7 @@ func TestGetCommand_Execute_LoginPasswordData(t *testing.T) {
 	reader := strings.NewReader("1\n")
 	writer := &bytes.Buffer{}
 
-	getCmd := NewGetCommand(dataService, tokenHolder, reader, writer)
+	getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, reader, writer)
 
 	err := getCmd.Execute()
 	if err != nil {
@@ -112,15 +113,14 @@ func TestGetCommand_Execute_LoginPasswordData(t *testing.T) {
 }
 
 func TestGetCommand_Execute_TextData(t *testing.T) {
-	textData := entity.TextData{
+	textData := &datapb.Text{
 		Text: "some text",
 	}
-	infoBytes, _ := json.Marshal(textData)
 
 	dataItem := &datapb.DataItem{
 		Id:       2,
 		InfoType: "text",
-		Info:     infoBytes,
+		Payload:  &datapb.DataItem_Text{Text: textData},
 		Meta:     "meta data",
 		Created:  timestamppb.Now(),
 	}
@@ -132,7 +132,7 @@ func TestGetCommand_Execute_TextData(t *testing.T) {
 	reader := strings.NewReader("2\n")
 	writer := &bytes.Buffer{}
 
-	getCmd := NewGetCommand(dataService, tokenHolder, reader, writer)
+	getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, reader, writer)
 
 	err := getCmd.Execute()
 	if err != nil {
@@ -146,16 +146,15 @@ func TestGetCommand_Execute_TextData(t *testing.T) {
 }
 
 func TestGetCommand_Execute_BinaryData(t *testing.T) {
-	binaryData := entity.BinaryData{
+	binaryData := &datapb.Binary{
 		FileName:    "testfile.bin",
 		FileContent: []byte{0x00, 0x01, 0x02},
 	}
-	infoBytes, _ := json.Marshal(binaryData)
 
 	dataItem := &datapb.DataItem{
 		Id:       3,
 		InfoType: "binary",
-		Info:     infoBytes,
+		Payload:  &datapb.DataItem_Binary{Binary: binaryData},
 		Meta:     "meta data",
 		Created:  timestamppb.Now(),
 	}
@@ -169,7 +168,7 @@ func TestGetCommand_Execute_BinaryData(t *testing.T) {
 
 	os.Remove("testfile.bin")
 
-	getCmd := NewGetCommand(dataService, tokenHolder, reader, writer)
+	getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, reader, writer)
 
 	err := getCmd.Execute()
 	if err != nil {
@@ -188,19 +187,59 @@ func TestGetCommand_Execute_BinaryData(t *testing.T) {
 	}
 }
 
+type mockBinaryDownloader struct {
+	info     *datapb.Binary
+	err      error
+	id       int32
+	filePath string
+}
+
+func (m *mockBinaryDownloader) Download(
+	ctx context.Context,
+	token string,
+	id int32,
+	info *datapb.Binary,
+	filePath string,
+) error {
+	m.id, m.info, m.filePath = id, info, filePath
+	return m.err
+}
+
+func TestGetCommand_Execute_ChunkedBinaryData(t *testing.T) {
+	// Info в прежнем формате JSON: содержимое разбирается из него, если payload не задан.
+	infoBytes := []byte(`{"FileName":"large.bin","Size":10485760,"SHA256":"abc","Chunked":true}`)
+	dataService := &mockGetDataService{
+		dataItem: &datapb.DataItem{Id: 4, InfoType: "binary", Info: infoBytes, Created: timestamppb.Now()},
+	}
+
+	downloader := &mockBinaryDownloader{}
+	writer := &bytes.Buffer{}
+	tokenHolder := &entity.TokenHolder{Token: "token"}
+	getCmd := NewGetCommand(dataService, downloader, nil, textOutput, tokenHolder, strings.NewReader("4\n"), writer)
+
+	assert.NoError(t, getCmd.Execute())
+	assert.Equal(t, int32(4), downloader.id)
+	assert.Equal(t, "large.bin", downloader.filePath)
+	assert.Equal(t, "abc", downloader.info.Sha256)
+	assert.Contains(t, writer.String(), "Бинарные данные сохранены в файл: large.bin")
+
+	downloader.err = errors.New("контрольная сумма файла не совпала")
+	getCmd = NewGetCommand(dataService, downloader, nil, textOutput, tokenHolder, strings.NewReader("4\n"), writer)
+	assert.ErrorContains(t, getCmd.Execute(), "ошибка сохранения файла")
+}
+
 func TestGetCommand_Execute_BankCardData(t *testing.T) {
-	bankCardData := entity.BankCardData{
+	bankCardData := &datapb.BankCard{
 		CardNumber: "1234-5678-9012-3456",
 		ExpiryDate: "12/24",
-		CVV:        "123",
+		Cvv:        "123",
 		HolderName: "John Doe",
 	}
-	infoBytes, _ := json.Marshal(bankCardData)
 
 	dataItem := &datapb.DataItem{
 		Id:       4,
 		InfoType: "bank_card",
-		Info:     infoBytes,
+		Payload:  &datapb.DataItem_BankCard{BankCard: bankCardData},
 		Meta:     "meta data",
 		Created:  timestamppb.Now(),
 	}
@@ -212,7 +251,7 @@ func TestGetCommand_Execute_BankCardData(t *testing.T) {
 	reader := strings.NewReader("4\n")
 	writer := &bytes.Buffer{}
 
-	getCmd := NewGetCommand(dataService, tokenHolder, reader, writer)
+	getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, reader, writer)
 
 	err := getCmd.Execute()
 	if err != nil {
@@ -250,7 +289,7 @@ func TestGetCommand_Execute_UnknownInfoType(t *testing.T) {
 	reader := strings.NewReader("5\n")
 	writer := &bytes.Buffer{}
 
-	getCmd := NewGetCommand(dataService, tokenHolder, reader, writer)
+	getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, reader, writer)
 
 	err := getCmd.Execute()
 	if err != nil {
@@ -269,7 +308,7 @@ func TestGetCommand_Execute_ScannerError(t *testing.T) {
 	reader := &errorReader{}
 	writer := &bytes.Buffer{}
 
-	getCmd := NewGetCommand(dataService, tokenHolder, reader, writer)
+	getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, reader, writer)
 
 	err := getCmd.Execute()
 	if err == nil || !strings.Contains(err.Error(), "ошибка ввода ID") {
@@ -284,8 +323,139 @@ func (e *errorReader) Read(p []byte) (n int, err error) {
 }
 
 func TestGetCommand_Name(t *testing.T) {
-	cmd := NewGetCommand(nil, nil, nil, nil)
+	cmd := NewGetCommand(nil, nil, nil, textOutput, nil, nil, nil)
 	expectedName := "get"
 	actualName := cmd.Name()
 	assert.Equal(t, expectedName, actualName, "Название команды должно быть 'get'")
 }
+
+func TestGetCommand_Run(t *testing.T) {
+	login := &datapb.LoginPassword{Login: "user", Password: "secret", Url: "https://example.com"}
+	dataService := &mockGetDataService{
+		dataItem: &datapb.DataItem{
+			Id:       42,
+			InfoType: "login_password",
+			Payload:  &datapb.DataItem_LoginPassword{LoginPassword: login},
+			Meta:     "почта",
+		},
+	}
+	tokenHolder := &entity.TokenHolder{Token: "token"}
+
+	t.Run("Одно поле", func(t *testing.T) {
+		writer := &bytes.Buffer{}
+		getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, strings.NewReader(""), writer)
+
+		assert.NoError(t, getCmd.Run([]string{"42", "--field", "password"}))
+		assert.Equal(t, "secret\n", writer.String())
+
+		writer.Reset()
+		assert.NoError(t, getCmd.Run([]string{"--field", "META", "42"}))
+		assert.Equal(t, "почта\n", writer.String())
+	})
+
+	t.Run("Неизвестное поле", func(t *testing.T) {
+		getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, strings.NewReader(""), &bytes.Buffer{})
+
+		err := getCmd.Run([]string{"42", "--field", "cvv"})
+		assert.ErrorContains(t, err, "доступны: id, type, meta, created, login, password, url")
+		assert.Equal(t, ExitUsage, ExitCode(err))
+	})
+
+	t.Run("Вся запись", func(t *testing.T) {
+		writer := &bytes.Buffer{}
+		getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, strings.NewReader(""), writer)
+
+		assert.NoError(t, getCmd.Run([]string{"42"}))
+		assert.Contains(t, writer.String(), "Логин: user")
+	})
+
+	t.Run("Файл в указанный путь", func(t *testing.T) {
+		binary := &datapb.DataItem_Binary{Binary: &datapb.Binary{FileName: "large.bin", Chunked: true}}
+		downloader := &mockBinaryDownloader{}
+		getCmd := NewGetCommand(
+			&mockGetDataService{dataItem: &datapb.DataItem{Id: 4, InfoType: "binary", Payload: binary}},
+			downloader, nil, textOutput, tokenHolder, strings.NewReader(""), &bytes.Buffer{},
+		)
+
+		assert.NoError(t, getCmd.Run([]string{"4", "--out", "/tmp/copy.bin"}))
+		assert.Equal(t, int32(4), downloader.id)
+		assert.Equal(t, "/tmp/copy.bin", downloader.filePath)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		writer := &bytes.Buffer{}
+		getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, strings.NewReader(""), writer)
+
+		assert.NoError(t, getCmd.Run([]string{"42", "--output", "json"}))
+		assert.JSONEq(t, `{
+			"id": 42,
+			"type": "login_password",
+			"meta": "почта",
+			"created": "1970-01-01T00:00:00Z",
+			"data": {"login": "user", "password": "secret", "url": "https://example.com"}
+		}`, writer.String())
+	})
+
+	t.Run("Путь к сохранённому файлу в YAML", func(t *testing.T) {
+		binary := &datapb.DataItem_Binary{Binary: &datapb.Binary{FileName: "large.bin", Size: 3, Chunked: true}}
+		writer := &bytes.Buffer{}
+		getCmd := NewGetCommand(
+			&mockGetDataService{dataItem: &datapb.DataItem{Id: 4, InfoType: "binary", Payload: binary}},
+			&mockBinaryDownloader{}, nil, textOutput, tokenHolder, strings.NewReader(""), writer,
+		)
+
+		assert.NoError(t, getCmd.Run([]string{"4", "-o", "yaml", "--out", "/tmp/copy.bin"}))
+		assert.Contains(t, writer.String(), "saved_to: /tmp/copy.bin\n")
+		assert.Contains(t, writer.String(), "size: 3\n")
+	})
+
+	t.Run("Неверные аргументы", func(t *testing.T) {
+		getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, strings.NewReader(""), &bytes.Buffer{})
+		assert.Equal(t, ExitUsage, ExitCode(getCmd.Run([]string{"42", "-o", "xml"})))
+
+		assert.Equal(t, ExitUsage, ExitCode(getCmd.Run(nil)))
+		assert.Equal(t, ExitUsage, ExitCode(getCmd.Run([]string{"abc"})))
+	})
+}
+
+func TestGetCommand_Custom(t *testing.T) {
+	fields := map[string]string{"ssid": "home", "password": "secret"}
+	wifi := &datapb.DataItem_Custom{Custom: &datapb.Custom{Fields: fields}}
+	dataService := &mockGetDataService{dataItem: &datapb.DataItem{Id: 8, InfoType: "wifi", Payload: wifi}}
+	tokenHolder := &entity.TokenHolder{Token: "token"}
+
+	t.Run("Скрытое поле", func(t *testing.T) {
+		writer := &bytes.Buffer{}
+		getCmd := NewGetCommand(dataService, nil, newWifiTypes(), textOutput, tokenHolder, strings.NewReader(""), writer)
+
+		assert.NoError(t, getCmd.Run([]string{"8"}))
+		assert.Contains(t, writer.String(), "Сеть: home\nПароль: ********\nexpires: \n")
+		assert.NotContains(t, writer.String(), "Неизвестный тип данных")
+
+		writer.Reset()
+		assert.NoError(t, getCmd.Run([]string{"8", "--reveal"}))
+		assert.Contains(t, writer.String(), "Пароль: secret\n")
+
+		writer.Reset()
+		assert.NoError(t, getCmd.Run([]string{"8", "--field", "password"}))
+		assert.Equal(t, "secret\n", writer.String())
+	})
+
+	t.Run("Показ в интерактивном режиме", func(t *testing.T) {
+		writer := &bytes.Buffer{}
+		getCmd := NewGetCommand(dataService, nil, newWifiTypes(), textOutput, tokenHolder,
+			strings.NewReader("8\ny\n"), writer)
+
+		assert.NoError(t, getCmd.Execute())
+		assert.Contains(t, writer.String(), "Показать скрытые поля? (y/N): ")
+		assert.Contains(t, writer.String(), "Пароль: secret\n")
+	})
+
+	t.Run("Схема недоступна", func(t *testing.T) {
+		writer := &bytes.Buffer{}
+		getCmd := NewGetCommand(dataService, nil, nil, textOutput, tokenHolder, strings.NewReader(""), writer)
+
+		assert.NoError(t, getCmd.Run([]string{"8"}))
+		assert.Contains(t, writer.String(), "Неизвестный тип данных\npassword: secret\nssid: home\n")
+	})
+}
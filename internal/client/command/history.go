@@ -0,0 +1,141 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/client/output"
+)
+
+const historyTimeLayout = "2006-01-02 15:04:05"
+
+type historyService interface {
+	ListVersions(ctx context.Context, token string, id int32) ([]*datapb.DataItem, error)
+	GetVersion(ctx context.Context, token string, id int32, revision int64) (*datapb.DataItem, error)
+}
+
+// HistoryCommand выводит прежние версии записи или содержимое одной из них.
+type HistoryCommand struct {
+	historyService historyService
+	formatter      output.Formatter
+	tokenHolder    *entity.TokenHolder
+	reader         io.Reader
+	writer         io.Writer
+}
+
+func NewHistoryCommand(
+	historyService historyService,
+	formatter output.Formatter,
+	tokenHolder *entity.TokenHolder,
+	reader io.Reader,
+	writer io.Writer,
+) *HistoryCommand {
+	return &HistoryCommand{
+		historyService: historyService,
+		formatter:      formatter,
+		tokenHolder:    tokenHolder,
+		reader:         reader,
+		writer:         writer,
+	}
+}
+
+func (c *HistoryCommand) Name() string {
+	return "history"
+}
+
+func (c *HistoryCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	fmt.Fprint(c.writer, "Введите ID данных: ")
+	scanner := bufio.NewScanner(c.reader)
+	if !scanner.Scan() {
+		return fmt.Errorf("ошибка ввода ID: %w", scanner.Err())
+	}
+	id, err := parseID(strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		return err
+	}
+
+	return c.list(id)
+}
+
+// Run выводит версии записи: `history 42` - список ревизий, `history 42 --rev 7` - содержимое версии 7.
+func (c *HistoryCommand) Run(args []string) error {
+	fs := newFlagSet("history <id> [флаги]")
+	rev := fs.Int64("rev", 0, "вывести содержимое версии с этой ревизией")
+	format := outputFlag(fs)
+
+	positional, err := parseArgs(fs, args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("укажите ID записи")
+	}
+	id, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+	formatter, err := formatterFor(c.formatter, *format)
+	if err != nil {
+		return err
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	if *rev == 0 {
+		return c.list(id)
+	}
+
+	version, err := c.historyService.GetVersion(context.Background(), c.tokenHolder.Token, id, *rev)
+	if err != nil {
+		return fmt.Errorf("ошибка получения версии: %w", err)
+	}
+	record, err := output.NewRecord(version)
+	if err != nil {
+		return err
+	}
+
+	return formatter.Record(c.writer, record)
+}
+
+// list печатает ревизии записи от новых к старым. Ревизию из списка можно передать в restore.
+func (c *HistoryCommand) list(id int32) error {
+	versions, err := c.historyService.ListVersions(context.Background(), c.tokenHolder.Token, id)
+	if err != nil {
+		return fmt.Errorf("ошибка получения версий: %w", err)
+	}
+	if len(versions) == 0 {
+		fmt.Fprintln(c.writer, "Прежних версий нет.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(c.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "РЕВИЗИЯ\tСОХРАНЕНО\tТИП\tМЕТА")
+	for _, version := range versions {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", version.Revision,
+			version.Updated.AsTime().Format(historyTimeLayout), version.InfoType, version.Meta)
+	}
+
+	return tw.Flush()
+}
+
+// parseRevision разбирает ревизию версии из аргумента команды.
+func parseRevision(arg string) (int64, error) {
+	revision, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || revision <= 0 {
+		return 0, usagef("некорректная ревизия: %s", arg)
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeHistoryService хранит версии записей в памяти.
+type fakeHistoryService struct {
+	versions map[int32][]*datapb.DataItem
+	restored map[int32]int64
+}
+
+func (f *fakeHistoryService) ListVersions(_ context.Context, _ string, id int32) ([]*datapb.DataItem, error) {
+	return f.versions[id], nil
+}
+
+func (f *fakeHistoryService) GetVersion(
+	_ context.Context,
+	_ string,
+	id int32,
+	revision int64,
+) (*datapb.DataItem, error) {
+	for _, version := range f.versions[id] {
+		if version.Revision == revision {
+			return version, nil
+		}
+	}
+	return nil, errors.New("версия записи не найдена")
+}
+
+func (f *fakeHistoryService) RestoreVersion(_ context.Context, _ string, id int32, revision int64) (int64, error) {
+	if _, err := f.GetVersion(context.Background(), "", id, revision); err != nil {
+		return 0, err
+	}
+	f.restored[id] = revision
+	return 100, nil
+}
+
+func newFakeHistoryService() *fakeHistoryService {
+	saved := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return &fakeHistoryService{
+		versions: map[int32][]*datapb.DataItem{42: {
+			{Id: 42, InfoType: "text", Meta: "заметка v2", Revision: 7, Updated: timestamppb.New(saved),
+				Info: []byte(`{"text":"вторая"}`)},
+			{Id: 42, InfoType: "text", Meta: "заметка", Revision: 3, Updated: timestamppb.New(saved.Add(-time.Hour)),
+				Info: []byte(`{"text":"первая"}`)},
+		}},
+		restored: make(map[int32]int64),
+	}
+}
+
+func TestHistoryCommand_Run(t *testing.T) {
+	writer := &bytes.Buffer{}
+	cmd := NewHistoryCommand(newFakeHistoryService(), textOutput, &entity.TokenHolder{Token: "token"},
+		strings.NewReader(""), writer)
+
+	assert.Equal(t, "history", cmd.Name())
+	assert.NoError(t, cmd.Run([]string{"42"}))
+	assert.Equal(t, `РЕВИЗИЯ  СОХРАНЕНО            ТИП   МЕТА
+7        2024-05-01 12:00:00  text  заметка v2
+3        2024-05-01 11:00:00  text  заметка
+`, writer.String())
+
+	writer.Reset()
+	assert.NoError(t, cmd.Run([]string{"42", "--rev", "3"}))
+	assert.Contains(t, writer.String(), "Текст: первая")
+
+	writer.Reset()
+	assert.NoError(t, cmd.Run([]string{"7"}))
+	assert.Equal(t, "Прежних версий нет.\n", writer.String())
+
+	assert.EqualError(t, cmd.Run([]string{"42", "--rev", "5"}), "ошибка получения версии: версия записи не найдена")
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run(nil)))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"42", "-o", "xml"})))
+
+	cmd = NewHistoryCommand(newFakeHistoryService(), textOutput, &entity.TokenHolder{}, strings.NewReader(""), writer)
+	assert.ErrorIs(t, cmd.Run([]string{"42"}), ErrNotLoggedIn)
+}
+
+func TestRestoreCommand_Run(t *testing.T) {
+	history := newFakeHistoryService()
+	cmd := NewRestoreCommand(history, &entity.TokenHolder{Token: "token"}, strings.NewReader(""), &bytes.Buffer{})
+
+	assert.Equal(t, "restore", cmd.Name())
+	assert.NoError(t, cmd.Run([]string{"42", "3"}))
+	assert.Equal(t, int64(3), history.restored[42])
+
+	assert.Error(t, cmd.Run([]string{"42", "5"}))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"42"})))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"42", "0"})))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"42", "abc"})))
+
+	cmd = NewRestoreCommand(history, &entity.TokenHolder{}, strings.NewReader(""), &bytes.Buffer{})
+	assert.ErrorIs(t, cmd.Run([]string{"42", "3"}), ErrNotLoggedIn)
+}
+
+func TestRestoreCommand_Execute(t *testing.T) {
+	history := newFakeHistoryService()
+	writer := &bytes.Buffer{}
+	cmd := NewRestoreCommand(history, &entity.TokenHolder{Token: "token"}, strings.NewReader("42\n7\n"), writer)
+
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, int64(7), history.restored[42])
+	assert.Contains(t, writer.String(), "Версия восстановлена.")
+}
@@ -5,33 +5,53 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/NikolosHGW/goph-keeper/api/datapb"
 	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/client/output"
+)
+
+const (
+	// listPageSize - сколько записей показывается за раз в интерактивном режиме.
+	listPageSize = 20
+	// listFetchSize - размер страницы, которыми Run выбирает весь список.
+	listFetchSize = 100
 )
 
 type listDataService interface {
-	ListData(ctx context.Context, token string, filter *entity.DataFilter) ([]*datapb.DataItem, error)
+	ListData(ctx context.Context, token string, filter *entity.DataFilter) (*entity.DataPage, error)
+}
+
+type listOrganizerService interface {
+	ListFolders(ctx context.Context, token string) ([]*entity.Folder, error)
+	ListTags(ctx context.Context, token string) ([]*entity.Tag, error)
 }
 
 type ListCommand struct {
-	dataService listDataService
-	tokenHolder *entity.TokenHolder
-	reader      io.Reader
-	writer      io.Writer
+	dataService      listDataService
+	organizerService listOrganizerService
+	formatter        output.Formatter
+	tokenHolder      *entity.TokenHolder
+	reader           io.Reader
+	writer           io.Writer
 }
 
 func NewListCommand(
 	dataService listDataService,
+	organizerService listOrganizerService,
+	formatter output.Formatter,
 	tokenHolder *entity.TokenHolder,
 	reader io.Reader,
 	writer io.Writer,
 ) *ListCommand {
 	return &ListCommand{
-		dataService: dataService,
-		tokenHolder: tokenHolder,
-		reader:      reader,
-		writer:      writer,
+		dataService:      dataService,
+		organizerService: organizerService,
+		formatter:        formatter,
+		tokenHolder:      tokenHolder,
+		reader:           reader,
+		writer:           writer,
 	}
 }
 
@@ -39,9 +59,10 @@ func (c *ListCommand) Name() string {
 	return "list"
 }
 
+// Execute выводит список постранично: после каждой страницы спрашивает, показывать ли следующую.
 func (c *ListCommand) Execute() error {
 	if c.tokenHolder.Token == "" {
-		return fmt.Errorf("вы должны войти в систему")
+		return ErrNotLoggedIn
 	}
 
 	scanner := bufio.NewScanner(c.reader)
@@ -54,22 +75,142 @@ func (c *ListCommand) Execute() error {
 		return fmt.Errorf("ошибка ввода типа данных: %w", scanner.Err())
 	}
 
-	filter := &entity.DataFilter{InfoType: infoType}
-	dataItems, err := c.dataService.ListData(context.Background(), c.tokenHolder.Token, filter)
+	filter := &entity.DataFilter{InfoType: infoType, PageSize: listPageSize}
+	for {
+		page, err := listPage(c.dataService, c.tokenHolder.Token, filter)
+		if err != nil {
+			return err
+		}
+		if err = c.formatter.List(c.writer, summaries(page.Items)); err != nil {
+			return err
+		}
+		if page.NextPageToken == "" {
+			return nil
+		}
+
+		fmt.Fprint(c.writer, "Показать следующую страницу? (Enter - да, q - выход): ")
+		if !scanner.Scan() || strings.EqualFold(strings.TrimSpace(scanner.Text()), "q") {
+			return scanner.Err()
+		}
+		filter.PageToken = page.NextPageToken
+	}
+}
+
+// Run выводит список записей: `list --type bank_card --folder работа --tag важное --output json`.
+// --json - сокращение для --output json, `--folder /` - записи вне папок.
+// Страницы запрашиваются у сервера, пока не наберётся --limit записей или список не кончится.
+func (c *ListCommand) Run(args []string) error {
+	fs := newFlagSet("list [флаги]")
+	infoType := fs.String("type", "", "вывести только записи этого типа")
+	folder := fs.String("folder", "", "вывести только записи этой папки, / - вне папок")
+	tag := fs.String("tag", "", "вывести только записи с этим тегом")
+	asJSON := fs.Bool("json", false, "вывести список в формате JSON")
+	limit := fs.Int("limit", 0, "сколько записей вывести, 0 - все")
+	format := outputFlag(fs)
+
+	positional, err := parseArgs(fs, args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("лишние аргументы: %s", strings.Join(positional, " "))
+	}
+	if *limit < 0 {
+		return usagef("--limit не может быть отрицательным")
+	}
+	if *asJSON {
+		*format = output.FormatJSON
+	}
+	formatter, err := formatterFor(c.formatter, *format)
 	if err != nil {
-		return fmt.Errorf("ошибка получения списка данных: %w", err)
+		return err
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
 	}
 
-	if len(dataItems) == 0 {
-		fmt.Fprintln(c.writer, "Данные не найдены.")
-		return nil
+	filter := &entity.DataFilter{InfoType: *infoType, PageSize: listFetchSize}
+	if err = c.organize(filter, *folder, *tag); err != nil {
+		return err
 	}
 
-	fmt.Fprintln(c.writer, "Список данных:")
-	for _, item := range dataItems {
-		fmt.Fprintf(c.writer, "ID: %d, Тип: %s, Мета: %s, Дата создания: %s\n",
-			item.Id, item.InfoType, item.Meta, item.Created.AsTime().Format("2006-01-02 15:04:05"))
+	dataItems, err := listAll(c.dataService, c.tokenHolder.Token, filter, *limit)
+	if err != nil {
+		return err
+	}
+
+	return formatter.List(c.writer, summaries(dataItems))
+}
+
+// organize дополняет filter ID папки по пути folderPath и ID тега tagName. Пустые значения не фильтруют.
+func (c *ListCommand) organize(filter *entity.DataFilter, folderPath, tagName string) error {
+	if folderPath != "" {
+		folders, err := c.organizerService.ListFolders(context.Background(), c.tokenHolder.Token)
+		if err != nil {
+			return fmt.Errorf("ошибка получения папок: %w", err)
+		}
+		folderID, err := folderIDByPath(folders, folderPath)
+		if err != nil {
+			return err
+		}
+		filter.FolderID = &folderID
+	}
+
+	if tagName != "" {
+		tags, err := c.organizerService.ListTags(context.Background(), c.tokenHolder.Token)
+		if err != nil {
+			return fmt.Errorf("ошибка получения тегов: %w", err)
+		}
+		tag := findTag(tags, tagName)
+		if tag == nil {
+			return fmt.Errorf("тег %q не найден", tagName)
+		}
+		filter.TagID = tag.ID
 	}
 
 	return nil
 }
+
+// listAll запрашивает страницы списка, пока не наберёт limit записей или список не кончится. 0 - без ограничения.
+func listAll(
+	dataService listDataService,
+	token string,
+	filter *entity.DataFilter,
+	limit int,
+) ([]*datapb.DataItem, error) {
+	var dataItems []*datapb.DataItem
+	for {
+		page, err := listPage(dataService, token, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		dataItems = append(dataItems, page.Items...)
+		if limit > 0 && len(dataItems) >= limit {
+			return dataItems[:limit], nil
+		}
+		if page.NextPageToken == "" {
+			return dataItems, nil
+		}
+		filter.PageToken = page.NextPageToken
+	}
+}
+
+func listPage(dataService listDataService, token string, filter *entity.DataFilter) (*entity.DataPage, error) {
+	page, err := dataService.ListData(context.Background(), token, filter)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения списка данных: %w", err)
+	}
+
+	return page, nil
+}
+
+// summaries возвращает записи для вывода списком, без содержимого.
+func summaries(dataItems []*datapb.DataItem) []*output.Record {
+	records := make([]*output.Record, 0, len(dataItems))
+	for _, item := range dataItems {
+		records = append(records, output.NewSummary(item))
+	}
+
+	return records
+}
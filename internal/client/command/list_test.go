@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -15,10 +16,10 @@ import (
 )
 
 type mockListDataService struct {
-	ListDataFunc func(ctx context.Context, token string, filter *entity.DataFilter) ([]*datapb.DataItem, error)
+	ListDataFunc func(ctx context.Context, token string, filter *entity.DataFilter) (*entity.DataPage, error)
 }
 
-func (m *mockListDataService) ListData(ctx context.Context, token string, filter *entity.DataFilter) ([]*datapb.DataItem, error) {
+func (m *mockListDataService) ListData(ctx context.Context, token string, filter *entity.DataFilter) (*entity.DataPage, error) {
 	return m.ListDataFunc(ctx, token, filter)
 }
 
@@ -27,7 +28,7 @@ func TestListCommand_Execute(t *testing.T) {
 		name           string
 		token          string
 		input          string
-		listDataFunc   func(ctx context.Context, token string, filter *entity.DataFilter) ([]*datapb.DataItem, error)
+		listDataFunc   func(ctx context.Context, token string, filter *entity.DataFilter) (*entity.DataPage, error)
 		expectedOutput string
 		expectedError  string
 	}{
@@ -47,7 +48,7 @@ func TestListCommand_Execute(t *testing.T) {
 			name:  "Ошибка сервиса данных",
 			token: "valid_token",
 			input: "\n",
-			listDataFunc: func(ctx context.Context, token string, filter *entity.DataFilter) ([]*datapb.DataItem, error) {
+			listDataFunc: func(ctx context.Context, token string, filter *entity.DataFilter) (*entity.DataPage, error) {
 				return nil, errors.New("service error")
 			},
 			expectedError: "ошибка получения списка данных:",
@@ -56,8 +57,8 @@ func TestListCommand_Execute(t *testing.T) {
 			name:  "Пустой список данных",
 			token: "valid_token",
 			input: "\n",
-			listDataFunc: func(ctx context.Context, token string, filter *entity.DataFilter) ([]*datapb.DataItem, error) {
-				return []*datapb.DataItem{}, nil
+			listDataFunc: func(ctx context.Context, token string, filter *entity.DataFilter) (*entity.DataPage, error) {
+				return &entity.DataPage{}, nil
 			},
 			expectedOutput: "Введите тип данных для фильтрации (оставьте пустым для всех типов): Данные не найдены.\n",
 		},
@@ -65,8 +66,8 @@ func TestListCommand_Execute(t *testing.T) {
 			name:  "Успешное получение данных",
 			token: "valid_token",
 			input: "\n",
-			listDataFunc: func(ctx context.Context, token string, filter *entity.DataFilter) ([]*datapb.DataItem, error) {
-				return []*datapb.DataItem{
+			listDataFunc: func(ctx context.Context, token string, filter *entity.DataFilter) (*entity.DataPage, error) {
+				return &entity.DataPage{Items: []*datapb.DataItem{
 					{
 						Id:       1,
 						InfoType: "type1",
@@ -79,7 +80,7 @@ func TestListCommand_Execute(t *testing.T) {
 						Meta:     "meta2",
 						Created:  timestamppb.New(time.Date(2023, 10, 16, 13, 0, 0, 0, time.UTC)),
 					},
-				}, nil
+				}}, nil
 			},
 			expectedOutput: "Введите тип данных для фильтрации (оставьте пустым для всех типов): Список данных:\n" +
 				"ID: 1, Тип: type1, Мета: meta1, Дата создания: 2023-10-15 12:00:00\n" +
@@ -99,12 +100,12 @@ func TestListCommand_Execute(t *testing.T) {
 			}
 
 			if tt.listDataFunc == nil {
-				dataService.ListDataFunc = func(ctx context.Context, token string, filter *entity.DataFilter) ([]*datapb.DataItem, error) {
-					return nil, nil
+				dataService.ListDataFunc = func(ctx context.Context, token string, filter *entity.DataFilter) (*entity.DataPage, error) {
+					return &entity.DataPage{}, nil
 				}
 			}
 
-			cmd := NewListCommand(dataService, tokenHolder, reader, writer)
+			cmd := NewListCommand(dataService, nil, textOutput, tokenHolder, reader, writer)
 
 			err := cmd.Execute()
 
@@ -127,8 +128,148 @@ func TestListCommand_Execute(t *testing.T) {
 }
 
 func TestListCommand_Name(t *testing.T) {
-	cmd := NewListCommand(nil, nil, nil, nil)
+	cmd := NewListCommand(nil, nil, textOutput, nil, nil, nil)
 	expectedName := "list"
 	actualName := cmd.Name()
 	assert.Equal(t, expectedName, actualName, "Название команды должно быть 'list'")
 }
+
+func TestListCommand_Run(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	var gotFilter *entity.DataFilter
+	dataService := &mockListDataService{
+		ListDataFunc: func(ctx context.Context, token string, filter *entity.DataFilter) (*entity.DataPage, error) {
+			gotFilter = filter
+			return &entity.DataPage{Items: []*datapb.DataItem{
+				{Id: 3, InfoType: "bank_card", Meta: "зарплатная", Info: []byte(`{"CVV":"123"}`),
+					Created: timestamppb.New(created)},
+			}}, nil
+		},
+	}
+	tokenHolder := &entity.TokenHolder{Token: "token"}
+
+	t.Run("JSON", func(t *testing.T) {
+		writer := &bytes.Buffer{}
+		cmd := NewListCommand(dataService, nil, textOutput, tokenHolder, strings.NewReader(""), writer)
+
+		assert.NoError(t, cmd.Run([]string{"--type", "bank_card", "--json"}))
+		assert.Equal(t, "bank_card", gotFilter.InfoType)
+		assert.JSONEq(t,
+			`[{"id":3,"type":"bank_card","meta":"зарплатная","created":"2024-05-01T10:00:00Z"}]`,
+			writer.String())
+	})
+
+	t.Run("Формат клиента", func(t *testing.T) {
+		writer := &bytes.Buffer{}
+		cmd := NewListCommand(dataService, nil, textOutput, tokenHolder, strings.NewReader(""), writer)
+
+		assert.NoError(t, cmd.Run(nil))
+		assert.Equal(t, "Список данных:\nID: 3, Тип: bank_card, Мета: зарплатная, Дата создания: 2024-05-01 10:00:00\n",
+			writer.String())
+	})
+
+	t.Run("Таблица", func(t *testing.T) {
+		writer := &bytes.Buffer{}
+		cmd := NewListCommand(dataService, nil, textOutput, tokenHolder, strings.NewReader(""), writer)
+
+		assert.NoError(t, cmd.Run([]string{"-o", "table"}))
+		assert.Equal(t,
+			"ID  ТИП        МЕТА        СОЗДАНО\n3   bank_card  зарплатная  2024-05-01 10:00:00\n",
+			writer.String())
+	})
+
+	t.Run("Неверные аргументы", func(t *testing.T) {
+		cmd := NewListCommand(dataService, nil, textOutput, tokenHolder, strings.NewReader(""), &bytes.Buffer{})
+
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"bank_card"})))
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"--output", "xml"})))
+		assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"--limit", "-1"})))
+	})
+}
+
+// pagedListDataService отдаёт записи 1..total страницами по filter.PageSize, токен - ID последней записи.
+func pagedListDataService(total int, filters *[]entity.DataFilter) *mockListDataService {
+	return &mockListDataService{
+		ListDataFunc: func(_ context.Context, _ string, filter *entity.DataFilter) (*entity.DataPage, error) {
+			*filters = append(*filters, *filter)
+
+			var from int
+			if filter.PageToken != "" {
+				from, _ = strconv.Atoi(filter.PageToken)
+			}
+			page := &entity.DataPage{}
+			for id := from + 1; id <= total && len(page.Items) < int(filter.PageSize); id++ {
+				page.Items = append(page.Items, &datapb.DataItem{Id: int32(id), InfoType: "text", Created: timestamppb.Now()})
+			}
+			if last := from + len(page.Items); last < total {
+				page.NextPageToken = strconv.Itoa(last)
+			}
+			return page, nil
+		},
+	}
+}
+
+func TestListCommand_Pages(t *testing.T) {
+	tokenHolder := &entity.TokenHolder{Token: "token"}
+
+	t.Run("Интерактивный режим", func(t *testing.T) {
+		var filters []entity.DataFilter
+		writer := &bytes.Buffer{}
+		cmd := NewListCommand(pagedListDataService(listPageSize*3, &filters), nil, textOutput, tokenHolder,
+			strings.NewReader("text\n\nq\n"), writer)
+
+		assert.NoError(t, cmd.Execute())
+		assert.Len(t, filters, 2, "после q следующая страница не запрашивается")
+		assert.Equal(t, "text", filters[1].InfoType)
+		assert.NotEmpty(t, filters[1].PageToken)
+		assert.Equal(t, 2, strings.Count(writer.String(), "Показать следующую страницу?"))
+		assert.Equal(t, listPageSize*2, strings.Count(writer.String(), "ID: "))
+	})
+
+	t.Run("Весь список", func(t *testing.T) {
+		var filters []entity.DataFilter
+		writer := &bytes.Buffer{}
+		cmd := NewListCommand(pagedListDataService(listFetchSize+1, &filters), nil, textOutput, tokenHolder,
+			strings.NewReader(""), writer)
+
+		assert.NoError(t, cmd.Run([]string{"-o", "json"}))
+		assert.Len(t, filters, 2)
+		assert.Equal(t, listFetchSize+1, strings.Count(writer.String(), `"id"`))
+	})
+
+	t.Run("Лимит", func(t *testing.T) {
+		var filters []entity.DataFilter
+		writer := &bytes.Buffer{}
+		cmd := NewListCommand(pagedListDataService(listFetchSize*2, &filters), nil, textOutput, tokenHolder,
+			strings.NewReader(""), writer)
+
+		assert.NoError(t, cmd.Run([]string{"--limit", "5", "-o", "json"}))
+		assert.Len(t, filters, 1)
+		assert.Equal(t, 5, strings.Count(writer.String(), `"id"`))
+	})
+}
+
+func TestListCommand_FolderAndTag(t *testing.T) {
+	var filters []entity.DataFilter
+	organizer := newFakeOrganizerService()
+	organizer.folders = []*entity.Folder{{ID: 1, Name: "работа"}, {ID: 2, ParentID: 1, Name: "почта"}}
+	organizer.tags = []*entity.Tag{{ID: 5, Name: "важное"}}
+	cmd := NewListCommand(pagedListDataService(1, &filters), organizer, textOutput, &entity.TokenHolder{Token: "token"},
+		strings.NewReader(""), &bytes.Buffer{})
+
+	assert.NoError(t, cmd.Run([]string{"--folder", "работа/почта", "--tag", "важное"}))
+	if assert.NotNil(t, filters[0].FolderID) {
+		assert.Equal(t, int32(2), *filters[0].FolderID)
+	}
+	assert.Equal(t, int32(5), filters[0].TagID)
+
+	assert.NoError(t, cmd.Run([]string{"--folder", "/"}))
+	if assert.NotNil(t, filters[1].FolderID) {
+		assert.Equal(t, int32(0), *filters[1].FolderID, "/ - записи вне папок")
+	}
+	assert.Zero(t, filters[1].TagID)
+
+	assert.EqualError(t, cmd.Run([]string{"--folder", "архив"}), `папка "архив" не найдена`)
+	assert.EqualError(t, cmd.Run([]string{"--tag", "личное"}), `тег "личное" не найден`)
+	assert.Len(t, filters, 2)
+}
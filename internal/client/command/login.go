@@ -5,16 +5,23 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
 )
 
 type service interface {
-	Login(ctx context.Context, login, password string) (string, error)
+	Login(ctx context.Context, login, password string) (*entity.LoginResult, error)
+	VerifyLoginCode(ctx context.Context, challengeID, code string) (*entity.LoginResult, error)
+}
+
+type vaultUnlocker interface {
+	Unlock(masterPassword string, params *entity.KDFParams) ([]byte, error)
 }
 
 type LoginCommand struct {
 	authService service
+	vault       vaultUnlocker
 	tokenHolder *entity.TokenHolder
 	reader      io.Reader
 	writer      io.Writer
@@ -22,12 +29,14 @@ type LoginCommand struct {
 
 func NewLoginCommand(
 	authService service,
+	vault vaultUnlocker,
 	tokenHolder *entity.TokenHolder,
 	reader io.Reader,
 	writer io.Writer,
 ) *LoginCommand {
 	return &LoginCommand{
 		authService: authService,
+		vault:       vault,
 		tokenHolder: tokenHolder,
 		reader:      reader,
 		writer:      writer,
@@ -61,12 +70,137 @@ func (c *LoginCommand) Execute() error {
 		return fmt.Errorf("ошибка ввода пароля: %w", scanner.Err())
 	}
 
-	token, err := c.authService.Login(context.Background(), login, password)
+	err = c.login(login, password, func(p loginPrompt) (string, error) {
+		if _, err := fmt.Fprintf(c.writer, "Введите %s: ", p.label); err != nil {
+			return "", fmt.Errorf("ошибка вывода запроса %s: %w", p.name, err)
+		}
+		if !scanner.Scan() {
+			return "", fmt.Errorf("ошибка ввода %s: %w", p.name, scanner.Err())
+		}
+		return scanner.Text(), nil
+	})
 	if err != nil {
-		return fmt.Errorf("ошибка входа: %w", err)
+		return err
 	}
 
-	c.tokenHolder.Token = token
 	fmt.Println("Вход выполнен успешно.")
 	return nil
 }
+
+// Credentials - данные для входа без интерактивных вопросов, например из переменных окружения.
+type Credentials struct {
+	Login          string
+	Password       string
+	Code           string
+	MasterPassword string
+}
+
+// LoginWith выполняет вход с заранее известными данными. Если сервер запросит код второго фактора
+// или мастер-пароль, а в creds их нет, вход завершается ошибкой.
+func (c *LoginCommand) LoginWith(creds Credentials) error {
+	answers := map[loginPrompt]string{
+		codePrompt:           creds.Code,
+		masterPasswordPrompt: creds.MasterPassword,
+	}
+
+	return c.login(creds.Login, creds.Password, func(p loginPrompt) (string, error) {
+		if answers[p] == "" {
+			return "", fmt.Errorf("для входа требуется %s: %w", p.label, ErrNotLoggedIn)
+		}
+		return answers[p], nil
+	})
+}
+
+// loginPrompt - дополнительный вопрос при входе: label для приглашения, name для сообщений об ошибках.
+type loginPrompt struct {
+	label string
+	name  string
+}
+
+var (
+	codePrompt           = loginPrompt{label: "код из приложения-аутентификатора или код восстановления", name: "кода"}
+	masterPasswordPrompt = loginPrompt{label: "мастер-пароль", name: "мастер-пароля"}
+)
+
+// login входит по логину и паролю, при необходимости запрашивая через ask код второго фактора и мастер-пароль.
+func (c *LoginCommand) login(login, password string, ask func(p loginPrompt) (string, error)) error {
+	result, err := c.authService.Login(context.Background(), login, password)
+	if err != nil {
+		return fmt.Errorf("ошибка входа: %w", err)
+	}
+
+	if result.ChallengeID != "" {
+		code, err := ask(codePrompt)
+		if err != nil {
+			return err
+		}
+
+		result, err = c.authService.VerifyLoginCode(context.Background(), result.ChallengeID, code)
+		if err != nil {
+			return fmt.Errorf("ошибка входа: %w", err)
+		}
+	}
+
+	var vaultKey []byte
+	if result.KDFParams != nil {
+		masterPassword, err := ask(masterPasswordPrompt)
+		if err != nil {
+			return err
+		}
+
+		vaultKey, err = c.vault.Unlock(masterPassword, result.KDFParams)
+		if err != nil {
+			return fmt.Errorf("ошибка разблокировки хранилища: %w", err)
+		}
+	}
+
+	c.tokenHolder.Token = result.Token
+	c.tokenHolder.Login = login
+	c.tokenHolder.RefreshToken = result.RefreshToken
+	c.tokenHolder.VaultKey = vaultKey
+	c.tokenHolder.KDFParams = result.KDFParams
+	return nil
+}
+
+// Run выполняет вход из командной строки: `login`. Логин и пароли читаются из stdin, как в интерактивном режиме,
+// а сессия сохраняется для следующих запусков.
+func (c *LoginCommand) Run(args []string) error {
+	positional, err := parseArgs(newFlagSet("login"), args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("лишние аргументы: %s", strings.Join(positional, " "))
+	}
+
+	return c.Execute()
+}
+
+// Unlock разблокирует хранилище восстановленной сессии мастер-паролем.
+func (c *LoginCommand) Unlock(masterPassword string) error {
+	if !c.tokenHolder.VaultLocked() {
+		return nil
+	}
+
+	vaultKey, err := c.vault.Unlock(masterPassword, c.tokenHolder.KDFParams)
+	if err != nil {
+		return fmt.Errorf("ошибка разблокировки хранилища: %w", err)
+	}
+	c.tokenHolder.VaultKey = vaultKey
+
+	return nil
+}
+
+// UnlockInteractive запрашивает мастер-пароль и разблокирует хранилище восстановленной сессии.
+func (c *LoginCommand) UnlockInteractive() error {
+	if _, err := fmt.Fprintf(c.writer, "Введите %s: ", masterPasswordPrompt.label); err != nil {
+		return fmt.Errorf("ошибка вывода запроса %s: %w", masterPasswordPrompt.name, err)
+	}
+
+	scanner := bufio.NewScanner(c.reader)
+	if !scanner.Scan() {
+		return fmt.Errorf("ошибка ввода %s: %w", masterPasswordPrompt.name, scanner.Err())
+	}
+
+	return c.Unlock(scanner.Text())
+}
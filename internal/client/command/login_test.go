@@ -15,15 +15,23 @@ type MockService struct {
 	mock.Mock
 }
 
-func (m *MockService) Login(ctx context.Context, login, password string) (string, error) {
+func (m *MockService) Login(ctx context.Context, login, password string) (*entity.LoginResult, error) {
 	args := m.Called(ctx, login, password)
-	return args.String(0), args.Error(1)
+	result, _ := args.Get(0).(*entity.LoginResult)
+	return result, args.Error(1)
+}
+
+func (m *MockService) VerifyLoginCode(ctx context.Context, challengeID, code string) (*entity.LoginResult, error) {
+	args := m.Called(ctx, challengeID, code)
+	result, _ := args.Get(0).(*entity.LoginResult)
+	return result, args.Error(1)
 }
 
 func TestLoginCommand_Execute_Success(t *testing.T) {
 	mockService := new(MockService)
 	expectedToken := "mocked_token"
-	mockService.On("Login", mock.Anything, "testuser", "testpass").Return(expectedToken, nil)
+	mockService.On("Login", mock.Anything, "testuser", "testpass").
+		Return(&entity.LoginResult{Token: expectedToken, RefreshToken: "refresh"}, nil)
 
 	tokenHolder := &entity.TokenHolder{}
 
@@ -31,17 +39,95 @@ func TestLoginCommand_Execute_Success(t *testing.T) {
 	reader := bytes.NewBufferString(input)
 	writer := &bytes.Buffer{}
 
-	cmd := NewLoginCommand(mockService, tokenHolder, reader, writer)
+	cmd := NewLoginCommand(mockService, nil, tokenHolder, reader, writer)
 
 	err := cmd.Execute()
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedToken, tokenHolder.Token)
+	assert.Equal(t, "testuser", tokenHolder.Login)
+	assert.Equal(t, "refresh", tokenHolder.RefreshToken)
+}
+
+func TestLoginCommand_Execute_UnlockVault(t *testing.T) {
+	kdfParams := &entity.KDFParams{Salt: []byte("salt"), Time: 3, MemoryKiB: 65536, Threads: 4}
+	vaultKey := []byte("0123456789abcdef0123456789abcdef")
+
+	mockService := new(MockService)
+	mockService.On("Login", mock.Anything, "testuser", "testpass").
+		Return(&entity.LoginResult{Token: "mocked_token", KDFParams: kdfParams}, nil)
+
+	t.Run("Верный мастер-пароль", func(t *testing.T) {
+		mockVault := new(MockVault)
+		mockVault.On("Unlock", "masterpass", kdfParams).Return(vaultKey, nil)
+
+		tokenHolder := &entity.TokenHolder{}
+		reader := bytes.NewBufferString("testuser\ntestpass\nmasterpass\n")
+
+		cmd := NewLoginCommand(mockService, mockVault, tokenHolder, reader, &bytes.Buffer{})
+
+		assert.NoError(t, cmd.Execute())
+		assert.Equal(t, "mocked_token", tokenHolder.Token)
+		assert.Equal(t, vaultKey, tokenHolder.VaultKey)
+		assert.Equal(t, kdfParams, tokenHolder.KDFParams)
+	})
+
+	t.Run("Неверный мастер-пароль", func(t *testing.T) {
+		mockVault := new(MockVault)
+		mockVault.On("Unlock", "wrong", kdfParams).Return(nil, errors.New("неверный мастер-пароль"))
+
+		tokenHolder := &entity.TokenHolder{}
+		reader := bytes.NewBufferString("testuser\ntestpass\nwrong\n")
+
+		cmd := NewLoginCommand(mockService, mockVault, tokenHolder, reader, &bytes.Buffer{})
+
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "ошибка разблокировки хранилища")
+		assert.Empty(t, tokenHolder.Token)
+	})
+}
+
+func TestLoginCommand_Execute_SecondFactor(t *testing.T) {
+	mockService := new(MockService)
+	mockService.On("Login", mock.Anything, "testuser", "testpass").
+		Return(&entity.LoginResult{ChallengeID: "challenge"}, nil)
+
+	t.Run("Верный код", func(t *testing.T) {
+		mockService.On("VerifyLoginCode", mock.Anything, "challenge", "123456").
+			Return(&entity.LoginResult{Token: "mocked_token", RefreshToken: "refresh"}, nil).Once()
+
+		tokenHolder := &entity.TokenHolder{}
+		writer := &bytes.Buffer{}
+		reader := bytes.NewBufferString("testuser\ntestpass\n123456\n")
+
+		cmd := NewLoginCommand(mockService, nil, tokenHolder, reader, writer)
+
+		assert.NoError(t, cmd.Execute())
+		assert.Contains(t, writer.String(), "код из приложения-аутентификатора")
+		assert.Equal(t, "mocked_token", tokenHolder.Token)
+		assert.Equal(t, "refresh", tokenHolder.RefreshToken)
+	})
+
+	t.Run("Неверный код", func(t *testing.T) {
+		mockService.On("VerifyLoginCode", mock.Anything, "challenge", "000000").
+			Return(nil, errors.New("неверный код")).Once()
+
+		tokenHolder := &entity.TokenHolder{}
+		reader := bytes.NewBufferString("testuser\ntestpass\n000000\n")
+
+		cmd := NewLoginCommand(mockService, nil, tokenHolder, reader, &bytes.Buffer{})
+
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "ошибка входа")
+		assert.Empty(t, tokenHolder.Token)
+	})
 }
 
 func TestLoginCommand_Execute_AuthError(t *testing.T) {
 	mockService := new(MockService)
-	mockService.On("Login", mock.Anything, "testuser", "wrongpass").Return("", errors.New("authentication failed"))
+	mockService.On("Login", mock.Anything, "testuser", "wrongpass").Return(nil, errors.New("authentication failed"))
 
 	tokenHolder := &entity.TokenHolder{}
 
@@ -49,7 +135,7 @@ func TestLoginCommand_Execute_AuthError(t *testing.T) {
 	reader := bytes.NewBufferString(input)
 	writer := &bytes.Buffer{}
 
-	cmd := NewLoginCommand(mockService, tokenHolder, reader, writer)
+	cmd := NewLoginCommand(mockService, nil, tokenHolder, reader, writer)
 
 	err := cmd.Execute()
 
@@ -66,7 +152,7 @@ func TestLoginCommand_Execute_InputError(t *testing.T) {
 		reader := bytes.NewBuffer(nil)
 		writer := &bytes.Buffer{}
 
-		cmd := NewLoginCommand(mockService, tokenHolder, reader, writer)
+		cmd := NewLoginCommand(mockService, nil, tokenHolder, reader, writer)
 
 		err := cmd.Execute()
 
@@ -80,7 +166,7 @@ func TestLoginCommand_Execute_InputError(t *testing.T) {
 		reader := bytes.NewBufferString(input)
 		writer := &bytes.Buffer{}
 
-		cmd := NewLoginCommand(mockService, tokenHolder, reader, writer)
+		cmd := NewLoginCommand(mockService, nil, tokenHolder, reader, writer)
 
 		err := cmd.Execute()
 
@@ -100,7 +186,7 @@ func TestLoginCommand_Execute_WriteError(t *testing.T) {
 	reader := bytes.NewBufferString(input)
 	writer := errorWriter
 
-	cmd := NewLoginCommand(mockService, tokenHolder, reader, writer)
+	cmd := NewLoginCommand(mockService, nil, tokenHolder, reader, writer)
 
 	err := cmd.Execute()
 
@@ -109,6 +195,68 @@ func TestLoginCommand_Execute_WriteError(t *testing.T) {
 	assert.Empty(t, tokenHolder.Token)
 }
 
+func TestLoginCommand_LoginWith(t *testing.T) {
+	kdfParams := &entity.KDFParams{Salt: []byte("salt")}
+	vaultKey := []byte("0123456789abcdef0123456789abcdef")
+
+	mockService := new(MockService)
+	mockService.On("Login", mock.Anything, "testuser", "testpass").
+		Return(&entity.LoginResult{ChallengeID: "challenge"}, nil)
+	mockService.On("VerifyLoginCode", mock.Anything, "challenge", "123456").
+		Return(&entity.LoginResult{Token: "mocked_token", KDFParams: kdfParams}, nil)
+	mockVault := new(MockVault)
+	mockVault.On("Unlock", "masterpass", kdfParams).Return(vaultKey, nil)
+
+	t.Run("Все данные заданы", func(t *testing.T) {
+		tokenHolder := &entity.TokenHolder{}
+		writer := &bytes.Buffer{}
+		cmd := NewLoginCommand(mockService, mockVault, tokenHolder, bytes.NewBuffer(nil), writer)
+
+		err := cmd.LoginWith(Credentials{
+			Login:          "testuser",
+			Password:       "testpass",
+			Code:           "123456",
+			MasterPassword: "masterpass",
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, writer.String())
+		assert.Equal(t, "mocked_token", tokenHolder.Token)
+		assert.Equal(t, vaultKey, tokenHolder.VaultKey)
+	})
+
+	t.Run("Не задан мастер-пароль", func(t *testing.T) {
+		tokenHolder := &entity.TokenHolder{}
+		cmd := NewLoginCommand(mockService, mockVault, tokenHolder, bytes.NewBuffer(nil), &bytes.Buffer{})
+
+		err := cmd.LoginWith(Credentials{Login: "testuser", Password: "testpass", Code: "123456"})
+
+		assert.ErrorIs(t, err, ErrNotLoggedIn)
+		assert.ErrorContains(t, err, "для входа требуется мастер-пароль")
+		assert.Empty(t, tokenHolder.Token)
+	})
+}
+
+func TestLoginCommand_Unlock(t *testing.T) {
+	kdfParams := &entity.KDFParams{Salt: []byte("salt")}
+	vaultKey := []byte("0123456789abcdef0123456789abcdef")
+	mockVault := new(MockVault)
+	mockVault.On("Unlock", "masterpass", kdfParams).Return(vaultKey, nil)
+	mockVault.On("Unlock", "wrong", kdfParams).Return(nil, errors.New("неверный мастер-пароль"))
+
+	tokenHolder := &entity.TokenHolder{Token: "token", KDFParams: kdfParams}
+	cmd := NewLoginCommand(nil, mockVault, tokenHolder, bytes.NewBufferString("wrong\n"), &bytes.Buffer{})
+
+	assert.ErrorContains(t, cmd.UnlockInteractive(), "ошибка разблокировки хранилища")
+	assert.True(t, tokenHolder.VaultLocked())
+
+	assert.NoError(t, cmd.Unlock("masterpass"))
+	assert.Equal(t, vaultKey, tokenHolder.VaultKey)
+	assert.False(t, tokenHolder.VaultLocked())
+
+	assert.NoError(t, cmd.Unlock("wrong"), "разблокированное хранилище не разблокируется повторно")
+}
+
 // ErrorWriter — это io.Writer, который всегда возвращает ошибку.
 type ErrorWriter struct{}
 
@@ -117,7 +265,7 @@ func (e *ErrorWriter) Write(p []byte) (n int, err error) {
 }
 
 func TestLoginCommand_Name(t *testing.T) {
-	cmd := NewLoginCommand(nil, nil, nil, nil)
+	cmd := NewLoginCommand(nil, nil, nil, nil, nil)
 	expectedName := "login"
 	actualName := cmd.Name()
 	assert.Equal(t, expectedName, actualName, "Название команды должно быть 'login'")
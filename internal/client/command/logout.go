@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type logoutService interface {
+	Logout(ctx context.Context, token string) error
+}
+
+type LogoutCommand struct {
+	authService logoutService
+	tokenHolder *entity.TokenHolder
+	writer      io.Writer
+}
+
+func NewLogoutCommand(authService logoutService, tokenHolder *entity.TokenHolder, writer io.Writer) *LogoutCommand {
+	return &LogoutCommand{
+		authService: authService,
+		tokenHolder: tokenHolder,
+		writer:      writer,
+	}
+}
+
+func (c *LogoutCommand) Name() string {
+	return "logout"
+}
+
+// Run завершает сессию из командной строки: `logout`. Сохранённая сессия удаляется.
+func (c *LogoutCommand) Run(args []string) error {
+	positional, err := parseArgs(newFlagSet("logout"), args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("лишние аргументы: %s", strings.Join(positional, " "))
+	}
+
+	return c.Execute()
+}
+
+func (c *LogoutCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	err := c.authService.Logout(context.Background(), c.tokenHolder.Token)
+	if err != nil && status.Code(err) != codes.Unauthenticated {
+		return fmt.Errorf("ошибка выхода: %w", err)
+	}
+
+	// Если сервер уже не признаёт сессию, её всё равно нужно забыть на устройстве.
+	c.tokenHolder.Token = ""
+	c.tokenHolder.Login = ""
+	c.tokenHolder.RefreshToken = ""
+	c.tokenHolder.VaultKey = nil
+	c.tokenHolder.KDFParams = nil
+
+	_, err = fmt.Fprintln(c.writer, "Сессия завершена.")
+	if err != nil {
+		return fmt.Errorf("ошибка вывода результата: %w", err)
+	}
+
+	return nil
+}
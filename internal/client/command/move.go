@@ -0,0 +1,104 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+)
+
+type moveDataService interface {
+	folderService
+	MoveData(ctx context.Context, token string, dataID, folderID int32) error
+}
+
+// MoveCommand переносит запись в папку по пути. Недостающие папки пути создаются.
+type MoveCommand struct {
+	organizerService moveDataService
+	tokenHolder      *entity.TokenHolder
+	reader           io.Reader
+	writer           io.Writer
+}
+
+func NewMoveCommand(
+	organizerService moveDataService,
+	tokenHolder *entity.TokenHolder,
+	reader io.Reader,
+	writer io.Writer,
+) *MoveCommand {
+	return &MoveCommand{
+		organizerService: organizerService,
+		tokenHolder:      tokenHolder,
+		reader:           reader,
+		writer:           writer,
+	}
+}
+
+func (c *MoveCommand) Name() string {
+	return "mv"
+}
+
+func (c *MoveCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	scanner := bufio.NewScanner(c.reader)
+
+	fmt.Fprint(c.writer, "Введите ID данных: ")
+	if !scanner.Scan() {
+		return fmt.Errorf("ошибка ввода ID: %w", scanner.Err())
+	}
+	id, err := parseID(strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(c.writer, "Введите путь папки, например работа/проекты (/ - корень): ")
+	if !scanner.Scan() {
+		return fmt.Errorf("ошибка ввода пути: %w", scanner.Err())
+	}
+
+	if err = c.move(id, scanner.Text()); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.writer, "Запись перенесена.")
+	return nil
+}
+
+// Run переносит запись: `mv 42 работа/проекты`, `mv 42 /` - в корень. При успехе ничего не выводит.
+func (c *MoveCommand) Run(args []string) error {
+	positional, err := parseArgs(newFlagSet("mv <id> <папка>"), args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return usagef("укажите ID записи и путь папки")
+	}
+	id, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	return c.move(id, positional[1])
+}
+
+func (c *MoveCommand) move(id int32, path string) error {
+	folderID, err := ensureFolder(c.organizerService, c.tokenHolder.Token, path)
+	if err != nil {
+		return err
+	}
+
+	if err = c.organizerService.MoveData(context.Background(), c.tokenHolder.Token, id, folderID); err != nil {
+		return fmt.Errorf("ошибка переноса записи: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoveCommand_Name(t *testing.T) {
+	assert.Equal(t, "mv", NewMoveCommand(nil, nil, nil, nil).Name())
+}
+
+func TestMoveCommand_Run(t *testing.T) {
+	tokenHolder := &entity.TokenHolder{Token: "token"}
+	organizer := newFakeOrganizerService()
+	organizer.folders = []*entity.Folder{{ID: 1, Name: "работа"}}
+	cmd := NewMoveCommand(organizer, tokenHolder, strings.NewReader(""), &bytes.Buffer{})
+
+	assert.NoError(t, cmd.Run([]string{"42", "работа/проекты"}))
+	assert.Equal(t, int32(2), organizer.moved[42])
+	assert.Equal(t, &entity.Folder{ID: 2, ParentID: 1, Name: "проекты"}, organizer.folders[1])
+
+	assert.NoError(t, cmd.Run([]string{"42", "/"}))
+	assert.Equal(t, int32(0), organizer.moved[42])
+
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"42"})))
+
+	cmd = NewMoveCommand(organizer, &entity.TokenHolder{}, strings.NewReader(""), &bytes.Buffer{})
+	assert.ErrorIs(t, cmd.Run([]string{"42", "/"}), ErrNotLoggedIn)
+}
+
+func TestMoveCommand_Execute(t *testing.T) {
+	organizer := newFakeOrganizerService()
+	writer := &bytes.Buffer{}
+	cmd := NewMoveCommand(organizer, &entity.TokenHolder{Token: "token"}, strings.NewReader("7\nархив\n"), writer)
+
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, int32(1), organizer.moved[7])
+	assert.Contains(t, writer.String(), "Запись перенесена.")
+}
@@ -0,0 +1,127 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+)
+
+type tagService interface {
+	CreateTag(ctx context.Context, token, name string) (int32, error)
+	ListTags(ctx context.Context, token string) ([]*entity.Tag, error)
+}
+
+type folderService interface {
+	CreateFolder(ctx context.Context, token string, parentID int32, name string) (int32, error)
+	ListFolders(ctx context.Context, token string) ([]*entity.Folder, error)
+}
+
+// resolveTags возвращает ID тегов по именам. Недостающие теги создаются, если create,
+// иначе возвращается ошибка.
+func resolveTags(svc tagService, token string, names []string, create bool) ([]int32, error) {
+	tags, err := svc.ListTags(context.Background(), token)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения тегов: %w", err)
+	}
+
+	ids := make([]int32, 0, len(names))
+	for _, name := range names {
+		tag := findTag(tags, name)
+		if tag != nil {
+			ids = append(ids, tag.ID)
+			continue
+		}
+		if !create {
+			return nil, fmt.Errorf("тег %q не найден", name)
+		}
+
+		id, err := svc.CreateTag(context.Background(), token, name)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка создания тега %q: %w", name, err)
+		}
+		tags = append(tags, &entity.Tag{ID: id, Name: name})
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// ensureFolder возвращает ID папки по пути вида `работа/проекты`, создавая недостающие папки пути.
+// Пустой путь и `/` - корень, ID 0.
+func ensureFolder(svc folderService, token, path string) (int32, error) {
+	names := splitFolderPath(path)
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	folders, err := svc.ListFolders(context.Background(), token)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения папок: %w", err)
+	}
+
+	var parentID int32
+	for _, name := range names {
+		if folder := findFolder(folders, parentID, name); folder != nil {
+			parentID = folder.ID
+			continue
+		}
+
+		id, err := svc.CreateFolder(context.Background(), token, parentID, name)
+		if err != nil {
+			return 0, fmt.Errorf("ошибка создания папки %q: %w", name, err)
+		}
+		folders = append(folders, &entity.Folder{ID: id, ParentID: parentID, Name: name})
+		parentID = id
+	}
+
+	return parentID, nil
+}
+
+// folderIDByPath возвращает ID папки по пути среди folders. Пустой путь и `/` - корень, ID 0.
+func folderIDByPath(folders []*entity.Folder, path string) (int32, error) {
+	names := splitFolderPath(path)
+
+	var parentID int32
+	for i, name := range names {
+		folder := findFolder(folders, parentID, name)
+		if folder == nil {
+			return 0, fmt.Errorf("папка %q не найдена", strings.Join(names[:i+1], "/"))
+		}
+		parentID = folder.ID
+	}
+
+	return parentID, nil
+}
+
+func splitFolderPath(path string) []string {
+	var names []string
+	for _, name := range strings.Split(path, "/") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func findFolder(folders []*entity.Folder, parentID int32, name string) *entity.Folder {
+	for _, folder := range folders {
+		if folder.ParentID == parentID && folder.Name == name {
+			return folder
+		}
+	}
+
+	return nil
+}
+
+func findTag(tags []*entity.Tag, name string) *entity.Tag {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tag
+		}
+	}
+
+	return nil
+}
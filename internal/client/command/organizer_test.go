@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeOrganizerService хранит папки, теги и изменения записей в памяти.
+type fakeOrganizerService struct {
+	tagged   map[int32][]int32
+	untagged map[int32][]int32
+	moved    map[int32]int32
+	folders  []*entity.Folder
+	tags     []*entity.Tag
+}
+
+func newFakeOrganizerService() *fakeOrganizerService {
+	return &fakeOrganizerService{
+		tagged:   make(map[int32][]int32),
+		untagged: make(map[int32][]int32),
+		moved:    make(map[int32]int32),
+	}
+}
+
+func (f *fakeOrganizerService) CreateFolder(_ context.Context, _ string, parentID int32, name string) (int32, error) {
+	id := int32(len(f.folders) + 1)
+	f.folders = append(f.folders, &entity.Folder{ID: id, ParentID: parentID, Name: name})
+	return id, nil
+}
+
+func (f *fakeOrganizerService) ListFolders(context.Context, string) ([]*entity.Folder, error) {
+	return f.folders, nil
+}
+
+func (f *fakeOrganizerService) CreateTag(_ context.Context, _, name string) (int32, error) {
+	id := int32(len(f.tags) + 1)
+	f.tags = append(f.tags, &entity.Tag{ID: id, Name: name})
+	return id, nil
+}
+
+func (f *fakeOrganizerService) ListTags(context.Context, string) ([]*entity.Tag, error) {
+	return f.tags, nil
+}
+
+func (f *fakeOrganizerService) TagData(_ context.Context, _ string, dataID int32, tagIDs []int32) error {
+	f.tagged[dataID] = append(f.tagged[dataID], tagIDs...)
+	return nil
+}
+
+func (f *fakeOrganizerService) UntagData(_ context.Context, _ string, dataID int32, tagIDs []int32) error {
+	f.untagged[dataID] = append(f.untagged[dataID], tagIDs...)
+	return nil
+}
+
+func (f *fakeOrganizerService) MoveData(_ context.Context, _ string, dataID, folderID int32) error {
+	f.moved[dataID] = folderID
+	return nil
+}
+
+func TestEnsureFolder(t *testing.T) {
+	organizer := newFakeOrganizerService()
+	organizer.folders = []*entity.Folder{{ID: 1, Name: "работа"}, {ID: 2, ParentID: 1, Name: "почта"}}
+
+	id, err := ensureFolder(organizer, "token", "/работа/почта/")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), id)
+
+	id, err = ensureFolder(organizer, "token", "работа/проекты/новый")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), id)
+	assert.Equal(t, []*entity.Folder{{ID: 3, ParentID: 1, Name: "проекты"}, {ID: 4, ParentID: 3, Name: "новый"}},
+		organizer.folders[2:])
+
+	id, err = ensureFolder(organizer, "token", "/")
+	assert.NoError(t, err)
+	assert.Zero(t, id)
+
+	_, err = folderIDByPath(organizer.folders, "работа/архив")
+	assert.EqualError(t, err, `папка "работа/архив" не найдена`)
+}
+
+func TestResolveTags(t *testing.T) {
+	organizer := newFakeOrganizerService()
+	organizer.tags = []*entity.Tag{{ID: 1, Name: "важное"}}
+
+	_, err := resolveTags(organizer, "token", []string{"важное", "личное"}, false)
+	assert.EqualError(t, err, `тег "личное" не найден`)
+	assert.Len(t, organizer.tags, 1)
+
+	ids, err := resolveTags(organizer, "token", []string{"личное", "важное", "личное"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{2, 1, 2}, ids, "тег создаётся один раз")
+}
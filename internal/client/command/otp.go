@@ -0,0 +1,223 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+	"github.com/NikolosHGW/goph-keeper/pkg/totp"
+)
+
+// ErrNoTOTP - запись не содержит TOTP и не связана с записью TOTP.
+var ErrNoTOTP = errors.New("у записи нет TOTP")
+
+// OTPCommand выводит одноразовые коды из записей totp и связывает их с записями login_password.
+type OTPCommand struct {
+	dataService updateDataService
+	tokenHolder *entity.TokenHolder
+	reader      io.Reader
+	writer      io.Writer
+	now         func() time.Time
+}
+
+func NewOTPCommand(
+	dataService updateDataService,
+	tokenHolder *entity.TokenHolder,
+	reader io.Reader,
+	writer io.Writer,
+) *OTPCommand {
+	return &OTPCommand{
+		dataService: dataService,
+		tokenHolder: tokenHolder,
+		reader:      reader,
+		writer:      writer,
+		now:         time.Now,
+	}
+}
+
+func (c *OTPCommand) Name() string {
+	return "otp"
+}
+
+func (c *OTPCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	fmt.Fprint(c.writer, "Введите ID записи TOTP или логина: ")
+	scanner := bufio.NewScanner(c.reader)
+	if !scanner.Scan() {
+		return fmt.Errorf("ошибка ввода ID")
+	}
+	id, err := parseID(strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		return err
+	}
+
+	return c.code(id)
+}
+
+// Run выводит текущий код: `otp 42` для записи totp или для записи login_password, связанной с ней.
+// `otp link 7 42` связывает логин 7 с записью TOTP 42, `otp unlink 7` удаляет связь.
+func (c *OTPCommand) Run(args []string) error {
+	fs := newFlagSet("otp <id> | link <id логина> <id TOTP> | unlink <id логина>")
+	positional, err := parseArgs(fs, args, c.writer)
+	if err != nil {
+		return err
+	}
+
+	var action func() error
+	switch {
+	case len(positional) == 1:
+		id, err := parseID(positional[0])
+		if err != nil {
+			return err
+		}
+		action = func() error { return c.code(id) }
+	case len(positional) == 3 && positional[0] == "link":
+		loginID, err := parseID(positional[1])
+		if err != nil {
+			return err
+		}
+		totpID, err := parseID(positional[2])
+		if err != nil {
+			return err
+		}
+		action = func() error { return c.link(loginID, totpID) }
+	case len(positional) == 2 && positional[0] == "unlink":
+		loginID, err := parseID(positional[1])
+		if err != nil {
+			return err
+		}
+		action = func() error { return c.link(loginID, 0) }
+	default:
+		return usagef("укажите ID записи, link <id логина> <id TOTP> или unlink <id логина>")
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	return action()
+}
+
+// code печатает текущий код и сколько секунд он ещё действует.
+func (c *OTPCommand) code(id int32) error {
+	dataItem, err := c.fetch(id)
+	if err != nil {
+		return err
+	}
+	data, err := c.resolve(dataItem)
+	if err != nil {
+		return err
+	}
+
+	params := payload.TOTPParams(data)
+	now := c.now()
+	code, err := params.Code(now)
+	if err != nil {
+		return fmt.Errorf("ошибка генерации кода: %w", err)
+	}
+
+	fmt.Fprintf(c.writer, "%s (действует ещё %d с)\n", code, int(params.Remaining(now).Seconds()))
+	return nil
+}
+
+// resolve возвращает TOTP записи: её собственный для totp или связанный для login_password.
+func (c *OTPCommand) resolve(dataItem *datapb.DataItem) (*datapb.Totp, error) {
+	message, err := payload.Decode(dataItem)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка десериализации данных: %w", err)
+	}
+
+	switch data := message.(type) {
+	case *datapb.Totp:
+		return data, nil
+	case *datapb.LoginPassword:
+		if data.TotpId == 0 {
+			return nil, fmt.Errorf("%w: запись %d не связана с записью TOTP", ErrNoTOTP, dataItem.Id)
+		}
+		linked, err := c.fetch(data.TotpId)
+		if err != nil {
+			return nil, err
+		}
+		if linked.InfoType != payload.TOTPType {
+			return nil, fmt.Errorf("%w: связанная запись %d типа %s", ErrNoTOTP, linked.Id, linked.InfoType)
+		}
+		return c.resolve(linked)
+	default:
+		return nil, fmt.Errorf("%w: запись %d типа %s", ErrNoTOTP, dataItem.Id, dataItem.InfoType)
+	}
+}
+
+// link записывает в логин loginID ссылку на запись TOTP totpID. Нулевой totpID удаляет ссылку.
+func (c *OTPCommand) link(loginID, totpID int32) error {
+	dataItem, err := c.fetch(loginID)
+	if err != nil {
+		return err
+	}
+	if err = payload.Unpack(dataItem); err != nil {
+		return fmt.Errorf("ошибка десериализации данных: %w", err)
+	}
+	login := dataItem.GetLoginPassword()
+	if login == nil {
+		return usagef("запись %d типа %s, а не login_password", loginID, dataItem.InfoType)
+	}
+
+	if totpID != 0 {
+		linked, err := c.fetch(totpID)
+		if err != nil {
+			return err
+		}
+		if linked.InfoType != payload.TOTPType {
+			return usagef("запись %d типа %s, а не totp", totpID, linked.InfoType)
+		}
+	}
+	login.TotpId = totpID
+
+	updatedDataItem := updatedItem(dataItem, login, dataItem.Meta)
+	if err = c.dataService.UpdateData(context.Background(), c.tokenHolder.Token, updatedDataItem); err != nil {
+		return fmt.Errorf("ошибка обновления данных: %w", err)
+	}
+
+	return nil
+}
+
+func (c *OTPCommand) fetch(id int32) (*datapb.DataItem, error) {
+	dataItem, err := c.dataService.GetData(context.Background(), c.tokenHolder.Token, id)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения данных: %w", err)
+	}
+
+	return dataItem, nil
+}
+
+// parseTOTP разбирает секрет в base32 или otpauth:// URI, введённые пользователем.
+func parseTOTP(input string) (*datapb.Totp, error) {
+	input = strings.TrimSpace(input)
+	if isOTPAuthURI(input) {
+		params, err := totp.ParseURI(input)
+		if err != nil {
+			return nil, err
+		}
+		return payload.NewTOTP(params), nil
+	}
+
+	data := &datapb.Totp{Secret: totp.NormalizeSecret(input)}
+	if err := payload.TOTPParams(data).Validate(); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// isOTPAuthURI сообщает, введён ли otpauth:// URI, а не секрет.
+func isOTPAuthURI(input string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(input)), "otpauth://")
+}
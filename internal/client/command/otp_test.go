@@ -0,0 +1,109 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeOTPDataService хранит записи в памяти по ID.
+type fakeOTPDataService struct {
+	items map[int32]*datapb.DataItem
+	sent  []*datapb.DataItem
+}
+
+func (f *fakeOTPDataService) GetData(_ context.Context, _ string, id int32) (*datapb.DataItem, error) {
+	item, ok := f.items[id]
+	if !ok {
+		return nil, errors.New("запись не найдена")
+	}
+	return item, nil
+}
+
+func (f *fakeOTPDataService) UpdateData(_ context.Context, _ string, data *datapb.DataItem) error {
+	f.sent = append(f.sent, data)
+	f.items[data.Id] = data
+	return nil
+}
+
+func newOTPVault() *fakeOTPDataService {
+	// Секрет из тестовых векторов RFC 6238: "12345678901234567890".
+	secret := &datapb.Totp{Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", Digits: 8}
+	login := &datapb.LoginPassword{Login: "user", Password: "pass", TotpId: 2}
+
+	return &fakeOTPDataService{items: map[int32]*datapb.DataItem{
+		1: {Id: 1, InfoType: "login_password", Payload: &datapb.DataItem_LoginPassword{LoginPassword: login}},
+		2: {Id: 2, InfoType: "totp", Payload: &datapb.DataItem_Totp{Totp: secret}},
+		3: {Id: 3, InfoType: "text", Info: []byte(`{"Text":"заметка"}`)},
+		4: {Id: 4, InfoType: "login_password", Info: []byte(`{"Login":"other"}`), Revision: 5},
+	}}
+}
+
+func TestOTPCommand_Run(t *testing.T) {
+	dataService := newOTPVault()
+	writer := &bytes.Buffer{}
+	cmd := NewOTPCommand(dataService, &entity.TokenHolder{Token: "token"}, strings.NewReader(""), writer)
+	cmd.now = func() time.Time { return time.Unix(59, 0) }
+
+	assert.Equal(t, "otp", cmd.Name())
+	assert.NoError(t, cmd.Run([]string{"2"}))
+	assert.Equal(t, "94287082 (действует ещё 1 с)\n", writer.String())
+
+	writer.Reset()
+	assert.NoError(t, cmd.Run([]string{"1"}))
+	assert.Equal(t, "94287082 (действует ещё 1 с)\n", writer.String(), "код по ссылке из логина")
+
+	assert.ErrorIs(t, cmd.Run([]string{"3"}), ErrNoTOTP)
+	assert.ErrorIs(t, cmd.Run([]string{"4"}), ErrNoTOTP)
+
+	assert.NoError(t, cmd.Run([]string{"link", "4", "2"}))
+	sent := dataService.sent[0]
+	assert.Equal(t, int32(2), sent.GetLoginPassword().GetTotpId())
+	assert.Equal(t, "other", sent.GetLoginPassword().GetLogin())
+	assert.Equal(t, int64(5), sent.Revision)
+
+	assert.NoError(t, cmd.Run([]string{"unlink", "1"}))
+	assert.Zero(t, dataService.items[1].GetLoginPassword().GetTotpId())
+	assert.Equal(t, "pass", dataService.items[1].GetLoginPassword().GetPassword())
+
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"link", "4", "3"})))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"link", "2", "2"})))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"link", "4"})))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run(nil)))
+
+	cmd = NewOTPCommand(dataService, &entity.TokenHolder{}, strings.NewReader(""), writer)
+	assert.ErrorIs(t, cmd.Run([]string{"2"}), ErrNotLoggedIn)
+}
+
+func TestOTPCommand_Execute(t *testing.T) {
+	writer := &bytes.Buffer{}
+	cmd := NewOTPCommand(newOTPVault(), &entity.TokenHolder{Token: "token"}, strings.NewReader("2\n"), writer)
+	cmd.now = func() time.Time { return time.Unix(1111111109, 0) }
+
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Введите ID записи TOTP или логина: 07081804 (действует ещё 1 с)\n", writer.String())
+}
+
+func TestParseTOTP(t *testing.T) {
+	data, err := parseTOTP(" otpauth://totp/GitHub:alice?secret=JBSWY3DPEHPK3PXP&period=60 ")
+	assert.NoError(t, err)
+	assert.Equal(t, "GitHub", data.Issuer)
+	assert.Equal(t, int32(60), data.Period)
+
+	data, err = parseTOTP("jbsw y3dp ehpk 3pxp")
+	assert.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", data.Secret)
+	assert.Zero(t, data.Digits, "параметры по умолчанию не сохраняются")
+
+	_, err = parseTOTP("https://example.com")
+	assert.Error(t, err)
+	_, err = parseTOTP("otpauth://hotp/x?secret=JBSWY3DPEHPK3PXP")
+	assert.Error(t, err)
+}
@@ -0,0 +1,238 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+)
+
+type recordTypeService interface {
+	CreateRecordType(ctx context.Context, token string, recordType *datapb.RecordType) (int32, error)
+	ListRecordTypes(ctx context.Context, token string) ([]*datapb.RecordType, error)
+	DeleteRecordType(ctx context.Context, token, name string) error
+}
+
+// recordTypeLookup находит схему пользовательского типа записей по имени.
+type recordTypeLookup interface {
+	RecordType(ctx context.Context, token, name string) (*datapb.RecordType, error)
+}
+
+// TypesCommand управляет пользовательскими типами записей: шаблонами со своими полями,
+// например для SSH-ключей, API-токенов или паспортов.
+type TypesCommand struct {
+	recordTypeService recordTypeService
+	tokenHolder       *entity.TokenHolder
+	writer            io.Writer
+}
+
+func NewTypesCommand(
+	recordTypeService recordTypeService,
+	tokenHolder *entity.TokenHolder,
+	writer io.Writer,
+) *TypesCommand {
+	return &TypesCommand{
+		recordTypeService: recordTypeService,
+		tokenHolder:       tokenHolder,
+		writer:            writer,
+	}
+}
+
+func (c *TypesCommand) Name() string {
+	return "types"
+}
+
+func (c *TypesCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	return c.list()
+}
+
+// Run управляет типами записей: `types` выводит их, `types add wifi --field ssid,label=Сеть,required
+// --field password,sensitive` создаёт тип, `types rm wifi` удаляет тип, у которого нет записей.
+func (c *TypesCommand) Run(args []string) error {
+	fs := newFlagSet("types [add <имя> --field <поле>... | rm <имя>]")
+	var fieldSpecs stringsFlag
+	fs.Var(&fieldSpecs, "field", "поле типа (add): имя[,label=подпись][,type=string|number|date|url]"+
+		"[,sensitive][,required]; флаг повторяется для каждого поля")
+
+	positional, err := parseArgs(fs, args, c.writer)
+	if err != nil {
+		return err
+	}
+
+	var action func() error
+	switch {
+	case len(positional) == 0:
+		action = c.list
+	case len(positional) == 2 && positional[0] == "add":
+		recordType := &datapb.RecordType{Name: positional[1]}
+		for _, spec := range fieldSpecs {
+			field, err := parseFieldSpec(spec)
+			if err != nil {
+				return err
+			}
+			recordType.Fields = append(recordType.Fields, field)
+		}
+		action = func() error { return c.create(recordType) }
+	case len(positional) == 2 && positional[0] == "rm":
+		action = func() error { return c.delete(positional[1]) }
+	default:
+		return usagef("укажите add <имя> --field <поле>... или rm <имя>")
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	return action()
+}
+
+func (c *TypesCommand) list() error {
+	recordTypes, err := c.recordTypeService.ListRecordTypes(context.Background(), c.tokenHolder.Token)
+	if err != nil {
+		return fmt.Errorf("ошибка получения типов записей: %w", err)
+	}
+	if len(recordTypes) == 0 {
+		fmt.Fprintln(c.writer, "Пользовательских типов нет.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(c.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ТИП\tПОЛЯ")
+	for _, recordType := range recordTypes {
+		fields := make([]string, 0, len(recordType.Fields))
+		for _, field := range recordType.Fields {
+			fields = append(fields, describeField(field))
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", recordType.Name, strings.Join(fields, ", "))
+	}
+
+	return tw.Flush()
+}
+
+func (c *TypesCommand) create(recordType *datapb.RecordType) error {
+	_, err := c.recordTypeService.CreateRecordType(context.Background(), c.tokenHolder.Token, recordType)
+	if err != nil {
+		return fmt.Errorf("ошибка создания типа записей: %w", err)
+	}
+
+	return nil
+}
+
+func (c *TypesCommand) delete(name string) error {
+	if err := c.recordTypeService.DeleteRecordType(context.Background(), c.tokenHolder.Token, name); err != nil {
+		return fmt.Errorf("ошибка удаления типа записей: %w", err)
+	}
+
+	return nil
+}
+
+// parseFieldSpec разбирает описание поля из флага --field: имя, затем через запятую
+// label=..., type=... и признаки sensitive и required.
+func parseFieldSpec(spec string) (*datapb.RecordField, error) {
+	parts := strings.Split(spec, ",")
+	field := &datapb.RecordField{Name: strings.TrimSpace(parts[0])}
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "label":
+			field.Label = value
+		case "type":
+			fieldType, ok := datapb.RecordField_Type_value[strings.ToUpper(value)]
+			if !ok {
+				return nil, usagef("поле %s: неизвестный тип %s, доступны: string, number, date, url", field.Name, value)
+			}
+			field.Type = datapb.RecordField_Type(fieldType)
+		case "sensitive":
+			field.Sensitive = true
+		case "required":
+			field.Required = true
+		default:
+			return nil, usagef("поле %s: неизвестный параметр %s", field.Name, part)
+		}
+	}
+
+	return field, nil
+}
+
+// firstCustomOption - номер первого пользовательского типа в меню add после встроенных.
+const firstCustomOption = 6
+
+// customItem возвращает запись пользовательского типа со значениями полей fields, проверенными по схеме.
+// Пустые значения не сохраняются.
+func customItem(recordType *datapb.RecordType, fields map[string]string, meta string) (*datapb.DataItem, error) {
+	custom := &datapb.Custom{Fields: make(map[string]string, len(fields))}
+	for name, value := range fields {
+		if value != "" {
+			custom.Fields[name] = value
+		}
+	}
+	if err := payload.ValidateCustom(recordType, custom); err != nil {
+		return nil, fmt.Errorf("некорректные значения полей: %w", err)
+	}
+
+	return newDataItem(recordType.Name, custom, meta), nil
+}
+
+// sensitiveField возвращает первое скрытое поле типа или nil, если таких полей нет.
+func sensitiveField(recordType *datapb.RecordType) *datapb.RecordField {
+	for _, field := range recordType.Fields {
+		if field.Sensitive {
+			return field
+		}
+	}
+
+	return nil
+}
+
+// fieldLabel - подпись поля в вопросах, а если её нет, - имя поля.
+func fieldLabel(field *datapb.RecordField) string {
+	if field.Label != "" {
+		return field.Label
+	}
+
+	return field.Name
+}
+
+// fieldHint - подсказка о формате значения поля для вопросов.
+func fieldHint(field *datapb.RecordField) string {
+	switch field.Type {
+	case datapb.RecordField_NUMBER:
+		return " (число)"
+	case datapb.RecordField_DATE:
+		return " (YYYY-MM-DD)"
+	case datapb.RecordField_URL:
+		return " (URL)"
+	default:
+		return ""
+	}
+}
+
+// describeField - поле типа для списка типов: имя, подпись и параметры, отличные от умолчаний.
+func describeField(field *datapb.RecordField) string {
+	var params []string
+	if field.Label != "" {
+		params = append(params, field.Label)
+	}
+	if field.Type != datapb.RecordField_STRING {
+		params = append(params, strings.ToLower(field.Type.String()))
+	}
+	if field.Sensitive {
+		params = append(params, "скрытое")
+	}
+	if field.Required {
+		params = append(params, "обязательное")
+	}
+	if len(params) == 0 {
+		return field.Name
+	}
+
+	return fmt.Sprintf("%s (%s)", field.Name, strings.Join(params, ", "))
+}
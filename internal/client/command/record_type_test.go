@@ -0,0 +1,94 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRecordTypeService хранит типы записей в памяти.
+type fakeRecordTypeService struct {
+	recordTypes []*datapb.RecordType
+}
+
+func (f *fakeRecordTypeService) CreateRecordType(
+	_ context.Context,
+	_ string,
+	recordType *datapb.RecordType,
+) (int32, error) {
+	recordType.Id = int32(len(f.recordTypes) + 1)
+	f.recordTypes = append(f.recordTypes, recordType)
+	return recordType.Id, nil
+}
+
+func (f *fakeRecordTypeService) ListRecordTypes(context.Context, string) ([]*datapb.RecordType, error) {
+	return f.recordTypes, nil
+}
+
+func (f *fakeRecordTypeService) RecordType(_ context.Context, _, name string) (*datapb.RecordType, error) {
+	for _, recordType := range f.recordTypes {
+		if recordType.Name == name {
+			return recordType, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", entity.ErrRecordTypeNotFound, name)
+}
+
+func (f *fakeRecordTypeService) DeleteRecordType(ctx context.Context, token, name string) error {
+	for i, recordType := range f.recordTypes {
+		if recordType.Name == name {
+			f.recordTypes = append(f.recordTypes[:i], f.recordTypes[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", entity.ErrRecordTypeNotFound, name)
+}
+
+// newWifiTypes возвращает сервис с типом wifi: сеть обязательна, пароль скрыт.
+func newWifiTypes() *fakeRecordTypeService {
+	return &fakeRecordTypeService{recordTypes: []*datapb.RecordType{{Id: 1, Name: "wifi", Fields: []*datapb.RecordField{
+		{Name: "ssid", Label: "Сеть", Required: true},
+		{Name: "password", Label: "Пароль", Sensitive: true},
+		{Name: "expires", Type: datapb.RecordField_DATE},
+	}}}}
+}
+
+func TestTypesCommand_Run(t *testing.T) {
+	recordTypes := &fakeRecordTypeService{}
+	writer := &bytes.Buffer{}
+	cmd := NewTypesCommand(recordTypes, &entity.TokenHolder{Token: "token"}, writer)
+
+	assert.Equal(t, "types", cmd.Name())
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Пользовательских типов нет.\n", writer.String())
+
+	assert.NoError(t, cmd.Run([]string{"add", "wifi",
+		"--field", "ssid,label=Сеть,required",
+		"--field", "password,sensitive",
+		"--field", "expires,type=date",
+	}))
+	assert.NoError(t, cmd.Run([]string{"add", "token", "--field", "value"}))
+
+	writer.Reset()
+	assert.NoError(t, cmd.Run(nil))
+	assert.Equal(t, `ТИП    ПОЛЯ
+wifi   ssid (Сеть, обязательное), password (скрытое), expires (date)
+token  value
+`, writer.String())
+
+	assert.NoError(t, cmd.Run([]string{"rm", "token"}))
+	assert.ErrorIs(t, cmd.Run([]string{"rm", "token"}), entity.ErrRecordTypeNotFound)
+	assert.Len(t, recordTypes.recordTypes, 1)
+
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"add", "ssh", "--field", "key,type=blob"})))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"add", "ssh", "--field", "key,secret"})))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"rm"})))
+
+	cmd = NewTypesCommand(recordTypes, &entity.TokenHolder{}, writer)
+	assert.ErrorIs(t, cmd.Run(nil), ErrNotLoggedIn)
+}
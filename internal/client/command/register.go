@@ -10,11 +10,16 @@ import (
 )
 
 type authService interface {
-	Register(ctx context.Context, login, password string) (string, error)
+	Register(ctx context.Context, login, password string, kdfParams *entity.KDFParams) (*entity.AuthTokens, error)
+}
+
+type vaultCreator interface {
+	Create(masterPassword string) (*entity.KDFParams, []byte, error)
 }
 
 type RegisterCommand struct {
 	authService authService
+	vault       vaultCreator
 	tokenHolder *entity.TokenHolder
 	reader      io.Reader
 	writer      io.Writer
@@ -22,12 +27,14 @@ type RegisterCommand struct {
 
 func NewRegisterCommand(
 	authService authService,
+	vault vaultCreator,
 	tokenHolder *entity.TokenHolder,
 	reader io.Reader,
 	writer io.Writer,
 ) *RegisterCommand {
 	return &RegisterCommand{
 		authService: authService,
+		vault:       vault,
 		tokenHolder: tokenHolder,
 		reader:      reader,
 		writer:      writer,
@@ -61,12 +68,40 @@ func (c *RegisterCommand) Execute() error {
 		return fmt.Errorf("ошибка ввода пароля: %w", scanner.Err())
 	}
 
-	token, err := c.authService.Register(context.Background(), login, password)
+	_, err = fmt.Fprint(c.writer, "Введите мастер-пароль для сквозного шифрования (оставьте пустым, чтобы не включать): ")
+	if err != nil {
+		return fmt.Errorf("ошибка stdin master password: %w", err)
+	}
+	var masterPassword string
+	if scanner.Scan() {
+		masterPassword = scanner.Text()
+	} else {
+		return fmt.Errorf("ошибка ввода мастер-пароля: %w", scanner.Err())
+	}
+
+	var kdfParams *entity.KDFParams
+	var vaultKey []byte
+	if masterPassword != "" {
+		if masterPassword == password {
+			return fmt.Errorf("мастер-пароль не должен совпадать с паролем для входа")
+		}
+
+		kdfParams, vaultKey, err = c.vault.Create(masterPassword)
+		if err != nil {
+			return fmt.Errorf("ошибка создания ключа хранилища: %w", err)
+		}
+	}
+
+	tokens, err := c.authService.Register(context.Background(), login, password, kdfParams)
 	if err != nil {
 		return fmt.Errorf("ошибка регистрации: %w", err)
 	}
 
-	c.tokenHolder.Token = token
+	c.tokenHolder.Token = tokens.Token
+	c.tokenHolder.Login = login
+	c.tokenHolder.RefreshToken = tokens.RefreshToken
+	c.tokenHolder.VaultKey = vaultKey
+	c.tokenHolder.KDFParams = kdfParams
 	_, err = fmt.Fprintln(c.writer, "Регистрация прошла успешно.")
 	if err != nil {
 		return fmt.Errorf("ошибка Fprintln : %w", err)
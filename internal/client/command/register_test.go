@@ -15,44 +15,111 @@ type MockAuthService struct {
 	mock.Mock
 }
 
-func (m *MockAuthService) Register(ctx context.Context, login, password string) (string, error) {
-	args := m.Called(ctx, login, password)
-	return args.String(0), args.Error(1)
+func (m *MockAuthService) Register(
+	ctx context.Context,
+	login, password string,
+	kdfParams *entity.KDFParams,
+) (*entity.AuthTokens, error) {
+	args := m.Called(ctx, login, password, kdfParams)
+	tokens, _ := args.Get(0).(*entity.AuthTokens)
+	return tokens, args.Error(1)
+}
+
+type MockVault struct {
+	mock.Mock
+}
+
+func (m *MockVault) Create(masterPassword string) (*entity.KDFParams, []byte, error) {
+	args := m.Called(masterPassword)
+	params, _ := args.Get(0).(*entity.KDFParams)
+	key, _ := args.Get(1).([]byte)
+	return params, key, args.Error(2)
+}
+
+func (m *MockVault) Unlock(masterPassword string, params *entity.KDFParams) ([]byte, error) {
+	args := m.Called(masterPassword, params)
+	key, _ := args.Get(0).([]byte)
+	return key, args.Error(1)
 }
 
 func TestRegisterCommand_Execute_Success(t *testing.T) {
 	mockAuthService := new(MockAuthService)
 	expectedToken := "mocked_token"
-	mockAuthService.On("Register", mock.Anything, "testuser", "testpass").Return(expectedToken, nil)
+	mockAuthService.On("Register", mock.Anything, "testuser", "testpass", (*entity.KDFParams)(nil)).
+		Return(&entity.AuthTokens{Token: expectedToken, RefreshToken: "refresh"}, nil)
 
 	tokenHolder := &entity.TokenHolder{}
 
-	input := "testuser\ntestpass\n"
+	input := "testuser\ntestpass\n\n"
 	reader := bytes.NewBufferString(input)
 	writer := &bytes.Buffer{}
 
-	cmd := NewRegisterCommand(mockAuthService, tokenHolder, reader, writer)
+	cmd := NewRegisterCommand(mockAuthService, nil, tokenHolder, reader, writer)
 
 	err := cmd.Execute()
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedToken, tokenHolder.Token)
+	assert.Equal(t, "testuser", tokenHolder.Login)
+	assert.Equal(t, "refresh", tokenHolder.RefreshToken)
 	assert.Contains(t, writer.String(), "Регистрация прошла успешно.")
 
 	mockAuthService.AssertExpectations(t)
 }
 
+func TestRegisterCommand_Execute_WithMasterPassword(t *testing.T) {
+	kdfParams := &entity.KDFParams{Salt: []byte("salt"), Time: 3, MemoryKiB: 65536, Threads: 4}
+	vaultKey := []byte("0123456789abcdef0123456789abcdef")
+
+	mockVault := new(MockVault)
+	mockVault.On("Create", "masterpass").Return(kdfParams, vaultKey, nil)
+
+	mockAuthService := new(MockAuthService)
+	mockAuthService.On("Register", mock.Anything, "testuser", "testpass", kdfParams).Return(&entity.AuthTokens{Token: "mocked_token"}, nil)
+
+	tokenHolder := &entity.TokenHolder{}
+	reader := bytes.NewBufferString("testuser\ntestpass\nmasterpass\n")
+	writer := &bytes.Buffer{}
+
+	cmd := NewRegisterCommand(mockAuthService, mockVault, tokenHolder, reader, writer)
+
+	err := cmd.Execute()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mocked_token", tokenHolder.Token)
+	assert.Equal(t, vaultKey, tokenHolder.VaultKey)
+	mockVault.AssertExpectations(t)
+	mockAuthService.AssertExpectations(t)
+}
+
+func TestRegisterCommand_Execute_MasterPasswordEqualsPassword(t *testing.T) {
+	mockAuthService := new(MockAuthService)
+	tokenHolder := &entity.TokenHolder{}
+	reader := bytes.NewBufferString("testuser\ntestpass\ntestpass\n")
+	writer := &bytes.Buffer{}
+
+	cmd := NewRegisterCommand(mockAuthService, new(MockVault), tokenHolder, reader, writer)
+
+	err := cmd.Execute()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "мастер-пароль не должен совпадать")
+	assert.Empty(t, tokenHolder.Token)
+	mockAuthService.AssertNotCalled(t, "Register", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestRegisterCommand_Execute_RegisterError(t *testing.T) {
 	mockAuthService := new(MockAuthService)
-	mockAuthService.On("Register", mock.Anything, "testuser", "wrongpass").Return("", errors.New("registration failed"))
+	mockAuthService.On("Register", mock.Anything, "testuser", "wrongpass", (*entity.KDFParams)(nil)).
+		Return(nil, errors.New("registration failed"))
 
 	tokenHolder := &entity.TokenHolder{}
 
-	input := "testuser\nwrongpass\n"
+	input := "testuser\nwrongpass\n\n"
 	reader := bytes.NewBufferString(input)
 	writer := &bytes.Buffer{}
 
-	cmd := NewRegisterCommand(mockAuthService, tokenHolder, reader, writer)
+	cmd := NewRegisterCommand(mockAuthService, nil, tokenHolder, reader, writer)
 
 	err := cmd.Execute()
 
@@ -72,7 +139,7 @@ func TestRegisterCommand_Execute_InputError(t *testing.T) {
 	reader := bytes.NewBuffer(nil)
 	writer := &bytes.Buffer{}
 
-	cmd := NewRegisterCommand(mockAuthService, tokenHolder, reader, writer)
+	cmd := NewRegisterCommand(mockAuthService, nil, tokenHolder, reader, writer)
 
 	err := cmd.Execute()
 
@@ -82,7 +149,7 @@ func TestRegisterCommand_Execute_InputError(t *testing.T) {
 }
 
 func TestRegisterCommand_Name(t *testing.T) {
-	cmd := NewRegisterCommand(nil, nil, nil, nil)
+	cmd := NewRegisterCommand(nil, nil, nil, nil, nil)
 	expectedName := "register"
 	actualName := cmd.Name()
 	assert.Equal(t, expectedName, actualName, "Название команды должно быть 'register'")
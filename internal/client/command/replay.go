@@ -0,0 +1,39 @@
+package command
+
+import (
+	"bytes"
+	"io"
+)
+
+// ReplayReader запоминает прочитанное из src, чтобы команду, повторённую после входа,
+// можно было выполнить с тем же вводом: первая попытка уже вычитала stdin.
+type ReplayReader struct {
+	src  io.Reader
+	read bytes.Buffer
+	pos  int
+}
+
+// NewReplayReader - конструктор читателя, запоминающего прочитанное из src.
+func NewReplayReader(src io.Reader) *ReplayReader {
+	return &ReplayReader{src: src}
+}
+
+// Read сначала отдаёт запомненное после Rewind, затем читает src.
+func (r *ReplayReader) Read(p []byte) (int, error) {
+	if r.pos < r.read.Len() {
+		n := copy(p, r.read.Bytes()[r.pos:])
+		r.pos += n
+		return n, nil
+	}
+
+	n, err := r.src.Read(p)
+	r.read.Write(p[:n])
+	r.pos += n
+
+	return n, err
+}
+
+// Rewind возвращает чтение к началу: всё прочитанное ранее будет отдано повторно.
+func (r *ReplayReader) Rewind() {
+	r.pos = 0
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+)
+
+type restoreService interface {
+	RestoreVersion(ctx context.Context, token string, id int32, revision int64) (int64, error)
+}
+
+// RestoreCommand возвращает записи содержимое прежней версии. Текущее содержимое
+// сохраняется как новая версия, поэтому восстановление можно отменить так же.
+type RestoreCommand struct {
+	historyService restoreService
+	tokenHolder    *entity.TokenHolder
+	reader         io.Reader
+	writer         io.Writer
+}
+
+func NewRestoreCommand(
+	historyService restoreService,
+	tokenHolder *entity.TokenHolder,
+	reader io.Reader,
+	writer io.Writer,
+) *RestoreCommand {
+	return &RestoreCommand{
+		historyService: historyService,
+		tokenHolder:    tokenHolder,
+		reader:         reader,
+		writer:         writer,
+	}
+}
+
+func (c *RestoreCommand) Name() string {
+	return "restore"
+}
+
+func (c *RestoreCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	scanner := bufio.NewScanner(c.reader)
+
+	fmt.Fprint(c.writer, "Введите ID данных: ")
+	if !scanner.Scan() {
+		return fmt.Errorf("ошибка ввода ID: %w", scanner.Err())
+	}
+	id, err := parseID(strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(c.writer, "Введите ревизию версии (см. history): ")
+	if !scanner.Scan() {
+		return fmt.Errorf("ошибка ввода ревизии: %w", scanner.Err())
+	}
+	revision, err := parseRevision(strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		return err
+	}
+
+	if err = c.restore(id, revision); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.writer, "Версия восстановлена.")
+	return nil
+}
+
+// Run восстанавливает версию записи: `restore 42 7`. При успехе ничего не выводит.
+func (c *RestoreCommand) Run(args []string) error {
+	positional, err := parseArgs(newFlagSet("restore <id> <ревизия>"), args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return usagef("укажите ID записи и ревизию версии")
+	}
+	id, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+	revision, err := parseRevision(positional[1])
+	if err != nil {
+		return err
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	return c.restore(id, revision)
+}
+
+func (c *RestoreCommand) restore(id int32, revision int64) error {
+	_, err := c.historyService.RestoreVersion(context.Background(), c.tokenHolder.Token, id, revision)
+	if err != nil {
+		return fmt.Errorf("ошибка восстановления версии: %w", err)
+	}
+
+	return nil
+}
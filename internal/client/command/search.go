@@ -0,0 +1,170 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/client/output"
+)
+
+const (
+	defaultSearchLimit = 50
+	searchPageSize     = 100
+	searchDateLayout   = "2006-01-02"
+)
+
+type searchDataService interface {
+	SearchData(ctx context.Context, token string, query *entity.SearchQuery) (*entity.DataPage, error)
+}
+
+// SearchCommand ищет записи по словам из мета, типу и дате создания.
+type SearchCommand struct {
+	dataService searchDataService
+	formatter   output.Formatter
+	tokenHolder *entity.TokenHolder
+	reader      io.Reader
+	writer      io.Writer
+}
+
+func NewSearchCommand(
+	dataService searchDataService,
+	formatter output.Formatter,
+	tokenHolder *entity.TokenHolder,
+	reader io.Reader,
+	writer io.Writer,
+) *SearchCommand {
+	return &SearchCommand{
+		dataService: dataService,
+		formatter:   formatter,
+		tokenHolder: tokenHolder,
+		reader:      reader,
+		writer:      writer,
+	}
+}
+
+func (c *SearchCommand) Name() string {
+	return "search"
+}
+
+func (c *SearchCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	scanner := bufio.NewScanner(c.reader)
+
+	fmt.Fprint(c.writer, "Введите слова для поиска по мета: ")
+	if !scanner.Scan() {
+		return fmt.Errorf("ошибка ввода строки поиска: %w", scanner.Err())
+	}
+	text := scanner.Text()
+
+	fmt.Fprint(c.writer, "Введите тип данных для фильтрации (оставьте пустым для всех типов): ")
+	if !scanner.Scan() {
+		return fmt.Errorf("ошибка ввода типа данных: %w", scanner.Err())
+	}
+
+	dataItems, err := c.search(&entity.SearchQuery{Text: text, InfoType: scanner.Text()}, defaultSearchLimit)
+	if err != nil {
+		return err
+	}
+
+	return c.formatter.List(c.writer, summaries(dataItems))
+}
+
+// Run ищет записи: `search рабочая почта --type login_password --from 2024-01-01 --sort oldest`.
+// Слова ищутся в мета без учёта регистра, каждое слово длиной от трёх букв - по любой его части.
+func (c *SearchCommand) Run(args []string) error {
+	fs := newFlagSet("search [слова] [флаги]")
+	infoType := fs.String("type", "", "искать только записи этого типа")
+	from := fs.String("from", "", "созданные не раньше этой даты, ГГГГ-ММ-ДД")
+	to := fs.String("to", "", "созданные не позже этой даты, ГГГГ-ММ-ДД")
+	order := fs.String("sort", "newest", "порядок по дате создания: newest или oldest")
+	limit := fs.Int("limit", defaultSearchLimit, "сколько записей вывести")
+	format := outputFlag(fs)
+
+	positional, err := parseArgs(fs, args, c.writer)
+	if err != nil {
+		return err
+	}
+
+	query := &entity.SearchQuery{Text: strings.Join(positional, " "), InfoType: *infoType}
+	switch *order {
+	case "newest":
+	case "oldest":
+		query.Ascending = true
+	default:
+		return usagef("неизвестный порядок %q, ожидается newest или oldest", *order)
+	}
+	if *limit < 1 {
+		return usagef("--limit должен быть больше нуля")
+	}
+	if query.CreatedFrom, err = parseSearchDate("--from", *from); err != nil {
+		return err
+	}
+	if query.CreatedTo, err = parseSearchDate("--to", *to); err != nil {
+		return err
+	}
+	if !query.CreatedTo.IsZero() {
+		// --to включает весь указанный день.
+		query.CreatedTo = query.CreatedTo.AddDate(0, 0, 1)
+		if !query.CreatedFrom.Before(query.CreatedTo) {
+			return usagef("дата --from позже даты --to")
+		}
+	}
+	formatter, err := formatterFor(c.formatter, *format)
+	if err != nil {
+		return err
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	dataItems, err := c.search(query, *limit)
+	if err != nil {
+		return err
+	}
+
+	return formatter.List(c.writer, summaries(dataItems))
+}
+
+// search запрашивает страницы результатов, пока не наберёт limit записей или выдача не кончится.
+func (c *SearchCommand) search(query *entity.SearchQuery, limit int) ([]*datapb.DataItem, error) {
+	query.PageSize = searchPageSize
+
+	var dataItems []*datapb.DataItem
+	for {
+		page, err := c.dataService.SearchData(context.Background(), c.tokenHolder.Token, query)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка поиска данных: %w", err)
+		}
+
+		dataItems = append(dataItems, page.Items...)
+		if len(dataItems) >= limit {
+			return dataItems[:limit], nil
+		}
+		if page.NextPageToken == "" {
+			return dataItems, nil
+		}
+		query.PageToken = page.NextPageToken
+	}
+}
+
+func parseSearchDate(flagName, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.ParseInLocation(searchDateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, usagef("некорректная дата %s: %s, ожидается ГГГГ-ММ-ДД", flagName, value)
+	}
+
+	return date, nil
+}
@@ -0,0 +1,132 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type mockSearchDataService struct {
+	SearchDataFunc func(ctx context.Context, token string, query *entity.SearchQuery) (*entity.DataPage, error)
+	queries        []entity.SearchQuery
+}
+
+func (m *mockSearchDataService) SearchData(
+	ctx context.Context,
+	token string,
+	query *entity.SearchQuery,
+) (*entity.DataPage, error) {
+	m.queries = append(m.queries, *query)
+	return m.SearchDataFunc(ctx, token, query)
+}
+
+func TestSearchCommand_Execute(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	dataService := &mockSearchDataService{
+		SearchDataFunc: func(context.Context, string, *entity.SearchQuery) (*entity.DataPage, error) {
+			return &entity.DataPage{Items: []*datapb.DataItem{
+				{Id: 3, InfoType: "login_password", Meta: "рабочая почта", Created: timestamppb.New(created)},
+			}}, nil
+		},
+	}
+	writer := &bytes.Buffer{}
+	cmd := NewSearchCommand(dataService, textOutput, &entity.TokenHolder{Token: "token"},
+		strings.NewReader("почта\nlogin_password\n"), writer)
+
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "почта", dataService.queries[0].Text)
+	assert.Equal(t, "login_password", dataService.queries[0].InfoType)
+	assert.Contains(t, writer.String(),
+		"ID: 3, Тип: login_password, Мета: рабочая почта, Дата создания: 2024-05-01 10:00:00\n")
+
+	cmd = NewSearchCommand(dataService, textOutput, &entity.TokenHolder{}, strings.NewReader(""), writer)
+	assert.ErrorIs(t, cmd.Execute(), ErrNotLoggedIn)
+}
+
+func TestSearchCommand_Name(t *testing.T) {
+	cmd := NewSearchCommand(nil, textOutput, nil, nil, nil)
+	assert.Equal(t, "search", cmd.Name())
+}
+
+func TestSearchCommand_Run(t *testing.T) {
+	tokenHolder := &entity.TokenHolder{Token: "token"}
+	item := func(id int32) *datapb.DataItem {
+		return &datapb.DataItem{Id: id, InfoType: "text", Meta: "заметка", Created: timestamppb.New(time.Unix(0, 0))}
+	}
+
+	t.Run("Параметры поиска", func(t *testing.T) {
+		dataService := &mockSearchDataService{
+			SearchDataFunc: func(context.Context, string, *entity.SearchQuery) (*entity.DataPage, error) {
+				return &entity.DataPage{Items: []*datapb.DataItem{item(1)}}, nil
+			},
+		}
+		writer := &bytes.Buffer{}
+		cmd := NewSearchCommand(dataService, textOutput, tokenHolder, strings.NewReader(""), writer)
+
+		err := cmd.Run([]string{"рабочая", "--type", "text", "почта", "--from", "2024-01-01", "--to", "2024-01-31",
+			"--sort", "oldest", "-o", "json"})
+
+		assert.NoError(t, err)
+		if assert.Len(t, dataService.queries, 1) {
+			query := dataService.queries[0]
+			assert.Equal(t, "рабочая почта", query.Text)
+			assert.Equal(t, "text", query.InfoType)
+			assert.True(t, query.Ascending)
+			assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), query.CreatedFrom)
+			assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local), query.CreatedTo, "--to включает весь день")
+		}
+		assert.JSONEq(t, `[{"id":1,"type":"text","meta":"заметка","created":"1970-01-01T00:00:00Z"}]`, writer.String())
+	})
+
+	t.Run("Страницы до лимита", func(t *testing.T) {
+		dataService := &mockSearchDataService{
+			SearchDataFunc: func(_ context.Context, _ string, query *entity.SearchQuery) (*entity.DataPage, error) {
+				if query.PageToken == "" {
+					return &entity.DataPage{Items: []*datapb.DataItem{item(1), item(2)}, NextPageToken: "p2"}, nil
+				}
+				return &entity.DataPage{Items: []*datapb.DataItem{item(3), item(4)}, NextPageToken: "p3"}, nil
+			},
+		}
+		writer := &bytes.Buffer{}
+		cmd := NewSearchCommand(dataService, textOutput, tokenHolder, strings.NewReader(""), writer)
+
+		assert.NoError(t, cmd.Run([]string{"--limit", "3", "-o", "json"}))
+		assert.Len(t, dataService.queries, 2)
+		assert.Equal(t, "p2", dataService.queries[1].PageToken)
+		assert.Equal(t, 3, strings.Count(writer.String(), `"id"`))
+	})
+
+	t.Run("Ошибка сервиса", func(t *testing.T) {
+		dataService := &mockSearchDataService{
+			SearchDataFunc: func(context.Context, string, *entity.SearchQuery) (*entity.DataPage, error) {
+				return nil, errors.New("service error")
+			},
+		}
+		cmd := NewSearchCommand(dataService, textOutput, tokenHolder, strings.NewReader(""), &bytes.Buffer{})
+
+		err := cmd.Run([]string{"почта"})
+		assert.ErrorContains(t, err, "ошибка поиска данных:")
+		assert.Equal(t, ExitError, ExitCode(err))
+	})
+
+	t.Run("Неверные аргументы", func(t *testing.T) {
+		cmd := NewSearchCommand(&mockSearchDataService{}, textOutput, tokenHolder, strings.NewReader(""), &bytes.Buffer{})
+
+		for _, args := range [][]string{
+			{"--from", "01.01.2024"},
+			{"--from", "2024-02-01", "--to", "2024-01-01"},
+			{"--sort", "random"},
+			{"--limit", "0"},
+		} {
+			assert.Equal(t, ExitUsage, ExitCode(cmd.Run(args)), "%v", args)
+		}
+	})
+}
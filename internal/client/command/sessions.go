@@ -0,0 +1,100 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+)
+
+const sessionTimeLayout = "2006-01-02 15:04:05"
+
+type sessionsService interface {
+	ListSessions(ctx context.Context, token string) ([]*entity.Session, error)
+	RevokeSession(ctx context.Context, token, sessionID string) error
+}
+
+type SessionsCommand struct {
+	authService sessionsService
+	tokenHolder *entity.TokenHolder
+	reader      io.Reader
+	writer      io.Writer
+}
+
+func NewSessionsCommand(
+	authService sessionsService,
+	tokenHolder *entity.TokenHolder,
+	reader io.Reader,
+	writer io.Writer,
+) *SessionsCommand {
+	return &SessionsCommand{
+		authService: authService,
+		tokenHolder: tokenHolder,
+		reader:      reader,
+		writer:      writer,
+	}
+}
+
+func (c *SessionsCommand) Name() string {
+	return "sessions"
+}
+
+func (c *SessionsCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	sessions, err := c.authService.ListSessions(context.Background(), c.tokenHolder.Token)
+	if err != nil {
+		return fmt.Errorf("ошибка получения списка сессий: %w", err)
+	}
+
+	for _, session := range sessions {
+		current := ""
+		if session.Current {
+			current = " (текущая)"
+		}
+		_, err = fmt.Fprintf(
+			c.writer,
+			"ID: %s%s, IP: %s, клиент: %s, вход: %s, активность: %s\n",
+			session.ID,
+			current,
+			session.IP,
+			session.UserAgent,
+			session.CreatedAt.Local().Format(sessionTimeLayout),
+			session.LastUsedAt.Local().Format(sessionTimeLayout),
+		)
+		if err != nil {
+			return fmt.Errorf("ошибка вывода сессии: %w", err)
+		}
+	}
+
+	_, err = fmt.Fprint(c.writer, "Введите ID сессии для завершения (пусто - отмена): ")
+	if err != nil {
+		return fmt.Errorf("ошибка вывода запроса на ввод ID: %w", err)
+	}
+
+	scanner := bufio.NewScanner(c.reader)
+	if !scanner.Scan() {
+		return nil
+	}
+	sessionID := strings.TrimSpace(scanner.Text())
+	if sessionID == "" {
+		return nil
+	}
+
+	err = c.authService.RevokeSession(context.Background(), c.tokenHolder.Token, sessionID)
+	if err != nil {
+		return fmt.Errorf("ошибка завершения сессии: %w", err)
+	}
+
+	_, err = fmt.Fprintln(c.writer, "Сессия завершена.")
+	if err != nil {
+		return fmt.Errorf("ошибка вывода результата: %w", err)
+	}
+
+	return nil
+}
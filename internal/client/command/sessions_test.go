@@ -0,0 +1,145 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MockSessionsService struct {
+	mock.Mock
+}
+
+func (m *MockSessionsService) ListSessions(ctx context.Context, token string) ([]*entity.Session, error) {
+	args := m.Called(ctx, token)
+	sessions, _ := args.Get(0).([]*entity.Session)
+	return sessions, args.Error(1)
+}
+
+func (m *MockSessionsService) RevokeSession(ctx context.Context, token, sessionID string) error {
+	args := m.Called(ctx, token, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockSessionsService) Logout(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func TestSessionsCommand_Execute(t *testing.T) {
+	now := time.Now()
+	sessions := []*entity.Session{
+		{ID: "current", IP: "10.0.0.1", UserAgent: "grpc-go", CreatedAt: now, LastUsedAt: now, Current: true},
+		{ID: "laptop", IP: "10.0.0.2", UserAgent: "grpc-go", CreatedAt: now, LastUsedAt: now},
+	}
+
+	tests := []struct {
+		name          string
+		token         string
+		input         string
+		mockSetup     func(m *MockSessionsService)
+		expectedError string
+		contains      []string
+	}{
+		{
+			name:  "Завершение чужой сессии",
+			token: "token",
+			input: "laptop\n",
+			mockSetup: func(m *MockSessionsService) {
+				m.On("ListSessions", mock.Anything, "token").Return(sessions, nil)
+				m.On("RevokeSession", mock.Anything, "token", "laptop").Return(nil)
+			},
+			contains: []string{"ID: current (текущая)", "ID: laptop, IP: 10.0.0.2", "Сессия завершена."},
+		},
+		{
+			name:  "Отмена",
+			token: "token",
+			input: "\n",
+			mockSetup: func(m *MockSessionsService) {
+				m.On("ListSessions", mock.Anything, "token").Return(sessions, nil)
+			},
+			contains: []string{"ID: laptop"},
+		},
+		{
+			name:  "Сессия не найдена",
+			token: "token",
+			input: "unknown\n",
+			mockSetup: func(m *MockSessionsService) {
+				m.On("ListSessions", mock.Anything, "token").Return(sessions, nil)
+				m.On("RevokeSession", mock.Anything, "token", "unknown").Return(errors.New("not found"))
+			},
+			expectedError: "ошибка завершения сессии: not found",
+		},
+		{
+			name:          "Отсутствие токена",
+			mockSetup:     func(m *MockSessionsService) {},
+			expectedError: "вы должны войти в систему",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSessionsService)
+			tt.mockSetup(mockService)
+			writer := &bytes.Buffer{}
+
+			cmd := NewSessionsCommand(
+				mockService, &entity.TokenHolder{Token: tt.token}, bytes.NewBufferString(tt.input), writer,
+			)
+			err := cmd.Execute()
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, s := range tt.contains {
+				assert.Contains(t, writer.String(), s)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLogoutCommand_Execute(t *testing.T) {
+	t.Run("Успешный выход", func(t *testing.T) {
+		mockService := new(MockSessionsService)
+		mockService.On("Logout", mock.Anything, "token").Return(nil)
+		holder := &entity.TokenHolder{Token: "token", RefreshToken: "refresh", VaultKey: []byte("key")}
+
+		err := NewLogoutCommand(mockService, holder, &bytes.Buffer{}).Execute()
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.TokenHolder{}, holder)
+	})
+
+	t.Run("Ошибка сервера", func(t *testing.T) {
+		mockService := new(MockSessionsService)
+		mockService.On("Logout", mock.Anything, "token").Return(errors.New("unavailable"))
+		holder := &entity.TokenHolder{Token: "token", RefreshToken: "refresh"}
+
+		err := NewLogoutCommand(mockService, holder, &bytes.Buffer{}).Execute()
+
+		assert.EqualError(t, err, "ошибка выхода: unavailable")
+		assert.Equal(t, "token", holder.Token)
+	})
+
+	t.Run("Сессия уже завершена на сервере", func(t *testing.T) {
+		mockService := new(MockSessionsService)
+		mockService.On("Logout", mock.Anything, "token").Return(status.Error(codes.Unauthenticated, "сессия завершена"))
+		holder := &entity.TokenHolder{Token: "token", RefreshToken: "refresh", KDFParams: &entity.KDFParams{}}
+
+		err := NewLogoutCommand(mockService, holder, &bytes.Buffer{}).Execute()
+
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.TokenHolder{}, holder)
+	})
+}
@@ -0,0 +1,83 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+)
+
+type syncService interface {
+	Sync(ctx context.Context, token string) (*entity.SyncResult, error)
+}
+
+type SyncCommand struct {
+	syncService syncService
+	tokenHolder *entity.TokenHolder
+	writer      io.Writer
+}
+
+func NewSyncCommand(syncService syncService, tokenHolder *entity.TokenHolder, writer io.Writer) *SyncCommand {
+	return &SyncCommand{
+		syncService: syncService,
+		tokenHolder: tokenHolder,
+		writer:      writer,
+	}
+}
+
+func (c *SyncCommand) Name() string {
+	return "sync"
+}
+
+// Run синхронизирует кеш из командной строки: `sync`. Аргументов у команды нет.
+func (c *SyncCommand) Run(args []string) error {
+	positional, err := parseArgs(newFlagSet("sync"), args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("лишние аргументы: %s", strings.Join(positional, " "))
+	}
+
+	return c.Execute()
+}
+
+func (c *SyncCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	result, err := c.syncService.Sync(context.Background(), c.tokenHolder.Token)
+	if err != nil {
+		if result != nil && result.Pushed > 0 {
+			_, _ = fmt.Fprintf(c.writer, "Отправлено изменений до ошибки: %d\n", result.Pushed)
+		}
+		return fmt.Errorf("ошибка синхронизации: %w", err)
+	}
+
+	_, err = fmt.Fprintf(
+		c.writer,
+		"Синхронизация завершена: отправлено %d, получено %d изменений, ревизия %d.\n",
+		result.Pushed,
+		result.Pulled,
+		result.Revision,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка вывода результата: %w", err)
+	}
+
+	if result.Conflicts > 0 {
+		_, err = fmt.Fprintf(
+			c.writer,
+			"Записей, изменённых на другом устройстве: %d. Ваши правки сохранены отдельными записями.\n",
+			result.Conflicts,
+		)
+		if err != nil {
+			return fmt.Errorf("ошибка вывода результата: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSyncService struct {
+	mock.Mock
+}
+
+func (m *MockSyncService) Sync(ctx context.Context, token string) (*entity.SyncResult, error) {
+	args := m.Called(ctx, token)
+	result, _ := args.Get(0).(*entity.SyncResult)
+	return result, args.Error(1)
+}
+
+func TestSyncCommand_Execute(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		result        *entity.SyncResult
+		err           error
+		expectedError string
+		contains      string
+	}{
+		{
+			name:     "Успешная синхронизация",
+			token:    "token",
+			result:   &entity.SyncResult{Pushed: 2, Pulled: 5, Revision: 42},
+			contains: "отправлено 2, получено 5 изменений, ревизия 42",
+		},
+		{
+			name:     "Конфликты сохранены копиями",
+			token:    "token",
+			result:   &entity.SyncResult{Pushed: 1, Revision: 7, Conflicts: 1},
+			contains: "Записей, изменённых на другом устройстве: 1",
+		},
+		{
+			name:          "Сервер недоступен",
+			token:         "token",
+			result:        &entity.SyncResult{Pushed: 1},
+			err:           errors.New("connection refused"),
+			expectedError: "ошибка синхронизации",
+			contains:      "Отправлено изменений до ошибки: 1",
+		},
+		{
+			name:          "Пользователь не вошёл",
+			expectedError: "вы должны войти в систему",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSyncService)
+			mockService.On("Sync", mock.Anything, tt.token).Return(tt.result, tt.err).Maybe()
+
+			writer := &bytes.Buffer{}
+			err := NewSyncCommand(mockService, &entity.TokenHolder{Token: tt.token}, writer).Execute()
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Contains(t, writer.String(), tt.contains)
+		})
+	}
+}
+
+func TestSyncCommand_Name(t *testing.T) {
+	assert.Equal(t, "sync", NewSyncCommand(nil, &entity.TokenHolder{}, nil).Name())
+}
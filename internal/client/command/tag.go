@@ -0,0 +1,138 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+)
+
+type tagDataService interface {
+	tagService
+	TagData(ctx context.Context, token string, dataID int32, tagIDs []int32) error
+	UntagData(ctx context.Context, token string, dataID int32, tagIDs []int32) error
+}
+
+// TagCommand добавляет записи теги по именам или снимает их, если untag.
+// Недостающие теги при добавлении создаются.
+type TagCommand struct {
+	organizerService tagDataService
+	tokenHolder      *entity.TokenHolder
+	reader           io.Reader
+	writer           io.Writer
+	untag            bool
+}
+
+func NewTagCommand(
+	organizerService tagDataService,
+	tokenHolder *entity.TokenHolder,
+	reader io.Reader,
+	writer io.Writer,
+) *TagCommand {
+	return &TagCommand{
+		organizerService: organizerService,
+		tokenHolder:      tokenHolder,
+		reader:           reader,
+		writer:           writer,
+	}
+}
+
+func NewUntagCommand(
+	organizerService tagDataService,
+	tokenHolder *entity.TokenHolder,
+	reader io.Reader,
+	writer io.Writer,
+) *TagCommand {
+	cmd := NewTagCommand(organizerService, tokenHolder, reader, writer)
+	cmd.untag = true
+	return cmd
+}
+
+func (c *TagCommand) Name() string {
+	if c.untag {
+		return "untag"
+	}
+	return "tag"
+}
+
+func (c *TagCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	scanner := bufio.NewScanner(c.reader)
+
+	fmt.Fprint(c.writer, "Введите ID данных: ")
+	if !scanner.Scan() {
+		return fmt.Errorf("ошибка ввода ID: %w", scanner.Err())
+	}
+	id, err := parseID(strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(c.writer, "Введите теги через пробел: ")
+	if !scanner.Scan() {
+		return fmt.Errorf("ошибка ввода тегов: %w", scanner.Err())
+	}
+	names := strings.Fields(scanner.Text())
+	if len(names) == 0 {
+		return fmt.Errorf("теги не указаны")
+	}
+
+	if err = c.tag(id, names); err != nil {
+		return err
+	}
+
+	if c.untag {
+		fmt.Fprintln(c.writer, "Теги сняты.")
+	} else {
+		fmt.Fprintln(c.writer, "Теги добавлены.")
+	}
+
+	return nil
+}
+
+// Run добавляет или снимает теги: `tag 42 работа важное`, `untag 42 важное`. При успехе ничего не выводит.
+func (c *TagCommand) Run(args []string) error {
+	positional, err := parseArgs(newFlagSet(c.Name()+" <id> <тег>..."), args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 {
+		return usagef("укажите ID записи и хотя бы один тег")
+	}
+	id, err := parseID(positional[0])
+	if err != nil {
+		return err
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	return c.tag(id, positional[1:])
+}
+
+func (c *TagCommand) tag(id int32, names []string) error {
+	tagIDs, err := resolveTags(c.organizerService, c.tokenHolder.Token, names, !c.untag)
+	if err != nil {
+		return err
+	}
+
+	if c.untag {
+		err = c.organizerService.UntagData(context.Background(), c.tokenHolder.Token, id, tagIDs)
+		if err != nil {
+			return fmt.Errorf("ошибка снятия тегов: %w", err)
+		}
+		return nil
+	}
+
+	if err = c.organizerService.TagData(context.Background(), c.tokenHolder.Token, id, tagIDs); err != nil {
+		return fmt.Errorf("ошибка добавления тегов: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagCommand_Name(t *testing.T) {
+	assert.Equal(t, "tag", NewTagCommand(nil, nil, nil, nil).Name())
+	assert.Equal(t, "untag", NewUntagCommand(nil, nil, nil, nil).Name())
+}
+
+func TestTagCommand_Run(t *testing.T) {
+	tokenHolder := &entity.TokenHolder{Token: "token"}
+	organizer := newFakeOrganizerService()
+	organizer.tags = []*entity.Tag{{ID: 1, Name: "важное"}}
+
+	cmd := NewTagCommand(organizer, tokenHolder, strings.NewReader(""), &bytes.Buffer{})
+	assert.NoError(t, cmd.Run([]string{"42", "важное", "работа"}))
+	assert.Equal(t, []int32{1, 2}, organizer.tagged[42])
+	assert.Equal(t, "работа", organizer.tags[1].Name, "недостающий тег создаётся")
+
+	untag := NewUntagCommand(organizer, tokenHolder, strings.NewReader(""), &bytes.Buffer{})
+	assert.NoError(t, untag.Run([]string{"42", "работа"}))
+	assert.Equal(t, []int32{2}, organizer.untagged[42])
+
+	assert.EqualError(t, untag.Run([]string{"42", "личное"}), `тег "личное" не найден`)
+	assert.Len(t, organizer.tags, 2, "untag не создаёт теги")
+
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"42"})))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"abc", "важное"})))
+
+	cmd = NewTagCommand(organizer, &entity.TokenHolder{}, strings.NewReader(""), &bytes.Buffer{})
+	assert.ErrorIs(t, cmd.Run([]string{"42", "важное"}), ErrNotLoggedIn)
+}
+
+func TestTagCommand_Execute(t *testing.T) {
+	organizer := newFakeOrganizerService()
+	writer := &bytes.Buffer{}
+	cmd := NewTagCommand(organizer, &entity.TokenHolder{Token: "token"}, strings.NewReader("7\nа б\n"), writer)
+
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, []int32{1, 2}, organizer.tagged[7])
+	assert.Contains(t, writer.String(), "Теги добавлены.")
+
+	cmd = NewTagCommand(organizer, &entity.TokenHolder{Token: "token"}, strings.NewReader("7\n\n"), writer)
+	assert.EqualError(t, cmd.Execute(), "теги не указаны")
+}
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+)
+
+type trashService interface {
+	ListTrash(ctx context.Context, token string) ([]*datapb.DataItem, error)
+	RestoreData(ctx context.Context, token string, id int32) error
+	PurgeData(ctx context.Context, token string, id int32) error
+	EmptyTrash(ctx context.Context, token string) (int64, error)
+}
+
+// TrashCommand показывает корзину, возвращает из неё записи и удаляет их навсегда.
+type TrashCommand struct {
+	trashService trashService
+	tokenHolder  *entity.TokenHolder
+	writer       io.Writer
+}
+
+func NewTrashCommand(trashService trashService, tokenHolder *entity.TokenHolder, writer io.Writer) *TrashCommand {
+	return &TrashCommand{
+		trashService: trashService,
+		tokenHolder:  tokenHolder,
+		writer:       writer,
+	}
+}
+
+func (c *TrashCommand) Name() string {
+	return "trash"
+}
+
+func (c *TrashCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	return c.list()
+}
+
+// Run управляет корзиной: `trash` выводит её, `trash restore 42` возвращает запись,
+// `trash purge 42` удаляет запись навсегда, `trash empty` удаляет навсегда все записи корзины.
+func (c *TrashCommand) Run(args []string) error {
+	positional, err := parseArgs(newFlagSet("trash [restore <id> | purge <id> | empty]"), args, c.writer)
+	if err != nil {
+		return err
+	}
+
+	var action func() error
+	switch {
+	case len(positional) == 0:
+		action = c.list
+	case len(positional) == 1 && positional[0] == "empty":
+		action = c.empty
+	case len(positional) == 2 && (positional[0] == "restore" || positional[0] == "purge"):
+		id, err := parseID(positional[1])
+		if err != nil {
+			return err
+		}
+		action = func() error { return c.change(positional[0], id) }
+	default:
+		return usagef("укажите restore <id>, purge <id> или empty")
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	return action()
+}
+
+func (c *TrashCommand) list() error {
+	items, err := c.trashService.ListTrash(context.Background(), c.tokenHolder.Token)
+	if err != nil {
+		return fmt.Errorf("ошибка получения корзины: %w", err)
+	}
+	if len(items) == 0 {
+		fmt.Fprintln(c.writer, "Корзина пуста.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(c.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tТИП\tМЕТА\tУДАЛЕНО")
+	for _, item := range items {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", item.Id, item.InfoType, item.Meta,
+			item.Deleted.AsTime().Format(historyTimeLayout))
+	}
+
+	return tw.Flush()
+}
+
+// change возвращает запись из корзины или удаляет её навсегда. При успехе ничего не выводит.
+func (c *TrashCommand) change(action string, id int32) error {
+	ctx := context.Background()
+	if action == "restore" {
+		if err := c.trashService.RestoreData(ctx, c.tokenHolder.Token, id); err != nil {
+			return fmt.Errorf("ошибка восстановления записи: %w", err)
+		}
+		return nil
+	}
+
+	if err := c.trashService.PurgeData(ctx, c.tokenHolder.Token, id); err != nil {
+		return fmt.Errorf("ошибка удаления записи: %w", err)
+	}
+
+	return nil
+}
+
+func (c *TrashCommand) empty() error {
+	purged, err := c.trashService.EmptyTrash(context.Background(), c.tokenHolder.Token)
+	if err != nil {
+		return fmt.Errorf("ошибка очистки корзины: %w", err)
+	}
+
+	fmt.Fprintf(c.writer, "Удалено записей: %d\n", purged)
+	return nil
+}
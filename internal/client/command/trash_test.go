@@ -0,0 +1,91 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeTrashService хранит корзину в памяти.
+type fakeTrashService struct {
+	restored []int32
+	items    []*datapb.DataItem
+}
+
+func (f *fakeTrashService) ListTrash(context.Context, string) ([]*datapb.DataItem, error) {
+	return f.items, nil
+}
+
+func (f *fakeTrashService) RestoreData(_ context.Context, _ string, id int32) error {
+	if err := f.remove(id); err != nil {
+		return err
+	}
+	f.restored = append(f.restored, id)
+	return nil
+}
+
+func (f *fakeTrashService) PurgeData(_ context.Context, _ string, id int32) error {
+	return f.remove(id)
+}
+
+func (f *fakeTrashService) EmptyTrash(context.Context, string) (int64, error) {
+	purged := int64(len(f.items))
+	f.items = nil
+	return purged, nil
+}
+
+func (f *fakeTrashService) remove(id int32) error {
+	for i, item := range f.items {
+		if item.Id == id {
+			f.items = append(f.items[:i], f.items[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("запись не найдена в корзине")
+}
+
+func TestTrashCommand_Run(t *testing.T) {
+	deleted := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	trash := &fakeTrashService{items: []*datapb.DataItem{
+		{Id: 3, InfoType: "text", Meta: "заметка", Deleted: timestamppb.New(deleted)},
+		{Id: 5, InfoType: "bank_card", Meta: "карта", Deleted: timestamppb.New(deleted.Add(-time.Hour))},
+		{Id: 7, InfoType: "text", Meta: "черновик", Deleted: timestamppb.New(deleted.Add(-2 * time.Hour))},
+	}}
+	writer := &bytes.Buffer{}
+	cmd := NewTrashCommand(trash, &entity.TokenHolder{Token: "token"}, writer)
+
+	assert.Equal(t, "trash", cmd.Name())
+	assert.NoError(t, cmd.Run(nil))
+	assert.Equal(t, `ID  ТИП        МЕТА      УДАЛЕНО
+3   text       заметка   2024-05-01 12:00:00
+5   bank_card  карта     2024-05-01 11:00:00
+7   text       черновик  2024-05-01 10:00:00
+`, writer.String())
+
+	assert.NoError(t, cmd.Run([]string{"restore", "3"}))
+	assert.Equal(t, []int32{3}, trash.restored)
+	assert.NoError(t, cmd.Run([]string{"purge", "5"}))
+	assert.EqualError(t, cmd.Run([]string{"purge", "5"}), "ошибка удаления записи: запись не найдена в корзине")
+
+	writer.Reset()
+	assert.NoError(t, cmd.Run([]string{"empty"}))
+	assert.Equal(t, "Удалено записей: 1\n", writer.String())
+
+	writer.Reset()
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Корзина пуста.\n", writer.String())
+
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"restore"})))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"restore", "abc"})))
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"wipe"})))
+
+	cmd = NewTrashCommand(trash, &entity.TokenHolder{}, writer)
+	assert.ErrorIs(t, cmd.Run(nil), ErrNotLoggedIn)
+}
@@ -0,0 +1,169 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+)
+
+// TreeCommand выводит папки деревом с записями и их тегами.
+type TreeCommand struct {
+	dataService      listDataService
+	organizerService listOrganizerService
+	tokenHolder      *entity.TokenHolder
+	writer           io.Writer
+}
+
+func NewTreeCommand(
+	dataService listDataService,
+	organizerService listOrganizerService,
+	tokenHolder *entity.TokenHolder,
+	writer io.Writer,
+) *TreeCommand {
+	return &TreeCommand{
+		dataService:      dataService,
+		organizerService: organizerService,
+		tokenHolder:      tokenHolder,
+		writer:           writer,
+	}
+}
+
+func (c *TreeCommand) Name() string {
+	return "tree"
+}
+
+func (c *TreeCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	return c.tree("")
+}
+
+// Run выводит дерево всех папок или только папки по пути: `tree`, `tree работа`.
+func (c *TreeCommand) Run(args []string) error {
+	positional, err := parseArgs(newFlagSet("tree [папка]"), args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return usagef("лишние аргументы: %s", strings.Join(positional[1:], " "))
+	}
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	var path string
+	if len(positional) == 1 {
+		path = positional[0]
+	}
+
+	return c.tree(path)
+}
+
+func (c *TreeCommand) tree(path string) error {
+	folders, err := c.organizerService.ListFolders(context.Background(), c.tokenHolder.Token)
+	if err != nil {
+		return fmt.Errorf("ошибка получения папок: %w", err)
+	}
+	rootID, err := folderIDByPath(folders, path)
+	if err != nil {
+		return err
+	}
+	tags, err := c.organizerService.ListTags(context.Background(), c.tokenHolder.Token)
+	if err != nil {
+		return fmt.Errorf("ошибка получения тегов: %w", err)
+	}
+	dataItems, err := listAll(c.dataService, c.tokenHolder.Token, &entity.DataFilter{PageSize: listFetchSize}, 0)
+	if err != nil {
+		return err
+	}
+
+	tree := newFolderTree(folders, tags, dataItems)
+	if rootID == 0 {
+		fmt.Fprintln(c.writer, "/")
+	} else {
+		fmt.Fprintf(c.writer, "%s/\n", strings.Join(splitFolderPath(path), "/"))
+	}
+	tree.print(c.writer, rootID, "")
+
+	return nil
+}
+
+// folderTree - папки и записи, сгруппированные по родительской папке. Записи из неизвестных папок
+// показываются в корне.
+type folderTree struct {
+	folders  map[int32][]*entity.Folder
+	items    map[int32][]*datapb.DataItem
+	tagNames map[int32]string
+}
+
+func newFolderTree(folders []*entity.Folder, tags []*entity.Tag, dataItems []*datapb.DataItem) *folderTree {
+	tree := &folderTree{
+		folders:  make(map[int32][]*entity.Folder),
+		items:    make(map[int32][]*datapb.DataItem),
+		tagNames: make(map[int32]string, len(tags)),
+	}
+
+	known := map[int32]bool{0: true}
+	for _, folder := range folders {
+		tree.folders[folder.ParentID] = append(tree.folders[folder.ParentID], folder)
+		known[folder.ID] = true
+	}
+	for _, children := range tree.folders {
+		sort.SliceStable(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+	}
+	for _, item := range dataItems {
+		folderID := item.FolderId
+		if !known[folderID] {
+			folderID = 0
+		}
+		tree.items[folderID] = append(tree.items[folderID], item)
+	}
+	for _, tag := range tags {
+		tree.tagNames[tag.ID] = tag.Name
+	}
+
+	return tree
+}
+
+// print выводит содержимое папки folderID: сначала вложенные папки, затем записи.
+func (t *folderTree) print(writer io.Writer, folderID int32, indent string) {
+	children := t.folders[folderID]
+	items := t.items[folderID]
+	total := len(children) + len(items)
+
+	for i, folder := range children {
+		branch, next := treeBranch(i == total-1)
+		fmt.Fprintf(writer, "%s%s%s/\n", indent, branch, folder.Name)
+		t.print(writer, folder.ID, indent+next)
+	}
+	for i, item := range items {
+		branch, _ := treeBranch(len(children)+i == total-1)
+		fmt.Fprintf(writer, "%s%s[%d] %s (%s)%s\n", indent, branch, item.Id, item.Meta, item.InfoType, t.tagsOf(item))
+	}
+}
+
+func (t *folderTree) tagsOf(item *datapb.DataItem) string {
+	var builder strings.Builder
+	for _, id := range item.TagIds {
+		// Удалённые теги сервер снимает с записей, но кеш может помнить их до синхронизации.
+		if name, ok := t.tagNames[id]; ok {
+			builder.WriteString(" #" + name)
+		}
+	}
+
+	return builder.String()
+}
+
+func treeBranch(last bool) (branch, next string) {
+	if last {
+		return "└── ", "    "
+	}
+	return "├── ", "│   "
+}
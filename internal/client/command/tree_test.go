@@ -0,0 +1,53 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeCommand_Run(t *testing.T) {
+	organizer := newFakeOrganizerService()
+	organizer.folders = []*entity.Folder{
+		{ID: 1, Name: "работа"},
+		{ID: 2, ParentID: 1, Name: "проекты"},
+		{ID: 3, Name: "архив"},
+	}
+	organizer.tags = []*entity.Tag{{ID: 1, Name: "важное"}}
+	dataService := &mockListDataService{
+		ListDataFunc: func(context.Context, string, *entity.DataFilter) (*entity.DataPage, error) {
+			return &entity.DataPage{Items: []*datapb.DataItem{
+				{Id: 3, InfoType: "login_password", Meta: "почта", FolderId: 1, TagIds: []int32{1, 9}},
+				{Id: 4, InfoType: "text", Meta: "план", FolderId: 2},
+				{Id: 5, InfoType: "bank_card", Meta: "карта"},
+			}}, nil
+		},
+	}
+	tokenHolder := &entity.TokenHolder{Token: "token"}
+
+	writer := &bytes.Buffer{}
+	cmd := NewTreeCommand(dataService, organizer, tokenHolder, writer)
+	assert.NoError(t, cmd.Run(nil))
+	assert.Equal(t, `/
+├── архив/
+├── работа/
+│   ├── проекты/
+│   │   └── [4] план (text)
+│   └── [3] почта (login_password) #важное
+└── [5] карта (bank_card)
+`, writer.String())
+
+	writer.Reset()
+	assert.NoError(t, cmd.Run([]string{"/работа/проекты"}))
+	assert.Equal(t, "работа/проекты/\n└── [4] план (text)\n", writer.String())
+
+	assert.EqualError(t, cmd.Run([]string{"личное"}), `папка "личное" не найдена`)
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Run([]string{"a", "b"})))
+
+	cmd = NewTreeCommand(dataService, organizer, &entity.TokenHolder{}, writer)
+	assert.ErrorIs(t, cmd.Execute(), ErrNotLoggedIn)
+}
@@ -0,0 +1,130 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+)
+
+type twoFactorService interface {
+	EnrollTOTP(ctx context.Context, token string) (*entity.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, token, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, token, code string) error
+}
+
+type TwoFactorCommand struct {
+	authService twoFactorService
+	tokenHolder *entity.TokenHolder
+	reader      io.Reader
+	writer      io.Writer
+}
+
+func NewTwoFactorCommand(
+	authService twoFactorService,
+	tokenHolder *entity.TokenHolder,
+	reader io.Reader,
+	writer io.Writer,
+) *TwoFactorCommand {
+	return &TwoFactorCommand{
+		authService: authService,
+		tokenHolder: tokenHolder,
+		reader:      reader,
+		writer:      writer,
+	}
+}
+
+func (c *TwoFactorCommand) Name() string {
+	return "2fa"
+}
+
+func (c *TwoFactorCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	scanner := bufio.NewScanner(c.reader)
+	action, err := c.prompt(scanner, "Включить (1) или отключить (2) второй фактор: ")
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "1":
+		return c.enable(scanner)
+	case "2":
+		return c.disable(scanner)
+	default:
+		return fmt.Errorf("неизвестное действие: %s", action)
+	}
+}
+
+func (c *TwoFactorCommand) enable(scanner *bufio.Scanner) error {
+	enrollment, err := c.authService.EnrollTOTP(context.Background(), c.tokenHolder.Token)
+	if err != nil {
+		return fmt.Errorf("ошибка включения второго фактора: %w", err)
+	}
+
+	_, err = fmt.Fprintf(
+		c.writer,
+		"Добавьте секрет в приложение-аутентификатор.\nСекрет: %s\nURI: %s\n",
+		enrollment.Secret,
+		enrollment.URI,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка вывода секрета: %w", err)
+	}
+
+	code, err := c.prompt(scanner, "Введите код из приложения-аутентификатора: ")
+	if err != nil {
+		return err
+	}
+
+	recoveryCodes, err := c.authService.ConfirmTOTP(context.Background(), c.tokenHolder.Token, code)
+	if err != nil {
+		return fmt.Errorf("ошибка включения второго фактора: %w", err)
+	}
+
+	_, err = fmt.Fprintf(
+		c.writer,
+		"Второй фактор включён. Сохраните коды восстановления, каждый действует один раз:\n%s\n",
+		strings.Join(recoveryCodes, "\n"),
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка вывода кодов восстановления: %w", err)
+	}
+
+	return nil
+}
+
+func (c *TwoFactorCommand) disable(scanner *bufio.Scanner) error {
+	code, err := c.prompt(scanner, "Введите код из приложения-аутентификатора или код восстановления: ")
+	if err != nil {
+		return err
+	}
+
+	if err = c.authService.DisableTOTP(context.Background(), c.tokenHolder.Token, code); err != nil {
+		return fmt.Errorf("ошибка отключения второго фактора: %w", err)
+	}
+
+	_, err = fmt.Fprintln(c.writer, "Второй фактор отключён.")
+	if err != nil {
+		return fmt.Errorf("ошибка вывода результата: %w", err)
+	}
+
+	return nil
+}
+
+func (c *TwoFactorCommand) prompt(scanner *bufio.Scanner, message string) (string, error) {
+	if _, err := fmt.Fprint(c.writer, message); err != nil {
+		return "", fmt.Errorf("ошибка вывода запроса: %w", err)
+	}
+	if !scanner.Scan() {
+		return "", fmt.Errorf("ошибка ввода: %w", scanner.Err())
+	}
+
+	return strings.TrimSpace(scanner.Text()), nil
+}
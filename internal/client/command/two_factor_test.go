@@ -0,0 +1,119 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTwoFactorService struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorService) EnrollTOTP(ctx context.Context, token string) (*entity.TOTPEnrollment, error) {
+	args := m.Called(ctx, token)
+	enrollment, _ := args.Get(0).(*entity.TOTPEnrollment)
+	return enrollment, args.Error(1)
+}
+
+func (m *MockTwoFactorService) ConfirmTOTP(ctx context.Context, token, code string) ([]string, error) {
+	args := m.Called(ctx, token, code)
+	recoveryCodes, _ := args.Get(0).([]string)
+	return recoveryCodes, args.Error(1)
+}
+
+func (m *MockTwoFactorService) DisableTOTP(ctx context.Context, token, code string) error {
+	args := m.Called(ctx, token, code)
+	return args.Error(0)
+}
+
+func TestTwoFactorCommand_Execute(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		input         string
+		mockSetup     func(m *MockTwoFactorService)
+		expectedError string
+		contains      []string
+	}{
+		{
+			name:  "Включение второго фактора",
+			token: "token",
+			input: "1\n123456\n",
+			mockSetup: func(m *MockTwoFactorService) {
+				m.On("EnrollTOTP", mock.Anything, "token").
+					Return(&entity.TOTPEnrollment{Secret: "SECRET", URI: "otpauth://totp/GophKeeper:alice"}, nil)
+				m.On("ConfirmTOTP", mock.Anything, "token", "123456").Return([]string{"AAAA-BBBB", "CCCC-DDDD"}, nil)
+			},
+			contains: []string{"Секрет: SECRET", "URI: otpauth://totp/GophKeeper:alice", "AAAA-BBBB\nCCCC-DDDD"},
+		},
+		{
+			name:  "Неверный код при включении",
+			token: "token",
+			input: "1\n000000\n",
+			mockSetup: func(m *MockTwoFactorService) {
+				m.On("EnrollTOTP", mock.Anything, "token").Return(&entity.TOTPEnrollment{Secret: "SECRET"}, nil)
+				m.On("ConfirmTOTP", mock.Anything, "token", "000000").Return(nil, errors.New("неверный код"))
+			},
+			expectedError: "ошибка включения второго фактора",
+		},
+		{
+			name:  "Отключение второго фактора",
+			token: "token",
+			input: "2\nAAAA-BBBB\n",
+			mockSetup: func(m *MockTwoFactorService) {
+				m.On("DisableTOTP", mock.Anything, "token", "AAAA-BBBB").Return(nil)
+			},
+			contains: []string{"Второй фактор отключён."},
+		},
+		{
+			name:          "Неизвестное действие",
+			token:         "token",
+			input:         "3\n",
+			mockSetup:     func(_ *MockTwoFactorService) {},
+			expectedError: "неизвестное действие",
+		},
+		{
+			name:          "Пользователь не вошёл",
+			mockSetup:     func(_ *MockTwoFactorService) {},
+			expectedError: "вы должны войти в систему",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTwoFactorService)
+			tt.mockSetup(mockService)
+
+			writer := &bytes.Buffer{}
+			cmd := NewTwoFactorCommand(
+				mockService,
+				&entity.TokenHolder{Token: tt.token},
+				bytes.NewBufferString(tt.input),
+				writer,
+			)
+
+			err := cmd.Execute()
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, s := range tt.contains {
+				assert.Contains(t, writer.String(), s)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTwoFactorCommand_Name(t *testing.T) {
+	cmd := NewTwoFactorCommand(nil, &entity.TokenHolder{}, nil, nil)
+	assert.Equal(t, "2fa", cmd.Name())
+}
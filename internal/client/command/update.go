@@ -4,13 +4,21 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"reflect"
+	"sort"
 	"strconv"
+	"time"
 
 	"github.com/NikolosHGW/goph-keeper/api/datapb"
 	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/client/output"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 type updateDataService interface {
@@ -20,6 +28,8 @@ type updateDataService interface {
 
 type UpdateCommand struct {
 	dataService updateDataService
+	uploader    binaryUploader
+	recordTypes recordTypeLookup
 	tokenHolder *entity.TokenHolder
 	reader      io.Reader
 	writer      io.Writer
@@ -27,12 +37,16 @@ type UpdateCommand struct {
 
 func NewUpdateCommand(
 	dataService updateDataService,
+	uploader binaryUploader,
+	recordTypes recordTypeLookup,
 	tokenHolder *entity.TokenHolder,
 	reader io.Reader,
 	writer io.Writer,
 ) *UpdateCommand {
 	return &UpdateCommand{
 		dataService: dataService,
+		uploader:    uploader,
+		recordTypes: recordTypes,
 		tokenHolder: tokenHolder,
 		reader:      reader,
 		writer:      writer,
@@ -45,7 +59,7 @@ func (c *UpdateCommand) Name() string {
 
 func (c *UpdateCommand) Execute() error {
 	if c.tokenHolder.Token == "" {
-		return fmt.Errorf("вы должны войти в систему")
+		return ErrNotLoggedIn
 	}
 
 	_, err := fmt.Fprint(c.writer, "Введите ID данных: ")
@@ -69,6 +83,9 @@ func (c *UpdateCommand) Execute() error {
 	if err != nil {
 		return fmt.Errorf("ошибка получения данных: %w", err)
 	}
+	if err = payload.Unpack(dataItem); err != nil {
+		return fmt.Errorf("ошибка десериализации текущих данных: %w", err)
+	}
 
 	var updatedDataItem *datapb.DataItem
 
@@ -81,16 +98,42 @@ func (c *UpdateCommand) Execute() error {
 		updatedDataItem, err = c.updateBinaryData(scanner, dataItem)
 	case "bank_card":
 		updatedDataItem, err = c.updateBankCardData(scanner, dataItem)
+	case "totp":
+		updatedDataItem, err = c.updateTOTPData(scanner, dataItem)
 	default:
-		fmt.Fprintln(c.writer, "Неизвестный тип данных")
-		return nil
+		if c.recordTypes == nil || payload.IsBuiltin(dataItem.InfoType) {
+			fmt.Fprintln(c.writer, "Неизвестный тип данных")
+			return nil
+		}
+		updatedDataItem, err = c.updateCustomData(scanner, dataItem)
 	}
 
 	if err != nil {
 		return err
 	}
+	if updatedDataItem == nil {
+		// Новый файл загружен по частям вместе с метаинформацией.
+		fmt.Fprintln(c.writer, "Данные успешно обновлены.")
+		return nil
+	}
+
+	for {
+		err = c.dataService.UpdateData(context.Background(), c.tokenHolder.Token, updatedDataItem)
 
-	err = c.dataService.UpdateData(context.Background(), c.tokenHolder.Token, updatedDataItem)
+		var conflict *entity.ConflictError
+		if !errors.As(err, &conflict) || conflict.Current == nil {
+			break
+		}
+
+		updatedDataItem, err = c.resolveConflict(scanner, updatedDataItem, conflict.Current)
+		if err != nil {
+			return err
+		}
+		if updatedDataItem == nil {
+			fmt.Fprintln(c.writer, "Оставлена версия с сервера.")
+			return nil
+		}
+	}
 	if err != nil {
 		return fmt.Errorf("ошибка обновления данных: %w", err)
 	}
@@ -99,13 +142,201 @@ func (c *UpdateCommand) Execute() error {
 	return nil
 }
 
-func (c *UpdateCommand) updateLoginPasswordData(scanner *bufio.Scanner, dataItem *datapb.DataItem) (*datapb.DataItem, error) {
-	var currentData entity.LoginPasswordData
-	if err := json.Unmarshal(dataItem.Info, &currentData); err != nil {
-		return nil, fmt.Errorf("ошибка десериализации текущих данных: %w", err)
+// resolveConflict показывает обе версии записи и спрашивает, какую сохранить.
+// Возвращает версию для повторной отправки или nil, если пользователь оставил версию с сервера.
+func (c *UpdateCommand) resolveConflict(
+	scanner *bufio.Scanner,
+	mine, theirs *datapb.DataItem,
+) (*datapb.DataItem, error) {
+	fmt.Fprintln(c.writer, "Запись изменена на другом устройстве, пока вы её редактировали.")
+	fmt.Fprintln(c.writer, "Ваша версия:")
+	if err := c.printVersion(mine); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(c.writer, "Версия на сервере (изменена %s):\n", theirs.Updated.AsTime().Local().Format(time.DateTime))
+	if err := c.printVersion(theirs); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprint(c.writer, "Выберите: 1 - сохранить вашу версию, 2 - оставить версию с сервера, 3 - объединить по полям: ")
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("ошибка ввода выбора: %w", scanner.Err())
+	}
+
+	var resolved *datapb.DataItem
+	switch scanner.Text() {
+	case "1":
+		resolved = &datapb.DataItem{
+			Id:       mine.Id,
+			InfoType: mine.InfoType,
+			Info:     mine.Info,
+			Payload:  mine.Payload,
+			Meta:     mine.Meta,
+		}
+	case "2":
+		return nil, nil
+	case "3":
+		merged, err := c.mergeVersions(scanner, mine, theirs)
+		if err != nil {
+			return nil, err
+		}
+		resolved = merged
+	default:
+		return nil, fmt.Errorf("неизвестный вариант: %s", scanner.Text())
+	}
+
+	// Следующая попытка сверяется с версией, которую пользователь только что видел.
+	resolved.Revision = theirs.Revision
+	return resolved, nil
+}
+
+func (c *UpdateCommand) printVersion(item *datapb.DataItem) error {
+	fields, err := infoFields(item)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range sortedKeys(fields) {
+		fmt.Fprintf(c.writer, "  %s: %s\n", name, shortValue(fields[name]))
+	}
+	fmt.Fprintf(c.writer, "  Метаинформация: %s\n", item.Meta)
+	return nil
+}
+
+// mergeVersions собирает запись по полям: для каждого различающегося поля пользователь выбирает,
+// чьё значение оставить.
+func (c *UpdateCommand) mergeVersions(
+	scanner *bufio.Scanner,
+	mine, theirs *datapb.DataItem,
+) (*datapb.DataItem, error) {
+	if mine.InfoType != theirs.InfoType {
+		return nil, fmt.Errorf("тип записи на сервере изменился, объединение невозможно")
+	}
+
+	mineFields, err := infoFields(mine)
+	if err != nil {
+		return nil, err
+	}
+	theirFields, err := infoFields(theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]any, len(mineFields))
+	for name, value := range theirFields {
+		merged[name] = value
+	}
+	for _, name := range sortedKeys(mineFields) {
+		theirValue, ok := theirFields[name]
+		if ok && reflect.DeepEqual(mineFields[name], theirValue) {
+			continue
+		}
+
+		useMine, err := c.chooseValue(scanner, name, shortValue(mineFields[name]), shortValue(theirValue))
+		if err != nil {
+			return nil, err
+		}
+		if useMine {
+			merged[name] = mineFields[name]
+		}
+	}
+
+	info, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации объединённых данных: %w", err)
+	}
+	resolved := &datapb.DataItem{Id: mine.Id, InfoType: mine.InfoType, Info: info}
+	if err = payload.Unpack(resolved); err != nil {
+		return nil, fmt.Errorf("ошибка сериализации объединённых данных: %w", err)
+	}
+
+	meta := theirs.Meta
+	if mine.Meta != theirs.Meta {
+		useMine, err := c.chooseValue(scanner, "Метаинформация", mine.Meta, theirs.Meta)
+		if err != nil {
+			return nil, err
+		}
+		if useMine {
+			meta = mine.Meta
+		}
+	}
+	resolved.Meta = meta
+
+	return resolved, nil
+}
+
+func (c *UpdateCommand) chooseValue(scanner *bufio.Scanner, name, mine, theirs string) (bool, error) {
+	fmt.Fprintf(c.writer, "%s: 1 - ваше значение (%s), 2 - с сервера (%s): ", name, mine, theirs)
+	if !scanner.Scan() {
+		return false, fmt.Errorf("ошибка ввода выбора: %w", scanner.Err())
 	}
 
-	fmt.Fprintf(c.writer, "Текущий логин: %s\n", currentData.Login)
+	switch scanner.Text() {
+	case "1":
+		return true, nil
+	case "2":
+		return false, nil
+	default:
+		return false, fmt.Errorf("неизвестный вариант: %s", scanner.Text())
+	}
+}
+
+// infoFields возвращает поля содержимого записи по их именам в JSON, включая пустые.
+func infoFields(item *datapb.DataItem) (map[string]any, error) {
+	message, err := payload.Decode(item)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка десериализации данных: %w", err)
+	}
+	info := item.Info
+	switch message.(type) {
+	case nil:
+	case *datapb.Custom:
+		// Поля пользовательского типа сравниваются по отдельности, а не одним объектом fields.
+		info, err = payload.Marshal(message)
+	default:
+		info, err = (protojson.MarshalOptions{EmitUnpopulated: true}).Marshal(message)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации данных: %w", err)
+	}
+
+	fields := make(map[string]any)
+	if err = json.Unmarshal(info, &fields); err != nil {
+		return nil, fmt.Errorf("ошибка десериализации данных: %w", err)
+	}
+
+	return fields, nil
+}
+
+func sortedKeys(fields map[string]any) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// shortValue обрезает длинные значения, например содержимое файлов, чтобы версии помещались на экране.
+func shortValue(value any) string {
+	const maxLen = 60
+
+	if value == nil {
+		return ""
+	}
+	text := []rune(fmt.Sprint(value))
+	if len(text) > maxLen {
+		return string(text[:maxLen]) + "..."
+	}
+
+	return string(text)
+}
+
+func (c *UpdateCommand) updateLoginPasswordData(scanner *bufio.Scanner, dataItem *datapb.DataItem) (*datapb.DataItem, error) {
+	currentData := dataItem.GetLoginPassword()
+
+	fmt.Fprintf(c.writer, "Текущий логин: %s\n", currentData.GetLogin())
 	fmt.Fprint(c.writer, "Введите новый логин (оставьте пустым, чтобы оставить без изменений): ")
 	var login string
 	if scanner.Scan() {
@@ -114,10 +345,10 @@ func (c *UpdateCommand) updateLoginPasswordData(scanner *bufio.Scanner, dataItem
 		return nil, fmt.Errorf("ошибка ввода логина: %w", scanner.Err())
 	}
 	if login == "" {
-		login = currentData.Login
+		login = currentData.GetLogin()
 	}
 
-	fmt.Fprintf(c.writer, "Текущий пароль: %s\n", currentData.Password)
+	fmt.Fprintf(c.writer, "Текущий пароль: %s\n", currentData.GetPassword())
 	fmt.Fprint(c.writer, "Введите новый пароль (оставьте пустым, чтобы оставить без изменений): ")
 	var password string
 	if scanner.Scan() {
@@ -126,10 +357,10 @@ func (c *UpdateCommand) updateLoginPasswordData(scanner *bufio.Scanner, dataItem
 		return nil, fmt.Errorf("ошибка ввода пароля: %w", scanner.Err())
 	}
 	if password == "" {
-		password = currentData.Password
+		password = currentData.GetPassword()
 	}
 
-	fmt.Fprintf(c.writer, "Текущий URL: %s\n", currentData.URL)
+	fmt.Fprintf(c.writer, "Текущий URL: %s\n", currentData.GetUrl())
 	fmt.Fprint(c.writer, "Введите новый URL (оставьте пустым, чтобы оставить без изменений): ")
 	var url string
 	if scanner.Scan() {
@@ -138,7 +369,7 @@ func (c *UpdateCommand) updateLoginPasswordData(scanner *bufio.Scanner, dataItem
 		return nil, fmt.Errorf("ошибка ввода URL: %w", scanner.Err())
 	}
 	if url == "" {
-		url = currentData.URL
+		url = currentData.GetUrl()
 	}
 
 	fmt.Fprintf(c.writer, "Текущая метаинформация: %s\n", dataItem.Meta)
@@ -153,34 +384,20 @@ func (c *UpdateCommand) updateLoginPasswordData(scanner *bufio.Scanner, dataItem
 		meta = dataItem.Meta
 	}
 
-	updatedData := &entity.LoginPasswordData{
+	updatedData := &datapb.LoginPassword{
 		Login:    login,
 		Password: password,
-		URL:      url,
+		Url:      url,
+		TotpId:   currentData.GetTotpId(),
 	}
 
-	infoBytes, err := json.Marshal(updatedData)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка сериализации обновленных данных: %w", err)
-	}
-
-	updatedDataItem := &datapb.DataItem{
-		Id:       dataItem.Id,
-		InfoType: dataItem.InfoType,
-		Info:     infoBytes,
-		Meta:     meta,
-	}
-
-	return updatedDataItem, nil
+	return updatedItem(dataItem, updatedData, meta), nil
 }
 
 func (c *UpdateCommand) updateTextData(scanner *bufio.Scanner, dataItem *datapb.DataItem) (*datapb.DataItem, error) {
-	var currentData entity.TextData
-	if err := json.Unmarshal(dataItem.Info, &currentData); err != nil {
-		return nil, fmt.Errorf("ошибка десериализации текущих данных: %w", err)
-	}
+	currentData := dataItem.GetText()
 
-	fmt.Fprintf(c.writer, "Текущий текст: %s\n", currentData.Text)
+	fmt.Fprintf(c.writer, "Текущий текст: %s\n", currentData.GetText())
 	fmt.Fprint(c.writer, "Введите новый текст (оставьте пустым, чтобы оставить без изменений): ")
 	var text string
 	if scanner.Scan() {
@@ -189,7 +406,7 @@ func (c *UpdateCommand) updateTextData(scanner *bufio.Scanner, dataItem *datapb.
 		return nil, fmt.Errorf("ошибка ввода текста: %w", scanner.Err())
 	}
 	if text == "" {
-		text = currentData.Text
+		text = currentData.GetText()
 	}
 
 	fmt.Fprintf(c.writer, "Текущая метаинформация: %s\n", dataItem.Meta)
@@ -204,32 +421,19 @@ func (c *UpdateCommand) updateTextData(scanner *bufio.Scanner, dataItem *datapb.
 		meta = dataItem.Meta
 	}
 
-	updatedData := &entity.TextData{
+	updatedData := &datapb.Text{
 		Text: text,
 	}
 
-	infoBytes, err := json.Marshal(updatedData)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка сериализации обновленных данных: %w", err)
-	}
-
-	updatedDataItem := &datapb.DataItem{
-		Id:       dataItem.Id,
-		InfoType: dataItem.InfoType,
-		Info:     infoBytes,
-		Meta:     meta,
-	}
-
-	return updatedDataItem, nil
+	return updatedItem(dataItem, updatedData, meta), nil
 }
 
+// updateBinaryData загружает новый файл по частям с заменой содержимого записи и возвращает nil.
+// Если файл не меняется, возвращает запись с новой метаинформацией и прежним описанием файла.
 func (c *UpdateCommand) updateBinaryData(scanner *bufio.Scanner, dataItem *datapb.DataItem) (*datapb.DataItem, error) {
-	var currentData entity.BinaryData
-	if err := json.Unmarshal(dataItem.Info, &currentData); err != nil {
-		return nil, fmt.Errorf("ошибка десериализации текущих данных: %w", err)
-	}
+	currentData := dataItem.GetBinary()
 
-	fmt.Fprintf(c.writer, "Текущее имя файла: %s\n", currentData.FileName)
+	fmt.Fprintf(c.writer, "Текущее имя файла: %s\n", currentData.GetFileName())
 	fmt.Fprint(c.writer, "Введите новый путь к файлу для обновления (оставьте пустым, чтобы оставить без изменений): ")
 	var filePath string
 	if scanner.Scan() {
@@ -237,19 +441,10 @@ func (c *UpdateCommand) updateBinaryData(scanner *bufio.Scanner, dataItem *datap
 	} else {
 		return nil, fmt.Errorf("ошибка ввода пути к файлу: %w", scanner.Err())
 	}
-
-	var fileName string
-	var fileContent []byte
 	if filePath != "" {
-		fileContent_, err := os.ReadFile(filePath)
-		if err != nil {
+		if _, err := os.Stat(filePath); err != nil {
 			return nil, fmt.Errorf("не удалось прочитать файл: %w", err)
 		}
-		fileName = getFileName(filePath)
-		fileContent = fileContent_
-	} else {
-		fileName = currentData.FileName
-		fileContent = currentData.FileContent
 	}
 
 	fmt.Fprintf(c.writer, "Текущая метаинформация: %s\n", dataItem.Meta)
@@ -264,33 +459,30 @@ func (c *UpdateCommand) updateBinaryData(scanner *bufio.Scanner, dataItem *datap
 		meta = dataItem.Meta
 	}
 
-	updatedData := &entity.BinaryData{
-		FileName:    fileName,
-		FileContent: fileContent,
-	}
-
-	infoBytes, err := json.Marshal(updatedData)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка сериализации обновленных данных: %w", err)
+	if filePath != "" {
+		_, err := c.uploader.Upload(context.Background(), c.tokenHolder.Token, filePath, meta, dataItem.Id)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка загрузки файла: %w", err)
+		}
+		return nil, nil
 	}
 
 	updatedDataItem := &datapb.DataItem{
 		Id:       dataItem.Id,
 		InfoType: dataItem.InfoType,
-		Info:     infoBytes,
+		Info:     dataItem.Info,
+		Payload:  dataItem.Payload,
 		Meta:     meta,
+		Revision: dataItem.Revision,
 	}
 
 	return updatedDataItem, nil
 }
 
 func (c *UpdateCommand) updateBankCardData(scanner *bufio.Scanner, dataItem *datapb.DataItem) (*datapb.DataItem, error) {
-	var currentData entity.BankCardData
-	if err := json.Unmarshal(dataItem.Info, &currentData); err != nil {
-		return nil, fmt.Errorf("ошибка десериализации текущих данных: %w", err)
-	}
+	currentData := dataItem.GetBankCard()
 
-	fmt.Fprintf(c.writer, "Текущий номер карты: %s\n", currentData.CardNumber)
+	fmt.Fprintf(c.writer, "Текущий номер карты: %s\n", currentData.GetCardNumber())
 	fmt.Fprint(c.writer, "Введите новый номер карты (оставьте пустым, чтобы оставить без изменений): ")
 	var cardNumber string
 	if scanner.Scan() {
@@ -299,10 +491,10 @@ func (c *UpdateCommand) updateBankCardData(scanner *bufio.Scanner, dataItem *dat
 		return nil, fmt.Errorf("ошибка ввода номера карты: %w", scanner.Err())
 	}
 	if cardNumber == "" {
-		cardNumber = currentData.CardNumber
+		cardNumber = currentData.GetCardNumber()
 	}
 
-	fmt.Fprintf(c.writer, "Текущий срок действия (MM/YY): %s\n", currentData.ExpiryDate)
+	fmt.Fprintf(c.writer, "Текущий срок действия (MM/YY): %s\n", currentData.GetExpiryDate())
 	fmt.Fprint(c.writer, "Введите новый срок действия (оставьте пустым, чтобы оставить без изменений): ")
 	var expiryDate string
 	if scanner.Scan() {
@@ -311,10 +503,10 @@ func (c *UpdateCommand) updateBankCardData(scanner *bufio.Scanner, dataItem *dat
 		return nil, fmt.Errorf("ошибка ввода срока действия: %w", scanner.Err())
 	}
 	if expiryDate == "" {
-		expiryDate = currentData.ExpiryDate
+		expiryDate = currentData.GetExpiryDate()
 	}
 
-	fmt.Fprintf(c.writer, "Текущий CVV: %s\n", currentData.CVV)
+	fmt.Fprintf(c.writer, "Текущий CVV: %s\n", currentData.GetCvv())
 	fmt.Fprint(c.writer, "Введите новый CVV (оставьте пустым, чтобы оставить без изменений): ")
 	var cvv string
 	if scanner.Scan() {
@@ -323,10 +515,10 @@ func (c *UpdateCommand) updateBankCardData(scanner *bufio.Scanner, dataItem *dat
 		return nil, fmt.Errorf("ошибка ввода CVV: %w", scanner.Err())
 	}
 	if cvv == "" {
-		cvv = currentData.CVV
+		cvv = currentData.GetCvv()
 	}
 
-	fmt.Fprintf(c.writer, "Текущее имя держателя карты: %s\n", currentData.HolderName)
+	fmt.Fprintf(c.writer, "Текущее имя держателя карты: %s\n", currentData.GetHolderName())
 	fmt.Fprint(c.writer, "Введите новое имя держателя карты (оставьте пустым, чтобы оставить без изменений): ")
 	var holderName string
 	if scanner.Scan() {
@@ -335,7 +527,7 @@ func (c *UpdateCommand) updateBankCardData(scanner *bufio.Scanner, dataItem *dat
 		return nil, fmt.Errorf("ошибка ввода имени держателя карты: %w", scanner.Err())
 	}
 	if holderName == "" {
-		holderName = currentData.HolderName
+		holderName = currentData.GetHolderName()
 	}
 
 	fmt.Fprintf(c.writer, "Текущая метаинформация: %s\n", dataItem.Meta)
@@ -350,24 +542,125 @@ func (c *UpdateCommand) updateBankCardData(scanner *bufio.Scanner, dataItem *dat
 		meta = dataItem.Meta
 	}
 
-	updatedData := &entity.BankCardData{
+	updatedData := &datapb.BankCard{
 		CardNumber: cardNumber,
 		ExpiryDate: expiryDate,
-		CVV:        cvv,
+		Cvv:        cvv,
 		HolderName: holderName,
 	}
 
-	infoBytes, err := json.Marshal(updatedData)
+	return updatedItem(dataItem, updatedData, meta), nil
+}
+
+// updateTOTPData заменяет секрет аутентификатора. Новый otpauth:// URI заменяет и остальные параметры,
+// а новый секрет в base32 - только секрет.
+func (c *UpdateCommand) updateTOTPData(scanner *bufio.Scanner, dataItem *datapb.DataItem) (*datapb.DataItem, error) {
+	currentData := dataItem.GetTotp()
+
+	fmt.Fprintf(c.writer, "Текущий секрет: %s\n", currentData.GetSecret())
+	fmt.Fprint(c.writer, "Введите новый секрет или otpauth:// URI (оставьте пустым, чтобы оставить без изменений): ")
+	var secret string
+	if scanner.Scan() {
+		secret = scanner.Text()
+	} else {
+		return nil, fmt.Errorf("ошибка ввода секрета: %w", scanner.Err())
+	}
+
+	updatedData := &datapb.Totp{
+		Secret:    currentData.GetSecret(),
+		Algorithm: currentData.GetAlgorithm(),
+		Digits:    currentData.GetDigits(),
+		Period:    currentData.GetPeriod(),
+		Issuer:    currentData.GetIssuer(),
+		Account:   currentData.GetAccount(),
+	}
+	if secret != "" {
+		parsed, err := parseTOTP(secret)
+		if err != nil {
+			return nil, err
+		}
+		if isOTPAuthURI(secret) {
+			updatedData = parsed
+		} else {
+			updatedData.Secret = parsed.Secret
+		}
+	}
+
+	fmt.Fprintf(c.writer, "Текущая метаинформация: %s\n", dataItem.Meta)
+	fmt.Fprint(c.writer, "Введите новую метаинформацию (оставьте пустым, чтобы оставить без изменений): ")
+	var meta string
+	if scanner.Scan() {
+		meta = scanner.Text()
+	} else {
+		return nil, fmt.Errorf("ошибка ввода метаинформации: %w", scanner.Err())
+	}
+	if meta == "" {
+		meta = dataItem.Meta
+	}
+
+	return updatedItem(dataItem, updatedData, meta), nil
+}
+
+// updateCustomData запрашивает новые значения полей пользовательского типа по его схеме.
+// Текущие значения скрытых полей не выводятся.
+func (c *UpdateCommand) updateCustomData(
+	scanner *bufio.Scanner,
+	dataItem *datapb.DataItem,
+) (*datapb.DataItem, error) {
+	recordType, err := c.recordTypes.RecordType(context.Background(), c.tokenHolder.Token, dataItem.InfoType)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка сериализации обновленных данных: %w", err)
+		return nil, fmt.Errorf("ошибка получения типа записей: %w", err)
 	}
 
-	updatedDataItem := &datapb.DataItem{
-		Id:       dataItem.Id,
-		InfoType: dataItem.InfoType,
-		Info:     infoBytes,
-		Meta:     meta,
+	currentFields := dataItem.GetCustom().GetFields()
+	fields := make(map[string]string, len(currentFields))
+	for name, value := range currentFields {
+		fields[name] = value
 	}
 
+	for _, field := range recordType.Fields {
+		current := fields[field.Name]
+		if field.Sensitive && current != "" {
+			current = output.HiddenValue
+		}
+		fmt.Fprintf(c.writer, "Текущее значение %s: %s\n", fieldLabel(field), current)
+		fmt.Fprintf(c.writer, "Введите новое значение%s (оставьте пустым, чтобы оставить без изменений): ",
+			fieldHint(field))
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("ошибка ввода поля %s: %w", field.Name, scanner.Err())
+		}
+		if value := scanner.Text(); value != "" {
+			fields[field.Name] = value
+		}
+	}
+
+	fmt.Fprintf(c.writer, "Текущая метаинформация: %s\n", dataItem.Meta)
+	fmt.Fprint(c.writer, "Введите новую метаинформацию (оставьте пустым, чтобы оставить без изменений): ")
+	var meta string
+	if scanner.Scan() {
+		meta = scanner.Text()
+	} else {
+		return nil, fmt.Errorf("ошибка ввода метаинформации: %w", scanner.Err())
+	}
+	if meta == "" {
+		meta = dataItem.Meta
+	}
+
+	updatedDataItem, err := customItem(recordType, fields, meta)
+	if err != nil {
+		return nil, err
+	}
+	updatedDataItem.Id = dataItem.Id
+	updatedDataItem.Revision = dataItem.Revision
+
 	return updatedDataItem, nil
 }
+
+// updatedItem возвращает новую версию записи dataItem с содержимым info.
+func updatedItem(dataItem *datapb.DataItem, info proto.Message, meta string) *datapb.DataItem {
+	updatedDataItem := newDataItem(dataItem.InfoType, info, meta)
+	updatedDataItem.Id = dataItem.Id
+	updatedDataItem.Revision = dataItem.Revision
+
+	return updatedDataItem
+}
@@ -3,7 +3,6 @@ package command
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"os"
 	"strings"
@@ -12,12 +11,17 @@ import (
 	"github.com/NikolosHGW/goph-keeper/api/datapb"
 	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
 )
 
 type mockUpdateDataService struct {
 	dataItem  *datapb.DataItem
 	getErr    error
 	updateErr error
+	// conflicts - версии с сервера, которыми по очереди отвечают на UpdateData.
+	conflicts []*datapb.DataItem
+	sent      []*datapb.DataItem
 }
 
 func (m *mockUpdateDataService) GetData(ctx context.Context, token string, id int32) (*datapb.DataItem, error) {
@@ -28,6 +32,12 @@ func (m *mockUpdateDataService) GetData(ctx context.Context, token string, id in
 }
 
 func (m *mockUpdateDataService) UpdateData(ctx context.Context, token string, data *datapb.DataItem) error {
+	m.sent = append(m.sent, data)
+	if len(m.conflicts) > 0 {
+		current := m.conflicts[0]
+		m.conflicts = m.conflicts[1:]
+		return &entity.ConflictError{Current: current}
+	}
 	if m.updateErr != nil {
 		return m.updateErr
 	}
@@ -41,7 +51,7 @@ func TestUpdateCommand_Execute_TokenMissing(t *testing.T) {
 	reader := strings.NewReader("")
 	writer := &bytes.Buffer{}
 
-	updateCmd := NewUpdateCommand(dataService, tokenHolder, reader, writer)
+	updateCmd := NewUpdateCommand(dataService, nil, nil, tokenHolder, reader, writer)
 
 	err := updateCmd.Execute()
 	if err == nil || err.Error() != "вы должны войти в систему" {
@@ -55,7 +65,7 @@ func TestUpdateCommand_Execute_InvalidIDInput(t *testing.T) {
 	reader := strings.NewReader("abc\n")
 	writer := &bytes.Buffer{}
 
-	updateCmd := NewUpdateCommand(dataService, tokenHolder, reader, writer)
+	updateCmd := NewUpdateCommand(dataService, nil, nil, tokenHolder, reader, writer)
 
 	err := updateCmd.Execute()
 	if err == nil || !strings.Contains(err.Error(), "некорректный ID") {
@@ -71,7 +81,7 @@ func TestUpdateCommand_Execute_GetDataError(t *testing.T) {
 	reader := strings.NewReader("1\n")
 	writer := &bytes.Buffer{}
 
-	updateCmd := NewUpdateCommand(dataService, tokenHolder, reader, writer)
+	updateCmd := NewUpdateCommand(dataService, nil, nil, tokenHolder, reader, writer)
 
 	err := updateCmd.Execute()
 	if err == nil || !strings.Contains(err.Error(), "ошибка получения данных") {
@@ -94,7 +104,7 @@ func TestUpdateCommand_Execute_UnknownInfoType(t *testing.T) {
 	reader := strings.NewReader("1\n")
 	writer := &bytes.Buffer{}
 
-	updateCmd := NewUpdateCommand(dataService, tokenHolder, reader, writer)
+	updateCmd := NewUpdateCommand(dataService, nil, nil, tokenHolder, reader, writer)
 
 	err := updateCmd.Execute()
 	if err != nil {
@@ -108,16 +118,15 @@ func TestUpdateCommand_Execute_UnknownInfoType(t *testing.T) {
 }
 
 func TestUpdateCommand_Execute_UpdateLoginPasswordData(t *testing.T) {
-	currentData := entity.LoginPasswordData{
+	currentData := &datapb.LoginPassword{
 		Login:    "current_user",
 		Password: "current_pass",
-		URL:      "http://current.com",
+		Url:      "http://current.com",
 	}
-	infoBytes, _ := json.Marshal(currentData)
 	dataItem := &datapb.DataItem{
 		Id:       1,
 		InfoType: "login_password",
-		Info:     infoBytes,
+		Payload:  &datapb.DataItem_LoginPassword{LoginPassword: currentData},
 		Meta:     "current meta",
 	}
 
@@ -138,26 +147,25 @@ func TestUpdateCommand_Execute_UpdateLoginPasswordData(t *testing.T) {
 	reader := strings.NewReader(input)
 	writer := &bytes.Buffer{}
 
-	updateCmd := NewUpdateCommand(dataService, tokenHolder, reader, writer)
+	updateCmd := NewUpdateCommand(dataService, nil, nil, tokenHolder, reader, writer)
 
 	err := updateCmd.Execute()
 	if err != nil {
 		t.Fatalf("Не ожидалось ошибки, получили: %v", err)
 	}
 
-	expectedUpdatedData := &entity.LoginPasswordData{
+	expectedUpdatedData := &datapb.LoginPassword{
 		Login:    "new_user",
 		Password: "new_pass",
-		URL:      "new_url",
+		Url:      "new_url",
 	}
-	expectedInfoBytes, _ := json.Marshal(expectedUpdatedData)
 
 	if dataService.dataItem.InfoType != "login_password" {
 		t.Errorf("Ожидался InfoType 'login_password', получили: %s", dataService.dataItem.InfoType)
 	}
 
-	if !bytes.Equal(dataService.dataItem.Info, expectedInfoBytes) {
-		t.Errorf("Обновленные данные Info не совпадают с ожидаемыми")
+	if !proto.Equal(dataService.dataItem.GetLoginPassword(), expectedUpdatedData) {
+		t.Errorf("Обновленное содержимое не совпадает с ожидаемым")
 	}
 
 	if dataService.dataItem.Meta != "new meta" {
@@ -171,14 +179,13 @@ func TestUpdateCommand_Execute_UpdateLoginPasswordData(t *testing.T) {
 }
 
 func TestUpdateCommand_Execute_UpdateTextData(t *testing.T) {
-	currentData := entity.TextData{
+	currentData := &datapb.Text{
 		Text: "current text",
 	}
-	infoBytes, _ := json.Marshal(currentData)
 	dataItem := &datapb.DataItem{
 		Id:       2,
 		InfoType: "text",
-		Info:     infoBytes,
+		Payload:  &datapb.DataItem_Text{Text: currentData},
 		Meta:     "current meta",
 	}
 
@@ -197,24 +204,23 @@ func TestUpdateCommand_Execute_UpdateTextData(t *testing.T) {
 	reader := strings.NewReader(input)
 	writer := &bytes.Buffer{}
 
-	updateCmd := NewUpdateCommand(dataService, tokenHolder, reader, writer)
+	updateCmd := NewUpdateCommand(dataService, nil, nil, tokenHolder, reader, writer)
 
 	err := updateCmd.Execute()
 	if err != nil {
 		t.Fatalf("Не ожидалось ошибки, получили: %v", err)
 	}
 
-	expectedUpdatedData := &entity.TextData{
+	expectedUpdatedData := &datapb.Text{
 		Text: "new text",
 	}
-	expectedInfoBytes, _ := json.Marshal(expectedUpdatedData)
 
 	if dataService.dataItem.InfoType != "text" {
 		t.Errorf("Ожидался InfoType 'text', получили: %s", dataService.dataItem.InfoType)
 	}
 
-	if !bytes.Equal(dataService.dataItem.Info, expectedInfoBytes) {
-		t.Errorf("Обновленные данные Info не совпадают с ожидаемыми")
+	if !proto.Equal(dataService.dataItem.GetText(), expectedUpdatedData) {
+		t.Errorf("Обновленное содержимое не совпадает с ожидаемым")
 	}
 
 	if dataService.dataItem.Meta != "new meta" {
@@ -227,16 +233,75 @@ func TestUpdateCommand_Execute_UpdateTextData(t *testing.T) {
 	}
 }
 
+func TestUpdateCommand_Execute_Conflict(t *testing.T) {
+	textItem := func(text, meta string, revision int64) *datapb.DataItem {
+		content := &datapb.DataItem_Text{Text: &datapb.Text{Text: text}}
+		return &datapb.DataItem{Id: 2, InfoType: "text", Payload: content, Meta: meta, Revision: revision}
+	}
+
+	tests := []struct {
+		name         string
+		choice       []string
+		expectedSent *datapb.DataItem
+		contains     string
+	}{
+		{
+			name:         "Сохранить свою версию",
+			choice:       []string{"1"},
+			expectedSent: textItem("мой текст", "моя мета", 5),
+			contains:     "Данные успешно обновлены.",
+		},
+		{
+			name:     "Оставить версию с сервера",
+			choice:   []string{"2"},
+			contains: "Оставлена версия с сервера.",
+		},
+		{
+			name:         "Объединить по полям",
+			choice:       []string{"3", "1", "2"},
+			expectedSent: textItem("мой текст", "мета с сервера", 5),
+			contains:     "Text: 1 - ваше значение (мой текст), 2 - с сервера (текст с сервера)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataService := &mockUpdateDataService{
+				dataItem:  textItem("старый текст", "старая мета", 3),
+				conflicts: []*datapb.DataItem{textItem("текст с сервера", "мета с сервера", 5)},
+			}
+			input := strings.Join(append([]string{"2", "мой текст", "моя мета"}, tt.choice...), "\n") + "\n"
+			writer := &bytes.Buffer{}
+
+			cmd := NewUpdateCommand(dataService, nil, nil, &entity.TokenHolder{Token: "token"}, strings.NewReader(input), writer)
+			err := cmd.Execute()
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(3), dataService.sent[0].Revision, "первая попытка сверяется с прочитанной ревизией")
+			if tt.expectedSent != nil {
+				if assert.Len(t, dataService.sent, 2) {
+					assert.Equal(t, tt.expectedSent.Revision, dataService.sent[1].Revision)
+					assert.True(t, proto.Equal(tt.expectedSent.GetText(), dataService.sent[1].GetText()))
+					assert.Equal(t, tt.expectedSent.Meta, dataService.sent[1].Meta)
+				}
+			} else {
+				assert.Len(t, dataService.sent, 1)
+			}
+			assert.Contains(t, writer.String(), "Версия на сервере")
+			assert.Contains(t, writer.String(), tt.contains)
+		})
+	}
+}
+
 func TestUpdateCommand_Execute_UpdateBinaryData(t *testing.T) {
-	currentData := entity.BinaryData{
+	currentData := &datapb.Binary{
 		FileName:    "current.bin",
 		FileContent: []byte("current content"),
 	}
-	infoBytes, _ := json.Marshal(currentData)
 	dataItem := &datapb.DataItem{
 		Id:       3,
 		InfoType: "binary",
-		Info:     infoBytes,
+		Payload:  &datapb.DataItem_Binary{Binary: currentData},
 		Meta:     "current meta",
 	}
 
@@ -260,29 +325,19 @@ func TestUpdateCommand_Execute_UpdateBinaryData(t *testing.T) {
 	reader := strings.NewReader(input)
 	writer := &bytes.Buffer{}
 
-	updateCmd := NewUpdateCommand(dataService, tokenHolder, reader, writer)
+	uploader := new(MockBinaryUploader)
+	uploader.On("Upload", mock.Anything, "valid_token", newFileName, "new meta", int32(3)).Return(int32(3), nil)
+
+	updateCmd := NewUpdateCommand(dataService, uploader, nil, tokenHolder, reader, writer)
 
 	err := updateCmd.Execute()
 	if err != nil {
 		t.Fatalf("Не ожидалось ошибки, получили: %v", err)
 	}
 
-	expectedUpdatedData := &entity.BinaryData{
-		FileName:    newFileName,
-		FileContent: newFileContent,
-	}
-	expectedInfoBytes, _ := json.Marshal(expectedUpdatedData)
-
-	if dataService.dataItem.InfoType != "binary" {
-		t.Errorf("Ожидался InfoType 'binary', получили: %s", dataService.dataItem.InfoType)
-	}
-
-	if !bytes.Equal(dataService.dataItem.Info, expectedInfoBytes) {
-		t.Errorf("Обновленные данные Info не совпадают с ожидаемыми")
-	}
-
-	if dataService.dataItem.Meta != "new meta" {
-		t.Errorf("Ожидалась Meta 'new meta', получили: %s", dataService.dataItem.Meta)
+	uploader.AssertExpectations(t)
+	if len(dataService.sent) != 0 {
+		t.Errorf("Новый файл загружается по частям, UpdateData не вызывается")
 	}
 
 	output := writer.String()
@@ -291,18 +346,46 @@ func TestUpdateCommand_Execute_UpdateBinaryData(t *testing.T) {
 	}
 }
 
+func TestUpdateCommand_Execute_UpdateBinaryMeta(t *testing.T) {
+	binary := &datapb.Binary{FileName: "large.bin", Size: 10 << 20, Sha256: "abc", Chunked: true}
+	dataService := &mockUpdateDataService{
+		dataItem: &datapb.DataItem{
+			Id:       3,
+			InfoType: "binary",
+			Payload:  &datapb.DataItem_Binary{Binary: binary},
+			Meta:     "current meta",
+			Revision: 4,
+		},
+	}
+
+	writer := &bytes.Buffer{}
+	updateCmd := NewUpdateCommand(
+		dataService,
+		nil,
+		nil,
+		&entity.TokenHolder{Token: "valid_token"},
+		strings.NewReader("3\n\nnew meta\n"),
+		writer,
+	)
+
+	assert.NoError(t, updateCmd.Execute())
+	assert.True(t, proto.Equal(binary, dataService.dataItem.GetBinary()), "описание файла не меняется")
+	assert.Equal(t, "new meta", dataService.dataItem.Meta)
+	assert.Equal(t, int64(4), dataService.dataItem.Revision)
+	assert.Contains(t, writer.String(), "Данные успешно обновлены.")
+}
+
 func TestUpdateCommand_Execute_UpdateBankCardData(t *testing.T) {
-	currentData := entity.BankCardData{
+	currentData := &datapb.BankCard{
 		CardNumber: "1234-5678-9012-3456",
 		ExpiryDate: "12/24",
-		CVV:        "123",
+		Cvv:        "123",
 		HolderName: "Current Holder",
 	}
-	infoBytes, _ := json.Marshal(currentData)
 	dataItem := &datapb.DataItem{
 		Id:       4,
 		InfoType: "bank_card",
-		Info:     infoBytes,
+		Payload:  &datapb.DataItem_BankCard{BankCard: currentData},
 		Meta:     "current meta",
 	}
 
@@ -324,27 +407,26 @@ func TestUpdateCommand_Execute_UpdateBankCardData(t *testing.T) {
 	reader := strings.NewReader(input)
 	writer := &bytes.Buffer{}
 
-	updateCmd := NewUpdateCommand(dataService, tokenHolder, reader, writer)
+	updateCmd := NewUpdateCommand(dataService, nil, nil, tokenHolder, reader, writer)
 
 	err := updateCmd.Execute()
 	if err != nil {
 		t.Fatalf("Не ожидалось ошибки, получили: %v", err)
 	}
 
-	expectedUpdatedData := &entity.BankCardData{
+	expectedUpdatedData := &datapb.BankCard{
 		CardNumber: "4321-8765-2109-6543",
 		ExpiryDate: "11/25",
-		CVV:        "321",
+		Cvv:        "321",
 		HolderName: "New Holder",
 	}
-	expectedInfoBytes, _ := json.Marshal(expectedUpdatedData)
 
 	if dataService.dataItem.InfoType != "bank_card" {
 		t.Errorf("Ожидался InfoType 'bank_card', получили: %s", dataService.dataItem.InfoType)
 	}
 
-	if !bytes.Equal(dataService.dataItem.Info, expectedInfoBytes) {
-		t.Errorf("Обновленные данные Info не совпадают с ожидаемыми")
+	if !proto.Equal(dataService.dataItem.GetBankCard(), expectedUpdatedData) {
+		t.Errorf("Обновленное содержимое не совпадает с ожидаемым")
 	}
 
 	if dataService.dataItem.Meta != "new meta" {
@@ -363,7 +445,7 @@ func TestUpdateCommand_Execute_ScannerError(t *testing.T) {
 	reader := &errorReader{}
 	writer := &bytes.Buffer{}
 
-	updateCmd := NewUpdateCommand(dataService, tokenHolder, reader, writer)
+	updateCmd := NewUpdateCommand(dataService, nil, nil, tokenHolder, reader, writer)
 
 	err := updateCmd.Execute()
 	if err == nil || !strings.Contains(err.Error(), "ошибка ввода ID") {
@@ -372,8 +454,84 @@ func TestUpdateCommand_Execute_ScannerError(t *testing.T) {
 }
 
 func TestUpdateCommand_Name(t *testing.T) {
-	cmd := NewUpdateCommand(nil, nil, nil, nil)
+	cmd := NewUpdateCommand(nil, nil, nil, nil, nil, nil)
 	expectedName := "update"
 	actualName := cmd.Name()
 	assert.Equal(t, expectedName, actualName, "Название команды должно быть 'update'")
 }
+
+func TestUpdateCommand_Execute_Custom(t *testing.T) {
+	current := &datapb.DataItem{Id: 9, InfoType: "wifi", Info: []byte(`{"password":"old","ssid":"home"}`), Meta: "дом"}
+	theirs := &datapb.DataItem{Id: 9, InfoType: "wifi", Meta: "дом", Revision: 2,
+		Payload: &datapb.DataItem_Custom{Custom: &datapb.Custom{Fields: map[string]string{
+			"password": "old",
+			"ssid":     "office",
+		}}},
+	}
+	dataService := &mockUpdateDataService{dataItem: current, conflicts: []*datapb.DataItem{theirs}}
+	writer := &bytes.Buffer{}
+	input := strings.Join([]string{"9", "", "new", "", "", "3", "1", "2"}, "\n") + "\n"
+
+	updateCmd := NewUpdateCommand(dataService, nil, newWifiTypes(), &entity.TokenHolder{Token: "token"},
+		strings.NewReader(input), writer)
+
+	assert.NoError(t, updateCmd.Execute())
+	assert.Contains(t, writer.String(), "Текущее значение Сеть: home\n")
+	assert.Contains(t, writer.String(), "Текущее значение Пароль: ********\n")
+	assert.Contains(t, writer.String(), "Введите новое значение (YYYY-MM-DD)")
+	assert.Contains(t, writer.String(), "Данные успешно обновлены.")
+
+	assert.Equal(t, map[string]string{"password": "new", "ssid": "office"}, dataService.dataItem.GetCustom().GetFields())
+	assert.Equal(t, int64(2), dataService.dataItem.Revision)
+}
+
+func TestUpdateCommand_Execute_TOTP(t *testing.T) {
+	current := &datapb.DataItem{Id: 2, InfoType: "totp", Revision: 3, Payload: &datapb.DataItem_Totp{
+		Totp: &datapb.Totp{Secret: "JBSWY3DPEHPK3PXP", Issuer: "GitHub", Digits: 8},
+	}}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected *datapb.Totp
+	}{
+		{
+			name:     "Новый секрет",
+			input:    "2\ngezd gnbv\n\n",
+			expected: &datapb.Totp{Secret: "GEZDGNBV", Issuer: "GitHub", Digits: 8},
+		},
+		{
+			name:  "Новый otpauth URI",
+			input: "2\notpauth://totp/GitLab:bob?secret=GEZDGNBV\n\n",
+			expected: &datapb.Totp{
+				Secret: "GEZDGNBV", Issuer: "GitLab", Account: "bob", Algorithm: "SHA1", Digits: 6, Period: 30,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataService := &mockUpdateDataService{dataItem: current}
+			writer := &bytes.Buffer{}
+			updateCmd := NewUpdateCommand(dataService, nil, nil, &entity.TokenHolder{Token: "token"},
+				strings.NewReader(tt.input), writer)
+
+			assert.NoError(t, updateCmd.Execute())
+			assert.Contains(t, writer.String(), "Текущий секрет: JBSWY3DPEHPK3PXP\n")
+			assert.True(t, proto.Equal(tt.expected, dataService.dataItem.GetTotp()), dataService.dataItem.GetTotp().String())
+			assert.Equal(t, int64(3), dataService.dataItem.Revision)
+		})
+	}
+}
+
+func TestUpdateCommand_Execute_KeepsTOTPLink(t *testing.T) {
+	dataService := &mockUpdateDataService{dataItem: &datapb.DataItem{Id: 1, InfoType: "login_password",
+		Payload: &datapb.DataItem_LoginPassword{LoginPassword: &datapb.LoginPassword{Login: "user", TotpId: 2}},
+	}}
+	updateCmd := NewUpdateCommand(dataService, nil, nil, &entity.TokenHolder{Token: "token"},
+		strings.NewReader("1\n\nnew\n\n\n"), &bytes.Buffer{})
+
+	assert.NoError(t, updateCmd.Execute())
+	assert.Equal(t, "new", dataService.dataItem.GetLoginPassword().GetPassword())
+	assert.Equal(t, int32(2), dataService.dataItem.GetLoginPassword().GetTotpId())
+}
@@ -0,0 +1,104 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+)
+
+type dataWatcher interface {
+	WatchData(ctx context.Context, token string, onEvent func(*datapb.DataEvent) error) error
+}
+
+var dataEventNames = map[datapb.DataEvent_Kind]string{
+	datapb.DataEvent_ADDED:   "добавлена",
+	datapb.DataEvent_UPDATED: "изменена",
+	datapb.DataEvent_DELETED: "удалена",
+}
+
+// WatchCommand выводит изменения данных, сделанные на других устройствах, и подтягивает их
+// в локальный кеш, пока пользователь не нажмёт Enter.
+type WatchCommand struct {
+	watcher     dataWatcher
+	syncService syncService
+	tokenHolder *entity.TokenHolder
+	reader      io.Reader
+	writer      io.Writer
+}
+
+func NewWatchCommand(
+	watcher dataWatcher,
+	syncService syncService,
+	tokenHolder *entity.TokenHolder,
+	reader io.Reader,
+	writer io.Writer,
+) *WatchCommand {
+	return &WatchCommand{
+		watcher:     watcher,
+		syncService: syncService,
+		tokenHolder: tokenHolder,
+		reader:      reader,
+		writer:      writer,
+	}
+}
+
+func (c *WatchCommand) Name() string {
+	return "watch"
+}
+
+func (c *WatchCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	fmt.Fprintln(c.writer, "Отслеживание изменений. Нажмите Enter, чтобы остановить.")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	token := c.tokenHolder.Token
+	done := make(chan error, 1)
+	go func() {
+		done <- c.watcher.WatchData(ctx, token, func(event *datapb.DataEvent) error {
+			return c.printEvent(ctx, token, event)
+		})
+	}()
+
+	stopped := make(chan struct{})
+	go func() {
+		bufio.NewScanner(c.reader).Scan()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		cancel()
+		<-done
+		return nil
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("отслеживание изменений прервано: %w", err)
+		}
+		fmt.Fprintln(c.writer, "Сервер завершил отслеживание изменений.")
+		return nil
+	}
+}
+
+func (c *WatchCommand) printEvent(ctx context.Context, token string, event *datapb.DataEvent) error {
+	if event.InfoType != "" {
+		fmt.Fprintf(c.writer, "Запись %d (%s) %s\n", event.Id, event.InfoType, dataEventNames[event.Kind])
+	} else {
+		fmt.Fprintf(c.writer, "Запись %d %s\n", event.Id, dataEventNames[event.Kind])
+	}
+
+	// Кеш нужен только для работы без сети, поэтому ошибка синхронизации не прерывает отслеживание.
+	if _, err := c.syncService.Sync(ctx, token); err != nil && ctx.Err() == nil {
+		fmt.Fprintf(c.writer, "Не удалось обновить локальный кеш: %v\n", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeWatcher передаёт события, затем возвращает err или, если err не задан, ждёт отмены.
+type fakeWatcher struct {
+	err    error
+	events []*datapb.DataEvent
+}
+
+func (w *fakeWatcher) WatchData(ctx context.Context, token string, onEvent func(*datapb.DataEvent) error) error {
+	for _, event := range w.events {
+		if err := onEvent(event); err != nil {
+			return err
+		}
+	}
+	if w.err != nil {
+		return w.err
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+func TestWatchCommand_Execute(t *testing.T) {
+	events := []*datapb.DataEvent{
+		{Kind: datapb.DataEvent_ADDED, Id: 1, InfoType: "text"},
+		{Kind: datapb.DataEvent_DELETED, Id: 2},
+	}
+
+	t.Run("Остановка по Enter", func(t *testing.T) {
+		syncService := new(MockSyncService)
+		syncService.On("Sync", mock.Anything, "token").Return(&entity.SyncResult{}, nil).Maybe()
+		writer := &bytes.Buffer{}
+
+		cmd := NewWatchCommand(&fakeWatcher{}, syncService, &entity.TokenHolder{Token: "token"},
+			strings.NewReader("\n"), writer)
+
+		assert.NoError(t, cmd.Execute())
+		assert.Contains(t, writer.String(), "Нажмите Enter, чтобы остановить.")
+	})
+
+	t.Run("Обрыв потока", func(t *testing.T) {
+		syncService := new(MockSyncService)
+		syncService.On("Sync", mock.Anything, "token").Return(nil, errors.New("нет сети")).Once()
+		syncService.On("Sync", mock.Anything, "token").Return(&entity.SyncResult{}, nil).Once()
+		reader, stdin := io.Pipe()
+		defer stdin.Close()
+		writer := &bytes.Buffer{}
+
+		cmd := NewWatchCommand(&fakeWatcher{events: events, err: errors.New("соединение разорвано")},
+			syncService, &entity.TokenHolder{Token: "token"}, reader, writer)
+
+		err := cmd.Execute()
+
+		assert.ErrorContains(t, err, "отслеживание изменений прервано")
+		assert.Contains(t, writer.String(), "Запись 1 (text) добавлена")
+		assert.Contains(t, writer.String(), "Не удалось обновить локальный кеш: нет сети")
+		assert.Contains(t, writer.String(), "Запись 2 удалена")
+		syncService.AssertExpectations(t)
+	})
+
+	t.Run("Пользователь не вошёл", func(t *testing.T) {
+		cmd := NewWatchCommand(&fakeWatcher{}, new(MockSyncService), &entity.TokenHolder{},
+			strings.NewReader(""), &bytes.Buffer{})
+
+		assert.ErrorContains(t, cmd.Execute(), "вы должны войти в систему")
+	})
+}
+
+func TestWatchCommand_Name(t *testing.T) {
+	cmd := NewWatchCommand(nil, nil, &entity.TokenHolder{}, nil, nil)
+	assert.Equal(t, "watch", cmd.Name())
+}
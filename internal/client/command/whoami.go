@@ -0,0 +1,73 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+)
+
+type WhoamiCommand struct {
+	tokenHolder *entity.TokenHolder
+	writer      io.Writer
+	now         func() time.Time
+}
+
+func NewWhoamiCommand(tokenHolder *entity.TokenHolder, writer io.Writer) *WhoamiCommand {
+	return &WhoamiCommand{
+		tokenHolder: tokenHolder,
+		writer:      writer,
+		now:         time.Now,
+	}
+}
+
+func (c *WhoamiCommand) Name() string {
+	return "whoami"
+}
+
+// Execute выводит пользователя текущей сессии, срок действия токена и состояние хранилища.
+func (c *WhoamiCommand) Execute() error {
+	if c.tokenHolder.Token == "" {
+		return ErrNotLoggedIn
+	}
+
+	fmt.Fprintf(c.writer, "Пользователь: %s\n", c.tokenHolder.Login)
+
+	expiresAt, ok := c.tokenHolder.ExpiresAt()
+	switch {
+	case !ok:
+		fmt.Fprintln(c.writer, "Токен: срок действия неизвестен")
+	case c.tokenHolder.Expired(c.now()) && c.tokenHolder.RefreshToken != "":
+		fmt.Fprintf(c.writer, "Токен: истёк %s, будет обновлён при следующем запросе\n", expiresAt.Format(time.RFC3339))
+	case c.tokenHolder.Expired(c.now()):
+		fmt.Fprintf(c.writer, "Токен: истёк %s, войдите заново\n", expiresAt.Format(time.RFC3339))
+	default:
+		fmt.Fprintf(c.writer, "Токен: действует до %s\n", expiresAt.Format(time.RFC3339))
+	}
+
+	switch {
+	case c.tokenHolder.VaultLocked():
+		fmt.Fprintln(c.writer, "Хранилище: заблокировано, нужен мастер-пароль")
+	case len(c.tokenHolder.VaultKey) > 0:
+		fmt.Fprintln(c.writer, "Хранилище: разблокировано")
+	default:
+		fmt.Fprintln(c.writer, "Хранилище: сквозное шифрование не включено")
+	}
+
+	return nil
+}
+
+// Run выводит сведения о сессии из командной строки: `whoami`.
+func (c *WhoamiCommand) Run(args []string) error {
+	positional, err := parseArgs(newFlagSet("whoami"), args, c.writer)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("лишние аргументы: %s", strings.Join(positional, " "))
+	}
+
+	return c.Execute()
+}
@@ -0,0 +1,81 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func signedToken(t *testing.T, expiresAt time.Time) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+
+	return token
+}
+
+func TestWhoamiCommand_Execute(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		tokenHolder *entity.TokenHolder
+		expected    []string
+	}{
+		{
+			name: "Действующий токен",
+			tokenHolder: &entity.TokenHolder{
+				Token:    signedToken(t, now.Add(time.Hour)),
+				Login:    "alice",
+				VaultKey: []byte("key"),
+			},
+			expected: []string{"Пользователь: alice", "действует до 2024-05-01T11:00:00Z", "Хранилище: разблокировано"},
+		},
+		{
+			name: "Истёкший токен обновится",
+			tokenHolder: &entity.TokenHolder{
+				Token:        signedToken(t, now.Add(-time.Minute)),
+				RefreshToken: "refresh",
+				KDFParams:    &entity.KDFParams{},
+			},
+			expected: []string{"будет обновлён при следующем запросе", "Хранилище: заблокировано"},
+		},
+		{
+			name:        "Истёкший токен без refresh",
+			tokenHolder: &entity.TokenHolder{Token: signedToken(t, now.Add(-time.Minute))},
+			expected:    []string{"войдите заново", "сквозное шифрование не включено"},
+		},
+		{
+			name:        "Токен не JWT",
+			tokenHolder: &entity.TokenHolder{Token: "opaque"},
+			expected:    []string{"срок действия неизвестен"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := &bytes.Buffer{}
+			cmd := NewWhoamiCommand(tt.tokenHolder, writer)
+			cmd.now = func() time.Time { return now }
+
+			assert.NoError(t, cmd.Run(nil))
+			for _, line := range tt.expected {
+				assert.Contains(t, writer.String(), line)
+			}
+		})
+	}
+}
+
+func TestWhoamiCommand_NotLoggedIn(t *testing.T) {
+	cmd := NewWhoamiCommand(&entity.TokenHolder{}, &bytes.Buffer{})
+
+	assert.ErrorIs(t, cmd.Execute(), ErrNotLoggedIn)
+	assert.Equal(t, "whoami", cmd.Name())
+}
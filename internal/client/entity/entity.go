@@ -1,31 +1,179 @@
 package entity
 
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/golang-jwt/jwt/v4"
+)
+
 type TokenHolder struct {
 	Token string
+	// Login - пользователь текущей сессии, по нему выбирается локальный кеш.
+	Login string
+	// RefreshToken - одноразовый токен для получения нового Token, когда тот истечёт.
+	RefreshToken string
+	// VaultKey - ключ хранилища, выведенный из мастер-пароля. Пустой, если сквозное шифрование не включено.
+	VaultKey []byte
+	// KDFParams - параметры вывода VaultKey. Сохраняются вместе с сессией, чтобы после перезапуска
+	// разблокировать хранилище мастер-паролем без повторного входа.
+	KDFParams *KDFParams
+}
+
+// VaultLocked сообщает, что сквозное шифрование включено, но ключ хранилища ещё не выведен.
+func (h *TokenHolder) VaultLocked() bool {
+	return h.KDFParams != nil && len(h.VaultKey) == 0
+}
+
+// ExpiresAt возвращает срок действия access токена из claim exp. Подпись не проверяется:
+// это делает сервер, а клиенту срок нужен, чтобы не отправлять заведомо истёкший токен.
+func (h *TokenHolder) ExpiresAt() (time.Time, bool) {
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(h.Token, &claims); err != nil || claims.ExpiresAt == nil {
+		return time.Time{}, false
+	}
+
+	return claims.ExpiresAt.Time, true
+}
+
+// Expired сообщает, что срок действия access токена истёк к моменту now.
+func (h *TokenHolder) Expired(now time.Time) bool {
+	expiresAt, ok := h.ExpiresAt()
+	return ok && !now.Before(expiresAt)
+}
+
+// KDFParams - параметры Argon2id для вывода ключа хранилища из мастер-пароля.
+type KDFParams struct {
+	Salt      []byte
+	KeyCheck  []byte
+	Time      uint32
+	MemoryKiB uint32
+	Threads   uint32
+}
+
+// LoginResult - результат входа: токены и, если включено сквозное шифрование, параметры KDF.
+// Если у пользователя включён второй фактор, токенов нет, а вход подтверждается кодом по ChallengeID.
+type LoginResult struct {
+	KDFParams    *KDFParams
+	Token        string
+	RefreshToken string
+	ChallengeID  string
+}
+
+// TOTPEnrollment - секрет для приложения-аутентификатора.
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// AuthTokens - access и refresh токены сессии.
+type AuthTokens struct {
+	Token        string
+	RefreshToken string
 }
 
-type LoginPasswordData struct {
-	Login    string
-	Password string
-	URL      string
+// SyncResult - итог синхронизации локального кеша с сервером.
+type SyncResult struct {
+	Revision int64
+	Pushed   int
+	Pulled   int
+	// Conflicts - сколько локальных правок не легли поверх изменений с сервера и сохранены копиями.
+	Conflicts int
 }
 
-type TextData struct {
-	Text string
+// ConflictError - запись на сервере изменилась после того, как клиент её прочитал.
+// Current - текущая версия записи на сервере, может быть nil, если сервер её не передал.
+type ConflictError struct {
+	Current *datapb.DataItem
 }
 
-type BinaryData struct {
-	FileName    string
-	FileContent []byte
+func (e *ConflictError) Error() string {
+	return "запись изменена на другом устройстве"
 }
 
-type BankCardData struct {
-	CardNumber string
-	ExpiryDate string
-	CVV        string
-	HolderName string
+// ErrRecordTypeNotFound - у пользователя нет типа записей с таким именем.
+var ErrRecordTypeNotFound = errors.New("тип записей не найден")
+
+// Виды ошибок RecordError.
+var (
+	ErrNotFound         = errors.New("запись не найдена")
+	ErrPermissionDenied = errors.New("нет доступа к записи")
+)
+
+// RecordError - сервер не нашёл запись ID или отказал в доступе к ней. Kind - ErrNotFound
+// или ErrPermissionDenied.
+type RecordError struct {
+	Kind error
+	ID   string
 }
 
+func (e *RecordError) Error() string {
+	if errors.Is(e.Kind, ErrPermissionDenied) {
+		return fmt.Sprintf("нет доступа к записи %s: она принадлежит другому пользователю", e.ID)
+	}
+	return fmt.Sprintf("запись %s не найдена", e.ID)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Kind
+}
+
+// Session - активная сессия пользователя на одном из устройств.
+type Session struct {
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ID         string
+	IP         string
+	UserAgent  string
+	Current    bool
+}
+
+// DataFilter - параметры страницы списка записей.
 type DataFilter struct {
 	InfoType string
+	// PageToken - токен следующей страницы из DataPage. Пусто - первая страница.
+	PageToken string
+	// FolderID - только записи этой папки, 0 - записи вне папок. nil - записи всех папок.
+	FolderID *int32
+	// PageSize - размер страницы. 0 - размер по умолчанию сервера.
+	PageSize int32
+	// TagID - только записи с этим тегом. 0 - без фильтра.
+	TagID int32
+}
+
+// Folder - папка записей. ParentID 0 - папка в корне.
+type Folder struct {
+	Name     string
+	ID       int32
+	ParentID int32
+}
+
+// Tag - тег записей.
+type Tag struct {
+	Name string
+	ID   int32
+}
+
+// SearchQuery - параметры поиска записей. Нулевые CreatedFrom и CreatedTo - без границы,
+// CreatedTo не включается в период.
+type SearchQuery struct {
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// Text - слова, которые должны встречаться в Meta. Пусто - поиск только по типу и дате.
+	Text     string
+	InfoType string
+	// PageToken - токен следующей страницы из DataPage. Пусто - первая страница.
+	PageToken string
+	// Tokens - токены поиска для сервера, их вычисляет сервис сквозного шифрования из Text.
+	Tokens    []string
+	PageSize  int32
+	Ascending bool
+}
+
+// DataPage - страница списка или результатов поиска. NextPageToken пуст, если страница последняя.
+type DataPage struct {
+	NextPageToken string
+	Items         []*datapb.DataItem
 }
@@ -4,13 +4,27 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 
 	"github.com/caarlos0/env"
 )
 
+const appDirName = "gophkeeper"
+
 type config struct {
 	ServerAddress string `env:"RUN_ADDRESS"`
 	RootCertPath  string `env:"ROOT_CERT_PATH"`
+	CacheDir      string `env:"CACHE_DIR"`
+	ConfigDir     string `env:"CONFIG_DIR"`
+	OutputFormat  string `env:"OUTPUT_FORMAT"`
+	// Login, Password, LoginCode и MasterPassword - данные для входа при вызове команды из скрипта.
+	// Задаются только через env, чтобы не попадать в аргументы процесса.
+	Login          string `env:"GOPHKEEPER_LOGIN"`
+	Password       string `env:"GOPHKEEPER_PASSWORD"`
+	LoginCode      string `env:"GOPHKEEPER_CODE"`
+	MasterPassword string `env:"GOPHKEEPER_MASTER_PASSWORD"`
+	args           []string
 }
 
 func (c *config) initEnv() error {
@@ -25,7 +39,11 @@ func (c *config) initEnv() error {
 func (c *config) parseFlags() {
 	flag.StringVar(&c.ServerAddress, "a", "localhost:8080", "net address host:port")
 	flag.StringVar(&c.RootCertPath, "ca", "./ca.pem", "root cert path")
+	flag.StringVar(&c.CacheDir, "cache-dir", defaultCacheDir(), "local encrypted cache directory")
+	flag.StringVar(&c.ConfigDir, "config-dir", defaultConfigDir(), "directory for the encrypted session file")
+	flag.StringVar(&c.OutputFormat, "output", "text", "output format of get and list: text, table, json or yaml")
 	flag.Parse()
+	c.args = flag.Args()
 }
 
 // NewConfig конструктор конфига, в котором идёт инициализация флагов и env переменных.
@@ -49,3 +67,63 @@ func (c config) GetServerAddress() string {
 func (c config) GetRootCertPath() string {
 	return c.RootCertPath
 }
+
+// GetCacheDir геттер для каталога локального кеша.
+func (c config) GetCacheDir() string {
+	return c.CacheDir
+}
+
+// GetConfigDir геттер для каталога конфигурации, где хранится сессия.
+func (c config) GetConfigDir() string {
+	return c.ConfigDir
+}
+
+// GetOutputFormat геттер для формата вывода команд get и list.
+func (c config) GetOutputFormat() string {
+	return c.OutputFormat
+}
+
+// GetArgs возвращает аргументы после флагов: команду и её аргументы. Пусто - интерактивный режим.
+func (c config) GetArgs() []string {
+	return c.args
+}
+
+// GetLogin геттер для логина входа без интерактивных вопросов.
+func (c config) GetLogin() string {
+	return c.Login
+}
+
+// GetPassword геттер для пароля входа без интерактивных вопросов.
+func (c config) GetPassword() string {
+	return c.Password
+}
+
+// GetLoginCode геттер для кода второго фактора.
+func (c config) GetLoginCode() string {
+	return c.LoginCode
+}
+
+// GetMasterPassword геттер для мастер-пароля хранилища.
+func (c config) GetMasterPassword() string {
+	return c.MasterPassword
+}
+
+// defaultCacheDir возвращает каталог приложения в пользовательском каталоге кеша ОС.
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(".", "."+appDirName)
+	}
+
+	return filepath.Join(dir, appDirName)
+}
+
+// defaultConfigDir возвращает каталог приложения в пользовательском каталоге конфигурации ОС.
+func defaultConfigDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return filepath.Join(".", "."+appDirName)
+	}
+
+	return filepath.Join(dir, appDirName)
+}
@@ -78,3 +78,40 @@ func TestConfig_GetRootCertPath(t *testing.T) {
 
 	assert.Equal(t, "/path/to/custom/ca.pem", cfg.GetRootCertPath())
 }
+
+func TestConfig_CacheDir(t *testing.T) {
+	t.Setenv("CACHE_DIR", "/tmp/gophkeeper-cache")
+
+	cfg := new(config)
+	err := cfg.initEnv()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/gophkeeper-cache", cfg.GetCacheDir())
+	assert.NotEmpty(t, defaultCacheDir())
+}
+
+func TestConfig_initEnv_Credentials(t *testing.T) {
+	t.Setenv("GOPHKEEPER_LOGIN", "user")
+	t.Setenv("GOPHKEEPER_PASSWORD", "pass")
+	t.Setenv("GOPHKEEPER_CODE", "123456")
+	t.Setenv("GOPHKEEPER_MASTER_PASSWORD", "master")
+
+	cfg := new(config)
+	err := cfg.initEnv()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user", cfg.GetLogin())
+	assert.Equal(t, "pass", cfg.GetPassword())
+	assert.Equal(t, "123456", cfg.GetLoginCode())
+	assert.Equal(t, "master", cfg.GetMasterPassword())
+}
+
+func TestConfig_initEnv_OutputFormat(t *testing.T) {
+	t.Setenv("OUTPUT_FORMAT", "json")
+
+	cfg := new(config)
+	err := cfg.initEnv()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "json", cfg.GetOutputFormat())
+}
@@ -0,0 +1,473 @@
+// Package localstore хранит локальную копию хранилища пользователя для работы без сети.
+//
+// Кеш каждого пользователя - отдельный файл в каталоге кеша, целиком зашифрованный AES-256-GCM
+// локальным ключом (файл local.key с правами 600). Записи хранятся в том виде, в котором их отдаёт
+// сервер, поэтому при сквозном шифровании Info и Meta остаются зашифрованными ключом хранилища.
+package localstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// OpAdd - запись создана без связи с сервером.
+	OpAdd = "add"
+	// OpUpdate - запись изменена без связи с сервером.
+	OpUpdate = "update"
+	// OpDelete - запись удалена без связи с сервером.
+	OpDelete = "delete"
+
+	keyFileName  = "local.key"
+	cacheFileExt = ".cache"
+	keyLength    = 32
+	filePerm     = 0o600
+	dirPerm      = 0o700
+)
+
+var (
+	// ErrNoUser - кеш не привязан к пользователю, нужно войти в систему.
+	ErrNoUser = errors.New("локальный кеш не выбран: войдите в систему")
+	// ErrNotFound - записи нет в локальном кеше.
+	ErrNotFound = errors.New("запись не найдена в локальном кеше")
+)
+
+// Operation - изменение, сделанное без связи с сервером и ожидающее отправки.
+type Operation struct {
+	Item *datapb.DataItem
+	Kind string
+	ID   int32
+}
+
+type item struct {
+	Created  time.Time `json:"created"`
+	InfoType string    `json:"info_type"`
+	Meta     string    `json:"meta"`
+	Info     []byte    `json:"info"`
+	TagIDs   []int32   `json:"tag_ids,omitempty"`
+	Revision int64     `json:"revision"`
+	ID       int32     `json:"id"`
+	FolderID int32     `json:"folder_id,omitempty"`
+}
+
+type operation struct {
+	Item *item  `json:"item,omitempty"`
+	Kind string `json:"kind"`
+	ID   int32  `json:"id"`
+}
+
+type state struct {
+	Items    map[int32]*item `json:"items"`
+	Pending  []*operation    `json:"pending"`
+	Revision int64           `json:"revision"`
+	// LastLocalID - последний выданный временный ID. Временные ID отрицательные,
+	// чтобы не пересекаться с серверными, и заменяются серверными после синхронизации.
+	LastLocalID int32 `json:"last_local_id"`
+}
+
+// Store - локальный кеш хранилища. Методы безопасны для конкурентного использования.
+type Store struct {
+	aead  cipher.AEAD
+	state *state
+	dir   string
+	path  string
+	mu    sync.Mutex
+}
+
+// Open открывает каталог кеша, при первом запуске создаёт его и локальный ключ.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, fmt.Errorf("не удалось создать каталог кеша: %w", err)
+	}
+
+	key, err := loadOrCreateKey(filepath.Join(dir, keyFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка инициализации шифра: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка инициализации GCM: %w", err)
+	}
+
+	return &Store{aead: aead, dir: dir}, nil
+}
+
+// Use переключает кеш на пользователя login и загружает его файл.
+func (s *Store) Use(login string) error {
+	if login == "" {
+		return ErrNoUser
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := filepath.Join(s.dir, cacheFileName(login))
+	if path == s.path {
+		return nil
+	}
+
+	st, err := s.load(path)
+	if err != nil {
+		return err
+	}
+	s.path = path
+	s.state = st
+
+	return nil
+}
+
+// Revision возвращает ревизию сервера, до которой кеш синхронизирован.
+func (s *Store) Revision() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == nil {
+		return 0, ErrNoUser
+	}
+
+	return s.state.Revision, nil
+}
+
+// Get возвращает запись из кеша.
+func (s *Store) Get(id int32) (*datapb.DataItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == nil {
+		return nil, ErrNoUser
+	}
+	it, ok := s.state.Items[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return it.toProto(), nil
+}
+
+// List возвращает записи кеша типа infoType (все, если он пустой) в порядке создания.
+func (s *Store) List(infoType string) ([]*datapb.DataItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == nil {
+		return nil, ErrNoUser
+	}
+
+	items := make([]*item, 0, len(s.state.Items))
+	for _, it := range s.state.Items {
+		if infoType == "" || it.InfoType == infoType {
+			items = append(items, it)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].Created.Equal(items[j].Created) {
+			return items[i].Created.Before(items[j].Created)
+		}
+		return items[i].ID < items[j].ID
+	})
+
+	result := make([]*datapb.DataItem, 0, len(items))
+	for _, it := range items {
+		result = append(result, it.toProto())
+	}
+
+	return result, nil
+}
+
+// Put сохраняет актуальную версию записи, полученную с сервера.
+func (s *Store) Put(data *datapb.DataItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == nil {
+		return ErrNoUser
+	}
+	if s.hasPending(data.Id) {
+		return nil
+	}
+	s.state.Items[data.Id] = fromProto(data)
+
+	return s.save()
+}
+
+// Apply применяет изменения с сервера и запоминает ревизию, до которой кеш синхронизирован.
+// Записи с неотправленными локальными изменениями не перезаписываются.
+func (s *Store) Apply(changes []*datapb.DataChange, revision int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == nil {
+		return ErrNoUser
+	}
+
+	for _, change := range changes {
+		if s.hasPending(change.Id) {
+			continue
+		}
+		if change.Deleted || change.Data == nil {
+			delete(s.state.Items, change.Id)
+			continue
+		}
+		s.state.Items[change.Id] = fromProto(change.Data)
+	}
+	s.state.Revision = revision
+
+	return s.save()
+}
+
+// Enqueue применяет изменение к кешу и ставит его в очередь на отправку.
+// Для новой записи возвращает временный отрицательный ID.
+func (s *Store) Enqueue(kind string, data *datapb.DataItem, id int32) (int32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == nil {
+		return 0, ErrNoUser
+	}
+
+	switch kind {
+	case OpAdd:
+		s.state.LastLocalID--
+		id = s.state.LastLocalID
+		it := fromProto(data)
+		it.ID = id
+		it.Created = time.Now()
+		s.state.Items[id] = it
+		s.state.Pending = append(s.state.Pending, &operation{Kind: OpAdd, ID: id, Item: it})
+	case OpUpdate:
+		it := fromProto(data)
+		it.ID = id
+		if old, ok := s.state.Items[id]; ok {
+			it.Created = old.Created
+			it.Revision = old.Revision
+		}
+		s.state.Items[id] = it
+		if op := s.pendingFor(id); op != nil {
+			// Повторное изменение заменяет ещё не отправленное: на сервер уйдёт только итоговая версия.
+			op.Item = it
+		} else {
+			s.state.Pending = append(s.state.Pending, &operation{Kind: OpUpdate, ID: id, Item: it})
+		}
+	case OpDelete:
+		delete(s.state.Items, id)
+		op := s.pendingFor(id)
+		s.removePending(id)
+		if op == nil || op.Kind != OpAdd {
+			s.state.Pending = append(s.state.Pending, &operation{Kind: OpDelete, ID: id})
+		}
+	default:
+		return 0, fmt.Errorf("неизвестная операция %q", kind)
+	}
+
+	return id, s.save()
+}
+
+// Pending возвращает неотправленные изменения в порядке их совершения.
+func (s *Store) Pending() ([]Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == nil {
+		return nil, ErrNoUser
+	}
+
+	ops := make([]Operation, 0, len(s.state.Pending))
+	for _, op := range s.state.Pending {
+		converted := Operation{Kind: op.Kind, ID: op.ID}
+		if op.Item != nil {
+			converted.Item = op.Item.toProto()
+		}
+		ops = append(ops, converted)
+	}
+
+	return ops, nil
+}
+
+// Complete убирает из очереди первое изменение после его отправки на сервер.
+// Для созданной записи serverID заменяет временный ID.
+func (s *Store) Complete(serverID int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == nil {
+		return ErrNoUser
+	}
+	if len(s.state.Pending) == 0 {
+		return nil
+	}
+
+	op := s.state.Pending[0]
+	s.state.Pending = s.state.Pending[1:]
+	if op.Kind == OpAdd {
+		if it, ok := s.state.Items[op.ID]; ok {
+			delete(s.state.Items, op.ID)
+			it.ID = serverID
+			s.state.Items[serverID] = it
+		}
+	}
+
+	return s.save()
+}
+
+func (s *Store) pendingFor(id int32) *operation {
+	for _, op := range s.state.Pending {
+		if op.ID == id {
+			return op
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) hasPending(id int32) bool {
+	return s.pendingFor(id) != nil
+}
+
+func (s *Store) removePending(id int32) {
+	pending := s.state.Pending[:0]
+	for _, op := range s.state.Pending {
+		if op.ID != id {
+			pending = append(pending, op)
+		}
+	}
+	s.state.Pending = pending
+}
+
+func (s *Store) load(path string) (*state, error) {
+	st := &state{Items: make(map[int32]*item)}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return st, nil
+		}
+		return nil, fmt.Errorf("не удалось прочитать кеш: %w", err)
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(content) < nonceSize {
+		return nil, errors.New("файл кеша повреждён")
+	}
+	plaintext, err := s.aead.Open(nil, content[:nonceSize], content[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось расшифровать кеш: %w", err)
+	}
+
+	if err = json.Unmarshal(plaintext, st); err != nil {
+		return nil, fmt.Errorf("файл кеша повреждён: %w", err)
+	}
+	if st.Items == nil {
+		st.Items = make(map[int32]*item)
+	}
+
+	return st, nil
+}
+
+// save перезаписывает файл кеша атомарно: через временный файл и переименование.
+func (s *Store) save() error {
+	plaintext, err := json.Marshal(s.state)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации кеша: %w", err)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("ошибка генерации nonce: %w", err)
+	}
+	content := s.aead.Seal(nonce, nonce, plaintext, nil)
+
+	tmp, err := os.CreateTemp(s.dir, "*.tmp")
+	if err != nil {
+		return fmt.Errorf("не удалось создать временный файл кеша: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err = tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("не удалось записать кеш: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("не удалось закрыть файл кеша: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("не удалось сохранить кеш: %w", err)
+	}
+
+	return nil
+}
+
+// loadOrCreateKey читает локальный ключ или создаёт новый. Ключ не покидает устройство.
+func loadOrCreateKey(path string) ([]byte, error) {
+	key, err := os.ReadFile(path)
+	if err == nil {
+		if len(key) != keyLength {
+			return nil, fmt.Errorf("локальный ключ %s должен быть длиной %d байт", path, keyLength)
+		}
+		return key, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("не удалось прочитать локальный ключ: %w", err)
+	}
+
+	key = make([]byte, keyLength)
+	if _, err = io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("ошибка генерации локального ключа: %w", err)
+	}
+	if err = os.WriteFile(path, key, filePerm); err != nil {
+		return nil, fmt.Errorf("не удалось сохранить локальный ключ: %w", err)
+	}
+
+	return key, nil
+}
+
+// cacheFileName не использует логин в имени файла напрямую, чтобы он не попадал в файловую систему.
+func cacheFileName(login string) string {
+	sum := sha256.Sum256([]byte(login))
+	return hex.EncodeToString(sum[:8]) + cacheFileExt
+}
+
+func fromProto(data *datapb.DataItem) *item {
+	return &item{
+		ID:       data.Id,
+		InfoType: data.InfoType,
+		Info:     data.Info,
+		Meta:     data.Meta,
+		Created:  data.Created.AsTime(),
+		Revision: data.Revision,
+		FolderID: data.FolderId,
+		TagIDs:   data.TagIds,
+	}
+}
+
+func (it *item) toProto() *datapb.DataItem {
+	return &datapb.DataItem{
+		Id:       it.ID,
+		InfoType: it.InfoType,
+		Info:     it.Info,
+		Meta:     it.Meta,
+		Created:  timestamppb.New(it.Created),
+		Revision: it.Revision,
+		FolderId: it.FolderID,
+		TagIds:   it.TagIDs,
+	}
+}
@@ -0,0 +1,129 @@
+package localstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/stretchr/testify/assert"
+)
+
+func openForUser(t *testing.T, dir, login string) *Store {
+	t.Helper()
+
+	store, err := Open(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Use(login))
+
+	return store
+}
+
+func TestStore_PersistsEncrypted(t *testing.T) {
+	dir := t.TempDir()
+	store := openForUser(t, dir, "alice")
+
+	err := store.Apply([]*datapb.DataChange{
+		{Id: 1, Revision: 5, Data: &datapb.DataItem{Id: 1, InfoType: "text", Info: []byte("секрет"), Meta: "заметка"}},
+	}, 5)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, cacheFileName("alice")))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "заметка", "кеш хранится зашифрованным")
+
+	info, err := os.Stat(filepath.Join(dir, keyFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(filePerm), info.Mode().Perm())
+
+	reopened := openForUser(t, dir, "alice")
+	revision, err := reopened.Revision()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), revision)
+
+	item, err := reopened.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("секрет"), item.Info)
+
+	other := openForUser(t, dir, "bob")
+	_, err = other.Get(1)
+	assert.ErrorIs(t, err, ErrNotFound, "кеши пользователей не пересекаются")
+}
+
+func TestStore_PendingOperations(t *testing.T) {
+	store := openForUser(t, t.TempDir(), "alice")
+	assert.NoError(t, store.Apply([]*datapb.DataChange{
+		{Id: 1, Revision: 1, Data: &datapb.DataItem{Id: 1, InfoType: "text", Meta: "старое"}},
+		{Id: 2, Revision: 2, Data: &datapb.DataItem{Id: 2, InfoType: "text", Meta: "удаляемое"}},
+	}, 2))
+
+	localID, err := store.Enqueue(OpAdd, &datapb.DataItem{InfoType: "text", Meta: "новое"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(-1), localID)
+
+	_, err = store.Enqueue(OpUpdate, &datapb.DataItem{InfoType: "text", Meta: "новое, исправленное"}, localID)
+	assert.NoError(t, err)
+	_, err = store.Enqueue(OpUpdate, &datapb.DataItem{Id: 1, InfoType: "text", Meta: "изменённое"}, 1)
+	assert.NoError(t, err)
+	_, err = store.Enqueue(OpDelete, nil, 2)
+	assert.NoError(t, err)
+
+	tempID, err := store.Enqueue(OpAdd, &datapb.DataItem{InfoType: "text", Meta: "передумал"}, 0)
+	assert.NoError(t, err)
+	_, err = store.Enqueue(OpDelete, nil, tempID)
+	assert.NoError(t, err)
+
+	ops, err := store.Pending()
+	assert.NoError(t, err)
+	if assert.Len(t, ops, 3, "правки одной записи объединяются, созданная и удалённая офлайн запись не отправляется") {
+		assert.Equal(t, OpAdd, ops[0].Kind)
+		assert.Equal(t, "новое, исправленное", ops[0].Item.Meta)
+		assert.Equal(t, OpUpdate, ops[1].Kind)
+		assert.Equal(t, OpDelete, ops[2].Kind)
+		assert.Equal(t, int32(2), ops[2].ID)
+	}
+
+	items, err := store.List("text")
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	// Изменение с сервера не затирает неотправленную локальную правку.
+	assert.NoError(t, store.Apply([]*datapb.DataChange{
+		{Id: 1, Revision: 3, Data: &datapb.DataItem{Id: 1, InfoType: "text", Meta: "с сервера"}},
+	}, 3))
+	item, err := store.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "изменённое", item.Meta)
+
+	assert.NoError(t, store.Complete(10))
+	_, err = store.Get(localID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	item, err = store.Get(10)
+	assert.NoError(t, err)
+	assert.Equal(t, "новое, исправленное", item.Meta)
+
+	assert.NoError(t, store.Complete(0))
+	assert.NoError(t, store.Complete(0))
+	ops, err = store.Pending()
+	assert.NoError(t, err)
+	assert.Empty(t, ops)
+}
+
+func TestStore_NoUser(t *testing.T) {
+	store, err := Open(t.TempDir())
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, store.Use(""), ErrNoUser)
+	_, err = store.List("")
+	assert.ErrorIs(t, err, ErrNoUser)
+	_, err = store.Enqueue(OpAdd, &datapb.DataItem{}, 0)
+	assert.ErrorIs(t, err, ErrNoUser)
+}
+
+func TestOpen_RejectsBrokenKey(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, keyFileName), []byte("short"), filePerm))
+
+	_, err := Open(dir)
+	assert.Error(t, err)
+}
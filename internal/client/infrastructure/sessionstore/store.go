@@ -0,0 +1,180 @@
+// Package sessionstore сохраняет сессию клиента между запусками.
+//
+// Сессия - файл session в каталоге конфигурации, зашифрованный AES-256-GCM локальным ключом
+// (файл session.key с правами 600). В файл попадают access и refresh токены, логин и параметры KDF.
+// Ключ хранилища на диск не записывается: после запуска хранилище разблокируется мастер-паролем.
+package sessionstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+)
+
+const (
+	keyFileName     = "session.key"
+	sessionFileName = "session"
+	keyLength       = 32
+	filePerm        = 0o600
+	dirPerm         = 0o700
+)
+
+// ErrNoSession - сохранённой сессии нет, нужно войти в систему.
+var ErrNoSession = errors.New("сохранённой сессии нет")
+
+type session struct {
+	KDFParams    *entity.KDFParams `json:"kdf_params,omitempty"`
+	Token        string            `json:"token"`
+	RefreshToken string            `json:"refresh_token,omitempty"`
+	Login        string            `json:"login"`
+}
+
+// Store - файл сессии клиента.
+type Store struct {
+	aead cipher.AEAD
+	dir  string
+}
+
+// Open открывает каталог конфигурации, при первом запуске создаёт его и локальный ключ.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, fmt.Errorf("не удалось создать каталог конфигурации: %w", err)
+	}
+
+	key, err := loadOrCreateKey(filepath.Join(dir, keyFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка инициализации шифра: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка инициализации GCM: %w", err)
+	}
+
+	return &Store{aead: aead, dir: dir}, nil
+}
+
+// Load восстанавливает сохранённую сессию в tokenHolder. Хранилище остаётся заблокированным.
+func (s *Store) Load(tokenHolder *entity.TokenHolder) error {
+	content, err := os.ReadFile(s.path())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrNoSession
+		}
+		return fmt.Errorf("не удалось прочитать сессию: %w", err)
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(content) < nonceSize {
+		return errors.New("файл сессии повреждён")
+	}
+	plaintext, err := s.aead.Open(nil, content[:nonceSize], content[nonceSize:], nil)
+	if err != nil {
+		return fmt.Errorf("не удалось расшифровать сессию: %w", err)
+	}
+
+	var saved session
+	if err = json.Unmarshal(plaintext, &saved); err != nil {
+		return fmt.Errorf("файл сессии повреждён: %w", err)
+	}
+
+	tokenHolder.Token = saved.Token
+	tokenHolder.RefreshToken = saved.RefreshToken
+	tokenHolder.Login = saved.Login
+	tokenHolder.KDFParams = saved.KDFParams
+	tokenHolder.VaultKey = nil
+
+	return nil
+}
+
+// Save сохраняет сессию из tokenHolder. Если токенов нет - сессия завершена и файл удаляется.
+func (s *Store) Save(tokenHolder *entity.TokenHolder) error {
+	if tokenHolder.Token == "" && tokenHolder.RefreshToken == "" {
+		return s.Clear()
+	}
+
+	plaintext, err := json.Marshal(&session{
+		KDFParams:    tokenHolder.KDFParams,
+		Token:        tokenHolder.Token,
+		RefreshToken: tokenHolder.RefreshToken,
+		Login:        tokenHolder.Login,
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации сессии: %w", err)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("ошибка генерации nonce: %w", err)
+	}
+	content := s.aead.Seal(nonce, nonce, plaintext, nil)
+
+	tmp, err := os.CreateTemp(s.dir, "*.tmp")
+	if err != nil {
+		return fmt.Errorf("не удалось создать временный файл сессии: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err = tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("не удалось записать сессию: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("не удалось закрыть файл сессии: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), s.path()); err != nil {
+		return fmt.Errorf("не удалось сохранить сессию: %w", err)
+	}
+
+	return nil
+}
+
+// Clear удаляет сохранённую сессию.
+func (s *Store) Clear() error {
+	err := os.Remove(s.path())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("не удалось удалить сессию: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) path() string {
+	return filepath.Join(s.dir, sessionFileName)
+}
+
+// loadOrCreateKey читает локальный ключ или создаёт новый. Ключ не покидает устройство.
+func loadOrCreateKey(path string) ([]byte, error) {
+	key, err := os.ReadFile(path)
+	if err == nil {
+		if len(key) != keyLength {
+			return nil, fmt.Errorf("локальный ключ %s должен быть длиной %d байт", path, keyLength)
+		}
+		return key, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("не удалось прочитать локальный ключ: %w", err)
+	}
+
+	key = make([]byte, keyLength)
+	if _, err = io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("ошибка генерации локального ключа: %w", err)
+	}
+	if err = os.WriteFile(path, key, filePerm); err != nil {
+		return nil, fmt.Errorf("не удалось сохранить локальный ключ: %w", err)
+	}
+
+	return key, nil
+}
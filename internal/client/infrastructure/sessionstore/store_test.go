@@ -0,0 +1,75 @@
+package sessionstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open(dir)
+	assert.NoError(t, err)
+
+	kdfParams := &entity.KDFParams{Salt: []byte("salt"), Time: 3, MemoryKiB: 65536, Threads: 4}
+	err = store.Save(&entity.TokenHolder{
+		Token:        "access-token",
+		RefreshToken: "refresh-token",
+		Login:        "alice",
+		VaultKey:     []byte("vault-key-never-on-disk"),
+		KDFParams:    kdfParams,
+	})
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, sessionFileName))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "refresh-token", "сессия хранится зашифрованной")
+
+	for _, name := range []string{sessionFileName, keyFileName} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(filePerm), info.Mode().Perm())
+	}
+
+	reopened, err := Open(dir)
+	assert.NoError(t, err)
+
+	holder := &entity.TokenHolder{VaultKey: []byte("stale")}
+	assert.NoError(t, reopened.Load(holder))
+	assert.Equal(t, &entity.TokenHolder{
+		Token:        "access-token",
+		RefreshToken: "refresh-token",
+		Login:        "alice",
+		KDFParams:    kdfParams,
+	}, holder)
+	assert.True(t, holder.VaultLocked(), "ключ хранилища не сохраняется на диск")
+}
+
+func TestStore_ClearOnLogout(t *testing.T) {
+	store, err := Open(t.TempDir())
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, store.Load(&entity.TokenHolder{}), ErrNoSession)
+
+	assert.NoError(t, store.Save(&entity.TokenHolder{Token: "access-token", Login: "alice"}))
+	assert.NoError(t, store.Save(&entity.TokenHolder{}))
+	assert.ErrorIs(t, store.Load(&entity.TokenHolder{}), ErrNoSession)
+
+	assert.NoError(t, store.Clear(), "удаление отсутствующей сессии не ошибка")
+}
+
+func TestStore_WrongKey(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open(dir)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Save(&entity.TokenHolder{Token: "access-token"}))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, keyFileName), make([]byte, keyLength), filePerm))
+	reopened, err := Open(dir)
+	assert.NoError(t, err)
+
+	assert.ErrorContains(t, reopened.Load(&entity.TokenHolder{}), "не удалось расшифровать сессию")
+}
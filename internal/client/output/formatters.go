@@ -0,0 +1,118 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const listTimeLayout = "2006-01-02 15:04:05"
+
+// textFormatter - подписанный текст, как в интерактивном режиме.
+type textFormatter struct{}
+
+func (textFormatter) Record(w io.Writer, record *Record) error {
+	fmt.Fprintf(w, "ID: %d\n", record.ID)
+	fmt.Fprintf(w, "Тип: %s\n", record.Type)
+	fmt.Fprintf(w, "Мета: %s\n", record.Meta)
+	fmt.Fprintf(w, "Создано: %s\n", record.Created)
+	if record.Unknown {
+		fmt.Fprintln(w, "Неизвестный тип данных")
+	}
+	for _, field := range record.Fields {
+		fmt.Fprintf(w, "%s: %v\n", field.Label, field.Value)
+	}
+
+	return nil
+}
+
+func (textFormatter) List(w io.Writer, records []*Record) error {
+	if len(records) == 0 {
+		_, err := fmt.Fprintln(w, "Данные не найдены.")
+		return err
+	}
+
+	fmt.Fprintln(w, "Список данных:")
+	for _, record := range records {
+		fmt.Fprintf(w, "ID: %d, Тип: %s, Мета: %s, Дата создания: %s\n",
+			record.ID, record.Type, record.Meta, record.Created.Format(listTimeLayout))
+	}
+
+	return nil
+}
+
+// tableFormatter - таблица с выровненными колонками.
+type tableFormatter struct{}
+
+func (tableFormatter) Record(w io.Writer, record *Record) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ПОЛЕ\tЗНАЧЕНИЕ")
+	for _, field := range record.allFields() {
+		value := field.Value
+		if created, ok := value.(time.Time); ok {
+			value = created.Format(listTimeLayout)
+		}
+		fmt.Fprintf(tw, "%s\t%v\n", field.Label, value)
+	}
+
+	return tw.Flush()
+}
+
+func (tableFormatter) List(w io.Writer, records []*Record) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tТИП\tМЕТА\tСОЗДАНО")
+	for _, record := range records {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", record.ID, record.Type, record.Meta, record.Created.Format(listTimeLayout))
+	}
+
+	return tw.Flush()
+}
+
+// jsonFormatter - JSON с отступами: объект для записи, массив для списка.
+type jsonFormatter struct{}
+
+func (jsonFormatter) Record(w io.Writer, record *Record) error {
+	return writeJSON(w, newView(record))
+}
+
+func (jsonFormatter) List(w io.Writer, records []*Record) error {
+	return writeJSON(w, newViews(records))
+}
+
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("ошибка вывода JSON: %w", err)
+	}
+
+	return nil
+}
+
+// yamlFormatter - YAML: документ для записи, последовательность для списка.
+type yamlFormatter struct{}
+
+func (yamlFormatter) Record(w io.Writer, record *Record) error {
+	return writeYAML(w, newView(record))
+}
+
+func (yamlFormatter) List(w io.Writer, records []*Record) error {
+	return writeYAML(w, newViews(records))
+}
+
+func writeYAML(w io.Writer, value any) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("ошибка вывода YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("ошибка вывода YAML: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,265 @@
+// Package output выводит записи хранилища в одном из форматов: text - подписанный текст для человека,
+// table - выровненная таблица, json и yaml - для разбора другими программами.
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+)
+
+// Форматы вывода.
+const (
+	FormatText  = "text"
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+)
+
+// HiddenValue выводится вместо значения чувствительного поля.
+const HiddenValue = "********"
+
+// ErrUnknownFormat - запрошен формат, для которого нет форматтера.
+var ErrUnknownFormat = errors.New("неизвестный формат вывода")
+
+// Formatter выводит одну запись или список записей.
+type Formatter interface {
+	Record(w io.Writer, record *Record) error
+	List(w io.Writer, records []*Record) error
+}
+
+// New возвращает форматтер по имени формата.
+func New(format string) (Formatter, error) {
+	switch format {
+	case FormatText:
+		return textFormatter{}, nil
+	case FormatTable:
+		return tableFormatter{}, nil
+	case FormatJSON:
+		return jsonFormatter{}, nil
+	case FormatYAML:
+		return yamlFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s, доступны: text, table, json, yaml", ErrUnknownFormat, format)
+	}
+}
+
+// Field - поле содержимого записи. Name - ключ в json и yaml, Label - подпись в text и table.
+type Field struct {
+	Value any
+	Name  string
+	Label string
+}
+
+// Record - запись хранилища в виде для вывода. Fields пуст в списках: содержимое записей туда не попадает.
+type Record struct {
+	Created time.Time
+	Type    string
+	Meta    string
+	Fields  []Field
+	ID      int32
+	// Unknown - тип записи клиенту не известен, поля разобраны из Info как есть.
+	Unknown bool
+}
+
+// NewSummary возвращает запись без содержимого, для списков.
+func NewSummary(item *datapb.DataItem) *Record {
+	return &Record{
+		ID:      item.Id,
+		Type:    item.InfoType,
+		Meta:    item.Meta,
+		Created: item.Created.AsTime(),
+	}
+}
+
+// NewRecord возвращает запись вместе с полями содержимого, разобранными по её типу. Содержимое берётся
+// из payload, а если его нет, - из Info. Info пользовательского типа, которое не разбирается как значения
+// полей, выводится как есть.
+func NewRecord(item *datapb.DataItem) (*Record, error) {
+	record := NewSummary(item)
+
+	message, err := payload.Decode(item)
+	if err != nil && payload.IsBuiltin(item.InfoType) {
+		return nil, fmt.Errorf("ошибка десериализации данных: %w", err)
+	}
+
+	switch data := message.(type) {
+	case *datapb.LoginPassword:
+		record.Fields = []Field{
+			{Name: "login", Label: "Логин", Value: data.Login},
+			{Name: "password", Label: "Пароль", Value: data.Password},
+			{Name: "url", Label: "URL", Value: data.Url},
+		}
+		if data.TotpId != 0 {
+			record.Fields = append(record.Fields, Field{Name: "totp_id", Label: "ID записи TOTP", Value: data.TotpId})
+		}
+	case *datapb.Text:
+		record.Fields = []Field{{Name: "text", Label: "Текст", Value: data.Text}}
+	case *datapb.Binary:
+		record.Fields = []Field{{Name: "file_name", Label: "Имя файла", Value: data.FileName}}
+		if data.Size > 0 {
+			record.Fields = append(record.Fields, Field{Name: "size", Label: "Размер", Value: data.Size})
+		}
+		if data.Sha256 != "" {
+			record.Fields = append(record.Fields, Field{Name: "sha256", Label: "SHA-256", Value: data.Sha256})
+		}
+	case *datapb.BankCard:
+		record.Fields = []Field{
+			{Name: "card_number", Label: "Номер карты", Value: data.CardNumber},
+			{Name: "expiry_date", Label: "Срок действия", Value: data.ExpiryDate},
+			{Name: "cvv", Label: "CVV", Value: data.Cvv},
+			{Name: "holder_name", Label: "Имя держателя", Value: data.HolderName},
+		}
+	case *datapb.Totp:
+		params := payload.TOTPParams(data)
+		record.Fields = []Field{
+			{Name: "issuer", Label: "Издатель", Value: params.Issuer},
+			{Name: "account", Label: "Аккаунт", Value: params.Account},
+			{Name: "secret", Label: "Секрет", Value: params.Secret},
+			{Name: "algorithm", Label: "Алгоритм", Value: params.Algorithm},
+			{Name: "digits", Label: "Цифр в коде", Value: params.Digits},
+			{Name: "period", Label: "Период, с", Value: params.Period},
+		}
+	case *datapb.Custom:
+		// Схема типа записи здесь не известна: поля идут по алфавиту, подписи - имена полей.
+		record.Unknown = true
+		names := make([]string, 0, len(data.Fields))
+		for name := range data.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			record.Fields = append(record.Fields, Field{Name: name, Label: name, Value: data.Fields[name]})
+		}
+	default:
+		record.Unknown = true
+		if record.Fields, err = rawFields(item.Info); err != nil {
+			return nil, fmt.Errorf("ошибка десериализации данных: %w", err)
+		}
+	}
+
+	return record, nil
+}
+
+// ApplySchema упорядочивает и подписывает поля записи пользовательского типа по схеме recordType.
+// Значения чувствительных полей скрываются, если reveal не задан. Поля, которых нет в схеме, идут в конце.
+func (r *Record) ApplySchema(recordType *datapb.RecordType, reveal bool) {
+	values := make(map[string]any, len(r.Fields))
+	for _, field := range r.Fields {
+		values[field.Name] = field.Value
+	}
+
+	fields := make([]Field, 0, len(r.Fields))
+	for _, schemaField := range recordType.Fields {
+		value, ok := values[schemaField.Name]
+		if !ok {
+			value = ""
+		}
+		delete(values, schemaField.Name)
+
+		label := schemaField.Label
+		if label == "" {
+			label = schemaField.Name
+		}
+		if schemaField.Sensitive && !reveal && value != "" {
+			value = HiddenValue
+		}
+		fields = append(fields, Field{Name: schemaField.Name, Label: label, Value: value})
+	}
+	for _, field := range r.Fields {
+		if _, ok := values[field.Name]; ok {
+			fields = append(fields, field)
+		}
+	}
+
+	r.Fields = fields
+	r.Unknown = false
+}
+
+// Lookup возвращает значение поля записи по имени, включая id, type, meta и created.
+func (r *Record) Lookup(name string) (any, bool) {
+	for _, field := range r.allFields() {
+		if field.Name == name {
+			return field.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Names возвращает имена всех полей записи, которые можно передать в Lookup.
+func (r *Record) Names() []string {
+	fields := r.allFields()
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+
+	return names
+}
+
+func (r *Record) allFields() []Field {
+	return append([]Field{
+		{Name: "id", Label: "ID", Value: r.ID},
+		{Name: "type", Label: "Тип", Value: r.Type},
+		{Name: "meta", Label: "Мета", Value: r.Meta},
+		{Name: "created", Label: "Создано", Value: r.Created},
+	}, r.Fields...)
+}
+
+// rawFields разбирает Info записи неизвестного типа как JSON-объект, поля идут по алфавиту.
+func rawFields(info []byte) ([]Field, error) {
+	values := make(map[string]any)
+	if err := json.Unmarshal(info, &values); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]Field, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, Field{Name: name, Label: name, Value: values[name]})
+	}
+
+	return fields, nil
+}
+
+// view - запись в json и yaml.
+type view struct {
+	Created time.Time      `json:"created" yaml:"created"`
+	Data    map[string]any `json:"data,omitempty" yaml:"data,omitempty"`
+	Type    string         `json:"type" yaml:"type"`
+	Meta    string         `json:"meta" yaml:"meta"`
+	ID      int32          `json:"id" yaml:"id"`
+}
+
+func newView(record *Record) *view {
+	v := &view{ID: record.ID, Type: record.Type, Meta: record.Meta, Created: record.Created}
+	if len(record.Fields) > 0 {
+		v.Data = make(map[string]any, len(record.Fields))
+		for _, field := range record.Fields {
+			v.Data[field.Name] = field.Value
+		}
+	}
+
+	return v
+}
+
+func newViews(records []*Record) []*view {
+	views := make([]*view, 0, len(records))
+	for _, record := range records {
+		views = append(views, newView(record))
+	}
+
+	return views
+}
@@ -0,0 +1,274 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var created = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+// newItem возвращает запись с содержимым info: в payload, если это сообщение содержимого, иначе в Info как JSON.
+func newItem(t *testing.T, infoType string, info any) *datapb.DataItem {
+	t.Helper()
+
+	item := &datapb.DataItem{
+		Id:       42,
+		InfoType: infoType,
+		Meta:     "почта",
+		Created:  timestamppb.New(created),
+	}
+	if message, ok := info.(proto.Message); ok {
+		assert.NoError(t, payload.Set(item, message))
+		return item
+	}
+
+	infoBytes, err := json.Marshal(info)
+	assert.NoError(t, err)
+	item.Info = infoBytes
+
+	return item
+}
+
+func TestNewRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		item   *datapb.DataItem
+		fields map[string]any
+	}{
+		{
+			name:   "Логин и пароль",
+			item:   newItem(t, "login_password", &datapb.LoginPassword{Login: "user", Password: "secret", Url: "https://a.b"}),
+			fields: map[string]any{"login": "user", "password": "secret", "url": "https://a.b"},
+		},
+		{
+			name:   "Прежний формат Info",
+			item:   newItem(t, "login_password", map[string]any{"Login": "user", "Password": "secret", "URL": "https://a.b"}),
+			fields: map[string]any{"login": "user", "password": "secret", "url": "https://a.b"},
+		},
+		{
+			name:   "Текст",
+			item:   newItem(t, "text", &datapb.Text{Text: "заметка"}),
+			fields: map[string]any{"text": "заметка"},
+		},
+		{
+			name:   "Файл",
+			item:   newItem(t, "binary", &datapb.Binary{FileName: "a.bin", Size: 10, Sha256: "ff", Chunked: true}),
+			fields: map[string]any{"file_name": "a.bin", "size": int64(10), "sha256": "ff"},
+		},
+		{
+			name: "Банковская карта",
+			item: newItem(t, "bank_card", &datapb.BankCard{
+				CardNumber: "4111", ExpiryDate: "12/30", Cvv: "123", HolderName: "IVAN",
+			}),
+			fields: map[string]any{"card_number": "4111", "expiry_date": "12/30", "cvv": "123", "holder_name": "IVAN"},
+		},
+		{
+			name:   "Неизвестный тип",
+			item:   newItem(t, "custom", map[string]any{"b": "2", "a": "1"}),
+			fields: map[string]any{"a": "1", "b": "2"},
+		},
+		{
+			name: "Логин со ссылкой на TOTP",
+			item: newItem(t, "login_password", &datapb.LoginPassword{Login: "user", TotpId: 7}),
+			fields: map[string]any{
+				"login": "user", "password": "", "url": "", "totp_id": int32(7),
+			},
+		},
+		{
+			name: "TOTP",
+			item: newItem(t, "totp", &datapb.Totp{Secret: "JBSWY3DPEHPK3PXP", Issuer: "GitHub", Account: "alice"}),
+			fields: map[string]any{
+				"issuer": "GitHub", "account": "alice", "secret": "JBSWY3DPEHPK3PXP",
+				"algorithm": "SHA1", "digits": 6, "period": 30,
+			},
+		},
+		{
+			name:   "Пользовательский тип в Info не из строк",
+			item:   newItem(t, "custom", map[string]any{"n": 1.5}),
+			fields: map[string]any{"n": 1.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, err := NewRecord(tt.item)
+			assert.NoError(t, err)
+
+			assert.Equal(t, int32(42), record.ID)
+			assert.Equal(t, created, record.Created)
+			assert.Len(t, record.Fields, len(tt.fields))
+			for name, value := range tt.fields {
+				got, ok := record.Lookup(name)
+				assert.True(t, ok, name)
+				assert.Equal(t, value, got, name)
+			}
+		})
+	}
+
+	_, err := NewRecord(&datapb.DataItem{InfoType: "text", Info: []byte("не json")})
+	assert.ErrorContains(t, err, "ошибка десериализации данных")
+}
+
+func TestRecord_ApplySchema(t *testing.T) {
+	item := newItem(t, "wifi", &datapb.Custom{Fields: map[string]string{
+		"password": "secret",
+		"ssid":     "home",
+		"extra":    "x",
+	}})
+	wifi := &datapb.RecordType{Name: "wifi", Fields: []*datapb.RecordField{
+		{Name: "ssid", Label: "Сеть"},
+		{Name: "password", Sensitive: true},
+		{Name: "expires", Type: datapb.RecordField_DATE},
+	}}
+
+	record, err := NewRecord(item)
+	assert.NoError(t, err)
+	assert.True(t, record.Unknown)
+
+	record.ApplySchema(wifi, false)
+	assert.False(t, record.Unknown)
+	assert.Equal(t, []Field{
+		{Name: "ssid", Label: "Сеть", Value: "home"},
+		{Name: "password", Label: "password", Value: HiddenValue},
+		{Name: "expires", Label: "expires", Value: ""},
+		{Name: "extra", Label: "extra", Value: "x"},
+	}, record.Fields)
+
+	record, _ = NewRecord(item)
+	record.ApplySchema(wifi, true)
+	value, _ := record.Lookup("password")
+	assert.Equal(t, "secret", value)
+}
+
+func TestFormatters_Record(t *testing.T) {
+	item := newItem(t, "login_password", &datapb.LoginPassword{Login: "user", Password: "secret"})
+	record, err := NewRecord(item)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: FormatText,
+			expected: "ID: 42\nТип: login_password\nМета: почта\nСоздано: 2024-05-01 10:00:00 +0000 UTC\n" +
+				"Логин: user\nПароль: secret\nURL: \n",
+		},
+		{
+			format: FormatTable,
+			expected: "ПОЛЕ     ЗНАЧЕНИЕ\nID       42\nТип      login_password\nМета     почта\n" +
+				"Создано  2024-05-01 10:00:00\nЛогин    user\nПароль   secret\nURL      \n",
+		},
+		{
+			format: FormatJSON,
+			expected: `{
+  "created": "2024-05-01T10:00:00Z",
+  "data": {
+    "login": "user",
+    "password": "secret",
+    "url": ""
+  },
+  "type": "login_password",
+  "meta": "почта",
+  "id": 42
+}
+`,
+		},
+		{
+			format: FormatYAML,
+			expected: `created: 2024-05-01T10:00:00Z
+data:
+  login: user
+  password: secret
+  url: ""
+type: login_password
+meta: почта
+id: 42
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			formatter, err := New(tt.format)
+			assert.NoError(t, err)
+
+			buf := &bytes.Buffer{}
+			assert.NoError(t, formatter.Record(buf, record))
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestFormatters_List(t *testing.T) {
+	records := []*Record{
+		NewSummary(&datapb.DataItem{Id: 1, InfoType: "text", Meta: "a", Created: timestamppb.New(created)}),
+		NewSummary(&datapb.DataItem{Id: 2, InfoType: "bank_card", Meta: "b", Created: timestamppb.New(created)}),
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: FormatText,
+			expected: "Список данных:\nID: 1, Тип: text, Мета: a, Дата создания: 2024-05-01 10:00:00\n" +
+				"ID: 2, Тип: bank_card, Мета: b, Дата создания: 2024-05-01 10:00:00\n",
+		},
+		{
+			format: FormatTable,
+			expected: "ID  ТИП        МЕТА  СОЗДАНО\n1   text       a     2024-05-01 10:00:00\n" +
+				"2   bank_card  b     2024-05-01 10:00:00\n",
+		},
+		{
+			format: FormatJSON,
+			expected: `[{"created":"2024-05-01T10:00:00Z","type":"text","meta":"a","id":1},` +
+				`{"created":"2024-05-01T10:00:00Z","type":"bank_card","meta":"b","id":2}]`,
+		},
+		{
+			format: FormatYAML,
+			expected: "- created: 2024-05-01T10:00:00Z\n  type: text\n  meta: a\n  id: 1\n" +
+				"- created: 2024-05-01T10:00:00Z\n  type: bank_card\n  meta: b\n  id: 2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			formatter, err := New(tt.format)
+			assert.NoError(t, err)
+
+			buf := &bytes.Buffer{}
+			assert.NoError(t, formatter.List(buf, records))
+			if tt.format == FormatJSON {
+				assert.JSONEq(t, tt.expected, buf.String())
+				return
+			}
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestFormatters_EmptyList(t *testing.T) {
+	buf := &bytes.Buffer{}
+	formatter, _ := New(FormatText)
+	assert.NoError(t, formatter.List(buf, nil))
+	assert.Equal(t, "Данные не найдены.\n", buf.String())
+
+	buf.Reset()
+	formatter, _ = New(FormatJSON)
+	assert.NoError(t, formatter.List(buf, nil))
+	assert.Equal(t, "[]\n", buf.String(), "пустой список в JSON - массив, а не null")
+}
+
+func TestNew_UnknownFormat(t *testing.T) {
+	_, err := New("xml")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/NikolosHGW/goph-keeper/api/authpb"
 	"github.com/NikolosHGW/goph-keeper/api/registerpb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
 	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"google.golang.org/grpc/metadata"
 )
 
 type authService struct {
@@ -23,27 +25,157 @@ func NewAuthService(grpcClient *GRPCClient, logger logger.CustomLogger) *authSer
 	}
 }
 
-func (s *authService) Register(ctx context.Context, login, password string) (string, error) {
+func (s *authService) Register(
+	ctx context.Context,
+	login, password string,
+	kdfParams *entity.KDFParams,
+) (*entity.AuthTokens, error) {
 	req := &registerpb.RegisterUserRequest{
 		Login:    login,
 		Password: password,
 	}
+	if kdfParams != nil {
+		req.KdfParams = &registerpb.KdfParams{
+			Salt:      kdfParams.Salt,
+			Time:      kdfParams.Time,
+			MemoryKib: kdfParams.MemoryKiB,
+			Threads:   kdfParams.Threads,
+			KeyCheck:  kdfParams.KeyCheck,
+		}
+	}
 	resp, err := s.registerClient.RegisterUser(ctx, req)
 	if err != nil {
 		s.logger.LogInfo("Ошибка регистрации", err)
-		return "", fmt.Errorf("ошибка при регистрации: %w", err)
+		return nil, fmt.Errorf("ошибка при регистрации: %w", err)
 	}
-	return resp.BearerToken, nil
+	return &entity.AuthTokens{Token: resp.BearerToken, RefreshToken: resp.RefreshToken}, nil
 }
 
-func (s *authService) Login(ctx context.Context, login, password string) (string, error) {
+func (s *authService) Login(ctx context.Context, login, password string) (*entity.LoginResult, error) {
 	req := &authpb.LoginUserRequest{
 		Login:    login,
 		Password: password,
 	}
 	res, err := s.authClient.LoginUser(ctx, req)
 	if err != nil {
-		return "", fmt.Errorf("ошибка при логине: %w", err)
+		return nil, fmt.Errorf("ошибка при логине: %w", err)
+	}
+
+	return loginResult(res), nil
+}
+
+// VerifyLoginCode завершает вход кодом второго фактора или кодом восстановления.
+func (s *authService) VerifyLoginCode(ctx context.Context, challengeID, code string) (*entity.LoginResult, error) {
+	res, err := s.authClient.VerifyLoginCode(ctx, &authpb.VerifyLoginCodeRequest{ChallengeId: challengeID, Code: code})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при проверке кода: %w", err)
+	}
+
+	return loginResult(res), nil
+}
+
+func loginResult(res *authpb.LoginUserResponse) *entity.LoginResult {
+	result := &entity.LoginResult{
+		Token:        res.BearerToken,
+		RefreshToken: res.RefreshToken,
+		ChallengeID:  res.ChallengeId,
+	}
+	if kdf := res.GetKdfParams(); kdf != nil {
+		result.KDFParams = &entity.KDFParams{
+			Salt:      kdf.Salt,
+			Time:      kdf.Time,
+			MemoryKiB: kdf.MemoryKib,
+			Threads:   kdf.Threads,
+			KeyCheck:  kdf.KeyCheck,
+		}
+	}
+
+	return result
+}
+
+func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (*entity.AuthTokens, error) {
+	res, err := s.authClient.RefreshToken(ctx, &authpb.RefreshTokenRequest{RefreshToken: refreshToken})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при обновлении токена: %w", err)
+	}
+
+	return &entity.AuthTokens{Token: res.BearerToken, RefreshToken: res.RefreshToken}, nil
+}
+
+func (s *authService) Logout(ctx context.Context, token string) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+
+	_, err := s.authClient.Logout(ctx, &authpb.LogoutRequest{})
+	if err != nil {
+		return fmt.Errorf("ошибка при выходе: %w", err)
+	}
+
+	return nil
+}
+
+func (s *authService) ListSessions(ctx context.Context, token string) ([]*entity.Session, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+
+	res, err := s.authClient.ListSessions(ctx, &authpb.ListSessionsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении сессий: %w", err)
 	}
-	return res.BearerToken, nil
+
+	sessions := make([]*entity.Session, 0, len(res.Sessions))
+	for _, session := range res.Sessions {
+		sessions = append(sessions, &entity.Session{
+			ID:         session.Id,
+			IP:         session.Ip,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt.AsTime(),
+			LastUsedAt: session.LastUsedAt.AsTime(),
+			Current:    session.Current,
+		})
+	}
+
+	return sessions, nil
+}
+
+func (s *authService) RevokeSession(ctx context.Context, token, sessionID string) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+
+	_, err := s.authClient.RevokeSession(ctx, &authpb.RevokeSessionRequest{SessionId: sessionID})
+	if err != nil {
+		return fmt.Errorf("ошибка при завершении сессии: %w", err)
+	}
+
+	return nil
+}
+
+func (s *authService) EnrollTOTP(ctx context.Context, token string) (*entity.TOTPEnrollment, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+
+	res, err := s.authClient.EnrollTOTP(ctx, &authpb.EnrollTOTPRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при выпуске секрета: %w", err)
+	}
+
+	return &entity.TOTPEnrollment{Secret: res.Secret, URI: res.OtpauthUri}, nil
+}
+
+func (s *authService) ConfirmTOTP(ctx context.Context, token, code string) ([]string, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+
+	res, err := s.authClient.ConfirmTOTP(ctx, &authpb.ConfirmTOTPRequest{Code: code})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при включении второго фактора: %w", err)
+	}
+
+	return res.RecoveryCodes, nil
+}
+
+func (s *authService) DisableTOTP(ctx context.Context, token, code string) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+
+	_, err := s.authClient.DisableTOTP(ctx, &authpb.DisableTOTPRequest{Code: code})
+	if err != nil {
+		return fmt.Errorf("ошибка при отключении второго фактора: %w", err)
+	}
+
+	return nil
 }
@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/NikolosHGW/goph-keeper/api/authpb"
 	"github.com/NikolosHGW/goph-keeper/api/registerpb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type MockRegisterClient struct {
@@ -129,7 +133,11 @@ func TestAuthService_Register(t *testing.T) {
 
 			authSvc := NewAuthService(mockGRPCClient, noOpLogger)
 
-			token, err := authSvc.Register(context.Background(), tt.login, tt.password)
+			tokens, err := authSvc.Register(context.Background(), tt.login, tt.password, nil)
+			token := ""
+			if tokens != nil {
+				token = tokens.Token
+			}
 
 			assert.Equal(t, tt.expectedToken, token)
 
@@ -158,6 +166,78 @@ func (m *MockAuthClient) LoginUser(
 	return resp, args.Error(1)
 }
 
+func (m *MockAuthClient) RefreshToken(
+	ctx context.Context, req *authpb.RefreshTokenRequest, opts ...grpc.CallOption,
+) (*authpb.RefreshTokenResponse, error) {
+	args := m.Called(ctx, req, opts)
+	resp, _ := args.Get(0).(*authpb.RefreshTokenResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockAuthClient) Logout(
+	ctx context.Context, req *authpb.LogoutRequest, opts ...grpc.CallOption,
+) (*authpb.LogoutResponse, error) {
+	args := m.Called(ctx, req, opts)
+	resp, _ := args.Get(0).(*authpb.LogoutResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockAuthClient) ListSessions(
+	ctx context.Context, req *authpb.ListSessionsRequest, opts ...grpc.CallOption,
+) (*authpb.ListSessionsResponse, error) {
+	args := m.Called(ctx, req, opts)
+	resp, _ := args.Get(0).(*authpb.ListSessionsResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockAuthClient) RevokeSession(
+	ctx context.Context, req *authpb.RevokeSessionRequest, opts ...grpc.CallOption,
+) (*authpb.RevokeSessionResponse, error) {
+	args := m.Called(ctx, req, opts)
+	resp, _ := args.Get(0).(*authpb.RevokeSessionResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockAuthClient) GetJWKS(
+	ctx context.Context, req *authpb.GetJWKSRequest, opts ...grpc.CallOption,
+) (*authpb.GetJWKSResponse, error) {
+	args := m.Called(ctx, req, opts)
+	resp, _ := args.Get(0).(*authpb.GetJWKSResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockAuthClient) VerifyLoginCode(
+	ctx context.Context, req *authpb.VerifyLoginCodeRequest, opts ...grpc.CallOption,
+) (*authpb.LoginUserResponse, error) {
+	args := m.Called(ctx, req, opts)
+	resp, _ := args.Get(0).(*authpb.LoginUserResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockAuthClient) EnrollTOTP(
+	ctx context.Context, req *authpb.EnrollTOTPRequest, opts ...grpc.CallOption,
+) (*authpb.EnrollTOTPResponse, error) {
+	args := m.Called(ctx, req, opts)
+	resp, _ := args.Get(0).(*authpb.EnrollTOTPResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockAuthClient) ConfirmTOTP(
+	ctx context.Context, req *authpb.ConfirmTOTPRequest, opts ...grpc.CallOption,
+) (*authpb.ConfirmTOTPResponse, error) {
+	args := m.Called(ctx, req, opts)
+	resp, _ := args.Get(0).(*authpb.ConfirmTOTPResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockAuthClient) DisableTOTP(
+	ctx context.Context, req *authpb.DisableTOTPRequest, opts ...grpc.CallOption,
+) (*authpb.DisableTOTPResponse, error) {
+	args := m.Called(ctx, req, opts)
+	resp, _ := args.Get(0).(*authpb.DisableTOTPResponse)
+	return resp, args.Error(1)
+}
+
 func TestAuthService_Login(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -269,7 +349,11 @@ func TestAuthService_Login(t *testing.T) {
 			}
 
 			// Выполнение метода Login
-			token, err := authSvc.Login(context.Background(), tt.login, tt.password)
+			result, err := authSvc.Login(context.Background(), tt.login, tt.password)
+			token := ""
+			if result != nil {
+				token = result.Token
+			}
 
 			// Проверка результатов
 			assert.Equal(t, tt.expectedToken, token)
@@ -286,3 +370,100 @@ func TestAuthService_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthService_Sessions(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	tokenInContext := mock.MatchedBy(func(ctx context.Context) bool {
+		md, ok := metadata.FromOutgoingContext(ctx)
+		return ok && len(md.Get("authorization")) == 1 && md.Get("authorization")[0] == "access"
+	})
+
+	mockAuthClient := new(MockAuthClient)
+	mockAuthClient.On("RefreshToken", mock.Anything, &authpb.RefreshTokenRequest{RefreshToken: "old"}, mock.Anything).
+		Return(&authpb.RefreshTokenResponse{BearerToken: "access2", RefreshToken: "new"}, nil)
+	mockAuthClient.On("ListSessions", tokenInContext, &authpb.ListSessionsRequest{}, mock.Anything).
+		Return(&authpb.ListSessionsResponse{Sessions: []*authpb.Session{{
+			Id:         "sid",
+			Ip:         "10.0.0.1",
+			UserAgent:  "grpc-go",
+			CreatedAt:  timestamppb.New(created),
+			LastUsedAt: timestamppb.New(created),
+			Current:    true,
+		}}}, nil)
+	mockAuthClient.On("RevokeSession", tokenInContext, &authpb.RevokeSessionRequest{SessionId: "sid"}, mock.Anything).
+		Return(nil, errors.New("not found"))
+	mockAuthClient.On("Logout", tokenInContext, &authpb.LogoutRequest{}, mock.Anything).
+		Return(&authpb.LogoutResponse{}, nil)
+
+	authSvc := NewAuthService(&GRPCClient{AuthClient: mockAuthClient}, &mockLogger{})
+
+	tokens, err := authSvc.RefreshToken(context.Background(), "old")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.AuthTokens{Token: "access2", RefreshToken: "new"}, tokens)
+
+	sessions, err := authSvc.ListSessions(context.Background(), "access")
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.Session{{
+		ID:         "sid",
+		IP:         "10.0.0.1",
+		UserAgent:  "grpc-go",
+		CreatedAt:  created,
+		LastUsedAt: created,
+		Current:    true,
+	}}, sessions)
+
+	err = authSvc.RevokeSession(context.Background(), "access", "sid")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ошибка при завершении сессии")
+
+	assert.NoError(t, authSvc.Logout(context.Background(), "access"))
+
+	mockAuthClient.AssertExpectations(t)
+}
+
+func TestAuthService_TwoFactor(t *testing.T) {
+	tokenInContext := mock.MatchedBy(func(ctx context.Context) bool {
+		md, ok := metadata.FromOutgoingContext(ctx)
+		return ok && len(md.Get("authorization")) == 1 && md.Get("authorization")[0] == "access"
+	})
+
+	mockAuthClient := new(MockAuthClient)
+	mockAuthClient.On("LoginUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(&authpb.LoginUserResponse{ChallengeId: "challenge"}, nil)
+	mockAuthClient.On(
+		"VerifyLoginCode",
+		mock.Anything,
+		&authpb.VerifyLoginCodeRequest{ChallengeId: "challenge", Code: "123456"},
+		mock.Anything,
+	).Return(&authpb.LoginUserResponse{BearerToken: "access", RefreshToken: "refresh"}, nil)
+	mockAuthClient.On("EnrollTOTP", tokenInContext, &authpb.EnrollTOTPRequest{}, mock.Anything).
+		Return(&authpb.EnrollTOTPResponse{Secret: "SECRET", OtpauthUri: "otpauth://totp/x"}, nil)
+	mockAuthClient.On("ConfirmTOTP", tokenInContext, &authpb.ConfirmTOTPRequest{Code: "654321"}, mock.Anything).
+		Return(&authpb.ConfirmTOTPResponse{RecoveryCodes: []string{"AAAA-BBBB"}}, nil)
+	mockAuthClient.On("DisableTOTP", tokenInContext, &authpb.DisableTOTPRequest{Code: "000000"}, mock.Anything).
+		Return(nil, errors.New("invalid code"))
+
+	authSvc := NewAuthService(&GRPCClient{AuthClient: mockAuthClient}, &mockLogger{})
+
+	result, err := authSvc.Login(context.Background(), "user", "password")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.LoginResult{ChallengeID: "challenge"}, result)
+
+	result, err = authSvc.VerifyLoginCode(context.Background(), "challenge", "123456")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.LoginResult{Token: "access", RefreshToken: "refresh"}, result)
+
+	enrollment, err := authSvc.EnrollTOTP(context.Background(), "access")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.TOTPEnrollment{Secret: "SECRET", URI: "otpauth://totp/x"}, enrollment)
+
+	recoveryCodes, err := authSvc.ConfirmTOTP(context.Background(), "access", "654321")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"AAAA-BBBB"}, recoveryCodes)
+
+	err = authSvc.DisableTOTP(context.Background(), "access", "000000")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ошибка при отключении второго фактора")
+
+	mockAuthClient.AssertExpectations(t)
+}
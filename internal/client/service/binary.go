@@ -0,0 +1,295 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// binaryChunkSize - размер части файла до шифрования. Зашифрованная часть укладывается
+	// в ограничение сервера на размер части.
+	binaryChunkSize  = 1 << 20
+	transferAttempts = 5
+)
+
+// ErrChecksumMismatch - содержимое файла не совпало с контрольной суммой.
+var ErrChecksumMismatch = errors.New("контрольная сумма файла не совпала")
+
+type binaryService struct {
+	client      datapb.BinaryServiceClient
+	tokenHolder *entity.TokenHolder
+	// backoff - пауза перед повтором после обрыва связи, растёт с каждой попыткой.
+	backoff time.Duration
+}
+
+// NewBinaryService - конструктор сервиса передачи файлов по частям. Если задан ключ хранилища,
+// каждая часть шифруется им отдельно, поэтому сервер не видит содержимое файла.
+func NewBinaryService(grpcClient *GRPCClient, tokenHolder *entity.TokenHolder) *binaryService {
+	return &binaryService{
+		client:      grpcClient.BinaryClient,
+		tokenHolder: tokenHolder,
+		backoff:     time.Second,
+	}
+}
+
+// Upload загружает файл filePath по частям. При replaceID не 0 файл заменяет содержимое
+// этой записи, иначе создаётся новая запись. Возвращает ID записи.
+// После обрыва связи загрузка продолжается с первой не принятой сервером части.
+func (s *binaryService) Upload(
+	ctx context.Context,
+	token, filePath, meta string,
+	replaceID int32,
+) (int32, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("не удалось открыть файл: %w", err)
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, file)
+	if err != nil {
+		return 0, fmt.Errorf("не удалось прочитать файл: %w", err)
+	}
+
+	info := &datapb.Binary{
+		FileName: filepath.Base(filePath),
+		Size:     size,
+		Sha256:   hex.EncodeToString(hasher.Sum(nil)),
+		Chunked:  true,
+	}
+
+	key, err := vaultKey(s.tokenHolder)
+	if err != nil {
+		return 0, err
+	}
+	item, err := encryptItem(key, &datapb.DataItem{
+		InfoType: payload.BinaryType,
+		Meta:     meta,
+		Payload:  &datapb.DataItem_Binary{Binary: info},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if item, err = toWire(item); err != nil {
+		return 0, err
+	}
+
+	chunkCount := max(1, int32((size+binaryChunkSize-1)/binaryChunkSize))
+	started, err := s.client.StartUpload(ctx, &datapb.StartUploadRequest{
+		ChunkCount: chunkCount,
+		Data:       item,
+		ReplaceId:  replaceID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	next := started.NextChunk
+	for attempt := 1; ; attempt++ {
+		id, err := s.sendChunks(ctx, file, started.UploadId, next, chunkCount)
+		if err == nil {
+			return id, nil
+		}
+		if !isOffline(err) || attempt == transferAttempts {
+			return 0, fmt.Errorf("загрузка %s прервана: %w", started.UploadId, err)
+		}
+
+		if err = s.wait(ctx, attempt); err != nil {
+			return 0, err
+		}
+
+		resumed, err := s.client.StartUpload(ctx, &datapb.StartUploadRequest{UploadId: started.UploadId})
+		if err != nil && !isOffline(err) {
+			return 0, err
+		}
+		if err == nil {
+			next = resumed.NextChunk
+		}
+	}
+}
+
+func (s *binaryService) sendChunks(
+	ctx context.Context,
+	file io.ReaderAt,
+	uploadID string,
+	from, chunkCount int32,
+) (int32, error) {
+	stream, err := s.client.UploadBinary(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Если сервер уже принял все части, но запись не создал, последняя часть отправляется повторно:
+	// сервер пропустит её и завершит загрузку.
+	from = min(from, chunkCount-1)
+
+	buf := make([]byte, binaryChunkSize)
+	for seq := from; seq < chunkCount; seq++ {
+		n, err := file.ReadAt(buf, int64(seq)*binaryChunkSize)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("не удалось прочитать файл: %w", err)
+		}
+
+		data, err := s.encryptChunk(buf[:n])
+		if err != nil {
+			return 0, err
+		}
+
+		sum := sha256.Sum256(data)
+		err = stream.Send(&datapb.BinaryChunk{UploadId: uploadID, Seq: seq, Data: data, Sha256: sum[:]})
+		if err != nil {
+			// Причину, по которой сервер закрыл поток, возвращает CloseAndRecv.
+			break
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, err
+	}
+	if resp.Id == 0 {
+		return 0, fmt.Errorf("сервер принял %d из %d частей", resp.NextChunk, chunkCount)
+	}
+
+	return resp.Id, nil
+}
+
+// Download сохраняет содержимое файла записи id в filePath и сверяет его с SHA-256 из info.
+// После обрыва связи скачивание продолжается со следующей части. Если файл не совпал
+// с контрольной суммой, он удаляется.
+func (s *binaryService) Download(
+	ctx context.Context,
+	token string,
+	id int32,
+	info *datapb.Binary,
+	filePath string,
+) (err error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("ошибка создания файла: %w", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(filePath)
+		}
+	}()
+
+	hasher := sha256.New()
+	var next int32
+	for attempt := 1; ; attempt++ {
+		next, err = s.receiveChunks(ctx, id, next, io.MultiWriter(file, hasher))
+		if err == nil {
+			break
+		}
+		if !isOffline(err) || attempt == transferAttempts {
+			return fmt.Errorf("скачивание прервано: %w", err)
+		}
+		if err = s.wait(ctx, attempt); err != nil {
+			return err
+		}
+	}
+
+	if info.Sha256 != "" && hex.EncodeToString(hasher.Sum(nil)) != info.Sha256 {
+		return ErrChecksumMismatch
+	}
+
+	return nil
+}
+
+// receiveChunks пишет в w части файла начиная с from и возвращает номер следующей части.
+func (s *binaryService) receiveChunks(ctx context.Context, id, from int32, w io.Writer) (int32, error) {
+	stream, err := s.client.DownloadBinary(ctx, &datapb.DownloadBinaryRequest{Id: id, FromChunk: from})
+	if err != nil {
+		return from, err
+	}
+
+	next := from
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return next, nil
+		}
+		if err != nil {
+			return next, err
+		}
+
+		sum := sha256.Sum256(chunk.Data)
+		if chunk.Seq != next || !bytes.Equal(sum[:], chunk.Sha256) {
+			return next, fmt.Errorf("%w: часть %d", ErrChecksumMismatch, chunk.Seq)
+		}
+
+		data, err := s.decryptChunk(chunk.Data)
+		if err != nil {
+			return next, err
+		}
+		if _, err = w.Write(data); err != nil {
+			return next, fmt.Errorf("ошибка записи файла: %w", err)
+		}
+		next++
+	}
+}
+
+func (s *binaryService) encryptChunk(data []byte) ([]byte, error) {
+	key, err := vaultKey(s.tokenHolder)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) == 0 {
+		return data, nil
+	}
+
+	encrypted, err := encryptWithVaultKey(key, data)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка шифрования части файла: %w", err)
+	}
+
+	return []byte(encrypted), nil
+}
+
+func (s *binaryService) decryptChunk(data []byte) ([]byte, error) {
+	if !isVaultCiphertext(string(data)) {
+		return data, nil
+	}
+
+	key := s.tokenHolder.VaultKey
+	if len(key) == 0 {
+		return nil, ErrVaultLocked
+	}
+
+	decrypted, err := decryptWithVaultKey(key, string(data))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расшифровки части файла: %w", err)
+	}
+
+	return decrypted, nil
+}
+
+func (s *binaryService) wait(ctx context.Context, attempt int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(s.backoff * time.Duration(attempt)):
+		return nil
+	}
+}
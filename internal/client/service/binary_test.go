@@ -0,0 +1,185 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeBinaryServer хранит загрузку в памяти. dropAfter > 0 обрывает поток после стольких частей,
+// как если бы пропала связь, а dropResponse обрывает его после приёма всех частей до ответа.
+type fakeBinaryServer struct {
+	start        *datapb.StartUploadRequest
+	chunks       [][]byte
+	dropAfter    int
+	dropResponse bool
+	starts       int
+}
+
+func (f *fakeBinaryServer) StartUpload(
+	_ context.Context,
+	in *datapb.StartUploadRequest,
+	_ ...grpc.CallOption,
+) (*datapb.StartUploadResponse, error) {
+	f.starts++
+	if in.UploadId == "" {
+		f.start = in
+	}
+	return &datapb.StartUploadResponse{UploadId: "up1", NextChunk: int32(len(f.chunks))}, nil
+}
+
+func (f *fakeBinaryServer) UploadBinary(
+	_ context.Context,
+	_ ...grpc.CallOption,
+) (grpc.ClientStreamingClient[datapb.BinaryChunk, datapb.UploadBinaryResponse], error) {
+	return &fakeUploadClient{server: f}, nil
+}
+
+func (f *fakeBinaryServer) DownloadBinary(
+	_ context.Context,
+	in *datapb.DownloadBinaryRequest,
+	_ ...grpc.CallOption,
+) (grpc.ServerStreamingClient[datapb.BinaryChunk], error) {
+	stream := &fakeDownloadClient{}
+	for seq := int(in.FromChunk); seq < len(f.chunks); seq++ {
+		sum := sha256.Sum256(f.chunks[seq])
+		stream.chunks = append(stream.chunks, &datapb.BinaryChunk{Seq: int32(seq), Data: f.chunks[seq], Sha256: sum[:]})
+	}
+	if f.dropAfter > 0 && len(stream.chunks) > f.dropAfter {
+		stream.chunks = stream.chunks[:f.dropAfter]
+		stream.err = status.Error(codes.Unavailable, "connection lost")
+		f.dropAfter = 0
+	}
+	return stream, nil
+}
+
+type fakeUploadClient struct {
+	grpc.ClientStream
+	server *fakeBinaryServer
+	err    error
+	sent   int
+}
+
+func (c *fakeUploadClient) Send(chunk *datapb.BinaryChunk) error {
+	if c.server.dropAfter > 0 && c.sent == c.server.dropAfter {
+		c.server.dropAfter = 0
+		c.err = status.Error(codes.Unavailable, "connection lost")
+		return io.EOF
+	}
+	if int(chunk.Seq) < len(c.server.chunks) {
+		return nil
+	}
+	sum := sha256.Sum256(chunk.Data)
+	if int(chunk.Seq) != len(c.server.chunks) || !bytes.Equal(sum[:], chunk.Sha256) {
+		c.err = status.Error(codes.FailedPrecondition, "bad chunk")
+		return io.EOF
+	}
+	c.server.chunks = append(c.server.chunks, chunk.Data)
+	c.sent++
+	return nil
+}
+
+func (c *fakeUploadClient) CloseAndRecv() (*datapb.UploadBinaryResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	resp := &datapb.UploadBinaryResponse{NextChunk: int32(len(c.server.chunks))}
+	if resp.NextChunk == c.server.start.ChunkCount {
+		if c.server.dropResponse {
+			c.server.dropResponse = false
+			return nil, status.Error(codes.Unavailable, "connection lost")
+		}
+		resp.Id = 7
+	}
+	return resp, nil
+}
+
+type fakeDownloadClient struct {
+	grpc.ClientStream
+	err    error
+	chunks []*datapb.BinaryChunk
+}
+
+func (c *fakeDownloadClient) Recv() (*datapb.BinaryChunk, error) {
+	if len(c.chunks) == 0 {
+		if c.err != nil {
+			return nil, c.err
+		}
+		return nil, io.EOF
+	}
+	chunk := c.chunks[0]
+	c.chunks = c.chunks[1:]
+	return chunk, nil
+}
+
+func TestBinaryService_UploadDownloadWithReconnect(t *testing.T) {
+	dir := t.TempDir()
+	content := bytes.Repeat([]byte("0123456789abcdef"), binaryChunkSize/16*3+100)
+	source := filepath.Join(dir, "large.bin")
+	assert.NoError(t, os.WriteFile(source, content, 0600))
+
+	server := &fakeBinaryServer{dropAfter: 2}
+	tokenHolder := &entity.TokenHolder{VaultKey: bytes.Repeat([]byte{1}, 32)}
+	binaryService := &binaryService{client: server, tokenHolder: tokenHolder}
+
+	id, err := binaryService.Upload(context.Background(), "token", source, "meta", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), id)
+	assert.Equal(t, int32(4), server.start.ChunkCount)
+	assert.Len(t, server.chunks, 4)
+	assert.Equal(t, 2, server.starts, "после обрыва загрузка продолжается через StartUpload")
+	assert.NotContains(t, string(server.chunks[0]), "0123456789abcdef", "части шифруются ключом хранилища")
+
+	item, err := decryptItem(tokenHolder.VaultKey, server.start.Data)
+	assert.NoError(t, err)
+	assert.Equal(t, "meta", item.Meta)
+	sum := sha256.Sum256(content)
+	info := item.GetBinary()
+	assert.True(t, proto.Equal(&datapb.Binary{
+		FileName: "large.bin",
+		Sha256:   hex.EncodeToString(sum[:]),
+		Size:     int64(len(content)),
+		Chunked:  true,
+	}, info))
+
+	target := filepath.Join(dir, "downloaded.bin")
+	server.dropAfter = 1
+	assert.NoError(t, binaryService.Download(context.Background(), "token", 7, info, target))
+	downloaded, err := os.ReadFile(target)
+	assert.NoError(t, err)
+	assert.Equal(t, content, downloaded)
+
+	info.Sha256 = hex.EncodeToString(make([]byte, sha256.Size))
+	err = binaryService.Download(context.Background(), "token", 7, info, target)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	_, err = os.Stat(target)
+	assert.True(t, os.IsNotExist(err), "файл с неверной контрольной суммой удаляется")
+}
+
+func TestBinaryService_UploadResumesAfterLastChunk(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "small.bin")
+	assert.NoError(t, os.WriteFile(source, []byte("content"), 0600))
+
+	server := &fakeBinaryServer{dropResponse: true}
+	tokenHolder := &entity.TokenHolder{VaultKey: bytes.Repeat([]byte{1}, 32)}
+	binaryService := &binaryService{client: server, tokenHolder: tokenHolder}
+
+	id, err := binaryService.Upload(context.Background(), "token", source, "meta", 0)
+	assert.NoError(t, err, "после обрыва до ответа последняя часть отправляется повторно")
+	assert.Equal(t, int32(7), id)
+	assert.Len(t, server.chunks, 1)
+	assert.Equal(t, 2, server.starts)
+}
@@ -2,13 +2,25 @@ package service
 
 import (
 	"context"
+	"errors"
+	"io"
+	"strconv"
 
 	"github.com/NikolosHGW/goph-keeper/api/datapb"
 	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
 	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// dataResourceType - тип записи в ResourceInfo ответов сервера.
+const dataResourceType = "user_data"
+
 type dataService struct {
 	client datapb.DataServiceClient
 	logger logger.CustomLogger
@@ -21,6 +33,11 @@ func NewDataService(grpcClient *GRPCClient, logger logger.CustomLogger) *dataSer
 func (s *dataService) AddData(ctx context.Context, token string, data *datapb.DataItem) (int32, error) {
 	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
 
+	data, err := toWire(data)
+	if err != nil {
+		return 0, err
+	}
+
 	req := &datapb.AddDataRequest{Data: data}
 	res, err := s.client.AddData(ctx, req)
 	if err != nil {
@@ -35,42 +52,202 @@ func (s *dataService) GetData(ctx context.Context, token string, id int32) (*dat
 	req := &datapb.GetDataRequest{Id: id}
 	res, err := s.client.GetData(ctx, req)
 	if err != nil {
+		return nil, recordFromStatus(err, id)
+	}
+	if err = payload.Pack(res.Data); err != nil {
 		return nil, err
 	}
 	return res.Data, nil
 }
 
+// UpdateData обновляет запись, если она не менялась после ревизии data.Revision.
+// Иначе возвращает *entity.ConflictError с текущей версией записи. Если записи нет или она чужая,
+// возвращает *entity.RecordError.
 func (s *dataService) UpdateData(ctx context.Context, token string, data *datapb.DataItem) error {
 	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
 
-	req := &datapb.UpdateDataRequest{Data: data}
-	_, err := s.client.UpdateData(ctx, req)
+	data, err := toWire(data)
 	if err != nil {
 		return err
 	}
+
+	req := &datapb.UpdateDataRequest{Data: data, ExpectedRevision: data.Revision}
+	_, err = s.client.UpdateData(ctx, req)
+	if err != nil {
+		return recordFromStatus(conflictFromStatus(err), data.Id)
+	}
 	return nil
 }
 
+// conflictFromStatus превращает ответ Aborted в *entity.ConflictError, остальные ошибки не меняет.
+func conflictFromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Aborted {
+		return err
+	}
+
+	conflict := &entity.ConflictError{}
+	for _, detail := range st.Details() {
+		if item, ok := detail.(*datapb.DataItem); ok {
+			conflict.Current = item
+		}
+	}
+
+	return conflict
+}
+
+// toWire готовит запись к отправке: содержимое без сквозного шифрования сервер принимает в payload
+// и проверяет. Остальные слои клиента, включая локальный кеш, хранят содержимое записи в Info.
+// Шифротекст остаётся в Info.
+func toWire(data *datapb.DataItem) (*datapb.DataItem, error) {
+	if isVaultCiphertext(string(data.Info)) {
+		return data, nil
+	}
+
+	wire, ok := proto.Clone(data).(*datapb.DataItem)
+	if !ok {
+		return nil, errors.New("не удалось скопировать данные")
+	}
+	if err := payload.Unpack(wire); err != nil {
+		return nil, err
+	}
+
+	return wire, nil
+}
+
+// recordFromStatus превращает ответы NotFound и PermissionDenied в *entity.RecordError, остальные ошибки
+// не меняет. ID записи берётся из ResourceInfo в деталях ответа, а если сервер его не передал, - id.
+func recordFromStatus(err error, id int32) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	recordErr := &entity.RecordError{ID: strconv.Itoa(int(id))}
+	switch st.Code() {
+	case codes.NotFound:
+		recordErr.Kind = entity.ErrNotFound
+	case codes.PermissionDenied:
+		recordErr.Kind = entity.ErrPermissionDenied
+	default:
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		if resource, ok := detail.(*errdetails.ResourceInfo); ok && resource.ResourceType == dataResourceType {
+			recordErr.ID = resource.ResourceName
+		}
+	}
+
+	return recordErr
+}
+
 func (s *dataService) DeleteData(ctx context.Context, token string, id int32) error {
 	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
 
 	req := &datapb.DeleteDataRequest{Id: id}
 	_, err := s.client.DeleteData(ctx, req)
 	if err != nil {
-		return err
+		return recordFromStatus(err, id)
 	}
 	return nil
 }
 
-func (s *dataService) ListData(ctx context.Context, token string, filter *entity.DataFilter) ([]*datapb.DataItem, error) {
+// ListData возвращает страницу списка записей в порядке даты создания.
+func (s *dataService) ListData(ctx context.Context, token string, filter *entity.DataFilter) (*entity.DataPage, error) {
 	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
 
 	req := &datapb.ListDataRequest{
-		InfoType: filter.InfoType,
+		InfoType:  filter.InfoType,
+		PageSize:  filter.PageSize,
+		PageToken: filter.PageToken,
+		FolderId:  filter.FolderID,
+		TagId:     filter.TagID,
 	}
 	res, err := s.client.ListData(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	return res.DataItems, nil
+	return &entity.DataPage{Items: res.DataItems, NextPageToken: res.NextPageToken}, nil
+}
+
+// SearchData возвращает страницу записей, найденных сервером по токенам, типу и дате создания.
+func (s *dataService) SearchData(
+	ctx context.Context,
+	token string,
+	query *entity.SearchQuery,
+) (*entity.DataPage, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+
+	req := &datapb.SearchDataRequest{
+		Tokens:    query.Tokens,
+		InfoType:  query.InfoType,
+		PageSize:  query.PageSize,
+		PageToken: query.PageToken,
+	}
+	if query.Ascending {
+		req.Sort = datapb.SearchDataRequest_CREATED_ASC
+	}
+	if !query.CreatedFrom.IsZero() {
+		req.CreatedFrom = timestamppb.New(query.CreatedFrom)
+	}
+	if !query.CreatedTo.IsZero() {
+		req.CreatedTo = timestamppb.New(query.CreatedTo)
+	}
+
+	res, err := s.client.SearchData(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.DataPage{Items: res.DataItems, NextPageToken: res.NextPageToken}, nil
+}
+
+func (s *dataService) SyncData(
+	ctx context.Context,
+	token string,
+	sinceRevision int64,
+	limit int32,
+) (*datapb.SyncDataResponse, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+
+	req := &datapb.SyncDataRequest{SinceRevision: sinceRevision, Limit: limit}
+	resp, err := s.client.SyncData(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range resp.Changes {
+		if change.Data == nil {
+			continue
+		}
+		if err = payload.Pack(change.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// WatchData подписывается на изменения данных пользователя и вызывает onEvent для каждого события,
+// пока не отменён ctx, сервер не закрыл поток или onEvent не вернул ошибку.
+func (s *dataService) WatchData(ctx context.Context, token string, onEvent func(*datapb.DataEvent) error) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+
+	stream, err := s.client.WatchData(ctx, &datapb.WatchDataRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = onEvent(event); err != nil {
+			return err
+		}
+	}
 }
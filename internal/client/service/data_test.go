@@ -3,14 +3,21 @@ package service
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/NikolosHGW/goph-keeper/api/datapb"
 	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type MockDataServiceClient struct {
@@ -42,6 +49,102 @@ func (m *MockDataServiceClient) ListData(ctx context.Context, in *datapb.ListDat
 	return args.Get(0).(*datapb.ListDataResponse), args.Error(1)
 }
 
+func (m *MockDataServiceClient) SearchData(
+	ctx context.Context, in *datapb.SearchDataRequest, opts ...grpc.CallOption,
+) (*datapb.SearchDataResponse, error) {
+	args := m.Called(ctx, in)
+	resp, _ := args.Get(0).(*datapb.SearchDataResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockDataServiceClient) SyncData(
+	ctx context.Context, in *datapb.SyncDataRequest, opts ...grpc.CallOption,
+) (*datapb.SyncDataResponse, error) {
+	args := m.Called(ctx, in)
+	resp, _ := args.Get(0).(*datapb.SyncDataResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockDataServiceClient) ListVersions(
+	ctx context.Context, in *datapb.ListVersionsRequest, opts ...grpc.CallOption,
+) (*datapb.ListVersionsResponse, error) {
+	args := m.Called(ctx, in)
+	resp, _ := args.Get(0).(*datapb.ListVersionsResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockDataServiceClient) GetVersion(
+	ctx context.Context, in *datapb.GetVersionRequest, opts ...grpc.CallOption,
+) (*datapb.GetVersionResponse, error) {
+	args := m.Called(ctx, in)
+	resp, _ := args.Get(0).(*datapb.GetVersionResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockDataServiceClient) RestoreVersion(
+	ctx context.Context, in *datapb.RestoreVersionRequest, opts ...grpc.CallOption,
+) (*datapb.RestoreVersionResponse, error) {
+	args := m.Called(ctx, in)
+	resp, _ := args.Get(0).(*datapb.RestoreVersionResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockDataServiceClient) ListTrash(
+	ctx context.Context, in *datapb.ListTrashRequest, opts ...grpc.CallOption,
+) (*datapb.ListTrashResponse, error) {
+	args := m.Called(ctx, in)
+	resp, _ := args.Get(0).(*datapb.ListTrashResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockDataServiceClient) RestoreData(
+	ctx context.Context, in *datapb.RestoreDataRequest, opts ...grpc.CallOption,
+) (*datapb.RestoreDataResponse, error) {
+	args := m.Called(ctx, in)
+	resp, _ := args.Get(0).(*datapb.RestoreDataResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockDataServiceClient) PurgeData(
+	ctx context.Context, in *datapb.PurgeDataRequest, opts ...grpc.CallOption,
+) (*datapb.PurgeDataResponse, error) {
+	args := m.Called(ctx, in)
+	resp, _ := args.Get(0).(*datapb.PurgeDataResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockDataServiceClient) EmptyTrash(
+	ctx context.Context, in *datapb.EmptyTrashRequest, opts ...grpc.CallOption,
+) (*datapb.EmptyTrashResponse, error) {
+	args := m.Called(ctx, in)
+	resp, _ := args.Get(0).(*datapb.EmptyTrashResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockDataServiceClient) WatchData(
+	ctx context.Context, in *datapb.WatchDataRequest, opts ...grpc.CallOption,
+) (grpc.ServerStreamingClient[datapb.DataEvent], error) {
+	args := m.Called(ctx, in)
+	stream, _ := args.Get(0).(grpc.ServerStreamingClient[datapb.DataEvent])
+	return stream, args.Error(1)
+}
+
+// fakeEventStream отдаёт заданные события, а затем ошибку err.
+type fakeEventStream struct {
+	grpc.ClientStream
+	err    error
+	events []*datapb.DataEvent
+}
+
+func (s *fakeEventStream) Recv() (*datapb.DataEvent, error) {
+	if len(s.events) == 0 {
+		return nil, s.err
+	}
+	event := s.events[0]
+	s.events = s.events[1:]
+	return event, nil
+}
+
 func TestDataService_AddData(t *testing.T) {
 	mockClient := new(MockDataServiceClient)
 	mockLogger := new(mockLogger)
@@ -125,11 +228,12 @@ func TestDataService_UpdateData(t *testing.T) {
 		InfoType: "text",
 		Info:     []byte("updated info"),
 		Meta:     "updated meta",
+		Revision: 3,
 	}
 
 	ctxWithMetadata := metadata.AppendToOutgoingContext(ctx, "authorization", token)
 
-	expectedRequest := &datapb.UpdateDataRequest{Data: dataItem}
+	expectedRequest := &datapb.UpdateDataRequest{Data: dataItem, ExpectedRevision: 3}
 
 	expectedResponse := &datapb.UpdateDataResponse{}
 
@@ -141,6 +245,81 @@ func TestDataService_UpdateData(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestDataService_UpdateData_Conflict(t *testing.T) {
+	mockClient := new(MockDataServiceClient)
+	dataService := &dataService{client: mockClient, logger: new(mockLogger)}
+
+	current := &datapb.DataItem{Id: 1, InfoType: "text", Meta: "с сервера", Revision: 5}
+	st, err := status.New(codes.Aborted, "запись изменена с момента чтения").WithDetails(current)
+	assert.NoError(t, err)
+	mockClient.On("UpdateData", mock.Anything, mock.Anything).Return((*datapb.UpdateDataResponse)(nil), st.Err())
+
+	err = dataService.UpdateData(context.Background(), "token", &datapb.DataItem{Id: 1, Revision: 3})
+
+	var conflict *entity.ConflictError
+	if assert.ErrorAs(t, err, &conflict) {
+		assert.True(t, proto.Equal(current, conflict.Current))
+	}
+}
+
+func TestDataService_Payload(t *testing.T) {
+	mockClient := new(MockDataServiceClient)
+	dataService := &dataService{client: mockClient, logger: new(mockLogger)}
+	ctx := context.Background()
+
+	mockClient.On("AddData", mock.Anything, mock.MatchedBy(func(req *datapb.AddDataRequest) bool {
+		return req.Data.GetText().GetText() == "заметка" && len(req.Data.Info) == 0
+	})).Return(&datapb.AddDataResponse{Id: 1}, nil)
+	_, err := dataService.AddData(ctx, "token", &datapb.DataItem{InfoType: "text", Info: []byte(`{"Text":"заметка"}`)})
+	assert.NoError(t, err, "содержимое без сквозного шифрования уходит на сервер в payload")
+
+	mockClient.On("AddData", mock.Anything, mock.MatchedBy(func(req *datapb.AddDataRequest) bool {
+		return string(req.Data.Info) == "e2e:v1:abc" && req.Data.Payload == nil
+	})).Return(&datapb.AddDataResponse{Id: 2}, nil)
+	id, err := dataService.AddData(ctx, "token", &datapb.DataItem{InfoType: "text", Info: []byte("e2e:v1:abc")})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), id, "шифротекст уходит в Info")
+
+	mockClient.On("GetData", mock.Anything, mock.Anything).Return(&datapb.GetDataResponse{Data: &datapb.DataItem{
+		InfoType: "text",
+		Payload:  &datapb.DataItem_Text{Text: &datapb.Text{Text: "заметка"}},
+	}}, nil)
+	item, err := dataService.GetData(ctx, "token", 1)
+	assert.NoError(t, err)
+	assert.Nil(t, item.Payload, "остальные слои клиента хранят содержимое в Info")
+	assert.JSONEq(t, `{"Text":"заметка"}`, string(item.Info))
+}
+
+func TestDataService_RecordErrors(t *testing.T) {
+	mockClient := new(MockDataServiceClient)
+	dataService := &dataService{client: mockClient, logger: new(mockLogger)}
+	ctx := context.Background()
+
+	forbidden, err := status.New(codes.PermissionDenied, "запись принадлежит другому пользователю").WithDetails(
+		&errdetails.ErrorInfo{Reason: "PERMISSION_DENIED", Domain: "goph-keeper"},
+		&errdetails.ResourceInfo{ResourceType: "user_data", ResourceName: "42"},
+	)
+	assert.NoError(t, err)
+	mockClient.On("DeleteData", mock.Anything, mock.Anything).Return((*datapb.DeleteDataResponse)(nil), forbidden.Err())
+
+	err = dataService.DeleteData(ctx, "token", 42)
+	assert.ErrorIs(t, err, entity.ErrPermissionDenied)
+	assert.EqualError(t, err, "нет доступа к записи 42: она принадлежит другому пользователю")
+
+	notFound := status.Error(codes.NotFound, "данные не найдены")
+	mockClient.On("GetData", mock.Anything, mock.Anything).Return((*datapb.GetDataResponse)(nil), notFound)
+
+	_, err = dataService.GetData(ctx, "token", 7)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+	assert.EqualError(t, err, "запись 7 не найдена")
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	mockClient.On("UpdateData", mock.Anything, mock.Anything).Return((*datapb.UpdateDataResponse)(nil), unavailable)
+
+	err = dataService.UpdateData(ctx, "token", &datapb.DataItem{Id: 7})
+	assert.Equal(t, codes.Unavailable, status.Code(err), "остальные ошибки сервера не меняются")
+}
+
 func TestDataService_DeleteData(t *testing.T) {
 	mockClient := new(MockDataServiceClient)
 	mockLogger := new(mockLogger)
@@ -211,13 +390,17 @@ func TestDataService_ListData(t *testing.T) {
 	token := "test-token"
 
 	filter := &entity.DataFilter{
-		InfoType: "text",
+		InfoType:  "text",
+		PageSize:  20,
+		PageToken: "page-2",
 	}
 
 	ctxWithMetadata := metadata.AppendToOutgoingContext(ctx, "authorization", token)
 
 	expectedRequest := &datapb.ListDataRequest{
-		InfoType: filter.InfoType,
+		InfoType:  filter.InfoType,
+		PageSize:  20,
+		PageToken: "page-2",
 	}
 
 	expectedDataItems := []*datapb.DataItem{
@@ -236,15 +419,16 @@ func TestDataService_ListData(t *testing.T) {
 	}
 
 	expectedResponse := &datapb.ListDataResponse{
-		DataItems: expectedDataItems,
+		DataItems:     expectedDataItems,
+		NextPageToken: "page-3",
 	}
 
 	mockClient.On("ListData", ctxWithMetadata, expectedRequest).Return(expectedResponse, nil)
 
-	dataItems, err := dataService.ListData(ctx, token, filter)
+	page, err := dataService.ListData(ctx, token, filter)
 
 	assert.NoError(t, err)
-	assert.Equal(t, expectedDataItems, dataItems)
+	assert.Equal(t, &entity.DataPage{Items: expectedDataItems, NextPageToken: "page-3"}, page)
 	mockClient.AssertExpectations(t)
 }
 
@@ -272,10 +456,10 @@ func TestDataService_ListData_Error(t *testing.T) {
 
 	mockClient.On("ListData", ctxWithMetadata, expectedRequest).Return((*datapb.ListDataResponse)(nil), errors.New("test error"))
 
-	dataItems, err := dataService.ListData(ctx, token, filter)
+	page, err := dataService.ListData(ctx, token, filter)
 
 	assert.Error(t, err)
-	assert.Nil(t, dataItems)
+	assert.Nil(t, page)
 	mockClient.AssertExpectations(t)
 }
 
@@ -300,3 +484,90 @@ func TestNewDataService(t *testing.T) {
 
 	assert.Equal(t, mockLogger, dataService.logger)
 }
+
+func TestDataService_SyncData(t *testing.T) {
+	mockClient := new(MockDataServiceClient)
+	dataService := &dataService{client: mockClient, logger: new(mockLogger)}
+
+	ctx := context.Background()
+	ctxWithMetadata := metadata.AppendToOutgoingContext(ctx, "authorization", "test-token")
+	expectedResponse := &datapb.SyncDataResponse{Revision: 7, Changes: []*datapb.DataChange{{Id: 1, Deleted: true}}}
+
+	mockClient.On("SyncData", ctxWithMetadata, &datapb.SyncDataRequest{SinceRevision: 5, Limit: 100}).
+		Return(expectedResponse, nil)
+
+	resp, err := dataService.SyncData(ctx, "test-token", 5, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResponse, resp)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDataService_SearchData(t *testing.T) {
+	mockClient := new(MockDataServiceClient)
+	dataService := &dataService{client: mockClient, logger: new(mockLogger)}
+
+	ctx := context.Background()
+	ctxWithMetadata := metadata.AppendToOutgoingContext(ctx, "authorization", "test-token")
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := []*datapb.DataItem{{Id: 1, InfoType: "text", Meta: "почта"}}
+
+	mockClient.On("SearchData", ctxWithMetadata, &datapb.SearchDataRequest{
+		Tokens:      []string{"t1"},
+		InfoType:    "text",
+		CreatedFrom: timestamppb.New(from),
+		Sort:        datapb.SearchDataRequest_CREATED_ASC,
+		PageSize:    10,
+		PageToken:   "page",
+	}).Return(&datapb.SearchDataResponse{DataItems: items, NextPageToken: "next"}, nil)
+
+	page, err := dataService.SearchData(ctx, "test-token", &entity.SearchQuery{
+		Text:        "почта",
+		Tokens:      []string{"t1"},
+		InfoType:    "text",
+		CreatedFrom: from,
+		Ascending:   true,
+		PageSize:    10,
+		PageToken:   "page",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.DataPage{Items: items, NextPageToken: "next"}, page)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDataService_WatchData(t *testing.T) {
+	ctx := context.Background()
+	ctxWithMetadata := metadata.AppendToOutgoingContext(ctx, "authorization", "token")
+	events := []*datapb.DataEvent{
+		{Kind: datapb.DataEvent_ADDED, Id: 1, InfoType: "text"},
+		{Kind: datapb.DataEvent_DELETED, Id: 2},
+	}
+
+	tests := []struct {
+		streamErr     error
+		expectedError error
+		name          string
+	}{
+		{name: "Сервер закрыл поток", streamErr: io.EOF},
+		{name: "Обрыв соединения", streamErr: errServerUnavailable, expectedError: errServerUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockDataServiceClient)
+			stream := &fakeEventStream{events: append([]*datapb.DataEvent(nil), events...), err: tt.streamErr}
+			mockClient.On("WatchData", ctxWithMetadata, &datapb.WatchDataRequest{}).Return(stream, nil)
+			dataService := &dataService{client: mockClient, logger: new(mockLogger)}
+
+			var received []*datapb.DataEvent
+			err := dataService.WatchData(ctx, "token", func(event *datapb.DataEvent) error {
+				received = append(received, event)
+				return nil
+			})
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, events, received)
+		})
+	}
+}
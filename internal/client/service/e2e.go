@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+	"google.golang.org/protobuf/proto"
+)
+
+type dataServicer interface {
+	AddData(ctx context.Context, token string, data *datapb.DataItem) (int32, error)
+	GetData(ctx context.Context, token string, id int32) (*datapb.DataItem, error)
+	UpdateData(ctx context.Context, token string, data *datapb.DataItem) error
+	DeleteData(ctx context.Context, token string, id int32) error
+	ListData(ctx context.Context, token string, filter *entity.DataFilter) (*entity.DataPage, error)
+	SearchData(ctx context.Context, token string, query *entity.SearchQuery) (*entity.DataPage, error)
+}
+
+// e2eDataService шифрует Info и Meta ключом хранилища перед отправкой на сервер
+// и расшифровывает их после получения. Если ключ хранилища не задан, данные передаются как есть.
+type e2eDataService struct {
+	next        dataServicer
+	tokenHolder *entity.TokenHolder
+}
+
+// NewE2EDataService - конструктор data service со сквозным шифрованием.
+func NewE2EDataService(next dataServicer, tokenHolder *entity.TokenHolder) *e2eDataService {
+	return &e2eDataService{next: next, tokenHolder: tokenHolder}
+}
+
+func (s *e2eDataService) AddData(ctx context.Context, token string, data *datapb.DataItem) (int32, error) {
+	key, err := vaultKey(s.tokenHolder)
+	if err != nil {
+		return 0, err
+	}
+
+	encrypted, err := encryptItem(key, data)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.next.AddData(ctx, token, encrypted)
+}
+
+func (s *e2eDataService) GetData(ctx context.Context, token string, id int32) (*datapb.DataItem, error) {
+	item, err := s.next.GetData(ctx, token, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return decryptItem(s.tokenHolder.VaultKey, item)
+}
+
+func (s *e2eDataService) UpdateData(ctx context.Context, token string, data *datapb.DataItem) error {
+	key, err := vaultKey(s.tokenHolder)
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := encryptItem(key, data)
+	if err != nil {
+		return err
+	}
+
+	err = s.next.UpdateData(ctx, token, encrypted)
+
+	var conflict *entity.ConflictError
+	if errors.As(err, &conflict) && conflict.Current != nil {
+		if conflict.Current, err = decryptItem(s.tokenHolder.VaultKey, conflict.Current); err != nil {
+			return err
+		}
+		return conflict
+	}
+
+	return err
+}
+
+func (s *e2eDataService) DeleteData(ctx context.Context, token string, id int32) error {
+	return s.next.DeleteData(ctx, token, id)
+}
+
+func (s *e2eDataService) ListData(
+	ctx context.Context,
+	token string,
+	filter *entity.DataFilter,
+) (*entity.DataPage, error) {
+	page, err := s.next.ListData(ctx, token, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range page.Items {
+		page.Items[i], err = decryptItem(s.tokenHolder.VaultKey, item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// SearchData ищет записи по словам query.Text: отправляет серверу токены поиска вместо самих слов,
+// расшифровывает найденные записи и отбрасывает те, что совпали с запросом только по триграммам.
+// Поэтому на странице может оказаться меньше записей, чем запрошено.
+func (s *e2eDataService) SearchData(
+	ctx context.Context,
+	token string,
+	query *entity.SearchQuery,
+) (*entity.DataPage, error) {
+	withTokens := *query
+	if query.Text != "" {
+		key, err := vaultKey(s.tokenHolder)
+		if err != nil {
+			return nil, err
+		}
+		withTokens.Tokens = QueryTokens(key, query.Text)
+	}
+
+	page, err := s.next.SearchData(ctx, token, &withTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*datapb.DataItem, 0, len(page.Items))
+	for _, item := range page.Items {
+		item, err = decryptItem(s.tokenHolder.VaultKey, item)
+		if err != nil {
+			return nil, err
+		}
+		if MatchesQuery(item.Meta, query.Text) {
+			items = append(items, item)
+		}
+	}
+	page.Items = items
+
+	return page, nil
+}
+
+// vaultKey возвращает ключ для шифрования новых данных. Пока хранилище заблокировано,
+// отправлять данные нельзя: без ключа они ушли бы на сервер незашифрованными.
+func vaultKey(tokenHolder *entity.TokenHolder) ([]byte, error) {
+	if tokenHolder.VaultLocked() {
+		return nil, ErrVaultLocked
+	}
+
+	return tokenHolder.VaultKey, nil
+}
+
+// encryptItem проверяет содержимое записи, переносит его из payload в Info копии записи и шифрует Info и Meta
+// копии ключом хранилища key, а также добавляет к ней токены поиска по Meta. Без ключа Info и Meta копии
+// не шифруются.
+func encryptItem(key []byte, data *datapb.DataItem) (*datapb.DataItem, error) {
+	if err := payload.Validate(data); err != nil {
+		return nil, err
+	}
+
+	encrypted, ok := proto.Clone(data).(*datapb.DataItem)
+	if !ok {
+		return nil, fmt.Errorf("не удалось скопировать данные")
+	}
+	if err := payload.Pack(encrypted); err != nil {
+		return nil, err
+	}
+	encrypted.SearchTokens = SearchTokens(key, data.Meta)
+	if len(key) == 0 {
+		return encrypted, nil
+	}
+
+	info, err := encryptWithVaultKey(key, encrypted.Info)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка шифрования Info: %w", err)
+	}
+	encrypted.Info = []byte(info)
+
+	encrypted.Meta, err = encryptWithVaultKey(key, []byte(data.Meta))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка шифрования Meta: %w", err)
+	}
+
+	return encrypted, nil
+}
+
+// decryptItem расшифровывает только значения с префиксом хранилища, поэтому записи, созданные
+// до включения сквозного шифрования, читаются без изменений. Содержимое записи переносится из Info в payload.
+func decryptItem(key []byte, item *datapb.DataItem) (*datapb.DataItem, error) {
+	encryptedInfo := isVaultCiphertext(string(item.Info))
+	encryptedMeta := isVaultCiphertext(item.Meta)
+	if (encryptedInfo || encryptedMeta) && len(key) == 0 {
+		return nil, ErrVaultLocked
+	}
+
+	if encryptedInfo {
+		info, err := decryptWithVaultKey(key, string(item.Info))
+		if err != nil {
+			return nil, fmt.Errorf("ошибка расшифровки Info: %w", err)
+		}
+		item.Info = info
+	}
+
+	if encryptedMeta {
+		meta, err := decryptWithVaultKey(key, item.Meta)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка расшифровки Meta: %w", err)
+		}
+		item.Meta = string(meta)
+	}
+
+	if err := payload.Unpack(item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDataServicer struct {
+	mock.Mock
+}
+
+func (m *MockDataServicer) AddData(ctx context.Context, token string, data *datapb.DataItem) (int32, error) {
+	args := m.Called(ctx, token, data)
+	return int32(args.Int(0)), args.Error(1)
+}
+
+func (m *MockDataServicer) GetData(ctx context.Context, token string, id int32) (*datapb.DataItem, error) {
+	args := m.Called(ctx, token, id)
+	item, _ := args.Get(0).(*datapb.DataItem)
+	return item, args.Error(1)
+}
+
+func (m *MockDataServicer) UpdateData(ctx context.Context, token string, data *datapb.DataItem) error {
+	args := m.Called(ctx, token, data)
+	return args.Error(0)
+}
+
+func (m *MockDataServicer) DeleteData(ctx context.Context, token string, id int32) error {
+	args := m.Called(ctx, token, id)
+	return args.Error(0)
+}
+
+func (m *MockDataServicer) ListData(
+	ctx context.Context,
+	token string,
+	filter *entity.DataFilter,
+) (*entity.DataPage, error) {
+	args := m.Called(ctx, token, filter)
+	page, _ := args.Get(0).(*entity.DataPage)
+	return page, args.Error(1)
+}
+
+func (m *MockDataServicer) SearchData(
+	ctx context.Context,
+	token string,
+	query *entity.SearchQuery,
+) (*entity.DataPage, error) {
+	args := m.Called(ctx, token, query)
+	page, _ := args.Get(0).(*entity.DataPage)
+	return page, args.Error(1)
+}
+
+func TestE2EDataService_AddAndGet(t *testing.T) {
+	ctx := context.Background()
+	key := []byte("0123456789abcdef0123456789abcdef")
+	tokenHolder := &entity.TokenHolder{Token: "token", VaultKey: key}
+
+	next := new(MockDataServicer)
+	svc := NewE2EDataService(next, tokenHolder)
+
+	item := &datapb.DataItem{
+		InfoType: "text",
+		Payload:  &datapb.DataItem_Text{Text: &datapb.Text{Text: "секрет"}},
+		Meta:     "заметка",
+	}
+
+	var sent *datapb.DataItem
+	next.On("AddData", ctx, "token", mock.AnythingOfType("*datapb.DataItem")).Return(1, nil).
+		Run(func(args mock.Arguments) {
+			sent = args.Get(2).(*datapb.DataItem)
+		})
+
+	id, err := svc.AddData(ctx, "token", item)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), id)
+	assert.True(t, isVaultCiphertext(string(sent.Info)))
+	assert.True(t, isVaultCiphertext(sent.Meta))
+	assert.Equal(t, SearchTokens(key, "заметка"), sent.SearchTokens)
+	assert.NotContains(t, sent.SearchTokens, "w:заметка", "токены поиска скрыты ключом хранилища")
+	assert.Equal(t, "заметка", item.Meta, "исходный элемент не должен изменяться")
+	assert.Empty(t, item.SearchTokens)
+
+	next.On("GetData", ctx, "token", int32(1)).Return(sent, nil)
+
+	got, err := svc.GetData(ctx, "token", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "секрет", got.GetText().GetText())
+	assert.Empty(t, got.Info)
+	assert.Equal(t, "заметка", got.Meta)
+}
+
+func TestE2EDataService_AddInvalidPayload(t *testing.T) {
+	next := new(MockDataServicer)
+	svc := NewE2EDataService(next, &entity.TokenHolder{Token: "token", VaultKey: make([]byte, 32)})
+
+	_, err := svc.AddData(context.Background(), "token", &datapb.DataItem{
+		InfoType: "bank_card",
+		Payload: &datapb.DataItem_BankCard{
+			BankCard: &datapb.BankCard{CardNumber: "4111111111111112", ExpiryDate: "01/27"},
+		},
+	})
+
+	assert.ErrorIs(t, err, payload.ErrInvalidCardNumber, "содержимое проверяется до шифрования")
+	next.AssertNotCalled(t, "AddData", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestE2EDataService_UpdateConflictDecrypted(t *testing.T) {
+	ctx := context.Background()
+	key := []byte("0123456789abcdef0123456789abcdef")
+	meta, err := encryptWithVaultKey(key, []byte("с сервера"))
+	assert.NoError(t, err)
+
+	next := new(MockDataServicer)
+	next.On("UpdateData", ctx, "token", mock.Anything).
+		Return(&entity.ConflictError{Current: &datapb.DataItem{Id: 1, Meta: meta, Revision: 5}})
+	svc := NewE2EDataService(next, &entity.TokenHolder{Token: "token", VaultKey: key})
+
+	err = svc.UpdateData(ctx, "token", &datapb.DataItem{Id: 1, Meta: "моё", Revision: 3})
+
+	var conflict *entity.ConflictError
+	if assert.ErrorAs(t, err, &conflict) {
+		assert.Equal(t, "с сервера", conflict.Current.Meta)
+		assert.Equal(t, int64(5), conflict.Current.Revision)
+	}
+}
+
+func TestE2EDataService_WithoutVaultKey(t *testing.T) {
+	ctx := context.Background()
+	tokenHolder := &entity.TokenHolder{Token: "token"}
+
+	next := new(MockDataServicer)
+	svc := NewE2EDataService(next, tokenHolder)
+
+	item := &datapb.DataItem{InfoType: "text", Info: []byte("plain"), Meta: "meta"}
+	next.On("UpdateData", ctx, "token", mock.MatchedBy(func(sent *datapb.DataItem) bool {
+		return string(sent.Info) == "plain" && sent.Meta == "meta" &&
+			assert.ObjectsAreEqual([]string{"w:meta", "g:met", "g:eta"}, sent.SearchTokens)
+	})).Return(nil)
+
+	assert.NoError(t, svc.UpdateData(ctx, "token", item))
+
+	encrypted := &datapb.DataItem{Id: 2, Meta: payload.CiphertextPrefix + "AAAA"}
+	next.On("ListData", ctx, "token", mock.Anything).
+		Return(&entity.DataPage{Items: []*datapb.DataItem{encrypted}}, nil)
+
+	_, err := svc.ListData(ctx, "token", &entity.DataFilter{})
+	assert.ErrorIs(t, err, ErrVaultLocked)
+}
+
+func TestE2EDataService_LockedVault(t *testing.T) {
+	ctx := context.Background()
+	tokenHolder := &entity.TokenHolder{Token: "token", KDFParams: &entity.KDFParams{Salt: []byte("salt")}}
+
+	next := new(MockDataServicer)
+	svc := NewE2EDataService(next, tokenHolder)
+
+	item := &datapb.DataItem{InfoType: "text", Info: []byte("plain"), Meta: "meta"}
+
+	_, err := svc.AddData(ctx, "token", item)
+	assert.ErrorIs(t, err, ErrVaultLocked)
+	assert.ErrorIs(t, svc.UpdateData(ctx, "token", item), ErrVaultLocked)
+	next.AssertNotCalled(t, "AddData", mock.Anything, mock.Anything, mock.Anything)
+	next.AssertNotCalled(t, "UpdateData", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestE2EDataService_SearchData(t *testing.T) {
+	ctx := context.Background()
+	key := []byte("0123456789abcdef0123456789abcdef")
+	encrypt := func(meta string) string {
+		encrypted, err := encryptWithVaultKey(key, []byte(meta))
+		assert.NoError(t, err)
+		return encrypted
+	}
+
+	next := new(MockDataServicer)
+	next.On("SearchData", ctx, "token", &entity.SearchQuery{
+		Text:     "почт",
+		Tokens:   QueryTokens(key, "почт"),
+		InfoType: "text",
+	}).Return(&entity.DataPage{
+		Items: []*datapb.DataItem{
+			{Id: 1, Meta: encrypt("Рабочая почта")},
+			{Id: 2, Meta: encrypt("почитать потом")},
+		},
+		NextPageToken: "next",
+	}, nil)
+	svc := NewE2EDataService(next, &entity.TokenHolder{Token: "token", VaultKey: key})
+
+	page, err := svc.SearchData(ctx, "token", &entity.SearchQuery{Text: "почт", InfoType: "text"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "next", page.NextPageToken)
+	if assert.Len(t, page.Items, 1, "совпадение только по триграммам отбрасывается") {
+		assert.Equal(t, "Рабочая почта", page.Items[0].Meta)
+	}
+
+	locked := NewE2EDataService(next, &entity.TokenHolder{Token: "token", KDFParams: &entity.KDFParams{}})
+	_, err = locked.SearchData(ctx, "token", &entity.SearchQuery{Text: "почт"})
+	assert.ErrorIs(t, err, ErrVaultLocked)
+}
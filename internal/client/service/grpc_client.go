@@ -12,20 +12,29 @@ import (
 )
 
 type GRPCClient struct {
-	conn           *grpc.ClientConn
-	RegisterClient registerpb.RegisterClient
-	AuthClient     authpb.AuthClient
-	DataClient     datapb.DataServiceClient
+	conn             *grpc.ClientConn
+	RegisterClient   registerpb.RegisterClient
+	AuthClient       authpb.AuthClient
+	DataClient       datapb.DataServiceClient
+	BinaryClient     datapb.BinaryServiceClient
+	OrganizerClient  datapb.OrganizerServiceClient
+	RecordTypeClient datapb.RecordTypeServiceClient
 }
 
-func NewGRPCClient(serverAddress string, logger logger.CustomLogger, rootCertPath string) (*GRPCClient, error) {
+func NewGRPCClient(
+	serverAddress string,
+	logger logger.CustomLogger,
+	rootCertPath string,
+	opts ...grpc.DialOption,
+) (*GRPCClient, error) {
 	creds, err := credentials.NewClientTLSFromFile(rootCertPath, "")
 	if err != nil {
 		logger.LogInfo("не удалось загрузить корневой CA сертификат", err)
 		return nil, fmt.Errorf("ошибка при загрузке CA сертификата: %w", err)
 	}
 
-	conn, err := grpc.NewClient(serverAddress, grpc.WithTransportCredentials(creds))
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, opts...)
+	conn, err := grpc.NewClient(serverAddress, opts...)
 	if err != nil {
 		logger.LogInfo("не удалось инициализировать клиент gRPC", err)
 
@@ -37,10 +46,13 @@ func NewGRPCClient(serverAddress string, logger logger.CustomLogger, rootCertPat
 	dataClient := datapb.NewDataServiceClient(conn)
 
 	return &GRPCClient{
-		conn:           conn,
-		RegisterClient: registerClient,
-		AuthClient:     authClient,
-		DataClient:     dataClient,
+		conn:             conn,
+		RegisterClient:   registerClient,
+		AuthClient:       authClient,
+		DataClient:       dataClient,
+		BinaryClient:     datapb.NewBinaryServiceClient(conn),
+		OrganizerClient:  datapb.NewOrganizerServiceClient(conn),
+		RecordTypeClient: datapb.NewRecordTypeServiceClient(conn),
 	}, nil
 }
 
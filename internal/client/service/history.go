@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"google.golang.org/grpc/metadata"
+)
+
+type historyService struct {
+	client      datapb.DataServiceClient
+	tokenHolder *entity.TokenHolder
+}
+
+// NewHistoryService - конструктор сервиса прежних версий записей. Версии хранятся на сервере
+// зашифрованными ключом хранилища, как и сами записи, и расшифровываются после получения.
+func NewHistoryService(grpcClient *GRPCClient, tokenHolder *entity.TokenHolder) *historyService {
+	return &historyService{client: grpcClient.DataClient, tokenHolder: tokenHolder}
+}
+
+// ListVersions возвращает прежние версии записи id без Info, от новых к старым.
+func (s *historyService) ListVersions(ctx context.Context, token string, id int32) ([]*datapb.DataItem, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	res, err := s.client.ListVersions(ctx, &datapb.ListVersionsRequest{DataId: id})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, version := range res.Versions {
+		if res.Versions[i], err = decryptItem(s.tokenHolder.VaultKey, version); err != nil {
+			return nil, err
+		}
+	}
+
+	return res.Versions, nil
+}
+
+// GetVersion возвращает версию записи id, сохранённую на ревизии revision.
+func (s *historyService) GetVersion(
+	ctx context.Context,
+	token string,
+	id int32,
+	revision int64,
+) (*datapb.DataItem, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	res, err := s.client.GetVersion(ctx, &datapb.GetVersionRequest{DataId: id, Revision: revision})
+	if err != nil {
+		return nil, err
+	}
+
+	return decryptItem(s.tokenHolder.VaultKey, res.Data)
+}
+
+// RestoreVersion возвращает записи id содержимое версии revision и возвращает новую ревизию записи.
+func (s *historyService) RestoreVersion(ctx context.Context, token string, id int32, revision int64) (int64, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	res, err := s.client.RestoreVersion(ctx, &datapb.RestoreVersionRequest{DataId: id, Revision: revision})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Revision, nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHistoryService(t *testing.T) {
+	ctx := context.Background()
+	key := []byte("0123456789abcdef0123456789abcdef")
+	encrypted := func() *datapb.DataItem {
+		item, err := encryptItem(key, &datapb.DataItem{Id: 3, InfoType: "text", Info: []byte("старое"), Meta: "заметка"})
+		assert.NoError(t, err)
+		item.Revision = 4
+		return item
+	}
+
+	client := new(MockDataServiceClient)
+	tokenHolder := &entity.TokenHolder{Token: "token", VaultKey: key}
+	svc := NewHistoryService(&GRPCClient{DataClient: client}, tokenHolder)
+
+	client.On("GetVersion", mock.Anything, &datapb.GetVersionRequest{DataId: 3, Revision: 4}).
+		Return(&datapb.GetVersionResponse{Data: encrypted()}, nil)
+	version, err := svc.GetVersion(ctx, "token", 3, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "старое", string(version.Info))
+	assert.Equal(t, "заметка", version.Meta)
+
+	client.On("ListVersions", mock.Anything, &datapb.ListVersionsRequest{DataId: 3}).
+		Return(&datapb.ListVersionsResponse{Versions: []*datapb.DataItem{encrypted()}}, nil).Once()
+	versions, err := svc.ListVersions(ctx, "token", 3)
+	assert.NoError(t, err)
+	if assert.Len(t, versions, 1) {
+		assert.Equal(t, "заметка", versions[0].Meta)
+	}
+
+	client.On("RestoreVersion", mock.Anything, &datapb.RestoreVersionRequest{DataId: 3, Revision: 4}).
+		Return(&datapb.RestoreVersionResponse{Revision: 12}, nil)
+	revision, err := svc.RestoreVersion(ctx, "token", 3, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), revision)
+
+	client.On("ListVersions", mock.Anything, &datapb.ListVersionsRequest{DataId: 3}).
+		Return(&datapb.ListVersionsResponse{Versions: []*datapb.DataItem{encrypted()}}, nil).Once()
+	tokenHolder.VaultKey = nil
+	_, err = svc.ListVersions(ctx, "token", 3)
+	assert.ErrorIs(t, err, ErrVaultLocked)
+
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/client/infrastructure/localstore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type localStore interface {
+	Use(login string) error
+	Revision() (int64, error)
+	Get(id int32) (*datapb.DataItem, error)
+	List(infoType string) ([]*datapb.DataItem, error)
+	Put(data *datapb.DataItem) error
+	Apply(changes []*datapb.DataChange, revision int64) error
+	Enqueue(kind string, data *datapb.DataItem, id int32) (int32, error)
+	Pending() ([]localstore.Operation, error)
+	Complete(serverID int32) error
+}
+
+// offlineDataService переключается на локальный кеш, когда сервер недоступен: чтение идёт из кеша,
+// а изменения применяются к нему и ждут отправки командой sync. Записи, созданные без сети,
+// получают временные отрицательные ID.
+type offlineDataService struct {
+	next        dataServicer
+	store       localStore
+	tokenHolder *entity.TokenHolder
+}
+
+// NewOfflineDataService - конструктор data service с локальным кешем.
+func NewOfflineDataService(next dataServicer, store localStore, tokenHolder *entity.TokenHolder) *offlineDataService {
+	return &offlineDataService{next: next, store: store, tokenHolder: tokenHolder}
+}
+
+func (s *offlineDataService) AddData(ctx context.Context, token string, data *datapb.DataItem) (int32, error) {
+	id, err := s.next.AddData(ctx, token, data)
+	if !isOffline(err) {
+		return id, err
+	}
+
+	return s.enqueue(localstore.OpAdd, data, 0, err)
+}
+
+func (s *offlineDataService) GetData(ctx context.Context, token string, id int32) (*datapb.DataItem, error) {
+	if id < 0 {
+		return s.cached(func() (*datapb.DataItem, error) { return s.store.Get(id) })
+	}
+
+	item, err := s.next.GetData(ctx, token, id)
+	if err == nil {
+		// Кеш только ускоряет работу без сети, поэтому ошибка его обновления не мешает чтению.
+		if s.store.Use(s.tokenHolder.Login) == nil {
+			_ = s.store.Put(item)
+		}
+		return item, nil
+	}
+	if !isOffline(err) {
+		return nil, err
+	}
+
+	return s.cached(func() (*datapb.DataItem, error) { return s.store.Get(id) })
+}
+
+func (s *offlineDataService) UpdateData(ctx context.Context, token string, data *datapb.DataItem) error {
+	var err error
+	if data.Id >= 0 {
+		err = s.next.UpdateData(ctx, token, data)
+		if !isOffline(err) {
+			return err
+		}
+	}
+
+	_, err = s.enqueue(localstore.OpUpdate, data, data.Id, err)
+	return err
+}
+
+func (s *offlineDataService) DeleteData(ctx context.Context, token string, id int32) error {
+	var err error
+	if id >= 0 {
+		err = s.next.DeleteData(ctx, token, id)
+		if !isOffline(err) {
+			return err
+		}
+	}
+
+	_, err = s.enqueue(localstore.OpDelete, nil, id, err)
+	return err
+}
+
+// ListData без сети возвращает записи из локального кеша одной страницей. Продолжить постраничный
+// обход, начатый на сервере, по кешу нельзя: сервис возвращает ошибку сервера.
+func (s *offlineDataService) ListData(
+	ctx context.Context,
+	token string,
+	filter *entity.DataFilter,
+) (*entity.DataPage, error) {
+	page, err := s.next.ListData(ctx, token, filter)
+	if !isOffline(err) || filter.PageToken != "" {
+		return page, err
+	}
+
+	if err = s.store.Use(s.tokenHolder.Login); err != nil {
+		return nil, err
+	}
+	cached, err := s.store.List(filter.InfoType)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения локального кеша: %w", err)
+	}
+
+	items := make([]*datapb.DataItem, 0, len(cached))
+	for _, item := range cached {
+		if filter.FolderID != nil && item.FolderId != *filter.FolderID {
+			continue
+		}
+		if filter.TagID != 0 && !slices.Contains(item.TagIds, filter.TagID) {
+			continue
+		}
+		// Сервер не отдаёт содержимое записей в списке, кеш ведёт себя так же.
+		item.Info = nil
+		items = append(items, item)
+	}
+
+	return &entity.DataPage{Items: items}, nil
+}
+
+// SearchData без сети ищет в локальном кеше по типу и дате создания и возвращает всё одной страницей.
+// Слова запроса проверяет сервис сквозного шифрования, когда расшифрует Meta.
+func (s *offlineDataService) SearchData(
+	ctx context.Context,
+	token string,
+	query *entity.SearchQuery,
+) (*entity.DataPage, error) {
+	page, err := s.next.SearchData(ctx, token, query)
+	if !isOffline(err) || query.PageToken != "" {
+		return page, err
+	}
+
+	if err = s.store.Use(s.tokenHolder.Login); err != nil {
+		return nil, err
+	}
+	cached, err := s.store.List(query.InfoType)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения локального кеша: %w", err)
+	}
+
+	items := make([]*datapb.DataItem, 0, len(cached))
+	for _, item := range cached {
+		created := item.Created.AsTime()
+		if !query.CreatedFrom.IsZero() && created.Before(query.CreatedFrom) ||
+			!query.CreatedTo.IsZero() && !created.Before(query.CreatedTo) {
+			continue
+		}
+		item.Info = nil
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if query.Ascending {
+			a, b = b, a
+		}
+		if !a.Created.AsTime().Equal(b.Created.AsTime()) {
+			return a.Created.AsTime().After(b.Created.AsTime())
+		}
+		return a.Id > b.Id
+	})
+
+	return &entity.DataPage{Items: items}, nil
+}
+
+func (s *offlineDataService) enqueue(kind string, data *datapb.DataItem, id int32, cause error) (int32, error) {
+	if err := s.store.Use(s.tokenHolder.Login); err != nil {
+		return 0, errors.Join(cause, err)
+	}
+
+	id, err := s.store.Enqueue(kind, data, id)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сохранения изменения в локальный кеш: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *offlineDataService) cached(get func() (*datapb.DataItem, error)) (*datapb.DataItem, error) {
+	if err := s.store.Use(s.tokenHolder.Login); err != nil {
+		return nil, err
+	}
+
+	item, err := get()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения локального кеша: %w", err)
+	}
+
+	return item, nil
+}
+
+// isOffline сообщает, что ошибка вызвана недоступностью сервера, а не отказом в запросе.
+func isOffline(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/client/infrastructure/localstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errServerUnavailable = status.Error(codes.Unavailable, "connection refused")
+
+func newTestStore(t *testing.T) *localstore.Store {
+	t.Helper()
+
+	store, err := localstore.Open(t.TempDir())
+	assert.NoError(t, err)
+
+	return store
+}
+
+func TestOfflineDataService_Online(t *testing.T) {
+	ctx := context.Background()
+	tokenHolder := &entity.TokenHolder{Token: "token", Login: "alice"}
+	store := newTestStore(t)
+
+	next := new(MockDataServicer)
+	svc := NewOfflineDataService(next, store, tokenHolder)
+
+	item := &datapb.DataItem{Id: 5, InfoType: "text", Info: []byte("секрет"), Revision: 3}
+	next.On("GetData", ctx, "token", int32(5)).Return(item, nil).Once()
+	next.On("GetData", ctx, "token", int32(6)).Return(nil, status.Error(codes.NotFound, "данные не найдены"))
+
+	got, err := svc.GetData(ctx, "token", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, item, got)
+
+	_, err = svc.GetData(ctx, "token", 6)
+	assert.Equal(t, codes.NotFound, status.Code(err), "ошибки сервера не подменяются кешем")
+
+	next.On("GetData", ctx, "token", int32(5)).Return(nil, errServerUnavailable)
+
+	cached, err := svc.GetData(ctx, "token", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("секрет"), cached.Info, "прочитанная онлайн запись доступна без сети")
+}
+
+func TestOfflineDataService_Offline(t *testing.T) {
+	ctx := context.Background()
+	tokenHolder := &entity.TokenHolder{Token: "token", Login: "alice"}
+	store := newTestStore(t)
+	assert.NoError(t, store.Use("alice"))
+	assert.NoError(t, store.Apply([]*datapb.DataChange{
+		{Id: 1, Revision: 1, Data: &datapb.DataItem{Id: 1, InfoType: "text", Info: []byte("i"), Meta: "старое"}},
+	}, 1))
+
+	next := new(MockDataServicer)
+	next.On("AddData", ctx, "token", mock.Anything).Return(0, errServerUnavailable)
+	next.On("UpdateData", ctx, "token", mock.Anything).Return(errServerUnavailable)
+	next.On("DeleteData", ctx, "token", mock.Anything).Return(errServerUnavailable)
+	next.On("ListData", ctx, "token", mock.Anything).Return(nil, errServerUnavailable)
+	svc := NewOfflineDataService(next, store, tokenHolder)
+
+	id, err := svc.AddData(ctx, "token", &datapb.DataItem{InfoType: "text", Info: []byte("новое"), Meta: "новое"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(-1), id)
+
+	assert.NoError(t, svc.UpdateData(ctx, "token", &datapb.DataItem{Id: 1, InfoType: "text", Meta: "изменённое"}))
+
+	page, err := svc.ListData(ctx, "token", &entity.DataFilter{InfoType: "text"})
+	assert.NoError(t, err)
+	assert.Empty(t, page.NextPageToken)
+	if assert.Len(t, page.Items, 2) {
+		assert.Equal(t, "изменённое", page.Items[0].Meta)
+		assert.Nil(t, page.Items[1].Info, "список не содержит данных записей")
+	}
+
+	_, err = svc.ListData(ctx, "token", &entity.DataFilter{PageToken: "next"})
+	assert.ErrorIs(t, err, errServerUnavailable, "продолжение серверного списка по кешу не строится")
+
+	got, err := svc.GetData(ctx, "token", -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("новое"), got.Info)
+
+	assert.NoError(t, svc.DeleteData(ctx, "token", 1))
+
+	ops, err := store.Pending()
+	assert.NoError(t, err)
+	assert.Len(t, ops, 2)
+}
+
+func TestOfflineDataService_OfflineFolderAndTag(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	assert.NoError(t, store.Use("alice"))
+	assert.NoError(t, store.Apply([]*datapb.DataChange{
+		{Id: 1, Revision: 1, Data: &datapb.DataItem{Id: 1, InfoType: "text", FolderId: 3, TagIds: []int32{2}}},
+		{Id: 2, Revision: 2, Data: &datapb.DataItem{Id: 2, InfoType: "text", TagIds: []int32{2, 4}}},
+	}, 2))
+
+	next := new(MockDataServicer)
+	next.On("ListData", ctx, "token", mock.Anything).Return(nil, errServerUnavailable)
+	svc := NewOfflineDataService(next, store, &entity.TokenHolder{Token: "token", Login: "alice"})
+
+	folderID := int32(3)
+	page, err := svc.ListData(ctx, "token", &entity.DataFilter{FolderID: &folderID})
+	assert.NoError(t, err)
+	if assert.Len(t, page.Items, 1) {
+		assert.Equal(t, int32(1), page.Items[0].Id)
+	}
+
+	folderID = 0
+	page, err = svc.ListData(ctx, "token", &entity.DataFilter{FolderID: &folderID, TagID: 4})
+	assert.NoError(t, err)
+	if assert.Len(t, page.Items, 1) {
+		assert.Equal(t, int32(2), page.Items[0].Id)
+	}
+
+	page, err = svc.ListData(ctx, "token", &entity.DataFilter{TagID: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+}
+
+func TestOfflineDataService_NoLogin(t *testing.T) {
+	ctx := context.Background()
+
+	next := new(MockDataServicer)
+	next.On("AddData", ctx, "token", mock.Anything).Return(0, errServerUnavailable)
+	svc := NewOfflineDataService(next, newTestStore(t), &entity.TokenHolder{Token: "token"})
+
+	_, err := svc.AddData(ctx, "token", &datapb.DataItem{InfoType: "text"})
+	assert.True(t, errors.Is(err, localstore.ErrNoUser))
+	assert.Equal(t, codes.Unavailable, status.Code(err), "исходная ошибка сервера сохраняется")
+}
+
+func TestOfflineDataService_SearchData(t *testing.T) {
+	ctx := context.Background()
+	tokenHolder := &entity.TokenHolder{Token: "token", Login: "alice"}
+	store := newTestStore(t)
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, store.Use("alice"))
+	assert.NoError(t, store.Apply([]*datapb.DataChange{
+		{Id: 1, Revision: 1, Data: &datapb.DataItem{Id: 1, InfoType: "text", Info: []byte("i"),
+			Created: timestamppb.New(day)}},
+		{Id: 2, Revision: 2, Data: &datapb.DataItem{Id: 2, InfoType: "text", Info: []byte("i"),
+			Created: timestamppb.New(day.Add(time.Hour))}},
+		{Id: 3, Revision: 3, Data: &datapb.DataItem{Id: 3, InfoType: "text", Info: []byte("i"),
+			Created: timestamppb.New(day.Add(-time.Hour))}},
+	}, 3))
+
+	next := new(MockDataServicer)
+	next.On("SearchData", ctx, "token", mock.Anything).Return(nil, errServerUnavailable)
+	svc := NewOfflineDataService(next, store, tokenHolder)
+
+	page, err := svc.SearchData(ctx, "token", &entity.SearchQuery{InfoType: "text", CreatedFrom: day})
+	assert.NoError(t, err)
+	assert.Empty(t, page.NextPageToken)
+	if assert.Len(t, page.Items, 2) {
+		assert.Equal(t, int32(2), page.Items[0].Id, "новые записи первыми")
+		assert.Equal(t, int32(1), page.Items[1].Id)
+		assert.Nil(t, page.Items[0].Info)
+	}
+
+	page, err = svc.SearchData(ctx, "token", &entity.SearchQuery{CreatedTo: day.Add(time.Hour), Ascending: true})
+	assert.NoError(t, err)
+	if assert.Len(t, page.Items, 2) {
+		assert.Equal(t, int32(3), page.Items[0].Id)
+		assert.Equal(t, int32(1), page.Items[1].Id)
+	}
+
+	_, err = svc.SearchData(ctx, "token", &entity.SearchQuery{PageToken: "next"})
+	assert.Equal(t, codes.Unavailable, status.Code(err), "продолжения выдачи сервера в кеше нет")
+}
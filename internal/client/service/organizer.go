@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"google.golang.org/grpc/metadata"
+)
+
+type organizerService struct {
+	client      datapb.OrganizerServiceClient
+	tokenHolder *entity.TokenHolder
+}
+
+// NewOrganizerService - конструктор сервиса папок и тегов. Имена папок и тегов шифруются
+// ключом хранилища так же, как Meta записей, и сервер видит только их ID.
+func NewOrganizerService(grpcClient *GRPCClient, tokenHolder *entity.TokenHolder) *organizerService {
+	return &organizerService{client: grpcClient.OrganizerClient, tokenHolder: tokenHolder}
+}
+
+// CreateFolder создаёт папку name внутри parentID, 0 - в корне. Возвращает ID папки.
+func (s *organizerService) CreateFolder(ctx context.Context, token string, parentID int32, name string) (int32, error) {
+	name, err := s.encryptName(name)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	res, err := s.client.CreateFolder(ctx, &datapb.CreateFolderRequest{ParentId: parentID, Name: name})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Id, nil
+}
+
+// UpdateFolder переименовывает папку folder.ID и переносит её в folder.ParentID.
+func (s *organizerService) UpdateFolder(ctx context.Context, token string, folder *entity.Folder) error {
+	name, err := s.encryptName(folder.Name)
+	if err != nil {
+		return err
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	_, err = s.client.UpdateFolder(ctx, &datapb.UpdateFolderRequest{
+		Folder: &datapb.Folder{Id: folder.ID, ParentId: folder.ParentID, Name: name},
+	})
+
+	return err
+}
+
+func (s *organizerService) DeleteFolder(ctx context.Context, token string, id int32) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	_, err := s.client.DeleteFolder(ctx, &datapb.DeleteFolderRequest{Id: id})
+
+	return err
+}
+
+// ListFolders возвращает все папки пользователя с расшифрованными именами.
+func (s *organizerService) ListFolders(ctx context.Context, token string) ([]*entity.Folder, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	res, err := s.client.ListFolders(ctx, &datapb.ListFoldersRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	folders := make([]*entity.Folder, len(res.Folders))
+	for i, folder := range res.Folders {
+		name, err := s.decryptName(folder.Name)
+		if err != nil {
+			return nil, err
+		}
+		folders[i] = &entity.Folder{ID: folder.Id, ParentID: folder.ParentId, Name: name}
+	}
+
+	return folders, nil
+}
+
+// CreateTag создаёт тег name. Возвращает ID тега.
+func (s *organizerService) CreateTag(ctx context.Context, token, name string) (int32, error) {
+	name, err := s.encryptName(name)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	res, err := s.client.CreateTag(ctx, &datapb.CreateTagRequest{Name: name})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Id, nil
+}
+
+func (s *organizerService) DeleteTag(ctx context.Context, token string, id int32) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	_, err := s.client.DeleteTag(ctx, &datapb.DeleteTagRequest{Id: id})
+
+	return err
+}
+
+// ListTags возвращает все теги пользователя с расшифрованными именами.
+func (s *organizerService) ListTags(ctx context.Context, token string) ([]*entity.Tag, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	res, err := s.client.ListTags(ctx, &datapb.ListTagsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]*entity.Tag, len(res.Tags))
+	for i, tag := range res.Tags {
+		name, err := s.decryptName(tag.Name)
+		if err != nil {
+			return nil, err
+		}
+		tags[i] = &entity.Tag{ID: tag.Id, Name: name}
+	}
+
+	return tags, nil
+}
+
+func (s *organizerService) TagData(ctx context.Context, token string, dataID int32, tagIDs []int32) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	_, err := s.client.TagData(ctx, &datapb.TagDataRequest{DataId: dataID, TagIds: tagIDs})
+
+	return err
+}
+
+func (s *organizerService) UntagData(ctx context.Context, token string, dataID int32, tagIDs []int32) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	_, err := s.client.UntagData(ctx, &datapb.UntagDataRequest{DataId: dataID, TagIds: tagIDs})
+
+	return err
+}
+
+// MoveData переносит запись в папку folderID, 0 - в корень.
+func (s *organizerService) MoveData(ctx context.Context, token string, dataID, folderID int32) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	_, err := s.client.MoveData(ctx, &datapb.MoveDataRequest{DataId: dataID, FolderId: folderID})
+
+	return err
+}
+
+// encryptName шифрует имя папки или тега ключом хранилища. Без ключа имя не шифруется, как и Meta.
+func (s *organizerService) encryptName(name string) (string, error) {
+	key, err := vaultKey(s.tokenHolder)
+	if err != nil {
+		return "", err
+	}
+	if len(key) == 0 {
+		return name, nil
+	}
+
+	encrypted, err := encryptWithVaultKey(key, []byte(name))
+	if err != nil {
+		return "", fmt.Errorf("ошибка шифрования имени: %w", err)
+	}
+
+	return encrypted, nil
+}
+
+func (s *organizerService) decryptName(name string) (string, error) {
+	if !isVaultCiphertext(name) {
+		return name, nil
+	}
+	if len(s.tokenHolder.VaultKey) == 0 {
+		return "", ErrVaultLocked
+	}
+
+	plaintext, err := decryptWithVaultKey(s.tokenHolder.VaultKey, name)
+	if err != nil {
+		return "", fmt.Errorf("ошибка расшифровки имени: %w", err)
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// fakeOrganizerClient хранит папки и теги в памяти. Методы, которые тесты не вызывают,
+// остаются от встроенного nil-интерфейса.
+type fakeOrganizerClient struct {
+	datapb.OrganizerServiceClient
+	folders []*datapb.Folder
+	tags    []*datapb.Tag
+	moved   *datapb.MoveDataRequest
+	token   string
+}
+
+func (f *fakeOrganizerClient) CreateFolder(
+	ctx context.Context,
+	in *datapb.CreateFolderRequest,
+	_ ...grpc.CallOption,
+) (*datapb.CreateFolderResponse, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	f.token = md.Get("authorization")[0]
+	id := int32(len(f.folders) + 1)
+	f.folders = append(f.folders, &datapb.Folder{Id: id, ParentId: in.ParentId, Name: in.Name})
+	return &datapb.CreateFolderResponse{Id: id}, nil
+}
+
+func (f *fakeOrganizerClient) ListFolders(
+	_ context.Context,
+	_ *datapb.ListFoldersRequest,
+	_ ...grpc.CallOption,
+) (*datapb.ListFoldersResponse, error) {
+	return &datapb.ListFoldersResponse{Folders: f.folders}, nil
+}
+
+func (f *fakeOrganizerClient) CreateTag(
+	_ context.Context,
+	in *datapb.CreateTagRequest,
+	_ ...grpc.CallOption,
+) (*datapb.CreateTagResponse, error) {
+	id := int32(len(f.tags) + 1)
+	f.tags = append(f.tags, &datapb.Tag{Id: id, Name: in.Name})
+	return &datapb.CreateTagResponse{Id: id}, nil
+}
+
+func (f *fakeOrganizerClient) ListTags(
+	_ context.Context,
+	_ *datapb.ListTagsRequest,
+	_ ...grpc.CallOption,
+) (*datapb.ListTagsResponse, error) {
+	return &datapb.ListTagsResponse{Tags: f.tags}, nil
+}
+
+func (f *fakeOrganizerClient) MoveData(
+	_ context.Context,
+	in *datapb.MoveDataRequest,
+	_ ...grpc.CallOption,
+) (*datapb.MoveDataResponse, error) {
+	f.moved = in
+	return &datapb.MoveDataResponse{}, nil
+}
+
+func TestOrganizerService_EncryptedNames(t *testing.T) {
+	ctx := context.Background()
+	key := []byte("0123456789abcdef0123456789abcdef")
+	client := &fakeOrganizerClient{}
+	tokenHolder := &entity.TokenHolder{Token: "token", VaultKey: key}
+	svc := NewOrganizerService(&GRPCClient{OrganizerClient: client}, tokenHolder)
+
+	id, err := svc.CreateFolder(ctx, "token", 0, "работа")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), id)
+	assert.Equal(t, "token", client.token)
+	assert.True(t, isVaultCiphertext(client.folders[0].Name), "имя папки уходит на сервер зашифрованным")
+
+	_, err = svc.CreateTag(ctx, "token", "личное")
+	assert.NoError(t, err)
+	assert.True(t, isVaultCiphertext(client.tags[0].Name), "имя тега уходит на сервер зашифрованным")
+
+	folders, err := svc.ListFolders(ctx, "token")
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.Folder{{ID: 1, Name: "работа"}}, folders)
+
+	tags, err := svc.ListTags(ctx, "token")
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.Tag{{ID: 1, Name: "личное"}}, tags)
+
+	assert.NoError(t, svc.MoveData(ctx, "token", 5, 1))
+	assert.Equal(t, int32(5), client.moved.DataId)
+	assert.Equal(t, int32(1), client.moved.FolderId)
+
+	tokenHolder.VaultKey = nil
+	tokenHolder.KDFParams = &entity.KDFParams{Salt: []byte("salt")}
+	_, err = svc.ListTags(ctx, "token")
+	assert.ErrorIs(t, err, ErrVaultLocked)
+	_, err = svc.CreateTag(ctx, "token", "ещё")
+	assert.ErrorIs(t, err, ErrVaultLocked)
+	assert.Len(t, client.tags, 1, "без ключа имя не отправляется на сервер")
+}
+
+func TestOrganizerService_WithoutVaultKey(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeOrganizerClient{}
+	svc := NewOrganizerService(&GRPCClient{OrganizerClient: client}, &entity.TokenHolder{Token: "token"})
+
+	_, err := svc.CreateFolder(ctx, "token", 0, "работа")
+	assert.NoError(t, err)
+	assert.Equal(t, "работа", client.folders[0].Name)
+}
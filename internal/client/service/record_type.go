@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+	"google.golang.org/grpc/metadata"
+)
+
+type recordTypeService struct {
+	client datapb.RecordTypeServiceClient
+}
+
+// NewRecordTypeService - конструктор сервиса пользовательских типов записей. Схемы типов хранит сервер,
+// поэтому без сети записи пользовательских типов выводятся без схемы, а добавить их нельзя.
+func NewRecordTypeService(grpcClient *GRPCClient) *recordTypeService {
+	return &recordTypeService{client: grpcClient.RecordTypeClient}
+}
+
+// CreateRecordType проверяет схему типа и создаёт его. Возвращает ID типа.
+func (s *recordTypeService) CreateRecordType(
+	ctx context.Context,
+	token string,
+	recordType *datapb.RecordType,
+) (int32, error) {
+	if err := payload.ValidateSchema(recordType); err != nil {
+		return 0, err
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	res, err := s.client.CreateRecordType(ctx, &datapb.CreateRecordTypeRequest{RecordType: recordType})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Id, nil
+}
+
+func (s *recordTypeService) ListRecordTypes(ctx context.Context, token string) ([]*datapb.RecordType, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	res, err := s.client.ListRecordTypes(ctx, &datapb.ListRecordTypesRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.RecordTypes, nil
+}
+
+// RecordType возвращает тип записей по имени или entity.ErrRecordTypeNotFound.
+func (s *recordTypeService) RecordType(ctx context.Context, token, name string) (*datapb.RecordType, error) {
+	recordTypes, err := s.ListRecordTypes(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, recordType := range recordTypes {
+		if recordType.Name == name {
+			return recordType, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", entity.ErrRecordTypeNotFound, name)
+}
+
+// DeleteRecordType удаляет тип записей по имени. Тип, у которого есть записи, сервер не удаляет.
+func (s *recordTypeService) DeleteRecordType(ctx context.Context, token, name string) error {
+	recordType, err := s.RecordType(ctx, token, name)
+	if err != nil {
+		return err
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	_, err = s.client.DeleteRecordType(ctx, &datapb.DeleteRecordTypeRequest{Id: recordType.Id})
+
+	return err
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// fakeRecordTypeClient хранит типы записей в памяти.
+type fakeRecordTypeClient struct {
+	recordTypes []*datapb.RecordType
+	deleted     int32
+}
+
+func (f *fakeRecordTypeClient) CreateRecordType(
+	_ context.Context,
+	in *datapb.CreateRecordTypeRequest,
+	_ ...grpc.CallOption,
+) (*datapb.CreateRecordTypeResponse, error) {
+	id := int32(len(f.recordTypes) + 1)
+	in.RecordType.Id = id
+	f.recordTypes = append(f.recordTypes, in.RecordType)
+	return &datapb.CreateRecordTypeResponse{Id: id}, nil
+}
+
+func (f *fakeRecordTypeClient) ListRecordTypes(
+	_ context.Context,
+	_ *datapb.ListRecordTypesRequest,
+	_ ...grpc.CallOption,
+) (*datapb.ListRecordTypesResponse, error) {
+	return &datapb.ListRecordTypesResponse{RecordTypes: f.recordTypes}, nil
+}
+
+func (f *fakeRecordTypeClient) DeleteRecordType(
+	_ context.Context,
+	in *datapb.DeleteRecordTypeRequest,
+	_ ...grpc.CallOption,
+) (*datapb.DeleteRecordTypeResponse, error) {
+	f.deleted = in.Id
+	return &datapb.DeleteRecordTypeResponse{}, nil
+}
+
+func TestRecordTypeService(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeRecordTypeClient{}
+	svc := NewRecordTypeService(&GRPCClient{RecordTypeClient: client})
+
+	_, err := svc.CreateRecordType(ctx, "token", &datapb.RecordType{Name: "wifi"})
+	assert.ErrorIs(t, err, payload.ErrNoFields)
+	assert.Empty(t, client.recordTypes, "некорректная схема не отправляется на сервер")
+
+	wifi := &datapb.RecordType{Name: "wifi", Fields: []*datapb.RecordField{{Name: "ssid"}}}
+	id, err := svc.CreateRecordType(ctx, "token", wifi)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), id)
+
+	found, err := svc.RecordType(ctx, "token", "wifi")
+	assert.NoError(t, err)
+	assert.Equal(t, "ssid", found.Fields[0].Name)
+
+	_, err = svc.RecordType(ctx, "token", "passport")
+	assert.ErrorIs(t, err, entity.ErrRecordTypeNotFound)
+
+	assert.NoError(t, svc.DeleteRecordType(ctx, "token", "wifi"))
+	assert.Equal(t, int32(1), client.deleted)
+	assert.ErrorIs(t, svc.DeleteRecordType(ctx, "token", "passport"), entity.ErrRecordTypeNotFound)
+}
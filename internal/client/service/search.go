@@ -0,0 +1,140 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode"
+)
+
+const (
+	// searchKeyLabel - назначение ключа токенов поиска, выводимого из ключа хранилища.
+	searchKeyLabel = "goph-keeper search tokens"
+	// maxSearchTokens - сколько токенов отправляется для одной записи, лишние отбрасываются.
+	maxSearchTokens = 512
+	// maxSearchTokenLength - ограничение сервера на длину токена. Длинные слова находятся по триграммам.
+	maxSearchTokenLength = 128
+	searchGramLength     = 3
+	searchTokenBytes     = 16
+)
+
+// SearchTokens возвращает токены поиска для текста meta: каждое слово целиком и все его триграммы.
+// Слова выделяются по буквам и цифрам без учёта регистра. С ключом хранилища токены заменяются
+// их HMAC ключом, выведенным из него, и сервер не может восстановить по ним слова.
+func SearchTokens(key []byte, meta string) []string {
+	var tokens []string
+	seen := make(map[string]struct{})
+	add := func(token string) {
+		if _, ok := seen[token]; ok || len(tokens) == maxSearchTokens || len(token) > maxSearchTokenLength {
+			return
+		}
+		seen[token] = struct{}{}
+		tokens = append(tokens, token)
+	}
+
+	for _, word := range searchWords(meta) {
+		add("w:" + word)
+		for _, gram := range trigrams(word) {
+			add("g:" + gram)
+		}
+	}
+
+	return blindSearchTokens(key, tokens)
+}
+
+// QueryTokens возвращает токены запроса: триграммы слов длиной от трёх символов и короткие слова целиком.
+// Запись подходит, если у неё есть все токены запроса. Триграммы находят слово по любой его части,
+// но могут совпасть и без вхождения подстроки, поэтому результат уточняется MatchesQuery.
+func QueryTokens(key []byte, query string) []string {
+	var tokens []string
+	seen := make(map[string]struct{})
+	for _, word := range searchWords(query) {
+		wordTokens := []string{"w:" + word}
+		if grams := trigrams(word); len(grams) > 0 {
+			wordTokens = wordTokens[:0]
+			for _, gram := range grams {
+				wordTokens = append(wordTokens, "g:"+gram)
+			}
+		}
+
+		for _, token := range wordTokens {
+			if _, ok := seen[token]; !ok {
+				seen[token] = struct{}{}
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	return blindSearchTokens(key, tokens)
+}
+
+// MatchesQuery сообщает, что каждое слово запроса входит в meta как подстрока без учёта регистра,
+// а слова короче трёх символов совпадают со словом meta целиком.
+func MatchesQuery(meta, query string) bool {
+	words := searchWords(meta)
+	lowerMeta := strings.Join(words, " ")
+
+	for _, queryWord := range searchWords(query) {
+		if len(trigrams(queryWord)) > 0 {
+			if !strings.Contains(lowerMeta, queryWord) {
+				return false
+			}
+			continue
+		}
+
+		found := false
+		for _, word := range words {
+			if word == queryWord {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func trigrams(word string) []string {
+	runes := []rune(word)
+	if len(runes) < searchGramLength {
+		return nil
+	}
+
+	grams := make([]string, 0, len(runes)-searchGramLength+1)
+	for i := 0; i+searchGramLength <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+searchGramLength]))
+	}
+
+	return grams
+}
+
+// blindSearchTokens заменяет токены их HMAC ключом поиска. Без ключа хранилища токены не меняются:
+// meta в этом случае и так доступна серверу, а в базе он хранит только свой слепой индекс.
+func blindSearchTokens(key []byte, tokens []string) []string {
+	if len(key) == 0 {
+		return tokens
+	}
+
+	keyMAC := hmac.New(sha256.New, key)
+	keyMAC.Write([]byte(searchKeyLabel))
+	searchKey := keyMAC.Sum(nil)
+
+	blinded := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		mac := hmac.New(sha256.New, searchKey)
+		mac.Write([]byte(token))
+		blinded = append(blinded, hex.EncodeToString(mac.Sum(nil)[:searchTokenBytes]))
+	}
+
+	return blinded
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTokens(t *testing.T) {
+	assert.Equal(t,
+		[]string{"w:vpn", "g:vpn", "w:рабочий", "g:раб", "g:або", "g:боч", "g:очи", "g:чий", "w:2"},
+		SearchTokens(nil, "VPN: рабочий, 2"),
+	)
+	assert.Empty(t, SearchTokens(nil, " — "))
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	blinded := SearchTokens(key, "рабочий")
+	assert.Len(t, blinded, 6)
+	assert.Equal(t, blinded, SearchTokens(key, "Рабочий"), "токены не зависят от регистра")
+	assert.NotEqual(t, blinded, SearchTokens([]byte("fedcba9876543210fedcba9876543210"), "рабочий"))
+}
+
+func TestQueryTokens(t *testing.T) {
+	assert.Equal(t, []string{"g:боч", "g:очи", "w:2"}, QueryTokens(nil, "бочи 2"))
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	indexed := SearchTokens(key, "рабочий VPN")
+	for _, token := range QueryTokens(key, "очий vpn") {
+		assert.Contains(t, indexed, token)
+	}
+}
+
+func TestMatchesQuery(t *testing.T) {
+	tests := []struct {
+		meta  string
+		query string
+		want  bool
+	}{
+		{meta: "Рабочая почта", query: "ПОЧТ", want: true},
+		{meta: "Рабочая почта", query: "почта рабочая", want: true},
+		{meta: "почитать потом", query: "почт", want: false},
+		{meta: "карта 2", query: "2", want: true},
+		{meta: "карта 22", query: "2", want: false},
+		{meta: "что угодно", query: "", want: true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MatchesQuery(tt.meta, tt.query), "%q / %q", tt.meta, tt.query)
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/client/infrastructure/localstore"
+)
+
+const syncPageSize = 500
+
+type syncClient interface {
+	AddData(ctx context.Context, token string, data *datapb.DataItem) (int32, error)
+	UpdateData(ctx context.Context, token string, data *datapb.DataItem) error
+	DeleteData(ctx context.Context, token string, id int32) error
+	SyncData(ctx context.Context, token string, sinceRevision int64, limit int32) (*datapb.SyncDataResponse, error)
+}
+
+type syncService struct {
+	remote      syncClient
+	store       localStore
+	tokenHolder *entity.TokenHolder
+}
+
+// NewSyncService - конструктор сервиса двусторонней синхронизации локального кеша с сервером.
+// remote должен работать с данными в том виде, в котором они хранятся на сервере, без сквозного шифрования.
+func NewSyncService(remote syncClient, store localStore, tokenHolder *entity.TokenHolder) *syncService {
+	return &syncService{remote: remote, store: store, tokenHolder: tokenHolder}
+}
+
+// Sync отправляет изменения, сделанные без сети, затем получает с сервера всё, что изменилось
+// после последней синхронизации. Если запись успели изменить на другом устройстве, локальная правка
+// не затирает её, а сохраняется на сервере отдельной записью-копией.
+func (s *syncService) Sync(ctx context.Context, token string) (*entity.SyncResult, error) {
+	if err := s.store.Use(s.tokenHolder.Login); err != nil {
+		return nil, err
+	}
+
+	result := &entity.SyncResult{}
+
+	if err := s.push(ctx, token, result); err != nil {
+		return result, err
+	}
+
+	since, err := s.store.Revision()
+	if err != nil {
+		return result, err
+	}
+	for {
+		resp, err := s.remote.SyncData(ctx, token, since, syncPageSize)
+		if err != nil {
+			return result, fmt.Errorf("ошибка получения изменений: %w", err)
+		}
+		if err = s.store.Apply(resp.Changes, resp.Revision); err != nil {
+			return result, fmt.Errorf("ошибка сохранения изменений в локальный кеш: %w", err)
+		}
+
+		result.Pulled += len(resp.Changes)
+		since = resp.Revision
+		if !resp.HasMore {
+			break
+		}
+	}
+	result.Revision = since
+
+	return result, nil
+}
+
+func (s *syncService) push(ctx context.Context, token string, result *entity.SyncResult) error {
+	ops, err := s.store.Pending()
+	if err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		var serverID int32
+		switch op.Kind {
+		case localstore.OpAdd:
+			op.Item.Id = 0
+			serverID, err = s.remote.AddData(ctx, token, op.Item)
+		case localstore.OpUpdate:
+			// Ревизия в правке - та, на которой запись была прочитана, сервер сверит её с текущей.
+			op.Item.Id = op.ID
+			err = s.remote.UpdateData(ctx, token, op.Item)
+
+			// Правка записи, которую удалили на другом устройстве, тоже сохраняется копией.
+			var conflict *entity.ConflictError
+			if errors.As(err, &conflict) || errors.Is(err, entity.ErrNotFound) {
+				op.Item.Id = 0
+				op.Item.Revision = 0
+				_, err = s.remote.AddData(ctx, token, op.Item)
+				result.Conflicts++
+			}
+		case localstore.OpDelete:
+			err = s.remote.DeleteData(ctx, token, op.ID)
+			// Запись уже удалили на другом устройстве.
+			if errors.Is(err, entity.ErrNotFound) {
+				err = nil
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("ошибка отправки изменения записи %d: %w", op.ID, err)
+		}
+
+		if err = s.store.Complete(serverID); err != nil {
+			return fmt.Errorf("ошибка обновления локального кеша: %w", err)
+		}
+		result.Pushed++
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/client/infrastructure/localstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSyncClient struct {
+	MockDataServicer
+}
+
+func (m *MockSyncClient) SyncData(
+	ctx context.Context,
+	token string,
+	sinceRevision int64,
+	limit int32,
+) (*datapb.SyncDataResponse, error) {
+	args := m.Called(ctx, token, sinceRevision, limit)
+	resp, _ := args.Get(0).(*datapb.SyncDataResponse)
+	return resp, args.Error(1)
+}
+
+func TestSyncService_Sync(t *testing.T) {
+	ctx := context.Background()
+	tokenHolder := &entity.TokenHolder{Token: "token", Login: "alice"}
+	store := newTestStore(t)
+	assert.NoError(t, store.Use("alice"))
+	assert.NoError(t, store.Apply([]*datapb.DataChange{
+		{Id: 1, Revision: 4, Data: &datapb.DataItem{Id: 1, InfoType: "text", Meta: "старое"}},
+	}, 4))
+
+	localID, err := store.Enqueue(localstore.OpAdd, &datapb.DataItem{InfoType: "text", Meta: "новое"}, 0)
+	assert.NoError(t, err)
+	_, err = store.Enqueue(localstore.OpDelete, nil, 1)
+	assert.NoError(t, err)
+
+	remote := new(MockSyncClient)
+	remote.On("AddData", ctx, "token", mock.MatchedBy(func(item *datapb.DataItem) bool {
+		return item.Id == 0 && item.Meta == "новое"
+	})).Return(10, nil)
+	remote.On("DeleteData", ctx, "token", int32(1)).Return(nil)
+	remote.On("SyncData", ctx, "token", int64(4), int32(syncPageSize)).Return(&datapb.SyncDataResponse{
+		Changes: []*datapb.DataChange{
+			{Id: 10, Revision: 5, Data: &datapb.DataItem{Id: 10, InfoType: "text", Meta: "новое", Revision: 5}},
+		},
+		Revision: 5,
+		HasMore:  true,
+	}, nil)
+	remote.On("SyncData", ctx, "token", int64(5), int32(syncPageSize)).Return(&datapb.SyncDataResponse{
+		Changes: []*datapb.DataChange{
+			{Id: 1, Revision: 6, Deleted: true},
+			{Id: 11, Revision: 7, Data: &datapb.DataItem{Id: 11, InfoType: "text", Meta: "с другого устройства"}},
+		},
+		Revision: 7,
+	}, nil)
+
+	result, err := NewSyncService(remote, store, tokenHolder).Sync(ctx, "token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.SyncResult{Revision: 7, Pushed: 2, Pulled: 3}, result)
+
+	items, err := store.List("")
+	assert.NoError(t, err)
+	if assert.Len(t, items, 2) {
+		ids := []int32{items[0].Id, items[1].Id}
+		assert.ElementsMatch(t, []int32{10, 11}, ids)
+	}
+	_, err = store.Get(localID)
+	assert.ErrorIs(t, err, localstore.ErrNotFound)
+
+	remote.AssertExpectations(t)
+}
+
+func TestSyncService_ConflictSavedAsCopy(t *testing.T) {
+	ctx := context.Background()
+	tokenHolder := &entity.TokenHolder{Token: "token", Login: "alice"}
+	store := newTestStore(t)
+	assert.NoError(t, store.Use("alice"))
+	assert.NoError(t, store.Apply([]*datapb.DataChange{
+		{Id: 1, Revision: 4, Data: &datapb.DataItem{Id: 1, InfoType: "text", Meta: "старое", Revision: 4}},
+	}, 4))
+	_, err := store.Enqueue(localstore.OpUpdate, &datapb.DataItem{Id: 1, InfoType: "text", Meta: "моё"}, 1)
+	assert.NoError(t, err)
+
+	remote := new(MockSyncClient)
+	remote.On("UpdateData", ctx, "token", mock.MatchedBy(func(item *datapb.DataItem) bool {
+		return item.Id == 1 && item.Revision == 4
+	})).Return(&entity.ConflictError{}).Once()
+	remote.On("AddData", ctx, "token", mock.MatchedBy(func(item *datapb.DataItem) bool {
+		return item.Id == 0 && item.Meta == "моё"
+	})).Return(12, nil)
+	remote.On("SyncData", ctx, "token", int64(4), int32(syncPageSize)).Return(&datapb.SyncDataResponse{
+		Changes: []*datapb.DataChange{
+			{Id: 1, Revision: 5, Data: &datapb.DataItem{Id: 1, InfoType: "text", Meta: "чужое", Revision: 5}},
+			{Id: 12, Revision: 6, Data: &datapb.DataItem{Id: 12, InfoType: "text", Meta: "моё", Revision: 6}},
+		},
+		Revision: 6,
+	}, nil)
+
+	result, err := NewSyncService(remote, store, tokenHolder).Sync(ctx, "token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.SyncResult{Revision: 6, Pushed: 1, Pulled: 2, Conflicts: 1}, result)
+	item, err := store.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "чужое", item.Meta, "правка с другого устройства не затёрта")
+	item, err = store.Get(12)
+	assert.NoError(t, err)
+	assert.Equal(t, "моё", item.Meta)
+	remote.AssertExpectations(t)
+}
+
+func TestSyncService_PushDeletedOnServer(t *testing.T) {
+	ctx := context.Background()
+	tokenHolder := &entity.TokenHolder{Token: "token", Login: "alice"}
+	store := newTestStore(t)
+	assert.NoError(t, store.Use("alice"))
+	assert.NoError(t, store.Apply([]*datapb.DataChange{
+		{Id: 1, Revision: 4, Data: &datapb.DataItem{Id: 1, InfoType: "text", Meta: "первое", Revision: 4}},
+		{Id: 2, Revision: 5, Data: &datapb.DataItem{Id: 2, InfoType: "text", Meta: "второе", Revision: 5}},
+	}, 5))
+	_, err := store.Enqueue(localstore.OpUpdate, &datapb.DataItem{Id: 1, InfoType: "text", Meta: "моё"}, 1)
+	assert.NoError(t, err)
+	_, err = store.Enqueue(localstore.OpDelete, nil, 2)
+	assert.NoError(t, err)
+
+	remote := new(MockSyncClient)
+	remote.On("UpdateData", ctx, "token", mock.Anything).
+		Return(&entity.RecordError{Kind: entity.ErrNotFound, ID: "1"}).Once()
+	remote.On("AddData", ctx, "token", mock.MatchedBy(func(item *datapb.DataItem) bool {
+		return item.Id == 0 && item.Meta == "моё"
+	})).Return(12, nil)
+	remote.On("DeleteData", ctx, "token", int32(2)).Return(&entity.RecordError{Kind: entity.ErrNotFound, ID: "2"})
+	remote.On("SyncData", ctx, "token", int64(5), int32(syncPageSize)).Return(&datapb.SyncDataResponse{
+		Revision: 5,
+	}, nil)
+
+	result, err := NewSyncService(remote, store, tokenHolder).Sync(ctx, "token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.SyncResult{Revision: 5, Pushed: 2, Conflicts: 1}, result)
+	ops, err := store.Pending()
+	assert.NoError(t, err)
+	assert.Empty(t, ops, "изменения удалённых на сервере записей не остаются в очереди")
+	remote.AssertExpectations(t)
+}
+
+func TestSyncService_PushStopsWhenOffline(t *testing.T) {
+	ctx := context.Background()
+	tokenHolder := &entity.TokenHolder{Token: "token", Login: "alice"}
+	store := newTestStore(t)
+	assert.NoError(t, store.Use("alice"))
+
+	_, err := store.Enqueue(localstore.OpAdd, &datapb.DataItem{InfoType: "text"}, 0)
+	assert.NoError(t, err)
+
+	remote := new(MockSyncClient)
+	remote.On("AddData", ctx, "token", mock.Anything).Return(0, errServerUnavailable)
+
+	result, err := NewSyncService(remote, store, tokenHolder).Sync(ctx, "token")
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, result.Pushed)
+	ops, err := store.Pending()
+	assert.NoError(t, err)
+	assert.Len(t, ops, 1, "неотправленное изменение остаётся в очереди")
+	remote.AssertNotCalled(t, "SyncData", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/authpb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodsWithoutToken - вызовы, которые не используют access токен и не должны его обновлять.
+var methodsWithoutToken = map[string]bool{
+	authpb.Auth_LoginUser_FullMethodName:       true,
+	authpb.Auth_VerifyLoginCode_FullMethodName: true,
+	authpb.Auth_RefreshToken_FullMethodName:    true,
+	"/register.Register/RegisterUser":          true,
+}
+
+// TokenRefresher при ответе Unauthenticated один раз обменивает refresh токен на новую пару
+// и повторяет вызов с новым access токеном. Если по claim exp токен уже истёк, он обновляется
+// до вызова, чтобы не отправлять на сервер заведомо отклоняемый запрос.
+type TokenRefresher struct {
+	tokenHolder   *entity.TokenHolder
+	newAuthClient func(cc grpc.ClientConnInterface) authpb.AuthClient
+	onRefresh     func()
+	now           func() time.Time
+	mu            sync.Mutex
+}
+
+// NewTokenRefresher - конструктор перехватчика, обновляющего истёкший access токен.
+func NewTokenRefresher(tokenHolder *entity.TokenHolder) *TokenRefresher {
+	return &TokenRefresher{tokenHolder: tokenHolder, newAuthClient: authpb.NewAuthClient, now: time.Now}
+}
+
+// OnRefresh задаёт функцию, которая вызывается после смены токенов, например для сохранения сессии:
+// refresh токен одноразовый, и старый после обмена уже недействителен.
+func (r *TokenRefresher) OnRefresh(fn func()) *TokenRefresher {
+	r.onRefresh = fn
+	return r
+}
+
+// Unary возвращает клиентский unary перехватчик.
+func (r *TokenRefresher) Unary() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if !methodsWithoutToken[method] {
+			ctx = r.refreshExpired(ctx, cc)
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unauthenticated || methodsWithoutToken[method] {
+			return err
+		}
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if !ok || len(md.Get("authorization")) == 0 {
+			return err
+		}
+
+		token, refreshErr := r.refresh(ctx, md.Get("authorization")[0], r.newAuthClient(cc))
+		if refreshErr != nil {
+			return err
+		}
+
+		md = md.Copy()
+		md.Set("authorization", token)
+
+		return invoker(metadata.NewOutgoingContext(ctx, md), method, req, reply, cc, opts...)
+	}
+}
+
+// refreshExpired заменяет в запросе истёкший токен сессии на новый. Если обновить не удалось,
+// запрос уходит со старым токеном, и ошибку вернёт сервер.
+func (r *TokenRefresher) refreshExpired(ctx context.Context, cc grpc.ClientConnInterface) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok || len(md.Get("authorization")) == 0 {
+		return ctx
+	}
+
+	token := md.Get("authorization")[0]
+	r.mu.Lock()
+	expired := token == r.tokenHolder.Token && r.tokenHolder.Expired(r.now())
+	r.mu.Unlock()
+	if !expired {
+		return ctx
+	}
+
+	fresh, err := r.refresh(ctx, token, r.newAuthClient(cc))
+	if err != nil {
+		return ctx
+	}
+
+	md = md.Copy()
+	md.Set("authorization", fresh)
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// refresh возвращает действующий access токен. Если токен уже обновил параллельный вызов,
+// повторно refresh токен не предъявляется: он одноразовый.
+func (r *TokenRefresher) refresh(ctx context.Context, rejectedToken string, client authpb.AuthClient) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.tokenHolder.Token != "" && r.tokenHolder.Token != rejectedToken {
+		return r.tokenHolder.Token, nil
+	}
+	if r.tokenHolder.RefreshToken == "" {
+		return "", status.Error(codes.Unauthenticated, "refresh токен отсутствует")
+	}
+
+	res, err := client.RefreshToken(ctx, &authpb.RefreshTokenRequest{RefreshToken: r.tokenHolder.RefreshToken})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			r.tokenHolder.Token = ""
+			r.tokenHolder.RefreshToken = ""
+			r.notify()
+		}
+
+		return "", err
+	}
+
+	r.tokenHolder.Token = res.BearerToken
+	r.tokenHolder.RefreshToken = res.RefreshToken
+	r.notify()
+
+	return res.BearerToken, nil
+}
+
+func (r *TokenRefresher) notify() {
+	if r.onRefresh != nil {
+		r.onRefresh()
+	}
+}
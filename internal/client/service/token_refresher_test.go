@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/authpb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testDataMethod = "/data.DataService/ListData"
+
+func newTestRefresher(holder *entity.TokenHolder, client *MockAuthClient) *TokenRefresher {
+	refresher := NewTokenRefresher(holder)
+	refresher.newAuthClient = func(grpc.ClientConnInterface) authpb.AuthClient { return client }
+	return refresher
+}
+
+// recordingInvoker отвечает Unauthenticated на все токены, кроме valid, и запоминает присланные токены.
+func recordingInvoker(valid string, seen *[]string) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		token := md.Get("authorization")[0]
+		*seen = append(*seen, token)
+		if token != valid {
+			return status.Error(codes.Unauthenticated, "токен истёк")
+		}
+		return nil
+	}
+}
+
+func TestTokenRefresher_RefreshesAndRetries(t *testing.T) {
+	holder := &entity.TokenHolder{Token: "expired", RefreshToken: "refresh1"}
+	client := new(MockAuthClient)
+	client.On("RefreshToken", mock.Anything, &authpb.RefreshTokenRequest{RefreshToken: "refresh1"}, mock.Anything).
+		Return(&authpb.RefreshTokenResponse{BearerToken: "fresh", RefreshToken: "refresh2"}, nil).Once()
+
+	var seen []string
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "expired")
+
+	err := newTestRefresher(holder, client).Unary()(ctx, testDataMethod, nil, nil, nil, recordingInvoker("fresh", &seen))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"expired", "fresh"}, seen)
+	assert.Equal(t, &entity.TokenHolder{Token: "fresh", RefreshToken: "refresh2"}, holder)
+	client.AssertExpectations(t)
+}
+
+func TestTokenRefresher_UsesTokenRefreshedByAnotherCall(t *testing.T) {
+	holder := &entity.TokenHolder{Token: "fresh", RefreshToken: "refresh2"}
+	client := new(MockAuthClient)
+
+	var seen []string
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "expired")
+
+	err := newTestRefresher(holder, client).Unary()(ctx, testDataMethod, nil, nil, nil, recordingInvoker("fresh", &seen))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"expired", "fresh"}, seen)
+	client.AssertNotCalled(t, "RefreshToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTokenRefresher_RefreshRejected(t *testing.T) {
+	holder := &entity.TokenHolder{Token: "expired", RefreshToken: "reused"}
+	client := new(MockAuthClient)
+	client.On("RefreshToken", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.Unauthenticated, "недействительный refresh токен"))
+
+	var seen []string
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "expired")
+
+	err := newTestRefresher(holder, client).Unary()(ctx, testDataMethod, nil, nil, nil, recordingInvoker("fresh", &seen))
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, []string{"expired"}, seen)
+	assert.Empty(t, holder.Token)
+	assert.Empty(t, holder.RefreshToken)
+}
+
+func TestTokenRefresher_NotifiesWhenRefreshRejected(t *testing.T) {
+	holder := &entity.TokenHolder{Token: "expired", RefreshToken: "reused"}
+	client := new(MockAuthClient)
+	client.On("RefreshToken", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.Unauthenticated, "недействительный refresh токен"))
+
+	saved := 0
+	refresher := newTestRefresher(holder, client).OnRefresh(func() { saved++ })
+
+	var seen []string
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "expired")
+
+	err := refresher.Unary()(ctx, testDataMethod, nil, nil, nil, recordingInvoker("fresh", &seen))
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, 1, saved, "сессия без токенов тоже сохраняется, чтобы её удалить")
+}
+
+func TestTokenRefresher_SkipsLogin(t *testing.T) {
+	holder := &entity.TokenHolder{Token: "expired", RefreshToken: "refresh1"}
+	client := new(MockAuthClient)
+
+	var seen []string
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "expired")
+
+	err := newTestRefresher(holder, client).Unary()(
+		ctx, authpb.Auth_LoginUser_FullMethodName, nil, nil, nil, recordingInvoker("fresh", &seen),
+	)
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, []string{"expired"}, seen)
+	client.AssertNotCalled(t, "RefreshToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTokenRefresher_RefreshesExpiredBeforeCall(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(now.Add(-time.Second)),
+	}).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+
+	holder := &entity.TokenHolder{Token: expired, RefreshToken: "refresh1"}
+	client := new(MockAuthClient)
+	client.On("RefreshToken", mock.Anything, &authpb.RefreshTokenRequest{RefreshToken: "refresh1"}, mock.Anything).
+		Return(&authpb.RefreshTokenResponse{BearerToken: "fresh", RefreshToken: "refresh2"}, nil).Once()
+
+	saved := 0
+	refresher := newTestRefresher(holder, client).OnRefresh(func() { saved++ })
+	refresher.now = func() time.Time { return now }
+
+	var seen []string
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", expired)
+
+	err = refresher.Unary()(ctx, testDataMethod, nil, nil, nil, recordingInvoker("fresh", &seen))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fresh"}, seen, "истёкший токен не отправляется на сервер")
+	assert.Equal(t, "refresh2", holder.RefreshToken)
+	assert.Equal(t, 1, saved)
+	client.AssertExpectations(t)
+}
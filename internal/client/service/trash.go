@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"google.golang.org/grpc/metadata"
+)
+
+type trashService struct {
+	client      datapb.DataServiceClient
+	tokenHolder *entity.TokenHolder
+}
+
+// NewTrashService - конструктор сервиса корзины. Удалённые записи лежат в корзине на сервере,
+// пока их не вернут, не удалят навсегда или пока не истечёт срок хранения.
+func NewTrashService(grpcClient *GRPCClient, tokenHolder *entity.TokenHolder) *trashService {
+	return &trashService{client: grpcClient.DataClient, tokenHolder: tokenHolder}
+}
+
+// ListTrash возвращает записи в корзине без Info, начиная с удалённых последними.
+func (s *trashService) ListTrash(ctx context.Context, token string) ([]*datapb.DataItem, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	res, err := s.client.ListTrash(ctx, &datapb.ListTrashRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range res.DataItems {
+		if res.DataItems[i], err = decryptItem(s.tokenHolder.VaultKey, item); err != nil {
+			return nil, err
+		}
+	}
+
+	return res.DataItems, nil
+}
+
+func (s *trashService) RestoreData(ctx context.Context, token string, id int32) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	_, err := s.client.RestoreData(ctx, &datapb.RestoreDataRequest{Id: id})
+
+	return err
+}
+
+func (s *trashService) PurgeData(ctx context.Context, token string, id int32) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	_, err := s.client.PurgeData(ctx, &datapb.PurgeDataRequest{Id: id})
+
+	return err
+}
+
+// EmptyTrash удаляет навсегда все записи в корзине и возвращает их число.
+func (s *trashService) EmptyTrash(ctx context.Context, token string) (int64, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+	res, err := s.client.EmptyTrash(ctx, &datapb.EmptyTrashRequest{})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Purged, nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTrashService(t *testing.T) {
+	ctx := context.Background()
+	key := []byte("0123456789abcdef0123456789abcdef")
+	encrypted, err := encryptItem(key, &datapb.DataItem{Id: 3, InfoType: "text", Meta: "заметка"})
+	assert.NoError(t, err)
+
+	client := new(MockDataServiceClient)
+	svc := NewTrashService(&GRPCClient{DataClient: client}, &entity.TokenHolder{Token: "token", VaultKey: key})
+
+	client.On("ListTrash", mock.Anything, &datapb.ListTrashRequest{}).
+		Return(&datapb.ListTrashResponse{DataItems: []*datapb.DataItem{encrypted}}, nil)
+	trash, err := svc.ListTrash(ctx, "token")
+	assert.NoError(t, err)
+	if assert.Len(t, trash, 1) {
+		assert.Equal(t, "заметка", trash[0].Meta)
+	}
+
+	client.On("RestoreData", mock.Anything, &datapb.RestoreDataRequest{Id: 3}).
+		Return(&datapb.RestoreDataResponse{}, nil)
+	assert.NoError(t, svc.RestoreData(ctx, "token", 3))
+
+	client.On("PurgeData", mock.Anything, &datapb.PurgeDataRequest{Id: 3}).
+		Return(&datapb.PurgeDataResponse{}, nil)
+	assert.NoError(t, svc.PurgeData(ctx, "token", 3))
+
+	client.On("EmptyTrash", mock.Anything, &datapb.EmptyTrashRequest{}).
+		Return(&datapb.EmptyTrashResponse{Purged: 2}, nil)
+	purged, err := svc.EmptyTrash(ctx, "token")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+
+	client.AssertExpectations(t)
+}
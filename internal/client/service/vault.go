@@ -0,0 +1,181 @@
+package service
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	vaultKeyCheck = "goph-keeper-vault-key-check"
+
+	vaultKeyLength      = 32
+	kdfSaltLength       = 16
+	defaultKDFTime      = 3
+	defaultKDFMemoryKiB = 64 * 1024
+	defaultKDFThreads   = 4
+
+	// Границы параметров KDF совпадают с проверкой при регистрации на сервере.
+	maxKDFTime      = 16
+	minKDFMemoryKiB = 19 * 1024
+	maxKDFMemoryKiB = 1024 * 1024
+	maxKDFThreads   = 255
+)
+
+var (
+	ErrWrongMasterPassword = errors.New("неверный мастер-пароль")
+	ErrVaultLocked         = errors.New("данные зашифрованы мастер-паролем, войдите заново, чтобы разблокировать хранилище")
+)
+
+type vault struct {
+	params entity.KDFParams
+}
+
+// NewVault - конструктор сервиса ключа хранилища с параметрами Argon2id по умолчанию.
+func NewVault() *vault {
+	return &vault{params: entity.KDFParams{
+		Time:      defaultKDFTime,
+		MemoryKiB: defaultKDFMemoryKiB,
+		Threads:   defaultKDFThreads,
+	}}
+}
+
+// Create генерирует соль, выводит ключ хранилища из мастер-пароля
+// и возвращает параметры KDF для регистрации вместе с самим ключом.
+func (v *vault) Create(masterPassword string) (*entity.KDFParams, []byte, error) {
+	salt := make([]byte, kdfSaltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, nil, fmt.Errorf("ошибка генерации соли: %w", err)
+	}
+
+	params := v.params
+	params.Salt = salt
+
+	key := deriveVaultKey(masterPassword, &params)
+	keyCheck, err := encryptWithVaultKey(key, []byte(vaultKeyCheck))
+	if err != nil {
+		return nil, nil, err
+	}
+	params.KeyCheck = []byte(keyCheck)
+
+	return &params, key, nil
+}
+
+// Unlock выводит ключ хранилища по сохранённым параметрам и проверяет его по контрольному значению.
+// Параметры приходят с сервера, поэтому до вывода ключа они проверяются на допустимые границы.
+func (v *vault) Unlock(masterPassword string, params *entity.KDFParams) ([]byte, error) {
+	if err := validateKDFParams(params); err != nil {
+		return nil, fmt.Errorf("неправильные параметры KDF: %w", err)
+	}
+
+	key := deriveVaultKey(masterPassword, params)
+
+	check, err := decryptWithVaultKey(key, string(params.KeyCheck))
+	if err != nil || subtle.ConstantTimeCompare(check, []byte(vaultKeyCheck)) != 1 {
+		return nil, ErrWrongMasterPassword
+	}
+
+	return key, nil
+}
+
+// validateKDFParams не даёт argon2 упасть на нулевых параметрах или исчерпать память на слишком больших.
+func validateKDFParams(params *entity.KDFParams) error {
+	if params == nil {
+		return errors.New("параметры не переданы")
+	}
+	if len(params.Salt) < kdfSaltLength {
+		return fmt.Errorf("соль должна быть не короче %d байт", kdfSaltLength)
+	}
+	if params.Time == 0 || params.Time > maxKDFTime {
+		return fmt.Errorf("число итераций должно быть от 1 до %d", maxKDFTime)
+	}
+	if params.MemoryKiB < minKDFMemoryKiB || params.MemoryKiB > maxKDFMemoryKiB {
+		return fmt.Errorf("объём памяти должен быть от %d до %d КиБ", minKDFMemoryKiB, maxKDFMemoryKiB)
+	}
+	if params.Threads == 0 || params.Threads > maxKDFThreads {
+		return fmt.Errorf("число потоков должно быть от 1 до %d", maxKDFThreads)
+	}
+	if len(params.KeyCheck) == 0 {
+		return errors.New("контрольное значение ключа не передано")
+	}
+
+	return nil
+}
+
+func deriveVaultKey(masterPassword string, params *entity.KDFParams) []byte {
+	threads := uint8(params.Threads)
+
+	return argon2.IDKey([]byte(masterPassword), params.Salt, params.Time, params.MemoryKiB, threads, vaultKeyLength)
+}
+
+func isVaultCiphertext(value string) bool {
+	return strings.HasPrefix(value, payload.CiphertextPrefix)
+}
+
+func encryptWithVaultKey(key, plaintext []byte) (string, error) {
+	aesGCM, err := newVaultGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aesGCM.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("ошибка генерации nonce: %w", err)
+	}
+
+	ciphertext := aesGCM.Seal(nonce, nonce, plaintext, nil)
+
+	return payload.CiphertextPrefix + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+func decryptWithVaultKey(key []byte, value string) ([]byte, error) {
+	if !isVaultCiphertext(value) {
+		return nil, errors.New("значение не зашифровано ключом хранилища")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, payload.CiphertextPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка декодирования base64: %w", err)
+	}
+
+	aesGCM, err := newVaultGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := aesGCM.NonceSize()
+	if len(data) < nonceSize {
+		return nil, errors.New("некорректный размер данных")
+	}
+
+	plaintext, err := aesGCM.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расшифровки данных: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+func newVaultGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания шифра: %w", err)
+	}
+
+	aesGCM, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания GCM: %w", err)
+	}
+
+	return aesGCM, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/client/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestVault() *vault {
+	v := NewVault()
+	v.params.MemoryKiB = minKDFMemoryKiB
+	v.params.Time = 1
+	v.params.Threads = 1
+
+	return v
+}
+
+func TestVault_CreateAndUnlock(t *testing.T) {
+	v := newTestVault()
+
+	params, key, err := v.Create("masterpass")
+	assert.NoError(t, err)
+	assert.Len(t, params.Salt, kdfSaltLength)
+	assert.Len(t, key, vaultKeyLength)
+	assert.True(t, isVaultCiphertext(string(params.KeyCheck)))
+
+	unlocked, err := v.Unlock("masterpass", params)
+	assert.NoError(t, err)
+	assert.Equal(t, key, unlocked)
+
+	_, err = v.Unlock("wrongpass", params)
+	assert.ErrorIs(t, err, ErrWrongMasterPassword)
+}
+
+func TestVault_UnlockRejectsInvalidParams(t *testing.T) {
+	params, _, err := newTestVault().Create("masterpass")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(p *entity.KDFParams)
+	}{
+		{name: "zero time", modify: func(p *entity.KDFParams) { p.Time = 0 }},
+		{name: "too many iterations", modify: func(p *entity.KDFParams) { p.Time = maxKDFTime + 1 }},
+		{name: "too much memory", modify: func(p *entity.KDFParams) { p.MemoryKiB = maxKDFMemoryKiB + 1 }},
+		{name: "zero threads", modify: func(p *entity.KDFParams) { p.Threads = 0 }},
+		{name: "threads overflow", modify: func(p *entity.KDFParams) { p.Threads = maxKDFThreads + 1 }},
+		{name: "short salt", modify: func(p *entity.KDFParams) { p.Salt = p.Salt[:4] }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalid := *params
+			tt.modify(&invalid)
+
+			_, err := newTestVault().Unlock("masterpass", &invalid)
+			assert.Error(t, err)
+			assert.NotErrorIs(t, err, ErrWrongMasterPassword)
+		})
+	}
+
+	_, err = newTestVault().Unlock("masterpass", nil)
+	assert.Error(t, err)
+}
+
+func TestVault_EncryptDecrypt(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	ciphertext, err := encryptWithVaultKey(key, []byte("секрет"))
+	assert.NoError(t, err)
+	assert.True(t, isVaultCiphertext(ciphertext))
+
+	plaintext, err := decryptWithVaultKey(key, ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "секрет", string(plaintext))
+
+	_, err = decryptWithVaultKey([]byte("fedcba9876543210fedcba9876543210"), ciphertext)
+	assert.Error(t, err)
+
+	_, err = decryptWithVaultKey(key, "plain text")
+	assert.Error(t, err)
+}
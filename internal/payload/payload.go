@@ -0,0 +1,182 @@
+// Package payload - содержимое записей по типам: перенос между payload и Info записи и проверка перед
+// сохранением. Пакет общий для сервера и клиента: клиент проверяет содержимое до сквозного шифрования,
+// а сервер - содержимое, пришедшее без него. Записи типов, отличных от встроенных, - записи
+// пользовательских типов с содержимым Custom.
+package payload
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Типы записей с содержимым в payload.
+const (
+	LoginPasswordType = "login_password"
+	TextType          = "text"
+	BinaryType        = "binary"
+	BankCardType      = "bank_card"
+	TOTPType          = "totp"
+)
+
+// IsBuiltin сообщает, встроенный ли тип записей infoType.
+func IsBuiltin(infoType string) bool {
+	switch infoType {
+	case LoginPasswordType, TextType, BinaryType, BankCardType, TOTPType:
+		return true
+	default:
+		return false
+	}
+}
+
+// ErrTypeMismatch - содержимое записи другого типа, чем info_type.
+var ErrTypeMismatch = errors.New("содержимое не соответствует типу записи")
+
+// Message возвращает содержимое записи из payload, nil - если payload не задан.
+func Message(item *datapb.DataItem) proto.Message {
+	switch p := item.Payload.(type) {
+	case *datapb.DataItem_LoginPassword:
+		return p.LoginPassword
+	case *datapb.DataItem_Text:
+		return p.Text
+	case *datapb.DataItem_Binary:
+		return p.Binary
+	case *datapb.DataItem_BankCard:
+		return p.BankCard
+	case *datapb.DataItem_Totp:
+		return p.Totp
+	case *datapb.DataItem_Custom:
+		return p.Custom
+	default:
+		return nil
+	}
+}
+
+// Set записывает содержимое в payload записи.
+func Set(item *datapb.DataItem, message proto.Message) error {
+	switch m := message.(type) {
+	case *datapb.LoginPassword:
+		item.Payload = &datapb.DataItem_LoginPassword{LoginPassword: m}
+	case *datapb.Text:
+		item.Payload = &datapb.DataItem_Text{Text: m}
+	case *datapb.Binary:
+		item.Payload = &datapb.DataItem_Binary{Binary: m}
+	case *datapb.BankCard:
+		item.Payload = &datapb.DataItem_BankCard{BankCard: m}
+	case *datapb.Totp:
+		item.Payload = &datapb.DataItem_Totp{Totp: m}
+	case *datapb.Custom:
+		item.Payload = &datapb.DataItem_Custom{Custom: m}
+	default:
+		return fmt.Errorf("%w: %T", ErrTypeMismatch, message)
+	}
+
+	return nil
+}
+
+// Marshal сериализует содержимое записи в JSON для Info. Содержимое Custom сериализуется
+// объектом значений полей без обёртки.
+func Marshal(message proto.Message) ([]byte, error) {
+	var info []byte
+	var err error
+	if custom, ok := message.(*datapb.Custom); ok {
+		info, err = json.Marshal(custom.Fields)
+	} else {
+		info, err = protojson.Marshal(message)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сериализации содержимого записи: %w", err)
+	}
+
+	return info, nil
+}
+
+// Unmarshal разбирает Info записи типа infoType. Для пустого типа возвращает nil.
+func Unmarshal(infoType string, info []byte) (proto.Message, error) {
+	message := newMessage(infoType)
+	if message == nil {
+		return nil, nil
+	}
+
+	var err error
+	if custom, ok := message.(*datapb.Custom); ok {
+		err = json.Unmarshal(info, &custom.Fields)
+	} else {
+		err = (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(info, message)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка десериализации содержимого записи: %w", err)
+	}
+
+	return message, nil
+}
+
+// Decode возвращает содержимое записи из payload, а если его нет, - разобранное из Info.
+// Для записи без типа возвращает nil.
+func Decode(item *datapb.DataItem) (proto.Message, error) {
+	if message := Message(item); message != nil {
+		return message, nil
+	}
+
+	return Unmarshal(item.InfoType, item.Info)
+}
+
+// Pack переносит содержимое записи из payload в Info. Запись без payload не меняется.
+func Pack(item *datapb.DataItem) error {
+	message := Message(item)
+	if message == nil {
+		return nil
+	}
+
+	info, err := Marshal(message)
+	if err != nil {
+		return err
+	}
+	item.Info = info
+	item.Payload = nil
+
+	return nil
+}
+
+// Unpack переносит содержимое записи из Info в payload. Записи без типа и Info не в JSON,
+// например шифротекст сквозного шифрования, не меняются. Если Info не разобрано, запись тоже не меняется.
+func Unpack(item *datapb.DataItem) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(item.Info), []byte("{")) {
+		return nil
+	}
+
+	message, err := Unmarshal(item.InfoType, item.Info)
+	if err != nil || message == nil {
+		return err
+	}
+	if err = Set(item, message); err != nil {
+		return err
+	}
+	item.Info = nil
+
+	return nil
+}
+
+func newMessage(infoType string) proto.Message {
+	switch infoType {
+	case LoginPasswordType:
+		return &datapb.LoginPassword{}
+	case TextType:
+		return &datapb.Text{}
+	case BinaryType:
+		return &datapb.Binary{}
+	case BankCardType:
+		return &datapb.BankCard{}
+	case TOTPType:
+		return &datapb.Totp{}
+	case "":
+		return nil
+	default:
+		return &datapb.Custom{}
+	}
+}
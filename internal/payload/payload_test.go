@@ -0,0 +1,165 @@
+package payload
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/pkg/totp"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPackUnpack(t *testing.T) {
+	item := &datapb.DataItem{
+		InfoType: BankCardType,
+		Payload: &datapb.DataItem_BankCard{BankCard: &datapb.BankCard{
+			CardNumber: "4111 1111 1111 1111", ExpiryDate: "12/30", Cvv: "123", HolderName: "IVAN IVANOV",
+		}},
+	}
+	original := proto.Clone(item).(*datapb.DataItem)
+
+	assert.NoError(t, Pack(item))
+	assert.Nil(t, item.Payload)
+	assert.Contains(t, string(item.Info), `"CardNumber"`)
+
+	assert.NoError(t, Unpack(item))
+	assert.Empty(t, item.Info)
+	assert.True(t, proto.Equal(original, item))
+}
+
+func TestUnpack_LegacyInfo(t *testing.T) {
+	item := &datapb.DataItem{
+		InfoType: BinaryType,
+		Info:     []byte(`{"FileName":"a.txt","SHA256":"abc","Size":42,"Chunked":true,"Extra":1}`),
+	}
+
+	assert.NoError(t, Unpack(item))
+	assert.True(t, proto.Equal(&datapb.Binary{FileName: "a.txt", Sha256: "abc", Size: 42, Chunked: true}, Message(item)))
+}
+
+func TestPackUnpack_Custom(t *testing.T) {
+	item := &datapb.DataItem{
+		InfoType: "ssh_key",
+		Payload:  &datapb.DataItem_Custom{Custom: &datapb.Custom{Fields: map[string]string{"host": "example.com"}}},
+	}
+
+	assert.NoError(t, Pack(item))
+	assert.JSONEq(t, `{"host":"example.com"}`, string(item.Info))
+
+	assert.NoError(t, Unpack(item))
+	assert.Equal(t, map[string]string{"host": "example.com"}, item.GetCustom().GetFields())
+}
+
+func TestUnpack_Unchanged(t *testing.T) {
+	tests := []struct {
+		name string
+		item *datapb.DataItem
+	}{
+		{name: "Шифротекст", item: &datapb.DataItem{InfoType: TextType, Info: []byte("vault:v1:abc")}},
+		{name: "Без типа", item: &datapb.DataItem{Info: []byte(`{"Key":"k"}`)}},
+		{name: "Пустое Info", item: &datapb.DataItem{InfoType: TextType}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := proto.Clone(tt.item)
+			assert.NoError(t, Unpack(tt.item))
+			assert.True(t, proto.Equal(original, tt.item))
+		})
+	}
+
+	broken := &datapb.DataItem{InfoType: TextType, Info: []byte(`{"Text":`)}
+	assert.Error(t, Unpack(broken))
+	assert.Equal(t, `{"Text":`, string(broken.Info))
+}
+
+func TestValidate(t *testing.T) {
+	card := func(number, expiry string) *datapb.DataItem {
+		return &datapb.DataItem{InfoType: BankCardType, Payload: &datapb.DataItem_BankCard{
+			BankCard: &datapb.BankCard{CardNumber: number, ExpiryDate: expiry},
+		}}
+	}
+	login := func(url string) *datapb.DataItem {
+		return &datapb.DataItem{InfoType: LoginPasswordType, Payload: &datapb.DataItem_LoginPassword{
+			LoginPassword: &datapb.LoginPassword{Login: "user", Url: url},
+		}}
+	}
+
+	tests := []struct {
+		name string
+		item *datapb.DataItem
+		want error
+	}{
+		{name: "Карта", item: card("4111-1111-1111-1111", "01/27")},
+		{name: "Карта не проходит проверку Луна", item: card("4111111111111112", "01/27"), want: ErrInvalidCardNumber},
+		{name: "Короткий номер карты", item: card("4242", "01/27"), want: ErrInvalidCardNumber},
+		{name: "Буквы в номере карты", item: card("4111a11111111111", "01/27"), want: ErrInvalidCardNumber},
+		{name: "Месяц 13", item: card("4111111111111111", "13/27"), want: ErrInvalidExpiry},
+		{name: "Год из четырёх цифр", item: card("4111111111111111", "01/2027"), want: ErrInvalidExpiry},
+		{name: "Логин с URL", item: login("https://example.com/login")},
+		{name: "Логин без URL", item: login("")},
+		{name: "URL без схемы", item: login("example.com"), want: ErrInvalidURL},
+		{name: "Некорректный URL", item: login("https://exa mple.com"), want: ErrInvalidURL},
+		{
+			name: "Файл без имени",
+			item: &datapb.DataItem{InfoType: BinaryType, Payload: &datapb.DataItem_Binary{Binary: &datapb.Binary{}}},
+			want: ErrEmptyFileName,
+		},
+		{
+			name: "Тип не совпадает",
+			item: &datapb.DataItem{InfoType: BankCardType, Payload: &datapb.DataItem_Text{Text: &datapb.Text{}}},
+			want: ErrTypeMismatch,
+		},
+		{
+			name: "TOTP с параметрами по умолчанию",
+			item: &datapb.DataItem{InfoType: TOTPType, Payload: &datapb.DataItem_Totp{
+				Totp: &datapb.Totp{Secret: "JBSWY3DPEHPK3PXP"},
+			}},
+		},
+		{
+			name: "TOTP с секретом не в base32",
+			item: &datapb.DataItem{InfoType: TOTPType, Payload: &datapb.DataItem_Totp{
+				Totp: &datapb.Totp{Secret: "не секрет"},
+			}},
+			want: totp.ErrInvalidSecret,
+		},
+		{
+			name: "Пустой текст",
+			item: &datapb.DataItem{InfoType: TextType, Payload: &datapb.DataItem_Text{Text: &datapb.Text{}}},
+			want: ErrEmptyText,
+		},
+		{
+			name: "TOTP без секрета",
+			item: &datapb.DataItem{InfoType: TOTPType, Payload: &datapb.DataItem_Totp{Totp: &datapb.Totp{}}},
+			want: totp.ErrInvalidSecret,
+		},
+		{name: "Шифротекст без payload", item: &datapb.DataItem{InfoType: BankCardType, Info: []byte("vault:v1:abc")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.item)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestValidateCiphertext(t *testing.T) {
+	envelope := CiphertextPrefix + base64.StdEncoding.EncodeToString(make([]byte, minCiphertextLength))
+	assert.NoError(t, ValidateCiphertext([]byte(envelope)))
+
+	for _, info := range []string{
+		"",
+		`{"Text":"открытый текст"}`,
+		"vault:v1:" + base64.StdEncoding.EncodeToString(make([]byte, minCiphertextLength)),
+		CiphertextPrefix + "не base64",
+		CiphertextPrefix + base64.StdEncoding.EncodeToString(make([]byte, minCiphertextLength-1)),
+	} {
+		assert.ErrorIs(t, ValidateCiphertext([]byte(info)), ErrInvalidCiphertext, info)
+	}
+}
@@ -0,0 +1,105 @@
+package payload
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+)
+
+// Ошибки схем пользовательских типов и содержимого их записей.
+var (
+	ErrInvalidTypeName  = errors.New("имя типа: латинские буквы в нижнем регистре, цифры и _, не длиннее 50 символов")
+	ErrBuiltinTypeName  = errors.New("имя типа совпадает со встроенным")
+	ErrNoFields         = errors.New("у типа должно быть хотя бы одно поле")
+	ErrInvalidFieldName = errors.New("имя поля: латинские буквы в нижнем регистре, цифры и _, не длиннее 50 символов")
+	ErrInvalidFieldType = errors.New("неизвестный тип поля")
+	ErrDuplicateField   = errors.New("поле описано дважды")
+	ErrUnknownField     = errors.New("поле не описано в типе записи")
+	ErrMissingField     = errors.New("не заполнено обязательное поле")
+	ErrInvalidNumber    = errors.New("значение должно быть числом")
+	ErrInvalidDate      = errors.New("дата должна быть в формате YYYY-MM-DD")
+)
+
+const dateLayout = "2006-01-02"
+
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// ValidateSchema проверяет пользовательский тип записей: имя типа не совпадает со встроенным,
+// у типа есть поля, имена полей допустимы и не повторяются.
+func ValidateSchema(recordType *datapb.RecordType) error {
+	if !namePattern.MatchString(recordType.Name) {
+		return ErrInvalidTypeName
+	}
+	if IsBuiltin(recordType.Name) {
+		return fmt.Errorf("%w: %s", ErrBuiltinTypeName, recordType.Name)
+	}
+	if len(recordType.Fields) == 0 {
+		return ErrNoFields
+	}
+
+	names := make(map[string]bool, len(recordType.Fields))
+	for _, field := range recordType.Fields {
+		if !namePattern.MatchString(field.Name) {
+			return fmt.Errorf("%w: %q", ErrInvalidFieldName, field.Name)
+		}
+		if _, ok := datapb.RecordField_Type_name[int32(field.Type)]; !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidFieldType, field.Name)
+		}
+		if names[field.Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicateField, field.Name)
+		}
+		names[field.Name] = true
+	}
+
+	return nil
+}
+
+// ValidateCustom проверяет содержимое записи пользовательского типа по схеме: все значения описаны
+// в типе, обязательные заполнены, а непустые значения соответствуют типам полей.
+func ValidateCustom(recordType *datapb.RecordType, custom *datapb.Custom) error {
+	fields := make(map[string]*datapb.RecordField, len(recordType.Fields))
+	for _, field := range recordType.Fields {
+		fields[field.Name] = field
+	}
+	for name := range custom.GetFields() {
+		if fields[name] == nil {
+			return fmt.Errorf("%w: %s", ErrUnknownField, name)
+		}
+	}
+
+	for _, field := range recordType.Fields {
+		value := custom.GetFields()[field.Name]
+		if value == "" {
+			if field.Required {
+				return fmt.Errorf("%w: %s", ErrMissingField, field.Name)
+			}
+			continue
+		}
+		if err := validateValue(field.Type, value); err != nil {
+			return fmt.Errorf("%s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateValue(fieldType datapb.RecordField_Type, value string) error {
+	switch fieldType {
+	case datapb.RecordField_NUMBER:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return ErrInvalidNumber
+		}
+	case datapb.RecordField_DATE:
+		if _, err := time.Parse(dateLayout, value); err != nil {
+			return ErrInvalidDate
+		}
+	case datapb.RecordField_URL:
+		return validateURL(value)
+	}
+
+	return nil
+}
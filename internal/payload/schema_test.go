@@ -0,0 +1,108 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchema(t *testing.T) {
+	field := func(name string, fieldType datapb.RecordField_Type) *datapb.RecordField {
+		return &datapb.RecordField{Name: name, Type: fieldType}
+	}
+
+	tests := []struct {
+		name       string
+		recordType *datapb.RecordType
+		want       error
+	}{
+		{
+			name: "Тип",
+			recordType: &datapb.RecordType{
+				Name:   "ssh_key",
+				Fields: []*datapb.RecordField{field("host", datapb.RecordField_URL), field("key", 0)},
+			},
+		},
+		{
+			name:       "Имя с пробелом",
+			recordType: &datapb.RecordType{Name: "ssh key", Fields: []*datapb.RecordField{field("host", 0)}},
+			want:       ErrInvalidTypeName,
+		},
+		{
+			name:       "Встроенное имя",
+			recordType: &datapb.RecordType{Name: TextType, Fields: []*datapb.RecordField{field("host", 0)}},
+			want:       ErrBuiltinTypeName,
+		},
+		{name: "Без полей", recordType: &datapb.RecordType{Name: "wifi"}, want: ErrNoFields},
+		{
+			name:       "Поле с заглавной буквой",
+			recordType: &datapb.RecordType{Name: "wifi", Fields: []*datapb.RecordField{field("SSID", 0)}},
+			want:       ErrInvalidFieldName,
+		},
+		{
+			name:       "Неизвестный тип поля",
+			recordType: &datapb.RecordType{Name: "wifi", Fields: []*datapb.RecordField{field("ssid", 42)}},
+			want:       ErrInvalidFieldType,
+		},
+		{
+			name:       "Поле дважды",
+			recordType: &datapb.RecordType{Name: "wifi", Fields: []*datapb.RecordField{field("ssid", 0), field("ssid", 0)}},
+			want:       ErrDuplicateField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSchema(tt.recordType)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestValidateCustom(t *testing.T) {
+	passport := &datapb.RecordType{Name: "passport", Fields: []*datapb.RecordField{
+		{Name: "number", Required: true, Sensitive: true},
+		{Name: "issued", Type: datapb.RecordField_DATE},
+		{Name: "series", Type: datapb.RecordField_NUMBER},
+		{Name: "site", Type: datapb.RecordField_URL},
+	}}
+	custom := func(fields map[string]string) *datapb.Custom {
+		return &datapb.Custom{Fields: fields}
+	}
+
+	tests := []struct {
+		name   string
+		custom *datapb.Custom
+		want   error
+	}{
+		{name: "Все поля", custom: custom(map[string]string{
+			"number": "123456", "issued": "2020-01-31", "series": "4510", "site": "https://gosuslugi.ru",
+		})},
+		{name: "Только обязательное", custom: custom(map[string]string{"number": "123456", "issued": ""})},
+		{name: "Без обязательного", custom: custom(map[string]string{"issued": "2020-01-31"}), want: ErrMissingField},
+		{name: "Лишнее поле", custom: custom(map[string]string{"number": "1", "cvv": "1"}), want: ErrUnknownField},
+		{name: "Не число", custom: custom(map[string]string{"number": "1", "series": "45 10"}), want: ErrInvalidNumber},
+		{name: "Не дата", custom: custom(map[string]string{"number": "1", "issued": "31.01.2020"}), want: ErrInvalidDate},
+		{
+			name:   "URL без схемы",
+			custom: custom(map[string]string{"number": "1", "site": "gosuslugi.ru"}),
+			want:   ErrInvalidURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCustom(passport, tt.custom)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
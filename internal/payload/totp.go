@@ -0,0 +1,37 @@
+package payload
+
+import (
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/pkg/totp"
+)
+
+// TOTPParams возвращает параметры генерации кодов из содержимого записи totp.
+// Незаданные алгоритм, число цифр и период заменяются значениями по умолчанию.
+func TOTPParams(data *datapb.Totp) totp.Params {
+	params := totp.NewParams(data.GetSecret())
+	params.Issuer = data.GetIssuer()
+	params.Account = data.GetAccount()
+	if data.GetAlgorithm() != "" {
+		params.Algorithm = data.GetAlgorithm()
+	}
+	if data.GetDigits() != 0 {
+		params.Digits = int(data.GetDigits())
+	}
+	if data.GetPeriod() != 0 {
+		params.Period = int(data.GetPeriod())
+	}
+
+	return params
+}
+
+// NewTOTP возвращает содержимое записи totp, например из otpauth URI, разобранного totp.ParseURI.
+func NewTOTP(params totp.Params) *datapb.Totp {
+	return &datapb.Totp{
+		Secret:    totp.NormalizeSecret(params.Secret),
+		Algorithm: params.Algorithm,
+		Digits:    int32(params.Digits),
+		Period:    int32(params.Period),
+		Issuer:    params.Issuer,
+		Account:   params.Account,
+	}
+}
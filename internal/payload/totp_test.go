@@ -0,0 +1,36 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/pkg/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTPParams(t *testing.T) {
+	params := TOTPParams(&datapb.Totp{Secret: "JBSWY3DPEHPK3PXP", Account: "alice"})
+	assert.Equal(t, totp.AlgorithmSHA1, params.Algorithm)
+	assert.Equal(t, totp.DefaultDigits, params.Digits)
+	assert.Equal(t, totp.DefaultPeriod, params.Period)
+	assert.Equal(t, "alice", params.Account)
+
+	params = TOTPParams(&datapb.Totp{Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA256", Digits: 8, Period: 60})
+	assert.Equal(t, totp.AlgorithmSHA256, params.Algorithm)
+	assert.Equal(t, 8, params.Digits)
+	assert.Equal(t, 60, params.Period)
+	assert.NoError(t, TOTPParams(&datapb.Totp{Secret: "jbsw y3dp ehpk 3pxp"}).Validate())
+	assert.Error(t, TOTPParams(&datapb.Totp{Secret: "JBSWY3DPEHPK3PXP", Digits: 4}).Validate())
+}
+
+func TestNewTOTP(t *testing.T) {
+	params, err := totp.ParseURI("otpauth://totp/Example:alice@example.com?secret=jbswy3dpehpk3pxp&digits=8")
+	assert.NoError(t, err)
+
+	data := NewTOTP(params)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", data.Secret)
+	assert.Equal(t, "Example", data.Issuer)
+	assert.Equal(t, "alice@example.com", data.Account)
+	assert.Equal(t, int32(8), data.Digits)
+	assert.Equal(t, params, TOTPParams(data))
+}
@@ -0,0 +1,132 @@
+package payload
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+)
+
+// Ошибки проверки содержимого записи.
+var (
+	ErrInvalidURL        = errors.New("URL должен содержать схему и адрес сайта, например https://example.com")
+	ErrInvalidCardNumber = errors.New("номер карты должен состоять из 12-19 цифр и проходить проверку по алгоритму Луна")
+	ErrInvalidExpiry     = errors.New("срок действия карты должен быть в формате MM/YY")
+	ErrEmptyFileName     = errors.New("не указано имя файла")
+	ErrEmptyText         = errors.New("текст записи пуст")
+	ErrInvalidCiphertext = errors.New("Info без payload должно быть шифротекстом сквозного шифрования")
+)
+
+// CiphertextPrefix помечает значения, зашифрованные ключом хранилища на клиенте.
+const CiphertextPrefix = "e2e:v1:"
+
+const (
+	minCardNumberLength = 12
+	maxCardNumberLength = 19
+
+	// minCiphertextLength - nonce и тег AES-GCM, которыми клиент дополняет шифротекст.
+	minCiphertextLength = 12 + 16
+)
+
+var expiryPattern = regexp.MustCompile(`^(0[1-9]|1[0-2])/[0-9]{2}$`)
+
+// Validate проверяет содержимое записи из payload: соответствие info_type, непустой текст, URL логина, номер
+// и срок действия карты, имя файла, параметры TOTP. Запись без payload не проверяется: её Info - шифротекст,
+// у которого сервер проверяет только конверт через ValidateCiphertext.
+func Validate(item *datapb.DataItem) error {
+	message := Message(item)
+	if message == nil {
+		return nil
+	}
+	if expected := newMessage(item.InfoType); expected == nil ||
+		expected.ProtoReflect().Descriptor() != message.ProtoReflect().Descriptor() {
+		return fmt.Errorf("%w: %s", ErrTypeMismatch, item.InfoType)
+	}
+
+	switch p := item.Payload.(type) {
+	case *datapb.DataItem_Text:
+		if p.Text.Text == "" {
+			return ErrEmptyText
+		}
+	case *datapb.DataItem_LoginPassword:
+		return validateURL(p.LoginPassword.Url)
+	case *datapb.DataItem_Binary:
+		if p.Binary.FileName == "" {
+			return ErrEmptyFileName
+		}
+	case *datapb.DataItem_BankCard:
+		if !validCardNumber(p.BankCard.CardNumber) {
+			return ErrInvalidCardNumber
+		}
+		if !expiryPattern.MatchString(p.BankCard.ExpiryDate) {
+			return ErrInvalidExpiry
+		}
+	case *datapb.DataItem_Totp:
+		return TOTPParams(p.Totp).Validate()
+	}
+
+	return nil
+}
+
+// ValidateCiphertext проверяет, что Info записи без payload - конверт сквозного шифрования: CiphertextPrefix
+// и base64 с nonce, шифротекстом и тегом AES-GCM. Расшифровать его может только клиент.
+func ValidateCiphertext(info []byte) error {
+	encoded, ok := bytes.CutPrefix(info, []byte(CiphertextPrefix))
+	if !ok {
+		return ErrInvalidCiphertext
+	}
+
+	data, err := base64.StdEncoding.DecodeString(string(encoded))
+	if err != nil || len(data) < minCiphertextLength {
+		return ErrInvalidCiphertext
+	}
+
+	return nil
+}
+
+// validateURL допускает пустой URL, иначе требует схему и адрес сайта.
+func validateURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return ErrInvalidURL
+	}
+
+	return nil
+}
+
+// validCardNumber проверяет длину номера и контрольную цифру по алгоритму Луна.
+// Пробелы и дефисы между группами цифр не учитываются.
+func validCardNumber(number string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(number)
+	if len(digits) < minCardNumberLength || len(digits) > maxCardNumberLength {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '0' || digits[i] > '9' {
+			return false
+		}
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
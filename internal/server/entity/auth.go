@@ -0,0 +1,9 @@
+package entity
+
+// AuthResult - результат успешной авторизации пользователя.
+// Если включён второй фактор, после проверки пароля заполняется только ChallengeID.
+type AuthResult struct {
+	KDFParams KDFParams
+	TokenPair
+	ChallengeID string
+}
@@ -0,0 +1,41 @@
+package entity
+
+// Upload - загрузка большого бинарного файла по частям. Пока загрузка не завершена,
+// Info и Meta будущей записи хранятся в ней зашифрованными, а после завершения DataID указывает на запись.
+type Upload struct {
+	ID   string
+	Info string
+	Meta string
+	// SearchTokens - токены поиска будущей записи, зашифрованные одной строкой через пробел.
+	SearchTokens string
+	// BlobRef - префикс ключей частей файла в хранилище файлов.
+	BlobRef string
+	// Size - сколько байт файла принято.
+	Size   int64
+	UserID int
+	DataID int
+	// ReplaceID - запись, содержимое которой заменит загрузка. 0 - загрузка создаёт новую запись.
+	ReplaceID  int
+	ChunkCount int
+	// Received - сколько частей принято. Следующей ожидается часть с этим номером.
+	Received int
+}
+
+// Completed сообщает, что все части приняты и запись создана.
+func (u *Upload) Completed() bool {
+	return u.DataID != 0
+}
+
+// BinaryChunk - расшифрованная часть файла.
+type BinaryChunk struct {
+	Data []byte
+	Seq  int
+}
+
+// BlobGarbage - части удалённой загрузки, которые нужно удалить из хранилища файлов.
+// Chunks - сколько частей могло быть записано: с номерами от 0 до Chunks-1.
+type BlobGarbage struct {
+	Ref    string
+	ID     int
+	Chunks int
+}
@@ -9,4 +9,70 @@ type UserData struct {
 	Info     string
 	Meta     string
 	Created  time.Time
+	// Updated - время последнего изменения записи.
+	Updated time.Time
+	// Deleted - время переноса записи в корзину. Нулевое - запись не в корзине.
+	Deleted time.Time
+	// Revision - ревизия данных пользователя, на которой запись изменилась последний раз.
+	Revision int64
+	// SearchTokens - токены поиска по Meta от клиента. В базе хранится только их слепой индекс.
+	SearchTokens []string
+	// TagIDs - теги записи в порядке возрастания ID.
+	TagIDs []int64
+	// FolderID - папка записи. 0 - корень.
+	FolderID int
+}
+
+// DataCursor - позиция в выдаче, отсортированной по дате создания и ID.
+type DataCursor struct {
+	Created time.Time
+	ID      int
+}
+
+// DataPage - страница списка записей пользователя в порядке даты создания и ID.
+type DataPage struct {
+	// After - последняя запись предыдущей страницы. nil - первая страница.
+	After *DataCursor
+	// FolderID - только записи этой папки, 0 - записи вне папок. nil - записи всех папок.
+	FolderID *int
+	InfoType string
+	Limit    int
+	// TagID - только записи с этим тегом. 0 - без фильтра.
+	TagID int
+}
+
+// DataSearch - параметры поиска записей пользователя. Нулевые CreatedFrom и CreatedTo - без границы.
+type DataSearch struct {
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// After - последняя запись предыдущей страницы. nil - первая страница.
+	After    *DataCursor
+	InfoType string
+	// Tokens - токены поиска, которые должны быть у записи. Пусто - без условия на Meta.
+	Tokens    []string
+	Limit     int
+	Ascending bool
+}
+
+// DataChange - изменение записи для синхронизации клиента: новая версия записи или её удаление.
+type DataChange struct {
+	Data     *UserData
+	Revision int64
+	ID       int
+	Deleted  bool
+}
+
+// Виды событий об изменении данных.
+const (
+	DataAdded   = "added"
+	DataUpdated = "updated"
+	DataDeleted = "deleted"
+)
+
+// DataEvent - событие об изменении записи пользователя для клиентов, подписанных на WatchData.
+type DataEvent struct {
+	Kind     string `json:"kind"`
+	InfoType string `json:"info_type,omitempty"`
+	UserID   int    `json:"user_id"`
+	ID       int    `json:"id"`
 }
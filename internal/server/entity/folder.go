@@ -0,0 +1,17 @@
+package entity
+
+// Folder - папка записей пользователя. Папки образуют дерево, Name хранится зашифрованным.
+type Folder struct {
+	Name string
+	ID   int
+	// ParentID - родительская папка. 0 - папка в корне.
+	ParentID int
+	UserID   int
+}
+
+// Tag - тег записей пользователя. Name хранится зашифрованным.
+type Tag struct {
+	Name   string
+	ID     int
+	UserID int
+}
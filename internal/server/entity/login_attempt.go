@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+const (
+	// AttemptKindLogin - счётчик неудачных попыток входа по логину.
+	AttemptKindLogin = "login"
+	// AttemptKindIP - счётчик неудачных попыток входа по IP-адресу клиента.
+	AttemptKindIP = "ip"
+)
+
+// LoginAttempt - состояние счётчика неудачных попыток входа.
+type LoginAttempt struct {
+	LastFailedAt time.Time
+	LockedUntil  time.Time
+	Kind         string
+	Key          string
+	FailedCount  int
+}
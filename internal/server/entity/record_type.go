@@ -0,0 +1,19 @@
+package entity
+
+// RecordType - пользовательский тип записей. Name - info_type записей этого типа.
+type RecordType struct {
+	Name   string
+	Fields []RecordField
+	ID     int
+	UserID int
+}
+
+// RecordField - поле пользовательского типа. Type - имя типа поля из datapb.RecordField_Type.
+// Поля хранятся в базе в JSON.
+type RecordField struct {
+	Name      string `json:"name"`
+	Label     string `json:"label,omitempty"`
+	Type      string `json:"type"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+	Required  bool   `json:"required,omitempty"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// Session - сессия пользователя, в рамках которой выдаются access и refresh токены.
+// Хранится только хеш текущего refresh токена.
+type Session struct {
+	CreatedAt        time.Time
+	LastUsedAt       time.Time
+	ExpiresAt        time.Time
+	ID               string
+	RefreshTokenHash string
+	UserAgent        string
+	IP               string
+	UserID           int
+}
+
+// ClientInfo - сведения о клиенте, открывающем сессию.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// TokenPair - короткоживущий access токен и refresh токен для его обновления.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "crypto"
+
+// SigningKey - ключ подписи access токенов. У ключа, оставленного только для проверки
+// уже выданных токенов, Private равен nil.
+type SigningKey struct {
+	Private crypto.Signer
+	Public  crypto.PublicKey
+	ID      string
+}
+
+// JWK - открытый ключ в формате RFC 7517.
+type JWK struct {
+	Kty string
+	Kid string
+	Alg string
+	Use string
+	Crv string
+	X   string
+	N   string
+	E   string
+}
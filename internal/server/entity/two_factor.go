@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// TOTP - настройки второго фактора пользователя. Secret хранится зашифрованным ключом пользователя.
+type TOTP struct {
+	CreatedAt time.Time
+	Secret    string
+	// LastCounter - шаг времени последнего принятого кода, чтобы один код нельзя было предъявить дважды.
+	LastCounter int64
+	UserID      int
+	Enabled     bool
+}
+
+// TOTPEnrollment - секрет для добавления в приложение-аутентификатор.
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// LoginChallenge - вход, ожидающий подтверждения вторым фактором.
+type LoginChallenge struct {
+	ExpiresAt time.Time
+	ID        string
+	UserID    int
+	Attempts  int
+}
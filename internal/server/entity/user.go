@@ -3,5 +3,22 @@ package entity
 type User struct {
 	Login    string `json:"login" db:"login"`
 	Password string `json:"password" db:"password"`
-	ID       int    `json:"id" db:"id"`
+	KDFParams
+	ID int `json:"id" db:"id"`
+}
+
+// KDFParams - параметры Argon2id для вывода ключа хранилища на клиенте.
+// Сервер хранит их как есть и не может по ним восстановить ключ: мастер-пароль ему не передаётся.
+// Пустая соль означает, что сквозное шифрование для пользователя не включено.
+type KDFParams struct {
+	Salt      []byte `json:"kdf_salt" db:"kdf_salt"`
+	KeyCheck  []byte `json:"kdf_key_check" db:"kdf_key_check"`
+	Time      uint32 `json:"kdf_time" db:"kdf_time"`
+	MemoryKiB uint32 `json:"kdf_memory_kib" db:"kdf_memory_kib"`
+	Threads   uint8  `json:"kdf_threads" db:"kdf_threads"`
+}
+
+// Enabled сообщает, включено ли сквозное шифрование.
+func (p KDFParams) Enabled() bool {
+	return len(p.Salt) > 0
 }
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// UserKey - ключ шифрования данных пользователя (DEK), обёрнутый мастер-ключом сервера.
+type UserKey struct {
+	CreatedAt   time.Time
+	MasterKeyID string
+	WrappedKey  string
+	ID          int
+	UserID      int
+	Active      bool
+}
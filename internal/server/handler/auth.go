@@ -2,26 +2,63 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"net"
 
 	pb "github.com/NikolosHGW/goph-keeper/api/authpb"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type auth interface {
-	Handle(context.Context, *pb.LoginUserRequest) (string, error)
+	Handle(ctx context.Context, req *pb.LoginUserRequest, client entity.ClientInfo) (*entity.AuthResult, error)
+	HandleCode(ctx context.Context, req *pb.VerifyLoginCodeRequest, client entity.ClientInfo) (*entity.AuthResult, error)
+}
+
+type sessionManager interface {
+	Refresh(ctx context.Context, refreshToken string) (*entity.TokenPair, error)
+	Revoke(ctx context.Context, userID int, sessionID string) error
+	List(ctx context.Context, userID int) ([]*entity.Session, error)
+}
+
+type jwksProvider interface {
+	JWKS() []*entity.JWK
+}
+
+type twoFactorManager interface {
+	Enroll(ctx context.Context, userID int) (*entity.TOTPEnrollment, error)
+	Confirm(ctx context.Context, userID int, code string) ([]string, error)
+	Disable(ctx context.Context, userID int, code string) error
 }
 
 // AuthServer - структура gRPC сервера для авторизации пользователя.
 type AuthServer struct {
 	pb.UnimplementedAuthServer
 
-	authUseCase auth
+	authUseCase    auth
+	sessionService sessionManager
+	tokenService   jwksProvider
+	twoFactor      twoFactorManager
 }
 
 // NewAuthServer - конструктор gRPC сервера для авторизации пользователя.
-func NewAuthServer(authUseCase auth) *AuthServer {
-	return &AuthServer{authUseCase: authUseCase}
+func NewAuthServer(
+	authUseCase auth,
+	sessionService sessionManager,
+	tokenService jwksProvider,
+	twoFactor twoFactorManager,
+) *AuthServer {
+	return &AuthServer{
+		authUseCase:    authUseCase,
+		sessionService: sessionService,
+		tokenService:   tokenService,
+		twoFactor:      twoFactor,
+	}
 }
 
 // LoginUser - реализация RPC сервиса.
@@ -34,12 +71,275 @@ func (s *AuthServer) LoginUser(
 		return nil, status.Errorf(codes.InvalidArgument, "неправильный запрос: %v", err)
 	}
 
-	token, err := s.authUseCase.Handle(ctx, req)
+	result, err := s.authUseCase.Handle(ctx, req, clientInfoFromContext(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, helper.ErrInvalidCredentials):
+			return nil, status.Error(codes.Unauthenticated, helper.ErrInvalidCredentials.Error())
+		case errors.Is(err, helper.ErrTooManyAttempts):
+			return nil, status.Error(codes.ResourceExhausted, helper.ErrTooManyAttempts.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "ошибка при авторизации: %v", err)
+		}
+	}
+
+	return loginResponse(result), nil
+}
+
+// VerifyLoginCode - второй шаг входа для пользователей с включённым вторым фактором.
+func (s *AuthServer) VerifyLoginCode(
+	ctx context.Context,
+	req *pb.VerifyLoginCodeRequest,
+) (*pb.LoginUserResponse, error) {
+	if req.ChallengeId == "" || req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "не указан код или подтверждение входа")
+	}
+
+	result, err := s.authUseCase.HandleCode(ctx, req, clientInfoFromContext(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, helper.ErrInvalidCode):
+			return nil, status.Error(codes.Unauthenticated, helper.ErrInvalidCode.Error())
+		case errors.Is(err, helper.ErrChallengeNotFound):
+			return nil, status.Error(codes.Unauthenticated, helper.ErrChallengeNotFound.Error())
+		case errors.Is(err, helper.ErrTooManyAttempts):
+			return nil, status.Error(codes.ResourceExhausted, helper.ErrTooManyAttempts.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "ошибка при авторизации: %v", err)
+		}
+	}
+
+	return loginResponse(result), nil
+}
+
+func loginResponse(result *entity.AuthResult) *pb.LoginUserResponse {
+	if result.ChallengeID != "" {
+		return &pb.LoginUserResponse{ChallengeId: result.ChallengeID}
+	}
+
+	resp := &pb.LoginUserResponse{
+		BearerToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+	}
+	if result.KDFParams.Enabled() {
+		resp.KdfParams = &pb.KdfParams{
+			Salt:      result.KDFParams.Salt,
+			Time:      result.KDFParams.Time,
+			MemoryKib: result.KDFParams.MemoryKiB,
+			Threads:   uint32(result.KDFParams.Threads),
+			KeyCheck:  result.KDFParams.KeyCheck,
+		}
+	}
+
+	return resp
+}
+
+// RefreshToken - обмен refresh токена на новую пару токенов.
+func (s *AuthServer) RefreshToken(
+	ctx context.Context,
+	req *pb.RefreshTokenRequest,
+) (*pb.RefreshTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "refresh токен не передан")
+	}
+
+	tokens, err := s.sessionService.Refresh(ctx, req.RefreshToken)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "ошибка при авторизации: %v", err)
+		if errors.Is(err, helper.ErrInvalidRefresh) {
+			return nil, status.Error(codes.Unauthenticated, helper.ErrInvalidRefresh.Error())
+		}
+
+		return nil, status.Errorf(codes.Internal, "ошибка при обновлении токена: %v", err)
 	}
 
-	return &pb.LoginUserResponse{
-		BearerToken: token,
+	return &pb.RefreshTokenResponse{
+		BearerToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}, nil
 }
+
+// Logout - завершение текущей сессии.
+func (s *AuthServer) Logout(ctx context.Context, _ *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+	sessionID, err := getSessionIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить сессию из контекста")
+	}
+
+	if err := s.sessionService.Revoke(ctx, userID, sessionID); err != nil && !errors.Is(err, helper.ErrSessionNotFound) {
+		return nil, status.Errorf(codes.Internal, "ошибка при завершении сессии: %v", err)
+	}
+
+	return &pb.LogoutResponse{}, nil
+}
+
+// ListSessions - список активных сессий пользователя.
+func (s *AuthServer) ListSessions(
+	ctx context.Context,
+	_ *pb.ListSessionsRequest,
+) (*pb.ListSessionsResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+	currentSessionID, _ := getSessionIDFromContext(ctx)
+
+	sessions, err := s.sessionService.List(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "ошибка при получении сессий: %v", err)
+	}
+
+	resp := &pb.ListSessionsResponse{Sessions: make([]*pb.Session, 0, len(sessions))}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, &pb.Session{
+			Id:         session.ID,
+			Ip:         session.IP,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  timestamppb.New(session.CreatedAt),
+			LastUsedAt: timestamppb.New(session.LastUsedAt),
+			Current:    session.ID == currentSessionID,
+		})
+	}
+
+	return resp, nil
+}
+
+// RevokeSession - завершение сессии пользователя по ID, например на потерянном устройстве.
+func (s *AuthServer) RevokeSession(
+	ctx context.Context,
+	req *pb.RevokeSessionRequest,
+) (*pb.RevokeSessionResponse, error) {
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "не указан ID сессии")
+	}
+
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	if err := s.sessionService.Revoke(ctx, userID, req.SessionId); err != nil {
+		if errors.Is(err, helper.ErrSessionNotFound) {
+			return nil, status.Error(codes.NotFound, helper.ErrSessionNotFound.Error())
+		}
+
+		return nil, status.Errorf(codes.Internal, "ошибка при завершении сессии: %v", err)
+	}
+
+	return &pb.RevokeSessionResponse{}, nil
+}
+
+// GetJWKS - открытые ключи подписи access токенов для их проверки сторонними сервисами.
+func (s *AuthServer) GetJWKS(_ context.Context, _ *pb.GetJWKSRequest) (*pb.GetJWKSResponse, error) {
+	jwks := s.tokenService.JWKS()
+	keys := make([]*pb.Jwk, 0, len(jwks))
+	for _, jwk := range jwks {
+		keys = append(keys, &pb.Jwk{
+			Kty: jwk.Kty,
+			Kid: jwk.Kid,
+			Alg: jwk.Alg,
+			Use: jwk.Use,
+			Crv: jwk.Crv,
+			X:   jwk.X,
+			N:   jwk.N,
+			E:   jwk.E,
+		})
+	}
+
+	return &pb.GetJWKSResponse{Keys: keys}, nil
+}
+
+// EnrollTOTP - выпуск нового секрета TOTP. Второй фактор включается только после ConfirmTOTP.
+func (s *AuthServer) EnrollTOTP(ctx context.Context, _ *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	enrollment, err := s.twoFactor.Enroll(ctx, userID)
+	if err != nil {
+		return nil, twoFactorError(err)
+	}
+
+	return &pb.EnrollTOTPResponse{Secret: enrollment.Secret, OtpauthUri: enrollment.URI}, nil
+}
+
+// ConfirmTOTP - включение второго фактора по коду из приложения. Возвращает коды восстановления.
+func (s *AuthServer) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	if req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "не указан код")
+	}
+
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	recoveryCodes, err := s.twoFactor.Confirm(ctx, userID, req.Code)
+	if err != nil {
+		return nil, twoFactorError(err)
+	}
+
+	return &pb.ConfirmTOTPResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DisableTOTP - отключение второго фактора. Требует действующий код или код восстановления.
+func (s *AuthServer) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	if req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "не указан код")
+	}
+
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	if err := s.twoFactor.Disable(ctx, userID, req.Code); err != nil {
+		return nil, twoFactorError(err)
+	}
+
+	return &pb.DisableTOTPResponse{}, nil
+}
+
+func twoFactorError(err error) error {
+	switch {
+	case errors.Is(err, helper.ErrInvalidCode):
+		return status.Error(codes.InvalidArgument, helper.ErrInvalidCode.Error())
+	case errors.Is(err, helper.ErrTOTPAlreadyEnabled):
+		return status.Error(codes.FailedPrecondition, helper.ErrTOTPAlreadyEnabled.Error())
+	case errors.Is(err, helper.ErrTOTPNotEnrolled):
+		return status.Error(codes.FailedPrecondition, helper.ErrTOTPNotEnrolled.Error())
+	default:
+		return status.Errorf(codes.Internal, "ошибка второго фактора: %v", err)
+	}
+}
+
+// clientInfoFromContext возвращает адрес и user-agent клиента для учёта сессий.
+func clientInfoFromContext(ctx context.Context) entity.ClientInfo {
+	client := entity.ClientInfo{IP: clientIPFromContext(ctx)}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			client.UserAgent = values[0]
+		}
+	}
+
+	return client
+}
+
+// clientIPFromContext возвращает IP-адрес клиента без порта, либо пустую строку, если он неизвестен.
+func clientIPFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
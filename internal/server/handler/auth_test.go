@@ -3,12 +3,19 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"testing"
+	"time"
 
 	pb "github.com/NikolosHGW/goph-keeper/api/authpb"
+	"github.com/NikolosHGW/goph-keeper/internal/contextkey"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -16,9 +23,24 @@ type MockAuthUseCase struct {
 	mock.Mock
 }
 
-func (m *MockAuthUseCase) Handle(ctx context.Context, req *pb.LoginUserRequest) (string, error) {
-	args := m.Called(ctx, req)
-	return args.String(0), args.Error(1)
+func (m *MockAuthUseCase) Handle(
+	ctx context.Context,
+	req *pb.LoginUserRequest,
+	client entity.ClientInfo,
+) (*entity.AuthResult, error) {
+	args := m.Called(ctx, req, client)
+	result, _ := args.Get(0).(*entity.AuthResult)
+	return result, args.Error(1)
+}
+
+func (m *MockAuthUseCase) HandleCode(
+	ctx context.Context,
+	req *pb.VerifyLoginCodeRequest,
+	client entity.ClientInfo,
+) (*entity.AuthResult, error) {
+	args := m.Called(ctx, req, client)
+	result, _ := args.Get(0).(*entity.AuthResult)
+	return result, args.Error(1)
 }
 
 func TestAuthServer_Login(t *testing.T) {
@@ -40,10 +62,45 @@ func TestAuthServer_Login(t *testing.T) {
 				Password: "password123",
 			},
 			setupMock: func(m *MockAuthUseCase) {
-				m.On("Handle", ctx, mock.AnythingOfType("*authpb.LoginUserRequest")).Return("testtoken", nil)
+				m.On("Handle", ctx, mock.AnythingOfType("*authpb.LoginUserRequest"), entity.ClientInfo{}).
+					Return(&entity.AuthResult{
+						TokenPair: entity.TokenPair{AccessToken: "testtoken", RefreshToken: "refresh"},
+					}, nil)
+			},
+			expectedResp: &pb.LoginUserResponse{
+				BearerToken:  "testtoken",
+				RefreshToken: "refresh",
+			},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "Успешная авторизация со сквозным шифрованием",
+			req: &pb.LoginUserRequest{
+				Login:    "testuser",
+				Password: "password123",
+			},
+			setupMock: func(m *MockAuthUseCase) {
+				m.On("Handle", ctx, mock.AnythingOfType("*authpb.LoginUserRequest"), entity.ClientInfo{}).
+					Return(&entity.AuthResult{
+						TokenPair: entity.TokenPair{AccessToken: "testtoken"},
+						KDFParams: entity.KDFParams{
+							Salt:      []byte("0123456789abcdef"),
+							Time:      3,
+							MemoryKiB: 65536,
+							Threads:   4,
+							KeyCheck:  []byte("check"),
+						},
+					}, nil)
 			},
 			expectedResp: &pb.LoginUserResponse{
 				BearerToken: "testtoken",
+				KdfParams: &pb.KdfParams{
+					Salt:      []byte("0123456789abcdef"),
+					Time:      3,
+					MemoryKib: 65536,
+					Threads:   4,
+					KeyCheck:  []byte("check"),
+				},
 			},
 			expectedErrCode: codes.OK,
 		},
@@ -84,11 +141,38 @@ func TestAuthServer_Login(t *testing.T) {
 				Password: "password123",
 			},
 			setupMock: func(m *MockAuthUseCase) {
-				m.On("Handle", ctx, mock.AnythingOfType("*authpb.LoginUserRequest")).Return("", errors.New("some internal error"))
+				m.On("Handle", ctx, mock.AnythingOfType("*authpb.LoginUserRequest"), entity.ClientInfo{}).
+					Return(nil, errors.New("some internal error"))
 			},
 			expectedResp:    nil,
 			expectedErrCode: codes.Internal,
 		},
+		{
+			name: "Неверный пароль",
+			req: &pb.LoginUserRequest{
+				Login:    "testuser",
+				Password: "wrongpassword",
+			},
+			setupMock: func(m *MockAuthUseCase) {
+				m.On("Handle", ctx, mock.AnythingOfType("*authpb.LoginUserRequest"), entity.ClientInfo{}).
+					Return(nil, helper.ErrInvalidCredentials)
+			},
+			expectedResp:    nil,
+			expectedErrCode: codes.Unauthenticated,
+		},
+		{
+			name: "Вход временно заблокирован",
+			req: &pb.LoginUserRequest{
+				Login:    "testuser",
+				Password: "password123",
+			},
+			setupMock: func(m *MockAuthUseCase) {
+				m.On("Handle", ctx, mock.AnythingOfType("*authpb.LoginUserRequest"), entity.ClientInfo{}).
+					Return(nil, fmt.Errorf("обёртка: %w", helper.ErrTooManyAttempts))
+			},
+			expectedResp:    nil,
+			expectedErrCode: codes.ResourceExhausted,
+		},
 	}
 
 	for _, tt := range tests {
@@ -98,7 +182,7 @@ func TestAuthServer_Login(t *testing.T) {
 				tt.setupMock(mockAuthUseCase)
 			}
 
-			server := NewAuthServer(mockAuthUseCase)
+			server := NewAuthServer(mockAuthUseCase, nil, nil, nil)
 
 			resp, err := server.LoginUser(ctx, tt.req)
 
@@ -116,3 +200,320 @@ func TestAuthServer_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestClientIPFromContext(t *testing.T) {
+	t.Run("Адрес клиента известен", func(t *testing.T) {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 54321},
+		})
+		assert.Equal(t, "10.0.0.7", clientIPFromContext(ctx))
+	})
+
+	t.Run("Адрес клиента неизвестен", func(t *testing.T) {
+		assert.Equal(t, "", clientIPFromContext(context.Background()))
+	})
+}
+
+type MockSessionManager struct {
+	mock.Mock
+}
+
+func (m *MockSessionManager) Refresh(ctx context.Context, refreshToken string) (*entity.TokenPair, error) {
+	args := m.Called(ctx, refreshToken)
+	tokens, _ := args.Get(0).(*entity.TokenPair)
+	return tokens, args.Error(1)
+}
+
+func (m *MockSessionManager) Revoke(ctx context.Context, userID int, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockSessionManager) List(ctx context.Context, userID int) ([]*entity.Session, error) {
+	args := m.Called(ctx, userID)
+	sessions, _ := args.Get(0).([]*entity.Session)
+	return sessions, args.Error(1)
+}
+
+func sessionContext(userID int, sessionID string) context.Context {
+	ctx := context.WithValue(context.Background(), contextkey.UserIDKey, userID)
+	return context.WithValue(ctx, contextkey.SessionIDKey, sessionID)
+}
+
+func TestAuthServer_RefreshToken(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		req             *pb.RefreshTokenRequest
+		setupMock       func(m *MockSessionManager)
+		expectedResp    *pb.RefreshTokenResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name: "Успешное обновление",
+			req:  &pb.RefreshTokenRequest{RefreshToken: "sid.secret"},
+			setupMock: func(m *MockSessionManager) {
+				m.On("Refresh", ctx, "sid.secret").
+					Return(&entity.TokenPair{AccessToken: "access", RefreshToken: "sid.new"}, nil)
+			},
+			expectedResp:    &pb.RefreshTokenResponse{BearerToken: "access", RefreshToken: "sid.new"},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name:            "Пустой токен",
+			req:             &pb.RefreshTokenRequest{},
+			setupMock:       func(m *MockSessionManager) {},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "Токен уже использован",
+			req:  &pb.RefreshTokenRequest{RefreshToken: "sid.old"},
+			setupMock: func(m *MockSessionManager) {
+				m.On("Refresh", ctx, "sid.old").Return(nil, helper.ErrInvalidRefresh)
+			},
+			expectedErrCode: codes.Unauthenticated,
+		},
+		{
+			name: "Ошибка сервиса",
+			req:  &pb.RefreshTokenRequest{RefreshToken: "sid.secret"},
+			setupMock: func(m *MockSessionManager) {
+				m.On("Refresh", ctx, "sid.secret").Return(nil, helper.ErrInternalServer)
+			},
+			expectedErrCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions := new(MockSessionManager)
+			tt.setupMock(sessions)
+
+			resp, err := NewAuthServer(nil, sessions, nil, nil).RefreshToken(ctx, tt.req)
+
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			assert.Equal(t, tt.expectedResp, resp)
+			sessions.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthServer_Logout(t *testing.T) {
+	ctx := sessionContext(7, "sid")
+
+	sessions := new(MockSessionManager)
+	sessions.On("Revoke", ctx, 7, "sid").Return(nil)
+
+	resp, err := NewAuthServer(nil, sessions, nil, nil).Logout(ctx, &pb.LogoutRequest{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	sessions.AssertExpectations(t)
+}
+
+func TestAuthServer_ListSessions(t *testing.T) {
+	ctx := sessionContext(7, "current")
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	sessions := new(MockSessionManager)
+	sessions.On("List", ctx, 7).Return([]*entity.Session{
+		{ID: "current", IP: "10.0.0.1", UserAgent: "cli", CreatedAt: created, LastUsedAt: created},
+		{ID: "other", IP: "10.0.0.2", UserAgent: "cli", CreatedAt: created, LastUsedAt: created},
+	}, nil)
+
+	resp, err := NewAuthServer(nil, sessions, nil, nil).ListSessions(ctx, &pb.ListSessionsRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Sessions, 2)
+	assert.True(t, resp.Sessions[0].Current)
+	assert.False(t, resp.Sessions[1].Current)
+	assert.Equal(t, "10.0.0.2", resp.Sessions[1].Ip)
+}
+
+func TestAuthServer_RevokeSession(t *testing.T) {
+	ctx := sessionContext(7, "current")
+
+	t.Run("Сессия завершена", func(t *testing.T) {
+		sessions := new(MockSessionManager)
+		sessions.On("Revoke", ctx, 7, "other").Return(nil)
+
+		_, err := NewAuthServer(nil, sessions, nil, nil).RevokeSession(ctx, &pb.RevokeSessionRequest{SessionId: "other"})
+
+		assert.NoError(t, err)
+		sessions.AssertExpectations(t)
+	})
+
+	t.Run("Чужая или несуществующая сессия", func(t *testing.T) {
+		sessions := new(MockSessionManager)
+		sessions.On("Revoke", ctx, 7, "foreign").Return(helper.ErrSessionNotFound)
+
+		_, err := NewAuthServer(nil, sessions, nil, nil).RevokeSession(ctx, &pb.RevokeSessionRequest{SessionId: "foreign"})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+type staticJWKS []*entity.JWK
+
+func (s staticJWKS) JWKS() []*entity.JWK { return s }
+
+func TestAuthServer_GetJWKS(t *testing.T) {
+	jwks := staticJWKS{
+		{Kty: "OKP", Kid: "2", Alg: "EdDSA", Use: "sig", Crv: "Ed25519", X: "x"},
+		{Kty: "RSA", Kid: "1", Alg: "RS256", Use: "sig", N: "n", E: "AQAB"},
+	}
+
+	resp, err := NewAuthServer(nil, nil, jwks, nil).GetJWKS(context.Background(), &pb.GetJWKSRequest{})
+
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Keys, 2) {
+		assert.Equal(t, "2", resp.Keys[0].Kid)
+		assert.Equal(t, "Ed25519", resp.Keys[0].Crv)
+		assert.Equal(t, "AQAB", resp.Keys[1].E)
+	}
+}
+
+func TestAuthServer_LoginWithSecondFactor(t *testing.T) {
+	ctx := context.Background()
+	req := &pb.LoginUserRequest{Login: "testuser", Password: "password123"}
+
+	useCase := new(MockAuthUseCase)
+	useCase.On("Handle", ctx, req, entity.ClientInfo{}).Return(&entity.AuthResult{ChallengeID: "challenge"}, nil)
+
+	resp, err := NewAuthServer(useCase, nil, nil, nil).LoginUser(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.LoginUserResponse{ChallengeId: "challenge"}, resp)
+}
+
+func TestAuthServer_VerifyLoginCode(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		req             *pb.VerifyLoginCodeRequest
+		result          *entity.AuthResult
+		err             error
+		expectedResp    *pb.LoginUserResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name:   "Код принят",
+			req:    &pb.VerifyLoginCodeRequest{ChallengeId: "challenge", Code: "123456"},
+			result: &entity.AuthResult{TokenPair: entity.TokenPair{AccessToken: "token", RefreshToken: "refresh"}},
+			expectedResp: &pb.LoginUserResponse{
+				BearerToken:  "token",
+				RefreshToken: "refresh",
+			},
+			expectedErrCode: codes.OK,
+		},
+		{
+			name:            "Пустой код",
+			req:             &pb.VerifyLoginCodeRequest{ChallengeId: "challenge"},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name:            "Неверный код",
+			req:             &pb.VerifyLoginCodeRequest{ChallengeId: "challenge", Code: "000000"},
+			err:             helper.ErrInvalidCode,
+			expectedErrCode: codes.Unauthenticated,
+		},
+		{
+			name:            "Подтверждение истекло",
+			req:             &pb.VerifyLoginCodeRequest{ChallengeId: "expired", Code: "123456"},
+			err:             helper.ErrChallengeNotFound,
+			expectedErrCode: codes.Unauthenticated,
+		},
+		{
+			name:            "Слишком много попыток",
+			req:             &pb.VerifyLoginCodeRequest{ChallengeId: "challenge", Code: "123456"},
+			err:             helper.ErrTooManyAttempts,
+			expectedErrCode: codes.ResourceExhausted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := new(MockAuthUseCase)
+			useCase.On("HandleCode", ctx, tt.req, entity.ClientInfo{}).Return(tt.result, tt.err).Maybe()
+
+			resp, err := NewAuthServer(useCase, nil, nil, nil).VerifyLoginCode(ctx, tt.req)
+
+			assert.Equal(t, tt.expectedErrCode, status.Code(err))
+			assert.Equal(t, tt.expectedResp, resp)
+		})
+	}
+}
+
+type MockTwoFactorManager struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorManager) Enroll(ctx context.Context, userID int) (*entity.TOTPEnrollment, error) {
+	args := m.Called(ctx, userID)
+	enrollment, _ := args.Get(0).(*entity.TOTPEnrollment)
+	return enrollment, args.Error(1)
+}
+
+func (m *MockTwoFactorManager) Confirm(ctx context.Context, userID int, code string) ([]string, error) {
+	args := m.Called(ctx, userID, code)
+	recoveryCodes, _ := args.Get(0).([]string)
+	return recoveryCodes, args.Error(1)
+}
+
+func (m *MockTwoFactorManager) Disable(ctx context.Context, userID int, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+func TestAuthServer_TOTP(t *testing.T) {
+	ctx := sessionContext(7, "sid")
+
+	t.Run("Выпуск секрета", func(t *testing.T) {
+		twoFactor := new(MockTwoFactorManager)
+		twoFactor.On("Enroll", ctx, 7).Return(&entity.TOTPEnrollment{Secret: "SECRET", URI: "otpauth://totp/x"}, nil)
+
+		resp, err := NewAuthServer(nil, nil, nil, twoFactor).EnrollTOTP(ctx, &pb.EnrollTOTPRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SECRET", resp.Secret)
+		assert.Equal(t, "otpauth://totp/x", resp.OtpauthUri)
+	})
+
+	t.Run("Второй фактор уже включён", func(t *testing.T) {
+		twoFactor := new(MockTwoFactorManager)
+		twoFactor.On("Enroll", ctx, 7).Return(nil, helper.ErrTOTPAlreadyEnabled)
+
+		_, err := NewAuthServer(nil, nil, nil, twoFactor).EnrollTOTP(ctx, &pb.EnrollTOTPRequest{})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("Подтверждение возвращает коды восстановления", func(t *testing.T) {
+		twoFactor := new(MockTwoFactorManager)
+		twoFactor.On("Confirm", ctx, 7, "123456").Return([]string{"AAAA-BBBB"}, nil)
+
+		resp, err := NewAuthServer(nil, nil, nil, twoFactor).ConfirmTOTP(ctx, &pb.ConfirmTOTPRequest{Code: "123456"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"AAAA-BBBB"}, resp.RecoveryCodes)
+	})
+
+	t.Run("Неверный код при отключении", func(t *testing.T) {
+		twoFactor := new(MockTwoFactorManager)
+		twoFactor.On("Disable", ctx, 7, "000000").Return(helper.ErrInvalidCode)
+
+		_, err := NewAuthServer(nil, nil, nil, twoFactor).DisableTOTP(ctx, &pb.DisableTOTPRequest{Code: "000000"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Второй фактор не включён", func(t *testing.T) {
+		twoFactor := new(MockTwoFactorManager)
+		twoFactor.On("Disable", ctx, 7, "123456").Return(helper.ErrTOTPNotEnrolled)
+
+		_, err := NewAuthServer(nil, nil, nil, twoFactor).DisableTOTP(ctx, &pb.DisableTOTPRequest{Code: "123456"})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type binaryService interface {
+	StartUpload(ctx context.Context, userID int, upload *entity.Upload) (*entity.Upload, error)
+	SaveChunk(ctx context.Context, userID int, uploadID string, seq int, data, checksum []byte) (*entity.Upload, error)
+	Download(ctx context.Context, userID, dataID, from int, send func(chunk *entity.BinaryChunk) error) error
+}
+
+type BinaryServer struct {
+	datapb.UnimplementedBinaryServiceServer
+	binaryService binaryService
+	logger        logger.CustomLogger
+}
+
+func NewBinaryServer(binaryService binaryService, logger logger.CustomLogger) *BinaryServer {
+	return &BinaryServer{
+		binaryService: binaryService,
+		logger:        logger,
+	}
+}
+
+// StartUpload начинает загрузку файла по частям или возвращает номер части, с которой
+// нужно продолжить прерванную загрузку.
+func (h *BinaryServer) StartUpload(
+	ctx context.Context,
+	req *datapb.StartUploadRequest,
+) (*datapb.StartUploadResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	upload := &entity.Upload{
+		ID:         req.UploadId,
+		ChunkCount: int(req.ChunkCount),
+		ReplaceID:  int(req.ReplaceId),
+	}
+	if req.Data != nil {
+		if upload.Info, err = recordInfo(req.Data); err != nil {
+			return nil, h.uploadError(err)
+		}
+		upload.Meta = req.Data.Meta
+		upload.SearchTokens = strings.Join(req.Data.SearchTokens, " ")
+	}
+
+	upload, err = h.binaryService.StartUpload(ctx, userID, upload)
+	if err != nil {
+		return nil, h.uploadError(err)
+	}
+
+	return &datapb.StartUploadResponse{UploadId: upload.ID, NextChunk: int32(upload.Received)}, nil
+}
+
+// UploadBinary принимает части файла до конца потока. Если поток оборвался, клиент узнаёт
+// через StartUpload, какие части уже приняты, и продолжает с них.
+func (h *BinaryServer) UploadBinary(
+	stream grpc.ClientStreamingServer[datapb.BinaryChunk, datapb.UploadBinaryResponse],
+) error {
+	ctx := stream.Context()
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	var upload *entity.Upload
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		upload, err = h.binaryService.SaveChunk(ctx, userID, chunk.UploadId, int(chunk.Seq), chunk.Data, chunk.Sha256)
+		if err != nil {
+			return h.uploadError(err)
+		}
+	}
+
+	if upload == nil {
+		return status.Error(codes.InvalidArgument, "не получено ни одной части файла")
+	}
+
+	return stream.SendAndClose(&datapb.UploadBinaryResponse{
+		NextChunk: int32(upload.Received),
+		Id:        int32(upload.DataID),
+	})
+}
+
+// DownloadBinary передаёт части файла записи, начиная с from_chunk, чтобы прерванное
+// скачивание можно было продолжить.
+func (h *BinaryServer) DownloadBinary(
+	req *datapb.DownloadBinaryRequest,
+	stream grpc.ServerStreamingServer[datapb.BinaryChunk],
+) error {
+	ctx := stream.Context()
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	err = h.binaryService.Download(ctx, userID, int(req.Id), int(req.FromChunk), func(chunk *entity.BinaryChunk) error {
+		sum := sha256.Sum256(chunk.Data)
+		return stream.Send(&datapb.BinaryChunk{Seq: int32(chunk.Seq), Data: chunk.Data, Sha256: sum[:]})
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return h.uploadError(err)
+	}
+
+	return nil
+}
+
+func (h *BinaryServer) uploadError(err error) error {
+	if st := kindError(err, nil); st != nil {
+		return st
+	}
+
+	switch {
+	case errors.Is(err, helper.ErrInvalidUpload), errors.Is(err, helper.ErrChunkTooLarge):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, helper.ErrChunkOutOfOrder):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, helper.ErrChecksumMismatch):
+		return status.Error(codes.DataLoss, err.Error())
+	default:
+		h.logger.LogInfo("Ошибка при передаче файла", err)
+		return status.Error(codes.Internal, "ошибка при передаче файла")
+	}
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockBinaryService struct {
+	StartUploadFunc func(ctx context.Context, userID int, upload *entity.Upload) (*entity.Upload, error)
+	SaveChunkFunc   func(ctx context.Context, userID int, id string, seq int, data, sum []byte) (*entity.Upload, error)
+	DownloadFunc    func(ctx context.Context, userID, dataID, from int, send func(*entity.BinaryChunk) error) error
+}
+
+func (m *mockBinaryService) StartUpload(
+	ctx context.Context,
+	userID int,
+	upload *entity.Upload,
+) (*entity.Upload, error) {
+	return m.StartUploadFunc(ctx, userID, upload)
+}
+
+func (m *mockBinaryService) SaveChunk(
+	ctx context.Context,
+	userID int,
+	uploadID string,
+	seq int,
+	data, checksum []byte,
+) (*entity.Upload, error) {
+	return m.SaveChunkFunc(ctx, userID, uploadID, seq, data, checksum)
+}
+
+func (m *mockBinaryService) Download(
+	ctx context.Context,
+	userID, dataID, from int,
+	send func(chunk *entity.BinaryChunk) error,
+) error {
+	return m.DownloadFunc(ctx, userID, dataID, from, send)
+}
+
+// fakeUploadStream отдаёт части из chunks, затем io.EOF.
+type fakeUploadStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	response *datapb.UploadBinaryResponse
+	chunks   []*datapb.BinaryChunk
+}
+
+func (s *fakeUploadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeUploadStream) Recv() (*datapb.BinaryChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func (s *fakeUploadStream) SendAndClose(response *datapb.UploadBinaryResponse) error {
+	s.response = response
+	return nil
+}
+
+type fakeDownloadStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*datapb.BinaryChunk
+}
+
+func (s *fakeDownloadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeDownloadStream) Send(chunk *datapb.BinaryChunk) error {
+	s.sent = append(s.sent, chunk)
+	return nil
+}
+
+func TestStartUpload(t *testing.T) {
+	service := &mockBinaryService{
+		StartUploadFunc: func(ctx context.Context, userID int, upload *entity.Upload) (*entity.Upload, error) {
+			if upload.ID == "missing" {
+				return nil, helper.ErrUploadNotFound
+			}
+			if userID != 1 || upload.ChunkCount != 3 || upload.Info != testCiphertext || upload.Meta != "meta" {
+				t.Errorf("Unexpected upload: %+v", upload)
+			}
+			return &entity.Upload{ID: "up1", Received: 2}, nil
+		},
+	}
+	server := NewBinaryServer(service, &mockLogger{})
+
+	resp, err := server.StartUpload(contextWithUserID(1), &datapb.StartUploadRequest{
+		ChunkCount: 3,
+		Data:       &datapb.DataItem{Info: []byte(testCiphertext), Meta: "meta"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.UploadId != "up1" || resp.NextChunk != 2 {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+
+	_, err = server.StartUpload(contextWithUserID(1), &datapb.StartUploadRequest{UploadId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got: %v", err)
+	}
+}
+
+func TestUploadBinary(t *testing.T) {
+	var saved []int
+	service := &mockBinaryService{
+		SaveChunkFunc: func(
+			ctx context.Context,
+			userID int,
+			uploadID string,
+			seq int,
+			data, checksum []byte,
+		) (*entity.Upload, error) {
+			if seq == 5 {
+				return nil, helper.ErrChunkOutOfOrder
+			}
+			saved = append(saved, seq)
+			upload := &entity.Upload{ID: uploadID, Received: seq + 1, ChunkCount: 2}
+			if upload.Received == upload.ChunkCount {
+				upload.DataID = 7
+			}
+			return upload, nil
+		},
+	}
+	server := NewBinaryServer(service, &mockLogger{})
+
+	stream := &fakeUploadStream{
+		ctx:    contextWithUserID(1),
+		chunks: []*datapb.BinaryChunk{{UploadId: "up1", Seq: 0}, {UploadId: "up1", Seq: 1}},
+	}
+	if err := server.UploadBinary(stream); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if stream.response.Id != 7 || stream.response.NextChunk != 2 || len(saved) != 2 {
+		t.Errorf("Unexpected response: %+v, saved %v", stream.response, saved)
+	}
+
+	err := server.UploadBinary(&fakeUploadStream{
+		ctx:    contextWithUserID(1),
+		chunks: []*datapb.BinaryChunk{{UploadId: "up1", Seq: 5}},
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got: %v", err)
+	}
+
+	err = server.UploadBinary(&fakeUploadStream{ctx: contextWithUserID(1)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for empty stream, got: %v", err)
+	}
+}
+
+func TestDownloadBinary(t *testing.T) {
+	service := &mockBinaryService{
+		DownloadFunc: func(ctx context.Context, userID, dataID, from int, send func(*entity.BinaryChunk) error) error {
+			if dataID != 7 {
+				return helper.ErrUploadNotFound
+			}
+			return send(&entity.BinaryChunk{Seq: from, Data: []byte("data")})
+		},
+	}
+	server := NewBinaryServer(service, &mockLogger{})
+
+	stream := &fakeDownloadStream{ctx: contextWithUserID(1)}
+	if err := server.DownloadBinary(&datapb.DownloadBinaryRequest{Id: 7, FromChunk: 3}, stream); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	sum := sha256.Sum256([]byte("data"))
+	if len(stream.sent) != 1 || stream.sent[0].Seq != 3 || !bytes.Equal(stream.sent[0].Sha256, sum[:]) {
+		t.Errorf("Unexpected chunks: %+v", stream.sent)
+	}
+
+	err := server.DownloadBinary(&datapb.DownloadBinaryRequest{Id: 8}, &fakeDownloadStream{ctx: contextWithUserID(1)})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got: %v", err)
+	}
+}
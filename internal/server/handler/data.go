@@ -2,12 +2,21 @@ package handler
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/NikolosHGW/goph-keeper/api/datapb"
 	"github.com/NikolosHGW/goph-keeper/internal/contextkey"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
 	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
 	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -16,14 +25,42 @@ import (
 type dataService interface {
 	AddData(ctx context.Context, userID int, data *entity.UserData) (int, error)
 	GetDataByID(ctx context.Context, userID, dataID int) (*entity.UserData, error)
-	UpdateData(ctx context.Context, userID int, data *entity.UserData) error
+	UpdateData(ctx context.Context, userID int, data *entity.UserData, expectedRevision int64) error
 	DeleteData(ctx context.Context, userID, dataID int) error
-	ListData(ctx context.Context, userID int, infoType string) ([]*entity.UserData, error)
+	ListData(ctx context.Context, userID int, page *entity.DataPage) ([]*entity.UserData, error)
+	SearchData(ctx context.Context, userID int, search *entity.DataSearch) ([]*entity.UserData, error)
+	SyncData(ctx context.Context, userID int, sinceRevision int64, limit int) ([]*entity.DataChange, error)
+	WatchData(userID int) (events <-chan *entity.DataEvent, cancel func())
+	ListVersions(ctx context.Context, userID, dataID int) ([]*entity.UserData, error)
+	GetVersion(ctx context.Context, userID, dataID int, revision int64) (*entity.UserData, error)
+	RestoreVersion(ctx context.Context, userID, dataID int, revision int64) (int64, error)
+	ListTrash(ctx context.Context, userID int) ([]*entity.UserData, error)
+	RestoreData(ctx context.Context, userID, dataID int) error
+	PurgeData(ctx context.Context, userID, dataID int) error
+	EmptyTrash(ctx context.Context, userID int) ([]int, error)
 }
 
+var dataEventKinds = map[string]datapb.DataEvent_Kind{
+	entity.DataAdded:   datapb.DataEvent_ADDED,
+	entity.DataUpdated: datapb.DataEvent_UPDATED,
+	entity.DataDeleted: datapb.DataEvent_DELETED,
+}
+
+const (
+	defaultSyncLimit = 500
+	maxSyncLimit     = 1000
+
+	defaultListPageSize = 100
+	maxListPageSize     = 1000
+
+	defaultSearchPageSize = 50
+	maxSearchPageSize     = 500
+)
+
 type DataServer struct {
 	datapb.UnimplementedDataServiceServer
 	dataService dataService
+	recordTypes recordTypeLookup
 	logger      logger.CustomLogger
 }
 
@@ -34,21 +71,34 @@ func NewDataServer(dataService dataService, logger logger.CustomLogger) *DataSer
 	}
 }
 
+// WithRecordTypes включает записи пользовательских типов: их тип ищется среди типов пользователя,
+// а содержимое проверяется по схеме типа. Без этого записи принимаются только встроенных типов.
+func (h *DataServer) WithRecordTypes(recordTypes recordTypeLookup) *DataServer {
+	h.recordTypes = recordTypes
+	return h
+}
+
 func (h *DataServer) AddData(ctx context.Context, req *datapb.AddDataRequest) (*datapb.AddDataResponse, error) {
 	userID, err := getUserIDFromContext(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
 	}
 
+	info, err := h.dataInfo(ctx, userID, req.Data)
+	if err != nil {
+		return nil, h.dataError("ошибка при добавлении данных", err, 0)
+	}
+
 	data := &entity.UserData{
-		InfoType: req.Data.InfoType,
-		Info:     string(req.Data.Info),
-		Meta:     req.Data.Meta,
+		InfoType:     req.Data.InfoType,
+		Info:         info,
+		Meta:         req.Data.Meta,
+		SearchTokens: req.Data.SearchTokens,
 	}
 
 	id, err := h.dataService.AddData(ctx, userID, data)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "ошибка при добавлении данных")
+		return nil, h.dataError("ошибка при добавлении данных", err, 0)
 	}
 
 	return &datapb.AddDataResponse{Id: int32(id)}, nil
@@ -62,18 +112,10 @@ func (h *DataServer) GetData(ctx context.Context, req *datapb.GetDataRequest) (*
 
 	data, err := h.dataService.GetDataByID(ctx, userID, int(req.Id))
 	if err != nil {
-		return nil, status.Error(codes.NotFound, "данные не найдены")
+		return nil, h.dataError("ошибка при получении данных", err, int(req.Id))
 	}
 
-	return &datapb.GetDataResponse{
-		Data: &datapb.DataItem{
-			Id:       int32(data.ID),
-			InfoType: data.InfoType,
-			Info:     []byte(data.Info),
-			Meta:     data.Meta,
-			Created:  timestamppb.New(data.Created),
-		},
-	}, nil
+	return &datapb.GetDataResponse{Data: h.toDataItem(data)}, nil
 }
 
 func (h *DataServer) UpdateData(ctx context.Context, req *datapb.UpdateDataRequest) (*datapb.UpdateDataResponse, error) {
@@ -83,24 +125,68 @@ func (h *DataServer) UpdateData(ctx context.Context, req *datapb.UpdateDataReque
 		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
 	}
 
+	info, err := h.dataInfo(ctx, userID, req.Data)
+	if err != nil {
+		return nil, h.dataError("ошибка при обновлении данных", err, int(req.Data.Id))
+	}
+
 	data := &entity.UserData{
-		ID:       int(req.Data.Id),
-		UserID:   userID,
-		InfoType: req.Data.InfoType,
-		Info:     string(req.Data.Info),
-		Meta:     req.Data.Meta,
-		Created:  req.Data.Created.AsTime(),
+		ID:           int(req.Data.Id),
+		UserID:       userID,
+		InfoType:     req.Data.InfoType,
+		Info:         info,
+		Meta:         req.Data.Meta,
+		Created:      req.Data.Created.AsTime(),
+		SearchTokens: req.Data.SearchTokens,
 	}
 
-	err = h.dataService.UpdateData(ctx, userID, data)
+	err = h.dataService.UpdateData(ctx, userID, data, req.ExpectedRevision)
+	if errors.Is(err, helper.ErrVersionConflict) {
+		return nil, h.conflictError(ctx, userID, data.ID)
+	}
 	if err != nil {
-		h.logger.LogInfo("Ошибка при обновлении данных", err)
-		return nil, status.Error(codes.Internal, "ошибка при обновлении данных")
+		return nil, h.dataError("ошибка при обновлении данных", err, data.ID)
 	}
 
 	return &datapb.UpdateDataResponse{}, nil
 }
 
+// conflictError - ответ Aborted с текущей версией записи в деталях, чтобы клиент мог показать её
+// пользователю и повторить обновление с её ревизией.
+func (h *DataServer) conflictError(ctx context.Context, userID, dataID int) error {
+	message := "запись изменена с момента чтения"
+
+	current, err := h.dataService.GetDataByID(ctx, userID, dataID)
+	if err != nil {
+		h.logger.LogInfo("Ошибка при получении текущей версии данных", err)
+		return status.Error(codes.Aborted, message)
+	}
+
+	st, err := status.New(codes.Aborted, message).WithDetails(h.toDataItem(current))
+	if err != nil {
+		h.logger.LogInfo("Ошибка при формировании деталей конфликта", err)
+		return status.Error(codes.Aborted, message)
+	}
+
+	return st.Err()
+}
+
+// dataError переводит ошибку сервиса в статус gRPC. Ошибки видов из helper получают свой код, а если dataID
+// не 0, ещё и ResourceInfo записи в деталях. Неизвестные ошибки логируются и возвращаются клиенту
+// как Internal с сообщением message.
+func (h *DataServer) dataError(message string, err error, dataID int) error {
+	var resource *errdetails.ResourceInfo
+	if dataID != 0 {
+		resource = dataResource(dataID)
+	}
+	if st := kindError(err, resource); st != nil {
+		return st
+	}
+
+	h.logger.LogInfo(message, err)
+	return status.Error(codes.Internal, message)
+}
+
 func (h *DataServer) DeleteData(ctx context.Context, req *datapb.DeleteDataRequest) (*datapb.DeleteDataResponse, error) {
 	userID, err := getUserIDFromContext(ctx)
 	if err != nil {
@@ -110,8 +196,7 @@ func (h *DataServer) DeleteData(ctx context.Context, req *datapb.DeleteDataReque
 
 	err = h.dataService.DeleteData(ctx, userID, int(req.Id))
 	if err != nil {
-		h.logger.LogInfo("Ошибка при удалении данных", err)
-		return nil, status.Error(codes.Internal, "ошибка при удалении данных")
+		return nil, h.dataError("ошибка при удалении данных", err, int(req.Id))
 	}
 
 	return &datapb.DeleteDataResponse{}, nil
@@ -129,26 +214,471 @@ func getUserIDFromContext(ctx context.Context) (int, error) {
 	return userID, nil
 }
 
+func getSessionIDFromContext(ctx context.Context) (string, error) {
+	sessionID, ok := ctx.Value(contextkey.SessionIDKey).(string)
+	if !ok || sessionID == "" {
+		return "", errors.New("sessionID не найден в контексте")
+	}
+	return sessionID, nil
+}
+
+// ListData возвращает записи пользователя страницами в порядке даты создания: если next_page_token
+// не пуст, клиент повторяет запрос с ним.
 func (h *DataServer) ListData(ctx context.Context, req *datapb.ListDataRequest) (*datapb.ListDataResponse, error) {
 	userID, err := getUserIDFromContext(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
 	}
 
-	dataItems, err := h.dataService.ListData(ctx, userID, req.InfoType)
+	page, err := toDataPage(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pageSize := page.Limit
+	page.Limit++
+
+	dataItems, err := h.dataService.ListData(ctx, userID, page)
 	if err != nil {
+		h.logger.LogInfo("Ошибка при получении данных", err)
 		return nil, status.Error(codes.Internal, "ошибка при получении данных")
 	}
 
-	responseItems := make([]*datapb.DataItem, len(dataItems))
+	resp := &datapb.ListDataResponse{}
+	resp.DataItems, resp.NextPageToken = toSummaryPage(dataItems, pageSize)
+
+	return resp, nil
+}
+
+func toDataPage(req *datapb.ListDataRequest) (*entity.DataPage, error) {
+	if req.PageSize < 0 {
+		return nil, errors.New("размер страницы не может быть отрицательным")
+	}
+
+	page := &entity.DataPage{InfoType: req.InfoType, Limit: int(req.PageSize), TagID: int(req.TagId)}
+	if req.FolderId != nil {
+		folderID := int(*req.FolderId)
+		page.FolderID = &folderID
+	}
+	if page.Limit == 0 {
+		page.Limit = defaultListPageSize
+	}
+	page.Limit = min(page.Limit, maxListPageSize)
+
+	if req.PageToken != "" {
+		cursor, err := decodePageToken(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		page.After = cursor
+	}
+
+	return page, nil
+}
+
+// SearchData ищет записи по токенам поиска, типу и дате создания. Результаты приходят страницами:
+// если next_page_token не пуст, клиент повторяет запрос с ним.
+func (h *DataServer) SearchData(
+	ctx context.Context,
+	req *datapb.SearchDataRequest,
+) (*datapb.SearchDataResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	search, err := toDataSearch(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pageSize := search.Limit
+	// Лишняя запись показывает, что за страницей есть продолжение.
+	search.Limit++
+
+	dataItems, err := h.dataService.SearchData(ctx, userID, search)
+	if err != nil {
+		return nil, h.dataError("ошибка при поиске данных", err, 0)
+	}
+
+	resp := &datapb.SearchDataResponse{}
+	resp.DataItems, resp.NextPageToken = toSummaryPage(dataItems, pageSize)
+
+	return resp, nil
+}
+
+// toSummaryPage обрезает записи, запрошенные с одной лишней, до размера страницы. Если лишняя запись
+// нашлась, возвращается токен следующей страницы с позицией последней записи этой.
+func toSummaryPage(dataItems []*entity.UserData, pageSize int) ([]*datapb.DataItem, string) {
+	var nextPageToken string
+	if len(dataItems) > pageSize {
+		dataItems = dataItems[:pageSize]
+		last := dataItems[pageSize-1]
+		nextPageToken = encodePageToken(&entity.DataCursor{Created: last.Created, ID: last.ID})
+	}
+
+	items := make([]*datapb.DataItem, len(dataItems))
 	for i, item := range dataItems {
-		responseItems[i] = &datapb.DataItem{
-			Id:       int32(item.ID),
-			InfoType: item.InfoType,
-			Meta:     item.Meta,
-			Created:  timestamppb.New(item.Created),
+		items[i] = toDataSummary(item)
+	}
+
+	return items, nextPageToken
+}
+
+func toDataSearch(req *datapb.SearchDataRequest) (*entity.DataSearch, error) {
+	if req.PageSize < 0 {
+		return nil, fmt.Errorf("%w: размер страницы не может быть отрицательным", helper.ErrInvalidSearch)
+	}
+
+	search := &entity.DataSearch{
+		Tokens:    req.Tokens,
+		InfoType:  req.InfoType,
+		Ascending: req.Sort == datapb.SearchDataRequest_CREATED_ASC,
+		Limit:     int(req.PageSize),
+	}
+	if search.Limit == 0 {
+		search.Limit = defaultSearchPageSize
+	}
+	search.Limit = min(search.Limit, maxSearchPageSize)
+
+	if req.CreatedFrom != nil {
+		search.CreatedFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		search.CreatedTo = req.CreatedTo.AsTime()
+	}
+	if !search.CreatedFrom.IsZero() && !search.CreatedTo.IsZero() && !search.CreatedFrom.Before(search.CreatedTo) {
+		return nil, fmt.Errorf("%w: начало периода должно быть раньше конца", helper.ErrInvalidSearch)
+	}
+
+	if req.PageToken != "" {
+		cursor, err := decodePageToken(req.PageToken)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", helper.ErrInvalidSearch, err)
 		}
+		search.After = cursor
+	}
+
+	return search, nil
+}
+
+// encodePageToken кодирует позицию последней записи страницы в непрозрачную для клиента строку.
+func encodePageToken(cursor *entity.DataCursor) string {
+	raw := strconv.FormatInt(cursor.Created.UnixNano(), 10) + ":" + strconv.Itoa(cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePageToken(token string) (*entity.DataCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, helper.ErrInvalidPageToken
+	}
+	rawCreated, rawID, found := strings.Cut(string(raw), ":")
+	if !found {
+		return nil, helper.ErrInvalidPageToken
+	}
+	created, err := strconv.ParseInt(rawCreated, 10, 64)
+	if err != nil {
+		return nil, helper.ErrInvalidPageToken
+	}
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		return nil, helper.ErrInvalidPageToken
+	}
+
+	return &entity.DataCursor{Created: time.Unix(0, created).UTC(), ID: id}, nil
+}
+
+// SyncData - изменения данных пользователя после ревизии клиента для синхронизации локального кеша.
+// Если has_more, клиент повторяет запрос с полученной ревизией.
+func (h *DataServer) SyncData(ctx context.Context, req *datapb.SyncDataRequest) (*datapb.SyncDataResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+	if req.SinceRevision < 0 || req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ревизия и лимит не могут быть отрицательными")
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultSyncLimit
+	}
+	limit = min(limit, maxSyncLimit)
+
+	changes, err := h.dataService.SyncData(ctx, userID, req.SinceRevision, limit)
+	if err != nil {
+		h.logger.LogInfo("Ошибка при получении изменений", err)
+		return nil, status.Error(codes.Internal, "ошибка при получении изменений")
+	}
+
+	resp := &datapb.SyncDataResponse{
+		Changes:  make([]*datapb.DataChange, 0, len(changes)),
+		Revision: req.SinceRevision,
+		HasMore:  len(changes) == limit,
+	}
+	for _, change := range changes {
+		item := &datapb.DataChange{
+			Id:       int32(change.ID),
+			Deleted:  change.Deleted,
+			Revision: change.Revision,
+		}
+		if change.Data != nil {
+			item.Data = h.toDataItem(change.Data)
+		}
+		resp.Changes = append(resp.Changes, item)
+		resp.Revision = change.Revision
+	}
+
+	return resp, nil
+}
+
+// WatchData - поток событий об изменении данных пользователя, пока клиент не отключится.
+// Если клиент не успевает получать события или часть событий потеряна, например при разрыве соединения
+// брокера с базой, поток завершается с Aborted: клиенту нужно синхронизироваться и подписаться заново.
+func (h *DataServer) WatchData(_ *datapb.WatchDataRequest, stream grpc.ServerStreamingServer[datapb.DataEvent]) error {
+	ctx := stream.Context()
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	events, cancel := h.dataService.WatchData(userID)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.Aborted, "события могли быть пропущены, синхронизируйтесь и подпишитесь заново")
+			}
+
+			err = stream.Send(&datapb.DataEvent{
+				Kind:     dataEventKinds[event.Kind],
+				Id:       int32(event.ID),
+				InfoType: event.InfoType,
+			})
+			if err != nil {
+				h.logger.LogInfo("Ошибка при отправке события", err)
+				return err
+			}
+		}
+	}
+}
+
+// ListVersions возвращает прежние версии записи без Info, от новых к старым.
+func (h *DataServer) ListVersions(
+	ctx context.Context,
+	req *datapb.ListVersionsRequest,
+) (*datapb.ListVersionsResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	versions, err := h.dataService.ListVersions(ctx, userID, int(req.DataId))
+	if err != nil {
+		return nil, h.dataError("ошибка при получении версий записи", err, int(req.DataId))
+	}
+
+	resp := &datapb.ListVersionsResponse{Versions: make([]*datapb.DataItem, len(versions))}
+	for i, version := range versions {
+		resp.Versions[i] = toDataSummary(version)
+	}
+
+	return resp, nil
+}
+
+// GetVersion возвращает прежнюю версию записи целиком.
+func (h *DataServer) GetVersion(
+	ctx context.Context,
+	req *datapb.GetVersionRequest,
+) (*datapb.GetVersionResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	version, err := h.dataService.GetVersion(ctx, userID, int(req.DataId), req.Revision)
+	if err != nil {
+		return nil, h.dataError("ошибка при получении версии записи", err, int(req.DataId))
+	}
+
+	return &datapb.GetVersionResponse{Data: h.toDataItem(version)}, nil
+}
+
+// RestoreVersion возвращает записи содержимое прежней версии. Текущее содержимое сохраняется как версия,
+// так что восстановление тоже можно отменить.
+func (h *DataServer) RestoreVersion(
+	ctx context.Context,
+	req *datapb.RestoreVersionRequest,
+) (*datapb.RestoreVersionResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	revision, err := h.dataService.RestoreVersion(ctx, userID, int(req.DataId), req.Revision)
+	if err != nil {
+		return nil, h.dataError("ошибка при восстановлении версии записи", err, int(req.DataId))
+	}
+
+	return &datapb.RestoreVersionResponse{Revision: revision}, nil
+}
+
+// ListTrash возвращает записи в корзине без Info, начиная с удалённых последними.
+func (h *DataServer) ListTrash(ctx context.Context, _ *datapb.ListTrashRequest) (*datapb.ListTrashResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	dataItems, err := h.dataService.ListTrash(ctx, userID)
+	if err != nil {
+		h.logger.LogInfo("Ошибка при получении корзины", err)
+		return nil, status.Error(codes.Internal, "ошибка при получении корзины")
+	}
+
+	resp := &datapb.ListTrashResponse{DataItems: make([]*datapb.DataItem, len(dataItems))}
+	for i, data := range dataItems {
+		resp.DataItems[i] = toDataSummary(data)
+		resp.DataItems[i].Deleted = timestamppb.New(data.Deleted)
+	}
+
+	return resp, nil
+}
+
+// RestoreData возвращает запись из корзины.
+func (h *DataServer) RestoreData(
+	ctx context.Context,
+	req *datapb.RestoreDataRequest,
+) (*datapb.RestoreDataResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	err = h.dataService.RestoreData(ctx, userID, int(req.Id))
+	if err != nil {
+		return nil, h.dataError("ошибка при восстановлении записи из корзины", err, int(req.Id))
+	}
+
+	return &datapb.RestoreDataResponse{}, nil
+}
+
+// PurgeData удаляет запись из корзины навсегда. Запись не из корзины не удаляется.
+func (h *DataServer) PurgeData(ctx context.Context, req *datapb.PurgeDataRequest) (*datapb.PurgeDataResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	err = h.dataService.PurgeData(ctx, userID, int(req.Id))
+	if err != nil {
+		return nil, h.dataError("ошибка при удалении записи из корзины", err, int(req.Id))
+	}
+
+	return &datapb.PurgeDataResponse{}, nil
+}
+
+// EmptyTrash удаляет навсегда все записи в корзине.
+func (h *DataServer) EmptyTrash(ctx context.Context, _ *datapb.EmptyTrashRequest) (*datapb.EmptyTrashResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	purged, err := h.dataService.EmptyTrash(ctx, userID)
+	if err != nil {
+		h.logger.LogInfo("Ошибка при очистке корзины", err)
+		return nil, status.Error(codes.Internal, "ошибка при очистке корзины")
+	}
+
+	return &datapb.EmptyTrashResponse{Purged: int64(len(purged))}, nil
+}
+
+// toDataSummary - запись для списков, без Info.
+func toDataSummary(data *entity.UserData) *datapb.DataItem {
+	return &datapb.DataItem{
+		Id:       int32(data.ID),
+		InfoType: data.InfoType,
+		Meta:     data.Meta,
+		Created:  timestamppb.New(data.Created),
+		Updated:  timestamppb.New(data.Updated),
+		Revision: data.Revision,
+		FolderId: int32(data.FolderID),
+		TagIds:   toInt32s(data.TagIDs),
+	}
+}
+
+// toDataItem - запись целиком. Содержимое известных типов передаётся в payload, а шифротекст сквозного
+// шифрования - в Info как есть. Info, которое не разбирается, тоже передаётся как есть, а ошибка логируется:
+// запись повреждена, но клиенту остаётся доступна.
+func (h *DataServer) toDataItem(data *entity.UserData) *datapb.DataItem {
+	item := &datapb.DataItem{
+		Id:       int32(data.ID),
+		InfoType: data.InfoType,
+		Info:     []byte(data.Info),
+		Meta:     data.Meta,
+		Created:  timestamppb.New(data.Created),
+		Updated:  timestamppb.New(data.Updated),
+		Revision: data.Revision,
+		FolderId: int32(data.FolderID),
+		TagIds:   toInt32s(data.TagIDs),
+	}
+	if err := payload.Unpack(item); err != nil {
+		h.logger.LogInfo(fmt.Sprintf("Не удалось разобрать Info записи %d", data.ID), err)
+	}
+
+	return item
+}
+
+// recordInfo возвращает Info записи для сохранения: проверенное содержимое из payload в JSON или,
+// если payload не задан, Info запроса как есть - его зашифровал клиент. Info без payload, которое не похоже
+// на шифротекст, отклоняется, чтобы открытые данные не попали в базу непроверенными.
+func recordInfo(item *datapb.DataItem) (string, error) {
+	message := payload.Message(item)
+	if message == nil {
+		if err := payload.ValidateCiphertext(item.Info); err != nil {
+			return "", fmt.Errorf("%w: %w", helper.ErrInvalidPayload, err)
+		}
+		return string(item.Info), nil
+	}
+
+	if err := payload.Validate(item); err != nil {
+		return "", fmt.Errorf("%w: %w", helper.ErrInvalidPayload, err)
+	}
+	info, err := payload.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+
+	return string(info), nil
+}
+
+// dataInfo возвращает Info записи для сохранения, как recordInfo, и для записи пользовательского типа
+// проверяет её по схеме типа.
+func (h *DataServer) dataInfo(ctx context.Context, userID int, item *datapb.DataItem) (string, error) {
+	info, err := recordInfo(item)
+	if err != nil || payload.IsBuiltin(item.InfoType) {
+		return info, err
+	}
+	if err = customInfo(ctx, h.recordTypes, userID, item); err != nil {
+		return "", err
+	}
+
+	return info, nil
+}
+
+func toInt32s(ids []int64) []int32 {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	result := make([]int32, len(ids))
+	for i, id := range ids {
+		result[i] = int32(id)
 	}
 
-	return &datapb.ListDataResponse{DataItems: responseItems}, nil
+	return result
 }
@@ -2,18 +2,29 @@ package handler
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/NikolosHGW/goph-keeper/api/datapb"
 	"github.com/NikolosHGW/goph-keeper/internal/contextkey"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
 	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// testCiphertext - Info записи, зашифрованное клиентом: конверт с нулевыми nonce, шифротекстом и тегом.
+var testCiphertext = payload.CiphertextPrefix + base64.StdEncoding.EncodeToString(make([]byte, 32))
+
 type mockLogger struct{}
 
 func (l *mockLogger) LogInfo(message string, err error) {}
@@ -21,9 +32,85 @@ func (l *mockLogger) LogInfo(message string, err error) {}
 type mockDataService struct {
 	AddDataFunc     func(ctx context.Context, userID int, data *entity.UserData) (int, error)
 	GetDataByIDFunc func(ctx context.Context, userID, dataID int) (*entity.UserData, error)
-	UpdateDataFunc  func(ctx context.Context, userID int, data *entity.UserData) error
+	UpdateDataFunc  func(ctx context.Context, userID int, data *entity.UserData, expected int64) error
 	DeleteDataFunc  func(ctx context.Context, userID, dataID int) error
-	ListDataFunc    func(ctx context.Context, userID int, infoType string) ([]*entity.UserData, error)
+	ListDataFunc    func(ctx context.Context, userID int, page *entity.DataPage) ([]*entity.UserData, error)
+	SearchDataFunc  func(ctx context.Context, userID int, search *entity.DataSearch) ([]*entity.UserData, error)
+	SyncDataFunc    func(ctx context.Context, userID int, since int64, limit int) ([]*entity.DataChange, error)
+	WatchDataFunc   func(userID int) (<-chan *entity.DataEvent, func())
+
+	ListVersionsFunc   func(ctx context.Context, userID, dataID int) ([]*entity.UserData, error)
+	GetVersionFunc     func(ctx context.Context, userID, dataID int, revision int64) (*entity.UserData, error)
+	RestoreVersionFunc func(ctx context.Context, userID, dataID int, revision int64) (int64, error)
+
+	ListTrashFunc   func(ctx context.Context, userID int) ([]*entity.UserData, error)
+	RestoreDataFunc func(ctx context.Context, userID, dataID int) error
+	PurgeDataFunc   func(ctx context.Context, userID, dataID int) error
+	EmptyTrashFunc  func(ctx context.Context, userID int) ([]int, error)
+}
+
+func (m *mockDataService) ListTrash(ctx context.Context, userID int) ([]*entity.UserData, error) {
+	return m.ListTrashFunc(ctx, userID)
+}
+
+func (m *mockDataService) RestoreData(ctx context.Context, userID, dataID int) error {
+	return m.RestoreDataFunc(ctx, userID, dataID)
+}
+
+func (m *mockDataService) PurgeData(ctx context.Context, userID, dataID int) error {
+	return m.PurgeDataFunc(ctx, userID, dataID)
+}
+
+func (m *mockDataService) EmptyTrash(ctx context.Context, userID int) ([]int, error) {
+	return m.EmptyTrashFunc(ctx, userID)
+}
+
+func (m *mockDataService) ListVersions(ctx context.Context, userID, dataID int) ([]*entity.UserData, error) {
+	return m.ListVersionsFunc(ctx, userID, dataID)
+}
+
+func (m *mockDataService) GetVersion(
+	ctx context.Context,
+	userID, dataID int,
+	revision int64,
+) (*entity.UserData, error) {
+	return m.GetVersionFunc(ctx, userID, dataID, revision)
+}
+
+func (m *mockDataService) RestoreVersion(ctx context.Context, userID, dataID int, revision int64) (int64, error) {
+	return m.RestoreVersionFunc(ctx, userID, dataID, revision)
+}
+
+func (m *mockDataService) SearchData(
+	ctx context.Context,
+	userID int,
+	search *entity.DataSearch,
+) ([]*entity.UserData, error) {
+	return m.SearchDataFunc(ctx, userID, search)
+}
+
+func (m *mockDataService) WatchData(userID int) (events <-chan *entity.DataEvent, cancel func()) {
+	return m.WatchDataFunc(userID)
+}
+
+// fakeEventStream собирает отправленные события. Send возвращает sendErr, если он задан.
+type fakeEventStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sendErr error
+	sent    []*datapb.DataEvent
+}
+
+func (s *fakeEventStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeEventStream) Send(event *datapb.DataEvent) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.sent = append(s.sent, event)
+	return nil
 }
 
 func (m *mockDataService) AddData(ctx context.Context, userID int, data *entity.UserData) (int, error) {
@@ -34,20 +121,34 @@ func (m *mockDataService) GetDataByID(ctx context.Context, userID, dataID int) (
 	return m.GetDataByIDFunc(ctx, userID, dataID)
 }
 
-func (m *mockDataService) UpdateData(ctx context.Context, userID int, data *entity.UserData) error {
-	return m.UpdateDataFunc(ctx, userID, data)
+func (m *mockDataService) UpdateData(
+	ctx context.Context,
+	userID int,
+	data *entity.UserData,
+	expectedRevision int64,
+) error {
+	return m.UpdateDataFunc(ctx, userID, data, expectedRevision)
 }
 
 func (m *mockDataService) DeleteData(ctx context.Context, userID, dataID int) error {
 	return m.DeleteDataFunc(ctx, userID, dataID)
 }
 
+func (m *mockDataService) SyncData(
+	ctx context.Context,
+	userID int,
+	sinceRevision int64,
+	limit int,
+) ([]*entity.DataChange, error) {
+	return m.SyncDataFunc(ctx, userID, sinceRevision, limit)
+}
+
 func contextWithUserID(userID int) context.Context {
 	return context.WithValue(context.Background(), contextkey.UserIDKey, userID)
 }
 
-func (m *mockDataService) ListData(ctx context.Context, userID int, infoType string) ([]*entity.UserData, error) {
-	return m.ListDataFunc(ctx, userID, infoType)
+func (m *mockDataService) ListData(ctx context.Context, userID int, page *entity.DataPage) ([]*entity.UserData, error) {
+	return m.ListDataFunc(ctx, userID, page)
 }
 
 func TestAddData(t *testing.T) {
@@ -69,8 +170,8 @@ func TestAddData(t *testing.T) {
 			ctx:  contextWithUserID(1),
 			request: &datapb.AddDataRequest{
 				Data: &datapb.DataItem{
-					InfoType: "password",
-					Info:     []byte("mypassword"),
+					InfoType: "login_password",
+					Info:     []byte(testCiphertext),
 					Meta:     "meta",
 				},
 			},
@@ -79,7 +180,7 @@ func TestAddData(t *testing.T) {
 					if userID != 1 {
 						t.Errorf("Expected userID 1, got %d", userID)
 					}
-					if data.InfoType != "password" || data.Info != "mypassword" || data.Meta != "meta" {
+					if data.InfoType != "login_password" || data.Info != testCiphertext || data.Meta != "meta" {
 						t.Errorf("Unexpected data: %+v", data)
 					}
 					return 123, nil
@@ -101,8 +202,8 @@ func TestAddData(t *testing.T) {
 			ctx:  contextWithUserID(1),
 			request: &datapb.AddDataRequest{
 				Data: &datapb.DataItem{
-					InfoType: "password",
-					Info:     []byte("mypassword"),
+					InfoType: "login_password",
+					Info:     []byte(testCiphertext),
 					Meta:     "meta",
 				},
 			},
@@ -158,8 +259,8 @@ func TestGetData(t *testing.T) {
 					return &entity.UserData{
 						ID:       123,
 						UserID:   1,
-						InfoType: "password",
-						Info:     "mypassword",
+						InfoType: "login_password",
+						Info:     testCiphertext,
 						Meta:     "meta",
 						Created:  time.Now(),
 					}, nil
@@ -168,8 +269,8 @@ func TestGetData(t *testing.T) {
 			expectedResp: &datapb.GetDataResponse{
 				Data: &datapb.DataItem{
 					Id:       123,
-					InfoType: "password",
-					Info:     []byte("mypassword"),
+					InfoType: "login_password",
+					Info:     []byte(testCiphertext),
 					Meta:     "meta",
 				},
 			},
@@ -191,7 +292,21 @@ func TestGetData(t *testing.T) {
 			},
 			setupMocks: func() {
 				mockService.GetDataByIDFunc = func(ctx context.Context, userID, dataID int) (*entity.UserData, error) {
-					return nil, errors.New("not found")
+					return nil, errors.New("connection refused")
+				}
+			},
+			expectedResp:  nil,
+			expectedError: statusError(codes.Internal, "ошибка при получении данных"),
+		},
+		{
+			name: "NotFound",
+			ctx:  contextWithUserID(1),
+			request: &datapb.GetDataRequest{
+				Id: 123,
+			},
+			setupMocks: func() {
+				mockService.GetDataByIDFunc = func(ctx context.Context, userID, dataID int) (*entity.UserData, error) {
+					return nil, fmt.Errorf("ошибка получения данных из репозитория: %w", helper.ErrDataNotFound)
 				}
 			},
 			expectedResp:  nil,
@@ -244,15 +359,21 @@ func TestUpdateData(t *testing.T) {
 			request: &datapb.UpdateDataRequest{
 				Data: &datapb.DataItem{
 					Id:       123,
-					InfoType: "password",
-					Info:     []byte("newpassword"),
+					InfoType: "login_password",
+					Info:     []byte(testCiphertext),
 					Meta:     "newmeta",
 					Created:  timestamppb.New(time.Now()),
 				},
+				ExpectedRevision: 4,
 			},
 			setupMocks: func() {
-				mockService.UpdateDataFunc = func(ctx context.Context, userID int, data *entity.UserData) error {
-					if userID != 1 || data.ID != 123 || data.Info != "newpassword" {
+				mockService.UpdateDataFunc = func(
+					ctx context.Context,
+					userID int,
+					data *entity.UserData,
+					expected int64,
+				) error {
+					if userID != 1 || data.ID != 123 || data.Info != testCiphertext || expected != 4 {
 						t.Errorf("Unexpected data in UpdateData")
 					}
 					return nil
@@ -275,14 +396,14 @@ func TestUpdateData(t *testing.T) {
 			request: &datapb.UpdateDataRequest{
 				Data: &datapb.DataItem{
 					Id:       123,
-					InfoType: "password",
-					Info:     []byte("newpassword"),
+					InfoType: "login_password",
+					Info:     []byte(testCiphertext),
 					Meta:     "newmeta",
 					Created:  timestamppb.New(time.Now()),
 				},
 			},
 			setupMocks: func() {
-				mockService.UpdateDataFunc = func(ctx context.Context, userID int, data *entity.UserData) error {
+				mockService.UpdateDataFunc = func(ctx context.Context, userID int, data *entity.UserData, _ int64) error {
 					return errors.New("update failed")
 				}
 			},
@@ -307,6 +428,37 @@ func TestUpdateData(t *testing.T) {
 	}
 }
 
+func TestUpdateData_Conflict(t *testing.T) {
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockService := &mockDataService{
+		UpdateDataFunc: func(ctx context.Context, userID int, data *entity.UserData, expected int64) error {
+			return fmt.Errorf("ошибка репозитория: %w", helper.ErrVersionConflict)
+		},
+		GetDataByIDFunc: func(ctx context.Context, userID, dataID int) (*entity.UserData, error) {
+			return &entity.UserData{ID: dataID, InfoType: "text", Info: "с сервера", Updated: updated, Revision: 7}, nil
+		},
+	}
+	server := NewDataServer(mockService, &mockLogger{})
+
+	_, err := server.UpdateData(contextWithUserID(1), &datapb.UpdateDataRequest{
+		Data:             &datapb.DataItem{Id: 3, InfoType: "text", Info: []byte(testCiphertext)},
+		ExpectedRevision: 5,
+	})
+
+	st := status.Convert(err)
+	if st.Code() != codes.Aborted {
+		t.Fatalf("Expected Aborted, got: %v", err)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("Expected current record in details, got: %v", st.Details())
+	}
+	current, ok := st.Details()[0].(*datapb.DataItem)
+	if !ok || current.Id != 3 || string(current.Info) != "с сервера" || current.Revision != 7 ||
+		!current.Updated.AsTime().Equal(updated) {
+		t.Errorf("Unexpected current record: %v", st.Details()[0])
+	}
+}
+
 func TestDeleteData(t *testing.T) {
 	mockService := &mockDataService{}
 	mockLogger := &mockLogger{}
@@ -378,6 +530,148 @@ func TestDeleteData(t *testing.T) {
 	}
 }
 
+func TestDataErrors(t *testing.T) {
+	mockService := &mockDataService{
+		DeleteDataFunc: func(ctx context.Context, userID, dataID int) error {
+			return helper.ErrDataForbidden
+		},
+		UpdateDataFunc: func(ctx context.Context, userID int, data *entity.UserData, expected int64) error {
+			return helper.ErrDataNotFound
+		},
+		SearchDataFunc: func(ctx context.Context, userID int, search *entity.DataSearch) ([]*entity.UserData, error) {
+			return nil, fmt.Errorf("%w: больше 64 токенов поиска", helper.ErrInvalidSearch)
+		},
+	}
+	server := NewDataServer(mockService, &mockLogger{})
+	ctx := contextWithUserID(1)
+
+	_, err := server.DeleteData(ctx, &datapb.DeleteDataRequest{Id: 42})
+	st := status.Convert(err)
+	if st.Code() != codes.PermissionDenied || st.Message() != "запись принадлежит другому пользователю" {
+		t.Errorf("Expected PermissionDenied, got: %v", err)
+	}
+	if len(st.Details()) != 2 {
+		t.Fatalf("Expected ErrorInfo and ResourceInfo in details, got: %v", st.Details())
+	}
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	if !ok || info.Reason != "PERMISSION_DENIED" || info.Domain != errorDomain {
+		t.Errorf("Unexpected ErrorInfo: %v", st.Details()[0])
+	}
+	resource, ok := st.Details()[1].(*errdetails.ResourceInfo)
+	if !ok || resource.ResourceType != "user_data" || resource.ResourceName != "42" {
+		t.Errorf("Unexpected ResourceInfo: %v", st.Details()[1])
+	}
+
+	_, err = server.UpdateData(ctx, &datapb.UpdateDataRequest{
+		Data: &datapb.DataItem{Id: 42, InfoType: "text", Info: []byte(testCiphertext)},
+	})
+	if st = status.Convert(err); st.Code() != codes.NotFound || st.Message() != "данные не найдены" {
+		t.Errorf("Expected NotFound, got: %v", err)
+	}
+
+	_, err = server.SearchData(ctx, &datapb.SearchDataRequest{Tokens: []string{"t"}})
+	st = status.Convert(err)
+	if st.Code() != codes.InvalidArgument || st.Message() != "некорректные параметры поиска: больше 64 токенов поиска" {
+		t.Errorf("Expected InvalidArgument, got: %v", err)
+	}
+	if len(st.Details()) != 1 {
+		t.Errorf("Expected only ErrorInfo in details, got: %v", st.Details())
+	}
+}
+
+func TestTypedPayload(t *testing.T) {
+	var saved *entity.UserData
+	mockService := &mockDataService{
+		AddDataFunc: func(ctx context.Context, userID int, data *entity.UserData) (int, error) {
+			saved = data
+			return 5, nil
+		},
+		GetDataByIDFunc: func(ctx context.Context, userID, dataID int) (*entity.UserData, error) {
+			return &entity.UserData{ID: dataID, InfoType: "login_password", Info: `{"Login":"user","URL":"https://a.ru"}`}, nil
+		},
+	}
+	server := NewDataServer(mockService, &mockLogger{})
+	ctx := contextWithUserID(1)
+	card := func(number string) *datapb.AddDataRequest {
+		return &datapb.AddDataRequest{Data: &datapb.DataItem{
+			InfoType: "bank_card",
+			Payload:  &datapb.DataItem_BankCard{BankCard: &datapb.BankCard{CardNumber: number, ExpiryDate: "01/27"}},
+		}}
+	}
+
+	_, err := server.AddData(ctx, card("4111111111111112"))
+	st := status.Convert(err)
+	invalid := st.Code() == codes.InvalidArgument &&
+		strings.HasPrefix(st.Message(), "некорректное содержимое записи: номер карты")
+	if !invalid {
+		t.Errorf("Expected InvalidArgument, got: %v", err)
+	}
+	if saved != nil {
+		t.Errorf("Invalid payload must not be saved")
+	}
+
+	if _, err = server.AddData(ctx, card("4111111111111111")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(saved.Info, `"CardNumber":"4111111111111111"`) &&
+		!strings.Contains(saved.Info, `"CardNumber": "4111111111111111"`) {
+		t.Errorf("Expected payload as JSON in Info, got: %s", saved.Info)
+	}
+
+	resp, err := server.GetData(ctx, &datapb.GetDataRequest{Id: 3})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	login := resp.Data.GetLoginPassword()
+	if login == nil || login.Login != "user" || login.Url != "https://a.ru" || len(resp.Data.Info) != 0 {
+		t.Errorf("Expected login_password payload, got: %v", resp.Data)
+	}
+}
+
+func TestUncheckedPayload(t *testing.T) {
+	var saved *entity.UserData
+	mockService := &mockDataService{
+		AddDataFunc: func(ctx context.Context, userID int, data *entity.UserData) (int, error) {
+			saved = data
+			return 5, nil
+		},
+	}
+	server := NewDataServer(mockService, &mockLogger{})
+	ctx := contextWithUserID(1)
+
+	tests := []struct {
+		name string
+		item *datapb.DataItem
+	}{
+		{name: "Открытый JSON без payload", item: &datapb.DataItem{InfoType: "text", Info: []byte(`{"Text":"пароль"}`)}},
+		{name: "Пустой Info", item: &datapb.DataItem{InfoType: "text"}},
+		{
+			name: "Префикс без base64",
+			item: &datapb.DataItem{InfoType: "text", Info: []byte(payload.CiphertextPrefix + "не base64")},
+		},
+		{
+			name: "Конверт короче nonce и тега",
+			item: &datapb.DataItem{InfoType: "text", Info: []byte(payload.CiphertextPrefix + "AAAA")},
+		},
+		{
+			name: "Пустой текст",
+			item: &datapb.DataItem{InfoType: "text", Payload: &datapb.DataItem_Text{Text: &datapb.Text{}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.AddData(ctx, &datapb.AddDataRequest{Data: tt.item})
+			if st := status.Convert(err); st.Code() != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument, got: %v", err)
+			}
+			if saved != nil {
+				t.Errorf("Unchecked payload must not be saved, got: %v", saved)
+			}
+		})
+	}
+}
+
 func compareErrors(got, want error) bool {
 	if got == nil && want == nil {
 		return true
@@ -443,9 +737,12 @@ func TestListData(t *testing.T) {
 				InfoType: "text",
 			},
 			setupMocks: func() {
-				mockService.ListDataFunc = func(ctx context.Context, userID int, infoType string) ([]*entity.UserData, error) {
-					if userID != 1 || infoType != "text" {
-						t.Errorf("Unexpected userID or infoType: %d, %s", userID, infoType)
+				mockService.ListDataFunc = func(_ context.Context, userID int, page *entity.DataPage) ([]*entity.UserData, error) {
+					if userID != 1 || page.InfoType != "text" {
+						t.Errorf("Unexpected userID or infoType: %d, %s", userID, page.InfoType)
+					}
+					if page.After != nil || page.Limit != defaultListPageSize+1 {
+						t.Errorf("Unexpected page: %+v", page)
 					}
 					return []*entity.UserData{
 						{
@@ -496,13 +793,29 @@ func TestListData(t *testing.T) {
 				InfoType: "text",
 			},
 			setupMocks: func() {
-				mockService.ListDataFunc = func(ctx context.Context, userID int, infoType string) ([]*entity.UserData, error) {
+				mockService.ListDataFunc = func(_ context.Context, userID int, page *entity.DataPage) ([]*entity.UserData, error) {
 					return nil, errors.New("service error")
 				}
 			},
 			expectedResp:  nil,
 			expectedError: statusError(codes.Internal, "ошибка при получении данных"),
 		},
+		{
+			name:          "NegativePageSize",
+			ctx:           contextWithUserID(1),
+			request:       &datapb.ListDataRequest{PageSize: -1},
+			setupMocks:    func() {},
+			expectedResp:  nil,
+			expectedError: statusError(codes.InvalidArgument, "размер страницы не может быть отрицательным"),
+		},
+		{
+			name:          "InvalidPageToken",
+			ctx:           contextWithUserID(1),
+			request:       &datapb.ListDataRequest{PageToken: "!"},
+			setupMocks:    func() {},
+			expectedResp:  nil,
+			expectedError: statusError(codes.InvalidArgument, helper.ErrInvalidPageToken.Error()),
+		},
 	}
 
 	for _, tt := range tests {
@@ -519,6 +832,48 @@ func TestListData(t *testing.T) {
 	}
 }
 
+func TestListData_Pages(t *testing.T) {
+	cursor := &entity.DataCursor{Created: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), ID: 7}
+	mockService := &mockDataService{
+		ListDataFunc: func(_ context.Context, _ int, page *entity.DataPage) ([]*entity.UserData, error) {
+			if page.Limit != 3 {
+				t.Errorf("Expected limit 3, got: %d", page.Limit)
+			}
+			if page.After == nil || !page.After.Created.Equal(cursor.Created) || page.After.ID != cursor.ID {
+				t.Errorf("Unexpected cursor: %+v", page.After)
+			}
+			if page.FolderID == nil || *page.FolderID != 4 || page.TagID != 6 {
+				t.Errorf("Unexpected folder or tag filter: %v, %d", page.FolderID, page.TagID)
+			}
+			return []*entity.UserData{
+				{ID: 8, Created: cursor.Created, FolderID: 4, TagIDs: []int64{6}},
+				{ID: 9, Created: cursor.Created.Add(time.Second)},
+				{ID: 10, Created: cursor.Created.Add(time.Minute)},
+			}, nil
+		},
+	}
+	server := NewDataServer(mockService, &mockLogger{})
+
+	folderID := int32(4)
+	resp, err := server.ListData(contextWithUserID(1), &datapb.ListDataRequest{
+		PageSize:  2,
+		PageToken: encodePageToken(cursor),
+		FolderId:  &folderID,
+		TagId:     6,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(resp.DataItems) != 2 || resp.DataItems[1].Id != 9 || resp.DataItems[0].FolderId != 4 ||
+		len(resp.DataItems[0].TagIds) != 1 || resp.DataItems[0].TagIds[0] != 6 {
+		t.Errorf("Unexpected items: %v", resp.DataItems)
+	}
+	next, err := decodePageToken(resp.NextPageToken)
+	if err != nil || next.ID != 9 || !next.Created.Equal(cursor.Created.Add(time.Second)) {
+		t.Errorf("Unexpected next page token: %+v, %v", next, err)
+	}
+}
+
 func compareListDataResponse(got, want *datapb.ListDataResponse) bool {
 	if got == nil && want == nil {
 		return true
@@ -526,7 +881,7 @@ func compareListDataResponse(got, want *datapb.ListDataResponse) bool {
 	if got == nil || want == nil {
 		return false
 	}
-	if len(got.DataItems) != len(want.DataItems) {
+	if got.NextPageToken != want.NextPageToken || len(got.DataItems) != len(want.DataItems) {
 		return false
 	}
 	for i := range got.DataItems {
@@ -539,3 +894,337 @@ func compareListDataResponse(got, want *datapb.ListDataResponse) bool {
 	}
 	return true
 }
+
+func TestSearchData(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var got *entity.DataSearch
+	mockService := &mockDataService{
+		SearchDataFunc: func(_ context.Context, userID int, search *entity.DataSearch) ([]*entity.UserData, error) {
+			if userID != 1 {
+				t.Errorf("Unexpected userID: %d", userID)
+			}
+			got = search
+			items := []*entity.UserData{
+				{ID: 3, InfoType: "text", Meta: "почта", Created: created},
+				{ID: 2, InfoType: "text", Meta: "почта 2", Created: created.Add(-time.Hour)},
+				{ID: 1, InfoType: "text", Meta: "почта 3", Created: created.Add(-2 * time.Hour)},
+			}
+			return items[:min(search.Limit, len(items))], nil
+		},
+	}
+	server := NewDataServer(mockService, &mockLogger{})
+
+	resp, err := server.SearchData(contextWithUserID(1), &datapb.SearchDataRequest{
+		Tokens:      []string{"w:почта"},
+		InfoType:    "text",
+		CreatedFrom: timestamppb.New(created.Add(-24 * time.Hour)),
+		PageSize:    2,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.Limit != 3 || got.Ascending || got.InfoType != "text" || got.After != nil ||
+		!got.CreatedFrom.Equal(created.Add(-24*time.Hour)) || !got.CreatedTo.IsZero() {
+		t.Errorf("Unexpected search: %+v", got)
+	}
+	if len(resp.DataItems) != 2 || resp.DataItems[1].Id != 2 || resp.DataItems[0].Info != nil {
+		t.Errorf("Unexpected items: %v", resp.DataItems)
+	}
+	if resp.NextPageToken == "" {
+		t.Fatal("Expected next page token")
+	}
+
+	resp, err = server.SearchData(contextWithUserID(1), &datapb.SearchDataRequest{
+		PageSize:  2,
+		PageToken: resp.NextPageToken,
+		Sort:      datapb.SearchDataRequest_CREATED_ASC,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.After == nil || got.After.ID != 2 || !got.After.Created.Equal(created.Add(-time.Hour)) || !got.Ascending {
+		t.Errorf("Unexpected cursor: %+v", got.After)
+	}
+	if len(resp.DataItems) != 2 || resp.NextPageToken == "" {
+		t.Errorf("Unexpected response: %v", resp)
+	}
+
+	invalid := []*datapb.SearchDataRequest{
+		{PageToken: "не токен"},
+		{PageSize: -1},
+		{CreatedFrom: timestamppb.New(created), CreatedTo: timestamppb.New(created)},
+	}
+	for _, req := range invalid {
+		if _, err = server.SearchData(contextWithUserID(1), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument for %v, got: %v", req, err)
+		}
+	}
+
+	mockService.SearchDataFunc = func(context.Context, int, *entity.DataSearch) ([]*entity.UserData, error) {
+		return nil, helper.ErrSearchDisabled
+	}
+	_, err = server.SearchData(contextWithUserID(1), &datapb.SearchDataRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got: %v", err)
+	}
+}
+
+func TestSyncData(t *testing.T) {
+	mockService := &mockDataService{
+		SyncDataFunc: func(_ context.Context, userID int, since int64, limit int) ([]*entity.DataChange, error) {
+			if userID != 1 || since != 10 {
+				t.Errorf("Unexpected userID or revision: %d, %d", userID, since)
+			}
+			changes := []*entity.DataChange{
+				{ID: 3, Revision: 11, Data: &entity.UserData{ID: 3, InfoType: "text", Info: "i", Revision: 11}},
+				{ID: 2, Revision: 12, Deleted: true},
+			}
+			return changes[:min(limit, len(changes))], nil
+		},
+	}
+	server := NewDataServer(mockService, &mockLogger{})
+
+	resp, err := server.SyncData(contextWithUserID(1), &datapb.SyncDataRequest{SinceRevision: 10})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := &datapb.SyncDataResponse{
+		Changes: []*datapb.DataChange{
+			{Id: 3, Revision: 11, Data: &datapb.DataItem{
+				Id: 3, InfoType: "text", Info: []byte("i"), Revision: 11,
+				Created: timestamppb.New(time.Time{}), Updated: timestamppb.New(time.Time{}),
+			}},
+			{Id: 2, Revision: 12, Deleted: true},
+		},
+		Revision: 12,
+	}
+	if !proto.Equal(resp, expected) {
+		t.Errorf("Expected response: %v, got: %v", expected, resp)
+	}
+
+	resp, err = server.SyncData(contextWithUserID(1), &datapb.SyncDataRequest{SinceRevision: 10, Limit: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !resp.HasMore || resp.Revision != 11 {
+		t.Errorf("Expected has_more and revision 11, got: %v", resp)
+	}
+
+	_, err = server.SyncData(contextWithUserID(1), &datapb.SyncDataRequest{SinceRevision: -1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got: %v", err)
+	}
+}
+
+func TestWatchData(t *testing.T) {
+	events := make(chan *entity.DataEvent, 3)
+	canceled := false
+	mockService := &mockDataService{
+		WatchDataFunc: func(userID int) (<-chan *entity.DataEvent, func()) {
+			if userID != 1 {
+				t.Errorf("Unexpected userID: %d", userID)
+			}
+			return events, func() { canceled = true }
+		},
+	}
+	server := NewDataServer(mockService, &mockLogger{})
+
+	events <- &entity.DataEvent{Kind: entity.DataAdded, UserID: 1, ID: 3, InfoType: "text"}
+	events <- &entity.DataEvent{Kind: entity.DataDeleted, UserID: 1, ID: 2}
+	close(events)
+
+	stream := &fakeEventStream{ctx: contextWithUserID(1)}
+	err := server.WatchData(&datapb.WatchDataRequest{}, stream)
+
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("Expected Aborted when subscription is dropped, got: %v", err)
+	}
+	if !canceled {
+		t.Errorf("Expected subscription to be canceled")
+	}
+	expected := []*datapb.DataEvent{
+		{Kind: datapb.DataEvent_ADDED, Id: 3, InfoType: "text"},
+		{Kind: datapb.DataEvent_DELETED, Id: 2},
+	}
+	if len(stream.sent) != len(expected) {
+		t.Fatalf("Expected %d events, got: %v", len(expected), stream.sent)
+	}
+	for i := range expected {
+		if !proto.Equal(expected[i], stream.sent[i]) {
+			t.Errorf("Expected event %v, got: %v", expected[i], stream.sent[i])
+		}
+	}
+
+	ctx, cancel := context.WithCancel(contextWithUserID(1))
+	cancel()
+	events = make(chan *entity.DataEvent)
+	err = server.WatchData(&datapb.WatchDataRequest{}, &fakeEventStream{ctx: ctx})
+	if err != nil {
+		t.Errorf("Expected nil error after client disconnect, got: %v", err)
+	}
+
+	err = server.WatchData(&datapb.WatchDataRequest{}, &fakeEventStream{ctx: context.Background()})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal without userID, got: %v", err)
+	}
+}
+
+func TestVersions(t *testing.T) {
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockService := &mockDataService{
+		ListVersionsFunc: func(_ context.Context, userID, dataID int) ([]*entity.UserData, error) {
+			if userID != 1 || dataID != 3 {
+				t.Errorf("Unexpected userID or dataID: %d, %d", userID, dataID)
+			}
+			return []*entity.UserData{
+				{ID: 3, InfoType: "text", Info: "i", Meta: "m2", Updated: updated, Revision: 7},
+				{ID: 3, InfoType: "text", Meta: "m1", Updated: updated.Add(-time.Hour), Revision: 4},
+			}, nil
+		},
+		GetVersionFunc: func(_ context.Context, _, _ int, revision int64) (*entity.UserData, error) {
+			if revision != 4 {
+				return nil, helper.ErrVersionNotFound
+			}
+			return &entity.UserData{ID: 3, InfoType: "text", Info: "старое", Meta: "m1", Revision: 4}, nil
+		},
+		RestoreVersionFunc: func(_ context.Context, _, _ int, revision int64) (int64, error) {
+			switch revision {
+			case 4:
+				return 9, nil
+			case 2:
+				return 0, helper.ErrVersionUnavailable
+			}
+			return 0, helper.ErrVersionNotFound
+		},
+	}
+	server := NewDataServer(mockService, &mockLogger{})
+	ctx := contextWithUserID(1)
+
+	list, err := server.ListVersions(ctx, &datapb.ListVersionsRequest{DataId: 3})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(list.Versions) != 2 || list.Versions[0].Revision != 7 || list.Versions[0].Info != nil {
+		t.Errorf("Unexpected versions: %v", list.Versions)
+	}
+
+	version, err := server.GetVersion(ctx, &datapb.GetVersionRequest{DataId: 3, Revision: 4})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(version.Data.Info) != "старое" || version.Data.Revision != 4 {
+		t.Errorf("Unexpected version: %v", version.Data)
+	}
+	_, err = server.GetVersion(ctx, &datapb.GetVersionRequest{DataId: 3, Revision: 5})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got: %v", err)
+	}
+
+	restored, err := server.RestoreVersion(ctx, &datapb.RestoreVersionRequest{DataId: 3, Revision: 4})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if restored.Revision != 9 {
+		t.Errorf("Expected revision 9, got: %d", restored.Revision)
+	}
+
+	codesByRevision := map[int64]codes.Code{2: codes.FailedPrecondition, 5: codes.NotFound}
+	for revision, code := range codesByRevision {
+		_, err = server.RestoreVersion(ctx, &datapb.RestoreVersionRequest{DataId: 3, Revision: revision})
+		if status.Code(err) != code {
+			t.Errorf("Expected %v for revision %d, got: %v", code, revision, err)
+		}
+	}
+}
+
+func TestTrash(t *testing.T) {
+	deleted := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	trash := map[int]bool{3: true}
+	mockService := &mockDataService{
+		ListTrashFunc: func(_ context.Context, userID int) ([]*entity.UserData, error) {
+			if userID != 1 {
+				t.Errorf("Unexpected userID: %d", userID)
+			}
+			return []*entity.UserData{{ID: 3, InfoType: "text", Info: "i", Meta: "m", Deleted: deleted}}, nil
+		},
+		RestoreDataFunc: func(_ context.Context, _, dataID int) error {
+			if !trash[dataID] {
+				return helper.ErrNotInTrash
+			}
+			return nil
+		},
+		PurgeDataFunc: func(_ context.Context, _, dataID int) error {
+			if !trash[dataID] {
+				return helper.ErrNotInTrash
+			}
+			return nil
+		},
+		EmptyTrashFunc: func(context.Context, int) ([]int, error) {
+			return []int{3, 5, 6, 7}, nil
+		},
+	}
+	server := NewDataServer(mockService, &mockLogger{})
+	ctx := contextWithUserID(1)
+
+	list, err := server.ListTrash(ctx, &datapb.ListTrashRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(list.DataItems) != 1 || list.DataItems[0].Info != nil || !list.DataItems[0].Deleted.AsTime().Equal(deleted) {
+		t.Errorf("Unexpected trash: %v", list.DataItems)
+	}
+
+	if _, err = server.RestoreData(ctx, &datapb.RestoreDataRequest{Id: 3}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err = server.RestoreData(ctx, &datapb.RestoreDataRequest{Id: 4}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got: %v", err)
+	}
+	if _, err = server.PurgeData(ctx, &datapb.PurgeDataRequest{Id: 3}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err = server.PurgeData(ctx, &datapb.PurgeDataRequest{Id: 4}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got: %v", err)
+	}
+
+	emptied, err := server.EmptyTrash(ctx, &datapb.EmptyTrashRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if emptied.Purged != 4 {
+		t.Errorf("Expected 4 purged, got: %d", emptied.Purged)
+	}
+}
+
+func TestTOTPPayload(t *testing.T) {
+	var saved *entity.UserData
+	mockService := &mockDataService{
+		AddDataFunc: func(ctx context.Context, userID int, data *entity.UserData) (int, error) {
+			saved = data
+			return 6, nil
+		},
+	}
+	server := NewDataServer(mockService, &mockLogger{})
+	ctx := contextWithUserID(1)
+	secret := func(data *datapb.Totp) *datapb.AddDataRequest {
+		return &datapb.AddDataRequest{Data: &datapb.DataItem{
+			InfoType: "totp",
+			Payload:  &datapb.DataItem_Totp{Totp: data},
+		}}
+	}
+
+	_, err := server.AddData(ctx, secret(&datapb.Totp{Secret: "JBSWY3DPEHPK3PXP", Digits: 4}))
+	st := status.Convert(err)
+	invalid := st.Code() == codes.InvalidArgument &&
+		strings.HasPrefix(st.Message(), "некорректное содержимое записи: число цифр")
+	if !invalid {
+		t.Errorf("Expected InvalidArgument, got: %v", err)
+	}
+
+	if _, err = server.AddData(ctx, secret(&datapb.Totp{Secret: "JBSWY3DPEHPK3PXP"})); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saved == nil || saved.InfoType != "totp" || !strings.Contains(saved.Info, "JBSWY3DPEHPK3PXP") {
+		t.Errorf("Expected TOTP saved without record types, got: %+v", saved)
+	}
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain - домен причин ошибок в ErrorInfo.
+const errorDomain = "goph-keeper"
+
+// dataResourceType - тип записи в ResourceInfo.
+const dataResourceType = "user_data"
+
+// errorKinds - коды gRPC и причины ErrorInfo для видов ошибок из helper.
+var errorKinds = []struct {
+	kind   error
+	code   codes.Code
+	reason string
+}{
+	{helper.ErrNotFound, codes.NotFound, "NOT_FOUND"},
+	{helper.ErrPermissionDenied, codes.PermissionDenied, "PERMISSION_DENIED"},
+	{helper.ErrConflict, codes.Aborted, "CONFLICT"},
+	{helper.ErrInvalidArgument, codes.InvalidArgument, "INVALID_ARGUMENT"},
+	{helper.ErrFailedPrecondition, codes.FailedPrecondition, "FAILED_PRECONDITION"},
+}
+
+// kindError переводит ошибку одного из видов helper в статус gRPC с ErrorInfo в деталях и, если resource
+// не nil, с ResourceInfo. Для ошибок других видов возвращает nil.
+func kindError(err error, resource *errdetails.ResourceInfo) error {
+	for _, kind := range errorKinds {
+		if !errors.Is(err, kind.kind) {
+			continue
+		}
+
+		message := kindMessage(err)
+		st := status.New(kind.code, message)
+		info := &errdetails.ErrorInfo{Reason: kind.reason, Domain: errorDomain}
+
+		var detailed *status.Status
+		var detailsErr error
+		if resource != nil {
+			resource.Description = message
+			detailed, detailsErr = st.WithDetails(info, resource)
+		} else {
+			detailed, detailsErr = st.WithDetails(info)
+		}
+		if detailsErr != nil {
+			return st.Err()
+		}
+
+		return detailed.Err()
+	}
+
+	return nil
+}
+
+// kindMessage - текст ошибки для клиента. Сервисы дополняют ошибки контекстом для логов,
+// поэтому текст начинается с сообщения самой ошибки из helper; уточнения после него сохраняются.
+func kindMessage(err error) string {
+	message := err.Error()
+
+	var known error = err
+	var kindErr *helper.KindError
+	if errors.As(err, &kindErr) {
+		known = kindErr
+	} else {
+		for _, kind := range errorKinds {
+			if errors.Is(err, kind.kind) {
+				known = kind.kind
+				break
+			}
+		}
+	}
+
+	if i := strings.Index(message, known.Error()); i > 0 {
+		message = message[i:]
+	}
+
+	return message
+}
+
+// dataResource - ResourceInfo записи dataID.
+func dataResource(dataID int) *errdetails.ResourceInfo {
+	return &errdetails.ResourceInfo{ResourceType: dataResourceType, ResourceName: strconv.Itoa(dataID)}
+}
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type organizerService interface {
+	CreateFolder(ctx context.Context, userID int, folder *entity.Folder) (int, error)
+	UpdateFolder(ctx context.Context, userID int, folder *entity.Folder) error
+	DeleteFolder(ctx context.Context, userID, folderID int) error
+	ListFolders(ctx context.Context, userID int) ([]*entity.Folder, error)
+	CreateTag(ctx context.Context, userID int, tag *entity.Tag) (int, error)
+	DeleteTag(ctx context.Context, userID, tagID int) error
+	ListTags(ctx context.Context, userID int) ([]*entity.Tag, error)
+	TagData(ctx context.Context, userID, dataID int, tagIDs []int) error
+	UntagData(ctx context.Context, userID, dataID int, tagIDs []int) error
+	MoveData(ctx context.Context, userID, dataID, folderID int) error
+}
+
+type OrganizerServer struct {
+	datapb.UnimplementedOrganizerServiceServer
+	organizerService organizerService
+	logger           logger.CustomLogger
+}
+
+func NewOrganizerServer(organizerService organizerService, logger logger.CustomLogger) *OrganizerServer {
+	return &OrganizerServer{
+		organizerService: organizerService,
+		logger:           logger,
+	}
+}
+
+func (h *OrganizerServer) CreateFolder(
+	ctx context.Context,
+	req *datapb.CreateFolderRequest,
+) (*datapb.CreateFolderResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	id, err := h.organizerService.CreateFolder(ctx, userID, &entity.Folder{ParentID: int(req.ParentId), Name: req.Name})
+	if err != nil {
+		return nil, h.organizerError("ошибка при создании папки", err)
+	}
+
+	return &datapb.CreateFolderResponse{Id: int32(id)}, nil
+}
+
+func (h *OrganizerServer) UpdateFolder(
+	ctx context.Context,
+	req *datapb.UpdateFolderRequest,
+) (*datapb.UpdateFolderResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+	if req.Folder == nil {
+		return nil, status.Error(codes.InvalidArgument, "папка не указана")
+	}
+
+	err = h.organizerService.UpdateFolder(ctx, userID, &entity.Folder{
+		ID:       int(req.Folder.Id),
+		ParentID: int(req.Folder.ParentId),
+		Name:     req.Folder.Name,
+	})
+	if err != nil {
+		return nil, h.organizerError("ошибка при обновлении папки", err)
+	}
+
+	return &datapb.UpdateFolderResponse{}, nil
+}
+
+func (h *OrganizerServer) DeleteFolder(
+	ctx context.Context,
+	req *datapb.DeleteFolderRequest,
+) (*datapb.DeleteFolderResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	if err = h.organizerService.DeleteFolder(ctx, userID, int(req.Id)); err != nil {
+		return nil, h.organizerError("ошибка при удалении папки", err)
+	}
+
+	return &datapb.DeleteFolderResponse{}, nil
+}
+
+func (h *OrganizerServer) ListFolders(
+	ctx context.Context,
+	_ *datapb.ListFoldersRequest,
+) (*datapb.ListFoldersResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	folders, err := h.organizerService.ListFolders(ctx, userID)
+	if err != nil {
+		return nil, h.organizerError("ошибка при получении папок", err)
+	}
+
+	resp := &datapb.ListFoldersResponse{Folders: make([]*datapb.Folder, len(folders))}
+	for i, folder := range folders {
+		resp.Folders[i] = &datapb.Folder{Id: int32(folder.ID), ParentId: int32(folder.ParentID), Name: folder.Name}
+	}
+
+	return resp, nil
+}
+
+func (h *OrganizerServer) CreateTag(
+	ctx context.Context,
+	req *datapb.CreateTagRequest,
+) (*datapb.CreateTagResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	id, err := h.organizerService.CreateTag(ctx, userID, &entity.Tag{Name: req.Name})
+	if err != nil {
+		return nil, h.organizerError("ошибка при создании тега", err)
+	}
+
+	return &datapb.CreateTagResponse{Id: int32(id)}, nil
+}
+
+func (h *OrganizerServer) DeleteTag(
+	ctx context.Context,
+	req *datapb.DeleteTagRequest,
+) (*datapb.DeleteTagResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	if err = h.organizerService.DeleteTag(ctx, userID, int(req.Id)); err != nil {
+		return nil, h.organizerError("ошибка при удалении тега", err)
+	}
+
+	return &datapb.DeleteTagResponse{}, nil
+}
+
+func (h *OrganizerServer) ListTags(ctx context.Context, _ *datapb.ListTagsRequest) (*datapb.ListTagsResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	tags, err := h.organizerService.ListTags(ctx, userID)
+	if err != nil {
+		return nil, h.organizerError("ошибка при получении тегов", err)
+	}
+
+	resp := &datapb.ListTagsResponse{Tags: make([]*datapb.Tag, len(tags))}
+	for i, tag := range tags {
+		resp.Tags[i] = &datapb.Tag{Id: int32(tag.ID), Name: tag.Name}
+	}
+
+	return resp, nil
+}
+
+func (h *OrganizerServer) TagData(ctx context.Context, req *datapb.TagDataRequest) (*datapb.TagDataResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+	if len(req.TagIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "теги не указаны")
+	}
+
+	if err = h.organizerService.TagData(ctx, userID, int(req.DataId), toInts(req.TagIds)); err != nil {
+		return nil, h.organizerError("ошибка при добавлении тегов", err)
+	}
+
+	return &datapb.TagDataResponse{}, nil
+}
+
+func (h *OrganizerServer) UntagData(
+	ctx context.Context,
+	req *datapb.UntagDataRequest,
+) (*datapb.UntagDataResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+	if len(req.TagIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "теги не указаны")
+	}
+
+	if err = h.organizerService.UntagData(ctx, userID, int(req.DataId), toInts(req.TagIds)); err != nil {
+		return nil, h.organizerError("ошибка при снятии тегов", err)
+	}
+
+	return &datapb.UntagDataResponse{}, nil
+}
+
+func (h *OrganizerServer) MoveData(ctx context.Context, req *datapb.MoveDataRequest) (*datapb.MoveDataResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	if err = h.organizerService.MoveData(ctx, userID, int(req.DataId), int(req.FolderId)); err != nil {
+		return nil, h.organizerError("ошибка при переносе записи", err)
+	}
+
+	return &datapb.MoveDataResponse{}, nil
+}
+
+// organizerError переводит ошибку сервиса в статус gRPC по её виду из helper. Неизвестные ошибки логируются
+// и возвращаются клиенту как Internal с сообщением message.
+func (h *OrganizerServer) organizerError(message string, err error) error {
+	if st := kindError(err, nil); st != nil {
+		return st
+	}
+
+	h.logger.LogInfo(message, err)
+	return status.Error(codes.Internal, message)
+}
+
+func toInts(ids []int32) []int {
+	result := make([]int, len(ids))
+	for i, id := range ids {
+		result[i] = int(id)
+	}
+
+	return result
+}
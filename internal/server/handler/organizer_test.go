@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockOrganizerService struct {
+	CreateFolderFunc func(ctx context.Context, userID int, folder *entity.Folder) (int, error)
+	UpdateFolderFunc func(ctx context.Context, userID int, folder *entity.Folder) error
+	DeleteFolderFunc func(ctx context.Context, userID, folderID int) error
+	ListFoldersFunc  func(ctx context.Context, userID int) ([]*entity.Folder, error)
+	CreateTagFunc    func(ctx context.Context, userID int, tag *entity.Tag) (int, error)
+	DeleteTagFunc    func(ctx context.Context, userID, tagID int) error
+	ListTagsFunc     func(ctx context.Context, userID int) ([]*entity.Tag, error)
+	TagDataFunc      func(ctx context.Context, userID, dataID int, tagIDs []int) error
+	UntagDataFunc    func(ctx context.Context, userID, dataID int, tagIDs []int) error
+	MoveDataFunc     func(ctx context.Context, userID, dataID, folderID int) error
+}
+
+func (m *mockOrganizerService) CreateFolder(ctx context.Context, userID int, folder *entity.Folder) (int, error) {
+	return m.CreateFolderFunc(ctx, userID, folder)
+}
+
+func (m *mockOrganizerService) UpdateFolder(ctx context.Context, userID int, folder *entity.Folder) error {
+	return m.UpdateFolderFunc(ctx, userID, folder)
+}
+
+func (m *mockOrganizerService) DeleteFolder(ctx context.Context, userID, folderID int) error {
+	return m.DeleteFolderFunc(ctx, userID, folderID)
+}
+
+func (m *mockOrganizerService) ListFolders(ctx context.Context, userID int) ([]*entity.Folder, error) {
+	return m.ListFoldersFunc(ctx, userID)
+}
+
+func (m *mockOrganizerService) CreateTag(ctx context.Context, userID int, tag *entity.Tag) (int, error) {
+	return m.CreateTagFunc(ctx, userID, tag)
+}
+
+func (m *mockOrganizerService) DeleteTag(ctx context.Context, userID, tagID int) error {
+	return m.DeleteTagFunc(ctx, userID, tagID)
+}
+
+func (m *mockOrganizerService) ListTags(ctx context.Context, userID int) ([]*entity.Tag, error) {
+	return m.ListTagsFunc(ctx, userID)
+}
+
+func (m *mockOrganizerService) TagData(ctx context.Context, userID, dataID int, tagIDs []int) error {
+	return m.TagDataFunc(ctx, userID, dataID, tagIDs)
+}
+
+func (m *mockOrganizerService) UntagData(ctx context.Context, userID, dataID int, tagIDs []int) error {
+	return m.UntagDataFunc(ctx, userID, dataID, tagIDs)
+}
+
+func (m *mockOrganizerService) MoveData(ctx context.Context, userID, dataID, folderID int) error {
+	return m.MoveDataFunc(ctx, userID, dataID, folderID)
+}
+
+func TestOrganizer_Folders(t *testing.T) {
+	mockService := &mockOrganizerService{
+		CreateFolderFunc: func(_ context.Context, userID int, folder *entity.Folder) (int, error) {
+			if userID != 1 || folder.ParentID != 2 || folder.Name != "name" {
+				t.Errorf("Unexpected folder: %d, %+v", userID, folder)
+			}
+			return 3, nil
+		},
+		ListFoldersFunc: func(context.Context, int) ([]*entity.Folder, error) {
+			return []*entity.Folder{{ID: 2, Name: "a"}, {ID: 3, ParentID: 2, Name: "b"}}, nil
+		},
+		DeleteFolderFunc: func(context.Context, int, int) error {
+			return helper.ErrFolderNotEmpty
+		},
+	}
+	server := NewOrganizerServer(mockService, &mockLogger{})
+	ctx := contextWithUserID(1)
+
+	created, err := server.CreateFolder(ctx, &datapb.CreateFolderRequest{ParentId: 2, Name: "name"})
+	if err != nil || created.Id != 3 {
+		t.Errorf("Unexpected response: %v, %v", created, err)
+	}
+
+	folders, err := server.ListFolders(ctx, &datapb.ListFoldersRequest{})
+	if err != nil || len(folders.Folders) != 2 || folders.Folders[1].ParentId != 2 || folders.Folders[1].Name != "b" {
+		t.Errorf("Unexpected response: %v, %v", folders, err)
+	}
+
+	_, err = server.DeleteFolder(ctx, &datapb.DeleteFolderRequest{Id: 2})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got: %v", err)
+	}
+
+	_, err = server.UpdateFolder(ctx, &datapb.UpdateFolderRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got: %v", err)
+	}
+
+	_, err = server.CreateFolder(context.Background(), &datapb.CreateFolderRequest{Name: "name"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Expected Internal, got: %v", err)
+	}
+}
+
+func TestOrganizer_TagsAndMove(t *testing.T) {
+	var tagged []int
+	mockService := &mockOrganizerService{
+		TagDataFunc: func(_ context.Context, _ int, dataID int, tagIDs []int) error {
+			if dataID != 5 {
+				t.Errorf("Unexpected dataID: %d", dataID)
+			}
+			tagged = tagIDs
+			return nil
+		},
+		UntagDataFunc: func(context.Context, int, int, []int) error {
+			return helper.ErrTagNotFound
+		},
+		MoveDataFunc: func(context.Context, int, int, int) error {
+			return errors.New("db error")
+		},
+	}
+	server := NewOrganizerServer(mockService, &mockLogger{})
+	ctx := contextWithUserID(1)
+
+	if _, err := server.TagData(ctx, &datapb.TagDataRequest{DataId: 5, TagIds: []int32{3, 4}}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(tagged) != 2 || tagged[0] != 3 || tagged[1] != 4 {
+		t.Errorf("Unexpected tags: %v", tagged)
+	}
+
+	_, err := server.TagData(ctx, &datapb.TagDataRequest{DataId: 5})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got: %v", err)
+	}
+
+	_, err = server.UntagData(ctx, &datapb.UntagDataRequest{DataId: 5, TagIds: []int32{9}})
+	if !compareErrors(err, statusError(codes.NotFound, helper.ErrTagNotFound.Error())) {
+		t.Errorf("Expected NotFound, got: %v", err)
+	}
+
+	_, err = server.MoveData(ctx, &datapb.MoveDataRequest{DataId: 5, FolderId: 2})
+	if !compareErrors(err, statusError(codes.Internal, "ошибка при переносе записи")) {
+		t.Errorf("Expected Internal, got: %v", err)
+	}
+}
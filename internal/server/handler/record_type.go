@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/payload"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type recordTypeService interface {
+	CreateRecordType(ctx context.Context, userID int, recordType *entity.RecordType) (int, error)
+	ListRecordTypes(ctx context.Context, userID int) ([]*entity.RecordType, error)
+	DeleteRecordType(ctx context.Context, userID, recordTypeID int) error
+}
+
+// recordTypeLookup находит пользовательский тип записей по имени для проверки записей.
+type recordTypeLookup interface {
+	GetRecordType(ctx context.Context, userID int, name string) (*entity.RecordType, error)
+}
+
+type RecordTypeServer struct {
+	datapb.UnimplementedRecordTypeServiceServer
+	recordTypeService recordTypeService
+	logger            logger.CustomLogger
+}
+
+func NewRecordTypeServer(recordTypeService recordTypeService, logger logger.CustomLogger) *RecordTypeServer {
+	return &RecordTypeServer{
+		recordTypeService: recordTypeService,
+		logger:            logger,
+	}
+}
+
+func (h *RecordTypeServer) CreateRecordType(
+	ctx context.Context,
+	req *datapb.CreateRecordTypeRequest,
+) (*datapb.CreateRecordTypeResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+	if req.RecordType == nil {
+		return nil, status.Error(codes.InvalidArgument, "тип записей не указан")
+	}
+
+	if err = payload.ValidateSchema(req.RecordType); err != nil {
+		return nil, h.recordTypeError("ошибка при создании типа записей",
+			fmt.Errorf("%w: %w", helper.ErrInvalidRecordType, err))
+	}
+
+	id, err := h.recordTypeService.CreateRecordType(ctx, userID, fromRecordType(req.RecordType))
+	if err != nil {
+		return nil, h.recordTypeError("ошибка при создании типа записей", err)
+	}
+
+	return &datapb.CreateRecordTypeResponse{Id: int32(id)}, nil
+}
+
+func (h *RecordTypeServer) ListRecordTypes(
+	ctx context.Context,
+	_ *datapb.ListRecordTypesRequest,
+) (*datapb.ListRecordTypesResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	recordTypes, err := h.recordTypeService.ListRecordTypes(ctx, userID)
+	if err != nil {
+		return nil, h.recordTypeError("ошибка при получении типов записей", err)
+	}
+
+	res := &datapb.ListRecordTypesResponse{RecordTypes: make([]*datapb.RecordType, len(recordTypes))}
+	for i, recordType := range recordTypes {
+		res.RecordTypes[i] = toRecordType(recordType)
+	}
+
+	return res, nil
+}
+
+func (h *RecordTypeServer) DeleteRecordType(
+	ctx context.Context,
+	req *datapb.DeleteRecordTypeRequest,
+) (*datapb.DeleteRecordTypeResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "не удалось получить userID из контекста")
+	}
+
+	if err = h.recordTypeService.DeleteRecordType(ctx, userID, int(req.Id)); err != nil {
+		return nil, h.recordTypeError("ошибка при удалении типа записей", err)
+	}
+
+	return &datapb.DeleteRecordTypeResponse{}, nil
+}
+
+// recordTypeError переводит ошибку сервиса в статус gRPC по её виду из helper. Неизвестные ошибки
+// логируются и возвращаются клиенту как Internal с сообщением message.
+func (h *RecordTypeServer) recordTypeError(message string, err error) error {
+	if st := kindError(err, nil); st != nil {
+		return st
+	}
+
+	h.logger.LogInfo(message, err)
+	return status.Error(codes.Internal, message)
+}
+
+// customInfo проверяет запись пользовательского типа: тип должен быть создан пользователем,
+// а содержимое из payload - соответствовать его схеме. Шифротекст в Info не проверяется.
+func customInfo(ctx context.Context, recordTypes recordTypeLookup, userID int, item *datapb.DataItem) error {
+	if recordTypes == nil {
+		return fmt.Errorf("%w: %s", helper.ErrUnknownRecordType, item.InfoType)
+	}
+
+	recordType, err := recordTypes.GetRecordType(ctx, userID, item.InfoType)
+	if errors.Is(err, helper.ErrRecordTypeNotFound) {
+		return fmt.Errorf("%w: %s", helper.ErrUnknownRecordType, item.InfoType)
+	}
+	if err != nil {
+		return err
+	}
+
+	if custom := item.GetCustom(); custom != nil {
+		if err = payload.ValidateCustom(toRecordType(recordType), custom); err != nil {
+			return fmt.Errorf("%w: %w", helper.ErrInvalidPayload, err)
+		}
+	}
+
+	return nil
+}
+
+func toRecordType(recordType *entity.RecordType) *datapb.RecordType {
+	fields := make([]*datapb.RecordField, len(recordType.Fields))
+	for i, field := range recordType.Fields {
+		fields[i] = &datapb.RecordField{
+			Name:      field.Name,
+			Label:     field.Label,
+			Type:      datapb.RecordField_Type(datapb.RecordField_Type_value[field.Type]),
+			Sensitive: field.Sensitive,
+			Required:  field.Required,
+		}
+	}
+
+	return &datapb.RecordType{Id: int32(recordType.ID), Name: recordType.Name, Fields: fields}
+}
+
+func fromRecordType(recordType *datapb.RecordType) *entity.RecordType {
+	fields := make([]entity.RecordField, len(recordType.Fields))
+	for i, field := range recordType.Fields {
+		fields[i] = entity.RecordField{
+			Name:      field.Name,
+			Label:     field.Label,
+			Type:      field.Type.String(),
+			Sensitive: field.Sensitive,
+			Required:  field.Required,
+		}
+	}
+
+	return &entity.RecordType{Name: recordType.Name, Fields: fields}
+}
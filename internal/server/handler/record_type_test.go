@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/api/datapb"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockRecordTypeService struct {
+	CreateRecordTypeFunc func(ctx context.Context, userID int, recordType *entity.RecordType) (int, error)
+	ListRecordTypesFunc  func(ctx context.Context, userID int) ([]*entity.RecordType, error)
+	GetRecordTypeFunc    func(ctx context.Context, userID int, name string) (*entity.RecordType, error)
+	DeleteRecordTypeFunc func(ctx context.Context, userID, recordTypeID int) error
+}
+
+func (m *mockRecordTypeService) CreateRecordType(
+	ctx context.Context,
+	userID int,
+	recordType *entity.RecordType,
+) (int, error) {
+	return m.CreateRecordTypeFunc(ctx, userID, recordType)
+}
+
+func (m *mockRecordTypeService) ListRecordTypes(ctx context.Context, userID int) ([]*entity.RecordType, error) {
+	return m.ListRecordTypesFunc(ctx, userID)
+}
+
+func (m *mockRecordTypeService) GetRecordType(
+	ctx context.Context,
+	userID int,
+	name string,
+) (*entity.RecordType, error) {
+	return m.GetRecordTypeFunc(ctx, userID, name)
+}
+
+func (m *mockRecordTypeService) DeleteRecordType(ctx context.Context, userID, recordTypeID int) error {
+	return m.DeleteRecordTypeFunc(ctx, userID, recordTypeID)
+}
+
+var wifiType = &entity.RecordType{ID: 3, Name: "wifi", Fields: []entity.RecordField{
+	{Name: "ssid", Label: "Сеть", Type: "STRING", Required: true},
+	{Name: "password", Type: "STRING", Sensitive: true},
+	{Name: "expires", Type: "DATE"},
+}}
+
+func TestRecordTypeServer(t *testing.T) {
+	var created *entity.RecordType
+	mockService := &mockRecordTypeService{
+		CreateRecordTypeFunc: func(_ context.Context, userID int, recordType *entity.RecordType) (int, error) {
+			if recordType.Name == "passport" {
+				return 0, helper.ErrRecordTypeExists
+			}
+			created = recordType
+			return 3, nil
+		},
+		ListRecordTypesFunc: func(context.Context, int) ([]*entity.RecordType, error) {
+			return []*entity.RecordType{wifiType}, nil
+		},
+		DeleteRecordTypeFunc: func(context.Context, int, int) error {
+			return helper.ErrRecordTypeInUse
+		},
+	}
+	server := NewRecordTypeServer(mockService, &mockLogger{})
+	ctx := contextWithUserID(1)
+	request := func(name string, fields ...*datapb.RecordField) *datapb.CreateRecordTypeRequest {
+		return &datapb.CreateRecordTypeRequest{RecordType: &datapb.RecordType{Name: name, Fields: fields}}
+	}
+
+	res, err := server.CreateRecordType(ctx, request("wifi",
+		&datapb.RecordField{Name: "ssid", Label: "Сеть", Required: true},
+		&datapb.RecordField{Name: "expires", Type: datapb.RecordField_DATE},
+	))
+	if err != nil || res.Id != 3 {
+		t.Fatalf("Unexpected response: %v, %v", res, err)
+	}
+	if len(created.Fields) != 2 || created.Fields[0].Type != "STRING" || created.Fields[1].Type != "DATE" {
+		t.Errorf("Unexpected fields: %+v", created.Fields)
+	}
+
+	_, err = server.CreateRecordType(ctx, request("text", &datapb.RecordField{Name: "ssid"}))
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument || !strings.HasPrefix(st.Message(), "некорректный тип записей: имя типа") {
+		t.Errorf("Expected InvalidArgument, got: %v", err)
+	}
+
+	_, err = server.CreateRecordType(ctx, request("passport", &datapb.RecordField{Name: "number"}))
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("Expected Aborted, got: %v", err)
+	}
+
+	list, err := server.ListRecordTypes(ctx, &datapb.ListRecordTypesRequest{})
+	if err != nil || len(list.RecordTypes) != 1 {
+		t.Fatalf("Unexpected response: %v, %v", list, err)
+	}
+	fields := list.RecordTypes[0].Fields
+	if len(fields) != 3 || !fields[1].Sensitive || fields[2].Type != datapb.RecordField_DATE {
+		t.Errorf("Unexpected fields: %v", fields)
+	}
+
+	_, err = server.DeleteRecordType(ctx, &datapb.DeleteRecordTypeRequest{Id: 3})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got: %v", err)
+	}
+}
+
+func TestCustomRecords(t *testing.T) {
+	var saved *entity.UserData
+	mockService := &mockDataService{
+		AddDataFunc: func(_ context.Context, _ int, data *entity.UserData) (int, error) {
+			saved = data
+			return 5, nil
+		},
+	}
+	recordTypes := &mockRecordTypeService{
+		GetRecordTypeFunc: func(_ context.Context, _ int, name string) (*entity.RecordType, error) {
+			if name != "wifi" {
+				return nil, helper.ErrRecordTypeNotFound
+			}
+			return wifiType, nil
+		},
+	}
+	server := NewDataServer(mockService, &mockLogger{}).WithRecordTypes(recordTypes)
+	ctx := contextWithUserID(1)
+	wifi := func(infoType string, fields map[string]string) *datapb.AddDataRequest {
+		return &datapb.AddDataRequest{Data: &datapb.DataItem{
+			InfoType: infoType,
+			Payload:  &datapb.DataItem_Custom{Custom: &datapb.Custom{Fields: fields}},
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		req     *datapb.AddDataRequest
+		message string
+	}{
+		{
+			name:    "Неизвестный тип",
+			req:     wifi("passport", map[string]string{"number": "1"}),
+			message: "неизвестный тип записи: passport",
+		},
+		{
+			name:    "Без обязательного поля",
+			req:     wifi("wifi", map[string]string{"password": "secret"}),
+			message: "некорректное содержимое записи: не заполнено обязательное поле: ssid",
+		},
+		{
+			name:    "Некорректная дата",
+			req:     wifi("wifi", map[string]string{"ssid": "home", "expires": "завтра"}),
+			message: "некорректное содержимое записи: expires: дата должна быть в формате YYYY-MM-DD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.AddData(ctx, tt.req)
+			st := status.Convert(err)
+			if st.Code() != codes.InvalidArgument || st.Message() != tt.message {
+				t.Errorf("Expected InvalidArgument %q, got: %v", tt.message, err)
+			}
+		})
+	}
+	if saved != nil {
+		t.Fatalf("Invalid records must not be saved")
+	}
+
+	_, err := server.AddData(ctx, wifi("wifi", map[string]string{"ssid": "home", "password": "secret"}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saved.InfoType != "wifi" || saved.Info != `{"password":"secret","ssid":"home"}` {
+		t.Errorf("Expected fields as JSON in Info, got: %+v", saved)
+	}
+
+	encrypted := &datapb.DataItem{InfoType: "wifi", Info: []byte(testCiphertext)}
+	_, err = server.AddData(ctx, &datapb.AddDataRequest{Data: encrypted})
+	if err != nil || saved.Info != testCiphertext {
+		t.Errorf("Expected ciphertext stored as is, got: %v, %+v", err, saved)
+	}
+}
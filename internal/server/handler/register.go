@@ -9,12 +9,21 @@ import (
 	"google.golang.org/grpc/status"
 
 	pb "github.com/NikolosHGW/goph-keeper/api/registerpb"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
 )
 
-const maxPasswordLength = 72
+const (
+	maxPasswordLength = 72
+
+	minKDFSaltLength = 16
+	maxKDFTime       = 16
+	minKDFMemoryKiB  = 19 * 1024
+	maxKDFMemoryKiB  = 1024 * 1024
+	maxKDFThreads    = 255
+)
 
 type register interface {
-	Handle(context.Context, *pb.RegisterUserRequest) (string, error)
+	Handle(context.Context, *pb.RegisterUserRequest, entity.ClientInfo) (*entity.TokenPair, error)
 }
 
 // RegisterServer - структура gRPC сервера для регистрации пользователя.
@@ -39,13 +48,19 @@ func (s *RegisterServer) RegisterUser(
 		return nil, status.Errorf(codes.InvalidArgument, "неправильный запрос: %v", err)
 	}
 
-	token, err := s.registerUseCase.Handle(ctx, req)
+	err = validateKDFParams(req.KdfParams)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "неправильные параметры KDF: %v", err)
+	}
+
+	tokens, err := s.registerUseCase.Handle(ctx, req, clientInfoFromContext(ctx))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "ошибка при регистрации пользователя: %v", err)
 	}
 
 	return &pb.RegisterUserResponse{
-		BearerToken: token,
+		BearerToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}, nil
 }
 
@@ -59,3 +74,27 @@ func validateLoginPasswordRequest(login, password string) error {
 
 	return nil
 }
+
+// validateKDFParams проверяет параметры Argon2id, если клиент включил сквозное шифрование.
+func validateKDFParams(params *pb.KdfParams) error {
+	if params == nil {
+		return nil
+	}
+	if len(params.Salt) < minKDFSaltLength {
+		return fmt.Errorf("соль должна быть не короче %d байт", minKDFSaltLength)
+	}
+	if params.Time == 0 || params.Time > maxKDFTime {
+		return fmt.Errorf("число итераций должно быть от 1 до %d", maxKDFTime)
+	}
+	if params.MemoryKib < minKDFMemoryKiB || params.MemoryKib > maxKDFMemoryKiB {
+		return fmt.Errorf("объём памяти должен быть от %d до %d КиБ", minKDFMemoryKiB, maxKDFMemoryKiB)
+	}
+	if params.Threads == 0 || params.Threads > maxKDFThreads {
+		return fmt.Errorf("число потоков должно быть от 1 до %d", maxKDFThreads)
+	}
+	if len(params.KeyCheck) == 0 {
+		return errors.New("контрольное значение ключа не передано")
+	}
+
+	return nil
+}
@@ -6,16 +6,21 @@ import (
 	"testing"
 
 	pb "github.com/NikolosHGW/goph-keeper/api/registerpb"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 type registerUseCaseMock struct {
-	handleFunc func(ctx context.Context, req *pb.RegisterUserRequest) (string, error)
+	handleFunc func(ctx context.Context, req *pb.RegisterUserRequest) (*entity.TokenPair, error)
 }
 
-func (m *registerUseCaseMock) Handle(ctx context.Context, req *pb.RegisterUserRequest) (string, error) {
+func (m *registerUseCaseMock) Handle(
+	ctx context.Context,
+	req *pb.RegisterUserRequest,
+	_ entity.ClientInfo,
+) (*entity.TokenPair, error) {
 	return m.handleFunc(ctx, req)
 }
 
@@ -37,8 +42,8 @@ func TestRegisterServer_RegisterUser(t *testing.T) {
 			},
 			setupMock: func() *registerUseCaseMock {
 				return &registerUseCaseMock{
-					handleFunc: func(ctx context.Context, req *pb.RegisterUserRequest) (string, error) {
-						return "testtoken", nil
+					handleFunc: func(ctx context.Context, req *pb.RegisterUserRequest) (*entity.TokenPair, error) {
+						return &entity.TokenPair{AccessToken: "testtoken", RefreshToken: "refresh"}, nil
 					},
 				}
 			},
@@ -54,6 +59,45 @@ func TestRegisterServer_RegisterUser(t *testing.T) {
 			setupMock:       func() *registerUseCaseMock { return nil },
 			expectedErrCode: codes.InvalidArgument,
 		},
+		{
+			name: "Успешная регистрация со сквозным шифрованием",
+			req: &pb.RegisterUserRequest{
+				Login:    "testuser",
+				Password: "password123",
+				KdfParams: &pb.KdfParams{
+					Salt:      []byte("0123456789abcdef"),
+					Time:      3,
+					MemoryKib: 64 * 1024,
+					Threads:   4,
+					KeyCheck:  []byte("check"),
+				},
+			},
+			setupMock: func() *registerUseCaseMock {
+				return &registerUseCaseMock{
+					handleFunc: func(ctx context.Context, req *pb.RegisterUserRequest) (*entity.TokenPair, error) {
+						return &entity.TokenPair{AccessToken: "testtoken", RefreshToken: "refresh"}, nil
+					},
+				}
+			},
+			expectedToken:   "testtoken",
+			expectedErrCode: codes.OK,
+		},
+		{
+			name: "Ошибка валидации - слабые параметры KDF",
+			req: &pb.RegisterUserRequest{
+				Login:    "testuser",
+				Password: "password123",
+				KdfParams: &pb.KdfParams{
+					Salt:      []byte("short"),
+					Time:      1,
+					MemoryKib: 1024,
+					Threads:   1,
+					KeyCheck:  []byte("check"),
+				},
+			},
+			setupMock:       func() *registerUseCaseMock { return nil },
+			expectedErrCode: codes.InvalidArgument,
+		},
 		{
 			name: "Ошибка в use case",
 			req: &pb.RegisterUserRequest{
@@ -62,8 +106,8 @@ func TestRegisterServer_RegisterUser(t *testing.T) {
 			},
 			setupMock: func() *registerUseCaseMock {
 				return &registerUseCaseMock{
-					handleFunc: func(ctx context.Context, req *pb.RegisterUserRequest) (string, error) {
-						return "", errors.New("some error")
+					handleFunc: func(ctx context.Context, req *pb.RegisterUserRequest) (*entity.TokenPair, error) {
+						return nil, errors.New("some error")
 					},
 				}
 			},
@@ -91,6 +135,7 @@ func TestRegisterServer_RegisterUser(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedToken, resp.BearerToken)
+				assert.Equal(t, "refresh", resp.RefreshToken)
 			}
 		})
 	}
@@ -145,3 +190,42 @@ func TestValidateRegisterUserRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateKDFParams(t *testing.T) {
+	valid := func() *pb.KdfParams {
+		return &pb.KdfParams{
+			Salt:      []byte("0123456789abcdef"),
+			Time:      3,
+			MemoryKib: 64 * 1024,
+			Threads:   4,
+			KeyCheck:  []byte("check"),
+		}
+	}
+
+	assert.NoError(t, validateKDFParams(nil))
+	assert.NoError(t, validateKDFParams(valid()))
+
+	noTime := valid()
+	noTime.Time = 0
+	assert.Error(t, validateKDFParams(noTime))
+
+	tooManyIterations := valid()
+	tooManyIterations.Time = maxKDFTime + 1
+	assert.Error(t, validateKDFParams(tooManyIterations))
+
+	lowMemory := valid()
+	lowMemory.MemoryKib = minKDFMemoryKiB - 1
+	assert.Error(t, validateKDFParams(lowMemory))
+
+	highMemory := valid()
+	highMemory.MemoryKib = maxKDFMemoryKiB + 1
+	assert.Error(t, validateKDFParams(highMemory))
+
+	tooManyThreads := valid()
+	tooManyThreads.Threads = maxKDFThreads + 1
+	assert.Error(t, validateKDFParams(tooManyThreads))
+
+	noCheck := valid()
+	noCheck.KeyCheck = nil
+	assert.Error(t, validateKDFParams(noCheck))
+}
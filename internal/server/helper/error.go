@@ -2,8 +2,73 @@ package helper
 
 import "errors"
 
+// Виды ошибок. Ошибка сервиса может относиться к одному из видов: errors.Is(err, helper.ErrNotFound)
+// верно для любой ошибки «не найдено». По виду обработчики выбирают код ответа.
+var (
+	ErrNotFound           = errors.New("не найдено")
+	ErrPermissionDenied   = errors.New("доступ запрещён")
+	ErrConflict           = errors.New("конфликт изменений")
+	ErrInvalidArgument    = errors.New("некорректный запрос")
+	ErrFailedPrecondition = errors.New("операция сейчас невозможна")
+)
+
 var (
 	ErrLoginAlreadyExists = errors.New("логин уже существует")
 	ErrInvalidCredentials = errors.New("неверная пара логин/пароль")
 	ErrInternalServer     = errors.New("внутренняя ошибка сервера")
+	ErrKeyNotFound        = errors.New("ключ шифрования не найден")
+	ErrTooManyAttempts    = errors.New("слишком много неудачных попыток входа, повторите позже")
+	ErrInvalidRefresh     = errors.New("недействительный refresh токен")
+	ErrSessionNotFound    = errors.New("сессия не найдена")
+	ErrTOTPNotEnrolled    = errors.New("двухфакторная аутентификация не настроена")
+	ErrTOTPAlreadyEnabled = errors.New("двухфакторная аутентификация уже включена")
+	ErrInvalidCode        = errors.New("неверный код подтверждения")
+	ErrChallengeNotFound  = errors.New("запрос подтверждения входа не найден или истёк")
+	ErrVersionConflict    = newKindError(ErrConflict, "запись изменена с момента чтения")
+	ErrUploadNotFound     = newKindError(ErrNotFound, "загрузка не найдена")
+	ErrInvalidUpload      = errors.New("некорректные параметры загрузки")
+	ErrChunkOutOfOrder    = errors.New("часть файла получена не по порядку")
+	ErrChunkTooLarge      = errors.New("часть файла слишком большая")
+	ErrChecksumMismatch   = errors.New("контрольная сумма части файла не совпала")
+	ErrInvalidSearch      = newKindError(ErrInvalidArgument, "некорректные параметры поиска")
+	ErrSearchDisabled     = newKindError(ErrFailedPrecondition, "поиск по мета не включён на сервере")
+	ErrInvalidPageToken   = errors.New("некорректный токен страницы")
+	ErrDataNotFound       = newKindError(ErrNotFound, "данные не найдены")
+	ErrDataForbidden      = newKindError(ErrPermissionDenied, "запись принадлежит другому пользователю")
+	ErrFolderNotFound     = newKindError(ErrNotFound, "папка не найдена")
+	ErrFolderNotEmpty     = newKindError(ErrFailedPrecondition, "папка не пуста")
+	ErrFolderCycle        = newKindError(ErrFailedPrecondition, "папку нельзя перенести в неё саму или в её подпапку")
+	ErrTagNotFound        = newKindError(ErrNotFound, "тег не найден")
+	ErrEmptyName          = newKindError(ErrInvalidArgument, "имя не может быть пустым")
+	ErrVersionNotFound    = newKindError(ErrNotFound, "версия записи не найдена")
+	ErrVersionUnavailable = newKindError(
+		ErrFailedPrecondition, "файл этой версии уже заменён и не может быть восстановлен",
+	)
+	ErrNotInTrash         = newKindError(ErrNotFound, "запись не найдена в корзине")
+	ErrInvalidPayload     = newKindError(ErrInvalidArgument, "некорректное содержимое записи")
+	ErrRecordTypeNotFound = newKindError(ErrNotFound, "тип записей не найден")
+	ErrRecordTypeExists   = newKindError(ErrConflict, "тип записей с таким именем уже есть")
+	ErrRecordTypeInUse    = newKindError(ErrFailedPrecondition, "у типа есть записи, в том числе в корзине")
+	ErrInvalidRecordType  = newKindError(ErrInvalidArgument, "некорректный тип записей")
+	ErrUnknownRecordType  = newKindError(ErrInvalidArgument, "неизвестный тип записи")
 )
+
+// KindError - ошибка одного из видов: ErrNotFound, ErrPermissionDenied, ErrConflict, ErrInvalidArgument
+// или ErrFailedPrecondition. Текст ошибки - только её собственное сообщение, без вида.
+type KindError struct {
+	kind    error
+	message string
+}
+
+func newKindError(kind error, message string) *KindError {
+	return &KindError{kind: kind, message: message}
+}
+
+func (e *KindError) Error() string {
+	return e.message
+}
+
+// Unwrap возвращает вид ошибки.
+func (e *KindError) Unwrap() error {
+	return e.kind
+}
@@ -0,0 +1,83 @@
+// Package blobstore хранит содержимое больших файлов отдельно от базы данных:
+// в каталоге на диске или в S3-совместимом объектном хранилище.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// StoreFS - объекты лежат файлами в каталоге -blob-dir.
+	StoreFS = "fs"
+	// StoreS3 - объекты лежат в бакете S3-совместимого хранилища (AWS S3, MinIO).
+	StoreS3 = "s3"
+)
+
+var (
+	// ErrNotFound - объекта с таким ключом нет в хранилище.
+	ErrNotFound = errors.New("объект не найден в хранилище")
+	// ErrInvalidKey - ключ объекта содержит недопустимые символы.
+	ErrInvalidKey = errors.New("некорректный ключ объекта")
+)
+
+// BlobStore - хранилище объектов по ключу. Ключ состоит из сегментов [A-Za-z0-9_-], разделённых "/".
+// Delete отсутствующего объекта не считается ошибкой.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type storeConfig interface {
+	GetBlobStore() string
+	GetBlobDir() string
+	GetS3Endpoint() string
+	GetS3Region() string
+	GetS3Bucket() string
+	GetS3AccessKey() string
+	GetS3SecretKey() string
+}
+
+// FromConfig создаёт хранилище, выбранное в конфигурации.
+func FromConfig(cfg storeConfig) (BlobStore, error) {
+	switch cfg.GetBlobStore() {
+	case StoreFS:
+		return NewFS(cfg.GetBlobDir())
+	case StoreS3:
+		return NewS3(S3Config{
+			Endpoint:  cfg.GetS3Endpoint(),
+			Region:    cfg.GetS3Region(),
+			Bucket:    cfg.GetS3Bucket(),
+			AccessKey: cfg.GetS3AccessKey(),
+			SecretKey: cfg.GetS3SecretKey(),
+		}, nil)
+	default:
+		return nil, fmt.Errorf("неизвестное хранилище файлов %q, ожидается fs или s3", cfg.GetBlobStore())
+	}
+}
+
+func validateKey(key string) error {
+	if key == "" {
+		return ErrInvalidKey
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+		for _, r := range segment {
+			if !isKeyRune(r) {
+				return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isKeyRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-'
+}
@@ -0,0 +1,96 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type fsStore struct {
+	dir string
+}
+
+// NewFS - хранилище объектов в файлах каталога dir. Каталог создаётся, если его нет,
+// и доступен только владельцу процесса.
+func NewFS(dir string) (*fsStore, error) {
+	if dir == "" {
+		return nil, errors.New("не задан каталог хранилища файлов")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("не удалось создать каталог хранилища файлов: %w", err)
+	}
+
+	return &fsStore{dir: dir}, nil
+}
+
+// Put записывает объект во временный файл и переименовывает его, поэтому Get
+// никогда не вернёт недописанный объект.
+func (s *fsStore) Put(_ context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("не удалось создать каталог объекта: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".put-*")
+	if err != nil {
+		return fmt.Errorf("не удалось создать файл объекта: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("не удалось записать объект: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("не удалось записать объект: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("не удалось сохранить объект: %w", err)
+	}
+
+	return nil
+}
+
+func (s *fsStore) Get(_ context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать объект: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *fsStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("не удалось удалить объект: %w", err)
+	}
+
+	return nil
+}
+
+func (s *fsStore) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
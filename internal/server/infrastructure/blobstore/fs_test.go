@@ -0,0 +1,43 @@
+package blobstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFS_PutGetDelete(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "blobs")
+	store, err := NewFS(dir)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	assert.NoError(t, store.Put(ctx, "7/up1/0", []byte("chunk")))
+	assert.NoError(t, store.Put(ctx, "7/up1/0", []byte("chunk v2")), "объект перезаписывается")
+
+	data, err := store.Get(ctx, "7/up1/0")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("chunk v2"), data)
+
+	info, err := os.Stat(filepath.Join(dir, "7", "up1"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+
+	assert.NoError(t, store.Delete(ctx, "7/up1/0"))
+	assert.NoError(t, store.Delete(ctx, "7/up1/0"), "удаление отсутствующего объекта не ошибка")
+
+	_, err = store.Get(ctx, "7/up1/0")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFS_RejectsInvalidKeys(t *testing.T) {
+	store, err := NewFS(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "../etc/passwd", "7//0", "/abs", "7/up1/../x", "7/a.b"} {
+		assert.ErrorIs(t, store.Put(context.Background(), key, []byte("x")), ErrInvalidKey, key)
+	}
+}
@@ -0,0 +1,200 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service       = "s3"
+	s3Algorithm     = "AWS4-HMAC-SHA256"
+	s3SignedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	amzDateFormat   = "20060102T150405Z"
+	s3Timeout       = 30 * time.Second
+	// maxErrorBody ограничивает, сколько байт ответа с ошибкой попадёт в текст ошибки.
+	maxErrorBody = 512
+)
+
+// S3Config - параметры подключения к S3-совместимому хранилищу.
+type S3Config struct {
+	// Endpoint - адрес хранилища, например https://s3.eu-central-1.amazonaws.com или http://minio:9000.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+type s3Store struct {
+	client   *http.Client
+	endpoint *url.URL
+	now      func() time.Time
+	cfg      S3Config
+}
+
+// NewS3 - хранилище объектов в бакете S3-совместимого сервиса. Запросы подписываются AWS Signature V4,
+// бакет адресуется в пути (path-style), как того ожидают MinIO и большинство совместимых хранилищ.
+// Если client nil, используется клиент с таймаутом s3Timeout.
+func NewS3(cfg S3Config, client *http.Client) (*s3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.Region == "" {
+		return nil, errors.New("для хранилища s3 нужны адрес, регион и бакет")
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("для хранилища s3 нужны ключи доступа")
+	}
+
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("некорректный адрес хранилища s3 %q", cfg.Endpoint)
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: s3Timeout}
+	}
+
+	return &s3Store{client: client, endpoint: endpoint, now: time.Now, cfg: cfg}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+
+	return nil
+}
+
+func (s *s3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, responseError(resp)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать объект: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return responseError(resp)
+	}
+}
+
+func (s *s3Store) do(ctx context.Context, method, key string, body []byte) (*http.Response, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	target := *s.endpoint
+	target.Path = strings.TrimSuffix(s.endpoint.Path, "/") + "/" + s.cfg.Bucket + "/" + key
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка формирования запроса к s3: %w", err)
+	}
+	req.ContentLength = int64(len(body))
+	s.sign(req, body)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка запроса к s3: %w", err)
+	}
+
+	return resp, nil
+}
+
+// sign добавляет к запросу заголовки подписи AWS Signature V4.
+func (s *s3Store) sign(req *http.Request, body []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	scope := strings.Join([]string{amzDate[:8], s.cfg.Region, s3Service, "aws4_request"}, "/")
+	signature := signatureV4(s.cfg.SecretKey, scope, amzDate, canonicalRequest(req, payloadHash))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.cfg.AccessKey, scope, s3SignedHeaders, signature,
+	))
+}
+
+func canonicalRequest(req *http.Request, payloadHash string) string {
+	return strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + req.Header.Get("x-amz-date"),
+		"",
+		s3SignedHeaders,
+		payloadHash,
+	}, "\n")
+}
+
+func signatureV4(secretKey, scope, amzDate, canonical string) string {
+	stringToSign := strings.Join([]string{s3Algorithm, amzDate, scope, sha256Hex([]byte(canonical))}, "\n")
+
+	key := []byte("AWS4" + secretKey)
+	for _, part := range strings.Split(scope, "/") {
+		key = hmacSHA256(key, part)
+	}
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return fmt.Errorf("хранилище s3 ответило %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
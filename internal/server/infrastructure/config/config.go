@@ -1,20 +1,51 @@
 package config
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/caarlos0/env"
 )
 
+const (
+	// DefaultCryptoKey - ключ шифрования по умолчанию. Допустим только в режиме разработки.
+	DefaultCryptoKey = "01234567890123456789012345678901"
+	// DefaultSecretKey - HMAC секрет подписи токенов по умолчанию. Допустим только в режиме разработки.
+	DefaultSecretKey = "abc"
+)
+
 type config struct {
-	RunAddress    string `env:"RUN_ADDRESS"`
-	DatabaseURI   string `env:"DATABASE_URI"`
-	SecretKey     string `env:"SECRET_KEY"`
-	CryptoKey     string `env:"CRYPTO_KEY"`
-	ServerKeyPath string `env:"SERVER_KEY_PATH"`
-	ServerCrtPath string `env:"SERVER_CRT_PATH"`
+	RunAddress      string        `env:"RUN_ADDRESS"`
+	DatabaseURI     string        `env:"DATABASE_URI"`
+	SecretKey       string        `env:"SECRET_KEY"`
+	JWTKeyDir       string        `env:"JWT_KEY_DIR"`
+	JWTKeyID        string        `env:"JWT_KEY_ID"`
+	CryptoKey       string        `env:"CRYPTO_KEY"`
+	CryptoKeyID     string        `env:"CRYPTO_KEY_ID"`
+	CryptoOldKeyIDs string        `env:"CRYPTO_OLD_KEY_IDS"`
+	KeyProvider     string        `env:"KEY_PROVIDER"`
+	CryptoKeyDir    string        `env:"CRYPTO_KEY_DIR"`
+	KMSRootKeyPath  string        `env:"KMS_ROOT_KEY_PATH"`
+	ServerKeyPath   string        `env:"SERVER_KEY_PATH"`
+	ServerCrtPath   string        `env:"SERVER_CRT_PATH"`
+	EventBus        string        `env:"EVENT_BUS"`
+	BlobStore       string        `env:"BLOB_STORE"`
+	BlobDir         string        `env:"BLOB_DIR"`
+	S3Endpoint      string        `env:"S3_ENDPOINT"`
+	S3Region        string        `env:"S3_REGION"`
+	S3Bucket        string        `env:"S3_BUCKET"`
+	S3AccessKey     string        `env:"S3_ACCESS_KEY"`
+	S3SecretKey     string        `env:"S3_SECRET_KEY"`
+	SearchKeyID     string        `env:"SEARCH_KEY_ID"`
+	UploadTTL       time.Duration `env:"UPLOAD_TTL"`
+	HistoryTTL      time.Duration `env:"HISTORY_TTL"`
+	TrashTTL        time.Duration `env:"TRASH_TTL"`
+	HistoryVersions int           `env:"HISTORY_VERSIONS"`
+	DevMode         bool          `env:"DEV_MODE"`
 }
 
 func (c *config) initEnv() error {
@@ -34,10 +65,31 @@ func (c *config) parseFlags() {
 			"dbname=gophkeeper "+
 			"sslmode=disable",
 		"data source name for connection")
-	flag.StringVar(&c.SecretKey, "k", "abc", "secret key for hash")
-	flag.StringVar(&c.CryptoKey, "crypto-key", "01234567890123456789012345678901", "crypto key")
+	flag.StringVar(&c.SecretKey, "k", DefaultSecretKey, "HMAC secret for tokens, used when -jwt-key-dir is empty")
+	flag.StringVar(&c.JWTKeyDir, "jwt-key-dir", "", "directory with <kid>.pem signing and <kid>.pub.pem verification keys")
+	flag.StringVar(&c.JWTKeyID, "jwt-key-id", "", "kid of the active token signing key in -jwt-key-dir")
+	flag.StringVar(&c.CryptoKey, "crypto-key", DefaultCryptoKey, "crypto key, used only with -key-provider=flag")
+	flag.StringVar(&c.CryptoKeyID, "crypto-key-id", "1", "crypto key version id")
+	flag.StringVar(&c.CryptoOldKeyIDs, "crypto-old-key-ids", "", "previous crypto key version ids as id,id")
+	flag.StringVar(&c.KeyProvider, "key-provider", "flag", "crypto key source: flag, file, env or kms")
+	flag.StringVar(&c.CryptoKeyDir, "crypto-key-dir", "./keys", "directory with <id>.key or <id>.enc key files")
+	flag.StringVar(&c.KMSRootKeyPath, "kms-root-key", "./keys/kms-root.key", "path to local KMS root key")
+	flag.BoolVar(&c.DevMode, "dev", false, "development mode, allows the default crypto key and token secret")
 	flag.StringVar(&c.ServerKeyPath, "server-key", "./server.key", "path to server key")
 	flag.StringVar(&c.ServerCrtPath, "server-crt", "./server.crt", "path to server crt")
+	flag.StringVar(&c.EventBus, "event-bus", "local", "WatchData event delivery: local (single node) or postgres")
+	flag.StringVar(&c.BlobStore, "blob-store", "fs", "storage for binary file contents: fs or s3")
+	flag.StringVar(&c.BlobDir, "blob-dir", "./blobs", "directory for -blob-store=fs")
+	flag.StringVar(&c.S3Endpoint, "s3-endpoint", "", "S3-compatible storage URL, e.g. http://localhost:9000")
+	flag.StringVar(&c.S3Region, "s3-region", "us-east-1", "S3 region")
+	flag.StringVar(&c.S3Bucket, "s3-bucket", "goph-keeper", "S3 bucket for binary file contents")
+	flag.StringVar(&c.S3AccessKey, "s3-access-key", "", "S3 access key id")
+	flag.StringVar(&c.S3SecretKey, "s3-secret-key", "", "S3 secret access key")
+	flag.StringVar(&c.SearchKeyID, "search-key-id", "", "crypto key version id for the search index, required unless -dev")
+	flag.DurationVar(&c.UploadTTL, "upload-ttl", 24*time.Hour, "unfinished uploads older than this are removed")
+	flag.IntVar(&c.HistoryVersions, "history-versions", 20, "previous versions kept per record, 0 keeps all")
+	flag.DurationVar(&c.HistoryTTL, "history-ttl", 0, "previous versions older than this are removed, 0 keeps forever")
+	flag.DurationVar(&c.TrashTTL, "trash-ttl", 30*24*time.Hour, "deleted records are purged after this, 0 keeps forever")
 	flag.Parse()
 }
 
@@ -68,11 +120,67 @@ func (c config) GetSecretKey() string {
 	return c.SecretKey
 }
 
-// GetCryptoKeyPath геттер для ключа шифрования.
-func (c config) GetCryptoKeyPath() string {
+// GetJWTKeyDir геттер для каталога с ключами подписи токенов.
+func (c config) GetJWTKeyDir() string {
+	return c.JWTKeyDir
+}
+
+// GetJWTKeyID геттер для идентификатора действующего ключа подписи токенов.
+func (c config) GetJWTKeyID() string {
+	return c.JWTKeyID
+}
+
+// GetCryptoKey геттер для ключа шифрования, переданного флагом.
+func (c config) GetCryptoKey() string {
 	return c.CryptoKey
 }
 
+// GetCryptoKeyID геттер для идентификатора версии ключа шифрования.
+func (c config) GetCryptoKeyID() string {
+	return c.CryptoKeyID
+}
+
+// GetCryptoOldKeyIDs возвращает идентификаторы предыдущих версий ключа шифрования.
+func (c config) GetCryptoOldKeyIDs() ([]string, error) {
+	if c.CryptoOldKeyIDs == "" {
+		return nil, nil
+	}
+
+	ids := strings.Split(c.CryptoOldKeyIDs, ",")
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return nil, errors.New("ожидается формат id,id")
+		}
+		if _, exists := seen[id]; exists {
+			return nil, fmt.Errorf("ключ %q указан несколько раз", id)
+		}
+		seen[id] = struct{}{}
+	}
+
+	return ids, nil
+}
+
+// GetKeyProvider геттер для источника ключей шифрования.
+func (c config) GetKeyProvider() string {
+	return c.KeyProvider
+}
+
+// GetCryptoKeyDir геттер для каталога с файлами ключей шифрования.
+func (c config) GetCryptoKeyDir() string {
+	return c.CryptoKeyDir
+}
+
+// GetKMSRootKeyPath геттер для пути к корневому ключу локального KMS.
+func (c config) GetKMSRootKeyPath() string {
+	return c.KMSRootKeyPath
+}
+
+// IsDevMode сообщает, запущен ли сервер в режиме разработки.
+func (c config) IsDevMode() bool {
+	return c.DevMode
+}
+
 // GetServerKeyPath геттер для пути к приватному ключу сервера.
 func (c config) GetServerKeyPath() string {
 	return c.ServerKeyPath
@@ -82,3 +190,68 @@ func (c config) GetServerKeyPath() string {
 func (c config) GetServerCrtPath() string {
 	return c.ServerCrtPath
 }
+
+// GetEventBus геттер для способа доставки событий WatchData.
+func (c config) GetEventBus() string {
+	return c.EventBus
+}
+
+// GetBlobStore геттер для хранилища содержимого файлов.
+func (c config) GetBlobStore() string {
+	return c.BlobStore
+}
+
+// GetBlobDir геттер для каталога хранилища файлов.
+func (c config) GetBlobDir() string {
+	return c.BlobDir
+}
+
+// GetS3Endpoint геттер для адреса S3-совместимого хранилища.
+func (c config) GetS3Endpoint() string {
+	return c.S3Endpoint
+}
+
+// GetS3Region геттер для региона S3.
+func (c config) GetS3Region() string {
+	return c.S3Region
+}
+
+// GetS3Bucket геттер для бакета S3.
+func (c config) GetS3Bucket() string {
+	return c.S3Bucket
+}
+
+// GetS3AccessKey геттер для идентификатора ключа доступа S3.
+func (c config) GetS3AccessKey() string {
+	return c.S3AccessKey
+}
+
+// GetS3SecretKey геттер для секретного ключа доступа S3.
+func (c config) GetS3SecretKey() string {
+	return c.S3SecretKey
+}
+
+// GetUploadTTL геттер для времени, через которое незавершённая загрузка считается брошенной.
+func (c config) GetUploadTTL() time.Duration {
+	return c.UploadTTL
+}
+
+// GetHistoryVersions геттер для числа хранимых прежних версий записи.
+func (c config) GetHistoryVersions() int {
+	return c.HistoryVersions
+}
+
+// GetHistoryTTL геттер для срока хранения прежних версий записей.
+func (c config) GetHistoryTTL() time.Duration {
+	return c.HistoryTTL
+}
+
+// GetTrashTTL геттер для срока хранения записей в корзине.
+func (c config) GetTrashTTL() time.Duration {
+	return c.TrashTTL
+}
+
+// GetSearchKeyID геттер для версии мастер-ключа, из которой выводится ключ индекса поиска.
+func (c config) GetSearchKeyID() string {
+	return c.SearchKeyID
+}
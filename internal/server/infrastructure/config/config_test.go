@@ -63,12 +63,67 @@ func TestConfig_Getters(t *testing.T) {
 		CryptoKey:     "/path/to/crypto.key",
 		ServerKeyPath: "/path/to/server.key",
 		ServerCrtPath: "/path/to/server.crt",
+		KeyProvider:   "file",
+		CryptoKeyDir:  "/path/to/keys",
+		JWTKeyDir:     "/path/to/jwt",
+		JWTKeyID:      "2024-05",
+		DevMode:       true,
 	}
 
 	assert.Equal(t, "127.0.0.1:9090", cfg.GetRunAddress())
 	assert.Equal(t, "user=test password=test dbname=testdb sslmode=disable", cfg.GetDatabaseURI())
 	assert.Equal(t, "supersecret", cfg.GetSecretKey())
-	assert.Equal(t, "/path/to/crypto.key", cfg.GetCryptoKeyPath())
+	assert.Equal(t, "/path/to/crypto.key", cfg.GetCryptoKey())
 	assert.Equal(t, "/path/to/server.key", cfg.GetServerKeyPath())
 	assert.Equal(t, "/path/to/server.crt", cfg.GetServerCrtPath())
+	assert.Equal(t, "file", cfg.GetKeyProvider())
+	assert.Equal(t, "/path/to/keys", cfg.GetCryptoKeyDir())
+	assert.Equal(t, "/path/to/jwt", cfg.GetJWTKeyDir())
+	assert.Equal(t, "2024-05", cfg.GetJWTKeyID())
+	assert.True(t, cfg.IsDevMode())
+}
+
+func TestConfig_GetCryptoOldKeyIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "старые ключи не заданы",
+			raw:      "",
+			expected: nil,
+		},
+		{
+			name:     "несколько ключей",
+			raw:      "1,2",
+			expected: []string{"1", "2"},
+		},
+		{
+			name:    "пустой идентификатор",
+			raw:     "1,,2",
+			wantErr: true,
+		},
+		{
+			name:    "повторяющийся идентификатор",
+			raw:     "1,1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config{CryptoOldKeyIDs: tt.raw}
+
+			ids, err := cfg.GetCryptoOldKeyIDs()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
 }
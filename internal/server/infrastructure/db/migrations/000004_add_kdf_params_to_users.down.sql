@@ -0,0 +1,10 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS kdf_salt,
+    DROP COLUMN IF EXISTS kdf_time,
+    DROP COLUMN IF EXISTS kdf_memory_kib,
+    DROP COLUMN IF EXISTS kdf_threads,
+    DROP COLUMN IF EXISTS kdf_key_check;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS kdf_salt BYTEA,
+    ADD COLUMN IF NOT EXISTS kdf_time INT,
+    ADD COLUMN IF NOT EXISTS kdf_memory_kib INT,
+    ADD COLUMN IF NOT EXISTS kdf_threads SMALLINT,
+    ADD COLUMN IF NOT EXISTS kdf_key_check BYTEA;
+
+COMMIT;
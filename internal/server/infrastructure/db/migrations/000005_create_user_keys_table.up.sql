@@ -0,0 +1,15 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE IF NOT EXISTS user_keys(
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id),
+    master_key_id VARCHAR(64) NOT NULL,
+    wrapped_key TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_keys_active_user_id_idx ON user_keys (user_id) WHERE active;
+CREATE INDEX IF NOT EXISTS user_keys_master_key_id_idx ON user_keys (master_key_id);
+
+COMMIT;
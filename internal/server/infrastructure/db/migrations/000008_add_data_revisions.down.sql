@@ -0,0 +1,8 @@
+BEGIN TRANSACTION;
+
+DROP TABLE IF EXISTS user_data_tombstones;
+DROP INDEX IF EXISTS idx_user_data_user_id_revision;
+ALTER TABLE user_data DROP COLUMN IF EXISTS revision;
+ALTER TABLE users DROP COLUMN IF EXISTS data_revision;
+
+COMMIT;
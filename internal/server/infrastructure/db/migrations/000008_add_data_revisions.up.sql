@@ -0,0 +1,19 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS data_revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE user_data ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
+
+UPDATE user_data SET revision = 1;
+UPDATE users SET data_revision = 1 WHERE id IN (SELECT user_id FROM user_data);
+
+CREATE INDEX IF NOT EXISTS idx_user_data_user_id_revision ON user_data(user_id, revision);
+
+CREATE TABLE IF NOT EXISTS user_data_tombstones(
+    data_id INT PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    revision BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_data_tombstones_user_id_revision ON user_data_tombstones(user_id, revision);
+
+COMMIT;
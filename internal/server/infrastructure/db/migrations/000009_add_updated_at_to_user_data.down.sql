@@ -0,0 +1,5 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE user_data DROP COLUMN IF EXISTS updated_at;
+
+COMMIT;
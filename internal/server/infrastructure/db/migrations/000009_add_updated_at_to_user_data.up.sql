@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE user_data ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+UPDATE user_data SET updated_at = created;
+
+COMMIT;
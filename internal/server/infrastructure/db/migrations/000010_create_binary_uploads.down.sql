@@ -0,0 +1,6 @@
+BEGIN TRANSACTION;
+
+DROP TABLE IF EXISTS binary_chunks;
+DROP TABLE IF EXISTS binary_uploads;
+
+COMMIT;
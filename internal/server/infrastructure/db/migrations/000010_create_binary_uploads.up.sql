@@ -0,0 +1,22 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE IF NOT EXISTS binary_uploads(
+    id VARCHAR(64) PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    data_id INT UNIQUE REFERENCES user_data(id) ON DELETE CASCADE,
+    replace_id INT NOT NULL DEFAULT 0,
+    info TEXT NOT NULL DEFAULT '',
+    meta TEXT NOT NULL DEFAULT '',
+    chunk_count INT NOT NULL,
+    received INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS binary_chunks(
+    upload_id VARCHAR(64) NOT NULL REFERENCES binary_uploads(id) ON DELETE CASCADE,
+    seq INT NOT NULL,
+    data TEXT NOT NULL,
+    PRIMARY KEY (upload_id, seq)
+);
+
+COMMIT;
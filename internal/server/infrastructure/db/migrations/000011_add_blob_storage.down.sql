@@ -0,0 +1,19 @@
+BEGIN TRANSACTION;
+
+DROP TRIGGER IF EXISTS binary_uploads_collect_blobs ON binary_uploads;
+DROP FUNCTION IF EXISTS collect_upload_blobs();
+DROP TABLE IF EXISTS blob_garbage;
+
+DELETE FROM binary_chunks WHERE data IS NULL;
+ALTER TABLE binary_chunks DROP COLUMN IF EXISTS sha256;
+ALTER TABLE binary_chunks DROP COLUMN IF EXISTS size;
+ALTER TABLE binary_chunks ALTER COLUMN data SET NOT NULL;
+
+ALTER TABLE binary_uploads DROP COLUMN IF EXISTS size;
+ALTER TABLE binary_uploads DROP COLUMN IF EXISTS blob_ref;
+
+ALTER TABLE user_data DROP COLUMN IF EXISTS blob_sha256;
+ALTER TABLE user_data DROP COLUMN IF EXISTS blob_size;
+ALTER TABLE user_data DROP COLUMN IF EXISTS blob_ref;
+
+COMMIT;
@@ -0,0 +1,35 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE user_data ADD COLUMN IF NOT EXISTS blob_ref VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE user_data ADD COLUMN IF NOT EXISTS blob_size BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE user_data ADD COLUMN IF NOT EXISTS blob_sha256 VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE binary_uploads ADD COLUMN IF NOT EXISTS blob_ref VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE binary_uploads ADD COLUMN IF NOT EXISTS size BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE binary_chunks ALTER COLUMN data DROP NOT NULL;
+ALTER TABLE binary_chunks ADD COLUMN IF NOT EXISTS size INT NOT NULL DEFAULT 0;
+ALTER TABLE binary_chunks ADD COLUMN IF NOT EXISTS sha256 VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS blob_garbage(
+    id SERIAL PRIMARY KEY,
+    blob_ref VARCHAR(128) NOT NULL,
+    chunks INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE OR REPLACE FUNCTION collect_upload_blobs() RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.blob_ref <> '' THEN
+        INSERT INTO blob_garbage (blob_ref, chunks) VALUES (OLD.blob_ref, OLD.received + 1);
+    END IF;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS binary_uploads_collect_blobs ON binary_uploads;
+CREATE TRIGGER binary_uploads_collect_blobs
+    AFTER DELETE ON binary_uploads
+    FOR EACH ROW EXECUTE FUNCTION collect_upload_blobs();
+
+COMMIT;
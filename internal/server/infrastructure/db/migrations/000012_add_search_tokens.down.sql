@@ -0,0 +1,8 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE binary_uploads DROP COLUMN IF EXISTS search_tokens;
+
+DROP INDEX IF EXISTS idx_user_data_search_tokens;
+ALTER TABLE user_data DROP COLUMN IF EXISTS search_tokens;
+
+COMMIT;
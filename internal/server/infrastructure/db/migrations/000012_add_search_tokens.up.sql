@@ -0,0 +1,9 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE user_data ADD COLUMN IF NOT EXISTS search_tokens TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_user_data_search_tokens ON user_data USING GIN (search_tokens);
+
+ALTER TABLE binary_uploads ADD COLUMN IF NOT EXISTS search_tokens TEXT NOT NULL DEFAULT '';
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN TRANSACTION;
+
+DROP INDEX IF EXISTS idx_user_data_user_id_created_id;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN TRANSACTION;
+
+CREATE INDEX IF NOT EXISTS idx_user_data_user_id_created_id ON user_data(user_id, created, id);
+
+COMMIT;
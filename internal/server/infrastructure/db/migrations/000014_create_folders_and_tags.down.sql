@@ -0,0 +1,9 @@
+BEGIN TRANSACTION;
+
+DROP TABLE IF EXISTS data_tags;
+DROP TABLE IF EXISTS tags;
+
+ALTER TABLE user_data DROP COLUMN IF EXISTS folder_id;
+DROP TABLE IF EXISTS folders;
+
+COMMIT;
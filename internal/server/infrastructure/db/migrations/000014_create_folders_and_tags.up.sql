@@ -0,0 +1,32 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE IF NOT EXISTS folders(
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    parent_id INT REFERENCES folders(id),
+    name TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_folders_user_id ON folders(user_id);
+
+ALTER TABLE user_data ADD COLUMN IF NOT EXISTS folder_id INT REFERENCES folders(id);
+
+CREATE INDEX IF NOT EXISTS idx_user_data_folder_id ON user_data(folder_id);
+
+CREATE TABLE IF NOT EXISTS tags(
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tags_user_id ON tags(user_id);
+
+CREATE TABLE IF NOT EXISTS data_tags(
+    data_id INT NOT NULL REFERENCES user_data(id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (data_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_tags_tag_id ON data_tags(tag_id);
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN TRANSACTION;
+
+CREATE TABLE IF NOT EXISTS user_data_versions(
+    data_id INT NOT NULL REFERENCES user_data(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+    info_type VARCHAR(50) NOT NULL,
+    info TEXT,
+    meta TEXT,
+    search_tokens TEXT[] NOT NULL DEFAULT '{}',
+    blob_ref VARCHAR(128) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL,
+    archived_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (data_id, revision)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_data_versions_archived_at ON user_data_versions(archived_at);
+
+COMMIT;
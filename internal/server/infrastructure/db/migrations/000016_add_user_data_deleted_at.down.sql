@@ -0,0 +1,8 @@
+BEGIN TRANSACTION;
+
+DELETE FROM user_data WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_user_data_deleted_at;
+ALTER TABLE user_data DROP COLUMN IF EXISTS deleted_at;
+
+COMMIT;
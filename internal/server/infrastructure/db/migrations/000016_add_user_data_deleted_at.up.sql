@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE user_data ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_user_data_deleted_at ON user_data(deleted_at) WHERE deleted_at IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN TRANSACTION;
+
+DROP TABLE IF EXISTS record_types;
+
+DELETE FROM user_data WHERE info_type NOT IN ('login_password', 'text', 'binary', 'bank_card');
+
+ALTER TABLE user_data ADD CONSTRAINT user_data_info_type_check
+    CHECK (info_type IN ('login_password', 'text', 'binary', 'bank_card'));
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE user_data DROP CONSTRAINT IF EXISTS user_data_info_type_check;
+
+CREATE TABLE IF NOT EXISTS record_types(
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    fields TEXT NOT NULL,
+    UNIQUE (user_id, name)
+);
+
+COMMIT;
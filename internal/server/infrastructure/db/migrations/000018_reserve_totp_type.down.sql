@@ -0,0 +1,14 @@
+BEGIN TRANSACTION;
+
+-- Без встроенного типа totp его записи становятся записями пользовательского типа totp с теми же полями.
+-- Содержимое зашифровано, поэтому миграция его не переписывает: записи и их история сохраняются как есть.
+INSERT INTO record_types (user_id, name, fields)
+SELECT DISTINCT user_id, 'totp',
+    '[{"name":"secret","type":"STRING","sensitive":true,"required":true},' ||
+    '{"name":"algorithm","type":"STRING"},{"name":"digits","type":"NUMBER"},{"name":"period","type":"NUMBER"},' ||
+    '{"name":"issuer","type":"STRING"},{"name":"account","type":"STRING"}]'
+FROM user_data
+WHERE info_type = 'totp'
+ON CONFLICT (user_id, name) DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN TRANSACTION;
+
+-- totp стал встроенным типом: пользовательские типы с этим именем и их записи переименовываются
+-- в custom_totp, а если это имя у пользователя уже занято - в custom_totp_2, custom_totp_3 и так далее.
+CREATE TEMPORARY TABLE totp_type_renames ON COMMIT DROP AS
+SELECT rt.user_id, (
+    SELECT candidate.name
+    FROM generate_series(1, 1000) AS n,
+        LATERAL (SELECT CASE WHEN n = 1 THEN 'custom_totp' ELSE 'custom_totp_' || n END AS name) AS candidate
+    WHERE NOT EXISTS (SELECT 1 FROM record_types o WHERE o.user_id = rt.user_id AND o.name = candidate.name)
+    ORDER BY n
+    LIMIT 1
+) AS new_name
+FROM record_types rt
+WHERE rt.name = 'totp';
+
+-- Ревизии переименованных записей поднимаются, чтобы клиенты обновили их в локальном кэше при синхронизации.
+UPDATE users SET data_revision = data_revision + 1
+WHERE id IN (SELECT user_id FROM user_data WHERE info_type = 'totp')
+    AND id IN (SELECT user_id FROM totp_type_renames);
+
+UPDATE user_data d SET info_type = r.new_name, revision = u.data_revision
+FROM totp_type_renames r, users u
+WHERE d.info_type = 'totp' AND d.user_id = r.user_id AND u.id = r.user_id;
+
+UPDATE user_data_versions v SET info_type = r.new_name
+FROM totp_type_renames r
+WHERE v.info_type = 'totp' AND v.user_id = r.user_id;
+
+UPDATE record_types rt SET name = r.new_name
+FROM totp_type_renames r
+WHERE rt.name = 'totp' AND rt.user_id = r.user_id;
+
+COMMIT;
@@ -0,0 +1,90 @@
+// Package eventbus доставляет события об изменении данных подписчикам WatchData.
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+)
+
+// subscriberBuffer - сколько событий ждёт подписчика, прежде чем он будет отключён как отстающий.
+const subscriberBuffer = 64
+
+type subscriber struct {
+	events chan *entity.DataEvent
+}
+
+// Local - брокер событий в памяти процесса. Подходит, когда сервер запущен на одном узле.
+type Local struct {
+	subscribers map[int]map[*subscriber]struct{}
+	mu          sync.Mutex
+}
+
+// NewLocal - конструктор брокера событий в памяти процесса.
+func NewLocal() *Local {
+	return &Local{subscribers: make(map[int]map[*subscriber]struct{})}
+}
+
+// Publish рассылает событие подписчикам его пользователя. Не блокируется: канал подписчика,
+// который не успевает читать события, закрывается, и подписчик должен переподключиться.
+func (b *Local) Publish(_ context.Context, event *entity.DataEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers[event.UserID] {
+		select {
+		case sub.events <- event:
+		default:
+			b.remove(event.UserID, sub)
+		}
+	}
+
+	return nil
+}
+
+// Subscribe подписывает на события пользователя. Канал закрывается после вызова cancel
+// или если подписчик отстал.
+func (b *Local) Subscribe(userID int) (events <-chan *entity.DataEvent, cancel func()) {
+	sub := &subscriber{events: make(chan *entity.DataEvent, subscriberBuffer)}
+
+	b.mu.Lock()
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[*subscriber]struct{})
+	}
+	b.subscribers[userID][sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub.events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.remove(userID, sub)
+	}
+}
+
+// DisconnectAll закрывает каналы всех подписчиков, например когда часть событий могла потеряться.
+// Подписчики должны синхронизироваться и подписаться заново.
+func (b *Local) DisconnectAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for userID, subs := range b.subscribers {
+		for sub := range subs {
+			b.remove(userID, sub)
+		}
+	}
+}
+
+func (b *Local) remove(userID int, sub *subscriber) {
+	subs := b.subscribers[userID]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	close(sub.events)
+	if len(subs) == 0 {
+		delete(b.subscribers, userID)
+	}
+}
@@ -0,0 +1,51 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal_PublishToUserSubscribers(t *testing.T) {
+	bus := NewLocal()
+	ctx := context.Background()
+
+	first, cancelFirst := bus.Subscribe(1)
+	second, cancelSecond := bus.Subscribe(1)
+	other, cancelOther := bus.Subscribe(2)
+	defer cancelSecond()
+	defer cancelOther()
+
+	event := &entity.DataEvent{Kind: entity.DataAdded, UserID: 1, ID: 7}
+	assert.NoError(t, bus.Publish(ctx, event))
+
+	assert.Equal(t, event, <-first)
+	assert.Equal(t, event, <-second)
+	assert.Empty(t, other, "события других пользователей не доставляются")
+
+	cancelFirst()
+	_, ok := <-first
+	assert.False(t, ok, "после отмены канал закрыт")
+	cancelFirst()
+
+	assert.NoError(t, bus.Publish(ctx, event))
+	assert.Equal(t, event, <-second)
+}
+
+func TestLocal_DropsSlowSubscriber(t *testing.T) {
+	bus := NewLocal()
+	events, cancel := bus.Subscribe(1)
+	defer cancel()
+
+	for i := range subscriberBuffer + 1 {
+		assert.NoError(t, bus.Publish(context.Background(), &entity.DataEvent{UserID: 1, ID: i}))
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received, "отстающий подписчик получает накопленное и отключается")
+}
@@ -0,0 +1,103 @@
+package eventbus
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"github.com/lib/pq"
+)
+
+const (
+	// Channel - канал LISTEN/NOTIFY, через который узлы обмениваются событиями.
+	Channel = "goph_keeper_data_events"
+
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+)
+
+type notifier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Postgres - брокер событий для нескольких узлов: события публикуются через NOTIFY,
+// а каждый узел слушает канал и раздаёт их своим подписчикам.
+// Событие доходит и до узла, который его опубликовал.
+type Postgres struct {
+	db       notifier
+	listener *pq.Listener
+	local    *Local
+	logger   logger.CustomLogger
+	done     chan struct{}
+}
+
+// NewPostgres - конструктор брокера событий на LISTEN/NOTIFY. dsn нужен для отдельного соединения слушателя.
+func NewPostgres(db notifier, dsn string, logger logger.CustomLogger) (*Postgres, error) {
+	listener := pq.NewListener(dsn, minReconnectInterval, maxReconnectInterval, func(_ pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.LogInfo("ошибка соединения слушателя событий", err)
+		}
+	})
+	if err := listener.Listen(Channel); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("не удалось подписаться на канал событий: %w", err)
+	}
+
+	b := &Postgres{db: db, listener: listener, local: NewLocal(), logger: logger, done: make(chan struct{})}
+	go b.run(listener.Notify)
+
+	return b, nil
+}
+
+// Publish отправляет событие всем узлам.
+func (b *Postgres) Publish(ctx context.Context, event *entity.DataEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации события: %w", err)
+	}
+
+	if _, err = b.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", Channel, string(payload)); err != nil {
+		return fmt.Errorf("ошибка отправки события: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe подписывает на события пользователя со всех узлов.
+func (b *Postgres) Subscribe(userID int) (events <-chan *entity.DataEvent, cancel func()) {
+	return b.local.Subscribe(userID)
+}
+
+// Close отключает слушателя и дожидается остановки раздачи событий.
+func (b *Postgres) Close() error {
+	err := b.listener.Close()
+	<-b.done
+
+	return err
+}
+
+// run раздаёт события из канала слушателя локальным подписчикам, пока канал не закроется.
+func (b *Postgres) run(notifications <-chan *pq.Notification) {
+	defer close(b.done)
+
+	for notification := range notifications {
+		// nil приходит после переподключения: события за время разрыва потеряны. Подписчики отключаются,
+		// чтобы клиенты восполнили их синхронизацией по ревизии и подписались заново.
+		if notification == nil {
+			b.local.DisconnectAll()
+			continue
+		}
+
+		event := &entity.DataEvent{}
+		if err := json.Unmarshal([]byte(notification.Extra), event); err != nil {
+			b.logger.LogInfo("некорректное событие в канале "+Channel, err)
+			continue
+		}
+
+		_ = b.local.Publish(context.Background(), event)
+	}
+}
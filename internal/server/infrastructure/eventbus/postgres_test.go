@@ -0,0 +1,55 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockLogger struct{}
+
+func (l *mockLogger) LogInfo(message string, err error) {}
+
+func TestPostgres_Publish(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	bus := &Postgres{db: db, local: NewLocal(), logger: new(mockLogger)}
+	mock.ExpectExec("SELECT pg_notify\\(\\$1, \\$2\\)").
+		WithArgs(Channel, `{"kind":"added","info_type":"text","user_id":1,"id":7}`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = bus.Publish(context.Background(), &entity.DataEvent{Kind: entity.DataAdded, UserID: 1, ID: 7, InfoType: "text"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgres_RunDisconnectsAfterReconnect(t *testing.T) {
+	bus := &Postgres{local: NewLocal(), logger: new(mockLogger), done: make(chan struct{})}
+	notifications := make(chan *pq.Notification)
+	go bus.run(notifications)
+
+	events, cancel := bus.Subscribe(1)
+	defer cancel()
+
+	notifications <- &pq.Notification{Channel: Channel, Extra: "не json"}
+	notifications <- &pq.Notification{Channel: Channel, Extra: `{"user_id":1,"id":7}`}
+	assert.Equal(t, &entity.DataEvent{UserID: 1, ID: 7}, <-events)
+
+	notifications <- nil
+	_, ok := <-events
+	assert.False(t, ok, "после переподключения подписчики отключены, чтобы клиенты синхронизировались")
+
+	again, cancelAgain := bus.Subscribe(1)
+	defer cancelAgain()
+	notifications <- &pq.Notification{Channel: Channel, Extra: `{"user_id":1,"id":8}`}
+	assert.Equal(t, &entity.DataEvent{UserID: 1, ID: 8}, <-again)
+
+	close(notifications)
+	<-bus.done
+}
@@ -0,0 +1,34 @@
+package keyprovider
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// EnvPrefix - префикс переменных окружения с мастер-ключами.
+const EnvPrefix = "MASTER_KEY_"
+
+type envProvider struct {
+	lookup func(string) (string, bool)
+}
+
+// NewEnvProvider - источник ключей из переменных окружения MASTER_KEY_<id>.
+func NewEnvProvider() *envProvider {
+	return &envProvider{lookup: os.LookupEnv}
+}
+
+func (p *envProvider) Key(_ context.Context, keyID string) ([]byte, error) {
+	name := EnvPrefix + keyID
+	encoded, ok := p.lookup(name)
+	if !ok || encoded == "" {
+		return nil, fmt.Errorf("%w: переменная %s не задана", ErrKeyNotFound, name)
+	}
+
+	key, err := DecodeKey(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,85 @@
+package keyprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// maxKeyFileSize ограничивает размер читаемого файла: ключ в base64 занимает меньше 100 байт.
+const maxKeyFileSize = 4096
+
+type fileProvider struct {
+	dir string
+}
+
+// NewFileProvider - источник ключей из файлов <dir>/<id>.key.
+func NewFileProvider(dir string) *fileProvider {
+	return &fileProvider{dir: dir}
+}
+
+func (p *fileProvider) Key(_ context.Context, keyID string) ([]byte, error) {
+	path, err := keyPath(p.dir, keyID, ".key")
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadKeyFile(path)
+}
+
+// ReadKeyFile читает ключ из файла, доступного только владельцу.
+func ReadKeyFile(path string) ([]byte, error) {
+	raw, err := readSecretFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := DecodeKey(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return key, nil
+}
+
+func readSecretFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, path)
+		}
+
+		return nil, fmt.Errorf("не удалось прочитать файл ключа: %w", err)
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s не является обычным файлом", path)
+	}
+	if info.Size() > maxKeyFileSize {
+		return nil, fmt.Errorf("%s слишком большой для файла ключа", path)
+	}
+	// На Windows права POSIX не отражают реальный доступ к файлу.
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("файл ключа %s доступен не только владельцу (%04o), выполните chmod 600",
+			path, info.Mode().Perm())
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать файл ключа: %w", err)
+	}
+
+	return raw, nil
+}
+
+func keyPath(dir, keyID, ext string) (string, error) {
+	if keyID == "" || keyID != filepath.Base(keyID) || keyID == "." || keyID == ".." {
+		return "", fmt.Errorf("недопустимый идентификатор ключа %q", keyID)
+	}
+
+	return filepath.Join(dir, keyID+ext), nil
+}
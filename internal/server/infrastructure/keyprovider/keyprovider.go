@@ -0,0 +1,113 @@
+// Package keyprovider загружает мастер-ключи шифрования и ключи подписи токенов сервера из внешних источников.
+package keyprovider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// ProviderFlag - ключ передан флагом -crypto-key. Только для одной версии ключа.
+	ProviderFlag = "flag"
+	// ProviderFile - ключи лежат в файлах <dir>/<id>.key в hex или base64.
+	ProviderFile = "file"
+	// ProviderEnv - ключи лежат в переменных окружения MASTER_KEY_<id> в hex или base64.
+	ProviderEnv = "env"
+	// ProviderKMS - в файлах <dir>/<id>.enc лежат ключи, зашифрованные во внешнем KMS.
+	ProviderKMS = "kms"
+)
+
+var (
+	// ErrKeyNotFound - источник не знает ключа с таким идентификатором.
+	ErrKeyNotFound = errors.New("мастер-ключ не найден")
+	// ErrDefaultKey - попытка использовать ключ по умолчанию вне режима разработки.
+	ErrDefaultKey = errors.New("ключ шифрования по умолчанию допустим только в режиме разработки (-dev)")
+)
+
+// KeyProvider - источник версионированных мастер-ключей.
+type KeyProvider interface {
+	Key(ctx context.Context, keyID string) ([]byte, error)
+}
+
+type providerConfig interface {
+	GetKeyProvider() string
+	GetCryptoKey() string
+	GetCryptoKeyID() string
+	GetCryptoKeyDir() string
+	GetKMSRootKeyPath() string
+	IsDevMode() bool
+}
+
+// FromConfig создаёт источник ключей, выбранный в конфигурации.
+func FromConfig(cfg providerConfig, defaultKey string) (KeyProvider, error) {
+	switch cfg.GetKeyProvider() {
+	case ProviderFlag:
+		if cfg.GetCryptoKey() == defaultKey && !cfg.IsDevMode() {
+			return nil, ErrDefaultKey
+		}
+
+		return NewStaticProvider(cfg.GetCryptoKeyID(), []byte(cfg.GetCryptoKey())), nil
+	case ProviderFile:
+		return NewFileProvider(cfg.GetCryptoKeyDir()), nil
+	case ProviderEnv:
+		return NewEnvProvider(), nil
+	case ProviderKMS:
+		rootKey, err := ReadKeyFile(cfg.GetKMSRootKeyPath())
+		if err != nil {
+			return nil, fmt.Errorf("не удалось загрузить корневой ключ KMS: %w", err)
+		}
+
+		kms, err := NewLocalKMS(rootKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewKMSProvider(kms, cfg.GetCryptoKeyDir()), nil
+	default:
+		return nil, fmt.Errorf("неизвестный источник ключей %q", cfg.GetKeyProvider())
+	}
+}
+
+type staticProvider struct {
+	keyID string
+	key   []byte
+}
+
+// NewStaticProvider - источник из одного ключа, переданного в конфигурации.
+func NewStaticProvider(keyID string, key []byte) *staticProvider {
+	return &staticProvider{keyID: keyID, key: key}
+}
+
+func (p *staticProvider) Key(_ context.Context, keyID string) ([]byte, error) {
+	if keyID != p.keyID {
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, keyID)
+	}
+
+	return p.key, nil
+}
+
+// DecodeKey разбирает ключ в hex или base64 и проверяет его длину.
+func DecodeKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+
+	key, err := hex.DecodeString(encoded)
+	if err != nil {
+		key, err = base64.StdEncoding.DecodeString(encoded)
+	}
+	if err != nil {
+		key, err = base64.RawStdEncoding.DecodeString(encoded)
+	}
+	if err != nil {
+		return nil, errors.New("ключ должен быть записан в hex или base64")
+	}
+
+	if len(key) != 16 && len(key) != 24 && len(key) != 32 {
+		return nil, fmt.Errorf("ключ должен быть длиной 16, 24 или 32 байта, получено %d", len(key))
+	}
+
+	return key, nil
+}
@@ -0,0 +1,162 @@
+package keyprovider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testKey = []byte("01234567890123456789012345678901")
+
+type configStub struct {
+	provider   string
+	cryptoKey  string
+	keyDir     string
+	kmsRootKey string
+	devMode    bool
+}
+
+func (c configStub) GetKeyProvider() string    { return c.provider }
+func (c configStub) GetCryptoKey() string      { return c.cryptoKey }
+func (c configStub) GetCryptoKeyID() string    { return "1" }
+func (c configStub) GetCryptoKeyDir() string   { return c.keyDir }
+func (c configStub) GetKMSRootKeyPath() string { return c.kmsRootKey }
+func (c configStub) IsDevMode() bool           { return c.devMode }
+
+func writeKeyFile(t *testing.T, path, content string, perm os.FileMode) {
+	t.Helper()
+	assert.NoError(t, os.WriteFile(path, []byte(content), perm))
+	assert.NoError(t, os.Chmod(path, perm))
+}
+
+func TestDecodeKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		wantErr bool
+	}{
+		{name: "hex", encoded: hex.EncodeToString(testKey)},
+		{name: "base64", encoded: base64.StdEncoding.EncodeToString(testKey)},
+		{name: "base64 с переводом строки", encoded: base64.StdEncoding.EncodeToString(testKey) + "\n"},
+		{name: "неверная длина", encoded: hex.EncodeToString(testKey[:10]), wantErr: true},
+		{name: "не hex и не base64", encoded: "ключ!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := DecodeKey(tt.encoded)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testKey, key)
+		})
+	}
+}
+
+func TestFileProvider_Key(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeKeyFile(t, filepath.Join(dir, "1.key"), hex.EncodeToString(testKey), 0o600)
+
+	provider := NewFileProvider(dir)
+
+	key, err := provider.Key(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, testKey, key)
+
+	_, err = provider.Key(ctx, "2")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = provider.Key(ctx, "../1")
+	assert.Error(t, err)
+}
+
+func TestFileProvider_Key_Permissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("права POSIX не проверяются на Windows")
+	}
+
+	dir := t.TempDir()
+	writeKeyFile(t, filepath.Join(dir, "1.key"), hex.EncodeToString(testKey), 0o644)
+
+	_, err := NewFileProvider(dir).Key(context.Background(), "1")
+	assert.ErrorContains(t, err, "chmod 600")
+}
+
+func TestEnvProvider_Key(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(EnvPrefix+"1", base64.StdEncoding.EncodeToString(testKey))
+
+	provider := NewEnvProvider()
+
+	key, err := provider.Key(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, testKey, key)
+
+	_, err = provider.Key(ctx, "2")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestKMSProvider_Key(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	kms, err := NewLocalKMS([]byte("10987654321098765432109876543210"))
+	assert.NoError(t, err)
+	ciphertext, err := kms.Encrypt(ctx, "1", testKey)
+	assert.NoError(t, err)
+	writeKeyFile(t, filepath.Join(dir, "1.enc"), base64.StdEncoding.EncodeToString(ciphertext), 0o600)
+	writeKeyFile(t, filepath.Join(dir, "2.enc"), base64.StdEncoding.EncodeToString(ciphertext), 0o600)
+
+	provider := NewKMSProvider(kms, dir)
+
+	key, err := provider.Key(ctx, "1")
+	assert.NoError(t, err)
+	assert.Equal(t, testKey, key)
+
+	_, err = provider.Key(ctx, "2")
+	assert.Error(t, err, "шифротекст привязан к идентификатору ключа")
+}
+
+func TestFromConfig(t *testing.T) {
+	const defaultKey = "01234567890123456789012345678901"
+
+	t.Run("ключ по умолчанию вне режима разработки", func(t *testing.T) {
+		_, err := FromConfig(configStub{provider: ProviderFlag, cryptoKey: defaultKey}, defaultKey)
+		assert.ErrorIs(t, err, ErrDefaultKey)
+	})
+
+	t.Run("ключ по умолчанию в режиме разработки", func(t *testing.T) {
+		provider, err := FromConfig(configStub{provider: ProviderFlag, cryptoKey: defaultKey, devMode: true}, defaultKey)
+		assert.NoError(t, err)
+
+		key, err := provider.Key(context.Background(), "1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(defaultKey), key)
+	})
+
+	t.Run("файловый источник", func(t *testing.T) {
+		provider, err := FromConfig(configStub{provider: ProviderFile, keyDir: t.TempDir()}, defaultKey)
+		assert.NoError(t, err)
+		assert.IsType(t, &fileProvider{}, provider)
+	})
+
+	t.Run("KMS без корневого ключа", func(t *testing.T) {
+		_, err := FromConfig(configStub{provider: ProviderKMS, kmsRootKey: filepath.Join(t.TempDir(), "root.key")}, defaultKey)
+		assert.Error(t, err)
+	})
+
+	t.Run("неизвестный источник", func(t *testing.T) {
+		_, err := FromConfig(configStub{provider: "vault"}, defaultKey)
+		assert.Error(t, err)
+	})
+}
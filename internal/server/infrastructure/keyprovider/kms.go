@@ -0,0 +1,104 @@
+package keyprovider
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// KMS - внешний сервис управления ключами. Сервер никогда не видит ключ KMS,
+// а только просит расшифровать свои мастер-ключи при запуске.
+type KMS interface {
+	Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+}
+
+type kmsProvider struct {
+	kms KMS
+	dir string
+}
+
+// NewKMSProvider - источник ключей, зашифрованных в KMS и хранящихся в файлах <dir>/<id>.enc.
+func NewKMSProvider(kms KMS, dir string) *kmsProvider {
+	return &kmsProvider{kms: kms, dir: dir}
+}
+
+func (p *kmsProvider) Key(ctx context.Context, keyID string) ([]byte, error) {
+	path, err := keyPath(p.dir, keyID, ".enc")
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := readSecretFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: зашифрованный ключ должен быть записан в base64", path)
+	}
+
+	key, err := p.kms.Decrypt(ctx, keyID, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("KMS не смог расшифровать ключ %q: %w", keyID, err)
+	}
+
+	if len(key) != 16 && len(key) != 24 && len(key) != 32 {
+		return nil, fmt.Errorf("KMS вернул ключ %q недопустимой длины %d", keyID, len(key))
+	}
+
+	return key, nil
+}
+
+// LocalKMS - локальная замена внешнего KMS для разработки и тестов.
+// Корневой ключ хранится в файле, идентификатор мастер-ключа привязывается к шифротексту.
+type LocalKMS struct {
+	aead cipher.AEAD
+}
+
+// NewLocalKMS - конструктор локального KMS.
+func NewLocalKMS(rootKey []byte) (*LocalKMS, error) {
+	block, err := aes.NewCipher(rootKey)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания шифра KMS: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания GCM KMS: %w", err)
+	}
+
+	return &LocalKMS{aead: aead}, nil
+}
+
+// Encrypt шифрует мастер-ключ корневым ключом KMS.
+func (k *LocalKMS) Encrypt(_ context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("ошибка генерации nonce: %w", err)
+	}
+
+	return k.aead.Seal(nonce, nonce, plaintext, []byte(keyID)), nil
+}
+
+// Decrypt расшифровывает мастер-ключ корневым ключом KMS.
+func (k *LocalKMS) Decrypt(_ context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	nonceSize := k.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, errors.New("шифротекст слишком короткий")
+	}
+
+	nonce, body := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	plaintext, err := k.aead.Open(nil, nonce, body, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расшифровки: %w", err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,118 @@
+package keyprovider
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+)
+
+const (
+	// SigningKeyExt - приватный ключ подписи токенов в PEM: <dir>/<kid>.pem.
+	SigningKeyExt = ".pem"
+	// VerificationKeyExt - открытый ключ, оставленный для проверки токенов после ротации: <dir>/<kid>.pub.pem.
+	VerificationKeyExt = ".pub.pem"
+)
+
+// LoadSigningKeys читает ключи подписи токенов из каталога. Идентификатор ключа (kid) - имя файла
+// без расширения. Если для одного kid есть и приватный, и открытый ключ, используется приватный.
+func LoadSigningKeys(dir string) ([]*entity.SigningKey, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать каталог ключей подписи: %w", err)
+	}
+
+	keys := make(map[string]*entity.SigningKey)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, SigningKeyExt) {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		if kid, ok := strings.CutSuffix(name, VerificationKeyExt); ok {
+			if _, exists := keys[kid]; exists {
+				continue
+			}
+			public, err := readPublicKey(path)
+			if err != nil {
+				return nil, err
+			}
+			keys[kid] = &entity.SigningKey{ID: kid, Public: public}
+
+			continue
+		}
+
+		kid := strings.TrimSuffix(name, SigningKeyExt)
+		private, err := readPrivateKey(path)
+		if err != nil {
+			return nil, err
+		}
+		keys[kid] = &entity.SigningKey{ID: kid, Private: private, Public: private.Public()}
+	}
+
+	result := make([]*entity.SigningKey, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, key)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+
+	return result, nil
+}
+
+func readPrivateKey(path string) (crypto.Signer, error) {
+	raw, err := readSecretFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("%s: ожидается ключ в формате PEM", path)
+	}
+
+	var key any
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		err = fmt.Errorf("неподдерживаемый тип PEM блока %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: ключ не поддерживает подпись", path)
+	}
+
+	return signer, nil
+}
+
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать открытый ключ: %w", err)
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("%s: ожидается открытый ключ PEM \"PUBLIC KEY\"", path)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,80 @@
+package keyprovider
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pemBlock(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	return string(pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}))
+}
+
+func TestLoadSigningKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	activePublic, activePrivate, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	privateDER, err := x509.MarshalPKCS8PrivateKey(activePrivate)
+	assert.NoError(t, err)
+	writeKeyFile(t, filepath.Join(dir, "2"+SigningKeyExt), pemBlock(t, "PRIVATE KEY", privateDER), 0o600)
+	// Открытая часть рядом с приватной не должна заменять её.
+	activePublicDER, err := x509.MarshalPKIXPublicKey(activePublic)
+	assert.NoError(t, err)
+	writeKeyFile(t, filepath.Join(dir, "2"+VerificationKeyExt), pemBlock(t, "PUBLIC KEY", activePublicDER), 0o644)
+
+	oldPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	oldPublicDER, err := x509.MarshalPKIXPublicKey(oldPublic)
+	assert.NoError(t, err)
+	writeKeyFile(t, filepath.Join(dir, "1"+VerificationKeyExt), pemBlock(t, "PUBLIC KEY", oldPublicDER), 0o644)
+	writeKeyFile(t, filepath.Join(dir, "README"), "не ключ", 0o644)
+
+	keys, err := LoadSigningKeys(dir)
+	assert.NoError(t, err)
+	if assert.Len(t, keys, 2) {
+		assert.Equal(t, "1", keys[0].ID)
+		assert.Nil(t, keys[0].Private)
+		assert.Equal(t, oldPublic, keys[0].Public)
+
+		assert.Equal(t, "2", keys[1].ID)
+		assert.NotNil(t, keys[1].Private)
+		assert.Equal(t, activePublic, keys[1].Public)
+	}
+}
+
+func TestLoadSigningKeys_Errors(t *testing.T) {
+	t.Run("Каталог не существует", func(t *testing.T) {
+		_, err := LoadSigningKeys(filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+	})
+
+	t.Run("Не PEM", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKeyFile(t, filepath.Join(dir, "1"+SigningKeyExt), "abc", 0o600)
+		_, err := LoadSigningKeys(dir)
+		assert.ErrorContains(t, err, "PEM")
+	})
+
+	t.Run("Приватный ключ доступен всем", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("права POSIX не проверяются на Windows")
+		}
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(private)
+		assert.NoError(t, err)
+
+		dir := t.TempDir()
+		writeKeyFile(t, filepath.Join(dir, "1"+SigningKeyExt), pemBlock(t, "PRIVATE KEY", der), 0o644)
+		_, err = LoadSigningKeys(dir)
+		assert.ErrorContains(t, err, "chmod 600")
+	})
+}
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+)
+
+type binaryRepository struct {
+	db     dataStorager
+	logger logger.CustomLogger
+}
+
+// NewBinaryRepository - конструктор репозитория загрузок бинарных файлов по частям.
+func NewBinaryRepository(db dataStorager, logger logger.CustomLogger) *binaryRepository {
+	return &binaryRepository{db: db, logger: logger}
+}
+
+const uploadColumns = `id, user_id, COALESCE(data_id, 0), replace_id, info, meta, search_tokens, blob_ref, size,
+    chunk_count, received`
+
+func scanUpload(row *sql.Row) (*entity.Upload, error) {
+	upload := &entity.Upload{}
+	err := row.Scan(
+		&upload.ID, &upload.UserID, &upload.DataID, &upload.ReplaceID,
+		&upload.Info, &upload.Meta, &upload.SearchTokens, &upload.BlobRef, &upload.Size, &upload.ChunkCount,
+		&upload.Received,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, helper.ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("ошибка чтения загрузки: %w", err)
+	}
+
+	return upload, nil
+}
+
+func (r *binaryRepository) CreateUpload(ctx context.Context, upload *entity.Upload) error {
+	query := `
+        INSERT INTO binary_uploads (id, user_id, replace_id, info, meta, search_tokens, blob_ref, chunk_count)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	_, err := r.db.ExecContext(
+		ctx, query,
+		upload.ID, upload.UserID, upload.ReplaceID, upload.Info, upload.Meta, upload.SearchTokens, upload.BlobRef,
+		upload.ChunkCount,
+	)
+	if err != nil {
+		return fmt.Errorf("ошибка создания загрузки: %w", err)
+	}
+
+	return nil
+}
+
+// Upload возвращает загрузку пользователя или helper.ErrUploadNotFound.
+func (r *binaryRepository) Upload(ctx context.Context, userID int, uploadID string) (*entity.Upload, error) {
+	query := `SELECT ` + uploadColumns + ` FROM binary_uploads WHERE id = $1 AND user_id = $2`
+
+	return scanUpload(r.db.QueryRowContext(ctx, query, uploadID, userID))
+}
+
+// UploadByData возвращает завершённую загрузку, содержимое которой хранит запись dataID. Если запись
+// в корзине, возвращает helper.ErrUploadNotFound, как и для удалённой.
+func (r *binaryRepository) UploadByData(ctx context.Context, userID, dataID int) (*entity.Upload, error) {
+	query := `
+        SELECT ` + uploadColumns + ` FROM binary_uploads
+        WHERE data_id = $1 AND user_id = $2 AND EXISTS (
+            SELECT 1 FROM user_data WHERE user_data.id = binary_uploads.data_id AND user_data.deleted_at IS NULL
+        )
+    `
+
+	return scanUpload(r.db.QueryRowContext(ctx, query, dataID, userID))
+}
+
+// SaveChunk отмечает часть seq принятой, только если она следующая по порядку. Иначе возвращает
+// helper.ErrChunkOutOfOrder, так что повтор части после переподключения не задвоит её.
+// Содержимое части хранится в хранилище файлов, здесь - только её размер и SHA-256.
+func (r *binaryRepository) SaveChunk(ctx context.Context, uploadID string, seq, size int, sha256 string) error {
+	query := `
+        WITH advanced AS (
+            UPDATE binary_uploads SET received = received + 1, size = size + $3
+            WHERE id = $1 AND received = $2 AND data_id IS NULL
+            RETURNING id
+        )
+        INSERT INTO binary_chunks (upload_id, seq, size, sha256)
+        SELECT id, $2, $3, $4 FROM advanced
+    `
+	res, err := r.db.ExecContext(ctx, query, uploadID, seq, size, sha256)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения части файла: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения числа обновлённых строк: %w", err)
+	}
+	if affected == 0 {
+		return helper.ErrChunkOutOfOrder
+	}
+
+	return nil
+}
+
+// InlineChunk возвращает содержимое части, сохранённой в базе до появления хранилища файлов,
+// или пустую строку, если часть лежит в хранилище.
+func (r *binaryRepository) InlineChunk(ctx context.Context, uploadID string, seq int) (string, error) {
+	query := `SELECT COALESCE(data, '') FROM binary_chunks WHERE upload_id = $1 AND seq = $2`
+
+	var data string
+	if err := r.db.QueryRowContext(ctx, query, uploadID, seq).Scan(&data); err != nil {
+		return "", fmt.Errorf("ошибка чтения части файла %d: %w", seq, err)
+	}
+
+	return data, nil
+}
+
+// ChunkHashes возвращает SHA-256 частей загрузки по порядку.
+func (r *binaryRepository) ChunkHashes(ctx context.Context, uploadID string) ([]string, error) {
+	query := `SELECT sha256 FROM binary_chunks WHERE upload_id = $1 ORDER BY seq`
+
+	rows, err := r.db.QueryContext(ctx, query, uploadID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса к базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err = rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("ошибка чтения хеша части файла: %w", err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// SetReplaceID запоминает в незавершённой загрузке запись, которую она заполняет.
+func (r *binaryRepository) SetReplaceID(ctx context.Context, uploadID string, dataID int) error {
+	query := `UPDATE binary_uploads SET replace_id = $2 WHERE id = $1 AND data_id IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, uploadID, dataID); err != nil {
+		return fmt.Errorf("ошибка сохранения записи загрузки: %w", err)
+	}
+
+	return nil
+}
+
+// AttachData связывает завершённую загрузку с записью и сохраняет в записи ссылку на файл,
+// его размер и хеш. Прежняя загрузка записи удаляется, её части попадают в blob_garbage.
+func (r *binaryRepository) AttachData(ctx context.Context, upload *entity.Upload, dataID int, sha256 string) error {
+	query := `
+        WITH replaced AS (
+            DELETE FROM binary_uploads WHERE data_id = $2 AND id <> $1
+        ), attached AS (
+            UPDATE binary_uploads SET data_id = $2, info = '', meta = '', search_tokens = ''
+            WHERE id = $1
+        )
+        UPDATE user_data SET blob_ref = $3, blob_size = $4, blob_sha256 = $5
+        WHERE id = $2
+    `
+	if _, err := r.db.ExecContext(ctx, query, upload.ID, dataID, upload.BlobRef, upload.Size, sha256); err != nil {
+		return fmt.Errorf("ошибка привязки загрузки к записи: %w", err)
+	}
+
+	return nil
+}
+
+// ExpireUploads удаляет незавершённые загрузки, начатые раньше before.
+func (r *binaryRepository) ExpireUploads(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM binary_uploads WHERE data_id IS NULL AND created_at < $1`
+
+	res, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка удаления брошенных загрузок: %w", err)
+	}
+
+	expired, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения числа удалённых строк: %w", err)
+	}
+
+	return expired, nil
+}
+
+// Garbage возвращает не больше limit наборов частей, которые нужно удалить из хранилища файлов.
+func (r *binaryRepository) Garbage(ctx context.Context, limit int) ([]*entity.BlobGarbage, error) {
+	query := `SELECT id, blob_ref, chunks FROM blob_garbage ORDER BY id LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса к базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var garbage []*entity.BlobGarbage
+	for rows.Next() {
+		item := &entity.BlobGarbage{}
+		if err = rows.Scan(&item.ID, &item.Ref, &item.Chunks); err != nil {
+			return nil, fmt.Errorf("ошибка чтения мусора хранилища: %w", err)
+		}
+		garbage = append(garbage, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return garbage, nil
+}
+
+// ForgetGarbage отмечает части удалёнными из хранилища файлов.
+func (r *binaryRepository) ForgetGarbage(ctx context.Context, id int) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM blob_garbage WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("ошибка удаления записи о мусоре хранилища: %w", err)
+	}
+
+	return nil
+}
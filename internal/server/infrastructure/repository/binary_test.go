@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+var uploadRows = []string{
+	"id", "user_id", "data_id", "replace_id", "info", "meta", "search_tokens", "blob_ref", "size", "chunk_count",
+	"received",
+}
+
+func TestBinary_CreateAndReadUpload(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBinaryRepository(db, new(mockLogger))
+	ctx := context.Background()
+
+	mock.ExpectExec("INSERT INTO binary_uploads").
+		WithArgs("up1", 7, 0, "info", "meta", "tokens", "7/up1", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	err = repo.CreateUpload(ctx, &entity.Upload{
+		ID: "up1", UserID: 7, Info: "info", Meta: "meta", SearchTokens: "tokens", BlobRef: "7/up1", ChunkCount: 3,
+	})
+	assert.NoError(t, err)
+
+	mock.ExpectQuery("SELECT (.+) FROM binary_uploads WHERE id = \\$1 AND user_id = \\$2").
+		WithArgs("up1", 7).
+		WillReturnRows(sqlmock.NewRows(uploadRows).AddRow("up1", 7, 0, 0, "info", "meta", "tokens", "7/up1", 10, 3, 1))
+	upload, err := repo.Upload(ctx, 7, "up1")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Upload{
+		ID: "up1", UserID: 7, Info: "info", Meta: "meta", SearchTokens: "tokens", BlobRef: "7/up1", Size: 10,
+		ChunkCount: 3, Received: 1,
+	}, upload)
+
+	mock.ExpectQuery("SELECT (.+) FROM binary_uploads WHERE data_id = \\$1 AND user_id = \\$2").
+		WithArgs(5, 7).
+		WillReturnRows(sqlmock.NewRows(uploadRows).AddRow("up1", 7, 5, 0, "info", "meta", "tokens", "7/up1", 10, 3, 3))
+	upload, err = repo.UploadByData(ctx, 7, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, upload.DataID)
+
+	// Запись в корзине: подзапрос к user_data ничего не находит, и загрузки как будто нет.
+	mock.ExpectQuery("SELECT (.+) FROM binary_uploads(.+)WHERE data_id = \\$1 AND user_id = \\$2 AND EXISTS "+
+		"(.+)user_data.deleted_at IS NULL").
+		WithArgs(5, 7).
+		WillReturnRows(sqlmock.NewRows(uploadRows))
+	_, err = repo.UploadByData(ctx, 7, 5)
+	assert.ErrorIs(t, err, helper.ErrUploadNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBinary_SaveChunk(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBinaryRepository(db, new(mockLogger))
+	ctx := context.Background()
+
+	mock.ExpectExec("UPDATE binary_uploads SET received = received \\+ 1, size = size \\+ \\$3"+
+		"(.+)INSERT INTO binary_chunks").
+		WithArgs("up1", 0, 5, "abc").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.SaveChunk(ctx, "up1", 0, 5, "abc"))
+
+	mock.ExpectExec("UPDATE binary_uploads SET received = received \\+ 1(.+)INSERT INTO binary_chunks").
+		WithArgs("up1", 0, 5, "abc").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.SaveChunk(ctx, "up1", 0, 5, "abc"), helper.ErrChunkOutOfOrder)
+
+	mock.ExpectQuery("SELECT COALESCE\\(data, ''\\) FROM binary_chunks").
+		WithArgs("up1", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"data"}).AddRow("chunk"))
+	data, err := repo.InlineChunk(ctx, "up1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "chunk", data)
+
+	mock.ExpectQuery("SELECT sha256 FROM binary_chunks WHERE upload_id = \\$1 ORDER BY seq").
+		WithArgs("up1").
+		WillReturnRows(sqlmock.NewRows([]string{"sha256"}).AddRow("aa").AddRow("bb"))
+	hashes, err := repo.ChunkHashes(ctx, "up1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"aa", "bb"}, hashes)
+
+	mock.ExpectExec("UPDATE binary_uploads SET replace_id = \\$2 WHERE id = \\$1 AND data_id IS NULL").
+		WithArgs("up1", 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.SetReplaceID(ctx, "up1", 5))
+
+	mock.ExpectExec("DELETE FROM binary_uploads WHERE data_id = \\$2(.+)UPDATE binary_uploads SET data_id = \\$2"+
+		"(.+)UPDATE user_data SET blob_ref = \\$3, blob_size = \\$4, blob_sha256 = \\$5").
+		WithArgs("up1", 5, "7/up1", int64(10), "ff").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.AttachData(ctx, &entity.Upload{ID: "up1", BlobRef: "7/up1", Size: 10}, 5, "ff"))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBinary_Garbage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewBinaryRepository(db, new(mockLogger))
+	ctx := context.Background()
+	before := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("DELETE FROM binary_uploads WHERE data_id IS NULL AND created_at < \\$1").
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	expired, err := repo.ExpireUploads(ctx, before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), expired)
+
+	mock.ExpectQuery("SELECT id, blob_ref, chunks FROM blob_garbage ORDER BY id LIMIT \\$1").
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "blob_ref", "chunks"}).AddRow(1, "7/up1", 3))
+	garbage, err := repo.Garbage(ctx, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.BlobGarbage{{ID: 1, Ref: "7/up1", Chunks: 3}}, garbage)
+
+	mock.ExpectExec("DELETE FROM blob_garbage WHERE id = \\$1").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.ForgetGarbage(ctx, 1))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
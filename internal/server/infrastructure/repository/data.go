@@ -3,10 +3,16 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
 	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"github.com/lib/pq"
 )
 
 type dataStorager interface {
@@ -25,14 +31,40 @@ func NewDataRepository(db dataStorager, logger logger.CustomLogger) *dataReposit
 	return &dataRepository{db: db, logger: logger}
 }
 
+// nextRevision увеличивает ревизию данных пользователя. Блокировка строки users держится до конца
+// запроса, поэтому изменения одного пользователя фиксируются строго в порядке их ревизий.
+const nextRevision = `
+        UPDATE users SET data_revision = data_revision + 1
+        WHERE id = $1
+        RETURNING data_revision
+`
+
+// organizeColumns - папка записи и её теги в порядке возрастания ID.
+const organizeColumns = `COALESCE(folder_id, 0),
+            ARRAY(SELECT tag_id FROM data_tags WHERE data_tags.data_id = user_data.id ORDER BY tag_id)`
+
+// previousColumns - колонки записи, которые сохраняются в её прежней версии.
+const previousColumns = `user_data.id, user_data.user_id, user_data.revision, user_data.info_type, user_data.info,
+                user_data.meta, user_data.search_tokens, user_data.blob_ref, user_data.updated_at`
+
+// archivePrevious сохраняет строки CTE previous, выбранные из user_data по previousColumns,
+// как прежние версии записей.
+const archivePrevious = `INSERT INTO user_data_versions
+            (data_id, user_id, revision, info_type, info, meta, search_tokens, blob_ref, updated_at)
+        SELECT id, user_id, revision, info_type, info, meta, search_tokens, blob_ref, updated_at
+        FROM previous`
+
 func (r *dataRepository) AddData(ctx context.Context, data *entity.UserData) (int, error) {
 	query := `
-        INSERT INTO user_data (user_id, info_type, info, meta, created)
-        VALUES ($1, $2, $3, $4, NOW())
+        WITH rev AS (` + nextRevision + `)
+        INSERT INTO user_data (user_id, info_type, info, meta, created, revision, search_tokens)
+        SELECT $1, $2, $3, $4, NOW(), rev.data_revision, $5 FROM rev
         RETURNING id
     `
 	var id int
-	err := r.db.QueryRowContext(ctx, query, data.UserID, data.InfoType, data.Info, data.Meta).Scan(&id)
+	err := r.db.QueryRowContext(
+		ctx, query, data.UserID, data.InfoType, data.Info, data.Meta, searchTokens(data.SearchTokens),
+	).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -41,55 +73,568 @@ func (r *dataRepository) AddData(ctx context.Context, data *entity.UserData) (in
 
 func (r *dataRepository) GetDataByID(ctx context.Context, userID, dataID int) (*entity.UserData, error) {
 	query := `
-        SELECT id, user_id, info_type, info, meta, created
+        SELECT id, user_id, info_type, info, meta, created, updated_at, revision, ` + organizeColumns + `
         FROM user_data
-        WHERE id = $1 AND user_id = $2
+        WHERE id = $1 AND deleted_at IS NULL
     `
-	row := r.db.QueryRowContext(ctx, query, dataID, userID)
+	row := r.db.QueryRowContext(ctx, query, dataID)
 	data := &entity.UserData{}
-	err := row.Scan(&data.ID, &data.UserID, &data.InfoType, &data.Info, &data.Meta, &data.Created)
+	err := row.Scan(
+		&data.ID, &data.UserID, &data.InfoType, &data.Info, &data.Meta, &data.Created, &data.Updated, &data.Revision,
+		&data.FolderID, (*pq.Int64Array)(&data.TagIDs),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, helper.ErrDataNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
+	if data.UserID != userID {
+		return nil, helper.ErrDataForbidden
+	}
 	return data, nil
 }
 
-func (r *dataRepository) UpdateData(ctx context.Context, data *entity.UserData) error {
+// UpdateData обновляет запись и сохраняет её прежнее содержимое в user_data_versions.
+// Если expectedRevision не 0, запись обновится, только пока её ревизия совпадает с ожидаемой,
+// иначе вернётся helper.ErrVersionConflict. Для отсутствующей записи возвращается helper.ErrDataNotFound,
+// для записи другого пользователя - helper.ErrDataForbidden.
+func (r *dataRepository) UpdateData(ctx context.Context, data *entity.UserData, expectedRevision int64) error {
 	query := `
-        UPDATE user_data
-        SET info_type = $1, info = $2, meta = $3
-        WHERE id = $4 AND user_id = $5
+        WITH rev AS (` + nextRevision + `), previous AS (
+            SELECT ` + previousColumns + `
+            FROM user_data, rev
+            WHERE user_data.id = $5 AND user_data.user_id = $1 AND user_data.deleted_at IS NULL
+                AND ($6 = 0 OR user_data.revision = $6)
+            FOR UPDATE OF user_data
+        ), updated AS (
+            UPDATE user_data
+            SET info_type = $2, info = $3, meta = $4, revision = rev.data_revision, updated_at = NOW(),
+                search_tokens = $7
+            FROM rev
+            WHERE user_data.id IN (SELECT id FROM previous)
+            RETURNING user_data.id
+        ), archived AS (
+            ` + archivePrevious + `
+            WHERE id IN (SELECT id FROM updated)
+        )
+        SELECT ` + fmt.Sprintf(dataOwner, "$5") + `, EXISTS (SELECT 1 FROM updated)
     `
-	_, err := r.db.ExecContext(ctx, query, data.InfoType, data.Info, data.Meta, data.ID, data.UserID)
-	return err
+	var owner sql.NullInt64
+	var updated bool
+	err := r.db.QueryRowContext(
+		ctx, query, data.UserID, data.InfoType, data.Info, data.Meta, data.ID, expectedRevision,
+		searchTokens(data.SearchTokens),
+	).Scan(&owner, &updated)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(owner, data.UserID); err != nil {
+		return err
+	}
+	if !updated {
+		return helper.ErrVersionConflict
+	}
+
+	return nil
 }
 
+// DeleteData переносит запись в корзину. Для синхронизации запись в корзине выглядит удалённой.
+// Для отсутствующей записи возвращается helper.ErrDataNotFound, для записи другого пользователя -
+// helper.ErrDataForbidden.
 func (r *dataRepository) DeleteData(ctx context.Context, userID, dataID int) error {
 	query := `
-        DELETE FROM user_data
-        WHERE id = $1 AND user_id = $2
+        WITH rev AS (` + nextRevision + `), deleted AS (
+            UPDATE user_data
+            SET deleted_at = NOW(), revision = rev.data_revision
+            FROM rev
+            WHERE id = $2 AND user_id = $1 AND deleted_at IS NULL
+            RETURNING user_data.id
+        )
+        SELECT ` + fmt.Sprintf(dataOwner, "$2") + `, EXISTS (SELECT 1 FROM deleted)
     `
-	_, err := r.db.ExecContext(ctx, query, dataID, userID)
-	return err
+	var owner sql.NullInt64
+	var deleted bool
+	if err := r.db.QueryRowContext(ctx, query, userID, dataID).Scan(&owner, &deleted); err != nil {
+		return err
+	}
+	if err := checkOwner(owner, userID); err != nil {
+		return err
+	}
+	if !deleted {
+		return helper.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// dataOwner - владелец записи не из корзины с ID из параметра запроса, NULL, если такой записи нет.
+// Запрос видит строку такой, какой она была до изменений в его CTE.
+const dataOwner = `(SELECT user_id FROM user_data WHERE id = %s AND deleted_at IS NULL)`
+
+// checkOwner сверяет владельца записи, выбранного по dataOwner, с пользователем запроса.
+func checkOwner(owner sql.NullInt64, userID int) error {
+	if !owner.Valid {
+		return helper.ErrDataNotFound
+	}
+	if owner.Int64 != int64(userID) {
+		return helper.ErrDataForbidden
+	}
+	return nil
 }
 
-func (r *dataRepository) ListData(ctx context.Context, userID int, infoType string) ([]*entity.UserData, error) {
+// ListTrash возвращает записи пользователя в корзине без Info, начиная с удалённых последними.
+func (r *dataRepository) ListTrash(ctx context.Context, userID int) ([]*entity.UserData, error) {
+	query := `
+        SELECT id, user_id, info_type, meta, created, updated_at, revision, ` + organizeColumns + `, deleted_at
+        FROM user_data
+        WHERE user_id = $1 AND deleted_at IS NOT NULL
+        ORDER BY deleted_at DESC, id DESC
+    `
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса к базе данных: %w", err)
+	}
+	defer rows.Close()
+
 	var dataItems []*entity.UserData
+	for rows.Next() {
+		var data entity.UserData
+		err := rows.Scan(
+			&data.ID, &data.UserID, &data.InfoType, &data.Meta, &data.Created, &data.Updated, &data.Revision,
+			&data.FolderID, (*pq.Int64Array)(&data.TagIDs), &data.Deleted,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения данных из базы данных: %w", err)
+		}
+		dataItems = append(dataItems, &data)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return dataItems, nil
+}
+
+// RestoreData возвращает запись из корзины и возвращает её тип. Ревизия записи увеличивается,
+// чтобы клиенты получили её при синхронизации.
+func (r *dataRepository) RestoreData(ctx context.Context, userID, dataID int) (string, error) {
+	query := `
+        WITH rev AS (` + nextRevision + `)
+        UPDATE user_data
+        SET deleted_at = NULL, revision = rev.data_revision
+        FROM rev
+        WHERE id = $2 AND user_id = $1 AND deleted_at IS NOT NULL
+        RETURNING info_type
+    `
+	var infoType string
+	err := r.db.QueryRowContext(ctx, query, userID, dataID).Scan(&infoType)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", helper.ErrNotInTrash
+	}
+	if err != nil {
+		return "", fmt.Errorf("ошибка восстановления записи из корзины: %w", err)
+	}
 
-	query := `SELECT id, user_id, info_type, info, meta, created FROM user_data WHERE user_id = $1`
-	args := []interface{}{userID}
+	return infoType, nil
+}
 
-	if infoType != "" {
-		query += ` AND info_type = $2`
-		args = append(args, infoType)
+// purgeTrashed удаляет строки user_data, выбранные условием, и оставляет вместо них метки удаления
+// с ревизией переноса в корзину: клиенты, уже получившие эту ревизию, видели запись удалённой.
+const purgeTrashed = `
+        WITH purged AS (
+            DELETE FROM user_data
+            WHERE deleted_at IS NOT NULL AND %s
+            RETURNING id, user_id, revision
+        )
+        INSERT INTO user_data_tombstones (data_id, user_id, revision)
+        SELECT id, user_id, revision FROM purged
+        RETURNING data_id, user_id
+    `
+
+// PurgeData удаляет запись из корзины навсегда.
+func (r *dataRepository) PurgeData(ctx context.Context, userID, dataID int) error {
+	res, err := r.db.ExecContext(ctx, fmt.Sprintf(purgeTrashed, "id = $2 AND user_id = $1"), userID, dataID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления записи из корзины: %w", err)
 	}
 
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения числа удалённых строк: %w", err)
+	}
+	if affected == 0 {
+		return helper.ErrNotInTrash
+	}
+
+	return nil
+}
+
+// EmptyTrash удаляет навсегда все записи пользователя в корзине и возвращает их идентификаторы.
+func (r *dataRepository) EmptyTrash(ctx context.Context, userID int) ([]int, error) {
+	purged, err := r.purge(ctx, fmt.Sprintf(purgeTrashed, "user_id = $1"), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(purged))
+	for _, data := range purged {
+		ids = append(ids, data.ID)
+	}
+
+	return ids, nil
+}
+
+// PurgeTrash удаляет навсегда записи всех пользователей, перенесённые в корзину раньше before.
+// Возвращает удалённые записи, в которых заполнены только ID и UserID.
+func (r *dataRepository) PurgeTrash(ctx context.Context, before time.Time) ([]*entity.UserData, error) {
+	return r.purge(ctx, fmt.Sprintf(purgeTrashed, "deleted_at < $1"), before)
+}
+
+func (r *dataRepository) purge(ctx context.Context, query string, args ...any) ([]*entity.UserData, error) {
 	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка очистки корзины: %w", err)
+	}
+	defer rows.Close()
+
+	var purged []*entity.UserData
+	for rows.Next() {
+		var data entity.UserData
+		if err = rows.Scan(&data.ID, &data.UserID); err != nil {
+			return nil, fmt.Errorf("ошибка чтения данных из базы данных: %w", err)
+		}
+		purged = append(purged, &data)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return purged, nil
+}
+
+// ListVersions возвращает прежние версии записи без Info, от новых к старым.
+func (r *dataRepository) ListVersions(ctx context.Context, userID, dataID int) ([]*entity.UserData, error) {
+	query := `
+        SELECT v.data_id, v.user_id, v.info_type, v.meta, d.created, v.updated_at, v.revision
+        FROM user_data_versions v
+        JOIN user_data d ON d.id = v.data_id
+        WHERE v.user_id = $1 AND v.data_id = $2
+        ORDER BY v.revision DESC
+    `
+	rows, err := r.db.QueryContext(ctx, query, userID, dataID)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка выполнения запроса к базе данных: %w", err)
 	}
 	defer rows.Close()
 
+	var versions []*entity.UserData
+	for rows.Next() {
+		var version entity.UserData
+		err := rows.Scan(
+			&version.ID, &version.UserID, &version.InfoType, &version.Meta, &version.Created, &version.Updated,
+			&version.Revision,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения данных из базы данных: %w", err)
+		}
+		versions = append(versions, &version)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return versions, nil
+}
+
+// GetVersion возвращает прежнюю версию записи, сохранённую на ревизии revision.
+func (r *dataRepository) GetVersion(ctx context.Context, userID, dataID int, revision int64) (*entity.UserData, error) {
+	query := `
+        SELECT v.data_id, v.user_id, v.info_type, v.info, v.meta, d.created, v.updated_at, v.revision
+        FROM user_data_versions v
+        JOIN user_data d ON d.id = v.data_id
+        WHERE v.user_id = $1 AND v.data_id = $2 AND v.revision = $3
+    `
+	version := &entity.UserData{}
+	err := r.db.QueryRowContext(ctx, query, userID, dataID, revision).Scan(
+		&version.ID, &version.UserID, &version.InfoType, &version.Info, &version.Meta, &version.Created,
+		&version.Updated, &version.Revision,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, helper.ErrVersionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения версии записи: %w", err)
+	}
+
+	return version, nil
+}
+
+// RestoreVersion возвращает записи содержимое версии revision, а текущее содержимое сохраняет как версию.
+// Шифротексты копируются без изменений. Версию файла, содержимое которого уже заменено, восстановить нельзя:
+// части прежнего файла удалены из хранилища. Возвращает новую ревизию записи.
+func (r *dataRepository) RestoreVersion(
+	ctx context.Context,
+	userID, dataID int,
+	revision int64,
+) (int64, string, error) {
+	query := `
+        WITH rev AS (` + nextRevision + `), version AS (
+            SELECT info_type, info, meta, search_tokens, blob_ref
+            FROM user_data_versions
+            WHERE user_id = $1 AND data_id = $2 AND revision = $3
+        ), previous AS (
+            SELECT ` + previousColumns + `
+            FROM user_data, rev, version
+            WHERE user_data.id = $2 AND user_data.user_id = $1 AND user_data.deleted_at IS NULL
+                AND user_data.blob_ref = version.blob_ref
+            FOR UPDATE OF user_data
+        ), updated AS (
+            UPDATE user_data
+            SET info_type = version.info_type, info = version.info, meta = version.meta,
+                search_tokens = version.search_tokens, revision = rev.data_revision, updated_at = NOW()
+            FROM rev, version
+            WHERE user_data.id IN (SELECT id FROM previous)
+            RETURNING user_data.revision, user_data.info_type
+        ), archived AS (
+            ` + archivePrevious + `
+            WHERE EXISTS (SELECT 1 FROM updated)
+        )
+        SELECT EXISTS (SELECT 1 FROM version), COALESCE((SELECT revision FROM updated), 0),
+            COALESCE((SELECT info_type FROM updated), '')
+    `
+	var found bool
+	var restored int64
+	var infoType string
+	err := r.db.QueryRowContext(ctx, query, userID, dataID, revision).Scan(&found, &restored, &infoType)
+	if err != nil {
+		return 0, "", fmt.Errorf("ошибка восстановления версии записи: %w", err)
+	}
+	if !found {
+		return 0, "", helper.ErrVersionNotFound
+	}
+	if restored == 0 {
+		return 0, "", helper.ErrVersionUnavailable
+	}
+
+	return restored, infoType, nil
+}
+
+// PruneVersions удаляет прежние версии, сохранённые раньше before, и версии сверх keep последних
+// у каждой записи. keep 0 - без ограничения числа версий. Возвращает число удалённых версий.
+func (r *dataRepository) PruneVersions(ctx context.Context, keep int, before time.Time) (int64, error) {
+	query := `
+        DELETE FROM user_data_versions
+        WHERE archived_at < $2 OR ($1 > 0 AND (data_id, revision) IN (
+            SELECT data_id, revision FROM (
+                SELECT data_id, revision, row_number() OVER (PARTITION BY data_id ORDER BY revision DESC) AS n
+                FROM user_data_versions
+            ) ranked
+            WHERE n > $1
+        ))
+    `
+	res, err := r.db.ExecContext(ctx, query, keep, before)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка удаления старых версий записей: %w", err)
+	}
+
+	pruned, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения числа удалённых строк: %w", err)
+	}
+
+	return pruned, nil
+}
+
+// ListData возвращает не больше page.Limit записей пользователя без Info в порядке даты создания и ID.
+// Следующая страница начинается после page.After.
+func (r *dataRepository) ListData(ctx context.Context, userID int, page *entity.DataPage) ([]*entity.UserData, error) {
+	var query strings.Builder
+	query.WriteString(`
+        SELECT id, user_id, info_type, meta, created, updated_at, revision, ` + organizeColumns + `
+        FROM user_data
+        WHERE user_id = $1 AND deleted_at IS NULL`)
+	args := []any{userID}
+	arg := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if page.InfoType != "" {
+		query.WriteString(` AND info_type = ` + arg(page.InfoType))
+	}
+	if page.FolderID != nil && *page.FolderID == 0 {
+		query.WriteString(` AND folder_id IS NULL`)
+	}
+	if page.FolderID != nil && *page.FolderID != 0 {
+		query.WriteString(` AND folder_id = ` + arg(*page.FolderID))
+	}
+	if page.TagID != 0 {
+		query.WriteString(` AND EXISTS (SELECT 1 FROM data_tags WHERE data_tags.data_id = user_data.id AND tag_id = ` +
+			arg(page.TagID) + `)`)
+	}
+	if page.After != nil {
+		created, id := arg(page.After.Created), arg(page.After.ID)
+		query.WriteString(` AND (created, id) > (` + created + `, ` + id + `)`)
+	}
+	query.WriteString(` ORDER BY created, id LIMIT ` + arg(page.Limit))
+
+	return r.querySummaries(ctx, query.String(), args...)
+}
+
+// SearchData возвращает не больше search.Limit записей пользователя без Info, у которых есть все токены
+// поиска, в порядке даты создания и ID. Следующая страница начинается после search.After.
+func (r *dataRepository) SearchData(
+	ctx context.Context,
+	userID int,
+	search *entity.DataSearch,
+) ([]*entity.UserData, error) {
+	var query strings.Builder
+	query.WriteString(`
+        SELECT id, user_id, info_type, meta, created, updated_at, revision, ` + organizeColumns + `
+        FROM user_data
+        WHERE user_id = $1 AND deleted_at IS NULL`)
+	args := []any{userID}
+	arg := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if len(search.Tokens) > 0 {
+		query.WriteString(` AND search_tokens @> ` + arg(pq.StringArray(search.Tokens)))
+	}
+	if search.InfoType != "" {
+		query.WriteString(` AND info_type = ` + arg(search.InfoType))
+	}
+	if !search.CreatedFrom.IsZero() {
+		query.WriteString(` AND created >= ` + arg(search.CreatedFrom))
+	}
+	if !search.CreatedTo.IsZero() {
+		query.WriteString(` AND created < ` + arg(search.CreatedTo))
+	}
+
+	order, direction := "DESC", "<"
+	if search.Ascending {
+		order, direction = "ASC", ">"
+	}
+	if search.After != nil {
+		created, id := arg(search.After.Created), arg(search.After.ID)
+		query.WriteString(` AND (created, id) ` + direction + ` (` + created + `, ` + id + `)`)
+	}
+	query.WriteString(` ORDER BY created ` + order + `, id ` + order + ` LIMIT ` + arg(search.Limit))
+
+	return r.querySummaries(ctx, query.String(), args...)
+}
+
+// querySummaries выполняет запрос записей без Info.
+func (r *dataRepository) querySummaries(ctx context.Context, query string, args ...any) ([]*entity.UserData, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса к базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var dataItems []*entity.UserData
+	for rows.Next() {
+		var data entity.UserData
+		err := rows.Scan(
+			&data.ID, &data.UserID, &data.InfoType, &data.Meta, &data.Created, &data.Updated, &data.Revision,
+			&data.FolderID, (*pq.Int64Array)(&data.TagIDs),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения данных из базы данных: %w", err)
+		}
+		dataItems = append(dataItems, &data)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return dataItems, nil
+}
+
+// searchTokens - значение для колонки search_tokens: запись без токенов хранит пустой массив, а не NULL.
+func searchTokens(tokens []string) pq.StringArray {
+	if tokens == nil {
+		return pq.StringArray{}
+	}
+
+	return pq.StringArray(tokens)
+}
+
+// Changes возвращает не больше limit изменений данных пользователя с ревизией больше sinceRevision
+// в порядке возрастания ревизий. Для удалённых записей и записей в корзине возвращается только ID.
+func (r *dataRepository) Changes(
+	ctx context.Context,
+	userID int,
+	sinceRevision int64,
+	limit int,
+) ([]*entity.DataChange, error) {
+	query := `
+        SELECT id, info_type, info, meta, created, updated_at, revision, ` + organizeColumns + `,
+            deleted_at IS NOT NULL AS deleted
+        FROM user_data
+        WHERE user_id = $1 AND revision > $2
+        UNION ALL
+        SELECT data_id, '', '', '', 'epoch'::timestamp, 'epoch'::timestamp, revision, 0, '{}'::int[], TRUE
+        FROM user_data_tombstones
+        WHERE user_id = $1 AND revision > $2
+        ORDER BY revision
+        LIMIT $3
+    `
+	rows, err := r.db.QueryContext(ctx, query, userID, sinceRevision, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса к базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*entity.DataChange
+	for rows.Next() {
+		data := &entity.UserData{UserID: userID}
+		change := &entity.DataChange{}
+		err := rows.Scan(
+			&data.ID, &data.InfoType, &data.Info, &data.Meta, &data.Created, &data.Updated, &data.Revision,
+			&data.FolderID, (*pq.Int64Array)(&data.TagIDs), &change.Deleted,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения данных из базы данных: %w", err)
+		}
+
+		change.ID = data.ID
+		change.Revision = data.Revision
+		if !change.Deleted {
+			change.Data = data
+		}
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return changes, nil
+}
+
+// DataBatch возвращает пачку записей всех пользователей с ID больше afterID в порядке возрастания ID.
+func (r *dataRepository) DataBatch(ctx context.Context, afterID, limit int) ([]*entity.UserData, error) {
+	query := `
+        SELECT id, user_id, info_type, info, meta, created
+        FROM user_data
+        WHERE id > $1
+        ORDER BY id
+        LIMIT $2
+    `
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса к базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var dataItems []*entity.UserData
 	for rows.Next() {
 		var data entity.UserData
 		err := rows.Scan(&data.ID, &data.UserID, &data.InfoType, &data.Info, &data.Meta, &data.Created)
@@ -105,3 +650,24 @@ func (r *dataRepository) ListData(ctx context.Context, userID int, infoType stri
 
 	return dataItems, nil
 }
+
+// ReplaceCiphertext заменяет шифротексты записи, только если они не изменились с момента чтения.
+// Возвращает false, если запись успели обновить или удалить.
+func (r *dataRepository) ReplaceCiphertext(ctx context.Context, old *entity.UserData, info, meta string) (bool, error) {
+	query := `
+        UPDATE user_data
+        SET info = $1, meta = $2
+        WHERE id = $3 AND info = $4 AND meta = $5
+    `
+	res, err := r.db.ExecContext(ctx, query, info, meta, old.ID, old.Info, old.Meta)
+	if err != nil {
+		return false, fmt.Errorf("ошибка обновления шифротекста: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения числа обновлённых строк: %w", err)
+	}
+
+	return affected == 1, nil
+}
@@ -0,0 +1,400 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_WritesBumpRevision(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataRepository(db, new(mockLogger))
+	ctx := context.Background()
+
+	mock.ExpectQuery("WITH rev AS (.+)UPDATE users SET data_revision = data_revision \\+ 1(.+)INSERT INTO user_data").
+		WithArgs(7, "text", "info", "meta", pq.StringArray{"t1"}).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	id, err := repo.AddData(ctx, &entity.UserData{
+		UserID: 7, InfoType: "text", Info: "info", Meta: "meta", SearchTokens: []string{"t1"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, id)
+
+	mock.ExpectQuery("WITH rev AS (.+)UPDATE user_data(.+)revision = rev.data_revision(.+)INSERT INTO user_data_versions").
+		WithArgs(7, "text", "info2", "meta2", 3, int64(0), pq.StringArray{}).
+		WillReturnRows(sqlmock.NewRows([]string{"owner", "updated"}).AddRow(7, true))
+
+	err = repo.UpdateData(ctx, &entity.UserData{ID: 3, UserID: 7, InfoType: "text", Info: "info2", Meta: "meta2"}, 0)
+	assert.NoError(t, err)
+
+	mock.ExpectQuery("WITH rev AS (.+)UPDATE user_data SET deleted_at = NOW\\(\\), revision = rev.data_revision").
+		WithArgs(7, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"owner", "deleted"}).AddRow(7, true))
+
+	assert.NoError(t, repo.DeleteData(ctx, 7, 3))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestData_UpdateDataWithExpectedRevision(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataRepository(db, new(mockLogger))
+	data := &entity.UserData{ID: 3, UserID: 7, InfoType: "text", Info: "info", Meta: "meta"}
+
+	mock.ExpectQuery("user_data.revision = \\$6\\) FOR UPDATE(.+)UPDATE user_data(.+)updated_at = NOW\\(\\)(.+)"+
+		"INSERT INTO user_data_versions").
+		WithArgs(7, "text", "info", "meta", 3, int64(5), pq.StringArray{}).
+		WillReturnRows(sqlmock.NewRows([]string{"owner", "updated"}).AddRow(7, true))
+	assert.NoError(t, repo.UpdateData(context.Background(), data, 5))
+
+	mock.ExpectQuery("UPDATE user_data").
+		WithArgs(7, "text", "info", "meta", 3, int64(5), pq.StringArray{}).
+		WillReturnRows(sqlmock.NewRows([]string{"owner", "updated"}).AddRow(7, false))
+	err = repo.UpdateData(context.Background(), data, 5)
+	assert.ErrorIs(t, err, helper.ErrVersionConflict)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestData_MissingAndForeignData(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataRepository(db, new(mockLogger))
+	ctx := context.Background()
+	data := &entity.UserData{ID: 3, UserID: 7, InfoType: "text", Info: "info", Meta: "meta"}
+	columns := []string{
+		"id", "user_id", "info_type", "info", "meta", "created", "updated_at", "revision", "folder_id", "tag_ids",
+	}
+
+	t.Run("Получение", func(t *testing.T) {
+		mock.ExpectQuery("FROM user_data WHERE id = \\$1 AND deleted_at IS NULL").
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(columns))
+		_, err := repo.GetDataByID(ctx, 7, 3)
+		assert.ErrorIs(t, err, helper.ErrDataNotFound)
+		assert.ErrorIs(t, err, helper.ErrNotFound)
+
+		mock.ExpectQuery("FROM user_data WHERE id = \\$1 AND deleted_at IS NULL").
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 8, "text", "info", "meta", time.Now(), time.Now(), 1, 0, "{}"))
+		_, err = repo.GetDataByID(ctx, 7, 3)
+		assert.ErrorIs(t, err, helper.ErrDataForbidden)
+		assert.ErrorIs(t, err, helper.ErrPermissionDenied)
+	})
+
+	t.Run("Обновление", func(t *testing.T) {
+		mock.ExpectQuery("SELECT \\(SELECT user_id FROM user_data WHERE id = \\$5 AND deleted_at IS NULL\\)").
+			WithArgs(7, "text", "info", "meta", 3, int64(0), pq.StringArray{}).
+			WillReturnRows(sqlmock.NewRows([]string{"owner", "updated"}).AddRow(nil, false))
+		assert.ErrorIs(t, repo.UpdateData(ctx, data, 0), helper.ErrDataNotFound)
+
+		mock.ExpectQuery("SELECT \\(SELECT user_id FROM user_data WHERE id = \\$5 AND deleted_at IS NULL\\)").
+			WithArgs(7, "text", "info", "meta", 3, int64(5), pq.StringArray{}).
+			WillReturnRows(sqlmock.NewRows([]string{"owner", "updated"}).AddRow(8, false))
+		assert.ErrorIs(t, repo.UpdateData(ctx, data, 5), helper.ErrDataForbidden)
+	})
+
+	t.Run("Удаление", func(t *testing.T) {
+		mock.ExpectQuery("SELECT \\(SELECT user_id FROM user_data WHERE id = \\$2 AND deleted_at IS NULL\\)").
+			WithArgs(7, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"owner", "deleted"}).AddRow(nil, false))
+		assert.ErrorIs(t, repo.DeleteData(ctx, 7, 3), helper.ErrDataNotFound)
+
+		mock.ExpectQuery("SELECT \\(SELECT user_id FROM user_data WHERE id = \\$2 AND deleted_at IS NULL\\)").
+			WithArgs(7, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"owner", "deleted"}).AddRow(8, false))
+		assert.ErrorIs(t, repo.DeleteData(ctx, 7, 3), helper.ErrDataForbidden)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestData_Changes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataRepository(db, new(mockLogger))
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+
+	mock.ExpectQuery("FROM user_data WHERE user_id = \\$1 AND revision > \\$2(.+)FROM user_data_tombstones").
+		WithArgs(7, int64(10), 100).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "info_type", "info", "meta", "created", "updated_at", "revision", "folder_id", "tag_ids", "deleted",
+		}).
+			AddRow(3, "text", "info", "meta", created, updated, int64(11), 5, "{1,4}", false).
+			AddRow(2, "", "", "", time.Unix(0, 0), time.Unix(0, 0), int64(12), 0, "{}", true))
+
+	changes, err := repo.Changes(context.Background(), 7, 10, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.DataChange{
+		{
+			ID:       3,
+			Revision: 11,
+			Data: &entity.UserData{
+				ID: 3, UserID: 7, InfoType: "text", Info: "info", Meta: "meta",
+				Created: created, Updated: updated, Revision: 11, FolderID: 5, TagIDs: []int64{1, 4},
+			},
+		},
+		{ID: 2, Revision: 12, Deleted: true},
+	}, changes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestData_ListData(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataRepository(db, new(mockLogger))
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	columns := []string{"id", "user_id", "info_type", "meta", "created", "updated_at", "revision", "folder_id", "tag_ids"}
+
+	t.Run("Первая страница", func(t *testing.T) {
+		mock.ExpectQuery("WHERE user_id = \\$1 AND deleted_at IS NULL ORDER BY created, id LIMIT \\$2").
+			WithArgs(7, 10).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 7, "text", "meta", created, created, int64(4), 0, "{2}"))
+
+		items, err := repo.ListData(context.Background(), 7, &entity.DataPage{Limit: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.UserData{{
+			ID: 3, UserID: 7, InfoType: "text", Meta: "meta", Created: created, Updated: created, Revision: 4,
+			TagIDs: []int64{2},
+		}}, items)
+	})
+
+	t.Run("Следующая страница по типу", func(t *testing.T) {
+		mock.ExpectQuery("WHERE user_id = \\$1 AND deleted_at IS NULL AND info_type = \\$2 "+
+			"AND \\(created, id\\) > \\(\\$3, \\$4\\) ORDER BY created, id LIMIT \\$5").
+			WithArgs(7, "text", created, 3, 10).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		items, err := repo.ListData(context.Background(), 7, &entity.DataPage{
+			InfoType: "text",
+			After:    &entity.DataCursor{Created: created, ID: 3},
+			Limit:    10,
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, items)
+	})
+
+	t.Run("Папка и тег", func(t *testing.T) {
+		root, folderID := 0, 5
+		mock.ExpectQuery("WHERE user_id = \\$1 AND deleted_at IS NULL AND folder_id IS NULL ORDER BY").
+			WithArgs(7, 10).
+			WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectQuery("WHERE user_id = \\$1 AND deleted_at IS NULL AND folder_id = \\$2 "+
+			"AND EXISTS \\(SELECT 1 FROM data_tags WHERE data_tags.data_id = user_data.id AND tag_id = \\$3\\) ORDER BY").
+			WithArgs(7, 5, 4, 10).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := repo.ListData(context.Background(), 7, &entity.DataPage{FolderID: &root, Limit: 10})
+		assert.NoError(t, err)
+		_, err = repo.ListData(context.Background(), 7, &entity.DataPage{FolderID: &folderID, TagID: 4, Limit: 10})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Ошибка запроса", func(t *testing.T) {
+		mock.ExpectQuery("FROM user_data").WillReturnError(errors.New("db error"))
+
+		_, err := repo.ListData(context.Background(), 7, &entity.DataPage{Limit: 10})
+
+		assert.ErrorContains(t, err, "ошибка выполнения запроса к базе данных")
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestData_SearchData(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataRepository(db, new(mockLogger))
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	after := &entity.DataCursor{Created: created.Add(time.Hour), ID: 9}
+	columns := []string{"id", "user_id", "info_type", "meta", "created", "updated_at", "revision", "folder_id", "tag_ids"}
+
+	t.Run("Все условия", func(t *testing.T) {
+		mock.ExpectQuery("WHERE user_id = \\$1 AND deleted_at IS NULL AND search_tokens @> \\$2 AND info_type = \\$3 "+
+			"AND created >= \\$4 AND \\(created, id\\) > \\(\\$5, \\$6\\) ORDER BY created ASC, id ASC LIMIT \\$7").
+			WithArgs(7, pq.StringArray{"a", "b"}, "text", from, after.Created, 9, 20).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 7, "text", "meta", created, created, int64(4), 0, "{2}"))
+
+		items, err := repo.SearchData(context.Background(), 7, &entity.DataSearch{
+			Tokens:      []string{"a", "b"},
+			InfoType:    "text",
+			CreatedFrom: from,
+			After:       after,
+			Limit:       20,
+			Ascending:   true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.UserData{{
+			ID: 3, UserID: 7, InfoType: "text", Meta: "meta", Created: created, Updated: created, Revision: 4,
+			TagIDs: []int64{2},
+		}}, items)
+	})
+
+	t.Run("Без условий, новые сначала", func(t *testing.T) {
+		mock.ExpectQuery("WHERE user_id = \\$1 AND deleted_at IS NULL AND created < \\$2 "+
+			"ORDER BY created DESC, id DESC LIMIT \\$3").
+			WithArgs(7, from, 10).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		items, err := repo.SearchData(context.Background(), 7, &entity.DataSearch{CreatedTo: from, Limit: 10})
+
+		assert.NoError(t, err)
+		assert.Empty(t, items)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestData_Versions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataRepository(db, new(mockLogger))
+	ctx := context.Background()
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	created := updated.Add(-24 * time.Hour)
+	columns := []string{"data_id", "user_id", "info_type", "meta", "created", "updated_at", "revision"}
+	mock.ExpectQuery("FROM user_data_versions v JOIN user_data d(.+)ORDER BY v.revision DESC").
+		WithArgs(7, 3).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(3, 7, "text", "meta2", created, updated, int64(5)).
+			AddRow(3, 7, "text", "meta1", created, updated.Add(-time.Hour), int64(2)))
+
+	versions, err := repo.ListVersions(ctx, 7, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.UserData{
+		{ID: 3, UserID: 7, InfoType: "text", Meta: "meta2", Created: created, Updated: updated, Revision: 5},
+		{ID: 3, UserID: 7, InfoType: "text", Meta: "meta1", Created: created, Updated: updated.Add(-time.Hour), Revision: 2},
+	}, versions)
+
+	columns = []string{"data_id", "user_id", "info_type", "info", "meta", "created", "updated_at", "revision"}
+	mock.ExpectQuery("FROM user_data_versions v(.+)WHERE v.user_id = \\$1 AND v.data_id = \\$2 AND v.revision = \\$3").
+		WithArgs(7, 3, int64(2)).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 7, "text", "info1", "meta1", created, updated, int64(2)))
+
+	version, err := repo.GetVersion(ctx, 7, 3, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "info1", version.Info)
+
+	mock.ExpectQuery("FROM user_data_versions").WithArgs(7, 3, int64(9)).WillReturnError(sql.ErrNoRows)
+	_, err = repo.GetVersion(ctx, 7, 3, 9)
+	assert.ErrorIs(t, err, helper.ErrVersionNotFound)
+
+	restore := "WITH rev AS (.+)FROM user_data_versions(.+)user_data.blob_ref = version.blob_ref(.+)" +
+		"INSERT INTO user_data_versions"
+	restoreColumns := []string{"found", "revision", "info_type"}
+	mock.ExpectQuery(restore).WithArgs(7, 3, int64(2)).
+		WillReturnRows(sqlmock.NewRows(restoreColumns).AddRow(true, int64(8), "text"))
+	revision, infoType, err := repo.RestoreVersion(ctx, 7, 3, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), revision)
+	assert.Equal(t, "text", infoType)
+
+	mock.ExpectQuery(restore).WithArgs(7, 3, int64(9)).
+		WillReturnRows(sqlmock.NewRows(restoreColumns).AddRow(false, int64(0), ""))
+	_, _, err = repo.RestoreVersion(ctx, 7, 3, 9)
+	assert.ErrorIs(t, err, helper.ErrVersionNotFound)
+
+	mock.ExpectQuery(restore).WithArgs(7, 3, int64(1)).
+		WillReturnRows(sqlmock.NewRows(restoreColumns).AddRow(true, int64(0), ""))
+	_, _, err = repo.RestoreVersion(ctx, 7, 3, 1)
+	assert.ErrorIs(t, err, helper.ErrVersionUnavailable)
+
+	mock.ExpectExec("DELETE FROM user_data_versions WHERE archived_at < \\$2 OR(.+)WHERE n > \\$1").
+		WithArgs(10, updated).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	pruned, err := repo.PruneVersions(ctx, 10, updated)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), pruned)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestData_Trash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewDataRepository(db, new(mockLogger))
+	ctx := context.Background()
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	deleted := created.Add(time.Hour)
+
+	mock.ExpectQuery("FROM user_data WHERE user_id = \\$1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "user_id", "info_type", "meta", "created", "updated_at", "revision", "folder_id", "tag_ids", "deleted_at",
+		}).AddRow(3, 7, "text", "meta", created, created, int64(4), 0, "{}", deleted))
+
+	trash, err := repo.ListTrash(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.UserData{{
+		ID: 3, UserID: 7, InfoType: "text", Meta: "meta", Created: created, Updated: created, Deleted: deleted,
+		Revision: 4, TagIDs: []int64{},
+	}}, trash)
+
+	restore := "WITH rev AS (.+)UPDATE user_data SET deleted_at = NULL, revision = rev.data_revision(.+)" +
+		"deleted_at IS NOT NULL"
+	mock.ExpectQuery(restore).WithArgs(7, 3).WillReturnRows(sqlmock.NewRows([]string{"info_type"}).AddRow("text"))
+	infoType, err := repo.RestoreData(ctx, 7, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "text", infoType)
+	mock.ExpectQuery(restore).WithArgs(7, 4).WillReturnError(sql.ErrNoRows)
+	_, err = repo.RestoreData(ctx, 7, 4)
+	assert.ErrorIs(t, err, helper.ErrNotInTrash)
+
+	purge := "DELETE FROM user_data WHERE deleted_at IS NOT NULL AND %s RETURNING id, user_id, revision \\) " +
+		"INSERT INTO user_data_tombstones \\(data_id, user_id, revision\\) SELECT id, user_id, revision FROM purged " +
+		"RETURNING data_id, user_id"
+	mock.ExpectExec(fmt.Sprintf(purge, "id = \\$2 AND user_id = \\$1")).WithArgs(7, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.PurgeData(ctx, 7, 3))
+	mock.ExpectExec(fmt.Sprintf(purge, "id = \\$2 AND user_id = \\$1")).WithArgs(7, 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.PurgeData(ctx, 7, 3), helper.ErrNotInTrash)
+
+	mock.ExpectQuery(fmt.Sprintf(purge, "user_id = \\$1")).WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"data_id", "user_id"}).AddRow(3, 7).AddRow(4, 7))
+	emptied, err := repo.EmptyTrash(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, emptied)
+
+	mock.ExpectQuery(fmt.Sprintf(purge, "deleted_at < \\$1")).WithArgs(deleted).
+		WillReturnRows(sqlmock.NewRows([]string{"data_id", "user_id"}).AddRow(5, 7).AddRow(6, 8))
+	purged, err := repo.PurgeTrash(ctx, deleted)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.UserData{{ID: 5, UserID: 7}, {ID: 6, UserID: 8}}, purged)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+)
+
+type loginAttemptStorager interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type loginAttemptRepository struct {
+	db     loginAttemptStorager
+	logger logger.CustomLogger
+}
+
+// NewLoginAttemptRepository - конструктор репозитория счётчиков неудачных попыток входа.
+func NewLoginAttemptRepository(db loginAttemptStorager, logger logger.CustomLogger) *loginAttemptRepository {
+	return &loginAttemptRepository{db: db, logger: logger}
+}
+
+// Attempt возвращает состояние счётчика. Если неудачных попыток не было, возвращается пустой счётчик.
+func (r *loginAttemptRepository) Attempt(ctx context.Context, kind, key string) (*entity.LoginAttempt, error) {
+	query := `
+        SELECT failed_count, last_failed_at, locked_until
+        FROM login_attempts
+        WHERE kind = $1 AND key = $2
+    `
+	attempt := &entity.LoginAttempt{Kind: kind, Key: key}
+	var lockedUntil sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, kind, key).
+		Scan(&attempt.FailedCount, &attempt.LastFailedAt, &lockedUntil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return attempt, nil
+		}
+
+		r.logger.LogInfo("ошибка при получении счётчика попыток входа", err)
+
+		return nil, helper.ErrInternalServer
+	}
+	if lockedUntil.Valid {
+		attempt.LockedUntil = lockedUntil.Time
+	}
+
+	return attempt, nil
+}
+
+// IncrementFailures атомарно увеличивает счётчик неудачных попыток и возвращает новое значение.
+// Если с последней неудачи прошло больше window, счётчик начинается заново.
+func (r *loginAttemptRepository) IncrementFailures(
+	ctx context.Context,
+	kind, key string,
+	window time.Duration,
+) (int, error) {
+	query := `
+        INSERT INTO login_attempts (kind, key, failed_count, last_failed_at)
+        VALUES ($1, $2, 1, NOW())
+        ON CONFLICT (kind, key) DO UPDATE SET
+            failed_count = CASE
+                WHEN login_attempts.last_failed_at < NOW() - make_interval(secs => $3) THEN 1
+                ELSE login_attempts.failed_count + 1
+            END,
+            last_failed_at = NOW()
+        RETURNING failed_count
+    `
+	var failedCount int
+	err := r.db.QueryRowContext(ctx, query, kind, key, window.Seconds()).Scan(&failedCount)
+	if err != nil {
+		r.logger.LogInfo("ошибка при увеличении счётчика попыток входа", err)
+		return 0, helper.ErrInternalServer
+	}
+
+	return failedCount, nil
+}
+
+// LockUntil блокирует вход до указанного момента.
+func (r *loginAttemptRepository) LockUntil(ctx context.Context, kind, key string, until time.Time) error {
+	query := `UPDATE login_attempts SET locked_until = $1 WHERE kind = $2 AND key = $3`
+	_, err := r.db.ExecContext(ctx, query, until, kind, key)
+	if err != nil {
+		r.logger.LogInfo("ошибка при блокировке входа", err)
+		return helper.ErrInternalServer
+	}
+
+	return nil
+}
+
+// ResetAttempts сбрасывает счётчик после успешного входа.
+func (r *loginAttemptRepository) ResetAttempts(ctx context.Context, kind, key string) error {
+	query := `DELETE FROM login_attempts WHERE kind = $1 AND key = $2`
+	_, err := r.db.ExecContext(ctx, query, kind, key)
+	if err != nil {
+		r.logger.LogInfo("ошибка при сбросе счётчика попыток входа", err)
+		return helper.ErrInternalServer
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginAttempt_Attempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLoginAttemptRepository(db, new(mockLogger))
+	lastFailed := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	lockedUntil := lastFailed.Add(time.Minute)
+
+	mock.ExpectQuery("SELECT failed_count, last_failed_at, locked_until FROM login_attempts").
+		WithArgs(entity.AttemptKindLogin, "testuser").
+		WillReturnRows(sqlmock.NewRows([]string{"failed_count", "last_failed_at", "locked_until"}).
+			AddRow(6, lastFailed, lockedUntil))
+
+	attempt, err := repo.Attempt(context.Background(), entity.AttemptKindLogin, "testuser")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.LoginAttempt{
+		Kind:         entity.AttemptKindLogin,
+		Key:          "testuser",
+		FailedCount:  6,
+		LastFailedAt: lastFailed,
+		LockedUntil:  lockedUntil,
+	}, attempt)
+}
+
+func TestLoginAttempt_Attempt_NoRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLoginAttemptRepository(db, new(mockLogger))
+
+	mock.ExpectQuery("SELECT failed_count").
+		WithArgs(entity.AttemptKindIP, "10.0.0.1").
+		WillReturnError(sql.ErrNoRows)
+
+	attempt, err := repo.Attempt(context.Background(), entity.AttemptKindIP, "10.0.0.1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, attempt.FailedCount)
+	assert.True(t, attempt.LockedUntil.IsZero())
+}
+
+func TestLoginAttempt_IncrementFailures(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLoginAttemptRepository(db, new(mockLogger))
+
+	mock.ExpectQuery("INSERT INTO login_attempts").
+		WithArgs(entity.AttemptKindLogin, "testuser", float64(3600)).
+		WillReturnRows(sqlmock.NewRows([]string{"failed_count"}).AddRow(3))
+
+	count, err := repo.IncrementFailures(context.Background(), entity.AttemptKindLogin, "testuser", time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestLoginAttempt_IncrementFailures_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLoginAttemptRepository(db, new(mockLogger))
+
+	mock.ExpectQuery("INSERT INTO login_attempts").
+		WillReturnError(errors.New("db error"))
+
+	_, err = repo.IncrementFailures(context.Background(), entity.AttemptKindLogin, "testuser", time.Hour)
+
+	assert.Equal(t, helper.ErrInternalServer, err)
+}
+
+func TestLoginAttempt_LockAndReset(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLoginAttemptRepository(db, new(mockLogger))
+	until := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("UPDATE login_attempts SET locked_until").
+		WithArgs(until, entity.AttemptKindLogin, "testuser").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM login_attempts").
+		WithArgs(entity.AttemptKindLogin, "testuser").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.LockUntil(context.Background(), entity.AttemptKindLogin, "testuser", until))
+	assert.NoError(t, repo.ResetAttempts(context.Background(), entity.AttemptKindLogin, "testuser"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"github.com/lib/pq"
+)
+
+type organizerRepository struct {
+	db     dataStorager
+	logger logger.CustomLogger
+}
+
+// NewOrganizerRepository - конструктор репозитория папок и тегов.
+func NewOrganizerRepository(db dataStorager, logger logger.CustomLogger) *organizerRepository {
+	return &organizerRepository{db: db, logger: logger}
+}
+
+func (r *organizerRepository) CreateFolder(ctx context.Context, folder *entity.Folder) (int, error) {
+	query := `
+        INSERT INTO folders (user_id, parent_id, name)
+        VALUES ($1, NULLIF($2, 0), $3)
+        RETURNING id
+    `
+	var id int
+	if err := r.db.QueryRowContext(ctx, query, folder.UserID, folder.ParentID, folder.Name).Scan(&id); err != nil {
+		return 0, fmt.Errorf("ошибка создания папки: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateFolder переименовывает папку и переносит её в folder.ParentID.
+// Что родительская папка принадлежит пользователю и не вложена в эту, проверяет сервис.
+func (r *organizerRepository) UpdateFolder(ctx context.Context, folder *entity.Folder) error {
+	query := `
+        UPDATE folders
+        SET parent_id = NULLIF($3, 0), name = $4
+        WHERE id = $2 AND user_id = $1
+    `
+	res, err := r.db.ExecContext(ctx, query, folder.UserID, folder.ID, folder.ParentID, folder.Name)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления папки: %w", err)
+	}
+
+	return requireAffected(res, helper.ErrFolderNotFound)
+}
+
+// DeleteFolder удаляет папку, если в ней нет ни записей, ни вложенных папок.
+func (r *organizerRepository) DeleteFolder(ctx context.Context, userID, folderID int) error {
+	query := `
+        WITH target AS (
+            SELECT id FROM folders WHERE id = $2 AND user_id = $1
+        ), busy AS (
+            SELECT EXISTS (SELECT 1 FROM folders WHERE parent_id = $2)
+                OR EXISTS (SELECT 1 FROM user_data WHERE folder_id = $2) AS busy
+        ), deleted AS (
+            DELETE FROM folders
+            WHERE id IN (SELECT id FROM target) AND NOT (SELECT busy FROM busy)
+        )
+        SELECT EXISTS (SELECT 1 FROM target), (SELECT busy FROM busy)
+    `
+	var found, busy bool
+	if err := r.db.QueryRowContext(ctx, query, userID, folderID).Scan(&found, &busy); err != nil {
+		return fmt.Errorf("ошибка удаления папки: %w", err)
+	}
+	if !found {
+		return helper.ErrFolderNotFound
+	}
+	if busy {
+		return helper.ErrFolderNotEmpty
+	}
+
+	return nil
+}
+
+// ListFolders возвращает все папки пользователя в порядке создания.
+func (r *organizerRepository) ListFolders(ctx context.Context, userID int) ([]*entity.Folder, error) {
+	query := `
+        SELECT id, COALESCE(parent_id, 0), name
+        FROM folders
+        WHERE user_id = $1
+        ORDER BY id
+    `
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса к базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var folders []*entity.Folder
+	for rows.Next() {
+		folder := &entity.Folder{UserID: userID}
+		if err = rows.Scan(&folder.ID, &folder.ParentID, &folder.Name); err != nil {
+			return nil, fmt.Errorf("ошибка чтения данных из базы данных: %w", err)
+		}
+		folders = append(folders, folder)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return folders, nil
+}
+
+func (r *organizerRepository) CreateTag(ctx context.Context, tag *entity.Tag) (int, error) {
+	query := `
+        INSERT INTO tags (user_id, name)
+        VALUES ($1, $2)
+        RETURNING id
+    `
+	var id int
+	if err := r.db.QueryRowContext(ctx, query, tag.UserID, tag.Name).Scan(&id); err != nil {
+		return 0, fmt.Errorf("ошибка создания тега: %w", err)
+	}
+
+	return id, nil
+}
+
+// DeleteTag удаляет тег и снимает его со всех записей. Ревизии записей при этом не меняются:
+// клиенты не находят удалённый тег среди тегов пользователя и не показывают его.
+func (r *organizerRepository) DeleteTag(ctx context.Context, userID, tagID int) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM tags WHERE id = $2 AND user_id = $1`, userID, tagID)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления тега: %w", err)
+	}
+
+	return requireAffected(res, helper.ErrTagNotFound)
+}
+
+// ListTags возвращает все теги пользователя в порядке создания.
+func (r *organizerRepository) ListTags(ctx context.Context, userID int) ([]*entity.Tag, error) {
+	query := `
+        SELECT id, name
+        FROM tags
+        WHERE user_id = $1
+        ORDER BY id
+    `
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса к базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*entity.Tag
+	for rows.Next() {
+		tag := &entity.Tag{UserID: userID}
+		if err = rows.Scan(&tag.ID, &tag.Name); err != nil {
+			return nil, fmt.Errorf("ошибка чтения данных из базы данных: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return tags, nil
+}
+
+// TagData добавляет записи теги tagIDs без повторов. Запись получает новую ревизию, чтобы клиенты
+// получили её теги при синхронизации. Если хотя бы один тег не найден, запись не меняется.
+func (r *organizerRepository) TagData(ctx context.Context, userID, dataID int, tagIDs []int) error {
+	return r.changeTags(ctx, userID, dataID, tagIDs, `
+        INSERT INTO data_tags (data_id, tag_id)
+        SELECT updated.id, owned.id FROM updated, owned
+        ON CONFLICT DO NOTHING`)
+}
+
+// UntagData снимает с записи теги tagIDs. Запись получает новую ревизию.
+func (r *organizerRepository) UntagData(ctx context.Context, userID, dataID int, tagIDs []int) error {
+	return r.changeTags(ctx, userID, dataID, tagIDs, `
+        DELETE FROM data_tags
+        WHERE data_id IN (SELECT id FROM updated) AND tag_id IN (SELECT id FROM owned)`)
+}
+
+// changeTags выполняет change над тегами записи, если запись и все теги принадлежат пользователю.
+// В change доступны updated - обновлённая запись, и owned - найденные теги.
+func (r *organizerRepository) changeTags(ctx context.Context, userID, dataID int, tagIDs []int, change string) error {
+	query := `
+        WITH rev AS (` + nextRevision + `), owned AS (
+            SELECT id FROM tags WHERE user_id = $1 AND id = ANY($3)
+        ), updated AS (
+            UPDATE user_data
+            SET revision = rev.data_revision, updated_at = NOW()
+            FROM rev
+            WHERE user_data.id = $2 AND user_data.user_id = $1
+                AND (SELECT count(*) FROM owned) = cardinality($3::int[])
+            RETURNING user_data.id
+        ), changed AS (` + change + `
+        )
+        SELECT EXISTS (SELECT 1 FROM updated), (SELECT count(*) FROM owned)
+    `
+	ids := make(pq.Int64Array, len(tagIDs))
+	for i, id := range tagIDs {
+		ids[i] = int64(id)
+	}
+
+	var updated bool
+	var owned int
+	if err := r.db.QueryRowContext(ctx, query, userID, dataID, ids).Scan(&updated, &owned); err != nil {
+		return fmt.Errorf("ошибка изменения тегов записи: %w", err)
+	}
+	if owned != len(tagIDs) {
+		return helper.ErrTagNotFound
+	}
+	if !updated {
+		return helper.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// MoveData переносит запись в папку folderID, 0 - в корень. Запись получает новую ревизию.
+func (r *organizerRepository) MoveData(ctx context.Context, userID, dataID, folderID int) error {
+	query := `
+        WITH rev AS (` + nextRevision + `), target AS (
+            SELECT $3::int = 0 OR EXISTS (SELECT 1 FROM folders WHERE id = $3 AND user_id = $1) AS found
+        ), updated AS (
+            UPDATE user_data
+            SET folder_id = NULLIF($3, 0), revision = rev.data_revision, updated_at = NOW()
+            FROM rev, target
+            WHERE user_data.id = $2 AND user_data.user_id = $1 AND target.found
+            RETURNING user_data.id
+        )
+        SELECT EXISTS (SELECT 1 FROM updated), (SELECT found FROM target)
+    `
+	var updated, found bool
+	if err := r.db.QueryRowContext(ctx, query, userID, dataID, folderID).Scan(&updated, &found); err != nil {
+		return fmt.Errorf("ошибка переноса записи: %w", err)
+	}
+	if !found {
+		return helper.ErrFolderNotFound
+	}
+	if !updated {
+		return helper.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// requireAffected возвращает notFound, если запрос не изменил ни одной строки.
+func requireAffected(res sql.Result, notFound error) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения числа изменённых строк: %w", err)
+	}
+	if affected == 0 {
+		return notFound
+	}
+
+	return nil
+}
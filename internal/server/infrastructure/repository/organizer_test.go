@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizer_Folders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewOrganizerRepository(db, new(mockLogger))
+	ctx := context.Background()
+
+	mock.ExpectQuery("INSERT INTO folders \\(user_id, parent_id, name\\) VALUES \\(\\$1, NULLIF\\(\\$2, 0\\), \\$3\\)").
+		WithArgs(7, 0, "name").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	id, err := repo.CreateFolder(ctx, &entity.Folder{UserID: 7, Name: "name"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, id)
+
+	mock.ExpectExec("UPDATE folders SET parent_id = NULLIF\\(\\$3, 0\\), name = \\$4 WHERE id = \\$2 AND user_id = \\$1").
+		WithArgs(7, 4, 2, "new").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = repo.UpdateFolder(ctx, &entity.Folder{ID: 4, UserID: 7, ParentID: 2, Name: "new"})
+	assert.ErrorIs(t, err, helper.ErrFolderNotFound)
+
+	mock.ExpectQuery("SELECT id, COALESCE\\(parent_id, 0\\), name FROM folders WHERE user_id = \\$1 ORDER BY id").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id", "name"}).AddRow(2, 0, "a").AddRow(4, 2, "b"))
+	folders, err := repo.ListFolders(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.Folder{
+		{ID: 2, UserID: 7, Name: "a"},
+		{ID: 4, UserID: 7, ParentID: 2, Name: "b"},
+	}, folders)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrganizer_DeleteFolder(t *testing.T) {
+	tests := []struct {
+		want  error
+		name  string
+		found bool
+		busy  bool
+	}{
+		{name: "Удалена", found: true},
+		{name: "Не найдена", want: helper.ErrFolderNotFound},
+		{name: "Не пуста", found: true, busy: true, want: helper.ErrFolderNotEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectQuery("DELETE FROM folders").
+				WithArgs(7, 4).
+				WillReturnRows(sqlmock.NewRows([]string{"found", "busy"}).AddRow(tt.found, tt.busy))
+
+			err = NewOrganizerRepository(db, new(mockLogger)).DeleteFolder(context.Background(), 7, 4)
+
+			assert.ErrorIs(t, err, tt.want)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrganizer_Tags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewOrganizerRepository(db, new(mockLogger))
+	ctx := context.Background()
+
+	mock.ExpectQuery("INSERT INTO tags \\(user_id, name\\) VALUES \\(\\$1, \\$2\\) RETURNING id").
+		WithArgs(7, "tag").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	id, err := repo.CreateTag(ctx, &entity.Tag{UserID: 7, Name: "tag"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, id)
+
+	mock.ExpectQuery("WITH rev AS (.+)UPDATE users SET data_revision(.+)INSERT INTO data_tags").
+		WithArgs(7, 5, pq.Int64Array{3, 4}).
+		WillReturnRows(sqlmock.NewRows([]string{"updated", "owned"}).AddRow(true, 2))
+	assert.NoError(t, repo.TagData(ctx, 7, 5, []int{3, 4}))
+
+	mock.ExpectQuery("DELETE FROM data_tags").
+		WithArgs(7, 5, pq.Int64Array{3, 9}).
+		WillReturnRows(sqlmock.NewRows([]string{"updated", "owned"}).AddRow(false, 1))
+	assert.ErrorIs(t, repo.UntagData(ctx, 7, 5, []int{3, 9}), helper.ErrTagNotFound)
+
+	mock.ExpectQuery("INSERT INTO data_tags").
+		WithArgs(7, 6, pq.Int64Array{3}).
+		WillReturnRows(sqlmock.NewRows([]string{"updated", "owned"}).AddRow(false, 1))
+	assert.ErrorIs(t, repo.TagData(ctx, 7, 6, []int{3}), helper.ErrDataNotFound)
+
+	mock.ExpectExec("DELETE FROM tags WHERE id = \\$2 AND user_id = \\$1").
+		WithArgs(7, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.DeleteTag(ctx, 7, 3))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrganizer_MoveData(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewOrganizerRepository(db, new(mockLogger))
+	ctx := context.Background()
+	columns := []string{"updated", "found"}
+
+	mock.ExpectQuery("WITH rev AS (.+)SET folder_id = NULLIF\\(\\$3, 0\\)").
+		WithArgs(7, 5, 2).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(true, true))
+	assert.NoError(t, repo.MoveData(ctx, 7, 5, 2))
+
+	mock.ExpectQuery("SET folder_id").
+		WithArgs(7, 5, 9).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(false, false))
+	assert.ErrorIs(t, repo.MoveData(ctx, 7, 5, 9), helper.ErrFolderNotFound)
+
+	mock.ExpectQuery("SET folder_id").
+		WithArgs(7, 6, 0).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(false, true))
+	assert.ErrorIs(t, repo.MoveData(ctx, 7, 6, 0), helper.ErrDataNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+)
+
+type recordTypeRepository struct {
+	db     dataStorager
+	logger logger.CustomLogger
+}
+
+// NewRecordTypeRepository - конструктор репозитория пользовательских типов записей.
+func NewRecordTypeRepository(db dataStorager, logger logger.CustomLogger) *recordTypeRepository {
+	return &recordTypeRepository{db: db, logger: logger}
+}
+
+// CreateRecordType сохраняет тип записей. Если у пользователя уже есть тип с таким именем,
+// возвращает helper.ErrRecordTypeExists.
+func (r *recordTypeRepository) CreateRecordType(ctx context.Context, recordType *entity.RecordType) (int, error) {
+	fields, err := json.Marshal(recordType.Fields)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка сериализации полей типа: %w", err)
+	}
+
+	query := `
+        INSERT INTO record_types (user_id, name, fields)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (user_id, name) DO NOTHING
+        RETURNING id
+    `
+	var id int
+	err = r.db.QueryRowContext(ctx, query, recordType.UserID, recordType.Name, string(fields)).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, helper.ErrRecordTypeExists
+	}
+	if err != nil {
+		return 0, fmt.Errorf("ошибка создания типа записей: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListRecordTypes возвращает все типы записей пользователя в порядке создания.
+func (r *recordTypeRepository) ListRecordTypes(ctx context.Context, userID int) ([]*entity.RecordType, error) {
+	query := `
+        SELECT id, name, fields
+        FROM record_types
+        WHERE user_id = $1
+        ORDER BY id
+    `
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса к базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var recordTypes []*entity.RecordType
+	for rows.Next() {
+		recordType, err := scanRecordType(rows, userID)
+		if err != nil {
+			return nil, err
+		}
+		recordTypes = append(recordTypes, recordType)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return recordTypes, nil
+}
+
+// GetRecordType возвращает тип записей пользователя по имени или helper.ErrRecordTypeNotFound.
+func (r *recordTypeRepository) GetRecordType(ctx context.Context, userID int, name string) (*entity.RecordType, error) {
+	query := `
+        SELECT id, name, fields
+        FROM record_types
+        WHERE user_id = $1 AND name = $2
+    `
+	recordType, err := scanRecordType(r.db.QueryRowContext(ctx, query, userID, name), userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, helper.ErrRecordTypeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return recordType, nil
+}
+
+// DeleteRecordType удаляет тип записей, если записей этого типа нет, в том числе в корзине.
+func (r *recordTypeRepository) DeleteRecordType(ctx context.Context, userID, recordTypeID int) error {
+	query := `
+        WITH target AS (
+            SELECT id, name FROM record_types WHERE id = $2 AND user_id = $1
+        ), busy AS (
+            SELECT EXISTS (
+                SELECT 1 FROM user_data WHERE user_id = $1 AND info_type IN (SELECT name FROM target)
+            ) AS busy
+        ), deleted AS (
+            DELETE FROM record_types
+            WHERE id IN (SELECT id FROM target) AND NOT (SELECT busy FROM busy)
+        )
+        SELECT EXISTS (SELECT 1 FROM target), (SELECT busy FROM busy)
+    `
+	var found, busy bool
+	if err := r.db.QueryRowContext(ctx, query, userID, recordTypeID).Scan(&found, &busy); err != nil {
+		return fmt.Errorf("ошибка удаления типа записей: %w", err)
+	}
+	if !found {
+		return helper.ErrRecordTypeNotFound
+	}
+	if busy {
+		return helper.ErrRecordTypeInUse
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRecordType(row rowScanner, userID int) (*entity.RecordType, error) {
+	recordType := &entity.RecordType{UserID: userID}
+	var fields string
+	if err := row.Scan(&recordType.ID, &recordType.Name, &fields); err != nil {
+		return nil, fmt.Errorf("ошибка чтения данных из базы данных: %w", err)
+	}
+	if err := json.Unmarshal([]byte(fields), &recordType.Fields); err != nil {
+		return nil, fmt.Errorf("ошибка десериализации полей типа: %w", err)
+	}
+
+	return recordType, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordType_CreateAndGet(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRecordTypeRepository(db, new(mockLogger))
+	ctx := context.Background()
+	recordType := &entity.RecordType{
+		UserID: 7,
+		Name:   "wifi",
+		Fields: []entity.RecordField{{Name: "ssid", Type: "STRING"}, {Name: "password", Type: "STRING", Sensitive: true}},
+	}
+	fields := `[{"name":"ssid","type":"STRING"},{"name":"password","type":"STRING","sensitive":true}]`
+
+	insert := "INSERT INTO record_types \\(user_id, name, fields\\) VALUES \\(\\$1, \\$2, \\$3\\) " +
+		"ON CONFLICT \\(user_id, name\\) DO NOTHING RETURNING id"
+	mock.ExpectQuery(insert).
+		WithArgs(7, "wifi", fields).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	id, err := repo.CreateRecordType(ctx, recordType)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, id)
+
+	mock.ExpectQuery(insert).
+		WithArgs(7, "wifi", fields).
+		WillReturnError(sql.ErrNoRows)
+	_, err = repo.CreateRecordType(ctx, recordType)
+	assert.ErrorIs(t, err, helper.ErrRecordTypeExists)
+
+	mock.ExpectQuery("SELECT id, name, fields FROM record_types WHERE user_id = \\$1 AND name = \\$2").
+		WithArgs(7, "wifi").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "fields"}).AddRow(3, "wifi", fields))
+	found, err := repo.GetRecordType(ctx, 7, "wifi")
+	assert.NoError(t, err)
+	recordType.ID = 3
+	assert.Equal(t, recordType, found)
+
+	mock.ExpectQuery("SELECT id, name, fields FROM record_types WHERE user_id = \\$1 AND name = \\$2").
+		WithArgs(7, "passport").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "fields"}))
+	_, err = repo.GetRecordType(ctx, 7, "passport")
+	assert.ErrorIs(t, err, helper.ErrRecordTypeNotFound)
+
+	mock.ExpectQuery("SELECT id, name, fields FROM record_types WHERE user_id = \\$1 ORDER BY id").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "fields"}).AddRow(3, "wifi", fields))
+	recordTypes, err := repo.ListRecordTypes(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.RecordType{recordType}, recordTypes)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordType_Delete(t *testing.T) {
+	tests := []struct {
+		want  error
+		name  string
+		found bool
+		busy  bool
+	}{
+		{name: "Удалён", found: true},
+		{name: "Не найден", want: helper.ErrRecordTypeNotFound},
+		{name: "Есть записи", found: true, busy: true, want: helper.ErrRecordTypeInUse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectQuery("DELETE FROM record_types").
+				WithArgs(7, 3).
+				WillReturnRows(sqlmock.NewRows([]string{"found", "busy"}).AddRow(tt.found, tt.busy))
+
+			err = NewRecordTypeRepository(db, new(mockLogger)).DeleteRecordType(context.Background(), 7, 3)
+			assert.ErrorIs(t, err, tt.want)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
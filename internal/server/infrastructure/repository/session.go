@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+)
+
+type sessionRepository struct {
+	db     dataStorager
+	logger logger.CustomLogger
+}
+
+// NewSessionRepository - конструктор репозитория сессий пользователей.
+func NewSessionRepository(db dataStorager, logger logger.CustomLogger) *sessionRepository {
+	return &sessionRepository{db: db, logger: logger}
+}
+
+// CreateSession сохраняет новую сессию.
+func (r *sessionRepository) CreateSession(ctx context.Context, session *entity.Session) error {
+	query := `
+        INSERT INTO sessions (id, user_id, refresh_token_hash, user_agent, ip, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+	_, err := r.db.ExecContext(ctx, query,
+		session.ID, session.UserID, session.RefreshTokenHash, session.UserAgent, session.IP, session.ExpiresAt)
+	if err != nil {
+		r.logger.LogInfo("ошибка при сохранении сессии", err)
+		return helper.ErrInternalServer
+	}
+
+	return nil
+}
+
+// ActiveSession возвращает неотозванную и не истёкшую сессию или helper.ErrSessionNotFound.
+func (r *sessionRepository) ActiveSession(ctx context.Context, sessionID string) (*entity.Session, error) {
+	query := `
+        SELECT id, user_id, refresh_token_hash, user_agent, ip, created_at, last_used_at, expires_at
+        FROM sessions
+        WHERE id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+    `
+	session := &entity.Session{}
+	err := r.db.QueryRowContext(ctx, query, sessionID).Scan(
+		&session.ID, &session.UserID, &session.RefreshTokenHash, &session.UserAgent, &session.IP,
+		&session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, helper.ErrSessionNotFound
+		}
+
+		r.logger.LogInfo("ошибка при получении сессии", err)
+
+		return nil, helper.ErrInternalServer
+	}
+
+	return session, nil
+}
+
+// RotateRefreshToken заменяет хеш refresh токена, только если предъявлен текущий токен сессии.
+// Возвращает false, если токен уже был использован или сессия отозвана.
+func (r *sessionRepository) RotateRefreshToken(
+	ctx context.Context,
+	sessionID, oldHash, newHash string,
+	expiresAt time.Time,
+) (bool, error) {
+	query := `
+        UPDATE sessions
+        SET refresh_token_hash = $1, expires_at = $2, last_used_at = NOW()
+        WHERE id = $3 AND refresh_token_hash = $4 AND revoked_at IS NULL AND expires_at > NOW()
+    `
+	res, err := r.db.ExecContext(ctx, query, newHash, expiresAt, sessionID, oldHash)
+	if err != nil {
+		r.logger.LogInfo("ошибка при обновлении refresh токена", err)
+		return false, helper.ErrInternalServer
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения числа обновлённых строк: %w", err)
+	}
+
+	return affected == 1, nil
+}
+
+// RevokeSession отзывает сессию пользователя. Возвращает helper.ErrSessionNotFound,
+// если активной сессии с таким ID у пользователя нет.
+func (r *sessionRepository) RevokeSession(ctx context.Context, userID int, sessionID string) error {
+	query := `
+        UPDATE sessions
+        SET revoked_at = NOW()
+        WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+    `
+	res, err := r.db.ExecContext(ctx, query, sessionID, userID)
+	if err != nil {
+		r.logger.LogInfo("ошибка при отзыве сессии", err)
+		return helper.ErrInternalServer
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения числа обновлённых строк: %w", err)
+	}
+	if affected == 0 {
+		return helper.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// ListActiveSessions возвращает активные сессии пользователя, начиная с последних использованных.
+func (r *sessionRepository) ListActiveSessions(ctx context.Context, userID int) ([]*entity.Session, error) {
+	query := `
+        SELECT id, user_id, user_agent, ip, created_at, last_used_at, expires_at
+        FROM sessions
+        WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+        ORDER BY last_used_at DESC
+    `
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса к базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*entity.Session
+	for rows.Next() {
+		var session entity.Session
+		err := rows.Scan(&session.ID, &session.UserID, &session.UserAgent, &session.IP,
+			&session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения данных из базы данных: %w", err)
+		}
+		sessions = append(sessions, &session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSession_CreateSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSessionRepository(db, new(mockLogger))
+	expires := time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("INSERT INTO sessions").
+		WithArgs("sid", 7, "hash", "cli", "10.0.0.1", expires).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.CreateSession(context.Background(), &entity.Session{
+		ID: "sid", UserID: 7, RefreshTokenHash: "hash", UserAgent: "cli", IP: "10.0.0.1", ExpiresAt: expires,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSession_ActiveSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSessionRepository(db, new(mockLogger))
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT (.+) FROM sessions WHERE id = \\$1 AND revoked_at IS NULL").
+		WithArgs("sid").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "user_id", "refresh_token_hash", "user_agent", "ip", "created_at", "last_used_at", "expires_at",
+		}).AddRow("sid", 7, "hash", "cli", "10.0.0.1", now, now, now.Add(time.Hour)))
+
+	session, err := repo.ActiveSession(context.Background(), "sid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, session.UserID)
+	assert.Equal(t, "hash", session.RefreshTokenHash)
+
+	mock.ExpectQuery("SELECT").WithArgs("revoked").WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.ActiveSession(context.Background(), "revoked")
+	assert.ErrorIs(t, err, helper.ErrSessionNotFound)
+}
+
+func TestSession_RotateRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSessionRepository(db, new(mockLogger))
+	expires := time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("UPDATE sessions SET refresh_token_hash").
+		WithArgs("new", expires, "sid", "old").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	rotated, err := repo.RotateRefreshToken(context.Background(), "sid", "old", "new", expires)
+
+	assert.NoError(t, err)
+	assert.False(t, rotated)
+}
+
+func TestSession_RevokeSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSessionRepository(db, new(mockLogger))
+
+	mock.ExpectExec("UPDATE sessions SET revoked_at").
+		WithArgs("sid", 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE sessions SET revoked_at").
+		WithArgs("foreign", 7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.RevokeSession(context.Background(), 7, "sid"))
+	assert.ErrorIs(t, repo.RevokeSession(context.Background(), 7, "foreign"), helper.ErrSessionNotFound)
+}
+
+func TestSession_ListActiveSessions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSessionRepository(db, new(mockLogger))
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT (.+) FROM sessions WHERE user_id = \\$1").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "user_id", "user_agent", "ip", "created_at", "last_used_at", "expires_at",
+		}).
+			AddRow("a", 7, "cli", "10.0.0.1", now, now, now.Add(time.Hour)).
+			AddRow("b", 7, "cli", "10.0.0.2", now, now, now.Add(time.Hour)))
+
+	sessions, err := repo.ListActiveSessions(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "10.0.0.2", sessions[1].IP)
+}
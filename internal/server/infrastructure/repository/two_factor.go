@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"github.com/lib/pq"
+)
+
+type twoFactorRepository struct {
+	db     dataStorager
+	logger logger.CustomLogger
+}
+
+// NewTwoFactorRepository - конструктор репозитория второго фактора и подтверждений входа.
+func NewTwoFactorRepository(db dataStorager, logger logger.CustomLogger) *twoFactorRepository {
+	return &twoFactorRepository{db: db, logger: logger}
+}
+
+// TOTP возвращает настройки второго фактора пользователя или helper.ErrTOTPNotEnrolled.
+func (r *twoFactorRepository) TOTP(ctx context.Context, userID int) (*entity.TOTP, error) {
+	query := `
+        SELECT user_id, secret, enabled, last_counter, created_at
+        FROM user_totp
+        WHERE user_id = $1
+    `
+	totp := &entity.TOTP{}
+	err := r.db.QueryRowContext(ctx, query, userID).
+		Scan(&totp.UserID, &totp.Secret, &totp.Enabled, &totp.LastCounter, &totp.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, helper.ErrTOTPNotEnrolled
+		}
+
+		r.logger.LogInfo("ошибка при получении настроек второго фактора", err)
+
+		return nil, helper.ErrInternalServer
+	}
+
+	return totp, nil
+}
+
+// SavePendingTOTP сохраняет новый, ещё не подтверждённый секрет. Включённый второй фактор не перезаписывается.
+func (r *twoFactorRepository) SavePendingTOTP(ctx context.Context, userID int, secret string) error {
+	query := `
+        INSERT INTO user_totp (user_id, secret)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_counter = 0, created_at = NOW()
+        WHERE NOT user_totp.enabled
+    `
+	res, err := r.db.ExecContext(ctx, query, userID, secret)
+	if err != nil {
+		r.logger.LogInfo("ошибка при сохранении секрета второго фактора", err)
+		return helper.ErrInternalServer
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения числа обновлённых строк: %w", err)
+	}
+	if affected == 0 {
+		return helper.ErrTOTPAlreadyEnabled
+	}
+
+	return nil
+}
+
+// EnableTOTP включает второй фактор и заменяет коды восстановления одним запросом.
+func (r *twoFactorRepository) EnableTOTP(ctx context.Context, userID int, counter int64, codeHashes []string) error {
+	query := `
+        WITH enabled AS (
+            UPDATE user_totp SET enabled = TRUE, last_counter = $2
+            WHERE user_id = $1 AND NOT enabled
+            RETURNING user_id
+        ), deleted AS (
+            DELETE FROM recovery_codes WHERE user_id IN (SELECT user_id FROM enabled)
+        )
+        INSERT INTO recovery_codes (user_id, code_hash)
+        SELECT enabled.user_id, hash FROM enabled, unnest($3::text[]) AS hash
+    `
+	res, err := r.db.ExecContext(ctx, query, userID, counter, pq.Array(codeHashes))
+	if err != nil {
+		r.logger.LogInfo("ошибка при включении второго фактора", err)
+		return helper.ErrInternalServer
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения числа обновлённых строк: %w", err)
+	}
+	if affected == 0 {
+		return helper.ErrTOTPAlreadyEnabled
+	}
+
+	return nil
+}
+
+// DisableTOTP удаляет секрет и коды восстановления пользователя.
+func (r *twoFactorRepository) DisableTOTP(ctx context.Context, userID int) error {
+	query := `
+        WITH deleted AS (DELETE FROM recovery_codes WHERE user_id = $1)
+        DELETE FROM user_totp WHERE user_id = $1
+    `
+	_, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		r.logger.LogInfo("ошибка при отключении второго фактора", err)
+		return helper.ErrInternalServer
+	}
+
+	return nil
+}
+
+// AdvanceCounter запоминает шаг времени принятого кода. Возвращает false, если код этого
+// или более позднего шага уже был принят.
+func (r *twoFactorRepository) AdvanceCounter(ctx context.Context, userID int, counter int64) (bool, error) {
+	query := `UPDATE user_totp SET last_counter = $2 WHERE user_id = $1 AND last_counter < $2`
+
+	return r.execAffected(ctx, "ошибка при обновлении счётчика второго фактора", query, userID, counter)
+}
+
+// UseRecoveryCode помечает код восстановления использованным. Возвращает false, если такого
+// неиспользованного кода нет.
+func (r *twoFactorRepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error) {
+	query := `
+        UPDATE recovery_codes SET used_at = NOW()
+        WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+    `
+
+	return r.execAffected(ctx, "ошибка при использовании кода восстановления", query, userID, codeHash)
+}
+
+// CreateChallenge сохраняет вход, ожидающий подтверждения вторым фактором.
+func (r *twoFactorRepository) CreateChallenge(ctx context.Context, challenge *entity.LoginChallenge) error {
+	query := `INSERT INTO login_challenges (id, user_id, expires_at) VALUES ($1, $2, $3)`
+	_, err := r.db.ExecContext(ctx, query, challenge.ID, challenge.UserID, challenge.ExpiresAt)
+	if err != nil {
+		r.logger.LogInfo("ошибка при сохранении подтверждения входа", err)
+		return helper.ErrInternalServer
+	}
+
+	return nil
+}
+
+// RegisterChallengeAttempt атомарно учитывает попытку ввода кода. Истёкшие подтверждения и
+// подтверждения с исчерпанными попытками не возвращаются: helper.ErrChallengeNotFound.
+func (r *twoFactorRepository) RegisterChallengeAttempt(
+	ctx context.Context,
+	challengeID string,
+	maxAttempts int,
+) (*entity.LoginChallenge, error) {
+	query := `
+        UPDATE login_challenges SET attempts = attempts + 1
+        WHERE id = $1 AND expires_at > NOW() AND attempts < $2
+        RETURNING id, user_id, attempts, expires_at
+    `
+	challenge := &entity.LoginChallenge{}
+	err := r.db.QueryRowContext(ctx, query, challengeID, maxAttempts).
+		Scan(&challenge.ID, &challenge.UserID, &challenge.Attempts, &challenge.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, helper.ErrChallengeNotFound
+		}
+
+		r.logger.LogInfo("ошибка при учёте попытки подтверждения входа", err)
+
+		return nil, helper.ErrInternalServer
+	}
+
+	return challenge, nil
+}
+
+// DeleteChallenge удаляет подтверждение входа и заодно все истёкшие.
+func (r *twoFactorRepository) DeleteChallenge(ctx context.Context, challengeID string) error {
+	query := `DELETE FROM login_challenges WHERE id = $1 OR expires_at <= NOW()`
+	_, err := r.db.ExecContext(ctx, query, challengeID)
+	if err != nil {
+		r.logger.LogInfo("ошибка при удалении подтверждения входа", err)
+		return helper.ErrInternalServer
+	}
+
+	return nil
+}
+
+func (r *twoFactorRepository) execAffected(ctx context.Context, logMessage, query string, args ...any) (bool, error) {
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.LogInfo(logMessage, err)
+		return false, helper.ErrInternalServer
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения числа обновлённых строк: %w", err)
+	}
+
+	return affected == 1, nil
+}
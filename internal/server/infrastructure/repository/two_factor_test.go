@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactor_TOTP(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTwoFactorRepository(db, new(mockLogger))
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT (.+) FROM user_totp WHERE user_id = \\$1").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "secret", "enabled", "last_counter", "created_at"}).
+			AddRow(7, "v2:1:secret", true, 100, created))
+
+	totp, err := repo.TOTP(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.TOTP{
+		UserID: 7, Secret: "v2:1:secret", Enabled: true, LastCounter: 100, CreatedAt: created,
+	}, totp)
+
+	mock.ExpectQuery("SELECT").WithArgs(8).WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.TOTP(context.Background(), 8)
+	assert.ErrorIs(t, err, helper.ErrTOTPNotEnrolled)
+}
+
+func TestTwoFactor_SavePendingTOTP(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTwoFactorRepository(db, new(mockLogger))
+
+	mock.ExpectExec("INSERT INTO user_totp (.+) WHERE NOT user_totp.enabled").
+		WithArgs(7, "secret").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.SavePendingTOTP(context.Background(), 7, "secret"))
+
+	mock.ExpectExec("INSERT INTO user_totp").
+		WithArgs(7, "secret").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.SavePendingTOTP(context.Background(), 7, "secret"), helper.ErrTOTPAlreadyEnabled)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactor_EnableTOTP(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTwoFactorRepository(db, new(mockLogger))
+	hashes := []string{"h1", "h2"}
+
+	mock.ExpectExec("UPDATE user_totp SET enabled = TRUE(.+)INSERT INTO recovery_codes").
+		WithArgs(7, int64(42), "{\"h1\",\"h2\"}").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	assert.NoError(t, repo.EnableTOTP(context.Background(), 7, 42, hashes))
+
+	mock.ExpectExec("UPDATE user_totp").
+		WithArgs(7, int64(42), "{\"h1\",\"h2\"}").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.EnableTOTP(context.Background(), 7, 42, hashes), helper.ErrTOTPAlreadyEnabled)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactor_AdvanceCounterAndRecoveryCodes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTwoFactorRepository(db, new(mockLogger))
+
+	mock.ExpectExec("UPDATE user_totp SET last_counter = \\$2 WHERE user_id = \\$1 AND last_counter < \\$2").
+		WithArgs(7, int64(42)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	advanced, err := repo.AdvanceCounter(context.Background(), 7, 42)
+	assert.NoError(t, err)
+	assert.False(t, advanced)
+
+	mock.ExpectExec("UPDATE recovery_codes SET used_at = NOW\\(\\)").
+		WithArgs(7, "hash").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	used, err := repo.UseRecoveryCode(context.Background(), 7, "hash")
+	assert.NoError(t, err)
+	assert.True(t, used)
+
+	mock.ExpectExec("DELETE FROM recovery_codes (.+) DELETE FROM user_totp").
+		WithArgs(7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.DisableTOTP(context.Background(), 7))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactor_Challenges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTwoFactorRepository(db, new(mockLogger))
+	expires := time.Date(2024, 1, 1, 12, 5, 0, 0, time.UTC)
+
+	mock.ExpectExec("INSERT INTO login_challenges").
+		WithArgs("cid", 7, expires).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.CreateChallenge(context.Background(), &entity.LoginChallenge{
+		ID: "cid", UserID: 7, ExpiresAt: expires,
+	}))
+
+	mock.ExpectQuery("UPDATE login_challenges SET attempts = attempts \\+ 1").
+		WithArgs("cid", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "attempts", "expires_at"}).
+			AddRow("cid", 7, 1, expires))
+	challenge, err := repo.RegisterChallengeAttempt(context.Background(), "cid", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.LoginChallenge{ID: "cid", UserID: 7, Attempts: 1, ExpiresAt: expires}, challenge)
+
+	mock.ExpectQuery("UPDATE login_challenges").WithArgs("cid", 5).WillReturnError(sql.ErrNoRows)
+	_, err = repo.RegisterChallengeAttempt(context.Background(), "cid", 5)
+	assert.ErrorIs(t, err, helper.ErrChallengeNotFound)
+
+	mock.ExpectExec("DELETE FROM login_challenges").
+		WithArgs("cid").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.DeleteChallenge(context.Background(), "cid"))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -27,8 +27,22 @@ func NewUser(db storager, logger logger.CustomLogger) *User {
 }
 
 func (r *User) Save(ctx context.Context, user *entity.User) error {
-	query := `INSERT INTO users (login, password) VALUES ($1, $2) RETURNING id`
-	err := r.db.QueryRowxContext(ctx, query, user.Login, user.Password).Scan(&user.ID)
+	query := `
+        INSERT INTO users (login, password, kdf_salt, kdf_time, kdf_memory_kib, kdf_threads, kdf_key_check)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id
+    `
+	err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		user.Login,
+		user.Password,
+		user.Salt,
+		user.Time,
+		user.MemoryKiB,
+		user.Threads,
+		user.KeyCheck,
+	).Scan(&user.ID)
 	if err != nil {
 		r.logger.LogInfo("ошибка при сохранении пользователя", err)
 		return helper.ErrInternalServer
@@ -49,9 +63,25 @@ func (r *User) ExistsByLogin(ctx context.Context, login string) (bool, error) {
 }
 
 func (r *User) User(ctx context.Context, login string) (*entity.User, error) {
+	return r.findUser(ctx, "login = $1", login)
+}
+
+// UserByID возвращает пользователя по ID, например при подтверждении входа вторым фактором.
+func (r *User) UserByID(ctx context.Context, id int) (*entity.User, error) {
+	return r.findUser(ctx, "id = $1", id)
+}
+
+func (r *User) findUser(ctx context.Context, condition string, arg any) (*entity.User, error) {
 	var user entity.User
-	query := `SELECT id, login, password FROM users WHERE login = $1`
-	err := r.db.GetContext(ctx, &user, query, login)
+	query := `
+        SELECT id, login, password, kdf_salt,
+            COALESCE(kdf_time, 0) AS kdf_time,
+            COALESCE(kdf_memory_kib, 0) AS kdf_memory_kib,
+            COALESCE(kdf_threads, 0) AS kdf_threads,
+            kdf_key_check
+        FROM users
+        WHERE ` + condition
+	err := r.db.GetContext(ctx, &user, query, arg)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, helper.ErrInvalidCredentials
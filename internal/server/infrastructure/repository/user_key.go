@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+)
+
+type userKeyRepository struct {
+	db     dataStorager
+	logger logger.CustomLogger
+}
+
+// NewUserKeyRepository - конструктор репозитория ключей шифрования пользователей.
+func NewUserKeyRepository(db dataStorager, logger logger.CustomLogger) *userKeyRepository {
+	return &userKeyRepository{db: db, logger: logger}
+}
+
+// ActiveKey возвращает действующий ключ пользователя или helper.ErrKeyNotFound.
+func (r *userKeyRepository) ActiveKey(ctx context.Context, userID int) (*entity.UserKey, error) {
+	query := `
+        SELECT id, user_id, master_key_id, wrapped_key, active, created_at
+        FROM user_keys
+        WHERE user_id = $1 AND active
+    `
+
+	return r.scanKey(r.db.QueryRowContext(ctx, query, userID))
+}
+
+// Key возвращает ключ пользователя по ID, в том числе выведенный из оборота.
+func (r *userKeyRepository) Key(ctx context.Context, userID, keyID int) (*entity.UserKey, error) {
+	query := `
+        SELECT id, user_id, master_key_id, wrapped_key, active, created_at
+        FROM user_keys
+        WHERE id = $1 AND user_id = $2
+    `
+
+	return r.scanKey(r.db.QueryRowContext(ctx, query, keyID, userID))
+}
+
+// CreateKey сохраняет новый действующий ключ пользователя.
+// Если параллельный запрос успел создать ключ раньше, возвращается уже существующий ключ.
+func (r *userKeyRepository) CreateKey(ctx context.Context, key *entity.UserKey) (*entity.UserKey, error) {
+	query := `
+        INSERT INTO user_keys (user_id, master_key_id, wrapped_key, active)
+        VALUES ($1, $2, $3, TRUE)
+        ON CONFLICT (user_id) WHERE active DO NOTHING
+    `
+	_, err := r.db.ExecContext(ctx, query, key.UserID, key.MasterKeyID, key.WrappedKey)
+	if err != nil {
+		r.logger.LogInfo("ошибка при сохранении ключа пользователя", err)
+		return nil, helper.ErrInternalServer
+	}
+
+	return r.ActiveKey(ctx, key.UserID)
+}
+
+// KeysNotWrappedWith возвращает пачку ключей, обёрнутых не указанным мастер-ключом.
+func (r *userKeyRepository) KeysNotWrappedWith(
+	ctx context.Context,
+	masterKeyID string,
+	limit int,
+) ([]*entity.UserKey, error) {
+	query := `
+        SELECT id, user_id, master_key_id, wrapped_key, active, created_at
+        FROM user_keys
+        WHERE master_key_id <> $1
+        ORDER BY id
+        LIMIT $2
+    `
+	rows, err := r.db.QueryContext(ctx, query, masterKeyID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса к базе данных: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*entity.UserKey
+	for rows.Next() {
+		var key entity.UserKey
+		err := rows.Scan(&key.ID, &key.UserID, &key.MasterKeyID, &key.WrappedKey, &key.Active, &key.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения данных из базы данных: %w", err)
+		}
+		keys = append(keys, &key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при итерации по строкам: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Rewrap заменяет обёртку ключа, если с момента чтения её никто не изменил.
+func (r *userKeyRepository) Rewrap(ctx context.Context, key *entity.UserKey, previousMasterKeyID string) (bool, error) {
+	query := `
+        UPDATE user_keys
+        SET master_key_id = $1, wrapped_key = $2
+        WHERE id = $3 AND master_key_id = $4
+    `
+	res, err := r.db.ExecContext(ctx, query, key.MasterKeyID, key.WrappedKey, key.ID, previousMasterKeyID)
+	if err != nil {
+		return false, fmt.Errorf("ошибка обновления ключа пользователя: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения числа обновлённых строк: %w", err)
+	}
+
+	return affected == 1, nil
+}
+
+// RetireActiveKeys выводит из оборота все действующие ключи: при следующей записи будут созданы новые.
+// Старые ключи остаются доступными для расшифровки, пока данные не будут перешифрованы.
+func (r *userKeyRepository) RetireActiveKeys(ctx context.Context) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `UPDATE user_keys SET active = FALSE WHERE active`)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка вывода ключей из оборота: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения числа обновлённых строк: %w", err)
+	}
+
+	return affected, nil
+}
+
+func (r *userKeyRepository) scanKey(row *sql.Row) (*entity.UserKey, error) {
+	key := &entity.UserKey{}
+	err := row.Scan(&key.ID, &key.UserID, &key.MasterKeyID, &key.WrappedKey, &key.Active, &key.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, helper.ErrKeyNotFound
+		}
+
+		r.logger.LogInfo("ошибка при получении ключа пользователя", err)
+
+		return nil, helper.ErrInternalServer
+	}
+
+	return key, nil
+}
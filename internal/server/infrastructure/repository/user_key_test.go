@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+var userKeyColumns = []string{"id", "user_id", "master_key_id", "wrapped_key", "active", "created_at"}
+
+func TestUserKey_ActiveKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserKeyRepository(db, new(mockLogger))
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT id, user_id, master_key_id, wrapped_key, active, created_at FROM user_keys").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(userKeyColumns).AddRow(3, 7, "1", "wrapped", true, created))
+
+	key, err := repo.ActiveKey(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.UserKey{
+		ID:          3,
+		UserID:      7,
+		MasterKeyID: "1",
+		WrappedKey:  "wrapped",
+		Active:      true,
+		CreatedAt:   created,
+	}, key)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserKey_ActiveKey_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserKeyRepository(db, new(mockLogger))
+
+	mock.ExpectQuery("SELECT id").WithArgs(7).WillReturnError(sql.ErrNoRows)
+
+	key, err := repo.ActiveKey(context.Background(), 7)
+
+	assert.Nil(t, key)
+	assert.ErrorIs(t, err, helper.ErrKeyNotFound)
+}
+
+func TestUserKey_CreateKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserKeyRepository(db, new(mockLogger))
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("INSERT INTO user_keys").
+		WithArgs(7, "2", "wrapped").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT id").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(userKeyColumns).AddRow(3, 7, "1", "existing", true, created))
+
+	key, err := repo.CreateKey(context.Background(), &entity.UserKey{UserID: 7, MasterKeyID: "2", WrappedKey: "wrapped"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "existing", key.WrappedKey, "при гонке возвращается ключ, созданный первым")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserKey_KeysNotWrappedWith(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserKeyRepository(db, new(mockLogger))
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT id, user_id, master_key_id, wrapped_key, active, created_at FROM user_keys WHERE master_key_id").
+		WithArgs("2", 100).
+		WillReturnRows(sqlmock.NewRows(userKeyColumns).
+			AddRow(1, 7, "1", "a", true, created).
+			AddRow(2, 8, "1", "b", false, created))
+
+	keys, err := repo.KeysNotWrappedWith(context.Background(), "2", 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+	assert.Equal(t, 8, keys[1].UserID)
+	assert.False(t, keys[1].Active)
+}
+
+func TestUserKey_Rewrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		expected bool
+	}{
+		{name: "ключ переобёрнут", affected: 1, expected: true},
+		{name: "ключ уже изменён", affected: 0, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			repo := NewUserKeyRepository(db, new(mockLogger))
+
+			mock.ExpectExec("UPDATE user_keys SET master_key_id").
+				WithArgs("2", "new", 3, "1").
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			ok, err := repo.Rewrap(context.Background(), &entity.UserKey{ID: 3, MasterKeyID: "2", WrappedKey: "new"}, "1")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+}
+
+func TestUserKey_RetireActiveKeys(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserKeyRepository(db, new(mockLogger))
+
+	mock.ExpectExec("UPDATE user_keys SET active = FALSE").WillReturnResult(sqlmock.NewResult(0, 5))
+
+	retired, err := repo.RetireActiveKeys(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), retired)
+}
@@ -32,7 +32,7 @@ func TestUser_Save_Success(t *testing.T) {
 	}
 
 	mock.ExpectQuery("INSERT INTO users").
-		WithArgs("testuser", "password123").
+		WithArgs("testuser", "password123", []byte(nil), uint32(0), uint32(0), uint8(0), []byte(nil)).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
 	err = repo.Save(context.Background(), user)
@@ -56,7 +56,7 @@ func TestUser_Save_Error(t *testing.T) {
 	}
 
 	mock.ExpectQuery("INSERT INTO users").
-		WithArgs("testuser", "password123").
+		WithArgs("testuser", "password123", []byte(nil), uint32(0), uint32(0), uint8(0), []byte(nil)).
 		WillReturnError(errors.New("some error"))
 
 	err = repo.Save(context.Background(), user)
@@ -124,10 +124,11 @@ func TestUser_User_Success(t *testing.T) {
 		Password: "password123",
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "login", "password"}).
-		AddRow(expectedUser.ID, expectedUser.Login, expectedUser.Password)
+	rows := sqlmock.NewRows([]string{
+		"id", "login", "password", "kdf_salt", "kdf_time", "kdf_memory_kib", "kdf_threads", "kdf_key_check",
+	}).AddRow(expectedUser.ID, expectedUser.Login, expectedUser.Password, nil, 0, 0, 0, nil)
 
-	mock.ExpectQuery("SELECT id, login, password FROM users WHERE login = \\$1").
+	mock.ExpectQuery("SELECT id, login, password, kdf_salt.+FROM users WHERE login = \\$1").
 		WithArgs(login).
 		WillReturnRows(rows)
 
@@ -148,7 +149,7 @@ func TestUser_User_NotFound(t *testing.T) {
 
 	login := "nonexistentuser"
 
-	mock.ExpectQuery("SELECT id, login, password FROM users WHERE login = \\$1").
+	mock.ExpectQuery("SELECT id, login, password, kdf_salt.+FROM users WHERE login = \\$1").
 		WithArgs(login).
 		WillReturnError(sql.ErrNoRows)
 
@@ -170,7 +171,7 @@ func TestUser_User_Error(t *testing.T) {
 
 	login := "testuser"
 
-	mock.ExpectQuery("SELECT id, login, password FROM users WHERE login = \\$1").
+	mock.ExpectQuery("SELECT id, login, password, kdf_salt.+FROM users WHERE login = \\$1").
 		WithArgs(login).
 		WillReturnError(errors.New("database error"))
 
@@ -180,3 +181,28 @@ func TestUser_User_Error(t *testing.T) {
 	assert.Nil(t, user)
 	assert.EqualError(t, err, "ошибка при поиске пользователя")
 }
+
+func TestUser_UserByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUser(sqlx.NewDb(db, "sqlmock"), new(mockLogger))
+
+	rows := sqlmock.NewRows([]string{
+		"id", "login", "password", "kdf_salt", "kdf_time", "kdf_memory_kib", "kdf_threads", "kdf_key_check",
+	}).AddRow(7, "testuser", "hash", nil, 0, 0, 0, nil)
+	mock.ExpectQuery("SELECT id, login, password, kdf_salt.+FROM users WHERE id = \\$1").
+		WithArgs(7).
+		WillReturnRows(rows)
+
+	user, err := repo.UserByID(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.User{ID: 7, Login: "testuser", Password: "hash"}, user)
+
+	mock.ExpectQuery("SELECT").WithArgs(8).WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.UserByID(context.Background(), 8)
+	assert.ErrorIs(t, err, helper.ErrInvalidCredentials)
+}
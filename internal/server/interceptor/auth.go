@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/NikolosHGW/goph-keeper/internal/contextkey"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -12,22 +13,32 @@ import (
 )
 
 type tokenValidator interface {
-	ValidateToken(tokenString string) (int, error)
+	ValidateToken(tokenString string) (*entity.Claims, error)
+}
+
+type sessionChecker interface {
+	IsActive(ctx context.Context, sessionID string) (bool, error)
 }
 
 type AuthInterceptor struct {
-	tokenService  tokenValidator
-	noAuthMethods map[string]bool
+	tokenService   tokenValidator
+	sessionService sessionChecker
+	noAuthMethods  map[string]bool
 }
 
-func NewAuthInterceptor(tokenService tokenValidator, noAuthMethods []string) *AuthInterceptor {
+func NewAuthInterceptor(
+	tokenService tokenValidator,
+	sessionService sessionChecker,
+	noAuthMethods []string,
+) *AuthInterceptor {
 	m := make(map[string]bool)
 	for _, method := range noAuthMethods {
 		m[method] = true
 	}
 	return &AuthInterceptor{
-		tokenService:  tokenService,
-		noAuthMethods: m,
+		tokenService:   tokenService,
+		sessionService: sessionService,
+		noAuthMethods:  m,
 	}
 }
 
@@ -38,40 +49,86 @@ func (ai *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-
-		if ai.noAuthMethods[info.FullMethod] {
-			return handler(ctx, req)
+		ctx, err := ai.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
 		}
 
-		userID, err := ai.authorize(ctx)
+		return handler(ctx, req)
+	}
+}
+
+// Stream - проверка токена для потоковых методов с теми же исключениями, что и в Unary.
+// Данные пользователя передаются обработчику через контекст обёрнутого потока.
+func (ai *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := ai.authenticate(ss.Context(), info.FullMethod)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		ctx = context.WithValue(ctx, contextkey.UserIDKey, userID)
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
 
-		return handler(ctx, req)
+// authenticatedStream подменяет контекст потока контекстом с данными пользователя.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate проверяет токен, если метод его требует, и добавляет в контекст ID пользователя и сессии.
+func (ai *AuthInterceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if ai.noAuthMethods[method] {
+		return ctx, nil
+	}
+
+	claims, err := ai.authorize(ctx)
+	if err != nil {
+		return nil, err
 	}
+
+	ctx = context.WithValue(ctx, contextkey.UserIDKey, claims.UserID)
+	ctx = context.WithValue(ctx, contextkey.SessionIDKey, claims.SessionID)
+
+	return ctx, nil
 }
 
-func (ai *AuthInterceptor) authorize(ctx context.Context) (int, error) {
+func (ai *AuthInterceptor) authorize(ctx context.Context) (*entity.Claims, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return 0, status.Error(codes.Unauthenticated, "метаданные не предоставлены")
+		return nil, status.Error(codes.Unauthenticated, "метаданные не предоставлены")
 	}
 
 	values := md["authorization"]
 	if len(values) == 0 {
-		return 0, status.Error(codes.Unauthenticated, "токен авторизации не предоставлен")
+		return nil, status.Error(codes.Unauthenticated, "токен авторизации не предоставлен")
 	}
 
 	accessToken := values[0]
 	accessToken = strings.TrimPrefix(accessToken, "Bearer ")
 
-	userID, err := ai.tokenService.ValidateToken(accessToken)
+	claims, err := ai.tokenService.ValidateToken(accessToken)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "недействительный токен доступа")
+	}
+
+	active, err := ai.sessionService.IsActive(ctx, claims.SessionID)
 	if err != nil {
-		return 0, status.Error(codes.Unauthenticated, "недействительный токен доступа")
+		return nil, status.Error(codes.Internal, "не удалось проверить сессию")
+	}
+	if !active {
+		return nil, status.Error(codes.Unauthenticated, "сессия завершена")
 	}
 
-	return userID, nil
+	return claims, nil
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/NikolosHGW/goph-keeper/internal/contextkey"
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
@@ -18,15 +19,26 @@ type MockTokenValidator struct {
 	mock.Mock
 }
 
-func (m *MockTokenValidator) ValidateToken(tokenString string) (int, error) {
+func (m *MockTokenValidator) ValidateToken(tokenString string) (*entity.Claims, error) {
 	args := m.Called(tokenString)
-	return args.Int(0), args.Error(1)
+	claims, _ := args.Get(0).(*entity.Claims)
+	return claims, args.Error(1)
+}
+
+type MockSessionChecker struct {
+	mock.Mock
+}
+
+func (m *MockSessionChecker) IsActive(ctx context.Context, sessionID string) (bool, error) {
+	args := m.Called(ctx, sessionID)
+	return args.Bool(0), args.Error(1)
 }
 
 func TestAuthInterceptor_Unary(t *testing.T) {
 	mockValidator := new(MockTokenValidator)
+	mockSessions := new(MockSessionChecker)
 	noAuthMethods := []string{"/package.Service/NoAuthMethod"}
-	interceptor := NewAuthInterceptor(mockValidator, noAuthMethods)
+	interceptor := NewAuthInterceptor(mockValidator, mockSessions, noAuthMethods)
 
 	tests := []struct {
 		name           string
@@ -57,7 +69,9 @@ func TestAuthInterceptor_Unary(t *testing.T) {
 				"authorization": "Bearer validtoken",
 			}),
 			mockSetup: func() {
-				mockValidator.On("ValidateToken", "validtoken").Return(123, nil)
+				mockValidator.On("ValidateToken", "validtoken").
+					Return(&entity.Claims{UserID: 123, SessionID: "sid"}, nil)
+				mockSessions.On("IsActive", mock.Anything, "sid").Return(true, nil)
 			},
 			expectedResult: 123,
 			expectedError:  nil,
@@ -66,6 +80,9 @@ func TestAuthInterceptor_Unary(t *testing.T) {
 				if !ok {
 					return nil, errors.New("userID не найден в контексте")
 				}
+				if ctx.Value(contextkey.SessionIDKey) != "sid" {
+					return nil, errors.New("sessionID не найден в контексте")
+				}
 				return userID, nil
 			},
 		},
@@ -100,7 +117,7 @@ func TestAuthInterceptor_Unary(t *testing.T) {
 				"authorization": "Bearer invalidtoken",
 			}),
 			mockSetup: func() {
-				mockValidator.On("ValidateToken", "invalidtoken").Return(0, errors.New("invalid token"))
+				mockValidator.On("ValidateToken", "invalidtoken").Return(nil, errors.New("invalid token"))
 			},
 			expectedResult: nil,
 			expectedError:  status.Error(codes.Unauthenticated, "недействительный токен доступа"),
@@ -108,6 +125,23 @@ func TestAuthInterceptor_Unary(t *testing.T) {
 				return nil, nil
 			},
 		},
+		{
+			name:   "Сессия отозвана",
+			method: "/package.Service/AuthMethod",
+			metadata: metadata.New(map[string]string{
+				"authorization": "Bearer revokedtoken",
+			}),
+			mockSetup: func() {
+				mockValidator.On("ValidateToken", "revokedtoken").
+					Return(&entity.Claims{UserID: 123, SessionID: "revoked"}, nil)
+				mockSessions.On("IsActive", mock.Anything, "revoked").Return(false, nil)
+			},
+			expectedResult: nil,
+			expectedError:  status.Error(codes.Unauthenticated, "сессия завершена"),
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -138,6 +172,175 @@ func TestAuthInterceptor_Unary(t *testing.T) {
 			}
 
 			mockValidator.AssertExpectations(t)
+			mockSessions.AssertExpectations(t)
+		})
+	}
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestAuthInterceptor_Stream(t *testing.T) {
+	mockValidator := new(MockTokenValidator)
+	mockSessions := new(MockSessionChecker)
+	noAuthMethods := []string{"/package.Service/NoAuthMethod"}
+	interceptor := NewAuthInterceptor(mockValidator, mockSessions, noAuthMethods)
+
+	noopHandler := func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		metadata      metadata.MD
+		mockSetup     func()
+		expectedError error
+		handler       grpc.StreamHandler
+	}{
+		{
+			name:   "Метод без аутентификации должен обходить проверку",
+			method: "/package.Service/NoAuthMethod",
+			metadata: metadata.New(map[string]string{
+				"authorization": "Bearer validtoken",
+			}),
+			mockSetup:     func() {},
+			expectedError: nil,
+			handler: func(srv interface{}, stream grpc.ServerStream) error {
+				if stream.Context().Value(contextkey.UserIDKey) != nil {
+					return errors.New("userID не должен попадать в контекст")
+				}
+				return nil
+			},
+		},
+		{
+			name:   "Успешная аутентификация",
+			method: "/package.Service/AuthMethod",
+			metadata: metadata.New(map[string]string{
+				"authorization": "Bearer validtoken",
+			}),
+			mockSetup: func() {
+				mockValidator.On("ValidateToken", "validtoken").
+					Return(&entity.Claims{UserID: 123, SessionID: "sid"}, nil)
+				mockSessions.On("IsActive", mock.Anything, "sid").Return(true, nil)
+			},
+			expectedError: nil,
+			handler: func(srv interface{}, stream grpc.ServerStream) error {
+				userID, ok := stream.Context().Value(contextkey.UserIDKey).(int)
+				if !ok || userID != 123 {
+					return errors.New("userID не найден в контексте")
+				}
+				if stream.Context().Value(contextkey.SessionIDKey) != "sid" {
+					return errors.New("sessionID не найден в контексте")
+				}
+				if _, ok := metadata.FromIncomingContext(stream.Context()); !ok {
+					return errors.New("метаданные запроса потеряны")
+				}
+				return nil
+			},
+		},
+		{
+			name:          "Отсутствуют метаданные",
+			method:        "/package.Service/AuthMethod",
+			metadata:      nil,
+			mockSetup:     func() {},
+			expectedError: status.Error(codes.Unauthenticated, "метаданные не предоставлены"),
+			handler:       noopHandler,
+		},
+		{
+			name:     "Отсутствует токен авторизации",
+			method:   "/package.Service/AuthMethod",
+			metadata: metadata.New(map[string]string{
+				// "authorization" отсутствует
+			}),
+			mockSetup:     func() {},
+			expectedError: status.Error(codes.Unauthenticated, "токен авторизации не предоставлен"),
+			handler:       noopHandler,
+		},
+		{
+			name:   "Недействительный токен",
+			method: "/package.Service/AuthMethod",
+			metadata: metadata.New(map[string]string{
+				"authorization": "Bearer invalidtoken",
+			}),
+			mockSetup: func() {
+				mockValidator.On("ValidateToken", "invalidtoken").Return(nil, errors.New("invalid token"))
+			},
+			expectedError: status.Error(codes.Unauthenticated, "недействительный токен доступа"),
+			handler:       noopHandler,
+		},
+		{
+			name:   "Сессия отозвана",
+			method: "/package.Service/AuthMethod",
+			metadata: metadata.New(map[string]string{
+				"authorization": "Bearer revokedtoken",
+			}),
+			mockSetup: func() {
+				mockValidator.On("ValidateToken", "revokedtoken").
+					Return(&entity.Claims{UserID: 123, SessionID: "revoked"}, nil)
+				mockSessions.On("IsActive", mock.Anything, "revoked").Return(false, nil)
+			},
+			expectedError: status.Error(codes.Unauthenticated, "сессия завершена"),
+			handler:       noopHandler,
+		},
+		{
+			name:   "Ошибка проверки сессии",
+			method: "/package.Service/AuthMethod",
+			metadata: metadata.New(map[string]string{
+				"authorization": "Bearer brokentoken",
+			}),
+			mockSetup: func() {
+				mockValidator.On("ValidateToken", "brokentoken").
+					Return(&entity.Claims{UserID: 123, SessionID: "broken"}, nil)
+				mockSessions.On("IsActive", mock.Anything, "broken").Return(false, errors.New("db down"))
+			},
+			expectedError: status.Error(codes.Internal, "не удалось проверить сессию"),
+			handler:       noopHandler,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			var ctx context.Context
+			if tt.metadata != nil {
+				ctx = metadata.NewIncomingContext(context.Background(), tt.metadata)
+			} else {
+				ctx = context.Background()
+			}
+
+			handlerCalled := false
+			handler := func(srv interface{}, stream grpc.ServerStream) error {
+				handlerCalled = true
+				return tt.handler(srv, stream)
+			}
+
+			streamInterceptor := interceptor.Stream()
+
+			err := streamInterceptor(
+				nil,
+				&mockServerStream{ctx: ctx},
+				&grpc.StreamServerInfo{FullMethod: tt.method, IsServerStream: true},
+				handler,
+			)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+				assert.False(t, handlerCalled, "обработчик не вызывается без аутентификации")
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, handlerCalled)
+			}
+
+			mockValidator.AssertExpectations(t)
+			mockSessions.AssertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+)
+
+const (
+	// MaxChunkSize - наибольший размер части файла, которую принимает сервер.
+	MaxChunkSize = 2 << 20
+	// MaxChunkCount ограничивает размер одного файла: MaxChunkCount * MaxChunkSize.
+	MaxChunkCount = 4096
+
+	binaryInfoType = "binary"
+	uploadIDLength = 16
+)
+
+type binaryRepo interface {
+	CreateUpload(ctx context.Context, upload *entity.Upload) error
+	Upload(ctx context.Context, userID int, uploadID string) (*entity.Upload, error)
+	UploadByData(ctx context.Context, userID, dataID int) (*entity.Upload, error)
+	SaveChunk(ctx context.Context, uploadID string, seq, size int, sha256 string) error
+	InlineChunk(ctx context.Context, uploadID string, seq int) (string, error)
+	ChunkHashes(ctx context.Context, uploadID string) ([]string, error)
+	SetReplaceID(ctx context.Context, uploadID string, dataID int) error
+	AttachData(ctx context.Context, upload *entity.Upload, dataID int, sha256 string) error
+}
+
+type blobStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+type binaryRecords interface {
+	AddData(ctx context.Context, userID int, data *entity.UserData) (int, error)
+	GetDataByID(ctx context.Context, userID, dataID int) (*entity.UserData, error)
+	UpdateData(ctx context.Context, userID int, data *entity.UserData, expectedRevision int64) error
+}
+
+type binaryService struct {
+	repo      binaryRepo
+	records   binaryRecords
+	blobs     blobStore
+	encryptor encryptor
+}
+
+// NewBinaryService - конструктор сервиса загрузки бинарных файлов по частям.
+// Части шифруются ключом пользователя и хранятся в blobs, в базе остаются только их размеры и хеши.
+// Запись создаётся через records после получения последней части, поэтому недогруженный файл
+// не виден ни в списке данных, ни при синхронизации.
+func NewBinaryService(repo binaryRepo, records binaryRecords, blobs blobStore, encryptor encryptor) *binaryService {
+	return &binaryService{repo: repo, records: records, blobs: blobs, encryptor: encryptor}
+}
+
+// chunkKey - ключ части seq файла в хранилище.
+func chunkKey(blobRef string, seq int) string {
+	return blobRef + "/" + strconv.Itoa(seq)
+}
+
+// StartUpload начинает загрузку или, если upload.ID задан, возвращает прерванную загрузку,
+// чтобы клиент продолжил отправку с части Received.
+func (s *binaryService) StartUpload(ctx context.Context, userID int, upload *entity.Upload) (*entity.Upload, error) {
+	if upload.ID != "" {
+		return s.repo.Upload(ctx, userID, upload.ID)
+	}
+
+	if upload.ChunkCount < 1 || upload.ChunkCount > MaxChunkCount {
+		return nil, fmt.Errorf("%w: число частей должно быть от 1 до %d", helper.ErrInvalidUpload, MaxChunkCount)
+	}
+
+	if upload.ReplaceID != 0 {
+		current, err := s.records.GetDataByID(ctx, userID, upload.ReplaceID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения заменяемой записи: %w", err)
+		}
+		if current.InfoType != binaryInfoType {
+			return nil, fmt.Errorf("%w: запись %d не является файлом", helper.ErrInvalidUpload, upload.ReplaceID)
+		}
+	}
+
+	id, err := randomHex(uploadIDLength)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.encryptor.Encrypt(ctx, userID, upload.Info)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка шифрования Info: %w", err)
+	}
+	meta, err := s.encryptor.Encrypt(ctx, userID, upload.Meta)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка шифрования Meta: %w", err)
+	}
+	var searchTokens string
+	if upload.SearchTokens != "" {
+		searchTokens, err = s.encryptor.Encrypt(ctx, userID, upload.SearchTokens)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка шифрования токенов поиска: %w", err)
+		}
+	}
+
+	created := &entity.Upload{
+		ID:           id,
+		UserID:       userID,
+		BlobRef:      fmt.Sprintf("%d/%s", userID, id),
+		ReplaceID:    upload.ReplaceID,
+		ChunkCount:   upload.ChunkCount,
+		Info:         info,
+		Meta:         meta,
+		SearchTokens: searchTokens,
+	}
+	if err = s.repo.CreateUpload(ctx, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// SaveChunk сохраняет часть файла. Уже принятая часть пропускается, чтобы клиент мог
+// безопасно повторить отправку после обрыва. После последней части создаётся или обновляется запись;
+// если это не удалось, запись создаётся при повторной отправке любой части.
+func (s *binaryService) SaveChunk(
+	ctx context.Context,
+	userID int,
+	uploadID string,
+	seq int,
+	data, checksum []byte,
+) (*entity.Upload, error) {
+	upload, err := s.repo.Upload(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	if upload.Completed() {
+		return upload, nil
+	}
+	if upload.Received == upload.ChunkCount {
+		if err = s.finish(ctx, upload); err != nil {
+			return nil, err
+		}
+		return upload, nil
+	}
+	if seq < upload.Received {
+		return upload, nil
+	}
+	if seq != upload.Received {
+		return nil, fmt.Errorf("%w: ожидается часть %d, получена %d", helper.ErrChunkOutOfOrder, upload.Received, seq)
+	}
+	if upload.BlobRef == "" {
+		return nil, fmt.Errorf("%w: загрузка начата до перехода на хранилище файлов", helper.ErrInvalidUpload)
+	}
+	if len(data) > MaxChunkSize {
+		return nil, helper.ErrChunkTooLarge
+	}
+	sum := sha256.Sum256(data)
+	if !bytes.Equal(sum[:], checksum) {
+		return nil, helper.ErrChecksumMismatch
+	}
+
+	encrypted, err := s.encryptor.Encrypt(ctx, userID, base64.StdEncoding.EncodeToString(data))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка шифрования части файла: %w", err)
+	}
+	if err = s.blobs.Put(ctx, chunkKey(upload.BlobRef, seq), []byte(encrypted)); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения части файла в хранилище: %w", err)
+	}
+	if err = s.repo.SaveChunk(ctx, upload.ID, seq, len(data), hex.EncodeToString(sum[:])); err != nil {
+		return nil, err
+	}
+	upload.Received++
+	upload.Size += int64(len(data))
+
+	if upload.Received == upload.ChunkCount {
+		if err = s.finish(ctx, upload); err != nil {
+			return nil, err
+		}
+	}
+
+	return upload, nil
+}
+
+// finish создаёт или обновляет запись файла и привязывает к ней загрузку. Повтор после ошибки безопасен:
+// созданная запись сразу запоминается в загрузке как заменяемая, и повтор обновит её, а не создаст вторую.
+func (s *binaryService) finish(ctx context.Context, upload *entity.Upload) error {
+	info, err := s.encryptor.Decrypt(ctx, upload.UserID, upload.Info)
+	if err != nil {
+		return fmt.Errorf("ошибка расшифровки Info: %w", err)
+	}
+	meta, err := s.encryptor.Decrypt(ctx, upload.UserID, upload.Meta)
+	if err != nil {
+		return fmt.Errorf("ошибка расшифровки Meta: %w", err)
+	}
+
+	data := &entity.UserData{ID: upload.ReplaceID, InfoType: binaryInfoType, Info: info, Meta: meta}
+	if upload.SearchTokens != "" {
+		searchTokens, err := s.encryptor.Decrypt(ctx, upload.UserID, upload.SearchTokens)
+		if err != nil {
+			return fmt.Errorf("ошибка расшифровки токенов поиска: %w", err)
+		}
+		data.SearchTokens = strings.Fields(searchTokens)
+	}
+	if upload.ReplaceID != 0 {
+		if err = s.records.UpdateData(ctx, upload.UserID, data, 0); err != nil {
+			return fmt.Errorf("ошибка обновления записи файла: %w", err)
+		}
+	} else {
+		data.ID, err = s.records.AddData(ctx, upload.UserID, data)
+		if err != nil {
+			return fmt.Errorf("ошибка создания записи файла: %w", err)
+		}
+		if err = s.repo.SetReplaceID(ctx, upload.ID, data.ID); err != nil {
+			return err
+		}
+		upload.ReplaceID = data.ID
+	}
+
+	hash, err := s.fileHash(ctx, upload.ID)
+	if err != nil {
+		return err
+	}
+	if err = s.repo.AttachData(ctx, upload, data.ID, hash); err != nil {
+		return err
+	}
+	upload.DataID = data.ID
+
+	return nil
+}
+
+// fileHash - SHA-256 от последовательности SHA-256 частей файла. Так хеш файла считается
+// без повторного чтения частей из хранилища.
+func (s *binaryService) fileHash(ctx context.Context, uploadID string) (string, error) {
+	hashes, err := s.repo.ChunkHashes(ctx, uploadID)
+	if err != nil {
+		return "", err
+	}
+
+	hasher := sha256.New()
+	for _, hash := range hashes {
+		raw, err := hex.DecodeString(hash)
+		if err != nil {
+			return "", fmt.Errorf("некорректный хеш части файла: %w", err)
+		}
+		hasher.Write(raw)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// Download передаёт в send расшифрованные части файла записи dataID, начиная с части from.
+func (s *binaryService) Download(
+	ctx context.Context,
+	userID, dataID, from int,
+	send func(chunk *entity.BinaryChunk) error,
+) error {
+	upload, err := s.repo.UploadByData(ctx, userID, dataID)
+	if err != nil {
+		return err
+	}
+	if from < 0 || from > upload.ChunkCount {
+		return fmt.Errorf("%w: в файле %d частей", helper.ErrInvalidUpload, upload.ChunkCount)
+	}
+
+	for seq := from; seq < upload.ChunkCount; seq++ {
+		encrypted, err := s.chunk(ctx, upload, seq)
+		if err != nil {
+			return err
+		}
+
+		encoded, err := s.encryptor.Decrypt(ctx, userID, encrypted)
+		if err != nil {
+			return fmt.Errorf("ошибка расшифровки части файла %d: %w", seq, err)
+		}
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("ошибка декодирования части файла %d: %w", seq, err)
+		}
+
+		if err = send(&entity.BinaryChunk{Seq: seq, Data: data}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// chunk читает зашифрованную часть из хранилища, а части загрузок, сделанных до появления
+// хранилища, - из базы.
+func (s *binaryService) chunk(ctx context.Context, upload *entity.Upload, seq int) (string, error) {
+	if upload.BlobRef == "" {
+		return s.repo.InlineChunk(ctx, upload.ID, seq)
+	}
+
+	data, err := s.blobs.Get(ctx, chunkKey(upload.BlobRef, seq))
+	if err != nil {
+		return "", fmt.Errorf("ошибка чтения части файла %d из хранилища: %w", seq, err)
+	}
+
+	return string(data), nil
+}
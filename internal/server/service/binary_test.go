@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// memoryUploads хранит загрузки в памяти с той же проверкой порядка частей, что и репозиторий.
+type memoryUploads struct {
+	uploads map[string]*entity.Upload
+	hashes  map[string][]string
+	// attachErr - ошибка следующего вызова AttachData.
+	attachErr error
+	// fileHashes - хеш файла, сохранённый в записи при AttachData.
+	fileHashes map[int]string
+}
+
+func newMemoryUploads() *memoryUploads {
+	return &memoryUploads{
+		uploads:    map[string]*entity.Upload{},
+		hashes:     map[string][]string{},
+		fileHashes: map[int]string{},
+	}
+}
+
+// memoryBlobs - хранилище файлов в памяти.
+type memoryBlobs map[string][]byte
+
+func (m memoryBlobs) Put(_ context.Context, key string, data []byte) error {
+	m[key] = data
+	return nil
+}
+
+func (m memoryBlobs) Get(_ context.Context, key string) ([]byte, error) {
+	data, ok := m[key]
+	if !ok {
+		return nil, errors.New("объект не найден")
+	}
+	return data, nil
+}
+
+func (m memoryBlobs) Delete(_ context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+func (m *memoryUploads) CreateUpload(_ context.Context, upload *entity.Upload) error {
+	stored := *upload
+	m.uploads[upload.ID] = &stored
+	return nil
+}
+
+func (m *memoryUploads) Upload(_ context.Context, userID int, uploadID string) (*entity.Upload, error) {
+	upload, ok := m.uploads[uploadID]
+	if !ok || upload.UserID != userID {
+		return nil, helper.ErrUploadNotFound
+	}
+	found := *upload
+	return &found, nil
+}
+
+func (m *memoryUploads) UploadByData(_ context.Context, userID, dataID int) (*entity.Upload, error) {
+	for _, upload := range m.uploads {
+		if upload.UserID == userID && upload.DataID == dataID {
+			found := *upload
+			return &found, nil
+		}
+	}
+	return nil, helper.ErrUploadNotFound
+}
+
+func (m *memoryUploads) SaveChunk(_ context.Context, uploadID string, seq, size int, sha256 string) error {
+	upload := m.uploads[uploadID]
+	if upload.Received != seq {
+		return helper.ErrChunkOutOfOrder
+	}
+	upload.Received++
+	upload.Size += int64(size)
+	m.hashes[uploadID] = append(m.hashes[uploadID], sha256)
+	return nil
+}
+
+func (m *memoryUploads) InlineChunk(_ context.Context, _ string, _ int) (string, error) {
+	return "", errors.New("части хранятся в хранилище файлов")
+}
+
+func (m *memoryUploads) ChunkHashes(_ context.Context, uploadID string) ([]string, error) {
+	return m.hashes[uploadID], nil
+}
+
+func (m *memoryUploads) SetReplaceID(_ context.Context, uploadID string, dataID int) error {
+	m.uploads[uploadID].ReplaceID = dataID
+	return nil
+}
+
+func (m *memoryUploads) AttachData(_ context.Context, upload *entity.Upload, dataID int, sha256 string) error {
+	if err := m.attachErr; err != nil {
+		m.attachErr = nil
+		return err
+	}
+	m.uploads[upload.ID].DataID = dataID
+	m.fileHashes[dataID] = sha256
+	return nil
+}
+
+type mockBinaryRecords struct {
+	mock.Mock
+}
+
+func (m *mockBinaryRecords) AddData(ctx context.Context, userID int, data *entity.UserData) (int, error) {
+	args := m.Called(ctx, userID, data)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockBinaryRecords) GetDataByID(ctx context.Context, userID, dataID int) (*entity.UserData, error) {
+	args := m.Called(ctx, userID, dataID)
+	data, _ := args.Get(0).(*entity.UserData)
+	return data, args.Error(1)
+}
+
+func (m *mockBinaryRecords) UpdateData(
+	ctx context.Context,
+	userID int,
+	data *entity.UserData,
+	expectedRevision int64,
+) error {
+	args := m.Called(ctx, userID, data, expectedRevision)
+	return args.Error(0)
+}
+
+func checksum(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+func TestBinaryService_UploadAndDownload(t *testing.T) {
+	ctx := context.Background()
+	encryptor := staticEncryptor{NewEncryptionService([]byte("01234567890123456789012345678901"))}
+	uploads := newMemoryUploads()
+	blobs := memoryBlobs{}
+	records := new(mockBinaryRecords)
+	binaryService := NewBinaryService(uploads, records, blobs, encryptor)
+
+	upload, err := binaryService.StartUpload(ctx, 1, &entity.Upload{
+		Info:         `{"file_name":"a.bin"}`,
+		Meta:         "meta",
+		SearchTokens: "t1 t2",
+		ChunkCount:   2,
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, upload.ID)
+	assert.Equal(t, "1/"+upload.ID, upload.BlobRef)
+	assert.NotEqual(t, "meta", uploads.uploads[upload.ID].Meta, "описание файла хранится зашифрованным")
+	assert.NotContains(t, uploads.uploads[upload.ID].SearchTokens, "t1", "токены поиска хранятся зашифрованными")
+
+	first, second := []byte("first"), []byte("second")
+
+	_, err = binaryService.SaveChunk(ctx, 1, upload.ID, 1, second, checksum(second))
+	assert.ErrorIs(t, err, helper.ErrChunkOutOfOrder)
+
+	_, err = binaryService.SaveChunk(ctx, 1, upload.ID, 0, first, checksum(second))
+	assert.ErrorIs(t, err, helper.ErrChecksumMismatch)
+
+	upload, err = binaryService.SaveChunk(ctx, 1, upload.ID, 0, first, checksum(first))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, upload.Received)
+
+	upload, err = binaryService.SaveChunk(ctx, 1, upload.ID, 0, first, checksum(first))
+	assert.NoError(t, err, "повтор принятой части пропускается")
+	assert.Equal(t, 1, upload.Received)
+	assert.NotContains(t, string(blobs[upload.BlobRef+"/0"]), "first", "части хранятся зашифрованными")
+
+	resumed, err := binaryService.StartUpload(ctx, 1, &entity.Upload{ID: upload.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resumed.Received)
+
+	records.On("AddData", ctx, 1, &entity.UserData{
+		InfoType: "binary", Info: `{"file_name":"a.bin"}`, Meta: "meta", SearchTokens: []string{"t1", "t2"},
+	}).Return(9, nil)
+	upload, err = binaryService.SaveChunk(ctx, 1, upload.ID, 1, second, checksum(second))
+	assert.NoError(t, err)
+	assert.Equal(t, 9, upload.DataID)
+	assert.Equal(t, int64(len(first)+len(second)), upload.Size)
+	records.AssertExpectations(t)
+
+	firstSum, secondSum := sha256.Sum256(first), sha256.Sum256(second)
+	fileHash := sha256.Sum256(append(firstSum[:], secondSum[:]...))
+	assert.Equal(t, hex.EncodeToString(fileHash[:]), uploads.fileHashes[9], "хеш файла - хеш хешей частей")
+
+	var received []byte
+	err = binaryService.Download(ctx, 1, 9, 1, func(chunk *entity.BinaryChunk) error {
+		received = append(received, chunk.Data...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, second, received)
+
+	err = binaryService.Download(ctx, 2, 9, 0, func(*entity.BinaryChunk) error { return nil })
+	assert.ErrorIs(t, err, helper.ErrUploadNotFound, "чужой файл недоступен")
+}
+
+func TestBinaryService_FinishRetry(t *testing.T) {
+	ctx := context.Background()
+	encryptor := staticEncryptor{NewEncryptionService([]byte("01234567890123456789012345678901"))}
+	uploads := newMemoryUploads()
+	records := new(mockBinaryRecords)
+	binaryService := NewBinaryService(uploads, records, memoryBlobs{}, encryptor)
+
+	upload, err := binaryService.StartUpload(ctx, 1, &entity.Upload{Info: `{"file_name":"a.bin"}`, ChunkCount: 1})
+	assert.NoError(t, err)
+
+	records.On("AddData", ctx, 1, mock.Anything).Return(9, nil).Once()
+	uploads.attachErr = errors.New("база недоступна")
+	chunk := []byte("chunk")
+	_, err = binaryService.SaveChunk(ctx, 1, upload.ID, 0, chunk, checksum(chunk))
+	assert.Error(t, err)
+	assert.Equal(t, 1, uploads.uploads[upload.ID].Received, "часть принята, хотя запись не привязана")
+
+	records.On("UpdateData", ctx, 1, mock.MatchedBy(func(data *entity.UserData) bool {
+		return data.ID == 9
+	}), int64(0)).Return(nil).Once()
+	upload, err = binaryService.SaveChunk(ctx, 1, upload.ID, 0, chunk, checksum(chunk))
+	assert.NoError(t, err, "повтор части завершает загрузку")
+	assert.Equal(t, 9, upload.DataID)
+	assert.Equal(t, 9, uploads.uploads[upload.ID].DataID)
+	records.AssertExpectations(t)
+	records.AssertNumberOfCalls(t, "AddData", 1)
+}
+
+func TestBinaryService_StartUploadValidation(t *testing.T) {
+	ctx := context.Background()
+	encryptor := staticEncryptor{NewEncryptionService([]byte("01234567890123456789012345678901"))}
+	records := new(mockBinaryRecords)
+	binaryService := NewBinaryService(newMemoryUploads(), records, memoryBlobs{}, encryptor)
+
+	_, err := binaryService.StartUpload(ctx, 1, &entity.Upload{ChunkCount: 0})
+	assert.ErrorIs(t, err, helper.ErrInvalidUpload)
+
+	_, err = binaryService.StartUpload(ctx, 1, &entity.Upload{ID: "missing"})
+	assert.ErrorIs(t, err, helper.ErrUploadNotFound)
+
+	records.On("GetDataByID", ctx, 1, 3).Return(&entity.UserData{ID: 3, InfoType: "text"}, nil)
+	_, err = binaryService.StartUpload(ctx, 1, &entity.Upload{ChunkCount: 1, ReplaceID: 3})
+	assert.ErrorIs(t, err, helper.ErrInvalidUpload)
+}
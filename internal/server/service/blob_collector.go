@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+)
+
+const garbageBatchSize = 100
+
+type garbageRepo interface {
+	ExpireUploads(ctx context.Context, before time.Time) (int64, error)
+	Garbage(ctx context.Context, limit int) ([]*entity.BlobGarbage, error)
+	ForgetGarbage(ctx context.Context, id int) error
+}
+
+type blobDeleter interface {
+	Delete(ctx context.Context, key string) error
+}
+
+// BlobCollector удаляет из хранилища файлов части удалённых и заменённых файлов, а также
+// брошенных загрузок. Какие части удалить, база записывает в blob_garbage при удалении загрузки,
+// поэтому сборщик не пропустит файл, даже если сервер остановился между удалением записи и файла.
+type BlobCollector struct {
+	repo      garbageRepo
+	blobs     blobDeleter
+	logger    logger.CustomLogger
+	now       func() time.Time
+	uploadTTL time.Duration
+}
+
+// NewBlobCollector - конструктор сборщика. Незавершённые загрузки старше uploadTTL считаются брошенными.
+func NewBlobCollector(
+	repo garbageRepo,
+	blobs blobDeleter,
+	uploadTTL time.Duration,
+	logger logger.CustomLogger,
+) *BlobCollector {
+	return &BlobCollector{repo: repo, blobs: blobs, logger: logger, now: time.Now, uploadTTL: uploadTTL}
+}
+
+// Collect выполняет один проход сборки и возвращает, сколько файлов удалено из хранилища.
+func (c *BlobCollector) Collect(ctx context.Context) (int, error) {
+	if _, err := c.repo.ExpireUploads(ctx, c.now().Add(-c.uploadTTL)); err != nil {
+		return 0, err
+	}
+
+	collected := 0
+	for {
+		garbage, err := c.repo.Garbage(ctx, garbageBatchSize)
+		if err != nil {
+			return collected, err
+		}
+
+		for _, item := range garbage {
+			for seq := range item.Chunks {
+				if err = c.blobs.Delete(ctx, chunkKey(item.Ref, seq)); err != nil {
+					return collected, fmt.Errorf("ошибка удаления части %d файла %s: %w", seq, item.Ref, err)
+				}
+			}
+			if err = c.repo.ForgetGarbage(ctx, item.ID); err != nil {
+				return collected, err
+			}
+			collected++
+		}
+
+		if len(garbage) < garbageBatchSize {
+			return collected, nil
+		}
+	}
+}
+
+// Run запускает Collect каждые interval, пока не отменён ctx. Ошибки прохода логируются,
+// следующий проход повторяет несделанное.
+func (c *BlobCollector) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Collect(ctx); err != nil {
+			c.logger.LogInfo("Ошибка при удалении файлов из хранилища", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryGarbage struct {
+	expiredBefore time.Time
+	garbage       []*entity.BlobGarbage
+	forgotten     []int
+}
+
+func (m *memoryGarbage) ExpireUploads(_ context.Context, before time.Time) (int64, error) {
+	m.expiredBefore = before
+	return 0, nil
+}
+
+func (m *memoryGarbage) Garbage(_ context.Context, limit int) ([]*entity.BlobGarbage, error) {
+	return m.garbage[:min(limit, len(m.garbage))], nil
+}
+
+func (m *memoryGarbage) ForgetGarbage(_ context.Context, id int) error {
+	m.forgotten = append(m.forgotten, id)
+	for i, item := range m.garbage {
+		if item.ID == id {
+			m.garbage = append(m.garbage[:i], m.garbage[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// failingBlobs не удаляет объекты с ключом failKey.
+type failingBlobs struct {
+	memoryBlobs
+	failKey string
+}
+
+func (f failingBlobs) Delete(ctx context.Context, key string) error {
+	if key == f.failKey {
+		return errors.New("storage unavailable")
+	}
+	return f.memoryBlobs.Delete(ctx, key)
+}
+
+func TestBlobCollector_Collect(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	blobs := memoryBlobs{"7/up1/0": nil, "7/up1/1": nil, "7/up2/0": nil, "8/up3/0": nil}
+	repo := &memoryGarbage{garbage: []*entity.BlobGarbage{
+		{ID: 1, Ref: "7/up1", Chunks: 3},
+		{ID: 2, Ref: "7/up2", Chunks: 1},
+	}}
+
+	collector := NewBlobCollector(repo, blobs, time.Hour, new(mockLogger))
+	collector.now = func() time.Time { return now }
+
+	collected, err := collector.Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, collected)
+	assert.Equal(t, now.Add(-time.Hour), repo.expiredBefore)
+	assert.Equal(t, []int{1, 2}, repo.forgotten)
+	assert.Equal(t, memoryBlobs{"8/up3/0": nil}, blobs, "файлы других загрузок не трогаются")
+}
+
+func TestBlobCollector_KeepsGarbageOnDeleteError(t *testing.T) {
+	repo := &memoryGarbage{garbage: []*entity.BlobGarbage{{ID: 1, Ref: "7/up1", Chunks: 2}}}
+	blobs := failingBlobs{memoryBlobs: memoryBlobs{"7/up1/0": nil, "7/up1/1": nil}, failKey: "7/up1/1"}
+
+	collected, err := NewBlobCollector(repo, blobs, time.Hour, new(mockLogger)).Collect(context.Background())
+	assert.ErrorContains(t, err, "storage unavailable")
+	assert.Equal(t, 0, collected)
+	assert.Empty(t, repo.forgotten, "запись о мусоре остаётся до следующего прохода")
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyPassword используется для сравнения, когда пользователь не найден,
+// чтобы время ответа не выдавало существование логина.
+const dummyPassword = "goph-keeper-dummy-password"
+
+type credentials struct {
+	log       logger.CustomLogger
+	dummyHash []byte
+}
+
+// NewCredentials - конструктор сервиса проверки пароля пользователя.
+func NewCredentials(log logger.CustomLogger) (*credentials, error) {
+	dummyHash, err := bcrypt.GenerateFromPassword([]byte(dummyPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось подготовить фиктивный хэш: %w", err)
+	}
+
+	return &credentials{log: log, dummyHash: dummyHash}, nil
+}
+
+// Verify сверяет пароль с bcrypt-хэшем пользователя.
+// Для nil-пользователя выполняется сравнение с фиктивным хэшем и возвращается ErrInvalidCredentials.
+func (c *credentials) Verify(user *entity.User, password string) error {
+	hash := c.dummyHash
+	if user != nil {
+		hash = []byte(user.Password)
+	}
+
+	err := bcrypt.CompareHashAndPassword(hash, []byte(password))
+	if user == nil {
+		return helper.ErrInvalidCredentials
+	}
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return helper.ErrInvalidCredentials
+		}
+
+		c.log.LogInfo("ошибка при сравнении пароля с хэшем: ", err)
+
+		return helper.ErrInternalServer
+	}
+
+	return nil
+}
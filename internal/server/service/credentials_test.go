@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestCredentials_Verify(t *testing.T) {
+	creds, err := NewCredentials(&mockLogger{})
+	assert.NoError(t, err)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	assert.NoError(t, err)
+	user := &entity.User{ID: 1, Login: "testuser", Password: string(hash)}
+
+	t.Run("Верный пароль", func(t *testing.T) {
+		assert.NoError(t, creds.Verify(user, "password123"))
+	})
+
+	t.Run("Неверный пароль", func(t *testing.T) {
+		assert.ErrorIs(t, creds.Verify(user, "wrongpassword"), helper.ErrInvalidCredentials)
+	})
+
+	t.Run("Пользователь не найден", func(t *testing.T) {
+		assert.ErrorIs(t, creds.Verify(nil, dummyPassword), helper.ErrInvalidCredentials)
+	})
+
+	t.Run("Повреждённый хэш", func(t *testing.T) {
+		broken := &entity.User{ID: 2, Login: "broken", Password: "not-a-bcrypt-hash"}
+		assert.ErrorIs(t, creds.Verify(broken, "password123"), helper.ErrInternalServer)
+	})
+}
@@ -5,40 +5,68 @@ import (
 	"fmt"
 
 	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
 )
 
 type dataRepo interface {
 	AddData(ctx context.Context, data *entity.UserData) (int, error)
 	GetDataByID(ctx context.Context, userID, dataID int) (*entity.UserData, error)
-	UpdateData(ctx context.Context, data *entity.UserData) error
+	UpdateData(ctx context.Context, data *entity.UserData, expectedRevision int64) error
 	DeleteData(ctx context.Context, userID, dataID int) error
-	ListData(ctx context.Context, userID int, infoType string) ([]*entity.UserData, error)
+	ListData(ctx context.Context, userID int, page *entity.DataPage) ([]*entity.UserData, error)
+	SearchData(ctx context.Context, userID int, search *entity.DataSearch) ([]*entity.UserData, error)
+	Changes(ctx context.Context, userID int, sinceRevision int64, limit int) ([]*entity.DataChange, error)
+	ListVersions(ctx context.Context, userID, dataID int) ([]*entity.UserData, error)
+	GetVersion(ctx context.Context, userID, dataID int, revision int64) (*entity.UserData, error)
+	RestoreVersion(ctx context.Context, userID, dataID int, revision int64) (int64, string, error)
+	ListTrash(ctx context.Context, userID int) ([]*entity.UserData, error)
+	RestoreData(ctx context.Context, userID, dataID int) (string, error)
+	PurgeData(ctx context.Context, userID, dataID int) error
+	EmptyTrash(ctx context.Context, userID int) ([]int, error)
+}
+
+type encryptor interface {
+	Encrypt(ctx context.Context, userID int, plaintext string) (string, error)
+	Decrypt(ctx context.Context, userID int, ciphertext string) (string, error)
 }
 
 type dataService struct {
 	dataRepo          dataRepo
-	encryptionService *EncryptionService
+	encryptionService encryptor
+	searchIndex       *SearchIndex
 }
 
 // NewDataService - конструктор data service.
-func NewDataService(dataRepo dataRepo, encryptionService *EncryptionService) *dataService {
+func NewDataService(dataRepo dataRepo, encryptionService encryptor) *dataService {
 	return &dataService{
 		dataRepo:          dataRepo,
 		encryptionService: encryptionService,
 	}
 }
 
+// WithSearchIndex включает поиск по Meta: токены поиска записей сохраняются в слепой индекс.
+// Без индекса токены записей отбрасываются, а поиск по токенам возвращает helper.ErrSearchDisabled.
+func (s *dataService) WithSearchIndex(index *SearchIndex) *dataService {
+	s.searchIndex = index
+	return s
+}
+
 func (s *dataService) AddData(ctx context.Context, userID int, data *entity.UserData) (int, error) {
 	data.UserID = userID
 
+	var err error
+	if data.SearchTokens, err = s.blindTokens(userID, data.SearchTokens); err != nil {
+		return 0, err
+	}
+
 	// Шифруем поля data.Info и data.Meta
-	encryptedInfo, err := s.encryptionService.Encrypt(data.Info)
+	encryptedInfo, err := s.encryptionService.Encrypt(ctx, userID, data.Info)
 	if err != nil {
 		return 0, fmt.Errorf("ошибка шифрования Info: %w", err)
 	}
 	data.Info = encryptedInfo
 
-	encryptedMeta, err := s.encryptionService.Encrypt(data.Meta)
+	encryptedMeta, err := s.encryptionService.Encrypt(ctx, userID, data.Meta)
 	if err != nil {
 		return 0, fmt.Errorf("ошибка шифрования Meta: %w", err)
 	}
@@ -54,13 +82,13 @@ func (s *dataService) GetDataByID(ctx context.Context, userID, dataID int) (*ent
 	}
 
 	// Расшифровываем поля data.Info и data.Meta
-	decryptedInfo, err := s.encryptionService.Decrypt(data.Info)
+	decryptedInfo, err := s.encryptionService.Decrypt(ctx, userID, data.Info)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка расшифровки Info: %w", err)
 	}
 	data.Info = decryptedInfo
 
-	decryptedMeta, err := s.encryptionService.Decrypt(data.Meta)
+	decryptedMeta, err := s.encryptionService.Decrypt(ctx, userID, data.Meta)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка расшифровки Meta: %w", err)
 	}
@@ -69,42 +97,216 @@ func (s *dataService) GetDataByID(ctx context.Context, userID, dataID int) (*ent
 	return data, nil
 }
 
-func (s *dataService) UpdateData(ctx context.Context, userID int, data *entity.UserData) error {
+// UpdateData обновляет запись. При expectedRevision не 0 запись, изменённая после этой ревизии,
+// не перезаписывается: возвращается helper.ErrVersionConflict. Отсутствующая запись даёт
+// helper.ErrDataNotFound, запись другого пользователя - helper.ErrDataForbidden.
+func (s *dataService) UpdateData(ctx context.Context, userID int, data *entity.UserData, expectedRevision int64) error {
 	data.UserID = userID
 
+	var err error
+	if data.SearchTokens, err = s.blindTokens(userID, data.SearchTokens); err != nil {
+		return err
+	}
+
 	// Шифруем поля перед обновлением
-	encryptedInfo, err := s.encryptionService.Encrypt(data.Info)
+	encryptedInfo, err := s.encryptionService.Encrypt(ctx, userID, data.Info)
 	if err != nil {
 		return fmt.Errorf("ошибка шифрования Info: %w", err)
 	}
 	data.Info = encryptedInfo
 
-	encryptedMeta, err := s.encryptionService.Encrypt(data.Meta)
+	encryptedMeta, err := s.encryptionService.Encrypt(ctx, userID, data.Meta)
 	if err != nil {
 		return fmt.Errorf("ошибка шифрования Meta: %w", err)
 	}
 	data.Meta = encryptedMeta
 
-	return s.dataRepo.UpdateData(ctx, data)
+	return s.dataRepo.UpdateData(ctx, data, expectedRevision)
 }
 
+// DeleteData переносит запись в корзину. Ошибки для отсутствующей и чужой записи - как в UpdateData.
 func (s *dataService) DeleteData(ctx context.Context, userID, dataID int) error {
 	return s.dataRepo.DeleteData(ctx, userID, dataID)
 }
 
-func (s *dataService) ListData(ctx context.Context, userID int, infoType string) ([]*entity.UserData, error) {
-	dataItems, err := s.dataRepo.ListData(ctx, userID, infoType)
+// ListTrash возвращает записи пользователя в корзине без Info с расшифрованной Meta.
+func (s *dataService) ListTrash(ctx context.Context, userID int) ([]*entity.UserData, error) {
+	dataItems, err := s.dataRepo.ListTrash(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения корзины из репозитория: %w", err)
+	}
+
+	for _, data := range dataItems {
+		data.Meta, err = s.encryptionService.Decrypt(ctx, userID, data.Meta)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка расшифровки Meta: %w", err)
+		}
+	}
+
+	return dataItems, nil
+}
+
+// RestoreData возвращает запись из корзины.
+func (s *dataService) RestoreData(ctx context.Context, userID, dataID int) error {
+	_, err := s.restoreData(ctx, userID, dataID)
+	return err
+}
+
+// restoreData возвращает запись из корзины и возвращает её тип для события об изменении.
+func (s *dataService) restoreData(ctx context.Context, userID, dataID int) (string, error) {
+	return s.dataRepo.RestoreData(ctx, userID, dataID)
+}
+
+// PurgeData удаляет запись из корзины навсегда.
+func (s *dataService) PurgeData(ctx context.Context, userID, dataID int) error {
+	return s.dataRepo.PurgeData(ctx, userID, dataID)
+}
+
+// EmptyTrash удаляет навсегда все записи пользователя в корзине и возвращает их идентификаторы.
+func (s *dataService) EmptyTrash(ctx context.Context, userID int) ([]int, error) {
+	return s.dataRepo.EmptyTrash(ctx, userID)
+}
+
+// ListData возвращает страницу записей пользователя без Info с расшифрованной Meta.
+func (s *dataService) ListData(ctx context.Context, userID int, page *entity.DataPage) ([]*entity.UserData, error) {
+	dataItems, err := s.dataRepo.ListData(ctx, userID, page)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения данных из репозитория: %w", err)
 	}
 
 	for _, data := range dataItems {
-		decryptedMetaBytes, err := s.encryptionService.Decrypt(data.Meta)
+		decryptedMeta, err := s.encryptionService.Decrypt(ctx, userID, data.Meta)
 		if err != nil {
 			return nil, fmt.Errorf("ошибка расшифровки Meta: %w", err)
 		}
-		data.Meta = string(decryptedMetaBytes)
+		data.Meta = decryptedMeta
 	}
 
 	return dataItems, nil
 }
+
+// SearchData ищет записи пользователя по токенам поиска, типу и дате создания. Info в результатах нет,
+// как и в ListData.
+func (s *dataService) SearchData(
+	ctx context.Context,
+	userID int,
+	search *entity.DataSearch,
+) ([]*entity.UserData, error) {
+	query := *search
+	if len(search.Tokens) > 0 {
+		if s.searchIndex == nil {
+			return nil, helper.ErrSearchDisabled
+		}
+
+		var err error
+		if query.Tokens, err = s.searchIndex.Blind(userID, search.Tokens); err != nil {
+			return nil, err
+		}
+	}
+
+	dataItems, err := s.dataRepo.SearchData(ctx, userID, &query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска данных в репозитории: %w", err)
+	}
+
+	for _, data := range dataItems {
+		data.Meta, err = s.encryptionService.Decrypt(ctx, userID, data.Meta)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка расшифровки Meta: %w", err)
+		}
+	}
+
+	return dataItems, nil
+}
+
+// ListVersions возвращает прежние версии записи без Info с расшифрованной Meta, начиная с последней.
+func (s *dataService) ListVersions(ctx context.Context, userID, dataID int) ([]*entity.UserData, error) {
+	versions, err := s.dataRepo.ListVersions(ctx, userID, dataID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения версий из репозитория: %w", err)
+	}
+
+	for _, version := range versions {
+		version.Meta, err = s.encryptionService.Decrypt(ctx, userID, version.Meta)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка расшифровки Meta: %w", err)
+		}
+	}
+
+	return versions, nil
+}
+
+// GetVersion возвращает расшифрованную прежнюю версию записи.
+func (s *dataService) GetVersion(ctx context.Context, userID, dataID int, revision int64) (*entity.UserData, error) {
+	version, err := s.dataRepo.GetVersion(ctx, userID, dataID, revision)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения версии из репозитория: %w", err)
+	}
+
+	version.Info, err = s.encryptionService.Decrypt(ctx, userID, version.Info)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расшифровки Info: %w", err)
+	}
+
+	version.Meta, err = s.encryptionService.Decrypt(ctx, userID, version.Meta)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расшифровки Meta: %w", err)
+	}
+
+	return version, nil
+}
+
+// RestoreVersion восстанавливает прежнюю версию записи и возвращает новую ревизию записи.
+func (s *dataService) RestoreVersion(ctx context.Context, userID, dataID int, revision int64) (int64, error) {
+	restored, _, err := s.restoreVersion(ctx, userID, dataID, revision)
+	return restored, err
+}
+
+// restoreVersion восстанавливает прежнюю версию записи и возвращает новую ревизию и тип восстановленной версии.
+func (s *dataService) restoreVersion(
+	ctx context.Context,
+	userID, dataID int,
+	revision int64,
+) (int64, string, error) {
+	return s.dataRepo.RestoreVersion(ctx, userID, dataID, revision)
+}
+
+// blindTokens заменяет токены поиска записи их слепым индексом. Без индекса токены не сохраняются.
+func (s *dataService) blindTokens(userID int, tokens []string) ([]string, error) {
+	if s.searchIndex == nil {
+		return nil, nil
+	}
+
+	return s.searchIndex.Blind(userID, tokens)
+}
+
+// SyncData возвращает расшифрованные изменения данных пользователя после ревизии sinceRevision.
+func (s *dataService) SyncData(
+	ctx context.Context,
+	userID int,
+	sinceRevision int64,
+	limit int,
+) ([]*entity.DataChange, error) {
+	changes, err := s.dataRepo.Changes(ctx, userID, sinceRevision, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения изменений из репозитория: %w", err)
+	}
+
+	for _, change := range changes {
+		if change.Deleted {
+			continue
+		}
+
+		change.Data.Info, err = s.encryptionService.Decrypt(ctx, userID, change.Data.Info)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка расшифровки Info: %w", err)
+		}
+
+		change.Data.Meta, err = s.encryptionService.Decrypt(ctx, userID, change.Data.Meta)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка расшифровки Meta: %w", err)
+		}
+	}
+
+	return changes, nil
+}
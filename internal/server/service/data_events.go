@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+)
+
+type eventBus interface {
+	Publish(ctx context.Context, event *entity.DataEvent) error
+	Subscribe(userID int) (events <-chan *entity.DataEvent, cancel func())
+}
+
+// watchedDataService сообщает подписчикам WatchData о каждом успешном изменении данных.
+type watchedDataService struct {
+	*dataService
+	bus    eventBus
+	logger logger.CustomLogger
+}
+
+// NewWatchedDataService - конструктор data service, публикующего события об изменениях.
+func NewWatchedDataService(next *dataService, bus eventBus, logger logger.CustomLogger) *watchedDataService {
+	return &watchedDataService{dataService: next, bus: bus, logger: logger}
+}
+
+func (s *watchedDataService) AddData(ctx context.Context, userID int, data *entity.UserData) (int, error) {
+	id, err := s.dataService.AddData(ctx, userID, data)
+	if err != nil {
+		return 0, err
+	}
+
+	s.publish(ctx, &entity.DataEvent{Kind: entity.DataAdded, UserID: userID, ID: id, InfoType: data.InfoType})
+	return id, nil
+}
+
+func (s *watchedDataService) UpdateData(
+	ctx context.Context,
+	userID int,
+	data *entity.UserData,
+	expectedRevision int64,
+) error {
+	if err := s.dataService.UpdateData(ctx, userID, data, expectedRevision); err != nil {
+		return err
+	}
+
+	s.publish(ctx, &entity.DataEvent{Kind: entity.DataUpdated, UserID: userID, ID: data.ID, InfoType: data.InfoType})
+	return nil
+}
+
+func (s *watchedDataService) DeleteData(ctx context.Context, userID, dataID int) error {
+	if err := s.dataService.DeleteData(ctx, userID, dataID); err != nil {
+		return err
+	}
+
+	s.publish(ctx, &entity.DataEvent{Kind: entity.DataDeleted, UserID: userID, ID: dataID})
+	return nil
+}
+
+func (s *watchedDataService) RestoreVersion(ctx context.Context, userID, dataID int, revision int64) (int64, error) {
+	restored, infoType, err := s.dataService.restoreVersion(ctx, userID, dataID, revision)
+	if err != nil {
+		return 0, err
+	}
+
+	s.publish(ctx, &entity.DataEvent{Kind: entity.DataUpdated, UserID: userID, ID: dataID, InfoType: infoType})
+	return restored, nil
+}
+
+// RestoreData сообщает о записи, возвращённой из корзины, как о добавленной.
+func (s *watchedDataService) RestoreData(ctx context.Context, userID, dataID int) error {
+	infoType, err := s.dataService.restoreData(ctx, userID, dataID)
+	if err != nil {
+		return err
+	}
+
+	s.publish(ctx, &entity.DataEvent{Kind: entity.DataAdded, UserID: userID, ID: dataID, InfoType: infoType})
+	return nil
+}
+
+// PurgeData сообщает об удалённой из корзины записи, чтобы клиенты убрали её из кэша корзины.
+func (s *watchedDataService) PurgeData(ctx context.Context, userID, dataID int) error {
+	if err := s.dataService.PurgeData(ctx, userID, dataID); err != nil {
+		return err
+	}
+
+	s.publish(ctx, &entity.DataEvent{Kind: entity.DataDeleted, UserID: userID, ID: dataID})
+	return nil
+}
+
+// EmptyTrash сообщает о каждой удалённой из корзины записи.
+func (s *watchedDataService) EmptyTrash(ctx context.Context, userID int) ([]int, error) {
+	purged, err := s.dataService.EmptyTrash(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dataID := range purged {
+		s.publish(ctx, &entity.DataEvent{Kind: entity.DataDeleted, UserID: userID, ID: dataID})
+	}
+	return purged, nil
+}
+
+// WatchData подписывает на изменения данных пользователя.
+func (s *watchedDataService) WatchData(userID int) (events <-chan *entity.DataEvent, cancel func()) {
+	return s.bus.Subscribe(userID)
+}
+
+// publish не возвращает ошибку: изменение уже сохранено, а пропущенное событие
+// клиент восполнит синхронизацией по ревизии.
+func (s *watchedDataService) publish(ctx context.Context, event *entity.DataEvent) {
+	if err := s.bus.Publish(ctx, event); err != nil {
+		s.logger.LogInfo("не удалось опубликовать событие об изменении данных", err)
+	}
+}
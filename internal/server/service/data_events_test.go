@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type recordingBus struct {
+	err       error
+	published []*entity.DataEvent
+}
+
+func (b *recordingBus) Publish(_ context.Context, event *entity.DataEvent) error {
+	b.published = append(b.published, event)
+	return b.err
+}
+
+func (b *recordingBus) Subscribe(int) (<-chan *entity.DataEvent, func()) {
+	return nil, func() {}
+}
+
+func TestWatchedDataService_PublishesChanges(t *testing.T) {
+	ctx := context.Background()
+	repo := new(DataRepoMock)
+	bus := &recordingBus{err: errors.New("брокер недоступен")}
+	svc := NewWatchedDataService(
+		NewDataService(repo, staticEncryptor{NewEncryptionService(testMasterKeyV1)}),
+		bus,
+		new(mockLogger),
+	)
+
+	repo.On("AddData", ctx, mock.Anything).Return(5, nil).Once()
+	repo.On("UpdateData", ctx, mock.Anything, int64(0)).Return(nil).Once()
+	repo.On("DeleteData", ctx, 1, 5).Return(nil).Once()
+	repo.On("DeleteData", ctx, 1, 6).Return(errors.New("ошибка базы")).Once()
+	repo.On("RestoreVersion", ctx, 1, 7, int64(2)).Return(int64(9), "bank_card", nil).Once()
+	repo.On("RestoreData", ctx, 1, 5).Return("text", nil).Once()
+	repo.On("PurgeData", ctx, 1, 8).Return(nil).Once()
+	repo.On("EmptyTrash", ctx, 1).Return([]int{9, 10}, nil).Once()
+
+	id, err := svc.AddData(ctx, 1, &entity.UserData{InfoType: "text"})
+	assert.NoError(t, err, "ошибка публикации не отменяет сохранённое изменение")
+	assert.Equal(t, 5, id)
+	assert.NoError(t, svc.UpdateData(ctx, 1, &entity.UserData{ID: 5, InfoType: "text"}, 0))
+	assert.NoError(t, svc.DeleteData(ctx, 1, 5))
+	assert.Error(t, svc.DeleteData(ctx, 1, 6))
+	revision, err := svc.RestoreVersion(ctx, 1, 7, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), revision)
+	assert.NoError(t, svc.RestoreData(ctx, 1, 5))
+	assert.NoError(t, svc.PurgeData(ctx, 1, 8))
+	purged, err := svc.EmptyTrash(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{9, 10}, purged)
+
+	assert.Equal(t, []*entity.DataEvent{
+		{Kind: entity.DataAdded, UserID: 1, ID: 5, InfoType: "text"},
+		{Kind: entity.DataUpdated, UserID: 1, ID: 5, InfoType: "text"},
+		{Kind: entity.DataDeleted, UserID: 1, ID: 5},
+		{Kind: entity.DataUpdated, UserID: 1, ID: 7, InfoType: "bank_card"},
+		{Kind: entity.DataAdded, UserID: 1, ID: 5, InfoType: "text"},
+		{Kind: entity.DataDeleted, UserID: 1, ID: 8},
+		{Kind: entity.DataDeleted, UserID: 1, ID: 9},
+		{Kind: entity.DataDeleted, UserID: 1, ID: 10},
+	}, bus.published)
+	repo.AssertExpectations(t)
+}
@@ -2,11 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -25,8 +27,8 @@ func (m *DataRepoMock) GetDataByID(ctx context.Context, userID, dataID int) (*en
 	return args.Get(0).(*entity.UserData), args.Error(1)
 }
 
-func (m *DataRepoMock) UpdateData(ctx context.Context, data *entity.UserData) error {
-	args := m.Called(ctx, data)
+func (m *DataRepoMock) UpdateData(ctx context.Context, data *entity.UserData, expectedRevision int64) error {
+	args := m.Called(ctx, data, expectedRevision)
 	return args.Error(0)
 }
 
@@ -35,17 +37,100 @@ func (m *DataRepoMock) DeleteData(ctx context.Context, userID, dataID int) error
 	return args.Error(0)
 }
 
-func (m *DataRepoMock) ListData(ctx context.Context, userID int, infoType string) ([]*entity.UserData, error) {
-	args := m.Called(ctx, userID, infoType)
+func (m *DataRepoMock) ListData(ctx context.Context, userID int, page *entity.DataPage) ([]*entity.UserData, error) {
+	args := m.Called(ctx, userID, page)
 	return args.Get(0).([]*entity.UserData), args.Error(1)
 }
 
+func (m *DataRepoMock) SearchData(
+	ctx context.Context,
+	userID int,
+	search *entity.DataSearch,
+) ([]*entity.UserData, error) {
+	args := m.Called(ctx, userID, search)
+	items, _ := args.Get(0).([]*entity.UserData)
+	return items, args.Error(1)
+}
+
+func (m *DataRepoMock) Changes(
+	ctx context.Context,
+	userID int,
+	sinceRevision int64,
+	limit int,
+) ([]*entity.DataChange, error) {
+	args := m.Called(ctx, userID, sinceRevision, limit)
+	changes, _ := args.Get(0).([]*entity.DataChange)
+	return changes, args.Error(1)
+}
+
+func (m *DataRepoMock) ListVersions(ctx context.Context, userID, dataID int) ([]*entity.UserData, error) {
+	args := m.Called(ctx, userID, dataID)
+	versions, _ := args.Get(0).([]*entity.UserData)
+	return versions, args.Error(1)
+}
+
+func (m *DataRepoMock) GetVersion(
+	ctx context.Context,
+	userID, dataID int,
+	revision int64,
+) (*entity.UserData, error) {
+	args := m.Called(ctx, userID, dataID, revision)
+	version, _ := args.Get(0).(*entity.UserData)
+	return version, args.Error(1)
+}
+
+func (m *DataRepoMock) RestoreVersion(
+	ctx context.Context,
+	userID, dataID int,
+	revision int64,
+) (int64, string, error) {
+	args := m.Called(ctx, userID, dataID, revision)
+	return args.Get(0).(int64), args.String(1), args.Error(2)
+}
+
+func (m *DataRepoMock) ListTrash(ctx context.Context, userID int) ([]*entity.UserData, error) {
+	args := m.Called(ctx, userID)
+	items, _ := args.Get(0).([]*entity.UserData)
+	return items, args.Error(1)
+}
+
+func (m *DataRepoMock) RestoreData(ctx context.Context, userID, dataID int) (string, error) {
+	args := m.Called(ctx, userID, dataID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *DataRepoMock) PurgeData(ctx context.Context, userID, dataID int) error {
+	args := m.Called(ctx, userID, dataID)
+	return args.Error(0)
+}
+
+func (m *DataRepoMock) EmptyTrash(ctx context.Context, userID int) ([]int, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+// staticEncryptor шифрует данные всех пользователей одним ключом.
+type staticEncryptor struct {
+	*EncryptionService
+}
+
+func (e staticEncryptor) Encrypt(_ context.Context, _ int, plaintext string) (string, error) {
+	return e.EncryptionService.Encrypt(plaintext)
+}
+
+func (e staticEncryptor) Decrypt(_ context.Context, _ int, ciphertext string) (string, error) {
+	return e.EncryptionService.Decrypt(ciphertext)
+}
+
 func TestDataService_AddData(t *testing.T) {
 	key := []byte("01234567890123456789012345678901")
 	encryptionService := NewEncryptionService(key)
 
 	dataRepoMock := new(DataRepoMock)
-	dataService := NewDataService(dataRepoMock, encryptionService)
+	dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService})
 
 	ctx := context.Background()
 	userID := 1
@@ -73,7 +158,7 @@ func TestDataService_GetDataByID(t *testing.T) {
 	encryptionService := NewEncryptionService(key)
 
 	dataRepoMock := new(DataRepoMock)
-	dataService := NewDataService(dataRepoMock, encryptionService)
+	dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService})
 
 	ctx := context.Background()
 	userID := 1
@@ -105,7 +190,7 @@ func TestDataService_UpdateData(t *testing.T) {
 	encryptionService := NewEncryptionService(key)
 
 	dataRepoMock := new(DataRepoMock)
-	dataService := NewDataService(dataRepoMock, encryptionService)
+	dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService})
 
 	ctx := context.Background()
 	userID := 1
@@ -116,13 +201,13 @@ func TestDataService_UpdateData(t *testing.T) {
 		Meta:     "обновленные метаданные",
 	}
 
-	dataRepoMock.On("UpdateData", ctx, mock.AnythingOfType("*entity.UserData")).Return(nil).Run(func(args mock.Arguments) {
+	dataRepoMock.On("UpdateData", ctx, mock.AnythingOfType("*entity.UserData"), int64(4)).Return(nil).Run(func(args mock.Arguments) {
 		argData := args.Get(1).(*entity.UserData)
 		assert.NotEqual(t, "обновленная информация", argData.Info)
 		assert.NotEqual(t, "обновленные метаданные", argData.Meta)
 	})
 
-	err := dataService.UpdateData(ctx, userID, data)
+	err := dataService.UpdateData(ctx, userID, data, 4)
 	assert.NoError(t, err)
 
 	dataRepoMock.AssertExpectations(t)
@@ -133,7 +218,7 @@ func TestDataService_DeleteData(t *testing.T) {
 	encryptionService := NewEncryptionService(key)
 
 	dataRepoMock := new(DataRepoMock)
-	dataService := NewDataService(dataRepoMock, encryptionService)
+	dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService})
 
 	ctx := context.Background()
 	userID := 1
@@ -152,7 +237,7 @@ func TestDataService_GetDataByID_DecryptionError(t *testing.T) {
 	encryptionService := NewEncryptionService(key)
 
 	dataRepoMock := new(DataRepoMock)
-	dataService := NewDataService(dataRepoMock, encryptionService)
+	dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService})
 
 	ctx := context.Background()
 	userID := 1
@@ -180,11 +265,11 @@ func TestDataService_ListData(t *testing.T) {
 	encryptionService := NewEncryptionService(key)
 
 	dataRepoMock := new(DataRepoMock)
-	dataService := NewDataService(dataRepoMock, encryptionService)
+	dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService})
 
 	ctx := context.Background()
 	userID := 1
-	infoType := "text"
+	page := &entity.DataPage{InfoType: "text", Limit: 10}
 
 	encryptedMeta1, _ := encryptionService.Encrypt("метаданные1")
 	encryptedMeta2, _ := encryptionService.Encrypt("метаданные2")
@@ -206,9 +291,9 @@ func TestDataService_ListData(t *testing.T) {
 		},
 	}
 
-	dataRepoMock.On("ListData", ctx, userID, infoType).Return(storedData, nil)
+	dataRepoMock.On("ListData", ctx, userID, page).Return(storedData, nil)
 
-	dataItems, err := dataService.ListData(ctx, userID, infoType)
+	dataItems, err := dataService.ListData(ctx, userID, page)
 	assert.NoError(t, err)
 	assert.Len(t, dataItems, 2)
 
@@ -223,17 +308,212 @@ func TestDataService_ListData_Error(t *testing.T) {
 	encryptionService := NewEncryptionService(key)
 
 	dataRepoMock := new(DataRepoMock)
-	dataService := NewDataService(dataRepoMock, encryptionService)
+	dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService})
 
 	ctx := context.Background()
 	userID := 1
-	infoType := "text"
+	page := &entity.DataPage{InfoType: "text", Limit: 10}
 
-	dataRepoMock.On("ListData", ctx, userID, infoType).Return(([]*entity.UserData)(nil), fmt.Errorf("database error"))
+	dataRepoMock.On("ListData", ctx, userID, page).Return(([]*entity.UserData)(nil), fmt.Errorf("database error"))
 
-	_, err := dataService.ListData(ctx, userID, infoType)
+	_, err := dataService.ListData(ctx, userID, page)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "ошибка получения данных из репозитория")
 
 	dataRepoMock.AssertExpectations(t)
 }
+
+func TestDataService_SyncData(t *testing.T) {
+	key := []byte("01234567890123456789012345678901")
+	encryptionService := NewEncryptionService(key)
+
+	dataRepoMock := new(DataRepoMock)
+	dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService})
+
+	ctx := context.Background()
+	encryptedInfo, _ := encryptionService.Encrypt("секрет")
+	encryptedMeta, _ := encryptionService.Encrypt("метаданные")
+
+	dataRepoMock.On("Changes", ctx, 1, int64(10), 100).Return([]*entity.DataChange{
+		{ID: 3, Revision: 11, Data: &entity.UserData{ID: 3, Info: encryptedInfo, Meta: encryptedMeta, Revision: 11}},
+		{ID: 2, Revision: 12, Deleted: true},
+	}, nil)
+
+	changes, err := dataService.SyncData(ctx, 1, 10, 100)
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, "секрет", changes[0].Data.Info)
+		assert.Equal(t, "метаданные", changes[0].Data.Meta)
+		assert.True(t, changes[1].Deleted)
+		assert.Nil(t, changes[1].Data)
+	}
+
+	dataRepoMock.On("Changes", ctx, 1, int64(0), 100).Return(nil, fmt.Errorf("database error"))
+
+	_, err = dataService.SyncData(ctx, 1, 0, 100)
+	assert.ErrorContains(t, err, "ошибка получения изменений из репозитория")
+
+	dataRepoMock.AssertExpectations(t)
+}
+
+func TestDataService_SearchTokens(t *testing.T) {
+	key := []byte("01234567890123456789012345678901")
+	encryptionService := NewEncryptionService(key)
+	index := NewSearchIndex([]byte("search index key"))
+	ctx := context.Background()
+
+	t.Run("Токены записи сохраняются слепым индексом", func(t *testing.T) {
+		dataRepoMock := new(DataRepoMock)
+		dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService}).WithSearchIndex(index)
+
+		blinded, err := index.Blind(1, []string{"w:почта", "g:поч"})
+		assert.NoError(t, err)
+		dataRepoMock.On("AddData", ctx, mock.MatchedBy(func(data *entity.UserData) bool {
+			return assert.ObjectsAreEqual(blinded, data.SearchTokens)
+		})).Return(3, nil)
+
+		_, err = dataService.AddData(ctx, 1, &entity.UserData{
+			InfoType: "text", Meta: "почта", SearchTokens: []string{"w:почта", "g:поч", "w:почта"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, blinded, 2)
+		assert.NotContains(t, blinded, "w:почта")
+		dataRepoMock.AssertExpectations(t)
+	})
+
+	t.Run("Без индекса токены отбрасываются", func(t *testing.T) {
+		dataRepoMock := new(DataRepoMock)
+		dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService})
+
+		dataRepoMock.On("UpdateData", ctx, mock.MatchedBy(func(data *entity.UserData) bool {
+			return data.SearchTokens == nil
+		}), int64(0)).Return(nil)
+
+		err := dataService.UpdateData(ctx, 1, &entity.UserData{ID: 3, SearchTokens: []string{"w:почта"}}, 0)
+		assert.NoError(t, err)
+		dataRepoMock.AssertExpectations(t)
+	})
+
+	t.Run("Слишком длинный токен", func(t *testing.T) {
+		dataService := NewDataService(new(DataRepoMock), staticEncryptor{encryptionService}).WithSearchIndex(index)
+
+		_, err := dataService.AddData(ctx, 1, &entity.UserData{SearchTokens: []string{string(make([]byte, 200))}})
+		assert.ErrorIs(t, err, helper.ErrInvalidSearch)
+	})
+}
+
+func TestDataService_SearchData(t *testing.T) {
+	key := []byte("01234567890123456789012345678901")
+	encryptionService := NewEncryptionService(key)
+	index := NewSearchIndex([]byte("search index key"))
+	ctx := context.Background()
+
+	encryptedMeta, err := encryptionService.Encrypt("рабочая почта")
+	assert.NoError(t, err)
+	blinded, err := index.Blind(1, []string{"w:почта"})
+	assert.NoError(t, err)
+
+	t.Run("Успешный поиск", func(t *testing.T) {
+		dataRepoMock := new(DataRepoMock)
+		dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService}).WithSearchIndex(index)
+
+		search := &entity.DataSearch{Tokens: []string{"w:почта"}, InfoType: "text", Limit: 10}
+		dataRepoMock.On("SearchData", ctx, 1, &entity.DataSearch{Tokens: blinded, InfoType: "text", Limit: 10}).
+			Return([]*entity.UserData{{ID: 3, UserID: 1, InfoType: "text", Meta: encryptedMeta}}, nil)
+
+		items, err := dataService.SearchData(ctx, 1, search)
+
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, "рабочая почта", items[0].Meta)
+		assert.Equal(t, []string{"w:почта"}, search.Tokens, "запрос вызывающего не меняется")
+		dataRepoMock.AssertExpectations(t)
+	})
+
+	t.Run("Поиск выключен", func(t *testing.T) {
+		dataService := NewDataService(new(DataRepoMock), staticEncryptor{encryptionService})
+
+		_, err := dataService.SearchData(ctx, 1, &entity.DataSearch{Tokens: []string{"w:почта"}})
+		assert.ErrorIs(t, err, helper.ErrSearchDisabled)
+	})
+
+	t.Run("Ошибка репозитория", func(t *testing.T) {
+		dataRepoMock := new(DataRepoMock)
+		dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService})
+
+		dataRepoMock.On("SearchData", ctx, 1, &entity.DataSearch{Limit: 10}).Return(nil, errors.New("db error"))
+
+		_, err := dataService.SearchData(ctx, 1, &entity.DataSearch{Limit: 10})
+		assert.Error(t, err)
+	})
+}
+
+func TestDataService_Versions(t *testing.T) {
+	key := []byte("01234567890123456789012345678901")
+	encryptionService := NewEncryptionService(key)
+
+	dataRepoMock := new(DataRepoMock)
+	dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService})
+
+	ctx := context.Background()
+	encryptedInfo, _ := encryptionService.Encrypt("старый секрет")
+	encryptedMeta, _ := encryptionService.Encrypt("старые метаданные")
+
+	dataRepoMock.On("ListVersions", ctx, 1, 3).
+		Return([]*entity.UserData{{ID: 3, UserID: 1, Meta: encryptedMeta, Revision: 5}}, nil)
+	versions, err := dataService.ListVersions(ctx, 1, 3)
+	assert.NoError(t, err)
+	if assert.Len(t, versions, 1) {
+		assert.Equal(t, "старые метаданные", versions[0].Meta)
+	}
+
+	dataRepoMock.On("GetVersion", ctx, 1, 3, int64(5)).
+		Return(&entity.UserData{ID: 3, UserID: 1, Info: encryptedInfo, Meta: encryptedMeta, Revision: 5}, nil)
+	version, err := dataService.GetVersion(ctx, 1, 3, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "старый секрет", version.Info)
+	assert.Equal(t, "старые метаданные", version.Meta)
+
+	dataRepoMock.On("GetVersion", ctx, 1, 3, int64(9)).Return(nil, helper.ErrVersionNotFound)
+	_, err = dataService.GetVersion(ctx, 1, 3, 9)
+	assert.ErrorIs(t, err, helper.ErrVersionNotFound)
+
+	dataRepoMock.On("RestoreVersion", ctx, 1, 3, int64(5)).Return(int64(12), "text", nil)
+	revision, err := dataService.RestoreVersion(ctx, 1, 3, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), revision)
+
+	dataRepoMock.AssertExpectations(t)
+}
+
+func TestDataService_Trash(t *testing.T) {
+	key := []byte("01234567890123456789012345678901")
+	encryptionService := NewEncryptionService(key)
+
+	dataRepoMock := new(DataRepoMock)
+	dataService := NewDataService(dataRepoMock, staticEncryptor{encryptionService})
+
+	ctx := context.Background()
+	encryptedMeta, _ := encryptionService.Encrypt("удалённая заметка")
+
+	dataRepoMock.On("ListTrash", ctx, 1).
+		Return([]*entity.UserData{{ID: 3, UserID: 1, Meta: encryptedMeta, Deleted: time.Now()}}, nil)
+	trash, err := dataService.ListTrash(ctx, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, trash, 1) {
+		assert.Equal(t, "удалённая заметка", trash[0].Meta)
+	}
+
+	dataRepoMock.On("RestoreData", ctx, 1, 4).Return("", helper.ErrNotInTrash)
+	assert.ErrorIs(t, dataService.RestoreData(ctx, 1, 4), helper.ErrNotInTrash)
+
+	dataRepoMock.On("PurgeData", ctx, 1, 3).Return(nil)
+	assert.NoError(t, dataService.PurgeData(ctx, 1, 3))
+
+	dataRepoMock.On("EmptyTrash", ctx, 1).Return([]int{3, 4}, nil)
+	purged, err := dataService.EmptyTrash(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, purged)
+
+	dataRepoMock.AssertExpectations(t)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+)
+
+const (
+	// envelopeCiphertextPrefix - формат "v2:<ID ключа пользователя>:<base64>".
+	// Шифротексты без префикса зашифрованы напрямую мастер-ключом.
+	envelopeCiphertextPrefix = "v2:"
+	dekLength                = 32
+)
+
+type userKeyRepo interface {
+	ActiveKey(ctx context.Context, userID int) (*entity.UserKey, error)
+	Key(ctx context.Context, userID, keyID int) (*entity.UserKey, error)
+	CreateKey(ctx context.Context, key *entity.UserKey) (*entity.UserKey, error)
+}
+
+// dekCacheKey - ключ кэша DEK. ID пользователя входит в него, чтобы шифротекст с ID чужого ключа
+// не расшифровывался ключом из кэша в обход проверки владельца в репозитории.
+type dekCacheKey struct {
+	userID int
+	keyID  int
+}
+
+// EnvelopeEncryption шифрует данные ключом пользователя (DEK),
+// который хранится в базе обёрнутым мастер-ключом сервера.
+type EnvelopeEncryption struct {
+	keyring *Keyring
+	repo    userKeyRepo
+	deks    map[dekCacheKey][]byte
+	mu      sync.RWMutex
+}
+
+// NewEnvelopeEncryption - конструктор сервиса конвертного шифрования.
+func NewEnvelopeEncryption(keyring *Keyring, repo userKeyRepo) *EnvelopeEncryption {
+	return &EnvelopeEncryption{
+		keyring: keyring,
+		repo:    repo,
+		deks:    make(map[dekCacheKey][]byte),
+	}
+}
+
+// Encrypt шифрует данные действующим ключом пользователя, при необходимости создавая его.
+func (e *EnvelopeEncryption) Encrypt(ctx context.Context, userID int, plaintext string) (string, error) {
+	key, dek, err := e.activeDEK(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := NewEncryptionService(dek).Encrypt(plaintext)
+	if err != nil {
+		return "", fmt.Errorf("ошибка шифрования ключом пользователя: %w", err)
+	}
+
+	return envelopeCiphertextPrefix + strconv.Itoa(key.ID) + ":" + ciphertext, nil
+}
+
+// Decrypt расшифровывает данные ключом, ID которого записан в шифротексте.
+func (e *EnvelopeEncryption) Decrypt(ctx context.Context, userID int, ciphertext string) (string, error) {
+	keyID, body, ok := ParseEnvelopeCiphertext(ciphertext)
+	if !ok {
+		plaintext, err := e.keyring.DecryptLegacy(ciphertext)
+		if err != nil {
+			return "", fmt.Errorf("ошибка расшифровки данных старого формата: %w", err)
+		}
+
+		return plaintext, nil
+	}
+
+	dek, err := e.dek(ctx, userID, keyID)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := NewEncryptionService(dek).Decrypt(body)
+	if err != nil {
+		return "", fmt.Errorf("ошибка расшифровки ключом пользователя: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// ActiveKeyID возвращает ID действующего ключа пользователя, при необходимости создавая его.
+func (e *EnvelopeEncryption) ActiveKeyID(ctx context.Context, userID int) (int, error) {
+	key, _, err := e.activeDEK(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return key.ID, nil
+}
+
+// ParseEnvelopeCiphertext извлекает ID ключа пользователя и сам шифротекст.
+// Для шифротекстов старого формата возвращает ok = false.
+func ParseEnvelopeCiphertext(ciphertext string) (keyID int, body string, ok bool) {
+	rest, found := strings.CutPrefix(ciphertext, envelopeCiphertextPrefix)
+	if !found {
+		return 0, "", false
+	}
+
+	rawID, body, found := strings.Cut(rest, ":")
+	if !found {
+		return 0, "", false
+	}
+
+	keyID, err := strconv.Atoi(rawID)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return keyID, body, true
+}
+
+func (e *EnvelopeEncryption) activeDEK(ctx context.Context, userID int) (*entity.UserKey, []byte, error) {
+	key, err := e.repo.ActiveKey(ctx, userID)
+	if errors.Is(err, helper.ErrKeyNotFound) {
+		key, err = e.createKey(ctx, userID)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка получения ключа пользователя: %w", err)
+	}
+
+	dek, err := e.unwrap(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, dek, nil
+}
+
+func (e *EnvelopeEncryption) createKey(ctx context.Context, userID int) (*entity.UserKey, error) {
+	dek := make([]byte, dekLength)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return nil, fmt.Errorf("ошибка генерации ключа пользователя: %w", err)
+	}
+
+	masterKeyID, wrapped, err := e.keyring.Wrap(dek)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := e.repo.CreateKey(ctx, &entity.UserKey{
+		UserID:      userID,
+		MasterKeyID: masterKeyID,
+		WrappedKey:  wrapped,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения ключа пользователя: %w", err)
+	}
+
+	return key, nil
+}
+
+func (e *EnvelopeEncryption) dek(ctx context.Context, userID, keyID int) ([]byte, error) {
+	e.mu.RLock()
+	dek, ok := e.deks[dekCacheKey{userID: userID, keyID: keyID}]
+	e.mu.RUnlock()
+	if ok {
+		return dek, nil
+	}
+
+	key, err := e.repo.Key(ctx, userID, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения ключа пользователя: %w", err)
+	}
+
+	return e.unwrap(key)
+}
+
+func (e *EnvelopeEncryption) unwrap(key *entity.UserKey) ([]byte, error) {
+	cacheKey := dekCacheKey{userID: key.UserID, keyID: key.ID}
+
+	e.mu.RLock()
+	dek, ok := e.deks[cacheKey]
+	e.mu.RUnlock()
+	if ok {
+		return dek, nil
+	}
+
+	dek, err := e.keyring.Unwrap(key.MasterKeyID, key.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.deks[cacheKey] = dek
+	e.mu.Unlock()
+
+	return dek, nil
+}
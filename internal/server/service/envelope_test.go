@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryUserKeyRepo - хранилище ключей пользователей в памяти.
+type memoryUserKeyRepo struct {
+	keys []*entity.UserKey
+	mu   sync.Mutex
+}
+
+func (r *memoryUserKeyRepo) ActiveKey(_ context.Context, userID int) (*entity.UserKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range r.keys {
+		if key.UserID == userID && key.Active {
+			copied := *key
+			return &copied, nil
+		}
+	}
+
+	return nil, helper.ErrKeyNotFound
+}
+
+func (r *memoryUserKeyRepo) Key(_ context.Context, userID, keyID int) (*entity.UserKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range r.keys {
+		if key.UserID == userID && key.ID == keyID {
+			copied := *key
+			return &copied, nil
+		}
+	}
+
+	return nil, helper.ErrKeyNotFound
+}
+
+func (r *memoryUserKeyRepo) CreateKey(ctx context.Context, key *entity.UserKey) (*entity.UserKey, error) {
+	if existing, err := r.ActiveKey(ctx, key.UserID); err == nil {
+		return existing, nil
+	}
+
+	r.mu.Lock()
+	created := *key
+	created.ID = len(r.keys) + 1
+	created.Active = true
+	r.keys = append(r.keys, &created)
+	r.mu.Unlock()
+
+	return r.ActiveKey(ctx, key.UserID)
+}
+
+func (r *memoryUserKeyRepo) KeysNotWrappedWith(_ context.Context, masterKeyID string, limit int) ([]*entity.UserKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var keys []*entity.UserKey
+	for _, key := range r.keys {
+		if key.MasterKeyID != masterKeyID && len(keys) < limit {
+			copied := *key
+			keys = append(keys, &copied)
+		}
+	}
+
+	return keys, nil
+}
+
+func (r *memoryUserKeyRepo) Rewrap(_ context.Context, key *entity.UserKey, previousMasterKeyID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stored := range r.keys {
+		if stored.ID == key.ID && stored.MasterKeyID == previousMasterKeyID {
+			stored.MasterKeyID = key.MasterKeyID
+			stored.WrappedKey = key.WrappedKey
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *memoryUserKeyRepo) RetireActiveKeys(_ context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var retired int64
+	for _, key := range r.keys {
+		if key.Active {
+			key.Active = false
+			retired++
+		}
+	}
+
+	return retired, nil
+}
+
+func TestEnvelopeEncryption_EncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	keyring, err := NewKeyring("1", testMasterKeyV1, nil)
+	assert.NoError(t, err)
+	repo := &memoryUserKeyRepo{}
+	envelope := NewEnvelopeEncryption(keyring, repo)
+
+	ciphertext, err := envelope.Encrypt(ctx, 7, "секрет")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(ciphertext, "v2:1:"))
+	assert.Len(t, repo.keys, 1)
+
+	plaintext, err := envelope.Decrypt(ctx, 7, ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "секрет", plaintext)
+
+	_, err = envelope.Encrypt(ctx, 7, "ещё секрет")
+	assert.NoError(t, err)
+	assert.Len(t, repo.keys, 1, "у пользователя один действующий ключ")
+
+	otherCiphertext, err := envelope.Encrypt(ctx, 8, "секрет")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(otherCiphertext, "v2:2:"))
+
+	_, err = envelope.Decrypt(ctx, 8, ciphertext)
+	assert.ErrorIs(t, err, helper.ErrKeyNotFound, "чужой ключ недоступен, даже если он уже в кэше")
+}
+
+func TestEnvelopeEncryption_DecryptLegacy(t *testing.T) {
+	ctx := context.Background()
+	keyring, err := NewKeyring("1", testMasterKeyV1, nil)
+	assert.NoError(t, err)
+	envelope := NewEnvelopeEncryption(keyring, &memoryUserKeyRepo{})
+
+	legacy, err := NewEncryptionService(testMasterKeyV1).Encrypt("старые данные")
+	assert.NoError(t, err)
+
+	plaintext, err := envelope.Decrypt(ctx, 7, legacy)
+	assert.NoError(t, err)
+	assert.Equal(t, "старые данные", plaintext)
+}
+
+func TestParseEnvelopeCiphertext(t *testing.T) {
+	keyID, body, ok := ParseEnvelopeCiphertext("v2:15:abc")
+	assert.True(t, ok)
+	assert.Equal(t, 15, keyID)
+	assert.Equal(t, "abc", body)
+
+	_, _, ok = ParseEnvelopeCiphertext("abc")
+	assert.False(t, ok)
+
+	_, _, ok = ParseEnvelopeCiphertext("v2:x:abc")
+	assert.False(t, ok)
+}
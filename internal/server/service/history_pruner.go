@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+)
+
+type versionPruner interface {
+	PruneVersions(ctx context.Context, keep int, before time.Time) (int64, error)
+}
+
+// HistoryPruner удаляет прежние версии записей сверх лимита: лишние по числу у каждой записи
+// и устаревшие по возрасту.
+type HistoryPruner struct {
+	repo   versionPruner
+	logger logger.CustomLogger
+	now    func() time.Time
+	maxAge time.Duration
+	keep   int
+}
+
+// NewHistoryPruner - конструктор очистки истории. У записи хранится не больше keep последних версий,
+// версии старше maxAge удаляются. Нулевое значение снимает соответствующее ограничение.
+func NewHistoryPruner(repo versionPruner, keep int, maxAge time.Duration, logger logger.CustomLogger) *HistoryPruner {
+	return &HistoryPruner{repo: repo, logger: logger, now: time.Now, maxAge: maxAge, keep: keep}
+}
+
+// Prune выполняет один проход очистки и возвращает число удалённых версий.
+func (p *HistoryPruner) Prune(ctx context.Context) (int64, error) {
+	var before time.Time
+	if p.maxAge > 0 {
+		before = p.now().Add(-p.maxAge)
+	}
+
+	return p.repo.PruneVersions(ctx, p.keep, before)
+}
+
+// Run запускает Prune каждые interval, пока не отменён ctx. Ошибки прохода логируются.
+func (p *HistoryPruner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Prune(ctx); err != nil {
+			p.logger.LogInfo("Ошибка при удалении старых версий записей", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingPruner struct {
+	before time.Time
+	keep   int
+}
+
+func (r *recordingPruner) PruneVersions(_ context.Context, keep int, before time.Time) (int64, error) {
+	r.keep, r.before = keep, before
+	return 3, nil
+}
+
+func TestHistoryPruner_Prune(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo := &recordingPruner{}
+
+	pruner := NewHistoryPruner(repo, 20, 24*time.Hour, new(mockLogger))
+	pruner.now = func() time.Time { return now }
+
+	pruned, err := pruner.Prune(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), pruned)
+	assert.Equal(t, 20, repo.keep)
+	assert.Equal(t, now.Add(-24*time.Hour), repo.before)
+
+	pruner = NewHistoryPruner(repo, 0, 0, new(mockLogger))
+	_, err = pruner.Prune(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, repo.before.IsZero(), "без срока хранения версии не устаревают")
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+)
+
+type rotatorKeyRepo interface {
+	KeysNotWrappedWith(ctx context.Context, masterKeyID string, limit int) ([]*entity.UserKey, error)
+	Rewrap(ctx context.Context, key *entity.UserKey, previousMasterKeyID string) (bool, error)
+	RetireActiveKeys(ctx context.Context) (int64, error)
+}
+
+type rotatorDataRepo interface {
+	DataBatch(ctx context.Context, afterID, limit int) ([]*entity.UserData, error)
+	ReplaceCiphertext(ctx context.Context, old *entity.UserData, info, meta string) (bool, error)
+}
+
+// RotationStats - итог прохода ротации.
+type RotationStats struct {
+	// Processed - сколько ключей или записей просмотрено.
+	Processed int
+	// Updated - сколько из них перезаписано.
+	Updated int
+	// Skipped - сколько не удалось обновить из-за параллельного изменения.
+	Skipped int
+}
+
+// KeyRotator переобёртывает ключи пользователей новым мастер-ключом
+// и перешифровывает записи действующими ключами пользователей.
+// Все изменения выполняются по одной записи с проверкой, что она не изменилась,
+// поэтому ротацию можно запускать на работающем сервере.
+type KeyRotator struct {
+	keyring  *Keyring
+	envelope *EnvelopeEncryption
+	keyRepo  rotatorKeyRepo
+	dataRepo rotatorDataRepo
+}
+
+// NewKeyRotator - конструктор сервиса ротации ключей.
+func NewKeyRotator(
+	keyring *Keyring,
+	envelope *EnvelopeEncryption,
+	keyRepo rotatorKeyRepo,
+	dataRepo rotatorDataRepo,
+) *KeyRotator {
+	return &KeyRotator{
+		keyring:  keyring,
+		envelope: envelope,
+		keyRepo:  keyRepo,
+		dataRepo: dataRepo,
+	}
+}
+
+// RewrapKeys переобёртывает активным мастер-ключом все ключи пользователей,
+// обёрнутые другими мастер-ключами. Сами данные при этом не перешифровываются.
+func (r *KeyRotator) RewrapKeys(ctx context.Context, batchSize int) (RotationStats, error) {
+	var stats RotationStats
+	activeID := r.keyring.ActiveID()
+
+	for {
+		keys, err := r.keyRepo.KeysNotWrappedWith(ctx, activeID, batchSize)
+		if err != nil {
+			return stats, fmt.Errorf("ошибка получения ключей для переобёртывания: %w", err)
+		}
+		if len(keys) == 0 {
+			return stats, nil
+		}
+
+		for _, key := range keys {
+			stats.Processed++
+
+			dek, err := r.keyring.Unwrap(key.MasterKeyID, key.WrappedKey)
+			if err != nil {
+				return stats, fmt.Errorf("ключ %d: %w", key.ID, err)
+			}
+
+			previousMasterKeyID := key.MasterKeyID
+			key.MasterKeyID, key.WrappedKey, err = r.keyring.Wrap(dek)
+			if err != nil {
+				return stats, fmt.Errorf("ключ %d: %w", key.ID, err)
+			}
+
+			ok, err := r.keyRepo.Rewrap(ctx, key, previousMasterKeyID)
+			if err != nil {
+				return stats, fmt.Errorf("ключ %d: %w", key.ID, err)
+			}
+			if ok {
+				stats.Updated++
+			} else {
+				stats.Skipped++
+			}
+		}
+	}
+}
+
+// RetireKeys выводит из оборота действующие ключи пользователей,
+// чтобы следующий ReencryptData перешифровал данные новыми ключами.
+func (r *KeyRotator) RetireKeys(ctx context.Context) (int64, error) {
+	retired, err := r.keyRepo.RetireActiveKeys(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка вывода ключей из оборота: %w", err)
+	}
+
+	return retired, nil
+}
+
+// ReencryptData перешифровывает действующими ключами пользователей записи,
+// зашифрованные напрямую мастер-ключом или выведенными из оборота ключами.
+func (r *KeyRotator) ReencryptData(ctx context.Context, batchSize int) (RotationStats, error) {
+	var stats RotationStats
+	activeKeyIDs := make(map[int]int)
+	afterID := 0
+
+	for {
+		batch, err := r.dataRepo.DataBatch(ctx, afterID, batchSize)
+		if err != nil {
+			return stats, fmt.Errorf("ошибка получения записей для перешифрования: %w", err)
+		}
+		if len(batch) == 0 {
+			return stats, nil
+		}
+
+		for _, data := range batch {
+			afterID = data.ID
+			stats.Processed++
+
+			activeKeyID, ok := activeKeyIDs[data.UserID]
+			if !ok {
+				activeKeyID, err = r.envelope.ActiveKeyID(ctx, data.UserID)
+				if err != nil {
+					return stats, fmt.Errorf("запись %d: %w", data.ID, err)
+				}
+				activeKeyIDs[data.UserID] = activeKeyID
+			}
+
+			info, infoChanged, err := r.reencrypt(ctx, data.UserID, activeKeyID, data.Info)
+			if err != nil {
+				return stats, fmt.Errorf("запись %d, Info: %w", data.ID, err)
+			}
+			meta, metaChanged, err := r.reencrypt(ctx, data.UserID, activeKeyID, data.Meta)
+			if err != nil {
+				return stats, fmt.Errorf("запись %d, Meta: %w", data.ID, err)
+			}
+			if !infoChanged && !metaChanged {
+				continue
+			}
+
+			replaced, err := r.dataRepo.ReplaceCiphertext(ctx, data, info, meta)
+			if err != nil {
+				return stats, fmt.Errorf("запись %d: %w", data.ID, err)
+			}
+			if replaced {
+				stats.Updated++
+			} else {
+				stats.Skipped++
+			}
+		}
+	}
+}
+
+func (r *KeyRotator) reencrypt(
+	ctx context.Context,
+	userID, activeKeyID int,
+	ciphertext string,
+) (result string, changed bool, err error) {
+	if keyID, _, ok := ParseEnvelopeCiphertext(ciphertext); ok && keyID == activeKeyID {
+		return ciphertext, false, nil
+	}
+
+	plaintext, err := r.envelope.Decrypt(ctx, userID, ciphertext)
+	if err != nil {
+		return "", false, err
+	}
+
+	result, err = r.envelope.Encrypt(ctx, userID, plaintext)
+	if err != nil {
+		return "", false, err
+	}
+
+	return result, true, nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryDataRepo - хранилище записей в памяти для проверки ротации.
+type memoryDataRepo struct {
+	rows []*entity.UserData
+}
+
+func (r *memoryDataRepo) DataBatch(_ context.Context, afterID, limit int) ([]*entity.UserData, error) {
+	var batch []*entity.UserData
+	for _, row := range r.rows {
+		if row.ID > afterID && len(batch) < limit {
+			copied := *row
+			batch = append(batch, &copied)
+		}
+	}
+
+	return batch, nil
+}
+
+func (r *memoryDataRepo) ReplaceCiphertext(_ context.Context, old *entity.UserData, info, meta string) (bool, error) {
+	for _, row := range r.rows {
+		if row.ID == old.ID && row.Info == old.Info && row.Meta == old.Meta {
+			row.Info = info
+			row.Meta = meta
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func TestKeyRotator_RewrapKeys(t *testing.T) {
+	ctx := context.Background()
+	keyRepo := &memoryUserKeyRepo{}
+
+	oldKeyring, err := NewKeyring("1", testMasterKeyV1, nil)
+	assert.NoError(t, err)
+	ciphertext, err := NewEnvelopeEncryption(oldKeyring, keyRepo).Encrypt(ctx, 7, "секрет")
+	assert.NoError(t, err)
+
+	keyring, err := NewKeyring("2", testMasterKeyV2, map[string][]byte{"1": testMasterKeyV1})
+	assert.NoError(t, err)
+	envelope := NewEnvelopeEncryption(keyring, keyRepo)
+	rotator := NewKeyRotator(keyring, envelope, keyRepo, &memoryDataRepo{})
+
+	stats, err := rotator.RewrapKeys(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, RotationStats{Processed: 1, Updated: 1}, stats)
+	assert.Equal(t, "2", keyRepo.keys[0].MasterKeyID)
+
+	keyringWithoutOld, err := NewKeyring("2", testMasterKeyV2, nil)
+	assert.NoError(t, err)
+	plaintext, err := NewEnvelopeEncryption(keyringWithoutOld, keyRepo).Decrypt(ctx, 7, ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "секрет", plaintext, "после переобёртывания старый мастер-ключ не нужен")
+}
+
+func TestKeyRotator_ReencryptData(t *testing.T) {
+	ctx := context.Background()
+	keyRepo := &memoryUserKeyRepo{}
+	keyring, err := NewKeyring("2", testMasterKeyV2, map[string][]byte{"1": testMasterKeyV1})
+	assert.NoError(t, err)
+	envelope := NewEnvelopeEncryption(keyring, keyRepo)
+
+	legacyInfo, err := NewEncryptionService(testMasterKeyV1).Encrypt("старое")
+	assert.NoError(t, err)
+	legacyMeta, err := NewEncryptionService(testMasterKeyV1).Encrypt("мета")
+	assert.NoError(t, err)
+	currentInfo, err := envelope.Encrypt(ctx, 8, "новое")
+	assert.NoError(t, err)
+	currentMeta, err := envelope.Encrypt(ctx, 8, "мета")
+	assert.NoError(t, err)
+
+	dataRepo := &memoryDataRepo{rows: []*entity.UserData{
+		{ID: 1, UserID: 7, Info: legacyInfo, Meta: legacyMeta},
+		{ID: 2, UserID: 8, Info: currentInfo, Meta: currentMeta},
+	}}
+	rotator := NewKeyRotator(keyring, envelope, keyRepo, dataRepo)
+
+	stats, err := rotator.ReencryptData(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, RotationStats{Processed: 2, Updated: 1}, stats)
+	assert.Equal(t, currentInfo, dataRepo.rows[1].Info, "запись на действующем ключе не перезаписывается")
+
+	keyID, _, ok := ParseEnvelopeCiphertext(dataRepo.rows[0].Info)
+	assert.True(t, ok)
+	activeKeyID, err := envelope.ActiveKeyID(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, activeKeyID, keyID)
+
+	retired, err := rotator.RetireKeys(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), retired)
+
+	stats, err = rotator.ReencryptData(ctx, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, RotationStats{Processed: 2, Updated: 2}, stats)
+
+	plaintext, err := envelope.Decrypt(ctx, 8, dataRepo.rows[1].Info)
+	assert.NoError(t, err)
+	assert.Equal(t, "новое", plaintext)
+}
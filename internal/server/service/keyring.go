@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+type keyProvider interface {
+	Key(ctx context.Context, keyID string) ([]byte, error)
+}
+
+// Keyring - набор версионированных мастер-ключей сервера.
+// Активный ключ оборачивает новые ключи пользователей, остальные нужны только для разворачивания
+// ключей, которые ещё не были переобёрнуты после ротации.
+type Keyring struct {
+	keys     map[string][]byte
+	activeID string
+}
+
+// NewKeyring - конструктор набора мастер-ключей.
+func NewKeyring(activeID string, activeKey []byte, oldKeys map[string][]byte) (*Keyring, error) {
+	if activeID == "" {
+		return nil, errors.New("не задан идентификатор активного мастер-ключа")
+	}
+
+	keys := make(map[string][]byte, len(oldKeys)+1)
+	for id, key := range oldKeys {
+		keys[id] = key
+	}
+	keys[activeID] = activeKey
+
+	for id, key := range keys {
+		if !isValidAESKeyLength(len(key)) {
+			return nil, fmt.Errorf("мастер-ключ %q должен быть длиной 16, 24 или 32 байта", id)
+		}
+	}
+
+	return &Keyring{keys: keys, activeID: activeID}, nil
+}
+
+// LoadKeyring загружает из источника активный мастер-ключ и его предыдущие версии.
+func LoadKeyring(ctx context.Context, provider keyProvider, activeID string, oldIDs []string) (*Keyring, error) {
+	activeKey, err := provider.Key(ctx, activeID)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось загрузить активный мастер-ключ %q: %w", activeID, err)
+	}
+
+	oldKeys := make(map[string][]byte, len(oldIDs))
+	for _, id := range oldIDs {
+		if id == activeID {
+			continue
+		}
+
+		key, err := provider.Key(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось загрузить мастер-ключ %q: %w", id, err)
+		}
+		oldKeys[id] = key
+	}
+
+	return NewKeyring(activeID, activeKey, oldKeys)
+}
+
+// ActiveID возвращает идентификатор активного мастер-ключа.
+func (k *Keyring) ActiveID() string {
+	return k.activeID
+}
+
+// Wrap шифрует ключ пользователя активным мастер-ключом.
+func (k *Keyring) Wrap(dek []byte) (masterKeyID, wrapped string, err error) {
+	wrapped, err = NewEncryptionService(k.keys[k.activeID]).Encrypt(string(dek))
+	if err != nil {
+		return "", "", fmt.Errorf("ошибка обёртывания ключа: %w", err)
+	}
+
+	return k.activeID, wrapped, nil
+}
+
+// Unwrap расшифровывает ключ пользователя мастер-ключом, которым он был обёрнут.
+func (k *Keyring) Unwrap(masterKeyID, wrapped string) ([]byte, error) {
+	masterKey, ok := k.keys[masterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("мастер-ключ %q не загружен", masterKeyID)
+	}
+
+	dek, err := NewEncryptionService(masterKey).Decrypt(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка разворачивания ключа: %w", err)
+	}
+
+	return []byte(dek), nil
+}
+
+// DeriveKey выводит из мастер-ключа keyID ключ для отдельного назначения purpose, например для
+// слепого индекса поиска. Пустой keyID - активный мастер-ключ.
+func (k *Keyring) DeriveKey(keyID, purpose string) ([]byte, error) {
+	if keyID == "" {
+		keyID = k.activeID
+	}
+
+	masterKey, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("мастер-ключ %q не загружен", keyID)
+	}
+
+	mac := hmac.New(sha256.New, masterKey)
+	mac.Write([]byte(purpose))
+
+	return mac.Sum(nil), nil
+}
+
+// DecryptLegacy расшифровывает данные, зашифрованные до перехода на ключи пользователей
+// напрямую одним из мастер-ключей.
+func (k *Keyring) DecryptLegacy(ciphertext string) (string, error) {
+	ids := make([]string, 0, len(k.keys))
+	ids = append(ids, k.activeID)
+	for id := range k.keys {
+		if id != k.activeID {
+			ids = append(ids, id)
+		}
+	}
+
+	var lastErr error
+	for _, id := range ids {
+		plaintext, err := NewEncryptionService(k.keys[id]).Decrypt(ciphertext)
+		if err == nil {
+			return plaintext, nil
+		}
+		lastErr = err
+	}
+
+	return "", fmt.Errorf("ни один мастер-ключ не подошёл: %w", lastErr)
+}
+
+func isValidAESKeyLength(length int) bool {
+	const (
+		aes128 = 16
+		aes192 = 24
+		aes256 = 32
+	)
+
+	return length == aes128 || length == aes192 || length == aes256
+}
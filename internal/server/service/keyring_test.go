@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testMasterKeyV1 = []byte("01234567890123456789012345678901")
+	testMasterKeyV2 = []byte("10987654321098765432109876543210")
+)
+
+func TestNewKeyring_InvalidKey(t *testing.T) {
+	_, err := NewKeyring("1", []byte("short"), nil)
+	assert.Error(t, err)
+
+	_, err = NewKeyring("2", testMasterKeyV2, map[string][]byte{"1": []byte("short")})
+	assert.Error(t, err)
+
+	_, err = NewKeyring("", testMasterKeyV1, nil)
+	assert.Error(t, err)
+}
+
+func TestKeyring_WrapUnwrap(t *testing.T) {
+	oldKeyring, err := NewKeyring("1", testMasterKeyV1, nil)
+	assert.NoError(t, err)
+
+	masterKeyID, wrapped, err := oldKeyring.Wrap([]byte("ключ пользователя"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", masterKeyID)
+
+	keyring, err := NewKeyring("2", testMasterKeyV2, map[string][]byte{"1": testMasterKeyV1})
+	assert.NoError(t, err)
+
+	dek, err := keyring.Unwrap(masterKeyID, wrapped)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("ключ пользователя"), dek)
+
+	_, err = keyring.Unwrap("3", wrapped)
+	assert.Error(t, err)
+}
+
+func TestKeyring_DecryptLegacy(t *testing.T) {
+	ciphertext, err := NewEncryptionService(testMasterKeyV1).Encrypt("старые данные")
+	assert.NoError(t, err)
+
+	keyring, err := NewKeyring("2", testMasterKeyV2, map[string][]byte{"1": testMasterKeyV1})
+	assert.NoError(t, err)
+
+	plaintext, err := keyring.DecryptLegacy(ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "старые данные", plaintext)
+
+	keyringWithoutOld, err := NewKeyring("2", testMasterKeyV2, nil)
+	assert.NoError(t, err)
+
+	_, err = keyringWithoutOld.DecryptLegacy(ciphertext)
+	assert.Error(t, err)
+}
+
+func TestKeyring_DeriveKey(t *testing.T) {
+	keyring, err := NewKeyring("2", testMasterKeyV2, map[string][]byte{"1": testMasterKeyV1})
+	assert.NoError(t, err)
+
+	active, err := keyring.DeriveKey("", "search")
+	assert.NoError(t, err)
+	explicit, err := keyring.DeriveKey("2", "search")
+	assert.NoError(t, err)
+	assert.Equal(t, active, explicit)
+	assert.Len(t, active, 32)
+
+	old, err := keyring.DeriveKey("1", "search")
+	assert.NoError(t, err)
+	assert.NotEqual(t, active, old)
+
+	other, err := keyring.DeriveKey("2", "other")
+	assert.NoError(t, err)
+	assert.NotEqual(t, active, other)
+
+	_, err = keyring.DeriveKey("3", "search")
+	assert.Error(t, err)
+}
+
+type mapKeyProvider map[string][]byte
+
+func (p mapKeyProvider) Key(_ context.Context, keyID string) ([]byte, error) {
+	key, ok := p[keyID]
+	if !ok {
+		return nil, errors.New("ключ не найден")
+	}
+
+	return key, nil
+}
+
+func TestLoadKeyring(t *testing.T) {
+	ctx := context.Background()
+	provider := mapKeyProvider{"1": testMasterKeyV1, "2": testMasterKeyV2}
+
+	keyring, err := LoadKeyring(ctx, provider, "2", []string{"1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", keyring.ActiveID())
+
+	legacy, err := NewEncryptionService(testMasterKeyV1).Encrypt("старые данные")
+	assert.NoError(t, err)
+	plaintext, err := keyring.DecryptLegacy(legacy)
+	assert.NoError(t, err)
+	assert.Equal(t, "старые данные", plaintext)
+
+	_, err = LoadKeyring(ctx, provider, "3", nil)
+	assert.Error(t, err)
+
+	_, err = LoadKeyring(ctx, provider, "2", []string{"3"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+)
+
+// LockoutPolicy - параметры прогрессивной блокировки входа.
+// Первые FreeAttempts неудачных попыток проходят без блокировки,
+// далее каждая неудача блокирует вход на BaseDelay, удваивая его вплоть до MaxDelay.
+// Если с последней неудачи прошло больше Window, счётчик начинается заново.
+type LockoutPolicy struct {
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	Window       time.Duration
+}
+
+var (
+	// LoginLockoutPolicy - политика блокировки для одного логина.
+	LoginLockoutPolicy = LockoutPolicy{
+		FreeAttempts: 5,
+		BaseDelay:    30 * time.Second,
+		MaxDelay:     15 * time.Minute,
+		Window:       time.Hour,
+	}
+	// IPLockoutPolicy - политика блокировки для одного IP-адреса, более мягкая,
+	// так как за одним адресом может находиться несколько пользователей.
+	IPLockoutPolicy = LockoutPolicy{
+		FreeAttempts: 20,
+		BaseDelay:    time.Minute,
+		MaxDelay:     time.Hour,
+		Window:       time.Hour,
+	}
+)
+
+// Delay возвращает длительность блокировки после failedCount неудачных попыток.
+func (p LockoutPolicy) Delay(failedCount int) time.Duration {
+	if failedCount <= p.FreeAttempts {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failedCount; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+
+	return delay
+}
+
+type loginAttemptRepo interface {
+	Attempt(ctx context.Context, kind, key string) (*entity.LoginAttempt, error)
+	IncrementFailures(ctx context.Context, kind, key string, window time.Duration) (int, error)
+	LockUntil(ctx context.Context, kind, key string, until time.Time) error
+	ResetAttempts(ctx context.Context, kind, key string) error
+}
+
+type loginGuard struct {
+	repo     loginAttemptRepo
+	policies map[string]LockoutPolicy
+	now      func() time.Time
+}
+
+// NewLoginGuard - конструктор сервиса защиты от перебора паролей.
+func NewLoginGuard(repo loginAttemptRepo) *loginGuard {
+	return &loginGuard{
+		repo: repo,
+		policies: map[string]LockoutPolicy{
+			entity.AttemptKindLogin: LoginLockoutPolicy,
+			entity.AttemptKindIP:    IPLockoutPolicy,
+		},
+		now: time.Now,
+	}
+}
+
+// Check возвращает ErrTooManyAttempts, если вход для логина или IP-адреса временно заблокирован.
+func (g *loginGuard) Check(ctx context.Context, login, ip string) error {
+	for kind, key := range attemptKeys(login, ip) {
+		attempt, err := g.repo.Attempt(ctx, kind, key)
+		if err != nil {
+			return fmt.Errorf("ошибка проверки блокировки входа: %w", err)
+		}
+		if g.now().Before(attempt.LockedUntil) {
+			return helper.ErrTooManyAttempts
+		}
+	}
+
+	return nil
+}
+
+// RegisterFailure учитывает неудачную попытку входа и при необходимости блокирует вход.
+func (g *loginGuard) RegisterFailure(ctx context.Context, login, ip string) error {
+	for kind, key := range attemptKeys(login, ip) {
+		policy := g.policies[kind]
+
+		failedCount, err := g.repo.IncrementFailures(ctx, kind, key, policy.Window)
+		if err != nil {
+			return fmt.Errorf("ошибка учёта неудачной попытки входа: %w", err)
+		}
+
+		delay := policy.Delay(failedCount)
+		if delay == 0 {
+			continue
+		}
+
+		if err := g.repo.LockUntil(ctx, kind, key, g.now().Add(delay)); err != nil {
+			return fmt.Errorf("ошибка блокировки входа: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Reset сбрасывает счётчик логина после успешного входа.
+// Счётчик IP-адреса не сбрасывается, иначе владелец одной учётной записи
+// мог бы обнулять его между попытками перебора чужих паролей.
+func (g *loginGuard) Reset(ctx context.Context, login string) error {
+	if err := g.repo.ResetAttempts(ctx, entity.AttemptKindLogin, login); err != nil {
+		return fmt.Errorf("ошибка сброса счётчика попыток входа: %w", err)
+	}
+
+	return nil
+}
+
+func attemptKeys(login, ip string) map[string]string {
+	keys := map[string]string{entity.AttemptKindLogin: login}
+	if ip != "" {
+		keys[entity.AttemptKindIP] = ip
+	}
+
+	return keys
+}
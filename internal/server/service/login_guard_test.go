@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type LoginAttemptRepoMock struct {
+	mock.Mock
+}
+
+func (m *LoginAttemptRepoMock) Attempt(ctx context.Context, kind, key string) (*entity.LoginAttempt, error) {
+	args := m.Called(ctx, kind, key)
+	attempt, _ := args.Get(0).(*entity.LoginAttempt)
+	return attempt, args.Error(1)
+}
+
+func (m *LoginAttemptRepoMock) IncrementFailures(
+	ctx context.Context,
+	kind, key string,
+	window time.Duration,
+) (int, error) {
+	args := m.Called(ctx, kind, key, window)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *LoginAttemptRepoMock) LockUntil(ctx context.Context, kind, key string, until time.Time) error {
+	args := m.Called(ctx, kind, key, until)
+	return args.Error(0)
+}
+
+func (m *LoginAttemptRepoMock) ResetAttempts(ctx context.Context, kind, key string) error {
+	args := m.Called(ctx, kind, key)
+	return args.Error(0)
+}
+
+func TestLockoutPolicy_Delay(t *testing.T) {
+	policy := LockoutPolicy{FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	assert.Equal(t, time.Duration(0), policy.Delay(3))
+	assert.Equal(t, time.Second, policy.Delay(4))
+	assert.Equal(t, 2*time.Second, policy.Delay(5))
+	assert.Equal(t, 4*time.Second, policy.Delay(6))
+	assert.Equal(t, 5*time.Second, policy.Delay(7))
+	assert.Equal(t, 5*time.Second, policy.Delay(100))
+}
+
+func TestLoginGuard_Check(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Вход разрешён", func(t *testing.T) {
+		repo := new(LoginAttemptRepoMock)
+		repo.On("Attempt", ctx, entity.AttemptKindLogin, "user").Return(&entity.LoginAttempt{}, nil)
+		repo.On("Attempt", ctx, entity.AttemptKindIP, "10.0.0.1").Return(&entity.LoginAttempt{}, nil)
+
+		guard := NewLoginGuard(repo)
+		guard.now = func() time.Time { return now }
+
+		assert.NoError(t, guard.Check(ctx, "user", "10.0.0.1"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("IP заблокирован", func(t *testing.T) {
+		repo := new(LoginAttemptRepoMock)
+		repo.On("Attempt", ctx, entity.AttemptKindLogin, "user").Return(&entity.LoginAttempt{}, nil).Maybe()
+		repo.On("Attempt", ctx, entity.AttemptKindIP, "10.0.0.1").
+			Return(&entity.LoginAttempt{LockedUntil: now.Add(time.Minute)}, nil)
+
+		guard := NewLoginGuard(repo)
+		guard.now = func() time.Time { return now }
+
+		assert.ErrorIs(t, guard.Check(ctx, "user", "10.0.0.1"), helper.ErrTooManyAttempts)
+	})
+
+	t.Run("Без IP проверяется только логин", func(t *testing.T) {
+		repo := new(LoginAttemptRepoMock)
+		repo.On("Attempt", ctx, entity.AttemptKindLogin, "user").
+			Return(&entity.LoginAttempt{LockedUntil: now.Add(-time.Minute)}, nil)
+
+		guard := NewLoginGuard(repo)
+		guard.now = func() time.Time { return now }
+
+		assert.NoError(t, guard.Check(ctx, "user", ""))
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestLoginGuard_RegisterFailure(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	repo := new(LoginAttemptRepoMock)
+	repo.On("IncrementFailures", ctx, entity.AttemptKindLogin, "user", LoginLockoutPolicy.Window).
+		Return(LoginLockoutPolicy.FreeAttempts+1, nil)
+	repo.On("LockUntil", ctx, entity.AttemptKindLogin, "user", now.Add(LoginLockoutPolicy.BaseDelay)).Return(nil)
+	repo.On("IncrementFailures", ctx, entity.AttemptKindIP, "10.0.0.1", IPLockoutPolicy.Window).Return(1, nil)
+
+	guard := NewLoginGuard(repo)
+	guard.now = func() time.Time { return now }
+
+	assert.NoError(t, guard.RegisterFailure(ctx, "user", "10.0.0.1"))
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "LockUntil", ctx, entity.AttemptKindIP, "10.0.0.1", mock.Anything)
+}
+
+func TestLoginGuard_Reset(t *testing.T) {
+	ctx := context.Background()
+
+	repo := new(LoginAttemptRepoMock)
+	repo.On("ResetAttempts", ctx, entity.AttemptKindLogin, "user").Return(nil)
+
+	guard := NewLoginGuard(repo)
+
+	assert.NoError(t, guard.Reset(ctx, "user"))
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+)
+
+type organizerRepo interface {
+	CreateFolder(ctx context.Context, folder *entity.Folder) (int, error)
+	UpdateFolder(ctx context.Context, folder *entity.Folder) error
+	DeleteFolder(ctx context.Context, userID, folderID int) error
+	ListFolders(ctx context.Context, userID int) ([]*entity.Folder, error)
+	CreateTag(ctx context.Context, tag *entity.Tag) (int, error)
+	DeleteTag(ctx context.Context, userID, tagID int) error
+	ListTags(ctx context.Context, userID int) ([]*entity.Tag, error)
+	TagData(ctx context.Context, userID, dataID int, tagIDs []int) error
+	UntagData(ctx context.Context, userID, dataID int, tagIDs []int) error
+	MoveData(ctx context.Context, userID, dataID, folderID int) error
+}
+
+// organizerService управляет папками и тегами записей. Имена папок и тегов шифруются так же, как Meta.
+// Перенос записи и изменение её тегов публикуются подписчикам WatchData как изменение записи.
+type organizerService struct {
+	repo              organizerRepo
+	encryptionService encryptor
+	bus               eventBus
+	logger            logger.CustomLogger
+}
+
+// NewOrganizerService - конструктор сервиса папок и тегов.
+func NewOrganizerService(
+	repo organizerRepo,
+	encryptionService encryptor,
+	bus eventBus,
+	logger logger.CustomLogger,
+) *organizerService {
+	return &organizerService{
+		repo:              repo,
+		encryptionService: encryptionService,
+		bus:               bus,
+		logger:            logger,
+	}
+}
+
+func (s *organizerService) CreateFolder(ctx context.Context, userID int, folder *entity.Folder) (int, error) {
+	if folder.Name == "" {
+		return 0, helper.ErrEmptyName
+	}
+	if folder.ParentID != 0 {
+		folders, err := s.repo.ListFolders(ctx, userID)
+		if err != nil {
+			return 0, fmt.Errorf("ошибка получения папок: %w", err)
+		}
+		if findFolder(folders, folder.ParentID) == nil {
+			return 0, helper.ErrFolderNotFound
+		}
+	}
+
+	name, err := s.encryptionService.Encrypt(ctx, userID, folder.Name)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка шифрования имени папки: %w", err)
+	}
+
+	return s.repo.CreateFolder(ctx, &entity.Folder{UserID: userID, ParentID: folder.ParentID, Name: name})
+}
+
+// UpdateFolder переименовывает папку и переносит её в folder.ParentID. Папку нельзя перенести
+// в неё саму или в её подпапку: дерево папок не должно содержать циклов.
+func (s *organizerService) UpdateFolder(ctx context.Context, userID int, folder *entity.Folder) error {
+	if folder.Name == "" {
+		return helper.ErrEmptyName
+	}
+
+	folders, err := s.repo.ListFolders(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения папок: %w", err)
+	}
+	if findFolder(folders, folder.ID) == nil {
+		return helper.ErrFolderNotFound
+	}
+	for parentID := folder.ParentID; parentID != 0; {
+		if parentID == folder.ID {
+			return helper.ErrFolderCycle
+		}
+		parent := findFolder(folders, parentID)
+		if parent == nil {
+			return helper.ErrFolderNotFound
+		}
+		parentID = parent.ParentID
+	}
+
+	name, err := s.encryptionService.Encrypt(ctx, userID, folder.Name)
+	if err != nil {
+		return fmt.Errorf("ошибка шифрования имени папки: %w", err)
+	}
+
+	return s.repo.UpdateFolder(ctx, &entity.Folder{ID: folder.ID, UserID: userID, ParentID: folder.ParentID, Name: name})
+}
+
+func (s *organizerService) DeleteFolder(ctx context.Context, userID, folderID int) error {
+	return s.repo.DeleteFolder(ctx, userID, folderID)
+}
+
+// ListFolders возвращает все папки пользователя с расшифрованными именами.
+func (s *organizerService) ListFolders(ctx context.Context, userID int) ([]*entity.Folder, error) {
+	folders, err := s.repo.ListFolders(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения папок: %w", err)
+	}
+
+	for _, folder := range folders {
+		if folder.Name, err = s.encryptionService.Decrypt(ctx, userID, folder.Name); err != nil {
+			return nil, fmt.Errorf("ошибка расшифровки имени папки: %w", err)
+		}
+	}
+
+	return folders, nil
+}
+
+func (s *organizerService) CreateTag(ctx context.Context, userID int, tag *entity.Tag) (int, error) {
+	if tag.Name == "" {
+		return 0, helper.ErrEmptyName
+	}
+
+	name, err := s.encryptionService.Encrypt(ctx, userID, tag.Name)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка шифрования имени тега: %w", err)
+	}
+
+	return s.repo.CreateTag(ctx, &entity.Tag{UserID: userID, Name: name})
+}
+
+func (s *organizerService) DeleteTag(ctx context.Context, userID, tagID int) error {
+	return s.repo.DeleteTag(ctx, userID, tagID)
+}
+
+// ListTags возвращает все теги пользователя с расшифрованными именами.
+func (s *organizerService) ListTags(ctx context.Context, userID int) ([]*entity.Tag, error) {
+	tags, err := s.repo.ListTags(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения тегов: %w", err)
+	}
+
+	for _, tag := range tags {
+		if tag.Name, err = s.encryptionService.Decrypt(ctx, userID, tag.Name); err != nil {
+			return nil, fmt.Errorf("ошибка расшифровки имени тега: %w", err)
+		}
+	}
+
+	return tags, nil
+}
+
+func (s *organizerService) TagData(ctx context.Context, userID, dataID int, tagIDs []int) error {
+	if err := s.repo.TagData(ctx, userID, dataID, uniqueIDs(tagIDs)); err != nil {
+		return err
+	}
+
+	s.publishUpdated(ctx, userID, dataID)
+	return nil
+}
+
+func (s *organizerService) UntagData(ctx context.Context, userID, dataID int, tagIDs []int) error {
+	if err := s.repo.UntagData(ctx, userID, dataID, uniqueIDs(tagIDs)); err != nil {
+		return err
+	}
+
+	s.publishUpdated(ctx, userID, dataID)
+	return nil
+}
+
+// MoveData переносит запись в папку folderID, 0 - в корень.
+func (s *organizerService) MoveData(ctx context.Context, userID, dataID, folderID int) error {
+	if err := s.repo.MoveData(ctx, userID, dataID, folderID); err != nil {
+		return err
+	}
+
+	s.publishUpdated(ctx, userID, dataID)
+	return nil
+}
+
+// publishUpdated не возвращает ошибку: изменение уже сохранено, а пропущенное событие
+// клиент восполнит синхронизацией по ревизии.
+func (s *organizerService) publishUpdated(ctx context.Context, userID, dataID int) {
+	event := &entity.DataEvent{Kind: entity.DataUpdated, UserID: userID, ID: dataID}
+	if err := s.bus.Publish(ctx, event); err != nil {
+		s.logger.LogInfo("не удалось опубликовать событие об изменении данных", err)
+	}
+}
+
+func findFolder(folders []*entity.Folder, id int) *entity.Folder {
+	for _, folder := range folders {
+		if folder.ID == id {
+			return folder
+		}
+	}
+
+	return nil
+}
+
+// uniqueIDs возвращает ID без повторов в порядке возрастания.
+func uniqueIDs(ids []int) []int {
+	unique := slices.Clone(ids)
+	slices.Sort(unique)
+	return slices.Compact(unique)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type OrganizerRepoMock struct {
+	mock.Mock
+}
+
+func (m *OrganizerRepoMock) CreateFolder(ctx context.Context, folder *entity.Folder) (int, error) {
+	args := m.Called(ctx, folder)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *OrganizerRepoMock) UpdateFolder(ctx context.Context, folder *entity.Folder) error {
+	args := m.Called(ctx, folder)
+	return args.Error(0)
+}
+
+func (m *OrganizerRepoMock) DeleteFolder(ctx context.Context, userID, folderID int) error {
+	args := m.Called(ctx, userID, folderID)
+	return args.Error(0)
+}
+
+func (m *OrganizerRepoMock) ListFolders(ctx context.Context, userID int) ([]*entity.Folder, error) {
+	args := m.Called(ctx, userID)
+	folders, _ := args.Get(0).([]*entity.Folder)
+	return folders, args.Error(1)
+}
+
+func (m *OrganizerRepoMock) CreateTag(ctx context.Context, tag *entity.Tag) (int, error) {
+	args := m.Called(ctx, tag)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *OrganizerRepoMock) DeleteTag(ctx context.Context, userID, tagID int) error {
+	args := m.Called(ctx, userID, tagID)
+	return args.Error(0)
+}
+
+func (m *OrganizerRepoMock) ListTags(ctx context.Context, userID int) ([]*entity.Tag, error) {
+	args := m.Called(ctx, userID)
+	tags, _ := args.Get(0).([]*entity.Tag)
+	return tags, args.Error(1)
+}
+
+func (m *OrganizerRepoMock) TagData(ctx context.Context, userID, dataID int, tagIDs []int) error {
+	args := m.Called(ctx, userID, dataID, tagIDs)
+	return args.Error(0)
+}
+
+func (m *OrganizerRepoMock) UntagData(ctx context.Context, userID, dataID int, tagIDs []int) error {
+	args := m.Called(ctx, userID, dataID, tagIDs)
+	return args.Error(0)
+}
+
+func (m *OrganizerRepoMock) MoveData(ctx context.Context, userID, dataID, folderID int) error {
+	args := m.Called(ctx, userID, dataID, folderID)
+	return args.Error(0)
+}
+
+func TestOrganizerService_Folders(t *testing.T) {
+	ctx := context.Background()
+	encryptor := staticEncryptor{NewEncryptionService(testMasterKeyV1)}
+	repo := new(OrganizerRepoMock)
+	svc := NewOrganizerService(repo, encryptor, &recordingBus{}, new(mockLogger))
+
+	// 1 - корень, 2 внутри 1, 3 внутри 2.
+	stored := []*entity.Folder{{ID: 1}, {ID: 2, ParentID: 1}, {ID: 3, ParentID: 2}}
+	for _, folder := range stored {
+		folder.Name, _ = encryptor.Encrypt(ctx, 7, "папка")
+	}
+	repo.On("ListFolders", ctx, 7).Return(stored, nil)
+
+	var created *entity.Folder
+	repo.On("CreateFolder", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		created, _ = args.Get(1).(*entity.Folder)
+	}).Return(4, nil).Once()
+
+	id, err := svc.CreateFolder(ctx, 7, &entity.Folder{ParentID: 3, Name: "работа"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, id)
+	if assert.NotNil(t, created) {
+		assert.NotEqual(t, "работа", created.Name, "имя папки хранится зашифрованным")
+		name, _ := encryptor.Decrypt(ctx, 7, created.Name)
+		assert.Equal(t, "работа", name)
+	}
+
+	_, err = svc.CreateFolder(ctx, 7, &entity.Folder{ParentID: 9, Name: "работа"})
+	assert.ErrorIs(t, err, helper.ErrFolderNotFound)
+	_, err = svc.CreateFolder(ctx, 7, &entity.Folder{})
+	assert.ErrorIs(t, err, helper.ErrEmptyName)
+
+	assert.ErrorIs(t, svc.UpdateFolder(ctx, 7, &entity.Folder{ID: 1, ParentID: 3, Name: "a"}), helper.ErrFolderCycle)
+	assert.ErrorIs(t, svc.UpdateFolder(ctx, 7, &entity.Folder{ID: 2, ParentID: 2, Name: "a"}), helper.ErrFolderCycle)
+	assert.ErrorIs(t, svc.UpdateFolder(ctx, 7, &entity.Folder{ID: 5, Name: "a"}), helper.ErrFolderNotFound)
+
+	repo.On("UpdateFolder", ctx, mock.MatchedBy(func(folder *entity.Folder) bool {
+		return folder.ID == 3 && folder.ParentID == 1 && folder.UserID == 7
+	})).Return(nil).Once()
+	assert.NoError(t, svc.UpdateFolder(ctx, 7, &entity.Folder{ID: 3, ParentID: 1, Name: "a"}))
+
+	folders, err := svc.ListFolders(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, "папка", folders[0].Name)
+
+	repo.AssertExpectations(t)
+}
+
+func TestOrganizerService_Tags(t *testing.T) {
+	ctx := context.Background()
+	encryptor := staticEncryptor{NewEncryptionService(testMasterKeyV1)}
+	repo := new(OrganizerRepoMock)
+	bus := &recordingBus{}
+	svc := NewOrganizerService(repo, encryptor, bus, new(mockLogger))
+
+	repo.On("CreateTag", ctx, mock.MatchedBy(func(tag *entity.Tag) bool {
+		name, err := encryptor.Decrypt(ctx, 7, tag.Name)
+		return err == nil && name == "личное" && tag.UserID == 7
+	})).Return(3, nil).Once()
+	id, err := svc.CreateTag(ctx, 7, &entity.Tag{Name: "личное"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, id)
+
+	repo.On("TagData", ctx, 7, 5, []int{3, 4}).Return(nil).Once()
+	assert.NoError(t, svc.TagData(ctx, 7, 5, []int{4, 3, 4}))
+
+	repo.On("UntagData", ctx, 7, 5, []int{9}).Return(helper.ErrTagNotFound).Once()
+	assert.ErrorIs(t, svc.UntagData(ctx, 7, 5, []int{9}), helper.ErrTagNotFound)
+
+	repo.On("MoveData", ctx, 7, 5, 0).Return(nil).Once()
+	assert.NoError(t, svc.MoveData(ctx, 7, 5, 0))
+
+	assert.Equal(t, []*entity.DataEvent{
+		{Kind: entity.DataUpdated, UserID: 7, ID: 5},
+		{Kind: entity.DataUpdated, UserID: 7, ID: 5},
+	}, bus.published, "событие публикуется только после успешного изменения")
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+)
+
+type recordTypeRepo interface {
+	CreateRecordType(ctx context.Context, recordType *entity.RecordType) (int, error)
+	ListRecordTypes(ctx context.Context, userID int) ([]*entity.RecordType, error)
+	GetRecordType(ctx context.Context, userID int, name string) (*entity.RecordType, error)
+	DeleteRecordType(ctx context.Context, userID, recordTypeID int) error
+}
+
+// recordTypeService управляет пользовательскими типами записей. Схемы типов не шифруются: имя типа -
+// info_type записей, а по полям сервер проверяет содержимое, пришедшее без сквозного шифрования.
+type recordTypeService struct {
+	repo recordTypeRepo
+}
+
+// NewRecordTypeService - конструктор сервиса пользовательских типов записей.
+func NewRecordTypeService(repo recordTypeRepo) *recordTypeService {
+	return &recordTypeService{repo: repo}
+}
+
+// CreateRecordType создаёт тип записей. Схему типа проверяет обработчик.
+func (s *recordTypeService) CreateRecordType(
+	ctx context.Context,
+	userID int,
+	recordType *entity.RecordType,
+) (int, error) {
+	recordType.UserID = userID
+
+	id, err := s.repo.CreateRecordType(ctx, recordType)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка создания типа записей: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *recordTypeService) ListRecordTypes(ctx context.Context, userID int) ([]*entity.RecordType, error) {
+	recordTypes, err := s.repo.ListRecordTypes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения типов записей: %w", err)
+	}
+
+	return recordTypes, nil
+}
+
+// GetRecordType возвращает тип записей по имени, helper.ErrRecordTypeNotFound - если его нет.
+func (s *recordTypeService) GetRecordType(ctx context.Context, userID int, name string) (*entity.RecordType, error) {
+	recordType, err := s.repo.GetRecordType(ctx, userID, name)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения типа записей %s: %w", name, err)
+	}
+
+	return recordType, nil
+}
+
+// DeleteRecordType удаляет тип записей. Тип, у которого есть записи, не удаляется:
+// возвращается helper.ErrRecordTypeInUse.
+func (s *recordTypeService) DeleteRecordType(ctx context.Context, userID, recordTypeID int) error {
+	if err := s.repo.DeleteRecordType(ctx, userID, recordTypeID); err != nil {
+		return fmt.Errorf("ошибка удаления типа записей: %w", err)
+	}
+
+	return nil
+}
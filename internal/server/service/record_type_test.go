@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RecordTypeRepoMock struct {
+	mock.Mock
+}
+
+func (m *RecordTypeRepoMock) CreateRecordType(ctx context.Context, recordType *entity.RecordType) (int, error) {
+	args := m.Called(ctx, recordType)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *RecordTypeRepoMock) ListRecordTypes(ctx context.Context, userID int) ([]*entity.RecordType, error) {
+	args := m.Called(ctx, userID)
+	recordTypes, _ := args.Get(0).([]*entity.RecordType)
+	return recordTypes, args.Error(1)
+}
+
+func (m *RecordTypeRepoMock) GetRecordType(ctx context.Context, userID int, name string) (*entity.RecordType, error) {
+	args := m.Called(ctx, userID, name)
+	recordType, _ := args.Get(0).(*entity.RecordType)
+	return recordType, args.Error(1)
+}
+
+func (m *RecordTypeRepoMock) DeleteRecordType(ctx context.Context, userID, recordTypeID int) error {
+	args := m.Called(ctx, userID, recordTypeID)
+	return args.Error(0)
+}
+
+func TestRecordTypeService(t *testing.T) {
+	ctx := context.Background()
+	repo := new(RecordTypeRepoMock)
+	svc := NewRecordTypeService(repo)
+
+	wifi := &entity.RecordType{Name: "wifi", Fields: []entity.RecordField{{Name: "ssid", Type: "STRING"}}}
+	repo.On("CreateRecordType", ctx, mock.MatchedBy(func(recordType *entity.RecordType) bool {
+		return recordType.UserID == 7 && recordType.Name == "wifi"
+	})).Return(3, nil)
+	id, err := svc.CreateRecordType(ctx, 7, wifi)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, id)
+
+	repo.On("GetRecordType", ctx, 7, "passport").Return(nil, helper.ErrRecordTypeNotFound)
+	_, err = svc.GetRecordType(ctx, 7, "passport")
+	assert.ErrorIs(t, err, helper.ErrRecordTypeNotFound)
+
+	repo.On("DeleteRecordType", ctx, 7, 3).Return(helper.ErrRecordTypeInUse)
+	assert.ErrorIs(t, svc.DeleteRecordType(ctx, 7, 3), helper.ErrRecordTypeInUse)
+
+	repo.AssertExpectations(t)
+}
@@ -30,5 +30,15 @@ func (u *register) CreateUser(req *pb.RegisterUserRequest) (*entity.User, error)
 		Password: string(passwordHash),
 	}
 
+	if kdf := req.GetKdfParams(); kdf != nil {
+		user.KDFParams = entity.KDFParams{
+			Salt:      kdf.Salt,
+			Time:      kdf.Time,
+			MemoryKiB: kdf.MemoryKib,
+			Threads:   uint8(kdf.Threads),
+			KeyCheck:  kdf.KeyCheck,
+		}
+	}
+
 	return user, nil
 }
@@ -44,6 +44,35 @@ func TestRegister_CreateUser_Success(t *testing.T) {
 	}
 }
 
+func TestRegister_CreateUser_WithKDFParams(t *testing.T) {
+	reg := NewRegister(&mockLogger{})
+
+	req := &pb.RegisterUserRequest{
+		Login:    "testuser",
+		Password: "password123",
+		KdfParams: &pb.KdfParams{
+			Salt:      []byte("0123456789abcdef"),
+			Time:      3,
+			MemoryKib: 65536,
+			Threads:   4,
+			KeyCheck:  []byte("check"),
+		},
+	}
+
+	user, err := reg.CreateUser(req)
+	if err != nil {
+		t.Fatalf("Ожидалось отсутствие ошибки, но получена: %v", err)
+	}
+
+	if !user.KDFParams.Enabled() {
+		t.Fatal("Ожидалось, что параметры KDF будут сохранены у пользователя")
+	}
+
+	if user.Time != 3 || user.MemoryKiB != 65536 || user.Threads != 4 {
+		t.Errorf("Параметры KDF скопированы неверно: %+v", user.KDFParams)
+	}
+}
+
 func TestRegister_CreateUser_HashError(t *testing.T) {
 	mockLogger := &mockLogger{}
 	reg := NewRegister(mockLogger)
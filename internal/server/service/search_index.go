@@ -0,0 +1,65 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+)
+
+const (
+	// SearchIndexPurpose - назначение ключа слепого индекса для Keyring.DeriveKey.
+	SearchIndexPurpose = "goph-keeper search index"
+	// MaxSearchTokens - наибольшее число токенов поиска у записи или в запросе.
+	MaxSearchTokens = 1024
+
+	maxSearchTokenLength = 128
+	blindTokenLength     = 16
+)
+
+// SearchIndex превращает токены поиска от клиента в слепой индекс: HMAC-SHA256 ключом сервера,
+// привязанный к пользователю. В базе не остаётся ни самих слов из Meta, ни токенов клиента,
+// а одинаковые слова разных пользователей дают разные значения индекса.
+type SearchIndex struct {
+	key []byte
+}
+
+// NewSearchIndex - конструктор слепого индекса с ключом key.
+func NewSearchIndex(key []byte) *SearchIndex {
+	return &SearchIndex{key: key}
+}
+
+// Blind возвращает слепые токены пользователя без повторов, отсортированные по значению.
+func (i *SearchIndex) Blind(userID int, tokens []string) ([]string, error) {
+	if len(tokens) > MaxSearchTokens {
+		return nil, fmt.Errorf("%w: больше %d токенов поиска", helper.ErrInvalidSearch, MaxSearchTokens)
+	}
+
+	user := []byte(strconv.Itoa(userID) + ":")
+	seen := make(map[string]struct{}, len(tokens))
+	blinded := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if token == "" || len(token) > maxSearchTokenLength {
+			return nil, fmt.Errorf("%w: длина токена поиска должна быть от 1 до %d байт",
+				helper.ErrInvalidSearch, maxSearchTokenLength)
+		}
+
+		mac := hmac.New(sha256.New, i.key)
+		mac.Write(user)
+		mac.Write([]byte(token))
+		value := hex.EncodeToString(mac.Sum(nil)[:blindTokenLength])
+
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		blinded = append(blinded, value)
+	}
+	sort.Strings(blinded)
+
+	return blinded, nil
+}
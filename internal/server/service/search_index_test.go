@@ -0,0 +1,69 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSearchKey = []byte("search-index-key-0123456789abcde")
+
+func TestSearchIndex_BlindSeparatesUsers(t *testing.T) {
+	index := NewSearchIndex(testSearchKey)
+
+	first, err := index.Blind(1, []string{"почта"})
+	assert.NoError(t, err)
+	again, err := index.Blind(1, []string{"почта"})
+	assert.NoError(t, err)
+	other, err := index.Blind(2, []string{"почта"})
+	assert.NoError(t, err)
+
+	assert.Len(t, first, 1)
+	assert.Len(t, first[0], blindTokenLength*2)
+	assert.NotContains(t, first[0], "почта")
+	assert.Equal(t, first, again)
+	assert.NotEqual(t, first, other)
+
+	otherKey, err := NewSearchIndex([]byte("another-search-key-0123456789abc")).Blind(1, []string{"почта"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, otherKey)
+}
+
+func TestSearchIndex_BlindDeduplicatesAndSorts(t *testing.T) {
+	index := NewSearchIndex(testSearchKey)
+
+	blinded, err := index.Blind(1, []string{"банк", "почта", "банк", "ban", "почта"})
+	assert.NoError(t, err)
+	assert.Len(t, blinded, 3)
+	assert.True(t, sort.StringsAreSorted(blinded))
+
+	empty, err := index.Blind(1, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestSearchIndex_BlindLimits(t *testing.T) {
+	index := NewSearchIndex(testSearchKey)
+
+	tokens := make([]string, MaxSearchTokens)
+	for i := range tokens {
+		tokens[i] = "t"
+	}
+	_, err := index.Blind(1, tokens)
+	assert.NoError(t, err)
+
+	_, err = index.Blind(1, append(tokens, "t"))
+	assert.ErrorIs(t, err, helper.ErrInvalidSearch)
+
+	_, err = index.Blind(1, []string{strings.Repeat("a", maxSearchTokenLength)})
+	assert.NoError(t, err)
+
+	_, err = index.Blind(1, []string{strings.Repeat("a", maxSearchTokenLength+1)})
+	assert.ErrorIs(t, err, helper.ErrInvalidSearch)
+
+	_, err = index.Blind(1, []string{"почта", ""})
+	assert.ErrorIs(t, err, helper.ErrInvalidSearch)
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+)
+
+const (
+	sessionIDLength     = 16
+	refreshSecretLength = 32
+	maxUserAgentLength  = 255
+)
+
+type sessionRepo interface {
+	CreateSession(ctx context.Context, session *entity.Session) error
+	ActiveSession(ctx context.Context, sessionID string) (*entity.Session, error)
+	RotateRefreshToken(ctx context.Context, sessionID, oldHash, newHash string, expiresAt time.Time) (bool, error)
+	RevokeSession(ctx context.Context, userID int, sessionID string) error
+	ListActiveSessions(ctx context.Context, userID int) ([]*entity.Session, error)
+}
+
+type accessTokenIssuer interface {
+	GenerateJWT(user *entity.User, sessionID string) (string, error)
+}
+
+type sessions struct {
+	repo   sessionRepo
+	tokens accessTokenIssuer
+	now    func() time.Time
+}
+
+// NewSessions - конструктор сервиса сессий.
+func NewSessions(repo sessionRepo, tokens accessTokenIssuer) *sessions {
+	return &sessions{repo: repo, tokens: tokens, now: time.Now}
+}
+
+// Start открывает сессию и выдаёт первую пару токенов.
+func (s *sessions) Start(ctx context.Context, user *entity.User, client entity.ClientInfo) (*entity.TokenPair, error) {
+	sessionID, err := randomHex(sessionIDLength)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshHash, err := newRefreshToken(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	err = s.repo.CreateSession(ctx, &entity.Session{
+		ID:               sessionID,
+		UserID:           user.ID,
+		RefreshTokenHash: refreshHash,
+		UserAgent:        strings.ToValidUTF8(userAgent, ""),
+		IP:               client.IP,
+		ExpiresAt:        s.now().Add(RefreshTokenExp),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при создании сессии: %w", err)
+	}
+
+	accessToken, err := s.tokens.GenerateJWT(user, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при генерации токена: %w", err)
+	}
+
+	return &entity.TokenPair{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// Refresh обменивает refresh токен на новую пару токенов. Каждый refresh токен одноразовый:
+// повторное предъявление уже использованного токена означает его утечку, и сессия отзывается.
+func (s *sessions) Refresh(ctx context.Context, refreshToken string) (*entity.TokenPair, error) {
+	sessionID, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || sessionID == "" || secret == "" {
+		return nil, helper.ErrInvalidRefresh
+	}
+
+	session, err := s.repo.ActiveSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, helper.ErrSessionNotFound) {
+			return nil, helper.ErrInvalidRefresh
+		}
+
+		return nil, err
+	}
+
+	presentedHash := hashRefreshSecret(secret)
+	if subtle.ConstantTimeCompare([]byte(presentedHash), []byte(session.RefreshTokenHash)) != 1 {
+		return nil, s.revokeReused(ctx, session)
+	}
+
+	newToken, newHash, err := newRefreshToken(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := s.repo.RotateRefreshToken(ctx, sessionID, presentedHash, newHash, s.now().Add(RefreshTokenExp))
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		return nil, s.revokeReused(ctx, session)
+	}
+
+	accessToken, err := s.tokens.GenerateJWT(&entity.User{ID: session.UserID}, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при генерации токена: %w", err)
+	}
+
+	return &entity.TokenPair{AccessToken: accessToken, RefreshToken: newToken}, nil
+}
+
+// Revoke завершает сессию пользователя.
+func (s *sessions) Revoke(ctx context.Context, userID int, sessionID string) error {
+	return s.repo.RevokeSession(ctx, userID, sessionID)
+}
+
+// List возвращает активные сессии пользователя.
+func (s *sessions) List(ctx context.Context, userID int) ([]*entity.Session, error) {
+	sessions, err := s.repo.ListActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения сессий: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// IsActive сообщает, не отозвана и не истекла ли сессия.
+func (s *sessions) IsActive(ctx context.Context, sessionID string) (bool, error) {
+	_, err := s.repo.ActiveSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, helper.ErrSessionNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *sessions) revokeReused(ctx context.Context, session *entity.Session) error {
+	err := s.repo.RevokeSession(ctx, session.UserID, session.ID)
+	if err != nil && !errors.Is(err, helper.ErrSessionNotFound) {
+		return err
+	}
+
+	return helper.ErrInvalidRefresh
+}
+
+// newRefreshToken возвращает токен вида "<ID сессии>.<секрет>" и хеш секрета для хранения.
+func newRefreshToken(sessionID string) (token, hash string, err error) {
+	secret, err := randomHex(refreshSecretLength)
+	if err != nil {
+		return "", "", err
+	}
+
+	return sessionID + "." + secret, hashRefreshSecret(secret), nil
+}
+
+func hashRefreshSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type SessionRepoMock struct {
+	mock.Mock
+}
+
+func (m *SessionRepoMock) CreateSession(ctx context.Context, session *entity.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *SessionRepoMock) ActiveSession(ctx context.Context, sessionID string) (*entity.Session, error) {
+	args := m.Called(ctx, sessionID)
+	session, _ := args.Get(0).(*entity.Session)
+	return session, args.Error(1)
+}
+
+func (m *SessionRepoMock) RotateRefreshToken(
+	ctx context.Context,
+	sessionID, oldHash, newHash string,
+	expiresAt time.Time,
+) (bool, error) {
+	args := m.Called(ctx, sessionID, oldHash, newHash, expiresAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *SessionRepoMock) RevokeSession(ctx context.Context, userID int, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *SessionRepoMock) ListActiveSessions(ctx context.Context, userID int) ([]*entity.Session, error) {
+	args := m.Called(ctx, userID)
+	sessions, _ := args.Get(0).([]*entity.Session)
+	return sessions, args.Error(1)
+}
+
+type AccessTokenIssuerMock struct {
+	mock.Mock
+}
+
+func (m *AccessTokenIssuerMock) GenerateJWT(user *entity.User, sessionID string) (string, error) {
+	args := m.Called(user, sessionID)
+	return args.String(0), args.Error(1)
+}
+
+func TestSessions_Start(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	user := &entity.User{ID: 7}
+
+	repo := new(SessionRepoMock)
+	tokens := new(AccessTokenIssuerMock)
+	var created *entity.Session
+	repo.On("CreateSession", ctx, mock.AnythingOfType("*entity.Session")).Return(nil).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*entity.Session)
+	})
+	tokens.On("GenerateJWT", user, mock.AnythingOfType("string")).Return("access", nil)
+
+	sessions := NewSessions(repo, tokens)
+	sessions.now = func() time.Time { return now }
+
+	pair, err := sessions.Start(ctx, user, entity.ClientInfo{IP: "10.0.0.1", UserAgent: "cli"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "access", pair.AccessToken)
+
+	sessionID, secret, ok := strings.Cut(pair.RefreshToken, ".")
+	assert.True(t, ok)
+	assert.Equal(t, created.ID, sessionID)
+	assert.Equal(t, hashRefreshSecret(secret), created.RefreshTokenHash)
+	assert.NotContains(t, created.RefreshTokenHash, secret, "в базе хранится только хеш")
+	assert.Equal(t, now.Add(RefreshTokenExp), created.ExpiresAt)
+	assert.Equal(t, "10.0.0.1", created.IP)
+	tokens.AssertCalled(t, "GenerateJWT", user, sessionID)
+}
+
+func TestSessions_Refresh(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := &entity.Session{ID: "sid", UserID: 7, RefreshTokenHash: hashRefreshSecret("secret")}
+
+	t.Run("Успешная ротация", func(t *testing.T) {
+		repo := new(SessionRepoMock)
+		tokens := new(AccessTokenIssuerMock)
+		repo.On("ActiveSession", ctx, "sid").Return(session, nil)
+		repo.On("RotateRefreshToken", ctx, "sid", session.RefreshTokenHash, mock.AnythingOfType("string"),
+			now.Add(RefreshTokenExp)).Return(true, nil)
+		tokens.On("GenerateJWT", &entity.User{ID: 7}, "sid").Return("access", nil)
+
+		sessions := NewSessions(repo, tokens)
+		sessions.now = func() time.Time { return now }
+
+		pair, err := sessions.Refresh(ctx, "sid.secret")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "access", pair.AccessToken)
+		assert.True(t, strings.HasPrefix(pair.RefreshToken, "sid."))
+		assert.NotEqual(t, "sid.secret", pair.RefreshToken)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Повторное использование отзывает сессию", func(t *testing.T) {
+		repo := new(SessionRepoMock)
+		repo.On("ActiveSession", ctx, "sid").Return(session, nil)
+		repo.On("RevokeSession", ctx, 7, "sid").Return(nil)
+
+		_, err := NewSessions(repo, new(AccessTokenIssuerMock)).Refresh(ctx, "sid.stolen")
+
+		assert.ErrorIs(t, err, helper.ErrInvalidRefresh)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Гонка при ротации отзывает сессию", func(t *testing.T) {
+		repo := new(SessionRepoMock)
+		repo.On("ActiveSession", ctx, "sid").Return(session, nil)
+		repo.On("RotateRefreshToken", ctx, "sid", session.RefreshTokenHash, mock.Anything, mock.Anything).
+			Return(false, nil)
+		repo.On("RevokeSession", ctx, 7, "sid").Return(nil)
+
+		_, err := NewSessions(repo, new(AccessTokenIssuerMock)).Refresh(ctx, "sid.secret")
+
+		assert.ErrorIs(t, err, helper.ErrInvalidRefresh)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Сессия отозвана", func(t *testing.T) {
+		repo := new(SessionRepoMock)
+		repo.On("ActiveSession", ctx, "sid").Return(nil, helper.ErrSessionNotFound)
+
+		_, err := NewSessions(repo, new(AccessTokenIssuerMock)).Refresh(ctx, "sid.secret")
+
+		assert.ErrorIs(t, err, helper.ErrInvalidRefresh)
+	})
+
+	t.Run("Неверный формат", func(t *testing.T) {
+		_, err := NewSessions(new(SessionRepoMock), new(AccessTokenIssuerMock)).Refresh(ctx, "garbage")
+
+		assert.ErrorIs(t, err, helper.ErrInvalidRefresh)
+	})
+}
+
+func TestSessions_IsActive(t *testing.T) {
+	ctx := context.Background()
+
+	repo := new(SessionRepoMock)
+	repo.On("ActiveSession", ctx, "active").Return(&entity.Session{ID: "active"}, nil)
+	repo.On("ActiveSession", ctx, "revoked").Return(nil, helper.ErrSessionNotFound)
+	repo.On("ActiveSession", ctx, "broken").Return(nil, helper.ErrInternalServer)
+
+	sessions := NewSessions(repo, new(AccessTokenIssuerMock))
+
+	active, err := sessions.IsActive(ctx, "active")
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	active, err = sessions.IsActive(ctx, "revoked")
+	assert.NoError(t, err)
+	assert.False(t, active)
+
+	_, err = sessions.IsActive(ctx, "broken")
+	assert.Error(t, err)
+}
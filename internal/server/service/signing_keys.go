@@ -0,0 +1,117 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// minRSAKeyBits - минимальный размер RSA ключа подписи.
+const minRSAKeyBits = 2048
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	public any
+}
+
+// SigningKeySet - ключи подписи access токенов: один действующий для подписи и несколько для проверки.
+// Ключи, выведенные из оборота, остаются для проверки, пока не истекут подписанные ими токены.
+type SigningKeySet struct {
+	active   *entity.SigningKey
+	method   jwt.SigningMethod
+	verifier map[string]verificationKey
+	jwks     []*entity.JWK
+}
+
+// NewSigningKeySet собирает набор ключей; activeID должен указывать на ключ с приватной частью.
+func NewSigningKeySet(activeID string, keys []*entity.SigningKey) (*SigningKeySet, error) {
+	set := &SigningKeySet{verifier: make(map[string]verificationKey, len(keys))}
+
+	for _, key := range keys {
+		if _, exists := set.verifier[key.ID]; exists {
+			return nil, fmt.Errorf("ключ подписи %q указан несколько раз", key.ID)
+		}
+
+		method, jwk, err := describeKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("ключ подписи %q: %w", key.ID, err)
+		}
+		set.verifier[key.ID] = verificationKey{method: method, public: key.Public}
+		set.jwks = append(set.jwks, jwk)
+
+		if key.ID == activeID {
+			if key.Private == nil {
+				return nil, fmt.Errorf("у действующего ключа подписи %q нет приватной части", key.ID)
+			}
+			set.active = key
+			set.method = method
+		}
+	}
+
+	if set.active == nil {
+		return nil, fmt.Errorf("действующий ключ подписи %q не найден", activeID)
+	}
+
+	return set, nil
+}
+
+// JWKS возвращает открытые ключи для проверки токенов сторонними сервисами.
+func (s *SigningKeySet) JWKS() []*entity.JWK {
+	return s.jwks
+}
+
+func (s *SigningKeySet) sign(claims entity.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.method, claims)
+	token.Header["kid"] = s.active.ID
+
+	return token.SignedString(s.active.Private)
+}
+
+func (s *SigningKeySet) verificationKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.verifier[kid]
+	if !ok {
+		return nil, fmt.Errorf("неизвестный ключ подписи %q", kid)
+	}
+	// Алгоритм берётся из ключа, а не из заголовка токена, иначе открытый ключ можно выдать за HMAC секрет.
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("алгоритм %s не соответствует ключу %q", token.Method.Alg(), kid)
+	}
+
+	return key.public, nil
+}
+
+func describeKey(key *entity.SigningKey) (jwt.SigningMethod, *entity.JWK, error) {
+	switch public := key.Public.(type) {
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, &entity.JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(public),
+			Kid: key.ID,
+			Alg: jwt.SigningMethodEdDSA.Alg(),
+			Use: "sig",
+		}, nil
+	case *rsa.PublicKey:
+		if public.N.BitLen() < minRSAKeyBits {
+			return nil, nil, fmt.Errorf("RSA ключ короче %d бит", minRSAKeyBits)
+		}
+
+		return jwt.SigningMethodRS256, &entity.JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+			Kid: key.ID,
+			Alg: jwt.SigningMethodRS256.Alg(),
+			Use: "sig",
+		}, nil
+	default:
+		return nil, nil, errors.New("поддерживаются только ключи Ed25519 и RSA")
+	}
+}
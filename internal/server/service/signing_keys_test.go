@@ -0,0 +1,132 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEd25519Key(t *testing.T, id string) *entity.SigningKey {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	return &entity.SigningKey{ID: id, Private: private, Public: public}
+}
+
+func TestSigningKeySet_New(t *testing.T) {
+	ed := newEd25519Key(t, "ed1")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	weakRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+
+	t.Run("Ed25519 и RSA", func(t *testing.T) {
+		set, err := NewSigningKeySet("ed1", []*entity.SigningKey{
+			ed,
+			{ID: "rsa1", Public: &rsaKey.PublicKey},
+		})
+		assert.NoError(t, err)
+
+		jwks := set.JWKS()
+		assert.Len(t, jwks, 2)
+		assert.Equal(t, "OKP", jwks[0].Kty)
+		assert.Equal(t, "EdDSA", jwks[0].Alg)
+		assert.Equal(t, "RSA", jwks[1].Kty)
+		assert.Equal(t, "RS256", jwks[1].Alg)
+		assert.Equal(t, "AQAB", jwks[1].E)
+	})
+
+	t.Run("Действующий ключ без приватной части", func(t *testing.T) {
+		_, err := NewSigningKeySet("rsa1", []*entity.SigningKey{{ID: "rsa1", Public: &rsaKey.PublicKey}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Действующий ключ не найден", func(t *testing.T) {
+		_, err := NewSigningKeySet("missing", []*entity.SigningKey{ed})
+		assert.Error(t, err)
+	})
+
+	t.Run("Короткий RSA ключ", func(t *testing.T) {
+		_, err := NewSigningKeySet("weak", []*entity.SigningKey{
+			{ID: "weak", Private: weakRSA, Public: &weakRSA.PublicKey},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Повтор идентификатора", func(t *testing.T) {
+		_, err := NewSigningKeySet("ed1", []*entity.SigningKey{ed, ed})
+		assert.Error(t, err)
+	})
+}
+
+func TestSigningToken_Rotation(t *testing.T) {
+	oldKey := newEd25519Key(t, "2024-01")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	newKey := &entity.SigningKey{ID: "2024-02", Private: rsaKey, Public: &rsaKey.PublicKey}
+	user := &entity.User{ID: 7}
+
+	oldSet, err := NewSigningKeySet(oldKey.ID, []*entity.SigningKey{oldKey})
+	assert.NoError(t, err)
+	oldToken, err := NewSigningToken(&mockLogger{}, oldSet).GenerateJWT(user, "session")
+	assert.NoError(t, err)
+
+	// После ротации старый ключ остаётся только для проверки.
+	rotated, err := NewSigningKeySet(newKey.ID, []*entity.SigningKey{
+		{ID: oldKey.ID, Public: oldKey.Public},
+		newKey,
+	})
+	assert.NoError(t, err)
+	tokenService := NewSigningToken(&mockLogger{}, rotated)
+
+	claims, err := tokenService.ValidateToken(oldToken)
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, claims.UserID)
+
+	newToken, err := tokenService.GenerateJWT(user, "session")
+	assert.NoError(t, err)
+	parsed, _, err := new(jwt.Parser).ParseUnverified(newToken, &entity.Claims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02", parsed.Header["kid"])
+	assert.Equal(t, "RS256", parsed.Method.Alg())
+
+	_, err = tokenService.ValidateToken(newToken)
+	assert.NoError(t, err)
+
+	// Токены, подписанные удалённым ключом, больше не принимаются.
+	_, err = NewSigningToken(&mockLogger{}, oldSet).ValidateToken(newToken)
+	assert.Error(t, err)
+}
+
+func TestSigningToken_RejectsAlgorithmSubstitution(t *testing.T) {
+	key := newEd25519Key(t, "ed1")
+	set, err := NewSigningKeySet(key.ID, []*entity.SigningKey{key})
+	assert.NoError(t, err)
+
+	// Подпись HMAC открытым ключом как секретом не должна проходить проверку.
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, entity.Claims{SessionID: "s", UserID: 1})
+	forged.Header["kid"] = key.ID
+	forgedString, err := forged.SignedString([]byte(key.Public.(ed25519.PublicKey)))
+	assert.NoError(t, err)
+
+	_, err = NewSigningToken(&mockLogger{}, set).ValidateToken(forgedString)
+	assert.Error(t, err)
+}
+
+func TestToken_ValidateToken_RejectsNonHMAC(t *testing.T) {
+	key := newEd25519Key(t, "ed1")
+	set, err := NewSigningKeySet(key.ID, []*entity.SigningKey{key})
+	assert.NoError(t, err)
+	signed, err := NewSigningToken(&mockLogger{}, set).GenerateJWT(&entity.User{ID: 1}, "session")
+	assert.NoError(t, err)
+
+	_, err = NewToken(&mockLogger{}, "secret").ValidateToken(signed)
+	assert.Error(t, err)
+	assert.Empty(t, NewToken(&mockLogger{}, "secret").JWKS())
+}
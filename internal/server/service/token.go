@@ -1,6 +1,8 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
@@ -11,32 +13,61 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 )
 
-const TokenExp = time.Hour * 5
+const (
+	// AccessTokenExp - время жизни access токена. Отзыв сессии проверяется на каждом запросе,
+	// а короткий срок ограничивает ущерб от утёкшего токена.
+	AccessTokenExp = time.Minute * 15
+	// RefreshTokenExp - сколько сессия живёт без обращений к RefreshToken.
+	RefreshTokenExp = time.Hour * 24 * 30
+
+	tokenIDLength = 16
+)
 
 type token struct {
 	log       logger.CustomLogger
+	keys      *SigningKeySet
 	secretKey string
 }
 
-// NewToken - конструктор создания токен-сервиса.
+// NewToken - конструктор создания токен-сервиса, подписывающего токены HMAC секретом.
 func NewToken(log logger.CustomLogger, secretKey string) *token {
 	return &token{log: log, secretKey: secretKey}
 }
 
-// GenerateJWT - генерирует токен на основе секретного ключа.
-func (t *token) GenerateJWT(user *entity.User) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, entity.Claims{
+// NewSigningToken - конструктор токен-сервиса, подписывающего токены асимметричными ключами (EdDSA, RS256).
+func NewSigningToken(log logger.CustomLogger, keys *SigningKeySet) *token {
+	return &token{log: log, keys: keys}
+}
+
+// GenerateJWT - генерирует access токен сессии на основе ключа подписи.
+func (t *token) GenerateJWT(user *entity.User, sessionID string) (string, error) {
+	tokenID, err := randomHex(tokenIDLength)
+	if err != nil {
+		t.log.LogInfo("ошибка генерации идентификатора токена", err)
+		return "", helper.ErrInternalServer
+	}
+
+	now := time.Now()
+	claims := entity.Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(TokenExp)),
+			ID:        tokenID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenExp)),
 		},
-		UserID: user.ID,
-	})
+		SessionID: sessionID,
+		UserID:    user.ID,
+	}
 
-	if t.secretKey == "" {
-		t.log.LogInfo("для создании подписи токена секретный ключ пустой", fmt.Errorf("пустой secretKey"))
-		return "", helper.ErrInternalServer
+	var tokenString string
+	if t.keys != nil {
+		tokenString, err = t.keys.sign(claims)
+	} else {
+		if t.secretKey == "" {
+			t.log.LogInfo("для создании подписи токена секретный ключ пустой", fmt.Errorf("пустой secretKey"))
+			return "", helper.ErrInternalServer
+		}
+		tokenString, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(t.secretKey))
 	}
-	tokenString, err := token.SignedString([]byte(t.secretKey))
 	if err != nil {
 		t.log.LogInfo("ошибки при создании подписи токена: ", err)
 		return "", helper.ErrInternalServer
@@ -45,22 +76,52 @@ func (t *token) GenerateJWT(user *entity.User) (string, error) {
 	return tokenString, nil
 }
 
-// ValidateToken валидирует токен.
-func (s *token) ValidateToken(tokenString string) (int, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &entity.Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(s.secretKey), nil
-	})
+// ValidateToken валидирует токен и возвращает его клеймы.
+func (s *token) ValidateToken(tokenString string) (*entity.Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &entity.Claims{}, s.verificationKey)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	if claims, ok := token.Claims.(*entity.Claims); ok && token.Valid {
 		if claims.UserID == 0 {
 			s.log.LogInfo("UserID отсутствует в клеймах токена", errors.New("invalid token: missing UserID"))
-			return 0, errors.New("недействительный токен")
+			return nil, errors.New("недействительный токен")
+		}
+		if claims.SessionID == "" {
+			return nil, errors.New("токен выдан вне сессии")
 		}
-		return claims.UserID, nil
+		return claims, nil
+	}
+
+	return nil, errors.New("недействительный токен")
+}
+
+// JWKS возвращает открытые ключи подписи. HMAC секрет не публикуется, поэтому для него список пуст.
+func (s *token) JWKS() []*entity.JWK {
+	if s.keys == nil {
+		return nil
+	}
+
+	return s.keys.JWKS()
+}
+
+func (s *token) verificationKey(token *jwt.Token) (interface{}, error) {
+	if s.keys != nil {
+		return s.keys.verificationKey(token)
+	}
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("неожиданный алгоритм подписи %s", token.Method.Alg())
+	}
+
+	return []byte(s.secretKey), nil
+}
+
+func randomHex(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("ошибка генерации случайных байт: %w", err)
 	}
 
-	return 0, errors.New("недействительный токен")
+	return hex.EncodeToString(b), nil
 }
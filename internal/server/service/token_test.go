@@ -19,7 +19,7 @@ func TestToken_GenerateJWT_Success(t *testing.T) {
 		ID: 1,
 	}
 
-	tokenString, err := tokenService.GenerateJWT(user)
+	tokenString, err := tokenService.GenerateJWT(user, "session")
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, tokenString)
@@ -43,7 +43,7 @@ func TestToken_GenerateJWT_EmptySecretKey(t *testing.T) {
 		ID: 1,
 	}
 
-	tokenString, err := tokenService.GenerateJWT(user)
+	tokenString, err := tokenService.GenerateJWT(user, "session")
 
 	assert.Empty(t, tokenString)
 	assert.ErrorIs(t, err, helper.ErrInternalServer)
@@ -58,13 +58,15 @@ func TestToken_ValidateToken_Success(t *testing.T) {
 		ID: 1,
 	}
 
-	tokenString, err := tokenService.GenerateJWT(user)
+	tokenString, err := tokenService.GenerateJWT(user, "session")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, tokenString)
 
-	userID, err := tokenService.ValidateToken(tokenString)
+	claims, err := tokenService.ValidateToken(tokenString)
 	assert.NoError(t, err)
-	assert.Equal(t, user.ID, userID)
+	assert.Equal(t, user.ID, claims.UserID)
+	assert.Equal(t, "session", claims.SessionID)
+	assert.NotEmpty(t, claims.ID)
 }
 
 func TestToken_ValidateToken_InvalidSignature(t *testing.T) {
@@ -76,16 +78,16 @@ func TestToken_ValidateToken_InvalidSignature(t *testing.T) {
 		ID: 1,
 	}
 
-	tokenString, err := tokenService.GenerateJWT(user)
+	tokenString, err := tokenService.GenerateJWT(user, "session")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, tokenString)
 
 	anotherSecretKey := "anothersecretkey"
 	anotherTokenService := NewToken(mockLogger, anotherSecretKey)
 
-	userID, err := anotherTokenService.ValidateToken(tokenString)
+	claims, err := anotherTokenService.ValidateToken(tokenString)
 	assert.Error(t, err)
-	assert.Equal(t, 0, userID)
+	assert.Nil(t, claims)
 }
 
 func TestToken_ValidateToken_ExpiredToken(t *testing.T) {
@@ -101,17 +103,18 @@ func TestToken_ValidateToken_ExpiredToken(t *testing.T) {
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour)), // истек час назад
 		},
-		UserID: user.ID,
+		SessionID: "session",
+		UserID:    user.ID,
 	})
 
 	tokenString, err := expiredToken.SignedString([]byte(secretKey))
 	assert.NoError(t, err)
 	assert.NotEmpty(t, tokenString)
 
-	userID, err := tokenService.ValidateToken(tokenString)
+	claims, err := tokenService.ValidateToken(tokenString)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "token is expired")
-	assert.Equal(t, 0, userID)
+	assert.Nil(t, claims)
 }
 
 func TestToken_ValidateToken_InvalidClaims(t *testing.T) {
@@ -120,16 +123,16 @@ func TestToken_ValidateToken_InvalidClaims(t *testing.T) {
 	tokenService := NewToken(mockLogger, secretKey)
 
 	invalidClaimsToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(TokenExp)),
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenExp)),
 	})
 
 	tokenString, err := invalidClaimsToken.SignedString([]byte(secretKey))
 	assert.NoError(t, err)
 	assert.NotEmpty(t, tokenString)
 
-	userID, err := tokenService.ValidateToken(tokenString)
+	claims, err := tokenService.ValidateToken(tokenString)
 	assert.Error(t, err)
-	assert.Equal(t, 0, userID)
+	assert.Nil(t, claims)
 }
 
 func TestToken_ValidateToken_EmptyToken(t *testing.T) {
@@ -139,7 +142,27 @@ func TestToken_ValidateToken_EmptyToken(t *testing.T) {
 
 	tokenString := ""
 
-	userID, err := tokenService.ValidateToken(tokenString)
+	claims, err := tokenService.ValidateToken(tokenString)
+	assert.Error(t, err)
+	assert.Nil(t, claims)
+}
+
+func TestToken_ValidateToken_WithoutSession(t *testing.T) {
+	mockLogger := &mockLogger{}
+	secretKey := "supersecretkey"
+	tokenService := NewToken(mockLogger, secretKey)
+
+	legacyToken := jwt.NewWithClaims(jwt.SigningMethodHS256, entity.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenExp)),
+		},
+		UserID: 1,
+	})
+
+	tokenString, err := legacyToken.SignedString([]byte(secretKey))
+	assert.NoError(t, err)
+
+	claims, err := tokenService.ValidateToken(tokenString)
 	assert.Error(t, err)
-	assert.Equal(t, 0, userID)
+	assert.Nil(t, claims)
 }
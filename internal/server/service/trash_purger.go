@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/pkg/logger"
+)
+
+type trashPurger interface {
+	PurgeTrash(ctx context.Context, before time.Time) ([]*entity.UserData, error)
+}
+
+// TrashPurger навсегда удаляет записи, пролежавшие в корзине дольше срока хранения,
+// и сообщает о них подписчикам WatchData.
+type TrashPurger struct {
+	repo   trashPurger
+	bus    eventBus
+	logger logger.CustomLogger
+	now    func() time.Time
+	ttl    time.Duration
+}
+
+// NewTrashPurger - конструктор очистки корзины. Записи удаляются через ttl после переноса в корзину.
+func NewTrashPurger(repo trashPurger, bus eventBus, ttl time.Duration, logger logger.CustomLogger) *TrashPurger {
+	return &TrashPurger{repo: repo, bus: bus, logger: logger, now: time.Now, ttl: ttl}
+}
+
+// Purge выполняет один проход очистки и возвращает число удалённых записей.
+func (p *TrashPurger) Purge(ctx context.Context) (int64, error) {
+	purged, err := p.repo.PurgeTrash(ctx, p.now().Add(-p.ttl))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, data := range purged {
+		event := &entity.DataEvent{Kind: entity.DataDeleted, UserID: data.UserID, ID: data.ID}
+		if err = p.bus.Publish(ctx, event); err != nil {
+			p.logger.LogInfo("не удалось опубликовать событие об изменении данных", err)
+		}
+	}
+
+	return int64(len(purged)), nil
+}
+
+// Run запускает Purge каждые interval, пока не отменён ctx. Ошибки прохода логируются.
+func (p *TrashPurger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Purge(ctx); err != nil {
+			p.logger.LogInfo("Ошибка при очистке корзины", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
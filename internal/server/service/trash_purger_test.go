@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingTrash struct {
+	before time.Time
+}
+
+func (r *recordingTrash) PurgeTrash(_ context.Context, before time.Time) ([]*entity.UserData, error) {
+	r.before = before
+	return []*entity.UserData{{ID: 3, UserID: 1}, {ID: 8, UserID: 2}}, nil
+}
+
+func TestTrashPurger_Purge(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo := &recordingTrash{}
+	bus := &recordingBus{}
+
+	purger := NewTrashPurger(repo, bus, 30*24*time.Hour, new(mockLogger))
+	purger.now = func() time.Time { return now }
+
+	purged, err := purger.Purge(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	assert.Equal(t, now.Add(-30*24*time.Hour), repo.before)
+	assert.Equal(t, []*entity.DataEvent{
+		{Kind: entity.DataDeleted, UserID: 1, ID: 3},
+		{Kind: entity.DataDeleted, UserID: 2, ID: 8},
+	}, bus.published)
+}
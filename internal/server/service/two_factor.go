@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/totp"
+)
+
+const (
+	// ChallengeTTL - сколько ждёт подтверждения вход, прошедший проверку пароля.
+	ChallengeTTL = 5 * time.Minute
+	// MaxChallengeAttempts - сколько кодов можно ввести для одного подтверждения входа.
+	MaxChallengeAttempts = 5
+	// RecoveryCodesCount - число кодов восстановления, выдаваемых при включении второго фактора.
+	RecoveryCodesCount = 10
+
+	challengeIDLength    = 16
+	recoveryCodeBytes    = 5
+	recoveryCodeHalf     = 4
+	totpAllowedClockSkew = 1
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type twoFactorRepo interface {
+	TOTP(ctx context.Context, userID int) (*entity.TOTP, error)
+	SavePendingTOTP(ctx context.Context, userID int, secret string) error
+	EnableTOTP(ctx context.Context, userID int, counter int64, codeHashes []string) error
+	DisableTOTP(ctx context.Context, userID int) error
+	AdvanceCounter(ctx context.Context, userID int, counter int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error)
+	CreateChallenge(ctx context.Context, challenge *entity.LoginChallenge) error
+	RegisterChallengeAttempt(ctx context.Context, challengeID string, maxAttempts int) (*entity.LoginChallenge, error)
+	DeleteChallenge(ctx context.Context, challengeID string) error
+}
+
+type userFinder interface {
+	UserByID(ctx context.Context, id int) (*entity.User, error)
+}
+
+type twoFactor struct {
+	repo      twoFactorRepo
+	users     userFinder
+	encryptor encryptor
+	now       func() time.Time
+	issuer    string
+}
+
+// NewTwoFactor - конструктор сервиса второго фактора (TOTP). Секреты шифруются ключом пользователя.
+func NewTwoFactor(repo twoFactorRepo, users userFinder, encryptor encryptor, issuer string) *twoFactor {
+	return &twoFactor{repo: repo, users: users, encryptor: encryptor, issuer: issuer, now: time.Now}
+}
+
+// Enroll генерирует новый секрет. Второй фактор включится только после подтверждения кодом в Confirm.
+func (s *twoFactor) Enroll(ctx context.Context, userID int) (*entity.TOTPEnrollment, error) {
+	user, err := s.users.UserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := s.encryptor.Encrypt(ctx, user.ID, secret)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка шифрования секрета: %w", err)
+	}
+
+	if err = s.repo.SavePendingTOTP(ctx, user.ID, encrypted); err != nil {
+		return nil, err
+	}
+
+	params := totp.NewParams(secret)
+	params.Issuer = s.issuer
+	params.Account = user.Login
+
+	return &entity.TOTPEnrollment{Secret: secret, URI: params.URI()}, nil
+}
+
+// Confirm включает второй фактор, если код из приложения совпал, и возвращает коды восстановления.
+func (s *twoFactor) Confirm(ctx context.Context, userID int, code string) ([]string, error) {
+	settings, err := s.repo.TOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Enabled {
+		return nil, helper.ErrTOTPAlreadyEnabled
+	}
+
+	counter, err := s.matchCode(ctx, settings, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.EnableTOTP(ctx, userID, counter, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Disable отключает второй фактор после проверки кода.
+func (s *twoFactor) Disable(ctx context.Context, userID int, code string) error {
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+
+	return s.repo.DisableTOTP(ctx, userID)
+}
+
+// Enabled сообщает, включён ли у пользователя второй фактор.
+func (s *twoFactor) Enabled(ctx context.Context, userID int) (bool, error) {
+	settings, err := s.repo.TOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, helper.ErrTOTPNotEnrolled) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return settings.Enabled, nil
+}
+
+// Verify проверяет код из приложения или одноразовый код восстановления.
+// Каждый код принимается один раз: повтор перехваченного кода отклоняется.
+func (s *twoFactor) Verify(ctx context.Context, userID int, code string) error {
+	settings, err := s.repo.TOTP(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !settings.Enabled {
+		return helper.ErrTOTPNotEnrolled
+	}
+
+	if isNumeric(strings.TrimSpace(code)) {
+		counter, err := s.matchCode(ctx, settings, code)
+		if err != nil {
+			return err
+		}
+
+		advanced, err := s.repo.AdvanceCounter(ctx, userID, counter)
+		if err != nil {
+			return err
+		}
+		if !advanced {
+			return helper.ErrInvalidCode
+		}
+
+		return nil
+	}
+
+	used, err := s.repo.UseRecoveryCode(ctx, userID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return helper.ErrInvalidCode
+	}
+
+	return nil
+}
+
+// StartChallenge открывает подтверждение входа для пользователя, прошедшего проверку пароля.
+func (s *twoFactor) StartChallenge(ctx context.Context, userID int) (string, error) {
+	challengeID, err := randomHex(challengeIDLength)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.repo.CreateChallenge(ctx, &entity.LoginChallenge{
+		ID:        challengeID,
+		UserID:    userID,
+		ExpiresAt: s.now().Add(ChallengeTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return challengeID, nil
+}
+
+// ChallengeUser учитывает попытку подтверждения и возвращает ID пользователя.
+func (s *twoFactor) ChallengeUser(ctx context.Context, challengeID string) (int, error) {
+	challenge, err := s.repo.RegisterChallengeAttempt(ctx, challengeID, MaxChallengeAttempts)
+	if err != nil {
+		return 0, err
+	}
+
+	return challenge.UserID, nil
+}
+
+// FinishChallenge удаляет использованное подтверждение входа.
+func (s *twoFactor) FinishChallenge(ctx context.Context, challengeID string) error {
+	return s.repo.DeleteChallenge(ctx, challengeID)
+}
+
+func (s *twoFactor) matchCode(ctx context.Context, settings *entity.TOTP, code string) (int64, error) {
+	secret, err := s.encryptor.Decrypt(ctx, settings.UserID, settings.Secret)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка расшифровки секрета: %w", err)
+	}
+
+	counter, ok := totp.NewParams(secret).Match(code, s.now(), totpAllowedClockSkew)
+	if !ok {
+		return 0, helper.ErrInvalidCode
+	}
+
+	return int64(counter), nil
+}
+
+// newRecoveryCodes возвращает коды вида XXXX-XXXX для пользователя и их хеши для хранения.
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	codes = make([]string, 0, RecoveryCodesCount)
+	hashes = make([]string, 0, RecoveryCodesCount)
+	for range RecoveryCodesCount {
+		raw := make([]byte, recoveryCodeBytes)
+		if _, err = rand.Read(raw); err != nil {
+			return nil, nil, fmt.Errorf("ошибка генерации кода восстановления: %w", err)
+		}
+
+		encoded := recoveryEncoding.EncodeToString(raw)
+		codes = append(codes, encoded[:recoveryCodeHalf]+"-"+encoded[recoveryCodeHalf:])
+		hashes = append(hashes, hashRecoveryCode(encoded))
+	}
+
+	return codes, hashes, nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NikolosHGW/goph-keeper/internal/server/entity"
+	"github.com/NikolosHGW/goph-keeper/internal/server/helper"
+	"github.com/NikolosHGW/goph-keeper/pkg/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryTwoFactorRepo - хранилище второго фактора в памяти с той же семантикой, что и в Postgres.
+type memoryTwoFactorRepo struct {
+	settings   map[int]*entity.TOTP
+	recovery   map[int]map[string]bool
+	challenges map[string]*entity.LoginChallenge
+}
+
+func newMemoryTwoFactorRepo() *memoryTwoFactorRepo {
+	return &memoryTwoFactorRepo{
+		settings:   make(map[int]*entity.TOTP),
+		recovery:   make(map[int]map[string]bool),
+		challenges: make(map[string]*entity.LoginChallenge),
+	}
+}
+
+func (r *memoryTwoFactorRepo) TOTP(_ context.Context, userID int) (*entity.TOTP, error) {
+	settings, ok := r.settings[userID]
+	if !ok {
+		return nil, helper.ErrTOTPNotEnrolled
+	}
+	copied := *settings
+	return &copied, nil
+}
+
+func (r *memoryTwoFactorRepo) SavePendingTOTP(_ context.Context, userID int, secret string) error {
+	if settings, ok := r.settings[userID]; ok && settings.Enabled {
+		return helper.ErrTOTPAlreadyEnabled
+	}
+	r.settings[userID] = &entity.TOTP{UserID: userID, Secret: secret}
+	return nil
+}
+
+func (r *memoryTwoFactorRepo) EnableTOTP(_ context.Context, userID int, counter int64, hashes []string) error {
+	settings, ok := r.settings[userID]
+	if !ok || settings.Enabled {
+		return helper.ErrTOTPAlreadyEnabled
+	}
+	settings.Enabled = true
+	settings.LastCounter = counter
+	r.recovery[userID] = make(map[string]bool)
+	for _, hash := range hashes {
+		r.recovery[userID][hash] = false
+	}
+	return nil
+}
+
+func (r *memoryTwoFactorRepo) DisableTOTP(_ context.Context, userID int) error {
+	delete(r.settings, userID)
+	delete(r.recovery, userID)
+	return nil
+}
+
+func (r *memoryTwoFactorRepo) AdvanceCounter(_ context.Context, userID int, counter int64) (bool, error) {
+	settings := r.settings[userID]
+	if settings.LastCounter >= counter {
+		return false, nil
+	}
+	settings.LastCounter = counter
+	return true, nil
+}
+
+func (r *memoryTwoFactorRepo) UseRecoveryCode(_ context.Context, userID int, hash string) (bool, error) {
+	used, ok := r.recovery[userID][hash]
+	if !ok || used {
+		return false, nil
+	}
+	r.recovery[userID][hash] = true
+	return true, nil
+}
+
+func (r *memoryTwoFactorRepo) CreateChallenge(_ context.Context, challenge *entity.LoginChallenge) error {
+	r.challenges[challenge.ID] = challenge
+	return nil
+}
+
+func (r *memoryTwoFactorRepo) RegisterChallengeAttempt(
+	_ context.Context,
+	challengeID string,
+	maxAttempts int,
+) (*entity.LoginChallenge, error) {
+	challenge, ok := r.challenges[challengeID]
+	if !ok || challenge.Attempts >= maxAttempts {
+		return nil, helper.ErrChallengeNotFound
+	}
+	challenge.Attempts++
+	return challenge, nil
+}
+
+func (r *memoryTwoFactorRepo) DeleteChallenge(_ context.Context, challengeID string) error {
+	delete(r.challenges, challengeID)
+	return nil
+}
+
+type staticUsers map[int]*entity.User
+
+func (u staticUsers) UserByID(_ context.Context, id int) (*entity.User, error) {
+	user, ok := u[id]
+	if !ok {
+		return nil, helper.ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+func newTestTwoFactor(now time.Time) (*twoFactor, *memoryTwoFactorRepo) {
+	repo := newMemoryTwoFactorRepo()
+	users := staticUsers{7: {ID: 7, Login: "alice"}}
+	service := NewTwoFactor(repo, users, staticEncryptor{NewEncryptionService(testMasterKeyV1)}, "GophKeeper")
+	service.now = func() time.Time { return now }
+	return service, repo
+}
+
+func TestTwoFactor_EnrollAndConfirm(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service, repo := newTestTwoFactor(now)
+	user := &entity.User{ID: 7}
+
+	enrollment, err := service.Enroll(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/GophKeeper:alice?"))
+	assert.NotContains(t, repo.settings[7].Secret, enrollment.Secret, "секрет должен храниться зашифрованным")
+
+	enabled, err := service.Enabled(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.False(t, enabled, "до подтверждения второй фактор не включён")
+
+	_, err = service.Confirm(ctx, user.ID, "000000")
+	assert.ErrorIs(t, err, helper.ErrInvalidCode)
+
+	code, err := totp.NewParams(enrollment.Secret).Code(now)
+	assert.NoError(t, err)
+	recoveryCodes, err := service.Confirm(ctx, user.ID, code)
+	assert.NoError(t, err)
+	assert.Len(t, recoveryCodes, RecoveryCodesCount)
+
+	enabled, err = service.Enabled(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+
+	_, err = service.Enroll(ctx, user.ID)
+	assert.ErrorIs(t, err, helper.ErrTOTPAlreadyEnabled)
+}
+
+func TestTwoFactor_Verify(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service, _ := newTestTwoFactor(now)
+	user := &entity.User{ID: 7}
+
+	enrollment, err := service.Enroll(ctx, user.ID)
+	assert.NoError(t, err)
+	params := totp.NewParams(enrollment.Secret)
+	code, _ := params.Code(now)
+	recoveryCodes, err := service.Confirm(ctx, user.ID, code)
+	assert.NoError(t, err)
+
+	// Код подтверждения уже использован и повторно не принимается.
+	assert.ErrorIs(t, service.Verify(ctx, user.ID, code), helper.ErrInvalidCode)
+
+	service.now = func() time.Time { return now.Add(30 * time.Second) }
+	nextCode, _ := params.Code(now.Add(30 * time.Second))
+	assert.NoError(t, service.Verify(ctx, user.ID, nextCode))
+
+	assert.NoError(t, service.Verify(ctx, user.ID, strings.ToLower(recoveryCodes[0])))
+	assert.ErrorIs(t, service.Verify(ctx, user.ID, recoveryCodes[0]), helper.ErrInvalidCode)
+	assert.ErrorIs(t, service.Verify(ctx, user.ID, "AAAA-AAAA"), helper.ErrInvalidCode)
+
+	assert.NoError(t, service.Disable(ctx, user.ID, recoveryCodes[1]))
+	enabled, err := service.Enabled(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+	assert.ErrorIs(t, service.Verify(ctx, user.ID, recoveryCodes[2]), helper.ErrTOTPNotEnrolled)
+}
+
+func TestTwoFactor_Challenge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service, repo := newTestTwoFactor(now)
+
+	challengeID, err := service.StartChallenge(ctx, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(ChallengeTTL), repo.challenges[challengeID].ExpiresAt)
+
+	for range MaxChallengeAttempts {
+		userID, err := service.ChallengeUser(ctx, challengeID)
+		assert.NoError(t, err)
+		assert.Equal(t, 7, userID)
+	}
+	_, err = service.ChallengeUser(ctx, challengeID)
+	assert.ErrorIs(t, err, helper.ErrChallengeNotFound)
+
+	assert.NoError(t, service.FinishChallenge(ctx, challengeID))
+	assert.Empty(t, repo.challenges)
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	pb "github.com/NikolosHGW/goph-keeper/api/authpb"
@@ -11,33 +12,152 @@ import (
 
 type authRepo interface {
 	User(context.Context, string) (*entity.User, error)
+	UserByID(context.Context, int) (*entity.User, error)
 	userRepo
 }
 
+type passwordVerifier interface {
+	Verify(*entity.User, string) error
+}
+
+type loginGuard interface {
+	Check(ctx context.Context, login, ip string) error
+	RegisterFailure(ctx context.Context, login, ip string) error
+	Reset(ctx context.Context, login string) error
+}
+
+type secondFactor interface {
+	Enabled(ctx context.Context, userID int) (bool, error)
+	Verify(ctx context.Context, userID int, code string) error
+	StartChallenge(ctx context.Context, userID int) (string, error)
+	ChallengeUser(ctx context.Context, challengeID string) (int, error)
+	FinishChallenge(ctx context.Context, challengeID string) error
+}
+
 type auth struct {
-	tokenService tokenServicer
-	authRepo     authRepo
+	sessionService   sessionStarter
+	authRepo         authRepo
+	passwordVerifier passwordVerifier
+	loginGuard       loginGuard
+	twoFactor        secondFactor
 }
 
-// NewAuth - конструктор юзкейса регистрации пользователя.
-func NewAuth(tokenService tokenServicer, authRepo authRepo) *auth {
+// NewAuth - конструктор юзкейса авторизации пользователя.
+func NewAuth(
+	sessionService sessionStarter,
+	authRepo authRepo,
+	passwordVerifier passwordVerifier,
+	loginGuard loginGuard,
+	twoFactor secondFactor,
+) *auth {
 	return &auth{
-		authRepo:     authRepo,
-		tokenService: tokenService,
+		authRepo:         authRepo,
+		sessionService:   sessionService,
+		passwordVerifier: passwordVerifier,
+		loginGuard:       loginGuard,
+		twoFactor:        twoFactor,
 	}
 }
 
 // Handle - авторизация пользователя.
-func (r *auth) Handle(ctx context.Context, req *pb.LoginUserRequest) (string, error) {
+func (r *auth) Handle(
+	ctx context.Context,
+	req *pb.LoginUserRequest,
+	client entity.ClientInfo,
+) (*entity.AuthResult, error) {
+	if err := r.loginGuard.Check(ctx, req.Login, client.IP); err != nil {
+		return nil, err
+	}
+
 	user, err := r.authRepo.User(ctx, req.Login)
+	if err != nil && !errors.Is(err, helper.ErrInvalidCredentials) {
+		return nil, helper.ErrInternalServer
+	}
+
+	if err := r.passwordVerifier.Verify(user, req.Password); err != nil {
+		if !errors.Is(err, helper.ErrInvalidCredentials) {
+			return nil, err
+		}
+		if err := r.loginGuard.RegisterFailure(ctx, req.Login, client.IP); err != nil {
+			return nil, err
+		}
+
+		return nil, helper.ErrInvalidCredentials
+	}
+
+	enabled, err := r.twoFactor.Enabled(ctx, user.ID)
 	if err != nil {
-		return "", helper.ErrInternalServer
+		return nil, err
+	}
+	if enabled {
+		// Счётчик неудачных попыток не сбрасывается, пока не подтверждён второй фактор,
+		// иначе знание пароля позволило бы перебирать коды без блокировки.
+		challengeID, err := r.twoFactor.StartChallenge(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка при создании подтверждения входа: %w", err)
+		}
+
+		return &entity.AuthResult{ChallengeID: challengeID}, nil
+	}
+
+	return r.completeLogin(ctx, user, client)
+}
+
+// HandleCode - второй шаг входа: проверка кода второго фактора.
+func (r *auth) HandleCode(
+	ctx context.Context,
+	req *pb.VerifyLoginCodeRequest,
+	client entity.ClientInfo,
+) (*entity.AuthResult, error) {
+	userID, err := r.twoFactor.ChallengeUser(ctx, req.ChallengeId)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.authRepo.UserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, helper.ErrInvalidCredentials) {
+			return nil, helper.ErrChallengeNotFound
+		}
+
+		return nil, helper.ErrInternalServer
+	}
+
+	if err := r.loginGuard.Check(ctx, user.Login, client.IP); err != nil {
+		return nil, err
+	}
+
+	if err := r.twoFactor.Verify(ctx, user.ID, req.Code); err != nil {
+		if !errors.Is(err, helper.ErrInvalidCode) {
+			return nil, err
+		}
+		if err := r.loginGuard.RegisterFailure(ctx, user.Login, client.IP); err != nil {
+			return nil, err
+		}
+
+		return nil, helper.ErrInvalidCode
+	}
+
+	if err := r.twoFactor.FinishChallenge(ctx, req.ChallengeId); err != nil {
+		return nil, err
+	}
+
+	return r.completeLogin(ctx, user, client)
+}
+
+func (r *auth) completeLogin(
+	ctx context.Context,
+	user *entity.User,
+	client entity.ClientInfo,
+) (*entity.AuthResult, error) {
+	if err := r.loginGuard.Reset(ctx, user.Login); err != nil {
+		return nil, err
 	}
 
-	token, err := r.tokenService.GenerateJWT(user)
+	tokens, err := r.sessionService.Start(ctx, user, client)
 	if err != nil {
-		return "", fmt.Errorf("ошибка при генерации токена: %w", err)
+		return nil, fmt.Errorf("ошибка при создании сессии: %w", err)
 	}
 
-	return token, nil
+	return &entity.AuthResult{TokenPair: *tokens, KDFParams: user.KDFParams}, nil
 }
@@ -18,99 +18,309 @@ func (m *UserRepoMock) User(ctx context.Context, login string) (*entity.User, er
 	return user, args.Error(1)
 }
 
-func TestAuth_Handle(t *testing.T) {
-	mockRepo := new(UserRepoMock)
-	mockTokenService := new(TokenServicerMock)
+func (m *UserRepoMock) UserByID(ctx context.Context, id int) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	user, _ := args.Get(0).(*entity.User)
+	return user, args.Error(1)
+}
+
+type SecondFactorMock struct {
+	mock.Mock
+}
+
+func (m *SecondFactorMock) Enabled(ctx context.Context, userID int) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *SecondFactorMock) Verify(ctx context.Context, userID int, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+func (m *SecondFactorMock) StartChallenge(ctx context.Context, userID int) (string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *SecondFactorMock) ChallengeUser(ctx context.Context, challengeID string) (int, error) {
+	args := m.Called(ctx, challengeID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *SecondFactorMock) FinishChallenge(ctx context.Context, challengeID string) error {
+	args := m.Called(ctx, challengeID)
+	return args.Error(0)
+}
+
+type PasswordVerifierMock struct {
+	mock.Mock
+}
+
+func (m *PasswordVerifierMock) Verify(user *entity.User, password string) error {
+	args := m.Called(user, password)
+	return args.Error(0)
+}
+
+type LoginGuardMock struct {
+	mock.Mock
+}
+
+func (m *LoginGuardMock) Check(ctx context.Context, login, ip string) error {
+	args := m.Called(ctx, login, ip)
+	return args.Error(0)
+}
 
-	authUseCase := NewAuth(mockTokenService, mockRepo)
+func (m *LoginGuardMock) RegisterFailure(ctx context.Context, login, ip string) error {
+	args := m.Called(ctx, login, ip)
+	return args.Error(0)
+}
 
+func (m *LoginGuardMock) Reset(ctx context.Context, login string) error {
+	args := m.Called(ctx, login)
+	return args.Error(0)
+}
+
+func TestAuth_Handle(t *testing.T) {
 	ctx := context.Background()
-	req := &pb.LoginUserRequest{Login: "testuser"}
+	req := &pb.LoginUserRequest{Login: "testuser", Password: "password123"}
+	client := entity.ClientInfo{IP: "10.0.0.1", UserAgent: "grpc-go"}
+	clientIP := client.IP
+
+	user := &entity.User{
+		ID:       123,
+		Login:    "testuser",
+		Password: "hashedpassword",
+	}
 
 	type testCase struct {
-		name             string
-		setupMocks       func()
-		expectedToken    string
-		expectedError    error
-		assertAdditional func()
+		name           string
+		setupMocks     func(*UserRepoMock, *SessionStarterMock, *PasswordVerifierMock, *LoginGuardMock, *SecondFactorMock)
+		expectedResult *entity.AuthResult
+		expectedError  error
 	}
 
 	tests := []testCase{
 		{
 			name: "успешная авторизация",
-			setupMocks: func() {
-				user := &entity.User{
-					ID:       123,
-					Login:    "testuser",
-					Password: "hashedpassword",
-				}
-				token := "jwt.token.string"
-
-				mockRepo.On("User", ctx, req.Login).Return(user, nil)
-				mockTokenService.On("GenerateJWT", user).Return(token, nil)
+			setupMocks: func(
+				repo *UserRepoMock, sessions *SessionStarterMock, verifier *PasswordVerifierMock,
+				guard *LoginGuardMock, tf *SecondFactorMock,
+			) {
+				guard.On("Check", ctx, req.Login, clientIP).Return(nil)
+				repo.On("User", ctx, req.Login).Return(user, nil)
+				verifier.On("Verify", user, req.Password).Return(nil)
+				tf.On("Enabled", ctx, user.ID).Return(false, nil)
+				guard.On("Reset", ctx, req.Login).Return(nil)
+				sessions.On("Start", ctx, user, client).
+					Return(&entity.TokenPair{AccessToken: "jwt.token.string", RefreshToken: "refresh"}, nil)
+			},
+			expectedResult: &entity.AuthResult{
+				TokenPair: entity.TokenPair{AccessToken: "jwt.token.string", RefreshToken: "refresh"},
 			},
-			expectedToken: "jwt.token.string",
 			expectedError: nil,
-			assertAdditional: func() {
-				mockRepo.AssertExpectations(t)
-				mockTokenService.AssertExpectations(t)
+		},
+		{
+			name: "вход заблокирован",
+			setupMocks: func(
+				repo *UserRepoMock, sessions *SessionStarterMock, verifier *PasswordVerifierMock,
+				guard *LoginGuardMock, tf *SecondFactorMock,
+			) {
+				guard.On("Check", ctx, req.Login, clientIP).Return(helper.ErrTooManyAttempts)
 			},
+			expectedError: helper.ErrTooManyAttempts,
+		},
+		{
+			name: "неверный пароль",
+			setupMocks: func(
+				repo *UserRepoMock, sessions *SessionStarterMock, verifier *PasswordVerifierMock,
+				guard *LoginGuardMock, tf *SecondFactorMock,
+			) {
+				guard.On("Check", ctx, req.Login, clientIP).Return(nil)
+				repo.On("User", ctx, req.Login).Return(user, nil)
+				verifier.On("Verify", user, req.Password).Return(helper.ErrInvalidCredentials)
+				guard.On("RegisterFailure", ctx, req.Login, clientIP).Return(nil)
+			},
+			expectedError: helper.ErrInvalidCredentials,
 		},
 		{
 			name: "пользователь не найден",
-			setupMocks: func() {
-				mockRepo.On("User", ctx, req.Login).Return(nil, helper.ErrInternalServer)
+			setupMocks: func(
+				repo *UserRepoMock, sessions *SessionStarterMock, verifier *PasswordVerifierMock,
+				guard *LoginGuardMock, tf *SecondFactorMock,
+			) {
+				guard.On("Check", ctx, req.Login, clientIP).Return(nil)
+				repo.On("User", ctx, req.Login).Return(nil, helper.ErrInvalidCredentials)
+				verifier.On("Verify", (*entity.User)(nil), req.Password).Return(helper.ErrInvalidCredentials)
+				guard.On("RegisterFailure", ctx, req.Login, clientIP).Return(nil)
+			},
+			expectedError: helper.ErrInvalidCredentials,
+		},
+		{
+			name: "ошибка при поиске пользователя",
+			setupMocks: func(
+				repo *UserRepoMock, sessions *SessionStarterMock, verifier *PasswordVerifierMock,
+				guard *LoginGuardMock, tf *SecondFactorMock,
+			) {
+				guard.On("Check", ctx, req.Login, clientIP).Return(nil)
+				repo.On("User", ctx, req.Login).Return(nil, errors.New("ошибка при поиске пользователя"))
 			},
-			expectedToken: "",
 			expectedError: helper.ErrInternalServer,
-			assertAdditional: func() {
-				mockRepo.AssertExpectations(t)
-				mockTokenService.AssertNotCalled(t, "GenerateJWT", mock.Anything)
+		},
+		{
+			name: "ошибка при учёте неудачной попытки",
+			setupMocks: func(
+				repo *UserRepoMock, sessions *SessionStarterMock, verifier *PasswordVerifierMock,
+				guard *LoginGuardMock, tf *SecondFactorMock,
+			) {
+				guard.On("Check", ctx, req.Login, clientIP).Return(nil)
+				repo.On("User", ctx, req.Login).Return(user, nil)
+				verifier.On("Verify", user, req.Password).Return(helper.ErrInvalidCredentials)
+				guard.On("RegisterFailure", ctx, req.Login, clientIP).Return(helper.ErrInternalServer)
 			},
+			expectedError: helper.ErrInternalServer,
 		},
 		{
-			name: "ошибка при генерации токена",
-			setupMocks: func() {
-				user := &entity.User{
-					ID:       123,
-					Login:    "testuser",
-					Password: "hashedpassword",
-				}
-				mockRepo.On("User", ctx, req.Login).Return(user, nil)
-				mockTokenService.On("GenerateJWT", user).Return("", errors.New("генерация токена не удалась"))
+			name: "ошибка при создании сессии",
+			setupMocks: func(
+				repo *UserRepoMock, sessions *SessionStarterMock, verifier *PasswordVerifierMock,
+				guard *LoginGuardMock, tf *SecondFactorMock,
+			) {
+				guard.On("Check", ctx, req.Login, clientIP).Return(nil)
+				repo.On("User", ctx, req.Login).Return(user, nil)
+				verifier.On("Verify", user, req.Password).Return(nil)
+				tf.On("Enabled", ctx, user.ID).Return(false, nil)
+				guard.On("Reset", ctx, req.Login).Return(nil)
+				sessions.On("Start", ctx, user, client).Return(nil, errors.New("генерация токена не удалась"))
 			},
-			expectedToken: "",
-			expectedError: errors.New("ошибка при генерации токена: генерация токена не удалась"),
-			assertAdditional: func() {
-				mockRepo.AssertExpectations(t)
-				mockTokenService.AssertExpectations(t)
+			expectedError: errors.New("ошибка при создании сессии: генерация токена не удалась"),
+		},
+		{
+			name: "требуется второй фактор",
+			setupMocks: func(
+				repo *UserRepoMock, sessions *SessionStarterMock, verifier *PasswordVerifierMock,
+				guard *LoginGuardMock, tf *SecondFactorMock,
+			) {
+				guard.On("Check", ctx, req.Login, clientIP).Return(nil)
+				repo.On("User", ctx, req.Login).Return(user, nil)
+				verifier.On("Verify", user, req.Password).Return(nil)
+				tf.On("Enabled", ctx, user.ID).Return(true, nil)
+				tf.On("StartChallenge", ctx, user.ID).Return("challenge", nil)
 			},
+			expectedResult: &entity.AuthResult{ChallengeID: "challenge"},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tc.setupMocks()
+			mockRepo := new(UserRepoMock)
+			mockSessionService := new(SessionStarterMock)
+			mockVerifier := new(PasswordVerifierMock)
+			mockGuard := new(LoginGuardMock)
+			mockTwoFactor := new(SecondFactorMock)
+			tc.setupMocks(mockRepo, mockSessionService, mockVerifier, mockGuard, mockTwoFactor)
+
+			authUseCase := NewAuth(mockSessionService, mockRepo, mockVerifier, mockGuard, mockTwoFactor)
 
-			result, err := authUseCase.Handle(ctx, req)
+			result, err := authUseCase.Handle(ctx, req, client)
 
 			if tc.expectedError != nil {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tc.expectedError.Error())
-				assert.Equal(t, tc.expectedToken, result)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tc.expectedToken, result)
 			}
+			assert.Equal(t, tc.expectedResult, result)
 
-			if tc.assertAdditional != nil {
-				tc.assertAdditional()
+			mockRepo.AssertExpectations(t)
+			mockSessionService.AssertExpectations(t)
+			mockVerifier.AssertExpectations(t)
+			mockGuard.AssertExpectations(t)
+			mockTwoFactor.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuth_HandleCode(t *testing.T) {
+	ctx := context.Background()
+	client := entity.ClientInfo{IP: "10.0.0.1", UserAgent: "grpc-go"}
+	req := &pb.VerifyLoginCodeRequest{ChallengeId: "challenge", Code: "123456"}
+	user := &entity.User{ID: 123, Login: "testuser"}
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*UserRepoMock, *SessionStarterMock, *LoginGuardMock, *SecondFactorMock)
+		expectedResult *entity.AuthResult
+		expectedError  error
+	}{
+		{
+			name: "верный код",
+			setupMocks: func(repo *UserRepoMock, sessions *SessionStarterMock, guard *LoginGuardMock, tf *SecondFactorMock) {
+				tf.On("ChallengeUser", ctx, "challenge").Return(user.ID, nil)
+				repo.On("UserByID", ctx, user.ID).Return(user, nil)
+				guard.On("Check", ctx, user.Login, client.IP).Return(nil)
+				tf.On("Verify", ctx, user.ID, "123456").Return(nil)
+				tf.On("FinishChallenge", ctx, "challenge").Return(nil)
+				guard.On("Reset", ctx, user.Login).Return(nil)
+				sessions.On("Start", ctx, user, client).
+					Return(&entity.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil)
+			},
+			expectedResult: &entity.AuthResult{
+				TokenPair: entity.TokenPair{AccessToken: "access", RefreshToken: "refresh"},
+			},
+		},
+		{
+			name: "неверный код учитывается как неудачная попытка",
+			setupMocks: func(repo *UserRepoMock, sessions *SessionStarterMock, guard *LoginGuardMock, tf *SecondFactorMock) {
+				tf.On("ChallengeUser", ctx, "challenge").Return(user.ID, nil)
+				repo.On("UserByID", ctx, user.ID).Return(user, nil)
+				guard.On("Check", ctx, user.Login, client.IP).Return(nil)
+				tf.On("Verify", ctx, user.ID, "123456").Return(helper.ErrInvalidCode)
+				guard.On("RegisterFailure", ctx, user.Login, client.IP).Return(nil)
+			},
+			expectedError: helper.ErrInvalidCode,
+		},
+		{
+			name: "подтверждение истекло",
+			setupMocks: func(repo *UserRepoMock, sessions *SessionStarterMock, guard *LoginGuardMock, tf *SecondFactorMock) {
+				tf.On("ChallengeUser", ctx, "challenge").Return(0, helper.ErrChallengeNotFound)
+			},
+			expectedError: helper.ErrChallengeNotFound,
+		},
+		{
+			name: "вход заблокирован",
+			setupMocks: func(repo *UserRepoMock, sessions *SessionStarterMock, guard *LoginGuardMock, tf *SecondFactorMock) {
+				tf.On("ChallengeUser", ctx, "challenge").Return(user.ID, nil)
+				repo.On("UserByID", ctx, user.ID).Return(user, nil)
+				guard.On("Check", ctx, user.Login, client.IP).Return(helper.ErrTooManyAttempts)
+			},
+			expectedError: helper.ErrTooManyAttempts,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(UserRepoMock)
+			mockSessionService := new(SessionStarterMock)
+			mockGuard := new(LoginGuardMock)
+			mockTwoFactor := new(SecondFactorMock)
+			tc.setupMocks(mockRepo, mockSessionService, mockGuard, mockTwoFactor)
+
+			authUseCase := NewAuth(mockSessionService, mockRepo, nil, mockGuard, mockTwoFactor)
+
+			result, err := authUseCase.HandleCode(ctx, req, client)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
 			}
+			assert.Equal(t, tc.expectedResult, result)
 
-			mockRepo.ExpectedCalls = nil
-			mockRepo.Calls = nil
-			mockTokenService.ExpectedCalls = nil
-			mockTokenService.Calls = nil
+			mockRepo.AssertExpectations(t)
+			mockSessionService.AssertExpectations(t)
+			mockGuard.AssertExpectations(t)
+			mockTwoFactor.AssertExpectations(t)
 		})
 	}
 }
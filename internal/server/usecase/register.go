@@ -18,48 +18,52 @@ type registerServicer interface {
 	CreateUser(*pb.RegisterUserRequest) (*entity.User, error)
 }
 
-type tokenServicer interface {
-	GenerateJWT(*entity.User) (string, error)
+type sessionStarter interface {
+	Start(ctx context.Context, user *entity.User, client entity.ClientInfo) (*entity.TokenPair, error)
 }
 
 type register struct {
 	registerService registerServicer
-	tokenService    tokenServicer
+	sessionService  sessionStarter
 	userRepo        userRepo
 }
 
 // NewRegister - конструктор юзкейса регистрации пользователя.
-func NewRegister(registerService registerServicer, tokenService tokenServicer, userRepo userRepo) *register {
+func NewRegister(registerService registerServicer, sessionService sessionStarter, userRepo userRepo) *register {
 	return &register{
 		registerService: registerService,
 		userRepo:        userRepo,
-		tokenService:    tokenService,
+		sessionService:  sessionService,
 	}
 }
 
 // Handle - регистрация пользователя.
-func (r *register) Handle(ctx context.Context, req *pb.RegisterUserRequest) (string, error) {
+func (r *register) Handle(
+	ctx context.Context,
+	req *pb.RegisterUserRequest,
+	client entity.ClientInfo,
+) (*entity.TokenPair, error) {
 	isLoginExist, err := r.userRepo.ExistsByLogin(ctx, req.Login)
 	if err != nil {
-		return "", helper.ErrInternalServer
+		return nil, helper.ErrInternalServer
 	}
 	if isLoginExist {
-		return "", helper.ErrLoginAlreadyExists
+		return nil, helper.ErrLoginAlreadyExists
 	}
 
 	user, err := r.registerService.CreateUser(req)
 	if err != nil {
-		return "", fmt.Errorf("ошибка создания пользователя: %w", err)
+		return nil, fmt.Errorf("ошибка создания пользователя: %w", err)
 	}
 
 	if err := r.userRepo.Save(ctx, user); err != nil {
-		return "", fmt.Errorf("ошибка при сохранении пользователя: %w", err)
+		return nil, fmt.Errorf("ошибка при сохранении пользователя: %w", err)
 	}
 
-	token, err := r.tokenService.GenerateJWT(user)
+	tokens, err := r.sessionService.Start(ctx, user, client)
 	if err != nil {
-		return "", fmt.Errorf("ошибка при генерации токена: %w", err)
+		return nil, fmt.Errorf("ошибка при создании сессии: %w", err)
 	}
 
-	return token, nil
+	return tokens, nil
 }
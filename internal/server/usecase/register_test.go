@@ -35,33 +35,40 @@ func (m *RegisterServicerMock) CreateUser(req *pb.RegisterUserRequest) (*entity.
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
-type TokenServicerMock struct {
+type SessionStarterMock struct {
 	mock.Mock
 }
 
-func (m *TokenServicerMock) GenerateJWT(user *entity.User) (string, error) {
-	args := m.Called(user)
-	return args.String(0), args.Error(1)
+func (m *SessionStarterMock) Start(
+	ctx context.Context,
+	user *entity.User,
+	client entity.ClientInfo,
+) (*entity.TokenPair, error) {
+	args := m.Called(ctx, user, client)
+	tokens, _ := args.Get(0).(*entity.TokenPair)
+	return tokens, args.Error(1)
 }
 
 func TestRegister_Handle(t *testing.T) {
 	ctx := context.Background()
+	client := entity.ClientInfo{IP: "10.0.0.1", UserAgent: "grpc-go"}
 
 	tests := []struct {
 		name          string
-		setupMocks    func(*UserRepoMock, *RegisterServicerMock, *TokenServicerMock)
+		setupMocks    func(*UserRepoMock, *RegisterServicerMock, *SessionStarterMock)
 		req           *pb.RegisterUserRequest
 		expectedToken string
 		expectedError error
 	}{
 		{
 			name: "Successful registration",
-			setupMocks: func(userRepo *UserRepoMock, registerService *RegisterServicerMock, tokenService *TokenServicerMock) {
+			setupMocks: func(userRepo *UserRepoMock, registerService *RegisterServicerMock, sessionService *SessionStarterMock) {
 				userRepo.On("ExistsByLogin", ctx, "newuser").Return(false, nil)
 				user := &entity.User{Login: "newuser", Password: "hashedpassword"}
 				registerService.On("CreateUser", mock.Anything).Return(user, nil)
 				userRepo.On("Save", ctx, user).Return(nil)
-				tokenService.On("GenerateJWT", user).Return("token123", nil)
+				sessionService.On("Start", ctx, user, client).
+					Return(&entity.TokenPair{AccessToken: "token123", RefreshToken: "refresh123"}, nil)
 			},
 			req: &pb.RegisterUserRequest{
 				Login:    "newuser",
@@ -72,7 +79,7 @@ func TestRegister_Handle(t *testing.T) {
 		},
 		{
 			name: "Login already exists",
-			setupMocks: func(userRepo *UserRepoMock, registerService *RegisterServicerMock, tokenService *TokenServicerMock) {
+			setupMocks: func(userRepo *UserRepoMock, registerService *RegisterServicerMock, sessionService *SessionStarterMock) {
 				userRepo.On("ExistsByLogin", ctx, "existinguser").Return(true, nil)
 			},
 			req: &pb.RegisterUserRequest{
@@ -84,7 +91,7 @@ func TestRegister_Handle(t *testing.T) {
 		},
 		{
 			name: "Error checking login existence",
-			setupMocks: func(userRepo *UserRepoMock, registerService *RegisterServicerMock, tokenService *TokenServicerMock) {
+			setupMocks: func(userRepo *UserRepoMock, registerService *RegisterServicerMock, sessionService *SessionStarterMock) {
 				userRepo.On("ExistsByLogin", ctx, "newuser").Return(false, errors.New("database error"))
 			},
 			req: &pb.RegisterUserRequest{
@@ -96,7 +103,7 @@ func TestRegister_Handle(t *testing.T) {
 		},
 		{
 			name: "Error creating user",
-			setupMocks: func(userRepo *UserRepoMock, registerService *RegisterServicerMock, tokenService *TokenServicerMock) {
+			setupMocks: func(userRepo *UserRepoMock, registerService *RegisterServicerMock, sessionService *SessionStarterMock) {
 				userRepo.On("ExistsByLogin", ctx, "newuser").Return(false, nil)
 				registerService.On("CreateUser", mock.Anything).Return((*entity.User)(nil), errors.New("creation error"))
 			},
@@ -109,7 +116,7 @@ func TestRegister_Handle(t *testing.T) {
 		},
 		{
 			name: "Error saving user",
-			setupMocks: func(userRepo *UserRepoMock, registerService *RegisterServicerMock, tokenService *TokenServicerMock) {
+			setupMocks: func(userRepo *UserRepoMock, registerService *RegisterServicerMock, sessionService *SessionStarterMock) {
 				userRepo.On("ExistsByLogin", ctx, "newuser").Return(false, nil)
 				user := &entity.User{Login: "newuser", Password: "hashedpassword"}
 				registerService.On("CreateUser", mock.Anything).Return(user, nil)
@@ -123,20 +130,20 @@ func TestRegister_Handle(t *testing.T) {
 			expectedError: errors.New("ошибка при сохранении пользователя: save error"),
 		},
 		{
-			name: "Error generating token",
-			setupMocks: func(userRepo *UserRepoMock, registerService *RegisterServicerMock, tokenService *TokenServicerMock) {
+			name: "Error starting session",
+			setupMocks: func(userRepo *UserRepoMock, registerService *RegisterServicerMock, sessionService *SessionStarterMock) {
 				userRepo.On("ExistsByLogin", ctx, "newuser").Return(false, nil)
 				user := &entity.User{Login: "newuser", Password: "hashedpassword"}
 				registerService.On("CreateUser", mock.Anything).Return(user, nil)
 				userRepo.On("Save", ctx, user).Return(nil)
-				tokenService.On("GenerateJWT", user).Return("", errors.New("token error"))
+				sessionService.On("Start", ctx, user, client).Return(nil, errors.New("token error"))
 			},
 			req: &pb.RegisterUserRequest{
 				Login:    "newuser",
 				Password: "password123",
 			},
 			expectedToken: "",
-			expectedError: errors.New("ошибка при генерации токена: token error"),
+			expectedError: errors.New("ошибка при создании сессии: token error"),
 		},
 	}
 
@@ -144,25 +151,25 @@ func TestRegister_Handle(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			userRepoMock := new(UserRepoMock)
 			registerServiceMock := new(RegisterServicerMock)
-			tokenServiceMock := new(TokenServicerMock)
+			sessionServiceMock := new(SessionStarterMock)
 
-			tt.setupMocks(userRepoMock, registerServiceMock, tokenServiceMock)
+			tt.setupMocks(userRepoMock, registerServiceMock, sessionServiceMock)
 
-			reg := NewRegister(registerServiceMock, tokenServiceMock, userRepoMock)
+			reg := NewRegister(registerServiceMock, sessionServiceMock, userRepoMock)
 
-			token, err := reg.Handle(ctx, tt.req)
+			tokens, err := reg.Handle(ctx, tt.req, client)
 
 			if tt.expectedError != nil {
 				assert.EqualError(t, err, tt.expectedError.Error())
-				assert.Empty(t, token)
+				assert.Nil(t, tokens)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedToken, token)
+				assert.Equal(t, tt.expectedToken, tokens.AccessToken)
 			}
 
 			userRepoMock.AssertExpectations(t)
 			registerServiceMock.AssertExpectations(t)
-			tokenServiceMock.AssertExpectations(t)
+			sessionServiceMock.AssertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,253 @@
+// Package totp реализует одноразовые пароли по времени (RFC 6238) и формат otpauth:// URI.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// AlgorithmSHA1 - алгоритм HMAC по умолчанию.
+	AlgorithmSHA1 = "SHA1"
+	// AlgorithmSHA256 - HMAC-SHA256.
+	AlgorithmSHA256 = "SHA256"
+	// AlgorithmSHA512 - HMAC-SHA512.
+	AlgorithmSHA512 = "SHA512"
+
+	// DefaultDigits - число цифр кода по умолчанию.
+	DefaultDigits = 6
+	// DefaultPeriod - шаг времени по умолчанию в секундах.
+	DefaultPeriod = 30
+	// SecretSize - длина генерируемого секрета в байтах (160 бит, как рекомендует RFC 4226).
+	SecretSize = 20
+
+	uriScheme = "otpauth"
+	uriType   = "totp"
+)
+
+var (
+	// ErrInvalidSecret - секрет не является корректной строкой base32.
+	ErrInvalidSecret = errors.New("некорректный секрет TOTP")
+	// ErrInvalidURI - строка не является otpauth://totp URI.
+	ErrInvalidURI = errors.New("некорректный otpauth URI")
+)
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Params - параметры генерации кодов.
+type Params struct {
+	// Secret - секрет в base32 без выравнивания.
+	Secret    string
+	Algorithm string
+	Issuer    string
+	Account   string
+	Digits    int
+	Period    int
+}
+
+// NewParams возвращает параметры по умолчанию для секрета.
+func NewParams(secret string) Params {
+	return Params{Secret: secret, Algorithm: AlgorithmSHA1, Digits: DefaultDigits, Period: DefaultPeriod}
+}
+
+// GenerateSecret генерирует случайный секрет в base32.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, SecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("ошибка генерации секрета: %w", err)
+	}
+
+	return secretEncoding.EncodeToString(secret), nil
+}
+
+// Validate проверяет, что параметры поддерживаются.
+func (p Params) Validate() error {
+	if _, err := p.key(); err != nil {
+		return err
+	}
+	if _, err := newHash(p.Algorithm); err != nil {
+		return err
+	}
+	if p.Digits < 6 || p.Digits > 8 {
+		return fmt.Errorf("число цифр должно быть от 6 до 8, получено %d", p.Digits)
+	}
+	if p.Period <= 0 {
+		return fmt.Errorf("период должен быть положительным, получено %d", p.Period)
+	}
+
+	return nil
+}
+
+// Counter возвращает номер шага времени для момента t.
+func (p Params) Counter(t time.Time) uint64 {
+	return uint64(t.Unix()) / uint64(p.Period)
+}
+
+// Remaining возвращает, сколько ещё действует код, выданный в момент t.
+func (p Params) Remaining(t time.Time) time.Duration {
+	period := int64(p.Period)
+	return time.Duration(period-t.Unix()%period) * time.Second
+}
+
+// Code возвращает код для момента t.
+func (p Params) Code(t time.Time) (string, error) {
+	return p.CodeAt(p.Counter(t))
+}
+
+// CodeAt возвращает код для шага времени counter (HOTP, RFC 4226).
+func (p Params) CodeAt(counter uint64) (string, error) {
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+
+	key, _ := p.key()
+	newFunc, _ := newHash(p.Algorithm)
+	mac := hmac.New(newFunc, key)
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < p.Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", p.Digits, value%mod), nil
+}
+
+// Match ищет code среди шагов времени t±skew и возвращает номер совпавшего шага.
+// Номер нужен вызывающему, чтобы не принять один и тот же код дважды.
+func (p Params) Match(code string, t time.Time, skew int) (uint64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != p.Digits {
+		return 0, false
+	}
+
+	current := p.Counter(t)
+	for delta := -skew; delta <= skew; delta++ {
+		if delta < 0 && current < uint64(-delta) {
+			continue
+		}
+		counter := uint64(int64(current) + int64(delta))
+		expected, err := p.CodeAt(counter)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter, true
+		}
+	}
+
+	return 0, false
+}
+
+// URI возвращает otpauth://totp URI для добавления секрета в приложение-аутентификатор.
+func (p Params) URI() string {
+	label := p.Account
+	if p.Issuer != "" {
+		label = p.Issuer + ":" + p.Account
+	}
+
+	query := url.Values{}
+	query.Set("secret", p.Secret)
+	if p.Issuer != "" {
+		query.Set("issuer", p.Issuer)
+	}
+	query.Set("algorithm", p.Algorithm)
+	query.Set("digits", strconv.Itoa(p.Digits))
+	query.Set("period", strconv.Itoa(p.Period))
+
+	u := url.URL{Scheme: uriScheme, Host: uriType, Path: "/" + label, RawQuery: query.Encode()}
+
+	return u.String()
+}
+
+// ParseURI разбирает otpauth://totp URI. Отсутствующие параметры принимают значения по умолчанию.
+func ParseURI(raw string) (Params, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return Params{}, fmt.Errorf("%w: %v", ErrInvalidURI, err)
+	}
+	if u.Scheme != uriScheme || !strings.EqualFold(u.Host, uriType) {
+		return Params{}, fmt.Errorf("%w: ожидается otpauth://totp/...", ErrInvalidURI)
+	}
+
+	query := u.Query()
+	params := NewParams(NormalizeSecret(query.Get("secret")))
+
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, ok := strings.Cut(label, ":"); ok {
+		params.Issuer = strings.TrimSpace(issuer)
+		params.Account = strings.TrimSpace(account)
+	} else {
+		params.Account = label
+	}
+	if issuer := query.Get("issuer"); issuer != "" {
+		params.Issuer = issuer
+	}
+	if algorithm := query.Get("algorithm"); algorithm != "" {
+		params.Algorithm = strings.ToUpper(algorithm)
+	}
+	if digits := query.Get("digits"); digits != "" {
+		if params.Digits, err = strconv.Atoi(digits); err != nil {
+			return Params{}, fmt.Errorf("%w: digits=%q", ErrInvalidURI, digits)
+		}
+	}
+	if period := query.Get("period"); period != "" {
+		if params.Period, err = strconv.Atoi(period); err != nil {
+			return Params{}, fmt.Errorf("%w: period=%q", ErrInvalidURI, period)
+		}
+	}
+
+	if err := params.Validate(); err != nil {
+		return Params{}, fmt.Errorf("%w: %v", ErrInvalidURI, err)
+	}
+
+	return params, nil
+}
+
+// NormalizeSecret приводит секрет, введённый пользователем, к base32 без пробелов и выравнивания.
+func NormalizeSecret(secret string) string {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	return strings.TrimRight(secret, "=")
+}
+
+func (p Params) key() ([]byte, error) {
+	key, err := secretEncoding.DecodeString(NormalizeSecret(p.Secret))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+
+	return key, nil
+}
+
+func newHash(algorithm string) (func() hash.Hash, error) {
+	switch algorithm {
+	case AlgorithmSHA1, "":
+		return sha1.New, nil
+	case AlgorithmSHA256:
+		return sha256.New, nil
+	case AlgorithmSHA512:
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("неподдерживаемый алгоритм %q", algorithm)
+	}
+}
@@ -0,0 +1,123 @@
+package totp
+
+import (
+	"encoding/base32"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rfcSecret(key string) string {
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(key))
+}
+
+func TestParams_Code_RFC6238(t *testing.T) {
+	sha1Key := rfcSecret("12345678901234567890")
+	sha256Key := rfcSecret("12345678901234567890123456789012")
+	sha512Key := rfcSecret("1234567890123456789012345678901234567890123456789012345678901234")
+
+	tests := []struct {
+		algorithm string
+		secret    string
+		unix      int64
+		expected  string
+	}{
+		{AlgorithmSHA1, sha1Key, 59, "94287082"},
+		{AlgorithmSHA256, sha256Key, 59, "46119246"},
+		{AlgorithmSHA512, sha512Key, 59, "90693936"},
+		{AlgorithmSHA1, sha1Key, 1111111109, "07081804"},
+		{AlgorithmSHA256, sha256Key, 1234567890, "91819424"},
+		{AlgorithmSHA512, sha512Key, 20000000000, "47863826"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm+"/"+tt.expected, func(t *testing.T) {
+			params := Params{Secret: tt.secret, Algorithm: tt.algorithm, Digits: 8, Period: 30}
+
+			code, err := params.Code(time.Unix(tt.unix, 0))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+}
+
+func TestParams_Match(t *testing.T) {
+	params := NewParams(rfcSecret("12345678901234567890"))
+	now := time.Unix(1111111109, 0)
+	previous, err := params.Code(now.Add(-30 * time.Second))
+	assert.NoError(t, err)
+
+	counter, ok := params.Match(previous, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, params.Counter(now)-1, counter)
+
+	_, ok = params.Match(previous, now, 0)
+	assert.False(t, ok)
+
+	_, ok = params.Match("12345", now, 1)
+	assert.False(t, ok)
+}
+
+func TestParams_Remaining(t *testing.T) {
+	params := NewParams("JBSWY3DPEHPK3PXP")
+
+	assert.Equal(t, 30*time.Second, params.Remaining(time.Unix(60, 0)))
+	assert.Equal(t, time.Second, params.Remaining(time.Unix(89, 0)))
+}
+
+func TestURI_RoundTrip(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+
+	params := NewParams(secret)
+	params.Issuer = "GophKeeper"
+	params.Account = "user@example.com"
+
+	parsed, err := ParseURI(params.URI())
+
+	assert.NoError(t, err)
+	assert.Equal(t, params, parsed)
+}
+
+func TestParseURI(t *testing.T) {
+	t.Run("Параметры по умолчанию", func(t *testing.T) {
+		params, err := ParseURI("otpauth://totp/Example:alice@google.com?secret=jbsw y3dpehpk3pxp")
+		assert.NoError(t, err)
+		assert.Equal(t, Params{
+			Secret:    "JBSWY3DPEHPK3PXP",
+			Algorithm: AlgorithmSHA1,
+			Issuer:    "Example",
+			Account:   "alice@google.com",
+			Digits:    DefaultDigits,
+			Period:    DefaultPeriod,
+		}, params)
+	})
+
+	t.Run("Явные параметры", func(t *testing.T) {
+		params, err := ParseURI(
+			"otpauth://totp/ACME%20Co:john?secret=JBSWY3DPEHPK3PXP&issuer=ACME%20Co&algorithm=sha256&digits=8&period=60",
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, "ACME Co", params.Issuer)
+		assert.Equal(t, "john", params.Account)
+		assert.Equal(t, AlgorithmSHA256, params.Algorithm)
+		assert.Equal(t, 8, params.Digits)
+		assert.Equal(t, 60, params.Period)
+	})
+
+	for name, uri := range map[string]string{
+		"HOTP":              "otpauth://hotp/x?secret=JBSWY3DPEHPK3PXP&counter=1",
+		"Другая схема":      "https://totp/x?secret=JBSWY3DPEHPK3PXP",
+		"Без секрета":       "otpauth://totp/x",
+		"Неверный алгоритм": "otpauth://totp/x?secret=JBSWY3DPEHPK3PXP&algorithm=MD5",
+		"Неверные цифры":    "otpauth://totp/x?secret=JBSWY3DPEHPK3PXP&digits=4",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseURI(uri)
+			assert.True(t, errors.Is(err, ErrInvalidURI))
+		})
+	}
+}